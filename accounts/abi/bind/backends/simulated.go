@@ -76,7 +76,14 @@ type SimulatedBackend struct {
 // and uses a simulated blockchain for testing purposes.
 // A simulated backend always uses chainID 1337.
 func NewSimulatedBackendWithDatabase(database ethdb.Database, alloc core.GenesisAlloc, gasLimit uint64) *SimulatedBackend {
-	genesis := core.Genesis{Config: params.AllEthashProtocolChanges, GasLimit: gasLimit, Alloc: alloc}
+	return NewSimulatedBackendWithConfig(database, alloc, gasLimit, params.AllEthashProtocolChanges)
+}
+
+// NewSimulatedBackendWithConfig creates a new binding backend based on the given database
+// and chain config, e.g. a config declaring the zion main chain role to simulate the native
+// contracts which only exist on the main chain.
+func NewSimulatedBackendWithConfig(database ethdb.Database, alloc core.GenesisAlloc, gasLimit uint64, config *params.ChainConfig) *SimulatedBackend {
+	genesis := core.Genesis{Config: config, GasLimit: gasLimit, Alloc: alloc}
 	genesis.MustCommit(database)
 	blockchain, _ := core.NewBlockChain(database, nil, genesis.Config, ethash.NewFaker(), vm.Config{}, nil, nil)
 
@@ -736,6 +743,12 @@ func (fb *filterBackend) HeaderByNumber(ctx context.Context, block rpc.BlockNumb
 	if block == rpc.LatestBlockNumber {
 		return fb.bc.CurrentHeader(), nil
 	}
+	if block == rpc.FinalizedBlockNumber {
+		if finalized := fb.bc.CurrentFinalizedBlock(); finalized != nil {
+			return finalized.Header(), nil
+		}
+		return nil, errors.New("finalized block not available")
+	}
 	return fb.bc.GetHeaderByNumber(uint64(block.Int64())), nil
 }
 
@@ -775,6 +788,10 @@ func (fb *filterBackend) SubscribeChainEvent(ch chan<- core.ChainEvent) event.Su
 	return fb.bc.SubscribeChainEvent(ch)
 }
 
+func (fb *filterBackend) SubscribeFinalizedHeadEvent(ch chan<- core.FinalizedHeadEvent) event.Subscription {
+	return fb.bc.SubscribeFinalizedHeadEvent(ch)
+}
+
 func (fb *filterBackend) SubscribeRemovedLogsEvent(ch chan<- core.RemovedLogsEvent) event.Subscription {
 	return fb.bc.SubscribeRemovedLogsEvent(ch)
 }
@@ -1050,7 +1050,10 @@ func GenDoc(ctx *cli.Context) {
 			"\n\n" +
 			"The `transaction` (on input into clef) can have either `data` or `input` -- if both are set, " +
 			"they must be identical, otherwise an error is generated. " +
-			"However, Clef will always use `data` when passing this struct on (if Clef does otherwise, please file a ticket)"
+			"However, Clef will always use `data` when passing this struct on (if Clef does otherwise, please file a ticket)" +
+			"\n\n" +
+			"If the transaction calls a Zion native contract, the decoded call is set in `native_call`, with the " +
+			"method name and the arguments, where the rlp or json encoded payloads are decoded as well."
 
 		data := hexutil.Bytes([]byte{0x01, 0x02, 0x03, 0x04})
 		add("SignTxRequest", desc, &core.SignTxRequest{
@@ -0,0 +1,139 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+// hotstuffsim runs HotStuff consensus scenarios on an in-process simulated network.
+//
+// A scenario describes the validators, the network conditions and the faults
+// injected at certain heights, e.g.
+//
+//     $ hotstuffsim cmd/hotstuffsim/scenarios/proposer-crash.json
+//     SCENARIO        RESULT  ELAPSED  HEIGHTS        SENT  DROPPED
+//     proposer-crash  OK      2.885s   [12 12 12 12]  234   0
+//
+// The command exits with non-zero status if any scenario violates the safety or
+// liveness properties, so that it can be used to reproduce regressions of view
+// change and epoch change.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/hotstuff/simulation"
+	"github.com/ethereum/go-ethereum/internal/flags"
+	"github.com/ethereum/go-ethereum/log"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var (
+	// Git SHA1 commit hash of the release (set via linker flags)
+	gitCommit = ""
+	gitDate   = ""
+)
+
+var (
+	verbosityFlag = cli.IntFlag{
+		Name:  "verbosity",
+		Usage: "Logging verbosity: 0=silent, 1=error, 2=warn, 3=info, 4=debug, 5=detail",
+		Value: int(log.LvlCrit),
+	}
+	seedFlag = cli.Int64Flag{
+		Name:  "seed",
+		Usage: "Seed of node keys and fault injection, overrides the seed of scenarios",
+	}
+	statsFlag = cli.BoolFlag{
+		Name:  "stats",
+		Usage: "Print the consensus statistics of each node after the scenario finished",
+	}
+)
+
+var app = flags.NewApp(gitCommit, gitDate, "HotStuff consensus simulator")
+
+func init() {
+	app.ArgsUsage = "<scenario.json> [<scenario.json> ...]"
+	app.Flags = []cli.Flag{
+		verbosityFlag,
+		seedFlag,
+		statsFlag,
+	}
+	app.Action = runScenarios
+}
+
+func main() {
+	if err := app.Run(os.Args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func runScenarios(ctx *cli.Context) error {
+	if ctx.NArg() == 0 {
+		return fmt.Errorf("no scenario specified")
+	}
+	glogger := log.NewGlogHandler(log.StreamHandler(os.Stderr, log.TerminalFormat(false)))
+	glogger.Verbosity(log.Lvl(ctx.GlobalInt(verbosityFlag.Name)))
+	log.Root().SetHandler(glogger)
+
+	var results []*simulation.Result
+	for _, path := range ctx.Args() {
+		scenario, err := simulation.LoadScenario(path)
+		if err != nil {
+			return err
+		}
+		if ctx.GlobalIsSet(seedFlag.Name) {
+			scenario.Seed = ctx.GlobalInt64(seedFlag.Name)
+		}
+		result, err := simulation.Run(scenario)
+		if err != nil {
+			return fmt.Errorf("scenario %s: %v", scenario.Name, err)
+		}
+		results = append(results, result)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 1, 2, 2, ' ', 0)
+	fmt.Fprintf(w, "SCENARIO\tRESULT\tELAPSED\tHEIGHTS\tSENT\tDROPPED\n")
+	failed := 0
+	for _, result := range results {
+		status := "OK"
+		if result.Err() != nil {
+			status = "FAIL"
+			failed++
+		}
+		elapsed := common.PrettyDuration(result.Elapsed.Round(time.Millisecond))
+		fmt.Fprintf(w, "%s\t%s\t%v\t%v\t%d\t%d\n", result.Name, status, elapsed, result.Heights, result.Sent, result.Dropped)
+	}
+	w.Flush()
+
+	for _, result := range results {
+		if err := result.Err(); err != nil {
+			fmt.Printf("%s: %v\n", result.Name, err)
+		}
+		if ctx.GlobalBool(statsFlag.Name) {
+			blob, _ := json.MarshalIndent(result.Stats, "", "  ")
+			fmt.Printf("%s stats: %s\n", result.Name, blob)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d scenarios failed", failed, len(results))
+	}
+	return nil
+}
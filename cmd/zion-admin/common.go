@@ -0,0 +1,172 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/external"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/cmd/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/contracts/native/governance/node_manager"
+	nutils "github.com/ethereum/go-ethereum/contracts/native/utils"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+	"gopkg.in/urfave/cli.v1"
+)
+
+// newRPCClient creates a rpc client with specified node URL.
+func newRPCClient(ctx *cli.Context) *rpc.Client {
+	client, err := rpc.Dial(ctx.GlobalString(nodeURLFlag.Name))
+	if err != nil {
+		utils.Fatalf("Failed to connect to Zion node: %v", err)
+	}
+	return client
+}
+
+// newTransactor creates a transaction signer backed by the keystore file if
+// specified, or by clef otherwise.
+func newTransactor(ctx *cli.Context, client *ethclient.Client) *bind.TransactOpts {
+	if !ctx.GlobalIsSet(keyFileFlag.Name) {
+		clef, err := external.NewExternalSigner(ctx.GlobalString(clefURLFlag.Name))
+		if err != nil {
+			utils.Fatalf("Failed to create clef signer %v", err)
+		}
+		if !ctx.GlobalIsSet(signerFlag.Name) {
+			utils.Fatalf("Please specify the signer address (--signer) for clef signing")
+		}
+		return bind.NewClefTransactor(clef, accounts.Account{Address: common.HexToAddress(ctx.GlobalString(signerFlag.Name))})
+	}
+	keyjson, err := ioutil.ReadFile(ctx.GlobalString(keyFileFlag.Name))
+	if err != nil {
+		utils.Fatalf("Failed to read keyfile: %v", err)
+	}
+	var password string
+	if ctx.GlobalIsSet(passwordFileFlag.Name) {
+		content, err := ioutil.ReadFile(ctx.GlobalString(passwordFileFlag.Name))
+		if err != nil {
+			utils.Fatalf("Failed to read password file: %v", err)
+		}
+		password = strings.TrimRight(strings.Split(string(content), "\n")[0], "\r")
+	} else {
+		password = utils.GetPassPhrase("Please enter the password of the keyfile", false)
+	}
+	key, err := keystore.DecryptKey(keyjson, password)
+	if err != nil {
+		utils.Fatalf("Failed to decrypt keyfile: %v", err)
+	}
+	chainID, err := client.ChainID(context.Background())
+	if err != nil {
+		utils.Fatalf("Failed to retrieve chain id: %v", err)
+	}
+	opts, err := bind.NewKeyedTransactorWithChainID(key.PrivateKey, chainID)
+	if err != nil {
+		utils.Fatalf("Failed to create keyed transactor: %v", err)
+	}
+	return opts
+}
+
+// transact sends the governance transaction to the native contract, waits until
+// it is mined and prints the governance events emitted.
+func transact(ctx *cli.Context, contract common.Address, payload []byte) error {
+	client := ethclient.NewClient(newRPCClient(ctx))
+	opts := newTransactor(ctx, client)
+
+	bound := bind.NewBoundContract(contract, abi.ABI{}, client, client, client)
+	tx, err := bound.RawTransact(opts, payload)
+	if err != nil {
+		return fmt.Errorf("failed to send transaction: %v", err)
+	}
+	fmt.Printf("Transaction %s sent by %s, waiting to be mined...\n", tx.Hash().Hex(), opts.From.Hex())
+
+	receipt, err := bind.WaitMined(context.Background(), client, tx)
+	if err != nil {
+		return fmt.Errorf("failed to wait transaction mined: %v", err)
+	}
+	fmt.Printf("Transaction mined in block %d, status %d\n", receipt.BlockNumber.Uint64(), receipt.Status)
+	printLogs(receipt.Logs)
+	return nil
+}
+
+// callContract executes a read only native contract method on the latest state.
+func callContract(client *ethclient.Client, contract common.Address, payload []byte) ([]byte, error) {
+	msg := ethereum.CallMsg{To: &contract, Data: payload}
+	return client.CallContract(context.Background(), msg, nil)
+}
+
+// getAddressList retrieves the rlp encoded address list stored by node manager under
+// raw storage key `key`, an empty list returned if nothing stored.
+func getAddressList(client *rpc.Client, key []byte) ([]common.Address, error) {
+	var enc hexutil.Bytes
+	if err := client.Call(&enc, "eth_getStorageAtCacheDB", nutils.NodeManagerContractAddress, hex.EncodeToString(key), "latest"); err != nil {
+		return nil, err
+	}
+	if len(enc) == 0 {
+		return nil, nil
+	}
+	var list *node_manager.AddressList
+	if err := rlp.DecodeBytes(enc, &list); err != nil {
+		return nil, err
+	}
+	return list.List, nil
+}
+
+// getCurrentEpoch retrieves the current epoch from node manager.
+func getCurrentEpoch(client *ethclient.Client) (*node_manager.EpochInfo, error) {
+	payload, err := new(node_manager.MethodEpochInput).Encode()
+	if err != nil {
+		return nil, err
+	}
+	enc, err := callContract(client, nutils.NodeManagerContractAddress, payload)
+	if err != nil {
+		return nil, err
+	}
+	output := new(node_manager.MethodEpochOutput)
+	if err := output.Decode(enc); err != nil {
+		return nil, err
+	}
+	return output.Epoch, nil
+}
+
+// printProgress prints the members of epoch which have or have not signed yet.
+func printProgress(epoch *node_manager.EpochInfo, signed []common.Address) {
+	done := make(map[common.Address]struct{})
+	for _, addr := range signed {
+		done[addr] = struct{}{}
+	}
+	fmt.Printf("Signed: %d, quorum: %d, members: %d\n", len(signed), epoch.QuorumSize(), len(epoch.MemberList()))
+	for _, addr := range epoch.MemberList() {
+		if _, ok := done[addr]; ok {
+			fmt.Printf("  [x] %s\n", addr.Hex())
+		} else {
+			fmt.Printf("  [ ] %s\n", addr.Hex())
+		}
+	}
+}
@@ -0,0 +1,140 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/cmd/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/contracts/native/governance/node_manager"
+	nutils "github.com/ethereum/go-ethereum/contracts/native/utils"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var commandEpoch = cli.Command{
+	Name:  "epoch",
+	Usage: "Propose, vote and query the validator epochs",
+	Subcommands: []cli.Command{
+		{
+			Name:   "status",
+			Usage:  "Show the current epoch and the voting progress of a proposal",
+			Flags:  []cli.Flag{hashFlag},
+			Action: utils.MigrateFlags(epochStatus),
+		},
+		{
+			Name:   "propose",
+			Usage:  "Propose a new epoch with the specified members",
+			Flags:  []cli.Flag{startFlag, peersFlag},
+			Action: utils.MigrateFlags(epochPropose),
+		},
+		{
+			Name:   "vote",
+			Usage:  "Vote for an epoch proposal",
+			Flags:  []cli.Flag{epochIDFlag, hashFlag},
+			Action: utils.MigrateFlags(epochVote),
+		},
+	},
+}
+
+// epochStatus prints the current epoch, and the members which voted for the
+// proposal if the proposal hash specified.
+func epochStatus(ctx *cli.Context) error {
+	rpcClient := newRPCClient(ctx)
+	epoch, err := getCurrentEpoch(ethclient.NewClient(rpcClient))
+	if err != nil {
+		return fmt.Errorf("failed to get current epoch: %v", err)
+	}
+	fmt.Printf("Current epoch %d, hash %s, start height %d\n", epoch.ID, epoch.Hash().Hex(), epoch.StartHeight)
+	for _, peer := range epoch.Peers.List {
+		fmt.Printf("  %s %s\n", peer.Address.Hex(), peer.PubKey)
+	}
+	if !ctx.IsSet(hashFlag.Name) {
+		return nil
+	}
+	hash := common.HexToHash(ctx.String(hashFlag.Name))
+	voters, err := getAddressList(rpcClient, node_manager.VoteStorageKey(hash))
+	if err != nil {
+		return fmt.Errorf("failed to get voters: %v", err)
+	}
+	fmt.Printf("Proposal %s\n", hash.Hex())
+	printProgress(epoch, voters)
+	return nil
+}
+
+// epochPropose proposes a new epoch with the validator key.
+func epochPropose(ctx *cli.Context) error {
+	if !ctx.IsSet(startFlag.Name) || !ctx.IsSet(peersFlag.Name) {
+		utils.Fatalf("Please specify the start height (--start) and members (--peers) of the proposal")
+	}
+	peers, err := parsePeers(ctx.String(peersFlag.Name))
+	if err != nil {
+		utils.Fatalf("Invalid peers: %v", err)
+	}
+	input := &node_manager.MethodProposeInput{StartHeight: ctx.Uint64(startFlag.Name), Peers: peers}
+	payload, err := input.Encode()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Proposing epoch with %d members from height %d\n", len(peers.List), input.StartHeight)
+	return transact(ctx, nutils.NodeManagerContractAddress, payload)
+}
+
+// epochVote votes for the epoch proposal with the validator key.
+func epochVote(ctx *cli.Context) error {
+	if !ctx.IsSet(epochIDFlag.Name) || !ctx.IsSet(hashFlag.Name) {
+		utils.Fatalf("Please specify the epoch id (--epoch) and hash (--hash) of the proposal")
+	}
+	input := &node_manager.MethodVoteInput{
+		EpochID:   ctx.Uint64(epochIDFlag.Name),
+		EpochHash: common.HexToHash(ctx.String(hashFlag.Name)),
+	}
+	payload, err := input.Encode()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Voting for epoch %d, proposal %s\n", input.EpochID, input.EpochHash.Hex())
+	return transact(ctx, nutils.NodeManagerContractAddress, payload)
+}
+
+// parsePeers converts the comma separated compressed public keys into sorted epoch peers.
+func parsePeers(str string) (*node_manager.Peers, error) {
+	peers := &node_manager.Peers{List: make([]*node_manager.PeerInfo, 0)}
+	for _, item := range strings.Split(str, ",") {
+		enc, err := hexutil.Decode(strings.TrimSpace(item))
+		if err != nil {
+			return nil, fmt.Errorf("invalid public key %s: %v", item, err)
+		}
+		pubkey, err := crypto.DecompressPubkey(enc)
+		if err != nil {
+			return nil, fmt.Errorf("invalid public key %s: %v", item, err)
+		}
+		peers.List = append(peers.List, &node_manager.PeerInfo{
+			PubKey:  hexutil.Encode(enc),
+			Address: crypto.PubkeyToAddress(*pubkey),
+		})
+	}
+	sort.Sort(peers)
+	return peers, nil
+}
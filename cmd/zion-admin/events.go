@@ -0,0 +1,150 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/cmd/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/contracts/native/governance/node_manager"
+	nutils "github.com/ethereum/go-ethereum/contracts/native/utils"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rlp"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var commandEvents = cli.Command{
+	Name:   "events",
+	Usage:  "Print the decoded governance events of a transaction or a block range",
+	Flags:  []cli.Flag{txFlag, fromFlag, toFlag},
+	Action: utils.MigrateFlags(events),
+}
+
+// events prints the governance events emitted in the transaction or block range.
+func events(ctx *cli.Context) error {
+	client := ethclient.NewClient(newRPCClient(ctx))
+	if ctx.IsSet(txFlag.Name) {
+		receipt, err := client.TransactionReceipt(context.Background(), common.HexToHash(ctx.String(txFlag.Name)))
+		if err != nil {
+			return fmt.Errorf("failed to get receipt: %v", err)
+		}
+		printLogs(receipt.Logs)
+		return nil
+	}
+	if !ctx.IsSet(fromFlag.Name) {
+		utils.Fatalf("Please specify the transaction (--tx) or the first block (--from) to scan")
+	}
+	query := ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(ctx.Uint64(fromFlag.Name)),
+		Addresses: governanceContracts(),
+	}
+	if ctx.IsSet(toFlag.Name) {
+		query.ToBlock = new(big.Int).SetUint64(ctx.Uint64(toFlag.Name))
+	}
+	logs, err := client.FilterLogs(context.Background(), query)
+	if err != nil {
+		return fmt.Errorf("failed to filter logs: %v", err)
+	}
+	list := make([]*types.Log, len(logs))
+	for i := range logs {
+		list[i] = &logs[i]
+	}
+	printLogs(list)
+	return nil
+}
+
+// governanceABIs returns the abi of native contracts involved in governance flows.
+func governanceABIs() map[common.Address]*abi.ABI {
+	return map[common.Address]*abi.ABI{
+		nutils.NodeManagerContractAddress:       node_manager.ABI,
+		nutils.SideChainManagerContractAddress:  sideChainABI,
+		nutils.RelayerManagerContractAddress:    relayerABI,
+		nutils.CrossChainManagerContractAddress: crossChainABI,
+	}
+}
+
+// governanceContracts returns the native contracts involved in governance flows.
+func governanceContracts() []common.Address {
+	var list []common.Address
+	for addr := range governanceABIs() {
+		list = append(list, addr)
+	}
+	return list
+}
+
+// printLogs prints the governance events, logs emitted by other contracts are skipped.
+func printLogs(logs []*types.Log) {
+	for _, l := range logs {
+		str, err := decodeLog(l)
+		if err != nil {
+			fmt.Printf("Block %d tx %s: undecodable log: %v\n", l.BlockNumber, l.TxHash.Hex(), err)
+			continue
+		}
+		if str != "" {
+			fmt.Printf("Block %d tx %s: %s\n", l.BlockNumber, l.TxHash.Hex(), str)
+		}
+	}
+}
+
+// decodeLog decodes the native contract event into readable string, an empty
+// string returned if the log is not emitted by governance contracts.
+func decodeLog(l *types.Log) (string, error) {
+	ab, ok := governanceABIs()[l.Address]
+	if !ok || len(l.Topics) == 0 {
+		return "", nil
+	}
+	event, err := ab.EventByID(l.Topics[0])
+	if err != nil {
+		return "", err
+	}
+	// native contracts pack all event arguments into log data
+	values, err := event.Inputs.Unpack(l.Data)
+	if err != nil {
+		return "", err
+	}
+	fields := make([]string, len(values))
+	for i, v := range values {
+		fields[i] = fmt.Sprintf("%s=%s", event.Inputs[i].Name, formatValue(v))
+	}
+	return fmt.Sprintf("%s %s", event.Name, strings.Join(fields, " ")), nil
+}
+
+// formatValue formats event argument, epoch info encoded in bytes is decoded.
+func formatValue(v interface{}) string {
+	switch v := v.(type) {
+	case common.Address:
+		return v.Hex()
+	case []byte:
+		var epoch *node_manager.EpochInfo
+		if err := rlp.DecodeBytes(v, &epoch); err == nil && epoch.Peers != nil {
+			return fmt.Sprintf("{id: %d hash: %s start: %d members: %d}", epoch.ID, epoch.Hash().Hex(), epoch.StartHeight, len(epoch.Peers.List))
+		}
+		return hexutil.Encode(v)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
@@ -0,0 +1,250 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/cmd/utils"
+	"github.com/ethereum/go-ethereum/common"
+	scom "github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/common"
+	"github.com/ethereum/go-ethereum/contracts/native/go_abi/node_manager_abi"
+	"github.com/ethereum/go-ethereum/contracts/native/go_abi/relayer_manager_abi"
+	"github.com/ethereum/go-ethereum/contracts/native/go_abi/side_chain_manager_abi"
+	"github.com/ethereum/go-ethereum/contracts/native/governance/node_manager"
+	"github.com/ethereum/go-ethereum/contracts/native/governance/relayer_manager"
+	"github.com/ethereum/go-ethereum/contracts/native/governance/side_chain_manager"
+	nutils "github.com/ethereum/go-ethereum/contracts/native/utils"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rlp"
+	"gopkg.in/urfave/cli.v1"
+)
+
+// flowArgs are the arguments of a governance flow parsed from command line.
+type flowArgs struct {
+	ID       uint64         // side chain id or relayer apply id
+	Contract common.Address // native contract to be upgraded
+	Version  uint64         // native contract version to be activated
+	Height   uint64         // activation height of the native contract version
+}
+
+// flow describes a governance action which takes effect after 2/3 of the current
+// epoch members sent the same transaction, see node_manager.CheckConsensusSigns.
+type flow struct {
+	Name     string
+	Usage    string
+	Flags    []cli.Flag
+	Contract common.Address
+
+	// Pack returns the transaction payload sent by `signer`, and the consensus
+	// sign recorded by node manager for the payload.
+	Pack func(args *flowArgs, signer common.Address) ([]byte, *node_manager.ConsensusSign, error)
+}
+
+var (
+	sideChainABI  = side_chain_manager.GetABI()
+	relayerABI    = relayer_manager.GetABI()
+	crossChainABI = scom.GetABI()
+)
+
+// flows are the governance flows which can be approved and queried.
+var flows = []*flow{
+	{
+		Name:     "sidechain-register",
+		Usage:    "Approve the registration of a side chain",
+		Flags:    []cli.Flag{idFlag},
+		Contract: nutils.SideChainManagerContractAddress,
+		Pack: packWithSigner(sideChainABI, side_chain_manager_abi.MethodApproveRegisterSideChain,
+			side_chain_manager_abi.MethodApproveRegisterSideChain),
+	},
+	{
+		Name:     "sidechain-update",
+		Usage:    "Approve the update of a side chain",
+		Flags:    []cli.Flag{idFlag},
+		Contract: nutils.SideChainManagerContractAddress,
+		Pack: packWithSigner(sideChainABI, side_chain_manager_abi.MethodApproveUpdateSideChain,
+			side_chain_manager_abi.MethodApproveUpdateSideChain),
+	},
+	{
+		Name:     "sidechain-quit",
+		Usage:    "Approve a side chain to quit",
+		Flags:    []cli.Flag{idFlag},
+		Contract: nutils.SideChainManagerContractAddress,
+		// side chain manager records the quit approval under method `quitSideChain`
+		Pack: packWithSigner(sideChainABI, side_chain_manager_abi.MethodApproveQuitSideChain,
+			side_chain_manager_abi.MethodQuitSideChain),
+	},
+	{
+		Name:     "relayer-register",
+		Usage:    "Approve the registration of relayers",
+		Flags:    []cli.Flag{idFlag},
+		Contract: nutils.RelayerManagerContractAddress,
+		Pack: packWithSigner(relayerABI, relayer_manager_abi.MethodApproveRegisterRelayer,
+			relayer_manager_abi.MethodApproveRegisterRelayer),
+	},
+	{
+		Name:     "relayer-remove",
+		Usage:    "Approve the removal of relayers",
+		Flags:    []cli.Flag{idFlag},
+		Contract: nutils.RelayerManagerContractAddress,
+		Pack: packWithSigner(relayerABI, relayer_manager_abi.MethodApproveRemoveRelayer,
+			relayer_manager_abi.MethodApproveRemoveRelayer),
+	},
+	{
+		Name:     "black-chain",
+		Usage:    "Approve to forbid the cross chain transactions of a side chain",
+		Flags:    []cli.Flag{idFlag},
+		Contract: nutils.CrossChainManagerContractAddress,
+		Pack: func(args *flowArgs, signer common.Address) ([]byte, *node_manager.ConsensusSign, error) {
+			payload, err := nutils.PackMethod(crossChainABI, scom.MethodBlackChain, args.ID)
+			if err != nil {
+				return nil, nil, err
+			}
+			return payload, &node_manager.ConsensusSign{Method: scom.MethodBlackChain, Input: nutils.GetUint64Bytes(args.ID)}, nil
+		},
+	},
+	{
+		Name:     "white-chain",
+		Usage:    "Approve to recover the cross chain transactions of a side chain",
+		Flags:    []cli.Flag{idFlag},
+		Contract: nutils.CrossChainManagerContractAddress,
+		Pack: func(args *flowArgs, signer common.Address) ([]byte, *node_manager.ConsensusSign, error) {
+			payload, err := nutils.PackMethod(crossChainABI, scom.MethodWhiteChain, args.ID)
+			if err != nil {
+				return nil, nil, err
+			}
+			// cross chain manager signs over the whole payload for white chain
+			return payload, &node_manager.ConsensusSign{Method: scom.MethodWhiteChain, Input: payload}, nil
+		},
+	},
+	{
+		Name:     "native-upgrade",
+		Usage:    "Approve a native contract version activated at the specified height",
+		Flags:    []cli.Flag{contractFlag, versionFlag, heightFlag},
+		Contract: nutils.NodeManagerContractAddress,
+		Pack: func(args *flowArgs, signer common.Address) ([]byte, *node_manager.ConsensusSign, error) {
+			input := &node_manager.MethodApproveNativeUpgradeInput{Contract: args.Contract, Version: args.Version, Height: args.Height}
+			payload, err := input.Encode()
+			if err != nil {
+				return nil, nil, err
+			}
+			enc, err := rlp.EncodeToBytes(input)
+			if err != nil {
+				return nil, nil, err
+			}
+			return payload, &node_manager.ConsensusSign{Method: node_manager_abi.MethodApproveNativeUpgrade, Input: enc}, nil
+		},
+	},
+}
+
+// packWithSigner packs the approval methods whose arguments are an id and the signer,
+// and the consensus sign recorded under `sign` with the id as input.
+func packWithSigner(ab *abi.ABI, method, sign string) func(*flowArgs, common.Address) ([]byte, *node_manager.ConsensusSign, error) {
+	return func(args *flowArgs, signer common.Address) ([]byte, *node_manager.ConsensusSign, error) {
+		payload, err := nutils.PackMethod(ab, method, args.ID, signer)
+		if err != nil {
+			return nil, nil, err
+		}
+		return payload, &node_manager.ConsensusSign{Method: sign, Input: nutils.GetUint64Bytes(args.ID)}, nil
+	}
+}
+
+var commandApprove = cli.Command{
+	Name:        "approve",
+	Usage:       "Send the approval of a governance flow",
+	Subcommands: flowCommands(approve),
+}
+
+var commandStatus = cli.Command{
+	Name:        "status",
+	Usage:       "Show the signing progress of a governance flow",
+	Subcommands: flowCommands(status),
+}
+
+// flowCommands creates a sub command for each governance flow.
+func flowCommands(action func(*cli.Context, *flow) error) []cli.Command {
+	commands := make([]cli.Command, 0, len(flows))
+	for _, f := range flows {
+		f := f
+		commands = append(commands, cli.Command{
+			Name:  f.Name,
+			Usage: f.Usage,
+			Flags: f.Flags,
+			Action: func(ctx *cli.Context) error {
+				return action(ctx, f)
+			},
+		})
+	}
+	return commands
+}
+
+// parseFlowArgs retrieves the flow arguments from command line.
+func parseFlowArgs(ctx *cli.Context, f *flow) *flowArgs {
+	for _, flag := range f.Flags {
+		if !ctx.IsSet(flag.GetName()) {
+			utils.Fatalf("Please specify --%s for %s", flag.GetName(), f.Name)
+		}
+	}
+	args := &flowArgs{
+		ID:      ctx.Uint64(idFlag.Name),
+		Version: ctx.Uint64(versionFlag.Name),
+		Height:  ctx.Uint64(heightFlag.Name),
+	}
+	if ctx.IsSet(contractFlag.Name) {
+		if !common.IsHexAddress(ctx.String(contractFlag.Name)) {
+			utils.Fatalf("Invalid contract address %s", ctx.String(contractFlag.Name))
+		}
+		args.Contract = common.HexToAddress(ctx.String(contractFlag.Name))
+	}
+	return args
+}
+
+// approve sends the approval transaction of the governance flow with the validator key.
+func approve(ctx *cli.Context, f *flow) error {
+	args := parseFlowArgs(ctx, f)
+	client := ethclient.NewClient(newRPCClient(ctx))
+	payload, sign, err := f.Pack(args, newTransactor(ctx, client).From)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Approving %s, consensus sign %s\n", f.Name, sign.Hash().Hex())
+	return transact(ctx, f.Contract, payload)
+}
+
+// status prints the members of current epoch which have signed the governance flow.
+func status(ctx *cli.Context, f *flow) error {
+	args := parseFlowArgs(ctx, f)
+	_, sign, err := f.Pack(args, common.Address{})
+	if err != nil {
+		return err
+	}
+	rpcClient := newRPCClient(ctx)
+	epoch, err := getCurrentEpoch(ethclient.NewClient(rpcClient))
+	if err != nil {
+		return fmt.Errorf("failed to get current epoch: %v", err)
+	}
+	signers, err := getAddressList(rpcClient, node_manager.SignerStorageKey(sign.Hash()))
+	if err != nil {
+		return fmt.Errorf("failed to get signers: %v", err)
+	}
+	fmt.Printf("Flow %s, consensus sign %s, epoch %d\n", f.Name, sign.Hash().Hex(), epoch.ID)
+	printProgress(epoch, signers)
+	return nil
+}
@@ -0,0 +1,147 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+// zion-admin is a utility for validators to drive the Zion governance flows which
+// must be signed by 2/3 of the current epoch members, e.g.
+//
+//     $ zion-admin --rpc http://localhost:8545 epoch status
+//     $ zion-admin --keyfile ./key.json --password ./pwd approve sidechain-register --id 77
+//     $ zion-admin status sidechain-register --id 77
+//     $ zion-admin events --tx 0x6e6f...
+//
+// Transactions are signed with a keystore file, or with clef if no keyfile specified.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common/fdlimit"
+	"github.com/ethereum/go-ethereum/contracts/native/governance/node_manager"
+	"github.com/ethereum/go-ethereum/internal/flags"
+	"github.com/ethereum/go-ethereum/log"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var (
+	// Git SHA1 commit hash of the release (set via linker flags)
+	gitCommit = ""
+	gitDate   = ""
+)
+
+var app *cli.App
+
+func init() {
+	node_manager.InitABI()
+
+	app = flags.NewApp(gitCommit, gitDate, "Zion governance helper tool")
+	app.Commands = []cli.Command{
+		commandEpoch,
+		commandApprove,
+		commandStatus,
+		commandEvents,
+	}
+	app.Flags = []cli.Flag{
+		nodeURLFlag,
+		clefURLFlag,
+		signerFlag,
+		keyFileFlag,
+		passwordFileFlag,
+	}
+	cli.CommandHelpTemplate = flags.OriginCommandHelpTemplate
+}
+
+// Commonly used command line flags.
+var (
+	nodeURLFlag = cli.StringFlag{
+		Name:  "rpc",
+		Value: "http://localhost:8545",
+		Usage: "The rpc endpoint of a local or remote zion node",
+	}
+	clefURLFlag = cli.StringFlag{
+		Name:  "clef",
+		Value: "http://localhost:8550",
+		Usage: "The rpc endpoint of clef",
+	}
+	signerFlag = cli.StringFlag{
+		Name:  "signer",
+		Usage: "Signer address for clef signing",
+	}
+	keyFileFlag = cli.StringFlag{
+		Name:  "keyfile",
+		Usage: "Keystore file of the validator, clef is used if not specified",
+	}
+	passwordFileFlag = cli.StringFlag{
+		Name:  "password",
+		Usage: "File containing the password of the keystore file",
+	}
+	idFlag = cli.Uint64Flag{
+		Name:  "id",
+		Usage: "Side chain id or relayer apply id of the governance flow",
+	}
+	contractFlag = cli.StringFlag{
+		Name:  "contract",
+		Usage: "Native contract address to be upgraded",
+	}
+	versionFlag = cli.Uint64Flag{
+		Name:  "version",
+		Usage: "Native contract version to be activated",
+	}
+	heightFlag = cli.Uint64Flag{
+		Name:  "height",
+		Usage: "Block height from which the native contract version is activated",
+	}
+	startFlag = cli.Uint64Flag{
+		Name:  "start",
+		Usage: "Start height of the proposed epoch",
+	}
+	peersFlag = cli.StringFlag{
+		Name:  "peers",
+		Usage: "Comma separated compressed public keys of the proposed epoch members",
+	}
+	epochIDFlag = cli.Uint64Flag{
+		Name:  "epoch",
+		Usage: "Epoch id of the proposal",
+	}
+	hashFlag = cli.StringFlag{
+		Name:  "hash",
+		Usage: "Epoch hash of the proposal",
+	}
+	txFlag = cli.StringFlag{
+		Name:  "tx",
+		Usage: "Transaction hash whose governance events to print",
+	}
+	fromFlag = cli.Uint64Flag{
+		Name:  "from",
+		Usage: "First block to scan for governance events",
+	}
+	toFlag = cli.Uint64Flag{
+		Name:  "to",
+		Usage: "Last block to scan for governance events (latest if not specified)",
+	}
+)
+
+func main() {
+	log.Root().SetHandler(log.LvlFilterHandler(log.LvlInfo, log.StreamHandler(os.Stderr, log.TerminalFormat(true))))
+	fdlimit.Raise(2048)
+
+	if err := app.Run(os.Args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,144 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	scom "github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/common"
+	"github.com/ethereum/go-ethereum/contracts/native/go_abi/node_manager_abi"
+	"github.com/ethereum/go-ethereum/contracts/native/go_abi/relayer_manager_abi"
+	"github.com/ethereum/go-ethereum/contracts/native/go_abi/side_chain_manager_abi"
+	"github.com/ethereum/go-ethereum/contracts/native/governance/node_manager"
+	"github.com/ethereum/go-ethereum/contracts/native/governance/relayer_manager"
+	"github.com/ethereum/go-ethereum/contracts/native/governance/side_chain_manager"
+	nutils "github.com/ethereum/go-ethereum/contracts/native/utils"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+func findFlow(name string) *flow {
+	for _, f := range flows {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// TestFlowPack checks that the payload is accepted by the native contracts, and the consensus
+// sign matches the one recorded by node manager.
+func TestFlowPack(t *testing.T) {
+	var (
+		signer = common.HexToAddress("0x258af48e28e4a6846e931ddff8e1cdf8579821e5")
+		args   = &flowArgs{ID: 77, Contract: nutils.RelayerManagerContractAddress, Version: 2, Height: 1000}
+	)
+	chainidFlows := map[string]string{
+		"sidechain-register": side_chain_manager_abi.MethodApproveRegisterSideChain,
+		"sidechain-update":   side_chain_manager_abi.MethodApproveUpdateSideChain,
+		"sidechain-quit":     side_chain_manager_abi.MethodApproveQuitSideChain,
+	}
+	for name, method := range chainidFlows {
+		payload, sign, err := findFlow(name).Pack(args, signer)
+		if err != nil {
+			t.Fatalf("%s: failed to pack: %v", name, err)
+		}
+		param := new(side_chain_manager.ChainidParam)
+		if err := nutils.UnpackMethod(sideChainABI, method, param, payload); err != nil {
+			t.Fatalf("%s: failed to unpack: %v", name, err)
+		}
+		if param.Chainid != args.ID || param.Address != signer {
+			t.Errorf("%s: param mismatch, got %+v", name, param)
+		}
+		if want := nutils.GetUint64Bytes(args.ID); string(sign.Input) != string(want) {
+			t.Errorf("%s: sign input mismatch", name)
+		}
+	}
+	if _, sign, _ := findFlow("sidechain-quit").Pack(args, signer); sign.Method != side_chain_manager_abi.MethodQuitSideChain {
+		t.Errorf("quit side chain sign method mismatch, got %s", sign.Method)
+	}
+
+	for name, method := range map[string]string{
+		"relayer-register": relayer_manager_abi.MethodApproveRegisterRelayer,
+		"relayer-remove":   relayer_manager_abi.MethodApproveRemoveRelayer,
+	} {
+		payload, sign, err := findFlow(name).Pack(args, signer)
+		if err != nil {
+			t.Fatalf("%s: failed to pack: %v", name, err)
+		}
+		param := new(relayer_manager.ApproveRelayerParam)
+		if err := nutils.UnpackMethod(relayerABI, method, param, payload); err != nil {
+			t.Fatalf("%s: failed to unpack: %v", name, err)
+		}
+		if param.ID != args.ID || param.Address != signer || sign.Method != method {
+			t.Errorf("%s: param mismatch, got %+v", name, param)
+		}
+	}
+
+	payload, sign, err := findFlow("white-chain").Pack(args, signer)
+	if err != nil {
+		t.Fatalf("white-chain: failed to pack: %v", err)
+	}
+	param := new(scom.BlackChainParam)
+	if err := nutils.UnpackMethod(crossChainABI, scom.MethodWhiteChain, param, payload); err != nil || param.ChainID != args.ID {
+		t.Fatalf("white-chain: failed to unpack: %v", err)
+	}
+	if string(sign.Input) != string(payload) {
+		t.Errorf("white-chain: sign input should be the payload")
+	}
+
+	payload, sign, err = findFlow("native-upgrade").Pack(args, signer)
+	if err != nil {
+		t.Fatalf("native-upgrade: failed to pack: %v", err)
+	}
+	input := new(node_manager.MethodApproveNativeUpgradeInput)
+	if err := input.Decode(payload); err != nil {
+		t.Fatalf("native-upgrade: failed to decode: %v", err)
+	}
+	enc, _ := rlp.EncodeToBytes(input)
+	want := &node_manager.ConsensusSign{Method: node_manager_abi.MethodApproveNativeUpgrade, Input: enc}
+	if sign.Hash() != want.Hash() {
+		t.Errorf("native-upgrade: sign hash mismatch, want %s got %s", want.Hash().Hex(), sign.Hash().Hex())
+	}
+}
+
+func TestDecodeLog(t *testing.T) {
+	signer := common.HexToAddress("0x258af48e28e4a6846e931ddff8e1cdf8579821e5")
+	event := node_manager.ABI.Events[node_manager_abi.EventConsensusSigned]
+	data, err := nutils.PackEvents(node_manager.ABI, event.Name, "approveRegisterSideChain", []byte{0x4d}, signer, uint64(3))
+	if err != nil {
+		t.Fatalf("failed to pack event: %v", err)
+	}
+	log := &types.Log{Address: nutils.NodeManagerContractAddress, Topics: []common.Hash{event.ID}, Data: data}
+	got, err := decodeLog(log)
+	if err != nil {
+		t.Fatalf("failed to decode log: %v", err)
+	}
+	want := "ConsensusSigned method=approveRegisterSideChain input=0x4d signer=" + signer.Hex() + " size=3"
+	if got != want {
+		t.Errorf("decoded log mismatch, want %s got %s", want, got)
+	}
+
+	// logs of other contracts are skipped
+	log.Address = nutils.LockProxyContractAddress
+	if got, _ := decodeLog(log); got != "" {
+		t.Errorf("unexpected decoded log %s", got)
+	}
+}
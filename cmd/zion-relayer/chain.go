@@ -0,0 +1,380 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	scom "github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/common"
+	"github.com/ethereum/go-ethereum/contracts/native/go_abi/main_chain_lock_proxy_abi"
+	"github.com/ethereum/go-ethereum/contracts/native/utils"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/ethclient/zionclient"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// eccmABI is the interface of the cross chain manager deployed on the side chain, which
+// verifies the main chain headers and executes the cross chain transactions.
+const eccmABI = `[
+	{"type":"function","name":"changeEpoch","stateMutability":"nonpayable","inputs":[{"name":"rawHeader","type":"bytes"},{"name":"rawSeals","type":"bytes"}],"outputs":[{"name":"","type":"bool"}]},
+	{"type":"function","name":"verifyHeaderAndExecuteTx","stateMutability":"nonpayable","inputs":[{"name":"rawHeader","type":"bytes"},{"name":"rawSeals","type":"bytes"},{"name":"accountProof","type":"bytes"},{"name":"storageProof","type":"bytes"},{"name":"rawCrossTx","type":"bytes"}],"outputs":[{"name":"","type":"bool"}]}
+]`
+
+var (
+	eccm          = mustParseABI(eccmABI)
+	lockProxyABI  = mustParseABI(main_chain_lock_proxy_abi.IMainChainLockProxyABI)
+	crossChainEvt = lockProxyABI.Events[main_chain_lock_proxy_abi.EventCrossChainEvent]
+)
+
+func mustParseABI(str string) *abi.ABI {
+	ab, err := abi.JSON(strings.NewReader(str))
+	if err != nil {
+		panic(fmt.Sprintf("failed to load abi json string: [%v]", err))
+	}
+	return &ab
+}
+
+// zionChain is the connection to a Zion chain shared by the main chain and side chains.
+type zionChain struct {
+	id     uint64
+	rpc    *rpc.Client
+	client *ethclient.Client
+	zion   *zionclient.Client
+	opts   *bind.TransactOpts
+}
+
+func newZionChain(id uint64, client *rpc.Client, opts *bind.TransactOpts) *zionChain {
+	ec := ethclient.NewClient(client)
+	return &zionChain{
+		id:     id,
+		rpc:    client,
+		client: ec,
+		zion:   zionclient.NewClient(ec),
+		opts:   opts,
+	}
+}
+
+// ID implements source and target, returning the cross chain id of the chain.
+func (c *zionChain) ID() uint64 {
+	return c.id
+}
+
+// LatestHeight implements source, returning the height of the finalized block.
+func (c *zionChain) LatestHeight(ctx context.Context) (uint64, error) {
+	header, err := c.client.HeaderByNumber(ctx, big.NewInt(int64(rpc.FinalizedBlockNumber)))
+	if err != nil {
+		return 0, err
+	}
+	return header.Number.Uint64(), nil
+}
+
+// EpochHeaders implements source, the epochs are walked back from the one effective at
+// the end of the range, and the epoch change header is the one before the epoch start.
+func (c *zionChain) EpochHeaders(ctx context.Context, from, to uint64) ([]*types.Header, error) {
+	opts := &bind.CallOpts{Context: ctx, BlockNumber: new(big.Int).SetUint64(to)}
+	epoch, err := c.zion.Epoch(opts)
+	if err != nil {
+		return nil, err
+	}
+	var headers []*types.Header
+	for epoch.StartHeight > 1 && epoch.StartHeight-1 >= from {
+		header, err := c.client.HeaderByNumber(ctx, new(big.Int).SetUint64(epoch.StartHeight-1))
+		if err != nil {
+			return nil, err
+		}
+		headers = append(headers, header)
+		if epoch, err = c.zion.EpochByID(opts, epoch.ID-1); err != nil {
+			return nil, err
+		}
+	}
+	sort.Slice(headers, func(i, j int) bool { return headers[i].Number.Cmp(headers[j].Number) < 0 })
+	return headers, nil
+}
+
+// waitMined waits the transaction to be mined and checks its status.
+func (c *zionChain) waitMined(ctx context.Context, tx *types.Transaction, err error) error {
+	if err != nil {
+		return err
+	}
+	receipt, err := bind.WaitMined(ctx, c.client, tx)
+	if err != nil {
+		return err
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return fmt.Errorf("transaction %s failed", tx.Hash().Hex())
+	}
+	return nil
+}
+
+func (c *zionChain) transactOpts(ctx context.Context) *bind.TransactOpts {
+	opts := *c.opts
+	opts.Context = ctx
+	return &opts
+}
+
+// mainChain is the Zion main chain, where the cross chain manager records the transfers
+// to side chains, and verifies the transfers from side chains.
+type mainChain struct {
+	*zionChain
+}
+
+// CrossChainTxs implements source, returning the requests recorded by `makeProof` logs.
+func (c *mainChain) CrossChainTxs(ctx context.Context, from, to, dst uint64) ([]*crossTx, error) {
+	it, err := c.zion.FilterMakeProof(&bind.FilterOpts{Start: from, End: &to, Context: ctx}, utils.CrossChainManagerContractAddress)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	var txs []*crossTx
+	for it.Next() {
+		value := it.Event.MerkleValue
+		if value.MakeTxParam == nil || value.MakeTxParam.ToChainID != dst {
+			continue
+		}
+		raw, err := rlp.EncodeToBytes(value)
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, &crossTx{
+			Height:  it.Event.Raw.BlockNumber,
+			Index:   uint64(it.Event.Raw.Index),
+			TxHash:  it.Event.Raw.TxHash,
+			ToChain: dst,
+			Raw:     raw,
+		})
+	}
+	return txs, it.Error()
+}
+
+// Proof implements source, retrieving the proof bundle by `zion_getCrossChainProof`.
+func (c *mainChain) Proof(ctx context.Context, tx *crossTx) (*crossProof, error) {
+	bundle := new(eth.CrossChainProof)
+	if err := c.rpc.CallContext(ctx, bundle, "zion_getCrossChainProof", tx.TxHash); err != nil {
+		return nil, err
+	}
+	return &crossProof{Header: bundle.Header, Account: bundle.Proof, Extra: bundle.Extra}, nil
+}
+
+// SyncHeaders implements target, syncing the side chain epoch headers into header sync.
+func (c *mainChain) SyncHeaders(ctx context.Context, src uint64, headers []*types.Header) error {
+	list := make([][]byte, len(headers))
+	for i, header := range headers {
+		enc, err := json.Marshal(header)
+		if err != nil {
+			return err
+		}
+		list[i] = enc
+	}
+	tx, err := c.zion.SyncBlockHeader(c.transactOpts(ctx), src, list)
+	return c.waitMined(ctx, tx, err)
+}
+
+// Deliver implements target, importing the side chain transfer into the cross chain manager.
+func (c *mainChain) Deliver(ctx context.Context, src uint64, tx *crossTx, proof *crossProof) error {
+	header, err := json.Marshal(proof.Header)
+	if err != nil {
+		return err
+	}
+	account, err := json.Marshal(proof.Account)
+	if err != nil {
+		return err
+	}
+	opts := c.transactOpts(ctx)
+	param := &scom.EntranceParam{
+		SourceChainID:         src,
+		Height:                uint32(proof.Header.Number.Uint64()),
+		Proof:                 account,
+		RelayerAddress:        opts.From[:],
+		Extra:                 proof.Extra,
+		HeaderOrCrossChainMsg: header,
+	}
+	sent, err := c.zion.ImportOuterTransfer(opts, param)
+	return c.waitMined(ctx, sent, err)
+}
+
+// crossChainEvent is the non indexed fields of `CrossChainEvent`.
+type crossChainEvent struct {
+	TxId                 []byte
+	ProxyOrAssetContract common.Address
+	ToChainId            uint64
+	ToContract           []byte
+	Rawdata              []byte
+}
+
+// sideChain is a Zion side chain, where the cross chain manager contract emits the
+// `CrossChainEvent` of the transfers to the main chain, and stores the request hash
+// in the data contract.
+type sideChain struct {
+	*zionChain
+	eccm common.Address // Cross chain manager emitting events and verifying main chain
+	eccd common.Address // Cross chain data contract storing the request hashes
+}
+
+// CrossChainTxs implements source, returning the requests of `CrossChainEvent` logs.
+func (c *sideChain) CrossChainTxs(ctx context.Context, from, to, dst uint64) ([]*crossTx, error) {
+	logs, err := c.client.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(from),
+		ToBlock:   new(big.Int).SetUint64(to),
+		Addresses: []common.Address{c.eccm},
+		Topics:    [][]common.Hash{{crossChainEvt.ID}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return crossChainTxs(logs, dst)
+}
+
+// crossChainTxs returns the requests of the `CrossChainEvent` logs to the main chain `dst`. The
+// transfers of the lock proxy to other side chains are delivered to the main chain as well, which
+// routes them into mints on the target side chains.
+func crossChainTxs(logs []types.Log, dst uint64) ([]*crossTx, error) {
+	var txs []*crossTx
+	for _, log := range logs {
+		event := new(crossChainEvent)
+		if err := lockProxyABI.UnpackIntoInterface(event, crossChainEvt.Name, log.Data); err != nil {
+			return nil, fmt.Errorf("failed to unpack CrossChainEvent of %s: %v", log.TxHash.Hex(), err)
+		}
+		if event.ToChainId != dst && event.ProxyOrAssetContract != utils.LockProxyContractAddress {
+			continue
+		}
+		txs = append(txs, &crossTx{
+			Height:  log.BlockNumber,
+			Index:   uint64(log.Index),
+			TxHash:  log.TxHash,
+			ToChain: dst,
+			Raw:     event.Rawdata,
+		})
+	}
+	return txs, nil
+}
+
+// Proof implements source, the request hash is stored in the mapping at the slot 1 of
+// the data contract, indexed by the parameter transaction hash.
+func (c *sideChain) Proof(ctx context.Context, tx *crossTx) (*crossProof, error) {
+	param, err := scom.DecodeTxParam(tx.Raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode cross chain request: %v", err)
+	}
+	header, err := c.client.HeaderByNumber(ctx, new(big.Int).SetUint64(tx.Height))
+	if err != nil {
+		return nil, err
+	}
+	account := new(ethapi.AccountResult)
+	slot := mappingSlot(param.TxHash, 1)
+	if err := c.rpc.CallContext(ctx, account, "eth_getProof", c.eccd, []string{slot.Hex()}, hexutil.EncodeUint64(tx.Height)); err != nil {
+		return nil, err
+	}
+	return &crossProof{Header: header, Account: account, Extra: tx.Raw}, nil
+}
+
+// mappingSlot returns the storage slot of the mapping value of the key, where the mapping
+// is declared at the given slot.
+func mappingSlot(key []byte, slot uint64) common.Hash {
+	return crypto.Keccak256Hash(common.LeftPadBytes(key, 32), common.LeftPadBytes(new(big.Int).SetUint64(slot).Bytes(), 32))
+}
+
+// SyncHeaders implements target, changing the epoch of the cross chain manager one by one.
+func (c *sideChain) SyncHeaders(ctx context.Context, src uint64, headers []*types.Header) error {
+	for _, header := range headers {
+		rawHeader, rawSeals, err := encodeHeader(header)
+		if err != nil {
+			return err
+		}
+		if err := c.transact(ctx, "changeEpoch", rawHeader, rawSeals); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Deliver implements target, executing the main chain transfer in the cross chain manager.
+func (c *sideChain) Deliver(ctx context.Context, src uint64, tx *crossTx, proof *crossProof) error {
+	rawHeader, rawSeals, err := encodeHeader(proof.Header)
+	if err != nil {
+		return err
+	}
+	if len(proof.Account.StorageProof) != 1 {
+		return fmt.Errorf("invalid storage proof of %s", tx.TxHash.Hex())
+	}
+	accountProof, err := encodeProof(proof.Account.AccountProof)
+	if err != nil {
+		return err
+	}
+	storageProof, err := encodeProof(proof.Account.StorageProof[0].Proof)
+	if err != nil {
+		return err
+	}
+	return c.transact(ctx, "verifyHeaderAndExecuteTx", rawHeader, rawSeals, accountProof, storageProof, proof.Extra)
+}
+
+func (c *sideChain) transact(ctx context.Context, method string, args ...interface{}) error {
+	payload, err := eccm.Pack(method, args...)
+	if err != nil {
+		return err
+	}
+	bound := bind.NewBoundContract(c.eccm, abi.ABI{}, c.client, c.client, c.client)
+	tx, err := bound.RawTransact(c.transactOpts(ctx), payload)
+	return c.waitMined(ctx, tx, err)
+}
+
+// encodeHeader encodes the header in rlp, with the committed seals split out.
+func encodeHeader(header *types.Header) ([]byte, []byte, error) {
+	extra, err := types.ExtractHotstuffExtra(header)
+	if err != nil {
+		return nil, nil, err
+	}
+	rawHeader, err := rlp.EncodeToBytes(header)
+	if err != nil {
+		return nil, nil, err
+	}
+	rawSeals, err := rlp.EncodeToBytes(extra.CommittedSeal)
+	if err != nil {
+		return nil, nil, err
+	}
+	return rawHeader, rawSeals, nil
+}
+
+// encodeProof encodes the hex merkle proof nodes into a rlp list.
+func encodeProof(nodes []string) ([]byte, error) {
+	list := make([][]byte, len(nodes))
+	for i, node := range nodes {
+		blob, err := hexutil.Decode(node)
+		if err != nil {
+			return nil, err
+		}
+		list[i] = blob
+	}
+	return rlp.EncodeToBytes(list)
+}
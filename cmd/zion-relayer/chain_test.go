@@ -0,0 +1,567 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	snr "github.com/ethereum/go-ethereum/consensus/hotstuff/signer"
+	scom "github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/common"
+	"github.com/ethereum/go-ethereum/contracts/native/go_abi/side_chain_lock_proxy_abi"
+	"github.com/ethereum/go-ethereum/contracts/native/governance/side_chain_manager"
+	"github.com/ethereum/go-ethereum/contracts/native/helper"
+	"github.com/ethereum/go-ethereum/contracts/native/utils"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/eth/downloader"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/light"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// makeCrossChainLog packs a `CrossChainEvent` log emitted by the side chain cross chain manager.
+func makeCrossChainLog(t *testing.T, index uint, proxy common.Address, toChainID uint64, raw []byte) types.Log {
+	data, err := crossChainEvt.Inputs.NonIndexed().Pack([]byte{byte(index)}, proxy, toChainID, utils.LockProxyContractAddress.Bytes(), raw)
+	if err != nil {
+		t.Fatalf("failed to pack CrossChainEvent: %v", err)
+	}
+	return types.Log{
+		BlockNumber: 10,
+		Index:       index,
+		TxHash:      common.BytesToHash([]byte{byte(index)}),
+		Data:        data,
+	}
+}
+
+func TestSideChainCrossChainTxs(t *testing.T) {
+	const (
+		mainID  = uint64(1)
+		otherID = uint64(78)
+	)
+	other := common.HexToAddress("0xabcd")
+	logs := []types.Log{
+		makeCrossChainLog(t, 0, utils.LockProxyContractAddress, mainID, []byte("unlock")),
+		makeCrossChainLog(t, 1, utils.LockProxyContractAddress, otherID, []byte("mint")),
+		makeCrossChainLog(t, 2, other, mainID, []byte("main")),
+		makeCrossChainLog(t, 3, other, otherID, []byte("other")),
+	}
+	txs, err := crossChainTxs(logs, mainID)
+	if err != nil {
+		t.Fatalf("failed to collect cross chain txs: %v", err)
+	}
+
+	// the lock proxy transfers to other side chains are routed by the main chain
+	want := []string{"unlock", "mint", "main"}
+	if len(txs) != len(want) {
+		t.Fatalf("cross chain txs mismatch: have %d, want %d", len(txs), len(want))
+	}
+	for i, tx := range txs {
+		if string(tx.Raw) != want[i] {
+			t.Errorf("tx %d: request mismatch: have %s, want %s", i, tx.Raw, want[i])
+		}
+		if tx.ToChain != mainID {
+			t.Errorf("tx %d: target chain mismatch: have %d, want %d", i, tx.ToChain, mainID)
+		}
+		if tx.Height != 10 || tx.Index != uint64(i) {
+			t.Errorf("tx %d: position mismatch: have %d/%d", i, tx.Height, tx.Index)
+		}
+	}
+}
+
+// testBackend is an in-process Zion node, whose single validator seals the blocks
+// by hotstuff consensus and sends the transactions of the test.
+type testBackend struct {
+	node *node.Node
+	eth  *eth.Ethereum
+	key  *ecdsa.PrivateKey
+	free bool // Transactions are sent without fee
+}
+
+// newTestBackend starts a Zion node of the chain role, the contracts of the given runtime
+// codes are deployed in the genesis.
+func newTestBackend(t *testing.T, zion *params.ZionConfig, codes map[common.Address][]byte) *testBackend {
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+
+	config := *params.AllEthashProtocolChanges
+	config.Ethash = nil
+	config.HotStuff = &params.HotStuffConfig{Protocol: "basic"}
+	config.Zion = zion
+
+	// the genesis allocation is minted on the main chain only, so the transactions of the
+	// side chain are sent without fee
+	free := !zion.IsMainChain()
+	if free {
+		config.LondonBlock = nil
+	}
+
+	extra, err := rlp.EncodeToBytes(&types.HotstuffExtra{Validators: []common.Address{addr}, Seal: []byte{}, CommittedSeal: [][]byte{}})
+	if err != nil {
+		t.Fatalf("failed to encode genesis extra: %v", err)
+	}
+	genesis := &core.Genesis{
+		Config:     &config,
+		ExtraData:  append(make([]byte, types.HotstuffExtraVanity), extra...),
+		GasLimit:   30000000,
+		Difficulty: big.NewInt(1),
+		Alloc: core.GenesisAlloc{addr: {
+			Balance:   new(big.Int).Mul(big.NewInt(1000), big.NewInt(params.Ether)),
+			PublicKey: crypto.CompressPubkey(&key.PublicKey),
+		}},
+	}
+
+	n, err := node.New(&node.Config{P2P: p2p.Config{PrivateKey: key, NoDiscovery: true}})
+	if err != nil {
+		t.Fatalf("failed to create node: %v", err)
+	}
+	// the genesis allocation is not applied on the side chain, so the codes are stored
+	// along with the genesis validators
+	if len(codes) > 0 {
+		regGenesis := core.RegGenesis
+		core.RegGenesis = func(db *state.StateDB, data core.GenesisAlloc) error {
+			for addr, code := range codes {
+				db.SetCode(addr, code)
+			}
+			return regGenesis(db, data)
+		}
+		defer func() { core.RegGenesis = regGenesis }()
+	}
+	ethcfg := ethconfig.Defaults
+	ethcfg.Genesis = genesis
+	ethcfg.SyncMode = downloader.FullSync
+	ethcfg.NoPruning = true
+	ethcfg.Miner.Etherbase = addr
+	ethservice, err := eth.New(n, &ethcfg)
+	if err != nil {
+		t.Fatalf("failed to create ethereum service: %v", err)
+	}
+	if err := n.Start(); err != nil {
+		t.Fatalf("failed to start node: %v", err)
+	}
+	// the single validator commits the first proposal, which should carry the pending transactions
+	ethservice.Miner().DisablePreseal()
+	if err := ethservice.StartMining(1); err != nil {
+		t.Fatalf("failed to start mining: %v", err)
+	}
+	return &testBackend{node: n, eth: ethservice, key: key, free: free}
+}
+
+// chain connects to the node in the way the relayer does, signing by the validator.
+func (b *testBackend) chain(t *testing.T, id uint64) *zionChain {
+	client, err := b.node.Attach()
+	if err != nil {
+		t.Fatalf("failed to attach node: %v", err)
+	}
+	opts, err := bind.NewKeyedTransactorWithChainID(b.key, params.AllEthashProtocolChanges.ChainID)
+	if err != nil {
+		t.Fatalf("failed to create transactor: %v", err)
+	}
+	if b.free {
+		opts.GasPrice = common.Big0
+	}
+	return newZionChain(id, client, opts)
+}
+
+// deploy deploys the contract of the runtime code, the storage is initialized by the
+// given slots.
+func (b *testBackend) deploy(t *testing.T, ctx context.Context, chain *zionChain, code []byte, storage map[common.Hash]common.Hash) common.Address {
+	var init []byte
+	for slot, value := range storage {
+		init = append(init, 0x7f) // PUSH32 value
+		init = append(init, value.Bytes()...)
+		init = append(init, 0x7f) // PUSH32 slot
+		init = append(init, slot.Bytes()...)
+		init = append(init, 0x55) // SSTORE
+	}
+	// CODECOPY(0, offset, len(code)), RETURN(0, len(code))
+	offset := byte(len(init) + 12)
+	init = append(init, 0x60, byte(len(code)), 0x60, offset, 0x60, 0x00, 0x39, 0x60, byte(len(code)), 0x60, 0x00, 0xf3)
+	init = append(init, code...)
+
+	addr, tx, _, err := bind.DeployContract(chain.transactOpts(ctx), abi.ABI{}, init, chain.client)
+	if err := chain.waitMined(ctx, tx, err); err != nil {
+		t.Fatalf("failed to deploy contract: %v", err)
+	}
+	return addr
+}
+
+// calls returns the payloads of the transactions sent to the contract after the height.
+func (b *testBackend) calls(contract common.Address, from uint64) [][]byte {
+	var payloads [][]byte
+	chain := b.eth.BlockChain()
+	for number := from + 1; number <= chain.CurrentBlock().NumberU64(); number++ {
+		for _, tx := range chain.GetBlockByNumber(number).Transactions() {
+			if to := tx.To(); to != nil && *to == contract {
+				payloads = append(payloads, tx.Data())
+			}
+		}
+	}
+	return payloads
+}
+
+// logCode is the code emitting the call data as a `CrossChainEvent` log, standing for
+// the cross chain manager of the side chain.
+func logCode() []byte {
+	code := []byte{0x36, 0x60, 0x00, 0x60, 0x00, 0x37, 0x7f} // CALLDATACOPY(0, 0, CALLDATASIZE), PUSH32
+	code = append(code, crossChainEvt.ID.Bytes()...)
+	return append(code, 0x36, 0x60, 0x00, 0xa1, 0x00) // LOG1(0, CALLDATASIZE, topic), STOP
+}
+
+// verifyProof verifies the storage value of the slot against the state root by the rlp
+// encoded account and storage proofs, as the cross chain manager of the side chain does.
+func verifyProof(t *testing.T, root common.Hash, contract common.Address, slot common.Hash, accountProof, storageProof []byte) []byte {
+	prove := func(root common.Hash, key []byte, enc []byte) []byte {
+		var nodes [][]byte
+		if err := rlp.DecodeBytes(enc, &nodes); err != nil {
+			t.Fatalf("failed to decode proof: %v", err)
+		}
+		list := new(light.NodeList)
+		for _, node := range nodes {
+			list.Put(nil, node)
+		}
+		value, err := trie.VerifyProof(root, crypto.Keccak256(key), list.NodeSet())
+		if err != nil {
+			t.Fatalf("failed to verify proof: %v", err)
+		}
+		return value
+	}
+	account := new(state.Account)
+	if err := rlp.DecodeBytes(prove(root, contract.Bytes(), accountProof), account); err != nil {
+		t.Fatalf("failed to decode account: %v", err)
+	}
+	return prove(account.Root, slot.Bytes(), storageProof)
+}
+
+// checkHeader checks the rlp encoded header and committed seals of the validator.
+func checkHeader(t *testing.T, rawHeader, rawSeals []byte, want *types.Header, validator common.Address) {
+	header := new(types.Header)
+	if err := rlp.DecodeBytes(rawHeader, header); err != nil {
+		t.Fatalf("failed to decode header: %v", err)
+	}
+	if header.Hash() != want.Hash() {
+		t.Fatalf("header mismatch: have %s, want %s", header.Hash().Hex(), want.Hash().Hex())
+	}
+	var seals [][]byte
+	if err := rlp.DecodeBytes(rawSeals, &seals); err != nil {
+		t.Fatalf("failed to decode seals: %v", err)
+	}
+	key, _ := crypto.GenerateKey()
+	signers, err := snr.NewSigner(key).GetSignersFromCommittedSeals(header.Hash(), seals)
+	if err != nil {
+		t.Fatalf("failed to recover committed seals: %v", err)
+	}
+	if len(signers) != 1 || signers[0] != validator {
+		t.Fatalf("committed seals mismatch: have %v, want %s", signers, validator.Hex())
+	}
+}
+
+// registerSideChain registers and approves the side chain of the zion router on the main chain.
+func registerSideChain(t *testing.T, ctx context.Context, mainc *mainChain, sideID uint64, eccm common.Address) {
+	owner := mainc.transactOpts(ctx)
+	tx, err := mainc.zion.RegisterSideChain(owner, &side_chain_manager.RegisterSideChainParam{
+		Address:      owner.From,
+		ChainId:      sideID,
+		Router:       utils.ZION_ROUTER,
+		Name:         "side",
+		BlocksToWait: 1,
+		CCMCAddress:  eccm.Bytes(),
+	})
+	if err := mainc.waitMined(ctx, tx, err); err != nil {
+		t.Fatalf("failed to register side chain: %v", err)
+	}
+	tx, err = mainc.zion.ApproveRegisterSideChain(mainc.transactOpts(ctx), sideID)
+	if err := mainc.waitMined(ctx, tx, err); err != nil {
+		t.Fatalf("failed to approve side chain: %v", err)
+	}
+}
+
+// lockToSideChain locks the native token of the main chain to the receiver of the side chain.
+func lockToSideChain(t *testing.T, ctx context.Context, mainc *mainChain, sideID uint64, to common.Address, amount *big.Int) *types.Transaction {
+	opts := mainc.transactOpts(ctx)
+	opts.Value = amount
+	tx, err := mainc.zion.Lock(opts, sideID, to, amount)
+	if err := mainc.waitMined(ctx, tx, err); err != nil {
+		t.Fatalf("failed to lock: %v", err)
+	}
+	return tx
+}
+
+func TestZionChains(t *testing.T) {
+	const (
+		mainID = uint64(1)
+		sideID = uint64(77)
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	mainNode := newTestBackend(t, &params.ZionConfig{Role: params.ZionRoleMain, CrossChainID: mainID}, nil)
+	defer mainNode.node.Close()
+	sideNode := newTestBackend(t, &params.ZionConfig{Role: params.ZionRoleSide, CrossChainID: sideID, RelayChainID: mainID}, nil)
+	defer sideNode.node.Close()
+
+	// the side chain request is stored by the data contract in the mapping at slot 1
+	param := &scom.MakeTxParam{
+		TxHash:              []byte{1},
+		CrossChainID:        []byte{2},
+		FromContractAddress: utils.LockProxyContractAddress[:],
+		ToChainID:           mainID,
+		ToContractAddress:   utils.LockProxyContractAddress[:],
+		Method:              "unlock",
+		Args:                []byte("args"),
+	}
+	raw, err := scom.EncodeTxParam(param)
+	if err != nil {
+		t.Fatalf("failed to encode request: %v", err)
+	}
+	sidec := &sideChain{zionChain: sideNode.chain(t, sideID)}
+	sidec.eccm = sideNode.deploy(t, ctx, sidec.zionChain, logCode(), nil)
+	sidec.eccd = sideNode.deploy(t, ctx, sidec.zionChain, nil, map[common.Hash]common.Hash{mappingSlot(param.TxHash, 1): crypto.Keccak256Hash(raw)})
+	mainc := &mainChain{mainNode.chain(t, mainID)}
+	mainValidator := crypto.PubkeyToAddress(mainNode.key.PublicKey)
+
+	// register the side chain and lock the native token to it on the main chain
+	registerSideChain(t, ctx, mainc, sideID, sidec.eccm)
+	lock := lockToSideChain(t, ctx, mainc, sideID, common.HexToAddress("0xbeef"), big.NewInt(params.Ether))
+
+	// the main chain request is proved by `zion_getCrossChainProof`
+	head := mainNode.eth.BlockChain().CurrentBlock().NumberU64()
+	if height, err := mainc.LatestHeight(ctx); err != nil || height == 0 || height > head {
+		t.Fatalf("unexpected finalized height %d of head %d, err: %v", height, head, err)
+	}
+	txs, err := mainc.CrossChainTxs(ctx, 0, head, sideID)
+	if err != nil {
+		t.Fatalf("failed to collect main chain txs: %v", err)
+	}
+	if len(txs) != 1 || txs[0].TxHash != lock.Hash() || txs[0].ToChain != sideID {
+		t.Fatalf("unexpected main chain txs %v", txs)
+	}
+	proof, err := mainc.Proof(ctx, txs[0])
+	if err != nil {
+		t.Fatalf("failed to get main chain proof: %v", err)
+	}
+	if proof.Header.Number.Uint64() != txs[0].Height || !bytes.Equal(proof.Extra, txs[0].Raw) {
+		t.Fatalf("unexpected main chain proof of %d", proof.Header.Number)
+	}
+	value := new(scom.ToMerkleValue)
+	if err := rlp.DecodeBytes(proof.Extra, value); err != nil {
+		t.Fatalf("failed to decode main chain request: %v", err)
+	}
+	key := utils.ConcatKey(utils.CrossChainManagerContractAddress, []byte(scom.REQUEST), utils.GetUint64Bytes(sideID), value.TxHash)
+	slot := state.Key2Slot(key[common.AddressLength:])
+
+	// the epoch change and the transfer are delivered to the side chain cross chain manager
+	start := sideNode.eth.BlockChain().CurrentBlock().NumberU64()
+	if err := sidec.SyncHeaders(ctx, mainID, []*types.Header{proof.Header}); err != nil {
+		t.Fatalf("failed to sync headers: %v", err)
+	}
+	if err := sidec.Deliver(ctx, mainID, txs[0], proof); err != nil {
+		t.Fatalf("failed to deliver: %v", err)
+	}
+	calls := sideNode.calls(sidec.eccm, start)
+	if len(calls) != 2 {
+		t.Fatalf("unexpected side chain calls %d", len(calls))
+	}
+	args, err := eccm.Methods["changeEpoch"].Inputs.Unpack(calls[0][4:])
+	if err != nil || !bytes.Equal(calls[0][:4], eccm.Methods["changeEpoch"].ID) {
+		t.Fatalf("failed to unpack changeEpoch: %v", err)
+	}
+	checkHeader(t, args[0].([]byte), args[1].([]byte), proof.Header, mainValidator)
+
+	args, err = eccm.Methods["verifyHeaderAndExecuteTx"].Inputs.Unpack(calls[1][4:])
+	if err != nil || !bytes.Equal(calls[1][:4], eccm.Methods["verifyHeaderAndExecuteTx"].ID) {
+		t.Fatalf("failed to unpack verifyHeaderAndExecuteTx: %v", err)
+	}
+	checkHeader(t, args[0].([]byte), args[1].([]byte), proof.Header, mainValidator)
+	stored := verifyProof(t, proof.Header.Root, utils.CrossChainManagerContractAddress, slot, args[2].([]byte), args[3].([]byte))
+	want := proof.Account.StorageProof[0]
+	if common.HexToHash(want.Key) != slot {
+		t.Fatalf("storage slot mismatch: have %s, want %s", want.Key, slot.Hex())
+	}
+	if enc, _ := rlp.EncodeToBytes(want.Value.ToInt().Bytes()); !bytes.Equal(stored, enc) {
+		t.Fatalf("storage value mismatch: have %x, want %x", stored, enc)
+	}
+	if !bytes.Equal(args[4].([]byte), proof.Extra) {
+		t.Fatalf("cross chain request mismatch")
+	}
+
+	// the side chain request is proved by `eth_getProof` of the data contract
+	payload, err := crossChainEvt.Inputs.NonIndexed().Pack(param.CrossChainID, utils.LockProxyContractAddress, mainID, utils.LockProxyContractAddress.Bytes(), raw)
+	if err != nil {
+		t.Fatalf("failed to pack CrossChainEvent: %v", err)
+	}
+	bound := bind.NewBoundContract(sidec.eccm, abi.ABI{}, sidec.client, sidec.client, sidec.client)
+	event, err := bound.RawTransact(sidec.transactOpts(ctx), payload)
+	if err := sidec.waitMined(ctx, event, err); err != nil {
+		t.Fatalf("failed to emit CrossChainEvent: %v", err)
+	}
+	receipt, err := sidec.client.TransactionReceipt(ctx, event.Hash())
+	if err != nil {
+		t.Fatalf("failed to get receipt: %v", err)
+	}
+	number := receipt.BlockNumber.Uint64()
+	txs, err = sidec.CrossChainTxs(ctx, number, number, mainID)
+	if err != nil {
+		t.Fatalf("failed to collect side chain txs: %v", err)
+	}
+	if len(txs) != 1 || txs[0].TxHash != event.Hash() || !bytes.Equal(txs[0].Raw, raw) {
+		t.Fatalf("unexpected side chain txs %v", txs)
+	}
+	proof, err = sidec.Proof(ctx, txs[0])
+	if err != nil {
+		t.Fatalf("failed to get side chain proof: %v", err)
+	}
+	result, err := helper.VerifyAccountResult(proof.Account, proof.Header, sidec.eccd)
+	if err != nil {
+		t.Fatalf("failed to verify side chain proof: %v", err)
+	}
+	if !helper.CheckProofResult(result, proof.Extra) {
+		t.Fatalf("side chain proof result mismatch")
+	}
+}
+
+// testECCMProxy is the well known address of the proxy, which is queried by the side chain
+// lock proxy for the cross chain manager allowed to mint.
+var testECCMProxy = common.HexToAddress("0xc6195336878Fc34B1b5A13895015a97c1aD9cc25")
+
+// eccmCode is the code of the proxy standing for the cross chain manager of the side chain
+// as well. The delivered transaction is trusted without verification, the transfer args are
+// the last `size` bytes of the raw cross chain request and minted by the lock proxy.
+func eccmCode(t *testing.T, size int, fromChainID uint64) []byte {
+	sideProxyABI := mustParseABI(side_chain_lock_proxy_abi.ISideChainLockProxyABI)
+	mint, err := sideProxyABI.Pack(side_chain_lock_proxy_abi.MethodMint, make([]byte, size), utils.LockProxyContractAddress.Bytes(), fromChainID)
+	if err != nil {
+		t.Fatalf("failed to pack mint: %v", err)
+	}
+	// the args are the first dynamic parameter of mint, after the heads and the length
+	argsOffset := 4 + 4*32
+	push2 := func(v int) []byte { return []byte{0x61, byte(v >> 8), byte(v)} }
+
+	// dispatch by the method id, the other calls such as `changeEpoch` are accepted
+	code := []byte{0x60, 0x00, 0x35, 0x60, 0xe0, 0x1c, 0x80, 0x63} // CALLDATALOAD(0) >> 224, DUP1, PUSH4
+	code = append(code, crypto.Keccak256([]byte("getEthCrossChainManager()"))[:4]...)
+	code = append(code, 0x14, 0x61, 0x00, 0x00, 0x57, 0x63) // EQ, JUMPI(getter), PUSH4
+	getter := len(code) - 4
+	code = append(code, eccm.Methods["verifyHeaderAndExecuteTx"].ID...)
+	code = append(code, 0x14, 0x61, 0x00, 0x00, 0x57, 0x00) // EQ, JUMPI(execute), STOP
+	execute := len(code) - 4
+
+	// the proxy returns itself as the cross chain manager
+	code[getter], code[getter+1] = byte(len(code)>>8), byte(len(code))
+	code = append(code, 0x5b, 0x30, 0x60, 0x00, 0x52, 0x60, 0x20, 0x60, 0x00, 0xf3) // JUMPDEST, MSTORE(0, ADDRESS), RETURN(0, 32)
+
+	// copy the mint payload into memory and fill it by the args of the raw request, which
+	// is the fifth parameter of `verifyHeaderAndExecuteTx`
+	code[execute], code[execute+1] = byte(len(code)>>8), byte(len(code))
+	code = append(code, 0x5b)                                                 // JUMPDEST
+	payload := len(code)                                                      // offset of the payload to be patched
+	code = append(code, 0x61, 0x00, 0x00, 0x61, 0x00, 0x00, 0x60, 0x00, 0x39) // CODECOPY(0, payload, len(mint))
+	code = append(code, push2(size)...)
+	code = append(code, 0x60, 0x84, 0x35, 0x60, 0x04, 0x01, 0x80, 0x35, 0x01, 0x60, 0x20, 0x01) // 4+CALLDATALOAD(132), end of the request
+	code = append(code, push2(size)...)
+	code = append(code, 0x90, 0x03) // SWAP1, SUB
+	code = append(code, push2(argsOffset)...)
+	code = append(code, 0x37) // CALLDATACOPY(argsOffset, end-size, size)
+
+	// call the lock proxy and revert if it fails
+	code = append(code, 0x60, 0x00, 0x60, 0x00)
+	code = append(code, push2(len(mint))...)
+	code = append(code, 0x60, 0x00, 0x60, 0x00, 0x73)
+	code = append(code, utils.LockProxyContractAddress.Bytes()...)
+	code = append(code, 0x5a, 0xf1, 0x61, 0x00, 0x00, 0x57, 0x60, 0x00, 0x80, 0xfd) // CALL, JUMPI(done), REVERT(0, 0)
+	done := len(code) - 7
+	code[done], code[done+1] = byte(len(code)>>8), byte(len(code))
+	code = append(code, 0x5b, 0x00) // JUMPDEST, STOP
+
+	copy(code[payload+1:], push2(len(mint))[1:])
+	copy(code[payload+4:], push2(len(code))[1:])
+	return append(code, mint...)
+}
+
+func TestRelayerTransfer(t *testing.T) {
+	const (
+		mainID = uint64(1)
+		sideID = uint64(77)
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	// the args of the transfer locked on the main chain
+	to, amount := common.HexToAddress("0xbeef"), big.NewInt(params.Ether)
+	args, err := rlp.EncodeToBytes(&scom.TxArgs{ToAssetHash: common.EmptyAddress.Bytes(), ToAddress: to.Bytes(), Amount: amount})
+	if err != nil {
+		t.Fatalf("failed to encode args: %v", err)
+	}
+
+	mainNode := newTestBackend(t, &params.ZionConfig{Role: params.ZionRoleMain, CrossChainID: mainID}, nil)
+	defer mainNode.node.Close()
+	sideNode := newTestBackend(t, &params.ZionConfig{Role: params.ZionRoleSide, CrossChainID: sideID, RelayChainID: mainID},
+		map[common.Address][]byte{testECCMProxy: eccmCode(t, len(args), mainID)})
+	defer sideNode.node.Close()
+
+	mainc := &mainChain{mainNode.chain(t, mainID)}
+	sidec := &sideChain{zionChain: sideNode.chain(t, sideID), eccm: testECCMProxy}
+	registerSideChain(t, ctx, mainc, sideID, sidec.eccm)
+	lockToSideChain(t, ctx, mainc, sideID, to, amount)
+
+	cfg := defaultConfig
+	cfg.Interval, cfg.Backoff = 100*time.Millisecond, 100*time.Millisecond
+	cfg.CursorsFile = filepath.Join(t.TempDir(), "cursors.json")
+	relayer, err := newRelayer(mainc, sidec, &cfg)
+	if err != nil {
+		t.Fatalf("failed to create relayer: %v", err)
+	}
+	relayer.start()
+	defer relayer.stop()
+
+	// the transfer is minted once the relayer delivers it to the side chain
+	for {
+		balance, err := sidec.client.BalanceAt(ctx, to, nil)
+		if err != nil {
+			t.Fatalf("failed to get balance: %v", err)
+		}
+		if balance.Cmp(amount) == 0 {
+			break
+		}
+		select {
+		case <-time.After(100 * time.Millisecond):
+		case <-ctx.Done():
+			t.Fatalf("transfer not minted, balance %v", balance)
+		}
+	}
+	calls := sideNode.calls(testECCMProxy, 0)
+	if len(calls) != 1 || !bytes.Equal(calls[0][:4], eccm.Methods["verifyHeaderAndExecuteTx"].ID) {
+		t.Fatalf("unexpected side chain calls %d", len(calls))
+	}
+}
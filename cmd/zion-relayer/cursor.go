@@ -0,0 +1,109 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// position locates an item relayed by a route, items are relayed in ascending order
+// of position. Cross chain transactions are indexed by their log index and the epoch
+// change header comes after all the transactions of the same block.
+type position struct {
+	Height uint64 `json:"height"`
+	Index  uint64 `json:"index"`
+}
+
+// less returns whether the position p is before q.
+func (p position) less(q position) bool {
+	return p.Height < q.Height || (p.Height == q.Height && p.Index < q.Index)
+}
+
+// cursor is the relaying progress of a route.
+type cursor struct {
+	Next uint64    `json:"next"` // Next block to scan, blocks before it are relayed
+	Last *position `json:"last"` // Last item relayed, nil if nothing relayed yet
+}
+
+// cursorStore persists the cursors of all the routes into a json file, so the relayer
+// resumes from where it stopped.
+type cursorStore struct {
+	path    string
+	cursors map[string]*cursor
+	lock    sync.Mutex
+}
+
+// newCursorStore loads the cursors from the file, a missing file is treated as empty.
+func newCursorStore(path string) (*cursorStore, error) {
+	store := &cursorStore{path: path, cursors: make(map[string]*cursor)}
+	blob, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(blob, &store.cursors); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+// get returns a copy of the cursor of the route, the start block is used if the route
+// has never been relayed.
+func (s *cursorStore) get(route string, start uint64) cursor {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	c, ok := s.cursors[route]
+	if !ok {
+		return cursor{Next: start}
+	}
+	cpy := cursor{Next: c.Next}
+	if c.Last != nil {
+		last := *c.Last
+		cpy.Last = &last
+	}
+	return cpy
+}
+
+// put updates the cursor of the route and flushes all the cursors to disk.
+func (s *cursorStore) put(route string, c cursor) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.cursors[route] = &c
+	blob, err := json.MarshalIndent(s.cursors, "", "  ")
+	if err != nil {
+		return err
+	}
+	// write to a temporary file first, a crash in the middle won't corrupt the cursors
+	tmp := s.path + ".tmp"
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(tmp, blob, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
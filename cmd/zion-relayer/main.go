@@ -0,0 +1,258 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+// zion-relayer is a reference relayer moving assets between the Zion main chain and a
+// side chain, e.g.
+//
+//     $ zion-relayer --main.rpc http://localhost:8545 --main.id 1 \
+//           --side.rpc http://localhost:9545 --side.id 77 --side.eccm 0x... --side.eccd 0x... \
+//           --keyfile ./key.json --password ./pwd --cursors ./relayer/cursors.json
+//
+// The epoch change headers and the cross chain transactions of both chains are relayed
+// in order, the progress is persisted so the relayer resumes from where it stopped.
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/cmd/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/fdlimit"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/internal/flags"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/metrics/exp"
+	"github.com/ethereum/go-ethereum/rpc"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var (
+	// Git SHA1 commit hash of the release (set via linker flags)
+	gitCommit = ""
+	gitDate   = ""
+)
+
+var app *cli.App
+
+func init() {
+	app = flags.NewApp(gitCommit, gitDate, "Zion main chain and side chain relayer")
+	app.Action = relay
+	app.Flags = []cli.Flag{
+		mainURLFlag,
+		mainIDFlag,
+		mainStartFlag,
+		sideURLFlag,
+		sideIDFlag,
+		sideStartFlag,
+		sideECCMFlag,
+		sideECCDFlag,
+		keyFileFlag,
+		passwordFileFlag,
+		cursorsFlag,
+		batchFlag,
+		rangeFlag,
+		intervalFlag,
+		retriesFlag,
+		metricsFlag,
+		metricsAddrFlag,
+	}
+	cli.CommandHelpTemplate = flags.OriginCommandHelpTemplate
+}
+
+var (
+	mainURLFlag = cli.StringFlag{
+		Name:  "main.rpc",
+		Value: "http://localhost:8545",
+		Usage: "The rpc endpoint of a Zion main chain node",
+	}
+	mainIDFlag = cli.Uint64Flag{
+		Name:  "main.id",
+		Value: 1,
+		Usage: "Cross chain id of the main chain",
+	}
+	mainStartFlag = cli.Uint64Flag{
+		Name:  "main.start",
+		Usage: "First main chain block to relay if no cursor persisted",
+	}
+	sideURLFlag = cli.StringFlag{
+		Name:  "side.rpc",
+		Usage: "The rpc endpoint of a Zion side chain node",
+	}
+	sideIDFlag = cli.Uint64Flag{
+		Name:  "side.id",
+		Usage: "Cross chain id of the side chain",
+	}
+	sideStartFlag = cli.Uint64Flag{
+		Name:  "side.start",
+		Usage: "First side chain block to relay if no cursor persisted",
+	}
+	sideECCMFlag = cli.StringFlag{
+		Name:  "side.eccm",
+		Usage: "Address of the cross chain manager contract on the side chain",
+	}
+	sideECCDFlag = cli.StringFlag{
+		Name:  "side.eccd",
+		Usage: "Address of the cross chain data contract on the side chain",
+	}
+	keyFileFlag = cli.StringFlag{
+		Name:  "keyfile",
+		Usage: "Keystore file of the relayer account, which signs on both chains",
+	}
+	passwordFileFlag = cli.StringFlag{
+		Name:  "password",
+		Usage: "File containing the password of the keystore file",
+	}
+	cursorsFlag = cli.StringFlag{
+		Name:  "cursors",
+		Value: "relayer-cursors.json",
+		Usage: "File to persist the relaying progress",
+	}
+	batchFlag = cli.IntFlag{
+		Name:  "batch",
+		Value: defaultConfig.Batch,
+		Usage: "Maximum number of epoch headers synced in one transaction",
+	}
+	rangeFlag = cli.Uint64Flag{
+		Name:  "range",
+		Value: defaultConfig.Range,
+		Usage: "Maximum number of blocks scanned in one round",
+	}
+	intervalFlag = cli.DurationFlag{
+		Name:  "interval",
+		Value: defaultConfig.Interval,
+		Usage: "Polling interval once the relayer catches up",
+	}
+	retriesFlag = cli.IntFlag{
+		Name:  "retries",
+		Value: defaultConfig.Retries,
+		Usage: "Number of attempts of a submission before retrying from the cursor",
+	}
+	metricsFlag = cli.BoolFlag{
+		Name:  "metrics",
+		Usage: "Enable metrics collection and reporting",
+	}
+	metricsAddrFlag = cli.StringFlag{
+		Name:  "metrics.addr",
+		Value: "127.0.0.1:6061",
+		Usage: "Listening address of the metrics server",
+	}
+)
+
+func main() {
+	log.Root().SetHandler(log.LvlFilterHandler(log.LvlInfo, log.StreamHandler(os.Stderr, log.TerminalFormat(true))))
+	fdlimit.Raise(2048)
+
+	if err := app.Run(os.Args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// relay runs the relayer until interrupted.
+func relay(ctx *cli.Context) error {
+	for _, flag := range []cli.StringFlag{sideURLFlag, sideECCMFlag, sideECCDFlag, keyFileFlag} {
+		if !ctx.IsSet(flag.Name) {
+			return fmt.Errorf("missing --%s", flag.Name)
+		}
+	}
+	if !ctx.IsSet(sideIDFlag.Name) {
+		return fmt.Errorf("missing --%s", sideIDFlag.Name)
+	}
+	if ctx.Bool(metricsFlag.Name) {
+		exp.Setup(ctx.String(metricsAddrFlag.Name))
+	}
+
+	mainRPC, err := rpc.Dial(ctx.String(mainURLFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to connect to main chain: %v", err)
+	}
+	sideRPC, err := rpc.Dial(ctx.String(sideURLFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to connect to side chain: %v", err)
+	}
+	mainc := &mainChain{newZionChain(ctx.Uint64(mainIDFlag.Name), mainRPC, newTransactor(ctx, mainRPC))}
+	sidec := &sideChain{
+		zionChain: newZionChain(ctx.Uint64(sideIDFlag.Name), sideRPC, newTransactor(ctx, sideRPC)),
+		eccm:      common.HexToAddress(ctx.String(sideECCMFlag.Name)),
+		eccd:      common.HexToAddress(ctx.String(sideECCDFlag.Name)),
+	}
+
+	cfg := defaultConfig
+	cfg.Batch = ctx.Int(batchFlag.Name)
+	cfg.Range = ctx.Uint64(rangeFlag.Name)
+	cfg.Interval = ctx.Duration(intervalFlag.Name)
+	cfg.Retries = ctx.Int(retriesFlag.Name)
+	cfg.MainStart = ctx.Uint64(mainStartFlag.Name)
+	cfg.SideStart = ctx.Uint64(sideStartFlag.Name)
+	cfg.CursorsFile = ctx.String(cursorsFlag.Name)
+
+	r, err := newRelayer(mainc, sidec, &cfg)
+	if err != nil {
+		return err
+	}
+	r.start()
+	log.Info("Relayer started", "main", mainc.ID(), "side", sidec.ID(), "metrics", metrics.Enabled)
+
+	sigc := make(chan os.Signal, 1)
+	signal.Notify(sigc, syscall.SIGINT, syscall.SIGTERM)
+	<-sigc
+	log.Info("Got interrupt, shutting down...")
+	r.stop()
+	return nil
+}
+
+// newTransactor creates the transaction signer of the relayer account from keystore
+// file, the chain id is retrieved from the connected node.
+func newTransactor(ctx *cli.Context, client *rpc.Client) *bind.TransactOpts {
+	keyjson, err := ioutil.ReadFile(ctx.String(keyFileFlag.Name))
+	if err != nil {
+		utils.Fatalf("Failed to read keyfile: %v", err)
+	}
+	var password string
+	if ctx.IsSet(passwordFileFlag.Name) {
+		content, err := ioutil.ReadFile(ctx.String(passwordFileFlag.Name))
+		if err != nil {
+			utils.Fatalf("Failed to read password file: %v", err)
+		}
+		password = strings.TrimRight(strings.Split(string(content), "\n")[0], "\r")
+	} else {
+		password = utils.GetPassPhrase("Please enter the password of the keyfile", false)
+	}
+	key, err := keystore.DecryptKey(keyjson, password)
+	if err != nil {
+		utils.Fatalf("Failed to decrypt keyfile: %v", err)
+	}
+	chainID, err := ethclient.NewClient(client).ChainID(context.Background())
+	if err != nil {
+		utils.Fatalf("Failed to retrieve chain id: %v", err)
+	}
+	opts, err := bind.NewKeyedTransactorWithChainID(key.PrivateKey, chainID)
+	if err != nil {
+		utils.Fatalf("Failed to create keyed transactor: %v", err)
+	}
+	return opts
+}
@@ -0,0 +1,358 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+// headerIndex is the position index of the epoch change header, which is relayed after
+// all the cross chain transactions in the same block since they are still verified by
+// the validators before the epoch change.
+const headerIndex = math.MaxUint64
+
+// crossTx is a cross chain transaction made on the source chain.
+type crossTx struct {
+	Height  uint64      // Block number of the transaction
+	Index   uint64      // Log index of the cross chain event in the block
+	TxHash  common.Hash // Hash of the transaction making the cross chain request
+	ToChain uint64      // Cross chain id of the target chain
+	Raw     []byte      // Raw cross chain request whose hash is stored by the source chain
+}
+
+// crossProof proves the cross chain request stored at the source chain.
+type crossProof struct {
+	Header  *types.Header         // Header with committed seals of the block proved
+	Account *ethapi.AccountResult // Account and storage proof of the request
+	Extra   []byte                // Raw request verified against the storage proof
+}
+
+// source is the chain where the cross chain transactions are made.
+type source interface {
+	// ID returns the cross chain id of the chain.
+	ID() uint64
+
+	// LatestHeight returns the height of the latest finalized block.
+	LatestHeight(ctx context.Context) (uint64, error)
+
+	// EpochHeaders returns the epoch change headers in the range [from, to].
+	EpochHeaders(ctx context.Context, from, to uint64) ([]*types.Header, error)
+
+	// CrossChainTxs returns the transactions to the target chain in the range [from, to].
+	CrossChainTxs(ctx context.Context, from, to, dst uint64) ([]*crossTx, error)
+
+	// Proof returns the proof of the cross chain transaction.
+	Proof(ctx context.Context, tx *crossTx) (*crossProof, error)
+}
+
+// target is the chain where the proofs of the source chain are verified.
+type target interface {
+	// ID returns the cross chain id of the chain.
+	ID() uint64
+
+	// SyncHeaders submits the epoch change headers of the source chain in ascending order.
+	SyncHeaders(ctx context.Context, src uint64, headers []*types.Header) error
+
+	// Deliver submits the proved cross chain transaction of the source chain.
+	Deliver(ctx context.Context, src uint64, tx *crossTx, proof *crossProof) error
+}
+
+// config is the tunables of the relayer.
+type config struct {
+	Batch       int           // Maximum number of headers synced in one transaction
+	Range       uint64        // Maximum number of blocks scanned in one round
+	Interval    time.Duration // Polling interval once the route catches up
+	Retries     int           // Number of attempts of a submission before giving up the round
+	Backoff     time.Duration // Initial retry backoff, doubled after each failure
+	MaxBackoff  time.Duration // Maximum retry backoff
+	MainStart   uint64        // First block of the main chain if no cursor stored
+	SideStart   uint64        // First block of the side chain if no cursor stored
+	CursorsFile string        // File to persist the relaying cursors
+}
+
+var defaultConfig = config{
+	Batch:      16,
+	Range:      1000,
+	Interval:   3 * time.Second,
+	Retries:    5,
+	Backoff:    time.Second,
+	MaxBackoff: time.Minute,
+}
+
+// item is an epoch change header or a cross chain transaction to be relayed.
+type item struct {
+	pos    position
+	header *types.Header
+	tx     *crossTx
+}
+
+// route relays the epoch change headers and the cross chain transactions of the source
+// chain to the target chain.
+type route struct {
+	name   string
+	src    source
+	dst    target
+	start  uint64
+	cfg    *config
+	store  *cursorStore
+	cursor cursor
+
+	heightGauge    metrics.Gauge
+	headersCounter metrics.Counter
+	txsCounter     metrics.Counter
+	failureMeter   metrics.Meter
+}
+
+func newRoute(name string, src source, dst target, start uint64, cfg *config, store *cursorStore) *route {
+	return &route{
+		name:           name,
+		src:            src,
+		dst:            dst,
+		start:          start,
+		cfg:            cfg,
+		store:          store,
+		heightGauge:    metrics.NewRegisteredGauge("relayer/"+name+"/height", nil),
+		headersCounter: metrics.NewRegisteredCounter("relayer/"+name+"/headers", nil),
+		txsCounter:     metrics.NewRegisteredCounter("relayer/"+name+"/txs", nil),
+		failureMeter:   metrics.NewRegisteredMeter("relayer/"+name+"/failures", nil),
+	}
+}
+
+// loop keeps relaying until the context is cancelled, a failed round is retried from
+// the persisted cursor after the polling interval.
+func (r *route) loop(ctx context.Context) {
+	r.cursor = r.store.get(r.name, r.start)
+	log.Info("Relaying route", "route", r.name, "from", r.cursor.Next)
+
+	for {
+		caughtUp, err := r.step(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			r.failureMeter.Mark(1)
+			log.Warn("Failed to relay", "route", r.name, "next", r.cursor.Next, "err", err)
+		}
+		if caughtUp || err != nil {
+			select {
+			case <-time.After(r.cfg.Interval):
+			case <-ctx.Done():
+				return
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// step relays a range of blocks, it returns whether the route has caught up with the
+// source chain.
+func (r *route) step(ctx context.Context) (bool, error) {
+	latest, err := r.src.LatestHeight(ctx)
+	if err != nil {
+		return false, err
+	}
+	from := r.cursor.Next
+	if from > latest {
+		return true, nil
+	}
+	to := latest
+	if to-from >= r.cfg.Range {
+		to = from + r.cfg.Range - 1
+	}
+
+	headers, err := r.src.EpochHeaders(ctx, from, to)
+	if err != nil {
+		return false, fmt.Errorf("failed to retrieve epoch headers: %v", err)
+	}
+	txs, err := r.src.CrossChainTxs(ctx, from, to, r.dst.ID())
+	if err != nil {
+		return false, fmt.Errorf("failed to retrieve cross chain transactions: %v", err)
+	}
+	items := make([]*item, 0, len(headers)+len(txs))
+	for _, header := range headers {
+		items = append(items, &item{pos: position{header.Number.Uint64(), headerIndex}, header: header})
+	}
+	for _, tx := range txs {
+		items = append(items, &item{pos: position{tx.Height, tx.Index}, tx: tx})
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].pos.less(items[j].pos) })
+
+	// consecutive headers are synced in batches, a transaction in between flushes the
+	// batch since it must be verified by the validators of its own epoch
+	var batch []*item
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		list := make([]*types.Header, len(batch))
+		for i, it := range batch {
+			list[i] = it.header
+		}
+		if err := r.retry(ctx, "sync headers", func() error {
+			return r.dst.SyncHeaders(ctx, r.src.ID(), list)
+		}); err != nil {
+			return err
+		}
+		log.Info("Synced epoch headers", "route", r.name, "count", len(list), "last", list[len(list)-1].Number)
+		r.headersCounter.Inc(int64(len(list)))
+		last := batch[len(batch)-1].pos
+		batch = batch[:0]
+		return r.commit(&last, r.cursor.Next)
+	}
+	for _, it := range items {
+		if r.cursor.Last != nil && !r.cursor.Last.less(it.pos) {
+			continue
+		}
+		if it.header != nil {
+			if batch = append(batch, it); len(batch) >= r.cfg.Batch {
+				if err := flush(); err != nil {
+					return false, err
+				}
+			}
+			continue
+		}
+		if err := flush(); err != nil {
+			return false, err
+		}
+		if err := r.relay(ctx, it.tx); err != nil {
+			return false, err
+		}
+		pos := it.pos
+		if err := r.commit(&pos, r.cursor.Next); err != nil {
+			return false, err
+		}
+	}
+	if err := flush(); err != nil {
+		return false, err
+	}
+	if err := r.commit(r.cursor.Last, to+1); err != nil {
+		return false, err
+	}
+	r.heightGauge.Update(int64(to))
+	return to == latest, nil
+}
+
+// relay proves the cross chain transaction and delivers it to the target chain.
+func (r *route) relay(ctx context.Context, tx *crossTx) error {
+	var proof *crossProof
+	if err := r.retry(ctx, "prove transaction", func() (err error) {
+		proof, err = r.src.Proof(ctx, tx)
+		return err
+	}); err != nil {
+		return err
+	}
+	if err := r.retry(ctx, "deliver transaction", func() error {
+		return r.dst.Deliver(ctx, r.src.ID(), tx, proof)
+	}); err != nil {
+		return err
+	}
+	log.Info("Relayed cross chain transaction", "route", r.name, "tx", tx.TxHash, "height", tx.Height)
+	r.txsCounter.Inc(1)
+	return nil
+}
+
+// commit persists the relaying progress.
+func (r *route) commit(last *position, next uint64) error {
+	r.cursor.Last, r.cursor.Next = last, next
+	return r.store.put(r.name, r.cursor)
+}
+
+// retry calls fn until it succeeds or the attempts are used up, waiting with an
+// exponential backoff between the attempts.
+func (r *route) retry(ctx context.Context, what string, fn func() error) error {
+	backoff := r.cfg.Backoff
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+		if attempt >= r.cfg.Retries {
+			return fmt.Errorf("failed to %s after %d attempts: %v", what, attempt, err)
+		}
+		log.Debug("Retrying", "route", r.name, "action", what, "attempt", attempt, "backoff", backoff, "err", err)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if backoff *= 2; backoff > r.cfg.MaxBackoff {
+			backoff = r.cfg.MaxBackoff
+		}
+	}
+}
+
+// relayer relays the transfers between the main chain and a side chain in both
+// directions.
+type relayer struct {
+	routes []*route
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newRelayer(main, side interface {
+	source
+	target
+}, cfg *config) (*relayer, error) {
+	if cfg.Batch <= 0 || cfg.Range == 0 || cfg.Retries <= 0 {
+		return nil, errors.New("invalid relayer config")
+	}
+	store, err := newCursorStore(cfg.CursorsFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load cursors: %v", err)
+	}
+	return &relayer{
+		routes: []*route{
+			newRoute(fmt.Sprintf("%d-%d", main.ID(), side.ID()), main, side, cfg.MainStart, cfg, store),
+			newRoute(fmt.Sprintf("%d-%d", side.ID(), main.ID()), side, main, cfg.SideStart, cfg, store),
+		},
+	}, nil
+}
+
+// start launches the routes in background.
+func (r *relayer) start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	for _, rt := range r.routes {
+		r.wg.Add(1)
+		go func(rt *route) {
+			defer r.wg.Done()
+			rt.loop(ctx)
+		}(rt)
+	}
+}
+
+// stop terminates the routes and waits for them to exit.
+func (r *relayer) stop() {
+	r.cancel()
+	r.wg.Wait()
+}
@@ -0,0 +1,327 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+)
+
+var errInjected = errors.New("injected failure")
+
+// testChain is an in-process chain, which makes cross chain transactions as a source,
+// and verifies the counterpart chain as a target in the way header sync and the cross
+// chain manager do: epoch headers are synced in order, and a transaction is accepted
+// only once and only by the validators of its own epoch.
+type testChain struct {
+	id   uint64
+	peer *testChain
+	lock sync.Mutex
+
+	height uint64
+	epochs []*types.Header
+	txs    []*crossTx
+
+	synced    []*types.Header
+	batches   []int
+	delivered []*crossTx
+	failures  int // Number of the following submissions to fail, -1 fails all
+}
+
+func newTestChain(id uint64) *testChain {
+	return &testChain{id: id}
+}
+
+// grow extends the chain to the height with epoch changes and cross chain transactions.
+func (c *testChain) grow(height uint64, epochs []uint64, txs []uint64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.height = height
+	for _, number := range epochs {
+		c.epochs = append(c.epochs, &types.Header{Number: new(big.Int).SetUint64(number)})
+	}
+	for _, number := range txs {
+		index := uint64(len(c.txs))
+		c.txs = append(c.txs, &crossTx{
+			Height:  number,
+			Index:   index,
+			TxHash:  common.BytesToHash([]byte(fmt.Sprintf("%d-%d", c.id, index))),
+			ToChain: c.peer.id,
+			Raw:     []byte{byte(index)},
+		})
+	}
+}
+
+func (c *testChain) setFailures(n int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.failures = n
+}
+
+func (c *testChain) fail() error {
+	if c.failures == 0 {
+		return nil
+	}
+	if c.failures > 0 {
+		c.failures--
+	}
+	return errInjected
+}
+
+func (c *testChain) ID() uint64 { return c.id }
+
+func (c *testChain) LatestHeight(ctx context.Context) (uint64, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.height, nil
+}
+
+func (c *testChain) EpochHeaders(ctx context.Context, from, to uint64) ([]*types.Header, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	var headers []*types.Header
+	for _, header := range c.epochs {
+		if number := header.Number.Uint64(); number >= from && number <= to {
+			headers = append(headers, header)
+		}
+	}
+	return headers, nil
+}
+
+func (c *testChain) CrossChainTxs(ctx context.Context, from, to, dst uint64) ([]*crossTx, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	var txs []*crossTx
+	for _, tx := range c.txs {
+		if tx.Height >= from && tx.Height <= to && tx.ToChain == dst {
+			txs = append(txs, tx)
+		}
+	}
+	return txs, nil
+}
+
+func (c *testChain) Proof(ctx context.Context, tx *crossTx) (*crossProof, error) {
+	return &crossProof{
+		Header:  &types.Header{Number: new(big.Int).SetUint64(tx.Height)},
+		Account: new(ethapi.AccountResult),
+		Extra:   tx.Raw,
+	}, nil
+}
+
+func (c *testChain) SyncHeaders(ctx context.Context, src uint64, headers []*types.Header) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err := c.fail(); err != nil {
+		return err
+	}
+	if src != c.peer.id {
+		return fmt.Errorf("unexpected source chain %d", src)
+	}
+	for i, header := range headers {
+		next := len(c.synced) + i
+		if next >= len(c.peer.epochs) || c.peer.epochs[next] != header {
+			return fmt.Errorf("epoch header %d out of order", header.Number)
+		}
+	}
+	c.synced = append(c.synced, headers...)
+	c.batches = append(c.batches, len(headers))
+	return nil
+}
+
+func (c *testChain) Deliver(ctx context.Context, src uint64, tx *crossTx, proof *crossProof) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err := c.fail(); err != nil {
+		return err
+	}
+	for _, done := range c.delivered {
+		if done.TxHash == tx.TxHash {
+			return errors.New("tx already done")
+		}
+	}
+	// the header must be at or above the current epoch start, and the epoch it belongs
+	// to must have been synced
+	height := proof.Header.Number.Uint64()
+	if n := len(c.synced); n > 0 && height < c.synced[n-1].Number.Uint64()+1 {
+		return fmt.Errorf("height %d is less than epoch start", height)
+	}
+	for _, header := range c.peer.epochs[len(c.synced):] {
+		if header.Number.Uint64() < height {
+			return fmt.Errorf("epoch header %d not synced", header.Number)
+		}
+	}
+	c.delivered = append(c.delivered, tx)
+	return nil
+}
+
+// counts returns the number of synced headers and delivered transactions.
+func (c *testChain) counts() (int, int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return len(c.synced), len(c.delivered)
+}
+
+func newTestChains() (*testChain, *testChain) {
+	main, side := newTestChain(1), newTestChain(77)
+	main.peer, side.peer = side, main
+	return main, side
+}
+
+func newTestConfig(t *testing.T) *config {
+	return &config{
+		Batch:       2,
+		Range:       7,
+		Interval:    5 * time.Millisecond,
+		Retries:     3,
+		Backoff:     time.Millisecond,
+		MaxBackoff:  4 * time.Millisecond,
+		CursorsFile: filepath.Join(t.TempDir(), "cursors.json"),
+	}
+}
+
+// waitRelayed waits until the chains have synced and delivered the expected items.
+func waitRelayed(t *testing.T, chain *testChain, headers, txs int) {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if h, n := chain.counts(); h == headers && n == txs {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	h, n := chain.counts()
+	t.Fatalf("chain %d: relayed %d headers and %d txs, want %d and %d", chain.id, h, n, headers, txs)
+}
+
+func TestRelay(t *testing.T) {
+	main, side := newTestChains()
+	// a transaction at the epoch change height is still verified by the old epoch
+	main.grow(40, []uint64{10, 20, 21, 30}, []uint64{5, 10, 15, 25, 25, 33})
+	side.grow(25, []uint64{12, 13, 14}, []uint64{3, 14, 20})
+
+	r, err := newRelayer(main, side, newTestConfig(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.start()
+	defer r.stop()
+
+	waitRelayed(t, side, 4, 6)
+	waitRelayed(t, main, 3, 3)
+	for _, batch := range side.batches {
+		if batch > 2 {
+			t.Errorf("batch size %d exceeds limit", batch)
+		}
+	}
+	for i, tx := range side.delivered {
+		if tx != main.txs[i] {
+			t.Errorf("tx %d delivered out of order", i)
+		}
+	}
+
+	// new blocks are relayed after catching up
+	side.grow(50, []uint64{40}, []uint64{45})
+	waitRelayed(t, main, 4, 4)
+}
+
+func TestRelayResume(t *testing.T) {
+	main, side := newTestChains()
+	main.grow(12, []uint64{10}, []uint64{5, 10})
+	cfg := newTestConfig(t)
+
+	// the relayer gets stuck once the side chain rejects all the submissions
+	side.setFailures(-1)
+	r, err := newRelayer(main, side, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.start()
+	time.Sleep(50 * time.Millisecond)
+	r.stop()
+	if h, n := side.counts(); h != 0 || n != 0 {
+		t.Fatalf("relayed %d headers and %d txs with failing target", h, n)
+	}
+
+	// relay the items so far, then restart from the persisted cursor
+	side.setFailures(0)
+	r, err = newRelayer(main, side, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.start()
+	waitRelayed(t, side, 1, 2)
+	r.stop()
+
+	main.grow(40, []uint64{20, 35}, []uint64{15, 25, 38})
+	r, err = newRelayer(main, side, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.start()
+	defer r.stop()
+
+	// nothing is delivered twice, which is rejected by the target
+	waitRelayed(t, side, 3, 5)
+}
+
+func TestRelayRetry(t *testing.T) {
+	main, side := newTestChains()
+	main.grow(10, []uint64{5}, []uint64{3, 8})
+
+	cfg := newTestConfig(t)
+	cfg.Range = 100
+	store, err := newCursorStore(cfg.CursorsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rt := newRoute("test", main, side, 0, cfg, store)
+
+	// transient failures are retried with backoff in the same round
+	side.setFailures(cfg.Retries - 1)
+	if caughtUp, err := rt.step(context.Background()); err != nil || !caughtUp {
+		t.Fatalf("step failed: caught up %v, err %v", caughtUp, err)
+	}
+	if h, n := side.counts(); h != 1 || n != 2 {
+		t.Fatalf("relayed %d headers and %d txs, want 1 and 2", h, n)
+	}
+
+	// the round fails once the attempts are used up, and the cursor stays put
+	main.grow(20, nil, []uint64{15})
+	side.setFailures(cfg.Retries)
+	if _, err := rt.step(context.Background()); err == nil {
+		t.Fatal("step should fail after retries")
+	}
+	if c := store.get("test", 0); c.Next != 11 || c.Last == nil || c.Last.Height != 8 {
+		t.Fatalf("cursor moved after failure: %+v", c)
+	}
+}
@@ -0,0 +1,340 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/hotstuff/tool"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/params"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var (
+	nodesFlag = cli.IntFlag{
+		Name:  "nodes",
+		Usage: "Number of validators",
+		Value: 4,
+	}
+	roleFlag = cli.StringFlag{
+		Name:  "role",
+		Usage: "Role of the chain in zion cross chain network (main, side)",
+		Value: params.ZionRoleMain,
+	}
+	chainIDFlag = cli.Uint64Flag{
+		Name:  "chainid",
+		Usage: "EIP155 chain id, also used as the network id",
+		Value: 60801,
+	}
+	crossChainIDFlag = cli.Uint64Flag{
+		Name:  "crosschainid",
+		Usage: "Chain id in cross chain protocol (default = chain id)",
+	}
+	relayChainIDFlag = cli.Uint64Flag{
+		Name:  "relaychainid",
+		Usage: "Cross chain id of the relay chain, required by side chain",
+	}
+	hostFlag = cli.StringFlag{
+		Name:  "host",
+		Usage: "IP address of validators in static nodes",
+		Value: "127.0.0.1",
+	}
+	portFlag = cli.IntFlag{
+		Name:  "port",
+		Usage: "P2P listening port of the first validator, increased by 1 for others",
+		Value: 30300,
+	}
+	balanceFlag = cli.StringFlag{
+		Name:  "balance",
+		Usage: "Genesis balance of each validator in wei",
+		Value: "100000000000000000000000000",
+	}
+	outFlag = cli.StringFlag{
+		Name:  "out",
+		Usage: "Output directory of the genesis, node list and validator datadirs",
+		Value: "zionnet",
+	}
+)
+
+var bootstrapCommand = cli.Command{
+	Name:   "bootstrap",
+	Usage:  "Generate validator keys, genesis and datadirs of a new network",
+	Action: bootstrap,
+	Flags: []cli.Flag{
+		nodesFlag,
+		roleFlag,
+		chainIDFlag,
+		crossChainIDFlag,
+		relayChainIDFlag,
+		hostFlag,
+		portFlag,
+		balanceFlag,
+		outFlag,
+	},
+	Description: `
+Bootstrap generates N validators and writes
+
+    <out>/genesis.json          genesis with the HotStuff extra and validator public keys
+    <out>/nodes.json            validator addresses, keys and enode urls
+    <out>/node<i>/geth/nodekey  validator key, used by HotStuff to seal blocks
+    <out>/node<i>/geth/static-nodes.json
+
+and initializes the chain database of each datadir with the genesis.`,
+}
+
+// networkConfig denotes the parameters of the generated network.
+type networkConfig struct {
+	Nodes   int
+	Zion    *params.ZionConfig
+	ChainID uint64
+	Host    net.IP
+	Port    int
+	Balance *big.Int
+}
+
+// validator describes the generated validator in nodes.json
+type validator struct {
+	Address   common.Address `json:"address"`
+	NodeKey   string         `json:"nodeKey"`
+	PublicKey string         `json:"publicKey"`
+	Static    string         `json:"static"`
+	Port      int            `json:"port"`
+	Datadir   string         `json:"datadir"`
+
+	key *ecdsa.PrivateKey
+}
+
+type network struct {
+	Genesis    *core.Genesis
+	Validators []*validator
+}
+
+func bootstrap(ctx *cli.Context) error {
+	config := &networkConfig{
+		Nodes:   ctx.Int(nodesFlag.Name),
+		ChainID: ctx.Uint64(chainIDFlag.Name),
+		Host:    net.ParseIP(ctx.String(hostFlag.Name)),
+		Port:    ctx.Int(portFlag.Name),
+		Zion: &params.ZionConfig{
+			Role:         ctx.String(roleFlag.Name),
+			CrossChainID: ctx.Uint64(crossChainIDFlag.Name),
+			RelayChainID: ctx.Uint64(relayChainIDFlag.Name),
+		},
+	}
+	if config.Host == nil {
+		return fmt.Errorf("invalid host %q", ctx.String(hostFlag.Name))
+	}
+	balance, ok := new(big.Int).SetString(ctx.String(balanceFlag.Name), 10)
+	if !ok {
+		return fmt.Errorf("invalid balance %q", ctx.String(balanceFlag.Name))
+	}
+	config.Balance = balance
+
+	nw, err := makeNetwork(config)
+	if err != nil {
+		return err
+	}
+	if _, err := verifyGenesis(nw.Genesis); err != nil {
+		return fmt.Errorf("generated genesis invalid: %v", err)
+	}
+	out := ctx.String(outFlag.Name)
+	if err := nw.write(out); err != nil {
+		return err
+	}
+	for _, v := range nw.Validators {
+		if err := initDatadir(v.Datadir, nw.Genesis); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("Generated %d validators of %s chain %d in %s\n", len(nw.Validators), config.Zion.Role, config.ChainID, out)
+	for i, v := range nw.Validators {
+		fmt.Printf("node%d  %s  geth --datadir %s --networkid %d --port %d --mine\n", i, v.Address.Hex(), v.Datadir, config.ChainID, v.Port)
+	}
+	return nil
+}
+
+// makeNetwork generates validator keys and the genesis which stores the validators
+// both in HotStuff extra and node manager genesis epoch.
+func makeNetwork(config *networkConfig) (*network, error) {
+	if config.Nodes <= 0 {
+		return nil, fmt.Errorf("validators number should be positive")
+	}
+	zion := *config.Zion
+	if zion.CrossChainID == 0 {
+		zion.CrossChainID = config.ChainID
+	}
+	if zion.Role == params.ZionRoleMain && zion.RelayChainID == 0 {
+		zion.RelayChainID = zion.CrossChainID
+	}
+	if err := zion.Validate(); err != nil {
+		return nil, err
+	}
+
+	var (
+		nodes      = make([]*tool.Node, config.Nodes)
+		validators = make(map[common.Address]*validator)
+	)
+	for i := 0; i < config.Nodes; i++ {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			return nil, err
+		}
+		v := &validator{
+			Address:   crypto.PubkeyToAddress(key.PublicKey),
+			NodeKey:   hexutil.Encode(crypto.FromECDSA(key)),
+			PublicKey: hexutil.Encode(crypto.CompressPubkey(&key.PublicKey)),
+			key:       key,
+		}
+		validators[v.Address] = v
+		nodes[i] = &tool.Node{Address: v.Address.Hex(), NodeKey: v.NodeKey}
+	}
+
+	// validators are sorted in the same order with HotStuff validator set
+	nodes = tool.SortNodes(nodes)
+	extra, err := tool.Encode(tool.NodesAddress(nodes))
+	if err != nil {
+		return nil, err
+	}
+
+	nw := &network{
+		Genesis: &core.Genesis{
+			Config:     chainConfig(config.ChainID, &zion),
+			Timestamp:  uint64(time.Now().Unix()),
+			ExtraData:  hexutil.MustDecode(extra),
+			GasLimit:   params.GenesisGasLimit,
+			Difficulty: big.NewInt(1),
+			Mixhash:    types.HotstuffDigest,
+			Alloc:      make(core.GenesisAlloc),
+		},
+	}
+	for i, node := range nodes {
+		v := validators[common.HexToAddress(node.Address)]
+		v.Port = config.Port + i
+		v.Static = enode.NewV4(&v.key.PublicKey, config.Host, v.Port, 0).URLv4()
+		v.Datadir = fmt.Sprintf("node%d", i)
+		nw.Validators = append(nw.Validators, v)
+
+		nw.Genesis.Alloc[v.Address] = core.GenesisAccount{
+			Balance:   new(big.Int).Set(config.Balance),
+			PublicKey: crypto.CompressPubkey(&v.key.PublicKey),
+		}
+	}
+	return nw, nil
+}
+
+// chainConfig enables all ethereum forks at genesis and the HotStuff engine.
+func chainConfig(chainID uint64, zion *params.ZionConfig) *params.ChainConfig {
+	return &params.ChainConfig{
+		ChainID:             new(big.Int).SetUint64(chainID),
+		HomesteadBlock:      big.NewInt(0),
+		EIP150Block:         big.NewInt(0),
+		EIP155Block:         big.NewInt(0),
+		EIP158Block:         big.NewInt(0),
+		ByzantiumBlock:      big.NewInt(0),
+		ConstantinopleBlock: big.NewInt(0),
+		PetersburgBlock:     big.NewInt(0),
+		IstanbulBlock:       big.NewInt(0),
+		MuirGlacierBlock:    big.NewInt(0),
+		BerlinBlock:         big.NewInt(0),
+		LondonBlock:         big.NewInt(0),
+		HotStuff:            &params.HotStuffConfig{Protocol: "basic"},
+		Zion:                zion,
+	}
+}
+
+// write stores the genesis, node list, node keys and static nodes into directory,
+// the datadir of validators are changed to the path under the directory.
+func (nw *network) write(dir string) error {
+	if files, err := ioutil.ReadDir(dir); err == nil && len(files) > 0 {
+		return fmt.Errorf("output directory %s is not empty", dir)
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	statics := make([]string, len(nw.Validators))
+	for i, v := range nw.Validators {
+		statics[i] = v.Static
+		v.Datadir = filepath.Join(dir, v.Datadir)
+	}
+
+	if err := writeJSON(filepath.Join(dir, "genesis.json"), nw.Genesis); err != nil {
+		return err
+	}
+	if err := writeJSON(filepath.Join(dir, "nodes.json"), nw.Validators); err != nil {
+		return err
+	}
+	for _, v := range nw.Validators {
+		instance := filepath.Join(v.Datadir, "geth")
+		if err := os.MkdirAll(instance, 0700); err != nil {
+			return err
+		}
+		if err := crypto.SaveECDSA(filepath.Join(instance, "nodekey"), v.key); err != nil {
+			return err
+		}
+		peers := make([]string, 0, len(statics)-1)
+		for _, static := range statics {
+			if static != v.Static {
+				peers = append(peers, static)
+			}
+		}
+		if err := writeJSON(filepath.Join(instance, "static-nodes.json"), peers); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// initDatadir writes the genesis into chain database just like `geth init`.
+func initDatadir(datadir string, genesis *core.Genesis) error {
+	chaindata := filepath.Join(datadir, "geth", "chaindata")
+	db, err := rawdb.NewLevelDBDatabaseWithFreezer(chaindata, 0, 0, filepath.Join(chaindata, "ancient"), "", false)
+	if err != nil {
+		return fmt.Errorf("failed to open database %s: %v", chaindata, err)
+	}
+	defer db.Close()
+
+	if _, _, err := core.SetupGenesisBlock(db, genesis); err != nil {
+		return fmt.Errorf("failed to write genesis into %s: %v", datadir, err)
+	}
+	return nil
+}
+
+func writeJSON(file string, v interface{}) error {
+	blob, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, blob, 0600)
+}
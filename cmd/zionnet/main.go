@@ -0,0 +1,79 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+// zionnet bootstraps Zion HotStuff networks.
+//
+// The bootstrap command generates validator keys, a genesis carrying the HotStuff
+// extra and the validator public keys, static nodes and initialized datadirs, e.g.
+//
+//     $ zionnet bootstrap --nodes 4 --role main --chainid 60801 --out ./testnet
+//     $ geth --datadir ./testnet/node0 --networkid 60801 --port 30300 --mine
+//
+// The verify command checks that a hand written genesis is accepted by the node
+// manager and the genesis epoch matches the HotStuff validators, e.g.
+//
+//     $ zionnet verify ./testnet/genesis.json
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/internal/flags"
+	"github.com/ethereum/go-ethereum/log"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var (
+	// Git SHA1 commit hash of the release (set via linker flags)
+	gitCommit = ""
+	gitDate   = ""
+)
+
+var (
+	verbosityFlag = cli.IntFlag{
+		Name:  "verbosity",
+		Usage: "Logging verbosity: 0=silent, 1=error, 2=warn, 3=info, 4=debug, 5=detail",
+		Value: int(log.LvlWarn),
+	}
+)
+
+var app = flags.NewApp(gitCommit, gitDate, "Zion HotStuff network bootstrap tool")
+
+func init() {
+	app.Flags = []cli.Flag{
+		verbosityFlag,
+	}
+	app.Before = func(ctx *cli.Context) error {
+		glogger := log.NewGlogHandler(log.StreamHandler(os.Stderr, log.TerminalFormat(false)))
+		glogger.Verbosity(log.Lvl(ctx.GlobalInt(verbosityFlag.Name)))
+		log.Root().SetHandler(glogger)
+		return nil
+	}
+	app.Commands = []cli.Command{
+		bootstrapCommand,
+		verifyCommand,
+	}
+}
+
+func main() {
+	if err := app.Run(os.Args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,172 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/contracts/native"
+	"github.com/ethereum/go-ethereum/contracts/native/governance/node_manager"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var verifyCommand = cli.Command{
+	Name:      "verify",
+	Usage:     "Verify that the genesis validators are accepted by node manager",
+	ArgsUsage: "<genesis.json>",
+	Action:    verify,
+	Description: `
+Verify checks the HotStuff config, the zion chain role and that the validators in
+genesis extra are exactly the alloc accounts, each carrying the compressed public
+key of the address. It then builds the genesis state and compares the genesis
+epoch stored by node manager with the HotStuff validators.`,
+}
+
+// genesisInfo is the genesis state which node will start with.
+type genesisInfo struct {
+	Hash       common.Hash
+	Zion       string
+	Validators []common.Address
+	Epoch      *node_manager.EpochInfo
+}
+
+func verify(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return fmt.Errorf("genesis file required")
+	}
+	file, err := os.Open(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	genesis := new(core.Genesis)
+	if err := json.NewDecoder(file).Decode(genesis); err != nil {
+		return fmt.Errorf("invalid genesis file: %v", err)
+	}
+	info, err := verifyGenesis(genesis)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Genesis hash: %s\n", info.Hash.Hex())
+	fmt.Printf("Zion chain:   %s\n", info.Zion)
+	fmt.Printf("Epoch %d validators:\n", info.Epoch.ID)
+	for _, peer := range info.Epoch.Peers.List {
+		fmt.Printf("  %s  %s\n", peer.Address.Hex(), peer.PubKey)
+	}
+	return nil
+}
+
+// verifyGenesis checks that the genesis validators stored by `core.RegGenesis` in
+// node manager are the same with validators in the HotStuff extra.
+func verifyGenesis(genesis *core.Genesis) (*genesisInfo, error) {
+	config := genesis.Config
+	if config == nil {
+		return nil, fmt.Errorf("genesis has no chain configuration")
+	}
+	if config.HotStuff == nil {
+		return nil, fmt.Errorf("hotstuff engine not configured")
+	}
+	zion := config.ZionConfig()
+	if err := zion.Validate(); err != nil {
+		return nil, err
+	}
+	if _, err := native.EnabledContracts(zion); err != nil {
+		return nil, err
+	}
+	if genesis.Mixhash != types.HotstuffDigest {
+		return nil, fmt.Errorf("mix hash should be %s, got %s", types.HotstuffDigest.Hex(), genesis.Mixhash.Hex())
+	}
+
+	extra, err := types.ExtractHotstuffExtraPayload(genesis.ExtraData)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hotstuff extra: %v", err)
+	}
+	if len(extra.Validators) == 0 {
+		return nil, fmt.Errorf("no validators in hotstuff extra")
+	}
+	validators := make(map[common.Address]struct{})
+	for _, v := range extra.Validators {
+		if _, ok := validators[v]; ok {
+			return nil, fmt.Errorf("duplicate validator %s in hotstuff extra", v.Hex())
+		}
+		validators[v] = struct{}{}
+	}
+
+	// node manager treats all alloc accounts as genesis validators
+	for addr, account := range genesis.Alloc {
+		if len(account.PublicKey) == 0 {
+			return nil, fmt.Errorf("alloc account %s has no public key", addr.Hex())
+		}
+		pubkey, err := crypto.DecompressPubkey(account.PublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("alloc account %s public key invalid: %v", addr.Hex(), err)
+		}
+		if got := crypto.PubkeyToAddress(*pubkey); got != addr {
+			return nil, fmt.Errorf("alloc account %s public key mismatch, got address %s", addr.Hex(), got.Hex())
+		}
+		if _, ok := validators[addr]; !ok {
+			return nil, fmt.Errorf("alloc account %s is not hotstuff validator", addr.Hex())
+		}
+	}
+	for _, v := range extra.Validators {
+		if _, ok := genesis.Alloc[v]; !ok {
+			return nil, fmt.Errorf("hotstuff validator %s not in alloc", v.Hex())
+		}
+	}
+
+	// build the genesis state and read the genesis epoch back
+	db := rawdb.NewMemoryDatabase()
+	block := genesis.ToBlock(db)
+	statedb, err := state.New(block.Root(), state.NewDatabase(db), nil)
+	if err != nil {
+		return nil, err
+	}
+	epoch, err := node_manager.GetEpochByHeight(statedb, 0)
+	if err != nil {
+		return nil, fmt.Errorf("genesis epoch not stored by node manager: %v", err)
+	}
+	if epoch.Peers == nil || len(epoch.Peers.List) != len(extra.Validators) {
+		return nil, fmt.Errorf("genesis epoch peers number mismatch")
+	}
+	for _, peer := range epoch.Peers.List {
+		if _, ok := validators[peer.Address]; !ok {
+			return nil, fmt.Errorf("genesis epoch peer %s is not hotstuff validator", peer.Address.Hex())
+		}
+		if peer.PubKey != hexutil.Encode(genesis.Alloc[peer.Address].PublicKey) {
+			return nil, fmt.Errorf("genesis epoch peer %s public key mismatch", peer.Address.Hex())
+		}
+	}
+
+	return &genesisInfo{
+		Hash:       block.Hash(),
+		Zion:       fmt.Sprintf("%s, cross chain id %d, relay chain id %d", zion.Role, zion.CrossChainID, zion.RelayChainID),
+		Validators: extra.Validators,
+		Epoch:      epoch,
+	}, nil
+}
@@ -0,0 +1,170 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func testNetworkConfig(zion *params.ZionConfig) *networkConfig {
+	return &networkConfig{
+		Nodes:   4,
+		Zion:    zion,
+		ChainID: 60801,
+		Host:    net.ParseIP("127.0.0.1"),
+		Port:    30300,
+		Balance: big.NewInt(1e18),
+	}
+}
+
+func TestBootstrap(t *testing.T) {
+	for _, zion := range []*params.ZionConfig{
+		{Role: params.ZionRoleMain},
+		{Role: params.ZionRoleSide, RelayChainID: 60800},
+	} {
+		nw, err := makeNetwork(testNetworkConfig(zion))
+		if err != nil {
+			t.Fatalf("%s: failed to make network: %v", zion.Role, err)
+		}
+		dir := t.TempDir()
+		if err := nw.write(dir); err != nil {
+			t.Fatalf("%s: failed to write network: %v", zion.Role, err)
+		}
+		if err := nw.write(dir); err == nil {
+			t.Fatalf("%s: expect error on non-empty directory", zion.Role)
+		}
+
+		// genesis loaded from file should be accepted by node manager
+		blob, err := ioutil.ReadFile(filepath.Join(dir, "genesis.json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		genesis := new(core.Genesis)
+		if err := json.Unmarshal(blob, genesis); err != nil {
+			t.Fatalf("%s: invalid genesis json: %v", zion.Role, err)
+		}
+		info, err := verifyGenesis(genesis)
+		if err != nil {
+			t.Fatalf("%s: verify genesis failed: %v", zion.Role, err)
+		}
+		if len(info.Epoch.Peers.List) != 4 {
+			t.Fatalf("%s: genesis epoch peers mismatch, have %d, want 4", zion.Role, len(info.Epoch.Peers.List))
+		}
+		if cfg := genesis.Config.ZionConfig(); cfg.Role != zion.Role || cfg.CrossChainID != 60801 {
+			t.Fatalf("%s: zion config mismatch: %+v", zion.Role, cfg)
+		}
+
+		for _, v := range nw.Validators {
+			key, err := crypto.LoadECDSA(filepath.Join(v.Datadir, "geth", "nodekey"))
+			if err != nil {
+				t.Fatalf("%s: failed to load node key: %v", zion.Role, err)
+			}
+			if crypto.PubkeyToAddress(key.PublicKey) != v.Address {
+				t.Fatalf("%s: node key mismatch", zion.Role)
+			}
+			var statics []string
+			blob, err := ioutil.ReadFile(filepath.Join(v.Datadir, "geth", "static-nodes.json"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal(blob, &statics); err != nil || len(statics) != 3 {
+				t.Fatalf("%s: static nodes invalid: %v %v", zion.Role, statics, err)
+			}
+
+			// datadir initialized with the same genesis
+			if err := initDatadir(v.Datadir, genesis); err != nil {
+				t.Fatalf("%s: failed to init datadir: %v", zion.Role, err)
+			}
+			chaindata := filepath.Join(v.Datadir, "geth", "chaindata")
+			db, err := rawdb.NewLevelDBDatabaseWithFreezer(chaindata, 0, 0, filepath.Join(chaindata, "ancient"), "", false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if hash := rawdb.ReadCanonicalHash(db, 0); hash != info.Hash {
+				t.Fatalf("%s: genesis hash mismatch, have %s, want %s", zion.Role, hash.Hex(), info.Hash.Hex())
+			}
+			db.Close()
+		}
+	}
+
+	if _, err := makeNetwork(testNetworkConfig(&params.ZionConfig{Role: params.ZionRoleSide})); err == nil {
+		t.Fatalf("expect error on side chain without relay chain id")
+	}
+}
+
+func TestVerifyGenesis(t *testing.T) {
+	nw, err := makeNetwork(testNetworkConfig(&params.ZionConfig{Role: params.ZionRoleMain}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, second := nw.Validators[0].Address, nw.Validators[1].Address
+	other, _ := crypto.GenerateKey()
+
+	tests := []struct {
+		name   string
+		modify func(genesis *core.Genesis)
+	}{
+		{"no hotstuff", func(g *core.Genesis) { g.Config.HotStuff = nil }},
+		{"invalid role", func(g *core.Genesis) { g.Config.Zion.Role = "relay" }},
+		{"mix hash", func(g *core.Genesis) { g.Mixhash = common.Hash{} }},
+		{"invalid extra", func(g *core.Genesis) { g.ExtraData = []byte{0x01} }},
+		{"missing public key", func(g *core.Genesis) {
+			account := g.Alloc[first]
+			account.PublicKey = nil
+			g.Alloc[first] = account
+		}},
+		{"mismatch public key", func(g *core.Genesis) {
+			account := g.Alloc[first]
+			account.PublicKey = g.Alloc[second].PublicKey
+			g.Alloc[first] = account
+		}},
+		{"validator not in alloc", func(g *core.Genesis) { delete(g.Alloc, first) }},
+		{"alloc not validator", func(g *core.Genesis) {
+			g.Alloc[crypto.PubkeyToAddress(other.PublicKey)] = core.GenesisAccount{
+				Balance:   big.NewInt(1),
+				PublicKey: crypto.CompressPubkey(&other.PublicKey),
+			}
+		}},
+	}
+	for _, test := range tests {
+		blob, err := json.Marshal(nw.Genesis)
+		if err != nil {
+			t.Fatal(err)
+		}
+		genesis := new(core.Genesis)
+		if err := json.Unmarshal(blob, genesis); err != nil {
+			t.Fatal(err)
+		}
+		test.modify(genesis)
+		if _, err := verifyGenesis(genesis); err == nil {
+			t.Errorf("%s: expect verify error", test.name)
+		}
+	}
+}
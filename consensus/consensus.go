@@ -170,6 +170,37 @@ type Handler interface {
 	SetBroadcaster(Broadcaster)
 }
 
+// Validator should be implemented if the consensus signs with a validator key which
+// may be proved to peers, e.g. in the `hotstuff` protocol handshake
+type Validator interface {
+	// Address returns the validator address of local node
+	Address() common.Address
+
+	// SignChallenge signs the handshake challenge with the validator key
+	SignChallenge(data []byte) ([]byte, error)
+
+	// CurrentValidators returns the validators of the current epoch
+	CurrentValidators() []common.Address
+}
+
+// EpochVerifier should be implemented if the validators of the consensus only change
+// at epoch change headers, so the validators of the chain head can be proved by the
+// epoch change headers without processing the blocks between them
+type EpochVerifier interface {
+	// EpochChanges retrieves at most limit local epoch change headers, starting at
+	// the given block number
+	EpochChanges(chain ChainHeaderReader, from uint64, limit int) []*types.Header
+
+	// VerifyEpochChange verifies the epoch change header against the quorum of the
+	// validators before it, and applies the validators of the next epoch. Headers
+	// should be verified in ascending order
+	VerifyEpochChange(header *types.Header) error
+
+	// VerifyCommitted verifies the committed seals of the header against the quorum
+	// of the validators known at its height
+	VerifyCommitted(header *types.Header) error
+}
+
 // PoW is a consensus engine based on proof-of-work.
 type PoW interface {
 	Engine
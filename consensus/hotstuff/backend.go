@@ -92,6 +92,9 @@ type CoreEngine interface {
 	// pending request is populated right at the request stage so this would give us the earliest verification
 	// to avoid any race condition of coming propagated blocks
 	IsCurrentProposal(blockHash common.Hash) bool
+
+	// Stats returns a snapshot of the consensus progress and validators participation
+	Stats() *Stats
 }
 
 type HotstuffProtocol string
@@ -21,6 +21,7 @@ package backend
 import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/hotstuff"
 )
 
 // API is a user facing RPC API to allow controlling the address and voting
@@ -60,3 +61,8 @@ func (api *API) Discard(address common.Address) {
 
 	delete(api.hotstuff.proposals, address)
 }
+
+// Stats returns the consensus progress and the participation of current validators.
+func (api *API) Stats() *hotstuff.Stats {
+	return api.hotstuff.Stats()
+}
@@ -61,6 +61,7 @@ type backend struct {
 
 	epochs              map[uint64]*Epoch // map epoch start height to epochs
 	maxEpochStartHeight uint64
+	epochMu             sync.RWMutex // Protects the epochs, which are also read by the p2p goroutines
 
 	// The channels for hotstuff engine notifications
 	sealMu            sync.Mutex
@@ -99,7 +100,7 @@ func New(config *hotstuff.Config, privateKey *ecdsa.PrivateKey, db ethdb.Databas
 		proposals:      make(map[common.Address]bool),
 	}
 
-	backend.core = core.New(backend, config, signer)
+	backend.core = core.New(backend, config, signer, db)
 	if err := backend.LoadEpoch(); err != nil {
 		panic(fmt.Sprintf("load epoch failed, err: %v", err))
 	}
@@ -158,6 +159,8 @@ func (s *backend) Gossip(valSet hotstuff.ValidatorSet, payload []byte) error {
 
 			m.Add(hash, true)
 			s.recentMessages.Add(addr, m)
+			msgOutMeter.Mark(1)
+			msgOutTrafficMeter.Mark(int64(len(payload)))
 			go p.Send(hotstuffMsg, payload)
 		}
 	}
@@ -193,6 +196,8 @@ func (s *backend) Unicast(valSet hotstuff.ValidatorSet, payload []byte) error {
 			}
 			m.Add(hash, true)
 			s.recentMessages.Add(target, m)
+			msgOutMeter.Mark(1)
+			msgOutTrafficMeter.Mark(int64(len(payload)))
 			go func() {
 				if err := p.Send(hotstuffMsg, payload); err != nil {
 					s.logger.Error("unicast message failed", "err", err)
@@ -245,7 +250,8 @@ func (s *backend) Commit(proposal hotstuff.Proposal) error {
 		s.logger.Error("Committed to miner worker", "proposal", "not block")
 		return errInvalidProposal
 	}
-	
+	committedMeter.Mark(1)
+
 	s.logger.Info("Committed", "address", s.Address(), "hash", proposal.Hash(), "number", proposal.Number().Uint64())
 	// - if the proposed and committed blocks are the same, send the proposed hash
 	//   to commit channel, which is being watched inside the engine.Seal() function.
@@ -372,6 +378,11 @@ func (s *backend) GetProposer(number uint64) common.Address {
 	return common.Address{}
 }
 
+// Stats returns a snapshot of consensus progress and validators participation
+func (s *backend) Stats() *hotstuff.Stats {
+	return s.core.Stats()
+}
+
 func (s *backend) HasBadProposal(hash common.Hash) bool {
 	if s.hasBadBlock == nil {
 		return false
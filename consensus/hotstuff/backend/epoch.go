@@ -21,8 +21,11 @@ package backend
 import (
 	"encoding/json"
 	"fmt"
+	"reflect"
+	"sort"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/consensus/hotstuff"
 	"github.com/ethereum/go-ethereum/consensus/hotstuff/validator"
 	"github.com/ethereum/go-ethereum/core"
@@ -48,6 +51,9 @@ func init() {
 }
 
 func (s *backend) Validators(height uint64) hotstuff.ValidatorSet {
+	s.epochMu.RLock()
+	defer s.epochMu.RUnlock()
+
 	startHeight := s.maxEpochStartHeight
 	for height < startHeight {
 		epoch := s.epochs[startHeight]
@@ -61,6 +67,9 @@ func (s *backend) Validators(height uint64) hotstuff.ValidatorSet {
 }
 
 func (s *backend) LoadEpoch() error {
+	s.epochMu.Lock()
+	defer s.epochMu.Unlock()
+
 	if s.epochs == nil {
 		s.epochs = make(map[uint64]*Epoch)
 	}
@@ -89,6 +98,9 @@ func (s *backend) LoadEpoch() error {
 }
 
 func (s *backend) UpdateEpoch(parent, header *types.Header) error {
+	s.epochMu.Lock()
+	defer s.epochMu.Unlock()
+
 	height := header.Number.Uint64()
 	if height <= s.maxEpochStartHeight || height == 1 {
 		return nil
@@ -106,10 +118,80 @@ func (s *backend) UpdateEpoch(parent, header *types.Header) error {
 }
 
 func (s *backend) ChangeEpoch(height uint64, list []common.Address) error {
+	s.epochMu.Lock()
+	defer s.epochMu.Unlock()
+
 	return s.saveEpoch(height, list)
 }
 
+// EpochChanges implements consensus.EpochVerifier.EpochChanges, the epoch change header
+// is the parent of the epoch start block.
+func (s *backend) EpochChanges(chain consensus.ChainHeaderReader, from uint64, limit int) []*types.Header {
+	s.epochMu.RLock()
+	heights := make([]uint64, 0, len(s.epochs))
+	for height := range s.epochs {
+		if height > 1 && height-1 >= from {
+			heights = append(heights, height)
+		}
+	}
+	s.epochMu.RUnlock()
+	sort.Slice(heights, func(i, j int) bool { return heights[i] < heights[j] })
+
+	headers := make([]*types.Header, 0, limit)
+	for _, height := range heights {
+		if len(headers) >= limit {
+			break
+		}
+		header := chain.GetHeaderByNumber(height - 1)
+		if header == nil {
+			break
+		}
+		headers = append(headers, header)
+	}
+	return headers
+}
+
+// VerifyEpochChange implements consensus.EpochVerifier.VerifyEpochChange
+func (s *backend) VerifyEpochChange(header *types.Header) error {
+	number := header.Number.Uint64()
+	if number == 0 {
+		return errUnknownBlock
+	}
+	extra, err := s.signer.VerifyHeader(header, s.Validators(number), true)
+	if err != nil {
+		return err
+	}
+	if len(extra.Validators) == 0 {
+		return errNoEpochChange
+	}
+
+	s.epochMu.Lock()
+	defer s.epochMu.Unlock()
+
+	// the epoch may be known already if a previous sync cycle failed halfway
+	height := number + 1
+	if epoch, ok := s.epochs[height]; ok {
+		if !reflect.DeepEqual(epoch.ValSet.AddressList(), NewDefaultValSet(extra.Validators).AddressList()) {
+			return errMismatchEpoch
+		}
+		return nil
+	}
+	if height < s.maxEpochStartHeight {
+		return errMismatchEpoch
+	}
+	return s.saveEpoch(height, extra.Validators)
+}
+
+// VerifyCommitted implements consensus.EpochVerifier.VerifyCommitted
+func (s *backend) VerifyCommitted(header *types.Header) error {
+	_, err := s.signer.VerifyHeader(header, s.Validators(header.Number.Uint64()), true)
+	return err
+}
+
 func (s *backend) DumpEpochs() string {
+	s.epochMu.RLock()
+	defer s.epochMu.RUnlock()
+
 	str := ""
 	for _, v := range s.epochs {
 		str += v.String() + "\r\n"
@@ -117,6 +199,7 @@ func (s *backend) DumpEpochs() string {
 	return str
 }
 
+// saveEpoch persist the epoch starting at `height`, the caller should hold the epoch lock.
 func (s *backend) saveEpoch(height uint64, list []common.Address) error {
 	if _, ok := s.epochs[height]; ok {
 		return nil
@@ -49,8 +49,12 @@ var (
 	errEmptyCommittedSeals = errors.New("zero committed seals")
 	// errMismatchTxhashes is returned if the TxHash in header is mismatch.
 	errMismatchTxhashes = errors.New("mismatch transactions hashes")
-	// errDecodeFailed is returned if the message can't be decode
-	errDecodeFailed = errors.New("decode p2p message failed")
+	// ErrDecodeFailed is returned if the message can't be decode
+	ErrDecodeFailed = errors.New("decode p2p message failed")
 	// errBadProposal
 	errBADProposal = errors.New("bad proposal")
+	// errNoEpochChange is returned if the header to verify carries no validators of next epoch.
+	errNoEpochChange = errors.New("no epoch change")
+	// errMismatchEpoch is returned if the epoch change header conflicts with the known epochs.
+	errMismatchEpoch = errors.New("mismatch epoch")
 )
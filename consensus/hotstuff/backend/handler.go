@@ -40,7 +40,7 @@ const (
 func (s *backend) decode(msg p2p.Msg) ([]byte, common.Hash, error) {
 	var data []byte
 	if err := msg.Decode(&data); err != nil {
-		return nil, common.Hash{}, errDecodeFailed
+		return nil, common.Hash{}, ErrDecodeFailed
 	}
 
 	return data, hotstuff.RLPHash(data), nil
@@ -57,8 +57,10 @@ func (s *backend) HandleMsg(addr common.Address, msg p2p.Msg) (bool, error) {
 
 		data, hash, err := s.decode(msg)
 		if err != nil {
-			return true, errDecodeFailed
+			return true, ErrDecodeFailed
 		}
+		msgInMeter.Mark(1)
+		msgInTrafficMeter.Mark(int64(msg.Size))
 		// Mark peer's message
 		ms, ok := s.recentMessages.Get(addr)
 		var m *lru.ARCCache
@@ -72,6 +74,7 @@ func (s *backend) HandleMsg(addr common.Address, msg p2p.Msg) (bool, error) {
 
 		// Mark self known message
 		if _, ok := s.knownMessages.Get(hash); ok {
+			msgKnownMeter.Mark(1)
 			return true, nil
 		}
 		s.knownMessages.Add(hash, true)
@@ -124,3 +127,20 @@ func (s *backend) NewChainHead(header *types.Header) error {
 	go s.eventMux.Post(hotstuff.FinalCommittedEvent{Header: header})
 	return nil
 }
+
+// SignChallenge implements consensus.Validator.SignChallenge
+func (s *backend) SignChallenge(data []byte) ([]byte, error) {
+	return s.signer.Sign(data)
+}
+
+// CurrentValidators implements consensus.Validator.CurrentValidators, the validators
+// of the latest epoch are returned if the engine is not started yet.
+func (s *backend) CurrentValidators() []common.Address {
+	s.epochMu.RLock()
+	height := s.maxEpochStartHeight
+	s.epochMu.RUnlock()
+	if s.currentBlock != nil {
+		height = s.currentBlock().NumberU64() + 1
+	}
+	return s.Validators(height).AddressList()
+}
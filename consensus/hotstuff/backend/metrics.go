@@ -0,0 +1,33 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package backend
+
+import (
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+var (
+	msgInMeter         = metrics.NewRegisteredMeter("hotstuff/backend/msg/in", nil)
+	msgInTrafficMeter  = metrics.NewRegisteredMeter("hotstuff/backend/msg/in/traffic", nil)
+	msgKnownMeter      = metrics.NewRegisteredMeter("hotstuff/backend/msg/known", nil)
+	msgOutMeter        = metrics.NewRegisteredMeter("hotstuff/backend/msg/out", nil)
+	msgOutTrafficMeter = metrics.NewRegisteredMeter("hotstuff/backend/msg/out/traffic", nil)
+
+	committedMeter = metrics.NewRegisteredMeter("hotstuff/backend/committed", nil)
+)
@@ -42,6 +42,7 @@ func (c *core) storeBacklog(msg *hotstuff.Message, src hotstuff.Validator) {
 	logger.Debug("Retrieving backlog queue", "for", src.Address(), "backlogs_size", len(c.backlogs.queue))
 
 	c.backlogs.Push(msg)
+	backlogGauge.Update(int64(c.backlogs.Size()))
 }
 
 func (c *core) processBacklog() {
@@ -82,6 +83,7 @@ func (c *core) processBacklog() {
 			go c.sendEvent(backlogEvent{src: src, msg: msg})
 		}
 	}
+	backlogGauge.Update(int64(c.backlogs.size()))
 }
 
 type backlog struct {
@@ -125,6 +127,24 @@ func (b *backlog) Pop(addr common.Address) (data *hotstuff.Message, priority int
 	}
 }
 
+// Size returns the number of messages cached in backlog
+func (b *backlog) Size() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.size()
+}
+
+func (b *backlog) size() int {
+	size := 0
+	for _, queue := range b.queue {
+		if queue != nil {
+			size += queue.Size()
+		}
+	}
+	return size
+}
+
 var messagePriorityTable = map[hotstuff.MsgType]int64{
 	MsgTypeNewView:       1,
 	MsgTypePrepare:       2,
@@ -55,6 +55,7 @@ func (c *core) handlePreCommitVote(data *hotstuff.Message, src hotstuff.Validato
 		logger.Trace("Failed to add vote", "type", msgTyp, "err", err)
 		return errAddPreCommitVote
 	}
+	c.updateVoteMetrics(MsgTypePreCommit, preCommitVoteTimer)
 
 	logger.Trace("handlePreCommitVote", "src", src.Address(), "hash", vote.Digest)
 
@@ -141,11 +142,16 @@ func (c *core) sendCommitVote() {
 		logger.Error("Failed to encode", "msg", msgTyp, "err", err)
 		return
 	}
+	if err := c.storeLockedState(); err != nil {
+		logger.Error("Failed to store locked state", "msg", msgTyp, "err", err)
+		return
+	}
 	c.broadcast(&hotstuff.Message{Code: msgTyp, Msg: payload})
 	logger.Trace("sendCommitVote", "vote view", vote.View, "vote", vote.Digest)
 
 	if !c.IsProposer() {
 		c.setCurrentState(StateCommitted)
+		c.updateRoundMetrics()
 		c.startNewRound(common.Big0)
 	}
 }
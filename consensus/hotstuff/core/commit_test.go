@@ -19,6 +19,7 @@
 package core
 
 import (
+	"fmt"
 	"math/big"
 	"testing"
 
@@ -306,7 +307,7 @@ func TestHandleCommit(t *testing.T) {
 				core := backend.core()
 				proposal, qc = newPreCommitMsg(core)
 				core.current.SetProposal(proposal)
-				core.current.SetPrepareQC(&hotstuff.QuorumCert{Hash: common.HexToHash("0x124")})
+				core.current.SetPrepareQC(&hotstuff.QuorumCert{View: qc.View, Proposer: qc.Proposer, Hash: common.HexToHash("0x124")})
 			}
 			msg := newP2PMsg(qc)
 			val := validator.New(sys.getLeader().Address())
@@ -314,11 +315,11 @@ func TestHandleCommit(t *testing.T) {
 				Sys:       sys,
 				Msg:       msg,
 				Leader:    val,
-				ExpectErr: nil,
+				ExpectErr: fmt.Errorf("expect %v, got %v", common.HexToHash("0x124"), qc.Hash),
 			}
 		}(),
 
-		// errState
+		// already pre-committed, nothing to do
 		func() *testcase {
 			sys := NewTestSystemWithBackend(N, F, H, R)
 			var (
@@ -338,7 +339,7 @@ func TestHandleCommit(t *testing.T) {
 				Sys:       sys,
 				Msg:       msg,
 				Leader:    val,
-				ExpectErr: errState,
+				ExpectErr: nil,
 			}
 		}(),
 	}
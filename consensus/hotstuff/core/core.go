@@ -21,10 +21,12 @@ package core
 import (
 	"math"
 	"math/big"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus/hotstuff"
+	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
 )
@@ -32,6 +34,7 @@ import (
 type core struct {
 	config *hotstuff.Config
 	logger log.Logger
+	db     ethdb.Database // Database to persist locked state
 
 	current  *roundState
 	backend  hotstuff.Backend
@@ -39,23 +42,29 @@ type core struct {
 	valSet   hotstuff.ValidatorSet
 	requests *requestSet
 	backlogs *backlog
+	health   *health
 
 	events            *event.TypeMuxSubscription
 	timeoutSub        *event.TypeMuxSubscription
 	finalCommittedSub *event.TypeMuxSubscription
 
 	roundChangeTimer *time.Timer
+	timerLock        sync.Mutex // Protects the timer which is stopped from outside of the events loop
 
 	validateFn func([]byte, []byte) (common.Address, error)
 	isRunning  bool
 }
 
 // New creates an HotStuff consensus core
-func New(backend hotstuff.Backend, config *hotstuff.Config, signer hotstuff.Signer) hotstuff.CoreEngine {
+func New(backend hotstuff.Backend, config *hotstuff.Config, signer hotstuff.Signer, db ethdb.Database) hotstuff.CoreEngine {
 	c := &core{
-		config:  config,
-		logger:  log.New("address", backend.Address()),
-		backend: backend,
+		config:   config,
+		logger:   log.New("address", backend.Address()),
+		db:       db,
+		backend:  backend,
+		requests: newRequestSet(),
+		backlogs: newBackLog(),
+		health:   newHealth(),
 	}
 	c.validateFn = c.checkValidatorSignature
 	c.signer = signer
@@ -71,6 +80,10 @@ func (c *core) IsProposer() bool {
 	return c.valSet.IsProposer(c.backend.Address())
 }
 
+func (c *core) Stats() *hotstuff.Stats {
+	return c.health.stats()
+}
+
 func (c *core) IsCurrentProposal(blockHash common.Hash) bool {
 	if c.current == nil {
 		return false
@@ -136,13 +149,25 @@ catchup:
 		newView.Round = new(big.Int).Set(round)
 	}
 
+	// restore the view and locked proposal persisted before restart
+	var restored *lockedState
+	if c.current == nil {
+		valSet := c.backend.Validators(newView.Height.Uint64())
+		if restored = c.loadLockedState(newView.Height.Uint64(), valSet); restored != nil {
+			newView.Round = new(big.Int).Set(restored.View.Round)
+			logger.Info("Restore locked state", "state", restored)
+		}
+	}
+
 	var (
 		lastProposalLocked bool
 		lastLockedProposal hotstuff.Proposal
+		lastLockedQC       *hotstuff.QuorumCert
 		lastPendingRequest *hotstuff.Request
 	)
 	if c.current != nil {
 		lastProposalLocked, lastLockedProposal = c.current.LastLockedProposal()
+		lastLockedQC = c.current.PreCommittedQC()
 		lastPendingRequest = c.current.PendingRequest()
 	}
 
@@ -154,10 +179,26 @@ catchup:
 	if changeView && lastProposalLocked && lastLockedProposal != nil {
 		c.current.SetProposal(lastLockedProposal)
 		c.current.LockProposal()
+		if lastLockedQC != nil && lastLockedQC.Hash == lastLockedProposal.Hash() {
+			c.current.SetPreCommittedQC(lastLockedQC)
+		}
 	}
 	if changeView && lastPendingRequest != nil {
 		c.current.SetPendingRequest(lastPendingRequest)
 	}
+	if restored != nil && restored.Proposal != nil {
+		c.current.SetProposal(restored.Proposal)
+		c.current.LockProposal()
+		c.current.SetPreCommittedQC(restored.PrepareQC)
+	}
+	if err := c.storeLockedState(); err != nil {
+		logger.Error("Failed to store locked state", "view", newView, "err", err)
+	}
+	if changeView {
+		viewChangeMeter.Mark(1)
+		c.health.markViewChange()
+	}
+	c.health.updateView(newView, c.valSet.GetProposer().Address(), c.valSet.AddressList())
 
 	logger.Debug("New round", "state", c.currentState(), "newView", newView, "new_proposer", c.valSet.GetProposer(), "valSet", c.valSet.List(), "size", c.valSet.Size(), "IsProposer", c.IsProposer())
 
@@ -194,13 +235,21 @@ func (c *core) Q() int {
 }
 
 func (c *core) stopTimer() {
+	c.timerLock.Lock()
+	defer c.timerLock.Unlock()
+
 	if c.roundChangeTimer != nil {
 		c.roundChangeTimer.Stop()
 	}
 }
 
 func (c *core) newRoundChangeTimer() {
-	c.stopTimer()
+	c.timerLock.Lock()
+	defer c.timerLock.Unlock()
+
+	if c.roundChangeTimer != nil {
+		c.roundChangeTimer.Stop()
+	}
 
 	// set timeout based on the round number
 	timeout := time.Duration(c.config.RequestTimeout) * time.Millisecond
@@ -74,31 +74,26 @@ func TestNewRequest(t *testing.T) {
 	H := uint64(1)
 	R := uint64(0)
 
+	needBroadCast = true
 	sys := NewTestSystemWithBackend(N, F, H, R)
 
 	close := sys.Run(true)
 	defer close()
 
-	request1 := makeBlock(1)
-	sys.backends[0].NewRequest(request1)
+	genesis, lastProposer := sys.backends[0].LastProposal()
+	leader := sys.getLeaderByRound(lastProposer, common.Big0).backend.(*mockBackend)
 
-	<-time.After(1 * time.Second)
-
-	request2 := makeBlock(2)
-	sys.backends[0].NewRequest(request2)
+	request := makeBlockWithParentHash(1, genesis.Hash())
+	sys.backends[0].NewRequest(request)
 
 	<-time.After(1 * time.Second)
 
-	for _, backend := range sys.backends {
-		if len(backend.committedMsgs) != 2 {
-			t.Errorf("the number of executed requests mismatch: have %v, want 2", len(backend.committedMsgs))
-		}
-		if !reflect.DeepEqual(request1.Number(), backend.committedMsgs[0].commitProposal.Number()) {
-			t.Errorf("the number of requests mismatch: have %v, want %v", request1.Number(), backend.committedMsgs[0].commitProposal.Number())
-		}
-		if !reflect.DeepEqual(request2.Number(), backend.committedMsgs[1].commitProposal.Number()) {
-			t.Errorf("the number of requests mismatch: have %v, want %v", request2.Number(), backend.committedMsgs[1].commitProposal.Number())
-		}
+	// only the proposer commits the block, replicas import it through block sync
+	if len(leader.committedMsgs) != 1 {
+		t.Fatalf("the number of executed requests mismatch: have %v, want 1", len(leader.committedMsgs))
+	}
+	if !reflect.DeepEqual(request.Hash(), leader.committedMsgs[0].commitProposal.Hash()) {
+		t.Errorf("the committed request mismatch: have %v, want %v", leader.committedMsgs[0].commitProposal.Hash(), request.Hash())
 	}
 }
 
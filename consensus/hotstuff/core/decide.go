@@ -56,6 +56,7 @@ func (c *core) handleCommitVote(data *hotstuff.Message, src hotstuff.Validator)
 		logger.Trace("Failed to add vote", "msg", msgTyp, "err", err)
 		return errAddPreCommitVote
 	}
+	c.updateVoteMetrics(MsgTypeCommit, commitVoteTimer)
 
 	logger.Trace("handleCommitVote", "msg", msgTyp, "src", src.Address(), "hash", vote.Digest)
 
@@ -67,6 +68,7 @@ func (c *core) handleCommitVote(data *hotstuff.Message, src hotstuff.Validator)
 			logger.Trace("Failed to commit proposal", "err", err)
 			return err
 		}
+		c.updateRoundMetrics()
 		c.startNewRound(common.Big0)
 	}
 
@@ -188,6 +188,8 @@ func (c *core) handleCheckedMsg(msg *hotstuff.Message, src hotstuff.Validator) (
 
 func (c *core) handleTimeoutMsg() {
 	c.logger.Trace("handleTimeout", "state", c.currentState(), "view", c.currentView())
+	timeoutMeter.Mark(1)
+	c.health.markTimeout()
 	round := new(big.Int).Add(c.current.Round(), common.Big1)
 	c.startNewRound(round)
 }
@@ -37,12 +37,12 @@ func TestHandleMsg(t *testing.T) {
 
 	sys := NewTestSystemWithBackend(N, F, H, R)
 
-	closer := sys.Run(true)
+	closer := sys.Run(false)
 	defer closer()
 
 	v0 := sys.backends[0]
 	r0 := v0.core()
-	_, val := v0.Validators().GetByAddress(v0.Address())
+	_, val := v0.Validators(H).GetByAddress(v0.Address())
 
 	// decode new view
 	{
@@ -0,0 +1,154 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package core
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum/consensus/hotstuff"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// lockedState is the write-ahead record of the round state which is necessary for the
+// locking safety rule. it is persisted before votes sent, and reloaded when the core
+// started, so that an restarted validator won't vote for a proposal conflict with the
+// one it had locked on.
+type lockedState struct {
+	View      *hotstuff.View
+	Proposal  hotstuff.Proposal    // locked proposal, nil if not locked
+	PrepareQC *hotstuff.QuorumCert // prepareQC which the proposal locked on, nil if not locked
+}
+
+// EncodeRLP serializes b into the Ethereum RLP format.
+func (s *lockedState) EncodeRLP(w io.Writer) error {
+	var (
+		proposal, qc []byte
+		err          error
+	)
+	if s.Proposal != nil {
+		block, ok := s.Proposal.(*types.Block)
+		if !ok {
+			return errInvalidProposal
+		}
+		if proposal, err = rlp.EncodeToBytes(block); err != nil {
+			return err
+		}
+	}
+	if s.PrepareQC != nil {
+		if qc, err = rlp.EncodeToBytes(s.PrepareQC); err != nil {
+			return err
+		}
+	}
+	return rlp.Encode(w, []interface{}{s.View, proposal, qc})
+}
+
+// DecodeRLP implements rlp.Decoder, and load the consensus fields from a RLP stream.
+func (s *lockedState) DecodeRLP(stream *rlp.Stream) error {
+	var state struct {
+		View      *hotstuff.View
+		Proposal  []byte
+		PrepareQC []byte
+	}
+	if err := stream.Decode(&state); err != nil {
+		return err
+	}
+	s.View = state.View
+	if len(state.Proposal) > 0 {
+		block := new(types.Block)
+		if err := rlp.DecodeBytes(state.Proposal, block); err != nil {
+			return err
+		}
+		s.Proposal = block
+	}
+	if len(state.PrepareQC) > 0 {
+		qc := new(hotstuff.QuorumCert)
+		if err := rlp.DecodeBytes(state.PrepareQC, qc); err != nil {
+			return err
+		}
+		s.PrepareQC = qc
+	}
+	return nil
+}
+
+func (s *lockedState) String() string {
+	if s.Proposal == nil {
+		return fmt.Sprintf("{View: %v, Locked: false}", s.View)
+	}
+	return fmt.Sprintf("{View: %v, Locked: true, Proposal: %v}", s.View, s.Proposal.Hash())
+}
+
+// storeLockedState writes current view and locked proposal into database, it should
+// be called before any vote sent out.
+func (c *core) storeLockedState() error {
+	if c.db == nil {
+		return nil
+	}
+
+	state := &lockedState{View: c.currentView()}
+	if isLocked, proposal := c.current.LastLockedProposal(); isLocked && proposal != nil {
+		state.Proposal = proposal
+		if qc := c.current.PreCommittedQC(); qc != nil && qc.Hash == proposal.Hash() {
+			state.PrepareQC = qc
+		}
+	}
+	blob, err := rlp.EncodeToBytes(state)
+	if err != nil {
+		return err
+	}
+	return rawdb.WriteLockedState(c.db, blob)
+}
+
+// loadLockedState reads the persisted round state, nil returned if there is no valid
+// state at the given height.
+func (c *core) loadLockedState(height uint64, valSet hotstuff.ValidatorSet) *lockedState {
+	logger := c.logger.New()
+
+	if c.db == nil {
+		return nil
+	}
+	blob, err := rawdb.ReadLockedState(c.db)
+	if err != nil || len(blob) == 0 {
+		return nil
+	}
+	state := new(lockedState)
+	if err := rlp.DecodeBytes(blob, state); err != nil {
+		logger.Warn("Failed to decode locked state", "err", err)
+		return nil
+	}
+	if state.View == nil || state.View.Height == nil || state.View.Round == nil {
+		return nil
+	}
+	if state.View.Height.Uint64() != height {
+		logger.Trace("Skip stale locked state", "state", state, "height", height)
+		return nil
+	}
+	if state.Proposal != nil {
+		if state.PrepareQC == nil || state.PrepareQC.Hash != state.Proposal.Hash() {
+			logger.Warn("Invalid locked state", "state", state, "err", "locked proposal without prepareQC")
+			state.Proposal, state.PrepareQC = nil, nil
+		} else if err := c.signer.VerifyQC(state.PrepareQC, valSet); err != nil {
+			logger.Warn("Invalid locked state", "state", state, "err", err)
+			state.Proposal, state.PrepareQC = nil, nil
+		}
+	}
+	return state
+}
@@ -0,0 +1,218 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/assert"
+)
+
+// restartCore kills the core of backend and starts an new one with the same database,
+// all of the memory state of the killed core will be dropped.
+func restartCore(t *testing.T, backend *mockBackend) *core {
+	old := backend.core()
+	if old.isRunning {
+		assert.NoError(t, old.Stop())
+	}
+
+	c := New(backend, old.config, old.signer, backend.db).(*core)
+	c.logger = testLogger
+	c.validateFn = old.validateFn
+	backend.engine = c
+	assert.NoError(t, c.Start(nil))
+	return c
+}
+
+func newLockedTestSystem(n, h uint64) *testSystem {
+	sys := NewTestSystemWithBackend(n, 1, h, 0)
+	parent := makeBlock(int64(h - 1))
+	for _, backend := range sys.backends {
+		backend.committedMsgs = append(backend.committedMsgs, testCommittedMsgs{commitProposal: parent})
+	}
+	return sys
+}
+
+func TestLockedStateEncode(t *testing.T) {
+	proposal := makeBlock(5)
+	state := &lockedState{
+		View:      makeView(5, 2),
+		Proposal:  proposal,
+		PrepareQC: proposal2QC(proposal, big.NewInt(2)),
+	}
+	enc, err := rlp.EncodeToBytes(state)
+	assert.NoError(t, err)
+
+	got := new(lockedState)
+	assert.NoError(t, rlp.DecodeBytes(enc, got))
+	assert.Equal(t, 0, got.View.Cmp(state.View))
+	assert.Equal(t, proposal.Hash(), got.Proposal.Hash())
+	assert.Equal(t, proposal.Hash(), got.PrepareQC.Hash)
+
+	// state without locked proposal
+	state = &lockedState{View: makeView(5, 3)}
+	enc, err = rlp.EncodeToBytes(state)
+	assert.NoError(t, err)
+
+	got = new(lockedState)
+	assert.NoError(t, rlp.DecodeBytes(enc, got))
+	assert.Equal(t, 0, got.View.Cmp(state.View))
+	assert.Nil(t, got.Proposal)
+	assert.Nil(t, got.PrepareQC)
+}
+
+// TestRestartWithLockedProposal kills all validators after they locked on an uncommitted
+// proposal, and the restarted validators should keep the round and locked proposal.
+func TestRestartWithLockedProposal(t *testing.T) {
+	N, H, R := uint64(4), uint64(5), uint64(2)
+	sys := newLockedTestSystem(N, H)
+
+	proposal := makeBlockWithParentHash(int64(H), makeBlock(int64(H-1)).Hash())
+	for _, backend := range sys.backends {
+		c := backend.core()
+		c.current = newRoundState(makeView(H, R), c.valSet, nil)
+		c.current.SetProposal(proposal)
+		c.lockQCAndProposal(proposal2QC(proposal, new(big.Int).SetUint64(R)))
+		assert.NoError(t, c.storeLockedState())
+
+		c = restartCore(t, backend)
+		assert.Equal(t, H, c.currentView().Height.Uint64())
+		assert.Equal(t, R, c.currentView().Round.Uint64())
+		assert.True(t, c.current.IsProposalLocked())
+		assert.Equal(t, proposal.Hash(), c.current.Proposal().Hash())
+
+		// conflicting proposal at the same height should be rejected after restart
+		conflict := makeBlockWithParentHash(int64(H), common.HexToHash("0x1234"))
+		assert.Error(t, c.checkLockedProposal(conflict))
+		assert.NoError(t, c.checkLockedProposal(proposal))
+		assert.NoError(t, c.Stop())
+	}
+}
+
+// TestRestartTwiceWithLockedProposal restarts the validator again after it restored the
+// locked proposal, and the lock should survive the second restart as well.
+func TestRestartTwiceWithLockedProposal(t *testing.T) {
+	N, H, R := uint64(4), uint64(5), uint64(2)
+	sys := newLockedTestSystem(N, H)
+
+	proposal := makeBlockWithParentHash(int64(H), makeBlock(int64(H-1)).Hash())
+	backend := sys.backends[0]
+	c := backend.core()
+	c.current = newRoundState(makeView(H, R), c.valSet, nil)
+	c.current.SetProposal(proposal)
+	c.lockQCAndProposal(proposal2QC(proposal, new(big.Int).SetUint64(R)))
+	assert.NoError(t, c.storeLockedState())
+
+	for i := 0; i < 2; i++ {
+		c = restartCore(t, backend)
+		assert.Equal(t, R, c.currentView().Round.Uint64())
+		assert.True(t, c.current.IsProposalLocked())
+		assert.Equal(t, proposal.Hash(), c.current.Proposal().Hash())
+		assert.Equal(t, proposal.Hash(), c.current.PreCommittedQC().Hash)
+	}
+	defer c.Stop()
+
+	conflict := makeBlockWithParentHash(int64(H), common.HexToHash("0x1234"))
+	assert.Error(t, c.checkLockedProposal(conflict))
+	assert.NoError(t, c.checkLockedProposal(proposal))
+}
+
+// TestRestartAfterViewChangeWithLockedProposal changes the view of an locked validator
+// and kills it in the new round, the lock should be carried over to the new round and
+// restored after restart.
+func TestRestartAfterViewChangeWithLockedProposal(t *testing.T) {
+	N, H, R := uint64(4), uint64(5), uint64(2)
+	sys := newLockedTestSystem(N, H)
+
+	proposal := makeBlockWithParentHash(int64(H), makeBlock(int64(H-1)).Hash())
+	backend := sys.backends[0]
+	c := restartCore(t, backend)
+	c.current = newRoundState(makeView(H, R), c.valSet, nil)
+	c.current.SetProposal(proposal)
+	c.lockQCAndProposal(proposal2QC(proposal, new(big.Int).SetUint64(R)))
+	assert.NoError(t, c.storeLockedState())
+
+	c.startNewRound(new(big.Int).SetUint64(R + 1))
+	assert.Equal(t, R+1, c.currentView().Round.Uint64())
+	assert.True(t, c.current.IsProposalLocked())
+	assert.Equal(t, proposal.Hash(), c.current.PreCommittedQC().Hash)
+
+	// the locked proposal re-proposed by the new leader is still safe
+	parentQC := proposal2QC(makeBlock(int64(H-1)), common.Big0)
+	assert.NoError(t, c.safeNode(proposal, parentQC))
+
+	c = restartCore(t, backend)
+	defer c.Stop()
+	assert.Equal(t, R+1, c.currentView().Round.Uint64())
+	assert.True(t, c.current.IsProposalLocked())
+	assert.Equal(t, proposal.Hash(), c.current.Proposal().Hash())
+}
+
+// TestRestartAfterViewChange kills an validator which has not locked on any proposal,
+// and it should be restarted at the round persisted before.
+func TestRestartAfterViewChange(t *testing.T) {
+	N, H, R := uint64(4), uint64(5), uint64(3)
+	sys := newLockedTestSystem(N, H)
+
+	backend := sys.backends[0]
+	c := backend.core()
+	c.current = newRoundState(makeView(H, R), c.valSet, nil)
+	assert.NoError(t, c.storeLockedState())
+
+	c = restartCore(t, backend)
+	defer c.Stop()
+	assert.Equal(t, H, c.currentView().Height.Uint64())
+	assert.Equal(t, R, c.currentView().Round.Uint64())
+	assert.False(t, c.current.IsProposalLocked())
+}
+
+// TestRestartWithStaleLockedState checks that the locked state is dropped if the chain
+// has committed the height, and an invalid state without prepareQC won't be restored.
+func TestRestartWithStaleLockedState(t *testing.T) {
+	N, H, R := uint64(4), uint64(5), uint64(2)
+	sys := newLockedTestSystem(N, H)
+
+	proposal := makeBlockWithParentHash(int64(H), makeBlock(int64(H-1)).Hash())
+	backend := sys.backends[0]
+	c := backend.core()
+	c.current = newRoundState(makeView(H, R), c.valSet, nil)
+	c.current.SetProposal(proposal)
+	c.lockQCAndProposal(proposal2QC(proposal, new(big.Int).SetUint64(R)))
+	assert.NoError(t, c.storeLockedState())
+
+	// the proposal committed by other validators while the node is offline
+	backend.committedMsgs = append(backend.committedMsgs, testCommittedMsgs{commitProposal: proposal})
+	c = restartCore(t, backend)
+	assert.Equal(t, H+1, c.currentView().Height.Uint64())
+	assert.Equal(t, uint64(0), c.currentView().Round.Uint64())
+	assert.False(t, c.current.IsProposalLocked())
+
+	// locked proposal without prepareQC
+	blob, err := rlp.EncodeToBytes(&lockedState{View: makeView(H+1, R), Proposal: makeBlock(int64(H + 1))})
+	assert.NoError(t, err)
+	assert.NoError(t, rawdb.WriteLockedState(backend.db, blob))
+	c = restartCore(t, backend)
+	defer c.Stop()
+	assert.Equal(t, R, c.currentView().Round.Uint64())
+	assert.False(t, c.current.IsProposalLocked())
+}
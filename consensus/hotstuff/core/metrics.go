@@ -0,0 +1,213 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package core
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/hotstuff"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+var (
+	prepareTimer   = metrics.NewRegisteredTimer("hotstuff/core/round/prepare", nil)
+	preCommitTimer = metrics.NewRegisteredTimer("hotstuff/core/round/precommit", nil)
+	commitTimer    = metrics.NewRegisteredTimer("hotstuff/core/round/commit", nil)
+	decideTimer    = metrics.NewRegisteredTimer("hotstuff/core/round/decide", nil)
+
+	viewChangeMeter = metrics.NewRegisteredMeter("hotstuff/core/viewchange", nil)
+	timeoutMeter    = metrics.NewRegisteredMeter("hotstuff/core/timeout", nil)
+
+	prepareVoteTimer   = metrics.NewRegisteredTimer("hotstuff/core/votes/prepare", nil)
+	preCommitVoteTimer = metrics.NewRegisteredTimer("hotstuff/core/votes/precommit", nil)
+	commitVoteTimer    = metrics.NewRegisteredTimer("hotstuff/core/votes/commit", nil)
+
+	proposalSizeHistogram = metrics.NewRegisteredHistogram("hotstuff/core/proposal/size", nil, metrics.NewExpDecaySample(1028, 0.015))
+	backlogGauge          = metrics.NewRegisteredGauge("hotstuff/core/backlog", nil)
+
+	// the participation of each validator is reported through the consensus stats,
+	// the gauges only count the signers and absentees of the latest quorum certificate.
+	qcSignedGauge = metrics.NewRegisteredGauge("hotstuff/core/qc/signed", nil)
+	qcMissedGauge = metrics.NewRegisteredGauge("hotstuff/core/qc/missed", nil)
+)
+
+// updateRoundMetrics reports the time spent in each phase of the current round,
+// it should be called right after the proposal committed.
+func (c *core) updateRoundMetrics() {
+	phases := []struct {
+		from, to State
+		timer    metrics.Timer
+	}{
+		{StateAcceptRequest, StatePrepared, prepareTimer},
+		{StatePrepared, StatePreCommitted, preCommitTimer},
+		{StatePreCommitted, StateCommitted, commitTimer},
+	}
+	for _, phase := range phases {
+		start, end := c.current.StateTime(phase.from), c.current.StateTime(phase.to)
+		if !start.IsZero() && !end.IsZero() {
+			phase.timer.Update(end.Sub(start))
+		}
+	}
+	// decide covers the span from assembling the commit qc until the decide message
+	// is broadcast, or the proposal is committed if the leader never sends it. only
+	// the leader assembles the commit qc, replicas enter the committed state on voting.
+	if !c.IsProposer() {
+		return
+	}
+	if committed := c.current.StateTime(StateCommitted); !committed.IsZero() {
+		decided := c.current.SentTime(MsgTypeDecide)
+		if decided.IsZero() {
+			decided = time.Now()
+		}
+		decideTimer.Update(decided.Sub(committed))
+	}
+}
+
+// updateVoteMetrics reports the latency between the leader broadcasting the
+// phase message and receiving the vote for it.
+func (c *core) updateVoteMetrics(phase MsgType, timer metrics.Timer) {
+	if sent := c.current.SentTime(phase); !sent.IsZero() {
+		timer.UpdateSince(sent)
+	}
+}
+
+func (c *core) updateProposalMetrics(proposal hotstuff.Proposal) {
+	if block, ok := proposal.(*types.Block); ok {
+		proposalSizeHistogram.Update(int64(block.Size()))
+	}
+}
+
+// updateQCMetrics counts the committed seals of an verified quorum certificate
+// against the current validator set. It is called once per round when the
+// prepare qc is accepted, both on the leader and the replicas.
+func (c *core) updateQCMetrics(qc *hotstuff.QuorumCert) {
+	if qc == nil || qc.HeightU64() == 0 {
+		return
+	}
+	extra, err := types.ExtractHotstuffExtraPayload(qc.Extra)
+	if err != nil {
+		return
+	}
+	committers, err := c.signer.GetSignersFromCommittedSeals(qc.Hash, extra.CommittedSeal)
+	if err != nil {
+		return
+	}
+	signed := make(map[common.Address]bool)
+	for _, addr := range committers {
+		signed[addr] = true
+	}
+	var signers, absentees int64
+	for _, addr := range c.valSet.AddressList() {
+		if signed[addr] {
+			signers++
+		} else {
+			absentees++
+		}
+	}
+	qcSignedGauge.Update(signers)
+	qcMissedGauge.Update(absentees)
+	c.health.updateQC(qc.HeightU64(), c.valSet.AddressList(), signed)
+}
+
+// health keeps track of the consensus progress and validators participation, the
+// fields are updated in the core events loop and read by external services.
+type health struct {
+	mu sync.RWMutex
+
+	height      uint64
+	round       uint64
+	proposer    common.Address
+	viewChanges uint64
+	timeouts    uint64
+	valSet      []common.Address
+	validators  map[common.Address]*hotstuff.ValidatorStats
+}
+
+func newHealth() *health {
+	return &health{
+		validators: make(map[common.Address]*hotstuff.ValidatorStats),
+	}
+}
+
+func (h *health) updateView(view *hotstuff.View, proposer common.Address, valSet []common.Address) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.height, h.round = view.Height.Uint64(), view.Round.Uint64()
+	h.proposer = proposer
+	h.valSet = valSet
+}
+
+func (h *health) markViewChange() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.viewChanges++
+}
+
+func (h *health) markTimeout() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.timeouts++
+}
+
+func (h *health) updateQC(height uint64, validators []common.Address, signed map[common.Address]bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, addr := range validators {
+		stats, ok := h.validators[addr]
+		if !ok {
+			stats = &hotstuff.ValidatorStats{Address: addr}
+			h.validators[addr] = stats
+		}
+		if signed[addr] {
+			stats.Signed++
+			stats.LastSigned = height
+		} else {
+			stats.Missed++
+		}
+	}
+}
+
+func (h *health) stats() *hotstuff.Stats {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	stats := &hotstuff.Stats{
+		Height:      h.height,
+		Round:       h.round,
+		Proposer:    h.proposer,
+		ViewChanges: h.viewChanges,
+		Timeouts:    h.timeouts,
+		Validators:  make([]*hotstuff.ValidatorStats, 0, len(h.valSet)),
+	}
+	for _, addr := range h.valSet {
+		item := &hotstuff.ValidatorStats{Address: addr}
+		if v, ok := h.validators[addr]; ok {
+			*item = *v
+		}
+		stats.Validators = append(stats.Validators, item)
+	}
+	return stats
+}
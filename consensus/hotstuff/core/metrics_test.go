@@ -0,0 +1,203 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package core
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/hotstuff"
+	"github.com/ethereum/go-ethereum/consensus/hotstuff/validator"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/assert"
+)
+
+// enableTestMetrics switches on the metrics system and replaces the phase timers
+// and qc gauges, which are nil metrics when the package is loaded without the
+// metrics flag.
+func enableTestMetrics() func() {
+	enabled := metrics.Enabled
+	timers := []*metrics.Timer{&prepareTimer, &preCommitTimer, &commitTimer, &decideTimer}
+	gauges := []*metrics.Gauge{&qcSignedGauge, &qcMissedGauge}
+	originTimers := make([]metrics.Timer, len(timers))
+	originGauges := make([]metrics.Gauge, len(gauges))
+
+	metrics.Enabled = true
+	for i, timer := range timers {
+		originTimers[i] = *timer
+		*timer = metrics.NewTimer()
+	}
+	for i, gauge := range gauges {
+		originGauges[i] = *gauge
+		*gauge = metrics.NewGauge()
+	}
+	return func() {
+		for i, timer := range timers {
+			*timer = originTimers[i]
+		}
+		for i, gauge := range gauges {
+			*gauge = originGauges[i]
+		}
+		metrics.Enabled = enabled
+	}
+}
+
+// makeBlockWithSeals creates a block whose extra carries the committed seals of
+// the given signers, the mock signer recovers the signer address from the seal.
+func makeBlockWithSeals(number int64, signers []common.Address) *types.Block {
+	seals := make([][]byte, 0, len(signers))
+	for _, addr := range signers {
+		seals = append(seals, addr.Bytes())
+	}
+	payload, _ := rlp.EncodeToBytes(&types.HotstuffExtra{CommittedSeal: seals})
+	header := &types.Header{
+		Difficulty: big.NewInt(0),
+		Number:     big.NewInt(number),
+		Extra:      append(make([]byte, types.HotstuffExtraVanity), payload...),
+	}
+	block := &types.Block{}
+	return block.WithSeal(header)
+}
+
+func TestRoundMetrics(t *testing.T) {
+	defer enableTestMetrics()()
+
+	sys := NewTestSystemWithBackend(4, 1, 5, 0)
+	leader, replica := sys.getLeader(), sys.getRepos()[0]
+
+	start := time.Now().Add(-10 * time.Second)
+	for _, c := range []*core{leader, replica} {
+		c.current.stateTime[StateAcceptRequest] = start
+		c.current.stateTime[StatePrepared] = start.Add(1 * time.Second)
+		c.current.stateTime[StatePreCommitted] = start.Add(3 * time.Second)
+		c.current.stateTime[StateCommitted] = start.Add(6 * time.Second)
+	}
+
+	// replicas do not assemble the commit qc and never decide
+	replica.updateRoundMetrics()
+	assert.Equal(t, int64(1), prepareTimer.Count())
+	assert.Equal(t, int64(1*time.Second), prepareTimer.Max())
+	assert.Equal(t, int64(2*time.Second), preCommitTimer.Max())
+	assert.Equal(t, int64(3*time.Second), commitTimer.Max())
+	assert.Equal(t, int64(0), decideTimer.Count())
+
+	// decide starts at the commit qc and ends when the decide message is broadcast
+	leader.current.sentTime[MsgTypeDecide] = start.Add(8 * time.Second)
+	leader.updateRoundMetrics()
+	assert.Equal(t, int64(2), commitTimer.Count())
+	assert.Equal(t, int64(1), decideTimer.Count())
+	assert.Equal(t, int64(2*time.Second), decideTimer.Max())
+}
+
+func TestQCMetrics(t *testing.T) {
+	defer enableTestMetrics()()
+
+	N := uint64(4)
+	F := uint64(1)
+	H := uint64(5)
+	R := uint64(0)
+
+	sys := NewTestSystemWithBackend(N, F, H, R)
+	leader := sys.getLeader()
+	vals := leader.valSet.AddressList()
+
+	// the first validator does not sign the prepare qc
+	missed, signers := vals[0], vals[1:]
+	proposal := makeBlockWithSeals(int64(H), signers)
+	for _, v := range sys.backends {
+		core := v.core()
+		core.current.SetProposal(proposal)
+		core.health.updateView(core.currentView(), leader.Address(), vals)
+	}
+
+	// the leader records the qc assembled from the prepare votes
+	for _, addr := range signers {
+		payload, _ := Encode(&Vote{View: leader.currentView(), Digest: proposal.Hash()})
+		msg := &hotstuff.Message{Code: MsgTypePrepareVote, Msg: payload, Address: addr}
+		assert.NoError(t, leader.handlePrepareVote(msg, validator.New(addr)))
+	}
+	assert.Equal(t, StatePrepared, leader.currentState())
+
+	// the replica records the qc carried by the pre-commit message, only once
+	replica := sys.getRepos()[0]
+	qc := proposal2QC(proposal, replica.current.Round())
+	payload, _ := Encode(&MsgPreCommit{View: replica.currentView(), Proposal: proposal, PrepareQC: qc})
+	msg := &hotstuff.Message{Code: MsgTypePreCommit, View: replica.currentView(), Msg: payload}
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, replica.handlePreCommit(msg, validator.New(leader.Address())))
+	}
+
+	for _, c := range []*core{leader, replica} {
+		stats := c.Stats()
+		assert.Equal(t, H, stats.Height)
+		assert.Equal(t, leader.Address(), stats.Proposer)
+		assert.Len(t, stats.Validators, int(N))
+		for _, v := range stats.Validators {
+			if v.Address == missed {
+				assert.Equal(t, uint64(0), v.Signed)
+				assert.Equal(t, uint64(1), v.Missed)
+				assert.Equal(t, uint64(0), v.LastSigned)
+			} else {
+				assert.Equal(t, uint64(1), v.Signed)
+				assert.Equal(t, uint64(0), v.Missed)
+				assert.Equal(t, H, v.LastSigned)
+			}
+		}
+	}
+
+	// the gauges count the signers of the latest qc only
+	assert.Equal(t, int64(len(signers)), qcSignedGauge.Value())
+	assert.Equal(t, int64(1), qcMissedGauge.Value())
+}
+
+func TestHealthStats(t *testing.T) {
+	vals := []common.Address{makeAddress(1), makeAddress(2), makeAddress(3)}
+
+	h := newHealth()
+	h.updateView(makeView(10, 2), vals[2], vals)
+	h.markViewChange()
+	h.markViewChange()
+	h.markTimeout()
+	h.updateQC(9, vals, map[common.Address]bool{vals[0]: true, vals[1]: true})
+	h.updateQC(10, vals, map[common.Address]bool{vals[0]: true})
+
+	stats := h.stats()
+	assert.Equal(t, uint64(10), stats.Height)
+	assert.Equal(t, uint64(2), stats.Round)
+	assert.Equal(t, vals[2], stats.Proposer)
+	assert.Equal(t, uint64(2), stats.ViewChanges)
+	assert.Equal(t, uint64(1), stats.Timeouts)
+	assert.Equal(t, []*hotstuff.ValidatorStats{
+		{Address: vals[0], Signed: 2, Missed: 0, LastSigned: 10},
+		{Address: vals[1], Signed: 1, Missed: 1, LastSigned: 9},
+		{Address: vals[2], Signed: 0, Missed: 2, LastSigned: 0},
+	}, stats.Validators)
+
+	// validators outside the current set are not reported, new ones start from zero
+	next := []common.Address{vals[1], makeAddress(4)}
+	h.updateView(makeView(11, 0), next[0], next)
+	assert.Equal(t, []*hotstuff.ValidatorStats{
+		{Address: vals[1], Signed: 1, Missed: 1, LastSigned: 9},
+		{Address: next[1]},
+	}, h.stats().Validators)
+}
@@ -80,7 +80,7 @@ func (m *mockBackend) Address() common.Address {
 }
 
 // Peers returns all connected peers
-func (m *mockBackend) Validators() hotstuff.ValidatorSet {
+func (m *mockBackend) Validators(height uint64) hotstuff.ValidatorSet {
 	return m.peers
 }
 
@@ -127,6 +127,10 @@ func (m *mockBackend) PreCommit(proposal hotstuff.Proposal, seals [][]byte) (hot
 	return proposal, nil
 }
 
+func (m *mockBackend) ForwardCommit(proposal hotstuff.Proposal, extra []byte) (hotstuff.Proposal, error) {
+	return proposal, nil
+}
+
 func (m *mockBackend) Commit(proposal hotstuff.Proposal) error {
 	testLogger.Info("commit Message", "address", m.Address())
 	msg := testCommittedMsgs{
@@ -154,10 +158,15 @@ func (m *mockBackend) HasBadProposal(hash common.Hash) bool {
 	return false
 }
 
+func (m *mockBackend) ValidateBlock(block *types.Block) error {
+	return nil
+}
+
 func (m *mockBackend) LastProposal() (hotstuff.Proposal, common.Address) {
 	l := len(m.committedMsgs)
 	if l == 0 {
-		return nil, EmptyAddress
+		// nothing committed yet, behave like a chain sitting at genesis
+		return makeBlock(0), EmptyAddress
 	} else {
 		proposal := m.committedMsgs[l-1].commitProposal
 		block := proposal.(*types.Block)
@@ -216,8 +225,8 @@ func (m *mockSinger) SignHash(hash common.Hash) ([]byte, error) {
 	return nil, nil
 }
 
-func (m *mockSinger) Recover(h *types.Header) (common.Address, error) {
-	return h.Coinbase, nil
+func (m *mockSinger) Recover(h *types.Header) (common.Address, *types.HotstuffExtra, error) {
+	return h.Coinbase, nil, nil
 }
 
 func (m *mockSinger) PrepareExtra(header *types.Header, valSet hotstuff.ValidatorSet) ([]byte, error) {
@@ -232,8 +241,8 @@ func (m *mockSinger) SealAfterCommit(h *types.Header, committedSeals [][]byte) e
 	return nil
 }
 
-func (m *mockSinger) VerifyHeader(header *types.Header, valSet hotstuff.ValidatorSet, seal bool) error {
-	return nil
+func (m *mockSinger) VerifyHeader(header *types.Header, valSet hotstuff.ValidatorSet, seal bool) (*types.HotstuffExtra, error) {
+	return nil, nil
 }
 
 func (m *mockSinger) VerifyQC(qc *hotstuff.QuorumCert, valSet hotstuff.ValidatorSet) error {
@@ -260,6 +269,14 @@ func (m *mockSinger) VerifyCommittedSeal(valSet hotstuff.ValidatorSet, hash comm
 	return nil
 }
 
+func (m *mockSinger) GetSignersFromCommittedSeals(hash common.Hash, seals [][]byte) ([]common.Address, error) {
+	signers := make([]common.Address, 0, len(seals))
+	for _, seal := range seals {
+		signers = append(signers, common.BytesToAddress(seal))
+	}
+	return signers, nil
+}
+
 // ==============================================
 //
 // define the struct that need to be provided for integration tests.
@@ -351,7 +368,7 @@ func NewTestSystemWithBackend(n, f, h, r uint64) *testSystem {
 		signer := &mockSinger{address: backend.address}
 		backend.signer = signer
 
-		core := New(backend, config, signer, vset).(*core)
+		core := New(backend, config, signer, backend.db).(*core)
 		core.current = newRoundState(&hotstuff.View{
 			Height: new(big.Int).SetUint64(h),
 			Round:  new(big.Int).SetUint64(r),
@@ -395,7 +412,7 @@ func (t *testSystem) listen() {
 func (t *testSystem) Run(core bool) func() {
 	for _, b := range t.backends {
 		if core {
-			b.engine.Start() // start hotstuff core
+			b.engine.Start(nil) // start hotstuff core
 		}
 	}
 
@@ -49,6 +49,7 @@ func (c *core) handlePrepareVote(data *hotstuff.Message, src hotstuff.Validator)
 		logger.Trace("Failed to add vote", "msg", msgTyp, "err", err)
 		return errAddPrepareVote
 	}
+	c.updateVoteMetrics(MsgTypePrepare, prepareVoteTimer)
 
 	logger.Trace("handlePrepareVote", "msg", msgTyp, "src", src.Address(), "hash", vote.Digest)
 
@@ -119,7 +120,6 @@ func (c *core) handlePreCommit(data *hotstuff.Message, src hotstuff.Validator) e
 		logger.Trace("Failed to verify prepareQC", "msg", msgTyp, "err", err)
 		return err
 	}
-
 	logger.Trace("handlePreCommit", "msg", msgTyp, "src", src.Address(), "hash", msg.Proposal.Hash())
 
 	if c.IsProposer() && c.currentState() < StatePreCommitted {
@@ -139,6 +139,7 @@ func (c *core) acceptPrepare(prepareQC *hotstuff.QuorumCert, proposal hotstuff.P
 	c.current.SetPrepareQC(prepareQC)
 	c.current.SetProposal(proposal)
 	c.current.SetState(StatePrepared)
+	c.updateQCMetrics(prepareQC)
 }
 
 func (c *core) sendPreCommitVote() {
@@ -155,6 +156,10 @@ func (c *core) sendPreCommitVote() {
 		logger.Error("Failed to encode", "msg", msgTyp, "err", err)
 		return
 	}
+	if err := c.storeLockedState(); err != nil {
+		logger.Error("Failed to store locked state", "msg", msgTyp, "err", err)
+		return
+	}
 	c.broadcast(&hotstuff.Message{Code: msgTyp, Msg: payload})
 	logger.Trace("sendPreCommitVote", "vote view", vote.View, "vote", vote.Digest)
 }
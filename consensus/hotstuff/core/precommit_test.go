@@ -179,10 +179,15 @@ func TestHandlePreCommit(t *testing.T) {
 		r := coreView.Round.Uint64()
 		return newProposalAndQC(c, h, r)
 	}
-	newP2PMsg := func(msg *hotstuff.QuorumCert) *hotstuff.Message {
-		payload, _ := Encode(msg)
+	newP2PMsg := func(proposal hotstuff.Proposal, qc *hotstuff.QuorumCert) *hotstuff.Message {
+		payload, _ := Encode(&MsgPreCommit{
+			View:      qc.View,
+			Proposal:  proposal,
+			PrepareQC: qc,
+		})
 		return &hotstuff.Message{
 			Code: MsgTypePreCommit,
+			View: qc.View,
 			Msg:  payload,
 		}
 	}
@@ -208,7 +213,7 @@ func TestHandlePreCommit(t *testing.T) {
 				proposal, qc = newPreCommitMsg(core)
 				core.current.SetProposal(proposal)
 			}
-			msg := newP2PMsg(qc)
+			msg := newP2PMsg(proposal, qc)
 			return &testcase{
 				Sys:       sys,
 				Msg:       msg,
@@ -232,7 +237,7 @@ func TestHandlePreCommit(t *testing.T) {
 				core.current.SetProposal(proposal)
 			}
 			qc.View.Height = new(big.Int).SetUint64(H - 1)
-			msg := newP2PMsg(qc)
+			msg := newP2PMsg(proposal, qc)
 			return &testcase{
 				Sys:       sys,
 				Msg:       msg,
@@ -256,7 +261,7 @@ func TestHandlePreCommit(t *testing.T) {
 				core.current.SetProposal(proposal)
 			}
 			qc.View.Round = new(big.Int).SetUint64(R + 1)
-			msg := newP2PMsg(qc)
+			msg := newP2PMsg(proposal, qc)
 			return &testcase{
 				Sys:       sys,
 				Msg:       msg,
@@ -277,7 +282,7 @@ func TestHandlePreCommit(t *testing.T) {
 				proposal, qc = newPreCommitMsg(core)
 				core.current.SetProposal(proposal)
 			}
-			msg := newP2PMsg(qc)
+			msg := newP2PMsg(proposal, qc)
 			val := validator.New(sys.getRepos()[0].Address())
 			return &testcase{
 				Sys:       sys,
@@ -287,7 +292,7 @@ func TestHandlePreCommit(t *testing.T) {
 			}
 		}(),
 
-		// errState
+		// already prepared, nothing to do
 		func() *testcase {
 			sys := NewTestSystemWithBackend(N, F, H, R)
 			var (
@@ -300,13 +305,13 @@ func TestHandlePreCommit(t *testing.T) {
 				core.current.SetProposal(proposal)
 				core.current.SetState(StatePrepared)
 			}
-			msg := newP2PMsg(qc)
+			msg := newP2PMsg(proposal, qc)
 			val := validator.New(sys.getLeader().Address())
 			return &testcase{
 				Sys:       sys,
 				Msg:       msg,
 				Leader:    val,
-				ExpectErr: errState,
+				ExpectErr: nil,
 			}
 		}(),
 	}
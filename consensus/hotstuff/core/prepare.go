@@ -116,6 +116,7 @@ func (c *core) handlePrepare(data *hotstuff.Message, src hotstuff.Validator) err
 		logger.Trace("Failed to pre-execute block", "msg", msgTyp, "err", err)
 		return err
 	}
+	c.updateProposalMetrics(msg.Proposal)
 
 	logger.Trace("handlePrepare", "msg", msgTyp, "src", src.Address(), "hash", msg.Proposal.Hash())
 
@@ -148,6 +149,10 @@ func (c *core) sendPrepareVote() {
 		logger.Trace("Failed to encode", "msg", msgTyp, "err", err)
 		return
 	}
+	if err := c.storeLockedState(); err != nil {
+		logger.Error("Failed to store locked state", "msg", msgTyp, "err", err)
+		return
+	}
 	c.broadcast(&hotstuff.Message{Code: msgTyp, Msg: payload})
 	logger.Trace("sendPrepareVote", "vote view", vote.View, "vote", vote.Digest)
 }
@@ -193,7 +198,10 @@ func (c *core) safeNode(proposal hotstuff.Proposal, highQC *hotstuff.QuorumCert)
 		logger.Trace("safeNodeChecking", "lockQC", "is nil")
 		return errSafeNode
 	}
-	if err := c.extend(proposal, c.current.PreCommittedQC()); err == nil {
+	// the locked proposal re-proposed after view change is safe by itself
+	if lockedQC := c.current.PreCommittedQC(); lockedQC.Hash == proposal.Hash() {
+		safety = true
+	} else if err := c.extend(proposal, lockedQC); err == nil {
 		safety = true
 	} else {
 		logger.Trace("safeNodeChecking", "extend err", err)
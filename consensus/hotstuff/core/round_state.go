@@ -20,6 +20,7 @@ package core
 
 import (
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/consensus/hotstuff"
 	"github.com/ethereum/go-ethereum/consensus/hotstuff/message_set"
@@ -46,6 +47,9 @@ type roundState struct {
 	prepareQC   *hotstuff.QuorumCert // prepareQC for repo and leader
 	lockedQC    *hotstuff.QuorumCert // lockedQC for repo and pre-committedQC for leader
 	committedQC *hotstuff.QuorumCert // committedQC for repo and leader
+
+	stateTime map[State]time.Time   // time of entering each state, used for metrics
+	sentTime  map[MsgType]time.Time // time of leader broadcasting phase messages, used for metrics
 }
 
 // newRoundState creates a new roundState instance with the given view and validatorSet
@@ -59,6 +63,8 @@ func newRoundState(view *hotstuff.View, validatorSet hotstuff.ValidatorSet, prep
 		prepareVotes:   message_set.NewMessageSet(validatorSet),
 		preCommitVotes: message_set.NewMessageSet(validatorSet),
 		commitVotes:    message_set.NewMessageSet(validatorSet),
+		stateTime:      map[State]time.Time{StateAcceptRequest: time.Now()},
+		sentTime:       make(map[MsgType]time.Time),
 	}
 	if prepareQC != nil {
 		rs.prepareQC = prepareQC.Copy()
@@ -85,6 +91,23 @@ func (s *roundState) View() *hotstuff.View {
 
 func (s *roundState) SetState(state State) {
 	s.state = state
+	if _, ok := s.stateTime[state]; !ok {
+		s.stateTime[state] = time.Now()
+	}
+}
+
+// StateTime returns the time of entering the state in current round, or zero time if never reached.
+func (s *roundState) StateTime(state State) time.Time {
+	return s.stateTime[state]
+}
+
+func (s *roundState) MarkSent(code MsgType) {
+	s.sentTime[code] = time.Now()
+}
+
+// SentTime returns the time of broadcasting the message in current round, or zero time if never sent.
+func (s *roundState) SentTime(code MsgType) time.Time {
+	return s.sentTime[code]
 }
 
 func (s *roundState) State() State {
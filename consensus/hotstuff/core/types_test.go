@@ -37,6 +37,7 @@ func TestProposal(t *testing.T) {
 	addr := makeAddress(1)
 	msg := &hotstuff.Message{
 		Code:    MsgTypeNewView,
+		View:    makeView(1, 0),
 		Msg:     payload,
 		Address: addr,
 	}
@@ -65,6 +66,7 @@ func TestQuorumCert(t *testing.T) {
 
 	msg := &hotstuff.Message{
 		Code:    MsgTypeNewView,
+		View:    makeView(1, 0),
 		Msg:     payload,
 		Address: makeAddress(1),
 	}
@@ -98,6 +100,7 @@ func TestNewView(t *testing.T) {
 	addr := makeAddress(1)
 	m := &hotstuff.Message{
 		Code:    MsgTypeNewView,
+		View:    makeView(1, 0),
 		Msg:     payload,
 		Address: addr,
 	}
@@ -141,6 +144,7 @@ func TestQuorumCertWithSig(t *testing.T) {
 	address := common.HexToAddress("0x1234567890")
 	m := &hotstuff.Message{
 		Code:          MsgTypePrepareVote,
+		View:          s.View,
 		Msg:           subjectPayload,
 		Address:       address,
 		Signature:     expectedSig,
@@ -86,7 +86,8 @@ func (c *core) checkVote(vote *Vote) error {
 		return fmt.Errorf("current vote is nil")
 	}
 	if !reflect.DeepEqual(c.current.Vote(), vote) {
-		return fmt.Errorf("expect %s, got %s", c.current.Vote().String(), vote.String())
+		c.logger.Trace("Inconsistent vote", "expect", c.current.Vote().String(), "got", vote.String())
+		return errInconsistentVote
 	}
 	return nil
 }
@@ -218,6 +219,7 @@ func (c *core) broadcast(msg *hotstuff.Message) {
 			logger.Error("Failed to unicast Message", "msg", msg, "err", err)
 		}
 	case MsgTypePrepare, MsgTypePreCommit, MsgTypeCommit, MsgTypeDecide:
+		c.current.MarkSent(msg.Code.(MsgType))
 		if err := c.backend.Broadcast(c.valSet, payload); err != nil {
 			logger.Error("Failed to broadcast Message", "msg", msg, "err", err)
 		}
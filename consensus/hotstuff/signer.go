@@ -59,4 +59,7 @@ type Signer interface {
 	VerifyHash(valSet ValidatorSet, hash common.Hash, sig []byte) error
 
 	VerifyCommittedSeal(valSet ValidatorSet, hash common.Hash, committedSeals [][]byte) error
+
+	// GetSignersFromCommittedSeals recover committers address from committed seals
+	GetSignersFromCommittedSeals(hash common.Hash, seals [][]byte) ([]common.Address, error)
 }
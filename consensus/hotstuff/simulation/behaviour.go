@@ -0,0 +1,148 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package simulation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/consensus/hotstuff"
+	"github.com/ethereum/go-ethereum/consensus/hotstuff/core"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Behaviour defines how a node treats its outgoing consensus messages.
+type Behaviour uint8
+
+const (
+	Honest        Behaviour = iota // follows the protocol
+	Silent                         // withholds all of the consensus messages
+	WithholdVotes                  // withholds prepare, pre-commit and commit votes
+	Equivocate                     // sends conflicting proposals to different validators as the proposer
+)
+
+func (b Behaviour) String() string {
+	switch b {
+	case Honest:
+		return "honest"
+	case Silent:
+		return "silent"
+	case WithholdVotes:
+		return "withhold-votes"
+	case Equivocate:
+		return "equivocate"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseBehaviour parses the behaviour from its name.
+func ParseBehaviour(name string) (Behaviour, error) {
+	for _, b := range []Behaviour{Honest, Silent, WithholdVotes, Equivocate} {
+		if strings.EqualFold(b.String(), name) {
+			return b, nil
+		}
+	}
+	return Honest, fmt.Errorf("unknown behaviour %q", name)
+}
+
+// outgoing applies the behaviour of node to a consensus message which will be
+// sent to the given number of targets, and returns the payload for each target,
+// a nil payload means that the message is withheld.
+func (n *Node) outgoing(payload []byte, targets int) [][]byte {
+	payloads := make([][]byte, targets)
+	behaviour := n.Behaviour()
+	if behaviour == Silent {
+		return payloads
+	}
+	for i := range payloads {
+		payloads[i] = payload
+	}
+	if behaviour == Honest {
+		return payloads
+	}
+
+	msg := new(hotstuff.Message)
+	if err := msg.FromPayload(payload, nil); err != nil {
+		n.logger.Warn("Failed to decode outgoing message", "err", err)
+		return payloads
+	}
+	switch {
+	case behaviour == WithholdVotes && isVote(msg.Code):
+		for i := range payloads {
+			payloads[i] = nil
+		}
+		n.logger.Debug("Withhold vote", "msg", msg.Code, "view", msg.View)
+
+	case behaviour == Equivocate && msg.Code == core.MsgTypePrepare:
+		fake, err := n.equivocate(msg)
+		if err != nil {
+			n.logger.Warn("Failed to equivocate proposal", "err", err)
+			return payloads
+		}
+		// send the conflicting proposal to half of the validators
+		for i := 1; i < len(payloads); i += 2 {
+			payloads[i] = fake
+		}
+		n.logger.Debug("Equivocate proposal", "view", msg.View)
+	}
+	return payloads
+}
+
+// equivocate builds a prepare message which carries a block conflicting with the
+// original proposal at the same view, signed by the node itself.
+func (n *Node) equivocate(msg *hotstuff.Message) ([]byte, error) {
+	var prepare *core.MsgPrepare
+	if err := msg.Decode(&prepare); err != nil {
+		return nil, err
+	}
+	block, ok := prepare.Proposal.(*types.Block)
+	if !ok {
+		return nil, errInvalidProposal
+	}
+	header := block.Header()
+	header.Time++
+	if err := n.signer.SealBeforeCommit(header); err != nil {
+		return nil, err
+	}
+	prepare.Proposal = block.WithSeal(header)
+
+	data, err := core.Encode(prepare)
+	if err != nil {
+		return nil, err
+	}
+	fake := &hotstuff.Message{
+		Code:    msg.Code,
+		View:    msg.View,
+		Msg:     data,
+		Address: msg.Address,
+	}
+	unsigned, err := fake.PayloadNoSig()
+	if err != nil {
+		return nil, err
+	}
+	if fake.Signature, err = n.signer.Sign(unsigned); err != nil {
+		return nil, err
+	}
+	return fake.Payload()
+}
+
+func isVote(code hotstuff.MsgType) bool {
+	return code == core.MsgTypePrepareVote || code == core.MsgTypePreCommitVote || code == core.MsgTypeCommitVote
+}
@@ -0,0 +1,605 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+// Package simulation implements an in-process HotStuff network which runs a
+// number of consensus cores over a virtual network. Latency, message loss,
+// partitions, crashes and byzantine behaviours can be injected into the network
+// to reproduce view change and epoch change problems without a real cluster.
+package simulation
+
+import (
+	"container/heap"
+	"crypto/ecdsa"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/hotstuff"
+	"github.com/ethereum/go-ethereum/consensus/hotstuff/validator"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	errInvalidConfig = errors.New("invalid simulation config")
+	errUnknownNode   = errors.New("unknown node")
+)
+
+// Config contains the parameters of a simulated network.
+type Config struct {
+	Nodes          int           // Number of nodes in the network
+	Seed           int64         // Seed of node keys and the fault injection randomness
+	RequestTimeout time.Duration // Timeout of the first round at each height
+	Latency        time.Duration // Base latency of each message
+	Jitter         time.Duration // Maximum random latency added to each message
+	Loss           float64       // Probability of dropping a consensus message
+	Epochs         []*Epoch      // Validator set changes, all nodes are validators if no epoch starts at height 0
+}
+
+// DefaultConfig contains the default settings of a four nodes network.
+var DefaultConfig = &Config{
+	Nodes:          4,
+	Seed:           1,
+	RequestTimeout: time.Second,
+	Latency:        5 * time.Millisecond,
+	Jitter:         5 * time.Millisecond,
+}
+
+// Epoch defines the validator set which takes effect from the start height on,
+// validators are referenced by node indexes.
+type Epoch struct {
+	Height     uint64 `json:"height"`
+	Validators []int  `json:"validators"`
+}
+
+// Network is a virtual network connecting a group of simulated nodes. Messages
+// are put into an event queue ordered by their delivery time and dispatched by a
+// single goroutine, every node handles its deliveries one by one in that order.
+// Random fault decisions are derived from the seed and the message itself, so
+// they do not depend on the order in which the nodes send messages.
+type Network struct {
+	config  *Config
+	nodes   []*Node
+	epochs  []*Epoch
+	genesis *types.Block
+	tracker *tracker
+
+	lock    sync.Mutex
+	latency time.Duration
+	jitter  time.Duration
+	loss    float64
+	groups  map[int]int // node index to partition group, empty if the network is healed
+	sent    uint64      // Number of consensus messages delivered
+	dropped uint64      // Number of consensus messages dropped
+
+	queue deliveryQueue // Scheduled deliveries, ordered by virtual time
+	seq   uint64        // Number of scheduled deliveries, breaks ties of delivery time
+	start time.Time     // Wall clock time of the virtual time zero
+	wake  chan struct{} // Notifies the dispatcher of an earlier delivery
+
+	quit    chan struct{}
+	running bool
+}
+
+// NewNetwork creates a simulated network with the given config, nodes will not
+// take part in the consensus until the network is started.
+func NewNetwork(config *Config) (*Network, error) {
+	if config.Nodes <= 0 || config.RequestTimeout <= 0 || config.Loss < 0 || config.Loss >= 1 {
+		return nil, errInvalidConfig
+	}
+	net := &Network{
+		config:  config,
+		tracker: newTracker(),
+		latency: config.Latency,
+		jitter:  config.Jitter,
+		loss:    config.Loss,
+		groups:  make(map[int]int),
+		wake:    make(chan struct{}, 1),
+	}
+	for i := 0; i < config.Nodes; i++ {
+		net.nodes = append(net.nodes, newNode(net, i, nodeKey(config.Seed, i)))
+	}
+
+	// sort and validate the epochs, the first epoch is always started at genesis
+	epochs := make([]*Epoch, 0, len(config.Epochs)+1)
+	for _, epoch := range config.Epochs {
+		if len(epoch.Validators) == 0 {
+			return nil, fmt.Errorf("%w: empty validators at epoch %d", errInvalidConfig, epoch.Height)
+		}
+		for _, index := range epoch.Validators {
+			if index < 0 || index >= config.Nodes {
+				return nil, fmt.Errorf("%w: validator %d at epoch %d", errUnknownNode, index, epoch.Height)
+			}
+		}
+		epochs = append(epochs, epoch)
+	}
+	sort.SliceStable(epochs, func(i, j int) bool { return epochs[i].Height < epochs[j].Height })
+	if len(epochs) == 0 || epochs[0].Height != 0 {
+		all := make([]int, config.Nodes)
+		for i := range all {
+			all[i] = i
+		}
+		epochs = append([]*Epoch{{Height: 0, Validators: all}}, epochs...)
+	}
+	for i := 1; i < len(epochs); i++ {
+		if epochs[i].Height <= epochs[i-1].Height || epochs[i].Height == 1 {
+			return nil, fmt.Errorf("%w: epoch height %d", errInvalidConfig, epochs[i].Height)
+		}
+	}
+	net.epochs = epochs
+
+	extra, err := encodeExtra(net.addresses(epochs[0].Validators))
+	if err != nil {
+		return nil, err
+	}
+	// the timestamps of the simulated chain are derived from the genesis, which
+	// keeps block hashes identical across runs with the same seed
+	net.genesis = types.NewBlockWithHeader(&types.Header{
+		Number:     common.Big0,
+		Time:       genesisTime,
+		Difficulty: defaultDifficulty,
+		MixDigest:  types.HotstuffDigest,
+		UncleHash:  types.EmptyUncleHash,
+		TxHash:     types.EmptyRootHash,
+		Extra:      extra,
+	})
+	return net, nil
+}
+
+// nodeKey derives the private key of a node from the simulation seed, so that
+// the same seed always produces the same validators and proposer rotation.
+func nodeKey(seed int64, index int) *ecdsa.PrivateKey {
+	var buf [16]byte
+	binary.BigEndian.PutUint64(buf[:8], uint64(seed))
+	binary.BigEndian.PutUint64(buf[8:], uint64(index))
+	for nonce := byte(0); ; nonce++ {
+		if key, err := crypto.ToECDSA(crypto.Keccak256(buf[:], []byte{nonce})); err == nil {
+			return key
+		}
+	}
+}
+
+// Start starts the consensus engines of all nodes.
+func (net *Network) Start() {
+	net.lock.Lock()
+	if net.running {
+		net.lock.Unlock()
+		return
+	}
+	net.running = true
+	net.quit = make(chan struct{})
+	net.queue, net.start = nil, time.Now()
+	net.lock.Unlock()
+
+	for _, node := range net.nodes {
+		node.run(net.quit)
+	}
+	for _, node := range net.nodes {
+		node.start()
+	}
+	net.schedule(nil, net.config.RequestTimeout/2, net.syncAll)
+	go net.dispatch(net.quit)
+}
+
+// Stop stops all of the nodes, messages in flight are dropped.
+func (net *Network) Stop() {
+	net.lock.Lock()
+	if !net.running {
+		net.lock.Unlock()
+		return
+	}
+	net.running = false
+	close(net.quit)
+	net.lock.Unlock()
+
+	for _, node := range net.nodes {
+		node.stop()
+	}
+}
+
+// Nodes returns all of the simulated nodes.
+func (net *Network) Nodes() []*Node {
+	return net.nodes
+}
+
+// Node returns the node with the given index.
+func (net *Network) Node(index int) (*Node, error) {
+	if index < 0 || index >= len(net.nodes) {
+		return nil, fmt.Errorf("%w: %d", errUnknownNode, index)
+	}
+	return net.nodes[index], nil
+}
+
+// Heights returns the chain height of all nodes.
+func (net *Network) Heights() []uint64 {
+	heights := make([]uint64, len(net.nodes))
+	for i, node := range net.nodes {
+		heights[i] = node.Height()
+	}
+	return heights
+}
+
+// Crash stops the consensus engine of the given nodes, the chain and the locked
+// state persisted in database survive and will be used after restart.
+func (net *Network) Crash(indexes ...int) error {
+	return net.each(indexes, func(node *Node) { node.stop() })
+}
+
+// Restart restarts crashed nodes with a new consensus engine.
+func (net *Network) Restart(indexes ...int) error {
+	return net.each(indexes, func(node *Node) { node.start() })
+}
+
+// SetBehaviour changes the behaviour of the given nodes.
+func (net *Network) SetBehaviour(behaviour Behaviour, indexes ...int) error {
+	return net.each(indexes, func(node *Node) { node.setBehaviour(behaviour) })
+}
+
+// Partition splits the network into isolated groups, nodes absent from all of the
+// groups are isolated from every other node.
+func (net *Network) Partition(groups ...[]int) error {
+	partition := make(map[int]int)
+	for id, group := range groups {
+		for _, index := range group {
+			if index < 0 || index >= len(net.nodes) {
+				return fmt.Errorf("%w: %d", errUnknownNode, index)
+			}
+			partition[index] = id + 1
+		}
+	}
+	for index := range net.nodes {
+		if _, ok := partition[index]; !ok {
+			partition[index] = -index - 1
+		}
+	}
+	net.lock.Lock()
+	net.groups = partition
+	net.lock.Unlock()
+
+	log.Info("Network partitioned", "groups", groups)
+	return nil
+}
+
+// Heal removes all partitions of the network.
+func (net *Network) Heal() {
+	net.lock.Lock()
+	net.groups = make(map[int]int)
+	net.lock.Unlock()
+
+	log.Info("Network healed")
+}
+
+// SetLoss changes the probability of dropping a consensus message.
+func (net *Network) SetLoss(loss float64) error {
+	if loss < 0 || loss >= 1 {
+		return fmt.Errorf("%w: loss %v", errInvalidConfig, loss)
+	}
+	net.lock.Lock()
+	net.loss = loss
+	net.lock.Unlock()
+	return nil
+}
+
+// SetLatency changes the base latency and the maximum jitter of messages.
+func (net *Network) SetLatency(latency, jitter time.Duration) {
+	net.lock.Lock()
+	net.latency, net.jitter = latency, jitter
+	net.lock.Unlock()
+}
+
+// Traffic returns the number of delivered and dropped consensus messages.
+func (net *Network) Traffic() (sent uint64, dropped uint64) {
+	net.lock.Lock()
+	defer net.lock.Unlock()
+
+	return net.sent, net.dropped
+}
+
+// CheckSafety returns an error if honest nodes committed conflicting blocks.
+func (net *Network) CheckSafety() error {
+	return net.tracker.check()
+}
+
+// WaitHeight waits until all of the running honest nodes reach the given height.
+func (net *Network) WaitHeight(height uint64, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		reached := true
+		for _, node := range net.nodes {
+			if node.Running() && node.Behaviour() == Honest && node.Height() < height {
+				reached = false
+				break
+			}
+		}
+		if reached {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("height %d not reached in %v, heights %v", height, timeout, net.Heights())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func (net *Network) each(indexes []int, fn func(node *Node)) error {
+	for _, index := range indexes {
+		if _, err := net.Node(index); err != nil {
+			return err
+		}
+	}
+	for _, index := range indexes {
+		fn(net.nodes[index])
+	}
+	return nil
+}
+
+// epoch returns the epoch which the given height belongs to.
+func (net *Network) epoch(height uint64) *Epoch {
+	epoch := net.epochs[0]
+	for _, e := range net.epochs[1:] {
+		if e.Height > height {
+			break
+		}
+		epoch = e
+	}
+	return epoch
+}
+
+// validators returns the validator set at the given height.
+func (net *Network) validators(height uint64) hotstuff.ValidatorSet {
+	return validator.NewSet(net.addresses(net.epoch(height).Validators), hotstuff.RoundRobin)
+}
+
+// nextValidators returns the validators of next epoch if the block at the given
+// height is the last block of current epoch.
+func (net *Network) nextValidators(height uint64) []common.Address {
+	for _, epoch := range net.epochs[1:] {
+		if epoch.Height == height+1 {
+			return net.addresses(epoch.Validators)
+		}
+	}
+	return nil
+}
+
+func (net *Network) addresses(indexes []int) []common.Address {
+	list := make([]common.Address, 0, len(indexes))
+	for _, index := range indexes {
+		list = append(list, net.nodes[index].address)
+	}
+	return list
+}
+
+// lookup returns the nodes with the addresses in validator set.
+func (net *Network) lookup(valSet hotstuff.ValidatorSet) []*Node {
+	nodes := make([]*Node, 0, valSet.Size())
+	for _, node := range net.nodes {
+		if idx, _ := valSet.GetByAddress(node.address); idx >= 0 {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+// route decides whether a message from one node can reach another, and returns
+// the latency of the message if so. Blocks are never dropped randomly because
+// the block synchronisation retries until the block is fetched.
+func (net *Network) route(from, to *Node, data []byte, lossy bool) (time.Duration, bool) {
+	net.lock.Lock()
+	defer net.lock.Unlock()
+
+	if !net.running || net.groups[from.index] != net.groups[to.index] {
+		if lossy {
+			net.dropped++
+		}
+		return 0, false
+	}
+	loss, jitter := net.draw(from, to, data)
+	if lossy && net.loss > 0 && loss < net.loss {
+		net.dropped++
+		return 0, false
+	}
+	if lossy {
+		net.sent++
+	}
+	delay := net.latency
+	if net.jitter > 0 {
+		delay += time.Duration(jitter % uint64(net.jitter))
+	}
+	return delay, true
+}
+
+// draw derives the random numbers of a message on the link from the seed, a
+// float in [0, 1) for the loss and an integer for the jitter.
+func (net *Network) draw(from, to *Node, data []byte) (float64, uint64) {
+	var buf [24]byte
+	binary.BigEndian.PutUint64(buf[:8], uint64(net.config.Seed))
+	binary.BigEndian.PutUint64(buf[8:16], uint64(from.index))
+	binary.BigEndian.PutUint64(buf[16:], uint64(to.index))
+	hash := crypto.Keccak256(buf[:], data)
+
+	loss := float64(binary.BigEndian.Uint64(hash[:8])>>11) / (1 << 53)
+	return loss, binary.BigEndian.Uint64(hash[8:16])
+}
+
+// reachable returns true if messages could be delivered between two nodes.
+func (net *Network) reachable(from, to *Node) bool {
+	net.lock.Lock()
+	defer net.lock.Unlock()
+
+	return net.groups[from.index] == net.groups[to.index]
+}
+
+// send delivers a consensus message to the target node after the latency.
+func (net *Network) send(from, to *Node, payload []byte) {
+	delay, ok := net.route(from, to, payload, true)
+	if !ok {
+		return
+	}
+	net.schedule(to, delay, func() { to.handleMessage(payload) })
+}
+
+// broadcastBlock propagates a committed block to all other nodes, blocks are
+// imported by the dispatcher so that a node waiting for its parent block in the
+// consensus engine never holds up the import.
+func (net *Network) broadcastBlock(from *Node, block *types.Block) {
+	hash := block.Hash()
+	for _, to := range net.nodes {
+		if to == from {
+			continue
+		}
+		to := to
+		if delay, ok := net.route(from, to, hash.Bytes(), false); ok {
+			net.schedule(nil, delay, func() { to.importBlock(block, from) })
+		}
+	}
+}
+
+// schedule puts a delivery into the event queue, the delivery is handed over to
+// the target node once the virtual clock reaches it, or run by the dispatcher
+// itself if the target is nil. Deliveries run by the dispatcher must not block.
+func (net *Network) schedule(to *Node, delay time.Duration, fn func()) {
+	net.lock.Lock()
+	if !net.running {
+		net.lock.Unlock()
+		return
+	}
+	net.seq++
+	ev := &delivery{at: time.Since(net.start) + delay, seq: net.seq, to: to, fn: fn}
+	heap.Push(&net.queue, ev)
+	first := net.queue[0] == ev
+	net.lock.Unlock()
+
+	if first {
+		select {
+		case net.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// dispatch is the only goroutine which pops deliveries out of the event queue,
+// the virtual clock follows the wall clock so that the consensus timeouts keep
+// their meaning relative to the message latency.
+func (net *Network) dispatch(quit chan struct{}) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		net.lock.Lock()
+		now := time.Since(net.start)
+		var due []*delivery
+		for len(net.queue) > 0 && net.queue[0].at <= now {
+			due = append(due, heap.Pop(&net.queue).(*delivery))
+		}
+		wait := time.Hour
+		if len(net.queue) > 0 {
+			wait = net.queue[0].at - now
+		}
+		net.lock.Unlock()
+
+		for _, ev := range due {
+			if ev.to == nil {
+				ev.fn()
+			} else {
+				ev.to.enqueue(ev.fn)
+			}
+		}
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		timer.Reset(wait)
+
+		select {
+		case <-timer.C:
+		case <-net.wake:
+		case <-quit:
+			return
+		}
+	}
+}
+
+// syncAll synchronises every node with the highest reachable peer periodically,
+// which simulates the block downloader of a real node and lets crashed or
+// partitioned nodes catch up.
+func (net *Network) syncAll() {
+	for _, node := range net.nodes {
+		if !node.Running() {
+			continue
+		}
+		var best *Node
+		for _, peer := range net.nodes {
+			if peer == node || !peer.Running() || !net.reachable(peer, node) {
+				continue
+			}
+			if best == nil || peer.Height() > best.Height() {
+				best = peer
+			}
+		}
+		if best != nil && best.Height() > node.Height() {
+			node.sync(best)
+		}
+	}
+	net.schedule(nil, net.config.RequestTimeout/2, net.syncAll)
+}
+
+// delivery is a message or task scheduled on the virtual network.
+type delivery struct {
+	at  time.Duration // Virtual time of the delivery
+	seq uint64        // Scheduling order, deliveries due at the same time keep it
+	to  *Node
+	fn  func()
+}
+
+// deliveryQueue implements heap.Interface, the earliest delivery comes first.
+type deliveryQueue []*delivery
+
+func (q deliveryQueue) Len() int { return len(q) }
+
+func (q deliveryQueue) Less(i, j int) bool {
+	if q[i].at != q[j].at {
+		return q[i].at < q[j].at
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q deliveryQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *deliveryQueue) Push(x interface{}) { *q = append(*q, x.(*delivery)) }
+
+func (q *deliveryQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	ev := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+	return ev
+}
+
+var (
+	defaultDifficulty = big.NewInt(1)
+	genesisTime       = uint64(1609459200) // 2021-01-01 00:00:00 UTC
+)
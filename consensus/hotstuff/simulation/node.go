@@ -0,0 +1,534 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package simulation
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/hotstuff"
+	"github.com/ethereum/go-ethereum/consensus/hotstuff/core"
+	"github.com/ethereum/go-ethereum/consensus/hotstuff/signer"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// blockPeriod is the interval of block timestamps in seconds.
+const blockPeriod = 1
+
+var (
+	errInvalidProposal = errors.New("invalid proposal")
+	errInvalidHeader   = errors.New("invalid header")
+	errNodeStopped     = errors.New("node stopped")
+)
+
+// Node is a simulated validator, it implements the hotstuff.Backend on top of
+// an in-memory chain and the virtual network.
+type Node struct {
+	index   int
+	address common.Address
+	net     *Network
+	signer  hotstuff.Signer
+	db      ethdb.Database // Database to persist the locked state across restarts
+	logger  log.Logger
+
+	lock      sync.RWMutex
+	mux       *event.TypeMux
+	engine    hotstuff.CoreEngine
+	running   bool
+	behaviour Behaviour
+	blocks    map[common.Hash]*types.Block
+	head      *types.Block
+
+	inboxLock sync.Mutex
+	inbox     []func()      // Deliveries handed over by the network, handled in order
+	notify    chan struct{} // Signals the arrival of deliveries
+}
+
+func newNode(net *Network, index int, key *ecdsa.PrivateKey) *Node {
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	return &Node{
+		index:   index,
+		address: address,
+		net:     net,
+		signer:  signer.NewSigner(key),
+		db:      rawdb.NewMemoryDatabase(),
+		logger:  log.New("node", index, "address", address),
+		mux:     new(event.TypeMux),
+		blocks:  make(map[common.Hash]*types.Block),
+		notify:  make(chan struct{}, 1),
+	}
+}
+
+// Index returns the index of node in the network.
+func (n *Node) Index() int {
+	return n.index
+}
+
+// Running returns true if the consensus engine of node is running.
+func (n *Node) Running() bool {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+
+	return n.running
+}
+
+// Behaviour returns the current behaviour of node.
+func (n *Node) Behaviour() Behaviour {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+
+	return n.behaviour
+}
+
+// Height returns the number of the chain head.
+func (n *Node) Height() uint64 {
+	return n.CurrentBlock().NumberU64()
+}
+
+// CurrentBlock returns the chain head.
+func (n *Node) CurrentBlock() *types.Block {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+
+	if n.head == nil {
+		return n.net.genesis
+	}
+	return n.head
+}
+
+// Stats returns the consensus statistics of node, nil if node is stopped.
+func (n *Node) Stats() *hotstuff.Stats {
+	n.lock.RLock()
+	engine, running := n.engine, n.running
+	n.lock.RUnlock()
+
+	if !running {
+		return nil
+	}
+	return engine.Stats()
+}
+
+func (n *Node) start() {
+	n.lock.Lock()
+	if n.running {
+		n.lock.Unlock()
+		return
+	}
+	if n.head == nil {
+		n.head = n.net.genesis
+		n.blocks[n.head.Hash()] = n.head
+	}
+	config := &hotstuff.Config{
+		RequestTimeout: uint64(n.net.config.RequestTimeout / time.Millisecond),
+		BlockPeriod:    blockPeriod,
+		LeaderPolicy:   hotstuff.RoundRobin,
+	}
+	// restarted engine never receives the events posted to the crashed one
+	n.mux = new(event.TypeMux)
+	n.engine = core.New(n, config, n.signer, n.db)
+	n.running = true
+	engine, head := n.engine, n.head
+	n.lock.Unlock()
+
+	n.logger.Info("Start simulated node", "height", head.NumberU64())
+	if err := engine.Start(nil); err != nil {
+		n.logger.Error("Failed to start consensus engine", "err", err)
+	}
+	n.propose(head)
+}
+
+func (n *Node) stop() {
+	n.lock.Lock()
+	if !n.running {
+		n.lock.Unlock()
+		return
+	}
+	n.running = false
+	engine, mux := n.engine, n.mux
+	n.lock.Unlock()
+
+	engine.Stop()
+	mux.Stop()
+	n.logger.Info("Stop simulated node", "height", n.Height())
+}
+
+func (n *Node) setBehaviour(behaviour Behaviour) {
+	n.lock.Lock()
+	n.behaviour = behaviour
+	n.lock.Unlock()
+
+	n.logger.Info("Change node behaviour", "behaviour", behaviour)
+}
+
+// run handles the deliveries of node one by one until the network stops, pending
+// deliveries of the previous run are dropped.
+func (n *Node) run(quit chan struct{}) {
+	n.inboxLock.Lock()
+	n.inbox = nil
+	n.inboxLock.Unlock()
+
+	go func() {
+		for {
+			select {
+			case <-n.notify:
+			case <-quit:
+				return
+			}
+			for {
+				n.inboxLock.Lock()
+				if len(n.inbox) == 0 {
+					n.inboxLock.Unlock()
+					break
+				}
+				fn := n.inbox[0]
+				n.inbox = n.inbox[1:]
+				n.inboxLock.Unlock()
+
+				fn()
+			}
+		}
+	}()
+}
+
+// enqueue appends a delivery to the inbox of node, it never blocks the caller.
+func (n *Node) enqueue(fn func()) {
+	n.inboxLock.Lock()
+	n.inbox = append(n.inbox, fn)
+	n.inboxLock.Unlock()
+
+	select {
+	case n.notify <- struct{}{}:
+	default:
+	}
+}
+
+// post delivers an event to the consensus engine through the inbox, so that
+// local events keep their order with the messages received from network.
+func (n *Node) post(ev interface{}) {
+	n.enqueue(func() {
+		if n.Running() {
+			n.EventMux().Post(ev)
+		}
+	})
+}
+
+// handleMessage posts the consensus message received from network to the engine.
+func (n *Node) handleMessage(payload []byte) {
+	if !n.Running() {
+		return
+	}
+	n.EventMux().Post(hotstuff.MessageEvent{Payload: payload})
+}
+
+// propose builds a new block on top of the parent and posts it to the engine,
+// just like the miner does after each new chain head.
+func (n *Node) propose(parent *types.Block) {
+	number := parent.NumberU64() + 1
+	extra, err := encodeExtra(n.net.nextValidators(number))
+	if err != nil {
+		n.logger.Error("Failed to encode extra", "err", err)
+		return
+	}
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Coinbase:   n.address,
+		Root:       parent.Root(),
+		TxHash:     types.EmptyRootHash,
+		UncleHash:  types.EmptyUncleHash,
+		Number:     new(big.Int).SetUint64(number),
+		Difficulty: defaultDifficulty,
+		Time:       parent.Time() + blockPeriod,
+		Extra:      extra,
+		MixDigest:  types.HotstuffDigest,
+	}
+	if err := n.signer.SealBeforeCommit(header); err != nil {
+		n.logger.Error("Failed to seal proposal", "err", err)
+		return
+	}
+	n.post(hotstuff.RequestEvent{Proposal: types.NewBlockWithHeader(header)})
+}
+
+// insert appends a block to the chain head, blocks which are not the child of
+// current head are ignored.
+func (n *Node) insert(block *types.Block) bool {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if block.ParentHash() != n.head.Hash() || block.NumberU64() != n.head.NumberU64()+1 {
+		return false
+	}
+	n.blocks[block.Hash()] = block
+	n.head = block
+	return true
+}
+
+// newHead notifies the engine of the new chain head and starts to propose
+// the next block.
+func (n *Node) newHead(block *types.Block) {
+	n.net.tracker.commit(n.index, n.Behaviour() == Honest, block)
+	n.logger.Debug("Imported new chain head", "number", block.NumberU64(), "hash", block.Hash())
+
+	n.post(hotstuff.FinalCommittedEvent{Header: block.Header()})
+	n.propose(block)
+}
+
+// importBlock imports a block propagated by the peer, it synchronises missing
+// ancestors from the peer if the block is not the child of current head.
+func (n *Node) importBlock(block *types.Block, peer *Node) {
+	if !n.Running() {
+		return
+	}
+	head := n.CurrentBlock()
+	if block.NumberU64() <= head.NumberU64() {
+		return
+	}
+	if block.NumberU64() > head.NumberU64()+1 {
+		n.sync(peer)
+		return
+	}
+	if err := n.verifyHeader(block.Header(), true); err != nil {
+		n.logger.Warn("Failed to verify propagated block", "number", block.NumberU64(), "hash", block.Hash(), "err", err)
+		return
+	}
+	if n.insert(block) {
+		n.newHead(block)
+	}
+}
+
+// sync fetches the blocks which are missing in local chain from the peer.
+func (n *Node) sync(peer *Node) {
+	head := n.CurrentBlock()
+
+	peer.lock.RLock()
+	var blocks []*types.Block
+	for block := peer.head; block != nil && block.NumberU64() > head.NumberU64(); block = peer.blocks[block.ParentHash()] {
+		blocks = append(blocks, block)
+	}
+	peer.lock.RUnlock()
+
+	for i := len(blocks) - 1; i >= 0; i-- {
+		block := blocks[i]
+		if err := n.verifyHeader(block.Header(), true); err != nil {
+			n.logger.Warn("Failed to verify synchronised block", "peer", peer.index, "number", block.NumberU64(), "err", err)
+			return
+		}
+		if !n.insert(block) {
+			return
+		}
+		n.newHead(block)
+	}
+}
+
+func (n *Node) verifyHeader(header *types.Header, seal bool) error {
+	if header.Number == nil || header.MixDigest != types.HotstuffDigest || header.UncleHash != types.EmptyUncleHash {
+		return errInvalidHeader
+	}
+	number := header.Number.Uint64()
+	if number == 0 {
+		return nil
+	}
+
+	n.lock.RLock()
+	parent := n.blocks[header.ParentHash]
+	n.lock.RUnlock()
+	if parent == nil || parent.NumberU64() != number-1 {
+		return consensus.ErrUnknownAncestor
+	}
+	_, err := n.signer.VerifyHeader(header, n.Validators(number), seal)
+	return err
+}
+
+// ==============================================
+//
+// define the functions that needs to be provided for hotstuff core.
+
+// Address implements hotstuff.Backend.Address
+func (n *Node) Address() common.Address {
+	return n.address
+}
+
+// Validators implements hotstuff.Backend.Validators
+func (n *Node) Validators(height uint64) hotstuff.ValidatorSet {
+	return n.net.validators(height)
+}
+
+// EventMux implements hotstuff.Backend.EventMux
+func (n *Node) EventMux() *event.TypeMux {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+
+	return n.mux
+}
+
+// Broadcast implements hotstuff.Backend.Broadcast
+func (n *Node) Broadcast(valSet hotstuff.ValidatorSet, payload []byte) error {
+	if err := n.Gossip(valSet, payload); err != nil {
+		return err
+	}
+	n.post(hotstuff.MessageEvent{Payload: payload})
+	return nil
+}
+
+// Gossip implements hotstuff.Backend.Gossip
+func (n *Node) Gossip(valSet hotstuff.ValidatorSet, payload []byte) error {
+	if !n.Running() {
+		return errNodeStopped
+	}
+	var targets []*Node
+	for _, node := range n.net.lookup(valSet) {
+		if node != n {
+			targets = append(targets, node)
+		}
+	}
+	for i, msg := range n.outgoing(payload, len(targets)) {
+		if msg != nil {
+			n.net.send(n, targets[i], msg)
+		}
+	}
+	return nil
+}
+
+// Unicast implements hotstuff.Backend.Unicast
+func (n *Node) Unicast(valSet hotstuff.ValidatorSet, payload []byte) error {
+	if !n.Running() {
+		return errNodeStopped
+	}
+	target := valSet.GetProposer().Address()
+	if target == n.address {
+		n.post(hotstuff.MessageEvent{Payload: payload})
+		return nil
+	}
+	for _, node := range n.net.nodes {
+		if node.address != target {
+			continue
+		}
+		if msg := n.outgoing(payload, 1)[0]; msg != nil {
+			n.net.send(n, node, msg)
+		}
+	}
+	return nil
+}
+
+// PreCommit implements hotstuff.Backend.PreCommit
+func (n *Node) PreCommit(proposal hotstuff.Proposal, seals [][]byte) (hotstuff.Proposal, error) {
+	block, ok := proposal.(*types.Block)
+	if !ok {
+		return nil, errInvalidProposal
+	}
+	h := block.Header()
+	if err := n.signer.SealAfterCommit(h, seals); err != nil {
+		return nil, err
+	}
+	return block.WithSeal(h), nil
+}
+
+// ForwardCommit implements hotstuff.Backend.ForwardCommit
+func (n *Node) ForwardCommit(proposal hotstuff.Proposal, extra []byte) (hotstuff.Proposal, error) {
+	block, ok := proposal.(*types.Block)
+	if !ok {
+		return nil, errInvalidProposal
+	}
+	h := block.Header()
+	h.Extra = extra
+	return block.WithSeal(h), nil
+}
+
+// Commit implements hotstuff.Backend.Commit, the committed block is written
+// into local chain and propagated to all of the other nodes.
+func (n *Node) Commit(proposal hotstuff.Proposal) error {
+	block, ok := proposal.(*types.Block)
+	if !ok {
+		return errInvalidProposal
+	}
+	if !n.Running() {
+		return errNodeStopped
+	}
+	n.logger.Info("Committed", "number", block.NumberU64(), "hash", block.Hash())
+	n.net.tracker.commit(n.index, n.Behaviour() == Honest, block)
+	if n.insert(block) {
+		n.newHead(block)
+	}
+	n.net.broadcastBlock(n, block)
+	return nil
+}
+
+// Verify implements hotstuff.Backend.Verify
+func (n *Node) Verify(proposal hotstuff.Proposal) (time.Duration, error) {
+	block, ok := proposal.(*types.Block)
+	if !ok {
+		return 0, errInvalidProposal
+	}
+	return 0, n.verifyHeader(block.Header(), false)
+}
+
+// VerifyUnsealedProposal implements hotstuff.Backend.VerifyUnsealedProposal
+func (n *Node) VerifyUnsealedProposal(proposal hotstuff.Proposal) (time.Duration, error) {
+	return n.Verify(proposal)
+}
+
+// LastProposal implements hotstuff.Backend.LastProposal
+func (n *Node) LastProposal() (hotstuff.Proposal, common.Address) {
+	block := n.CurrentBlock()
+	if block.NumberU64() == 0 {
+		return block, common.Address{}
+	}
+	return block, block.Coinbase()
+}
+
+// HasBadProposal implements hotstuff.Backend.HasBadProposal
+func (n *Node) HasBadProposal(hash common.Hash) bool {
+	return false
+}
+
+// ValidateBlock implements hotstuff.Backend.ValidateBlock, blocks of simulated
+// chain carry no transactions so that there is no state to be validated.
+func (n *Node) ValidateBlock(block *types.Block) error {
+	return nil
+}
+
+// Close implements hotstuff.Backend.Close
+func (n *Node) Close() error {
+	return nil
+}
+
+// encodeExtra generates the hotstuff extra with empty seals.
+func encodeExtra(validators []common.Address) ([]byte, error) {
+	extra := &types.HotstuffExtra{
+		Validators:    validators,
+		Seal:          make([]byte, types.HotstuffExtraSeal),
+		CommittedSeal: [][]byte{},
+	}
+	payload, err := rlp.EncodeToBytes(&extra)
+	if err != nil {
+		return nil, err
+	}
+	return append(bytes.Repeat([]byte{0x00}, types.HotstuffExtraVanity), payload...), nil
+}
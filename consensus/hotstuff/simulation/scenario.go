@@ -0,0 +1,261 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package simulation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/ethereum/go-ethereum/consensus/hotstuff"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// Duration is a time.Duration which is encoded as string like "1.5s" in json.
+type Duration time.Duration
+
+// MarshalJSON implements json.Marshaler
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (d *Duration) UnmarshalJSON(input []byte) error {
+	var str string
+	if err := json.Unmarshal(input, &str); err != nil {
+		return err
+	}
+	v, err := time.ParseDuration(str)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// Scenario actions
+const (
+	ActionCrash     = "crash"     // crash nodes
+	ActionRestart   = "restart"   // restart crashed nodes
+	ActionPartition = "partition" // split the network into groups
+	ActionHeal      = "heal"      // remove all partitions
+	ActionBehaviour = "behaviour" // change the behaviour of nodes
+	ActionLoss      = "loss"      // change the message loss rate
+	ActionLatency   = "latency"   // change the message latency
+)
+
+// Step is a fault injection of scenario. The step waits until all of the running
+// honest nodes reach the height, then waits for the duration before the action
+// is applied.
+type Step struct {
+	Height    uint64   `json:"height,omitempty"`
+	After     Duration `json:"after,omitempty"`
+	Action    string   `json:"action"`
+	Nodes     []int    `json:"nodes,omitempty"`
+	Groups    [][]int  `json:"groups,omitempty"`
+	Behaviour string   `json:"behaviour,omitempty"`
+	Loss      float64  `json:"loss,omitempty"`
+	Latency   Duration `json:"latency,omitempty"`
+	Jitter    Duration `json:"jitter,omitempty"`
+}
+
+func (s *Step) String() string {
+	return fmt.Sprintf("{Action: %s, Height: %d, After: %v, Nodes: %v}", s.Action, s.Height, time.Duration(s.After), s.Nodes)
+}
+
+// Expect is the liveness property of scenario, all of the running honest nodes
+// should reach the height before timeout.
+type Expect struct {
+	Height  uint64   `json:"height"`
+	Timeout Duration `json:"timeout"`
+}
+
+// Scenario describes a simulated network and the faults injected into it.
+type Scenario struct {
+	Name           string   `json:"name"`
+	Nodes          int      `json:"nodes"`
+	Seed           int64    `json:"seed,omitempty"`
+	RequestTimeout Duration `json:"requestTimeout,omitempty"`
+	Latency        Duration `json:"latency,omitempty"`
+	Jitter         Duration `json:"jitter,omitempty"`
+	Loss           float64  `json:"loss,omitempty"`
+	Epochs         []*Epoch `json:"epochs,omitempty"`
+	Steps          []*Step  `json:"steps,omitempty"`
+	Expect         Expect   `json:"expect"`
+}
+
+// LoadScenario reads a scenario from the json file.
+func LoadScenario(path string) (*Scenario, error) {
+	blob, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	scenario := new(Scenario)
+	if err := json.Unmarshal(blob, scenario); err != nil {
+		return nil, fmt.Errorf("invalid scenario %s: %v", path, err)
+	}
+	if scenario.Name == "" {
+		scenario.Name = path
+	}
+	return scenario, nil
+}
+
+// Config returns the network config of scenario, unset fields are filled with
+// the default settings.
+func (s *Scenario) Config() *Config {
+	config := *DefaultConfig
+	if s.Nodes > 0 {
+		config.Nodes = s.Nodes
+	}
+	if s.Seed != 0 {
+		config.Seed = s.Seed
+	}
+	if s.RequestTimeout > 0 {
+		config.RequestTimeout = time.Duration(s.RequestTimeout)
+	}
+	if s.Latency > 0 {
+		config.Latency = time.Duration(s.Latency)
+	}
+	if s.Jitter > 0 {
+		config.Jitter = time.Duration(s.Jitter)
+	}
+	config.Loss = s.Loss
+	config.Epochs = s.Epochs
+	return &config
+}
+
+// Result is the outcome of a scenario run.
+type Result struct {
+	Name     string            `json:"name"`
+	Elapsed  time.Duration     `json:"elapsed"`
+	Heights  []uint64          `json:"heights"`
+	Stats    []*hotstuff.Stats `json:"stats"`
+	Sent     uint64            `json:"sent"`
+	Dropped  uint64            `json:"dropped"`
+	Safety   error             `json:"-"`
+	Liveness error             `json:"-"`
+}
+
+// Err returns the violated property of the scenario, nil if the scenario passed.
+func (r *Result) Err() error {
+	if r.Safety != nil {
+		return fmt.Errorf("safety violated: %v", r.Safety)
+	}
+	if r.Liveness != nil {
+		return fmt.Errorf("liveness violated: %v", r.Liveness)
+	}
+	return nil
+}
+
+// Run runs the scenario to the end, the safety is checked after each step and
+// the liveness is checked at last. Errors returned only if the scenario itself
+// is invalid, violated properties are reported in the result.
+func Run(s *Scenario) (*Result, error) {
+	if s.Expect.Height == 0 || s.Expect.Timeout <= 0 {
+		return nil, errors.New("scenario expectation not specified")
+	}
+	for _, step := range s.Steps {
+		if err := step.validate(); err != nil {
+			return nil, fmt.Errorf("step %v: %v", step, err)
+		}
+	}
+	net, err := NewNetwork(s.Config())
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	deadline := start.Add(time.Duration(s.Expect.Timeout))
+
+	net.Start()
+	defer net.Stop()
+
+	result := &Result{Name: s.Name}
+	for _, step := range s.Steps {
+		if step.Height > 0 {
+			if err := net.WaitHeight(step.Height, time.Until(deadline)); err != nil {
+				result.Liveness = fmt.Errorf("step %v: %v", step, err)
+				break
+			}
+		}
+		time.Sleep(time.Duration(step.After))
+		if err := net.apply(step); err != nil {
+			return nil, fmt.Errorf("step %v: %v", step, err)
+		}
+		log.Info("Applied scenario step", "step", step, "heights", net.Heights())
+
+		if result.Safety = net.CheckSafety(); result.Safety != nil {
+			break
+		}
+	}
+	if result.Safety == nil && result.Liveness == nil {
+		result.Liveness = net.WaitHeight(s.Expect.Height, time.Until(deadline))
+		result.Safety = net.CheckSafety()
+	}
+
+	result.Elapsed = time.Since(start)
+	result.Heights = net.Heights()
+	for _, node := range net.Nodes() {
+		result.Stats = append(result.Stats, node.Stats())
+	}
+	result.Sent, result.Dropped = net.Traffic()
+	return result, nil
+}
+
+// validate checks the action of step before the scenario starts.
+func (s *Step) validate() error {
+	switch s.Action {
+	case ActionCrash, ActionRestart, ActionPartition, ActionHeal, ActionLoss, ActionLatency:
+		return nil
+	case ActionBehaviour:
+		_, err := ParseBehaviour(s.Behaviour)
+		return err
+	default:
+		return fmt.Errorf("unknown action %q", s.Action)
+	}
+}
+
+// apply applies the fault injection of a scenario step.
+func (net *Network) apply(step *Step) error {
+	switch step.Action {
+	case ActionCrash:
+		return net.Crash(step.Nodes...)
+	case ActionRestart:
+		return net.Restart(step.Nodes...)
+	case ActionPartition:
+		return net.Partition(step.Groups...)
+	case ActionHeal:
+		net.Heal()
+		return nil
+	case ActionBehaviour:
+		behaviour, err := ParseBehaviour(step.Behaviour)
+		if err != nil {
+			return err
+		}
+		return net.SetBehaviour(behaviour, step.Nodes...)
+	case ActionLoss:
+		return net.SetLoss(step.Loss)
+	case ActionLatency:
+		net.SetLatency(time.Duration(step.Latency), time.Duration(step.Jitter))
+		return nil
+	default:
+		return fmt.Errorf("unknown action %q", step.Action)
+	}
+}
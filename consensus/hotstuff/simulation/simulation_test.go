@@ -0,0 +1,225 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package simulation
+
+import (
+	"container/heap"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestNetwork(t *testing.T, nodes int, epochs ...*Epoch) *Network {
+	config := *DefaultConfig
+	config.Nodes = nodes
+	config.Epochs = epochs
+	net, err := NewNetwork(&config)
+	if err != nil {
+		t.Fatalf("failed to create network: %v", err)
+	}
+	net.Start()
+	t.Cleanup(net.Stop)
+	return net
+}
+
+func TestNormalConsensus(t *testing.T) {
+	net := newTestNetwork(t, 4)
+	assert.NoError(t, net.WaitHeight(5, 30*time.Second))
+	assert.NoError(t, net.CheckSafety())
+}
+
+func TestCrashAndRestart(t *testing.T) {
+	net := newTestNetwork(t, 4)
+	assert.NoError(t, net.WaitHeight(3, 30*time.Second))
+
+	// the remaining validators still form a quorum and keep producing blocks
+	// after view changes at the heights proposed by the crashed node
+	assert.NoError(t, net.Crash(1))
+	assert.NoError(t, net.WaitHeight(8, 60*time.Second))
+
+	assert.NoError(t, net.Restart(1))
+	assert.NoError(t, net.WaitHeight(10, 60*time.Second))
+	assert.NoError(t, net.CheckSafety())
+}
+
+func TestPartition(t *testing.T) {
+	net := newTestNetwork(t, 4)
+	assert.NoError(t, net.WaitHeight(2, 30*time.Second))
+
+	// neither of the groups is able to reach a quorum
+	assert.NoError(t, net.Partition([]int{0, 1}, []int{2, 3}))
+	time.Sleep(2 * time.Second)
+	heights := net.Heights()
+	time.Sleep(2 * time.Second)
+	for i, height := range net.Heights() {
+		assert.LessOrEqual(t, height, heights[i]+1, "node %d", i)
+	}
+
+	net.Heal()
+	assert.NoError(t, net.WaitHeight(heights[0]+3, 90*time.Second))
+	assert.NoError(t, net.CheckSafety())
+}
+
+func TestByzantineBehaviours(t *testing.T) {
+	for _, behaviour := range []Behaviour{Silent, WithholdVotes, Equivocate} {
+		t.Run(behaviour.String(), func(t *testing.T) {
+			net := newTestNetwork(t, 4)
+			assert.NoError(t, net.SetBehaviour(behaviour, 2))
+			assert.NoError(t, net.WaitHeight(6, 60*time.Second))
+			assert.NoError(t, net.CheckSafety())
+		})
+	}
+}
+
+func TestEpochChange(t *testing.T) {
+	net := newTestNetwork(t, 5, &Epoch{Height: 4, Validators: []int{1, 2, 3, 4}})
+	assert.NoError(t, net.WaitHeight(3, 30*time.Second))
+
+	// node 0 leaves the validator set at height 4
+	assert.NoError(t, net.Crash(0))
+	assert.NoError(t, net.WaitHeight(8, 30*time.Second))
+	assert.NoError(t, net.CheckSafety())
+}
+
+func TestTrackerConflict(t *testing.T) {
+	net, err := NewNetwork(DefaultConfig)
+	assert.NoError(t, err)
+
+	tracker := newTracker()
+	tracker.commit(0, true, net.genesis)
+	tracker.commit(1, true, net.genesis)
+	assert.NoError(t, tracker.check())
+
+	// conflicting blocks committed by byzantine nodes are ignored
+	header := net.genesis.Header()
+	header.Time++
+	conflict := types.NewBlockWithHeader(header)
+	tracker.commit(2, false, conflict)
+	assert.NoError(t, tracker.check())
+
+	tracker.commit(3, true, conflict)
+	assert.Error(t, tracker.check())
+}
+
+func TestDeterministicRoute(t *testing.T) {
+	config := *DefaultConfig
+	config.Loss = 0.3
+	config.Jitter = 50 * time.Millisecond
+
+	type decision struct {
+		delay time.Duration
+		ok    bool
+	}
+	route := func(config *Config, reverse bool) map[int]decision {
+		net, err := NewNetwork(config)
+		assert.NoError(t, err)
+		net.running = true
+
+		decisions := make(map[int]decision)
+		for i := 0; i < 200; i++ {
+			n := i
+			if reverse {
+				n = 199 - i
+			}
+			from, to := net.nodes[n%4], net.nodes[(n+1)%4]
+			delay, ok := net.route(from, to, []byte{byte(n)}, true)
+			decisions[n] = decision{delay, ok}
+		}
+		return decisions
+	}
+
+	// decisions depend on the seed, the link and the message only, the order in
+	// which the nodes send messages makes no difference
+	first := route(&config, false)
+	assert.Equal(t, first, route(&config, true))
+
+	dropped := 0
+	for _, d := range first {
+		if !d.ok {
+			dropped++
+			continue
+		}
+		assert.GreaterOrEqual(t, d.delay, config.Latency)
+		assert.Less(t, d.delay, config.Latency+config.Jitter)
+	}
+	assert.InDelta(t, 60, dropped, 30)
+
+	other := config
+	other.Seed = 2
+	assert.NotEqual(t, first, route(&other, false))
+}
+
+func TestDeterministicGenesis(t *testing.T) {
+	first, err := NewNetwork(DefaultConfig)
+	assert.NoError(t, err)
+	second, err := NewNetwork(DefaultConfig)
+	assert.NoError(t, err)
+
+	assert.Equal(t, first.genesis.Hash(), second.genesis.Hash())
+	for i := range first.nodes {
+		assert.Equal(t, first.nodes[i].address, second.nodes[i].address)
+	}
+}
+
+func TestDeliveryQueue(t *testing.T) {
+	var (
+		queue deliveryQueue
+		order []int
+	)
+	push := func(at time.Duration, seq uint64, id int) {
+		heap.Push(&queue, &delivery{at: at, seq: seq, fn: func() { order = append(order, id) }})
+	}
+	push(20*time.Millisecond, 1, 3)
+	push(10*time.Millisecond, 2, 1)
+	push(10*time.Millisecond, 3, 2)
+	push(5*time.Millisecond, 4, 0)
+
+	for queue.Len() > 0 {
+		heap.Pop(&queue).(*delivery).fn()
+	}
+	// earliest delivery first, scheduling order among deliveries due at the same time
+	assert.Equal(t, []int{0, 1, 2, 3}, order)
+}
+
+func TestRunScenario(t *testing.T) {
+	blob := `{
+		"name": "crash",
+		"nodes": 4,
+		"steps": [
+			{"height": 2, "action": "crash", "nodes": [3]},
+			{"height": 5, "action": "restart", "nodes": [3]}
+		],
+		"expect": {"height": 7, "timeout": "60s"}
+	}`
+	scenario := new(Scenario)
+	assert.NoError(t, json.Unmarshal([]byte(blob), scenario))
+	assert.Equal(t, Duration(time.Minute), scenario.Expect.Timeout)
+
+	result, err := Run(scenario)
+	assert.NoError(t, err)
+	assert.NoError(t, result.Err())
+	assert.Len(t, result.Heights, 4)
+
+	scenario.Steps = append(scenario.Steps, &Step{Action: "unknown"})
+	_, err = Run(scenario)
+	assert.Error(t, err)
+}
@@ -0,0 +1,91 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package simulation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// tracker records the blocks committed by every node to check the safety of
+// consensus, honest nodes should never commit different blocks at the same height.
+type tracker struct {
+	lock    sync.Mutex
+	commits map[uint64]map[common.Hash]map[int]struct{} // height -> block hash -> honest nodes
+}
+
+func newTracker() *tracker {
+	return &tracker{
+		commits: make(map[uint64]map[common.Hash]map[int]struct{}),
+	}
+}
+
+// commit records the block committed or imported by node, blocks of byzantine
+// nodes are ignored.
+func (t *tracker) commit(node int, honest bool, block *types.Block) {
+	if !honest {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	height := block.NumberU64()
+	if t.commits[height] == nil {
+		t.commits[height] = make(map[common.Hash]map[int]struct{})
+	}
+	if t.commits[height][block.Hash()] == nil {
+		t.commits[height][block.Hash()] = make(map[int]struct{})
+	}
+	t.commits[height][block.Hash()][node] = struct{}{}
+}
+
+// check returns an error which describes the lowest height at which honest
+// nodes committed conflicting blocks.
+func (t *tracker) check() error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	heights := make([]uint64, 0, len(t.commits))
+	for height, blocks := range t.commits {
+		if len(blocks) > 1 {
+			heights = append(heights, height)
+		}
+	}
+	if len(heights) == 0 {
+		return nil
+	}
+	sort.Slice(heights, func(i, j int) bool { return heights[i] < heights[j] })
+
+	var conflicts []string
+	for hash, nodes := range t.commits[heights[0]] {
+		list := make([]int, 0, len(nodes))
+		for node := range nodes {
+			list = append(list, node)
+		}
+		sort.Ints(list)
+		conflicts = append(conflicts, fmt.Sprintf("%s by nodes %v", hash.TerminalString(), list))
+	}
+	sort.Strings(conflicts)
+	return fmt.Errorf("conflicting commits at height %d: %s", heights[0], strings.Join(conflicts, ", "))
+}
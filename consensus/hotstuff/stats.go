@@ -0,0 +1,41 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package hotstuff
+
+import "github.com/ethereum/go-ethereum/common"
+
+// ValidatorStats records the participation of a single validator in the quorum
+// certificates observed by the local node.
+type ValidatorStats struct {
+	Address    common.Address `json:"address"`
+	Signed     uint64         `json:"signed"`     // number of QCs carrying the validator's committed seal
+	Missed     uint64         `json:"missed"`     // number of QCs without the validator's committed seal
+	LastSigned uint64         `json:"lastSigned"` // height of the last QC signed by the validator
+}
+
+// Stats is a snapshot of the local consensus progress, it is used by health
+// reporting services such as ethstats.
+type Stats struct {
+	Height      uint64            `json:"height"`
+	Round       uint64            `json:"round"`
+	Proposer    common.Address    `json:"proposer"`
+	ViewChanges uint64            `json:"viewChanges"`
+	Timeouts    uint64            `json:"timeouts"`
+	Validators  []*ValidatorStats `json:"validators"`
+}
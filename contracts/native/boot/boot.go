@@ -18,6 +18,11 @@
 package boot
 
 import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/contracts/native"
 	"github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager"
 	mlp "github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/zion/mainchain/lock_proxy"
 	slp "github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/zion/sidechain/lock_proxy"
@@ -26,33 +31,96 @@ import (
 	"github.com/ethereum/go-ethereum/contracts/native/governance/relayer_manager"
 	"github.com/ethereum/go-ethereum/contracts/native/governance/side_chain_manager"
 	"github.com/ethereum/go-ethereum/contracts/native/header_sync"
-	"github.com/ethereum/go-ethereum/contracts/native/utils"
+	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
 )
 
-func InitMainChainNativeContracts() {
-	header_sync.InitHeaderSync()
-	cross_chain_manager.InitCrossChainManager()
-	neo3_state_manager.InitNeo3StateManager()
-	node_manager.InitNodeManager()
-	relayer_manager.InitRelayerManager()
-	side_chain_manager.InitSideChainManager()
-	mlp.InitLockProxy()
-
-	log.Info("Initialize main chain native contracts",
-		"header sync", utils.HeaderSyncContractAddress.Hex(),
-		"cross chain manager", utils.CrossChainManagerContractAddress.Hex(),
-		"neo3 state manager", utils.Neo3StateManagerContractAddress.Hex(),
-		"node manager", utils.NodeManagerContractAddress.Hex(),
-		"relayer manager", utils.RelayerManagerContractAddress.Hex(),
-		"side chain manager", utils.SideChainManagerContractAddress.Hex(),
-		"native lock proxy", utils.LockProxyContractAddress.Hex())
+var registerOnce sync.Once
+
+// InitNativeContracts register the native contracts of all chain roles, and validate the
+// contracts enabled in chain config. native calls are dispatched by the chain role carried
+// in the contract ref, so that chains running in one process do not interfere.
+func InitNativeContracts(config *params.ChainConfig) error {
+	zionConfig := config.ZionConfig()
+	if err := zionConfig.Validate(); err != nil {
+		return err
+	}
+	enabled, err := native.EnabledContracts(zionConfig)
+	if err != nil {
+		return err
+	}
+	registerOnce.Do(func() {
+		header_sync.InitHeaderSync()
+		cross_chain_manager.InitCrossChainManager()
+		neo3_state_manager.InitNeo3StateManager()
+		node_manager.InitNodeManager()
+		relayer_manager.InitRelayerManager()
+		side_chain_manager.InitSideChainManager()
+		mlp.InitLockProxy()
+		slp.InitLockProxy()
+	})
+
+	names := make([]string, 0, len(enabled))
+	for name := range enabled {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	ctx := []interface{}{"role", zionConfig.Role, "cross chain id", zionConfig.CrossChainID, "relay chain id", zionConfig.RelayChainID}
+	for _, name := range names {
+		ctx = append(ctx, name, enabled[name].Hex())
+	}
+	log.Info("Initialize native contracts", ctx...)
+	return nil
+}
+
+// CheckNativeUpgrades validate native contract upgrades declared in chain config, all
+// scheduled versions should be supported by current node. the upgrades are carried by
+// the chain config itself, so that chains running in one process do not interfere.
+func CheckNativeUpgrades(config *params.ChainConfig) error {
+	registry := native.RoleRegistry(config.ZionConfig().Role)
+	if registry == nil {
+		return fmt.Errorf("native upgrade: unknown zion chain role %s", config.ZionConfig().Role)
+	}
+	for _, v := range config.NativeUpgrades {
+		if !native.IsNativeContract(v.Contract) {
+			return fmt.Errorf("native upgrade: %s is not native contract address", v.Contract.Hex())
+		}
+		if v.Version == 0 || v.Block == nil {
+			return fmt.Errorf("native upgrade: invalid version %d or block of %s", v.Version, v.Contract.Hex())
+		}
+		if !registry.HasVersion(v.Contract, v.Version) {
+			return fmt.Errorf("native upgrade: version %d of %s is not supported, node should be upgraded", v.Version, v.Contract.Hex())
+		}
+		log.Info("Schedule native contract upgrade", "contract", v.Contract.Hex(), "version", v.Version, "block", v.Block)
+	}
+	return nil
 }
 
-func InitSideChainNativeContracts() {
-	node_manager.InitNodeManager()
-	slp.InitLockProxy()
-	log.Info("Initialize side chain native contracts",
-		"node manager", utils.NodeManagerContractAddress.Hex(),
-		"native lock proxy", utils.LockProxyContractAddress.Hex())
+// CheckApprovedUpgrades validate the native contract upgrades approved by governance in the
+// state of chain head. the node refuses to start if an unsupported version is active from
+// the next block, and warns the operator to upgrade before the pending ones activated.
+func CheckApprovedUpgrades(config *params.ChainConfig, statedb *state.StateDB, head uint64) error {
+	registry := native.RoleRegistry(config.ZionConfig().Role)
+	if registry == nil {
+		return fmt.Errorf("native upgrade: unknown zion chain role %s", config.ZionConfig().Role)
+	}
+	db := (*state.CacheDB)(statedb)
+	for _, addr := range native.NativeContractAddrMap {
+		upgrades, err := native.GetUpgrades(db, config.NativeUpgrades, addr)
+		if err != nil {
+			return err
+		}
+		for _, v := range upgrades {
+			if registry.HasVersion(addr, v.Version) {
+				continue
+			}
+			if v.Height <= head+1 {
+				return fmt.Errorf("native upgrade: version %d of %s is active from block %d but not supported, node should be upgraded", v.Version, addr.Hex(), v.Height)
+			}
+			log.Warn("Unsupported native contract upgrade approved, please upgrade the node before activation", "contract", addr.Hex(), "version", v.Version, "block", v.Height)
+		}
+	}
+	return nil
 }
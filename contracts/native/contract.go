@@ -25,6 +25,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/contracts/native/utils"
 	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/params"
 )
 
 type (
@@ -32,9 +33,45 @@ type (
 	MethodHandler   func(contract *NativeContract) ([]byte, error)
 )
 
-var (
-	Contracts = make(map[common.Address]RegisterService)
-)
+// Registry binds the native contract addresses to the implementations running on one
+// zion chain role. native calls are dispatched through the registry of the chain role
+// carried by the contract ref, so that the main chain and side chain contracts deployed
+// at the same address do not overwrite each other in one process.
+type Registry struct {
+	contracts map[common.Address]RegisterService            // version 0 implementations
+	versions  map[common.Address]map[uint64]RegisterService // implementations activated by upgrades
+}
+
+func newRegistry() *Registry {
+	return &Registry{
+		contracts: make(map[common.Address]RegisterService),
+		versions:  make(map[common.Address]map[uint64]RegisterService),
+	}
+}
+
+// registries map zion chain role to the native contracts running on it
+var registries = map[string]*Registry{
+	params.ZionRoleMain: newRegistry(),
+	params.ZionRoleSide: newRegistry(),
+}
+
+// RoleRegistry returns the native contract registry of zion chain role, nil returned
+// if the role is unknown.
+func RoleRegistry(role string) *Registry {
+	return registries[role]
+}
+
+// Register binds the implementation to the native contract address on all chain roles.
+func Register(addr common.Address, register RegisterService) {
+	for _, r := range registries {
+		r.Register(addr, register)
+	}
+}
+
+// Register binds the implementation to the native contract address.
+func (r *Registry) Register(addr common.Address, register RegisterService) {
+	r.contracts[addr] = register
+}
 
 type NativeContract struct {
 	ref      *ContractRef
@@ -96,9 +133,9 @@ func (s *NativeContract) Invoke() ([]byte, error) {
 	methodID := hexutil.Encode(ctx.Payload[:4])
 
 	// register methods
-	registerHandler, ok := Contracts[ctx.ContractAddress]
-	if !ok {
-		return nil, fmt.Errorf("failed to find contract: [%x]", ctx.ContractAddress)
+	registerHandler, err := s.lookupContract(ctx.ContractAddress)
+	if err != nil {
+		return nil, err
 	}
 	registerHandler(s)
 
@@ -27,6 +27,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/contracts/native"
 	scom "github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/common"
+	eth2 "github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/eth"
 	"github.com/ethereum/go-ethereum/contracts/native/governance/side_chain_manager"
 	"github.com/ethereum/go-ethereum/contracts/native/header_sync/bsc"
 	"github.com/ethereum/go-ethereum/contracts/native/header_sync/eth/types"
@@ -91,6 +92,14 @@ func verifyFromTx(native *native.NativeContract, proof, extra []byte, fromChainI
 		return nil, fmt.Errorf("verifyFromTx, GetCanonicalHeader height:%d, error:%s", height, err)
 	}
 
+	receiptMode, err := eth2.IsReceiptMode(sideChain)
+	if err != nil {
+		return nil, fmt.Errorf("verifyFromTx, %v", err)
+	}
+	if receiptMode {
+		return eth2.VerifyFromReceipt(proof, headerWithSum.Header.ReceiptHash, sideChain.CCMCAddress, extra)
+	}
+
 	bscProof := new(Proof)
 	err = json.Unmarshal(proof, bscProof)
 	if err != nil {
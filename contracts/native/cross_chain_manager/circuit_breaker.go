@@ -0,0 +1,329 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package cross_chain_manager
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/contracts/native"
+	scom "github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/common"
+	zutils "github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/zion/utils"
+	"github.com/ethereum/go-ethereum/contracts/native/governance/side_chain_manager"
+	"github.com/ethereum/go-ethereum/contracts/native/utils"
+	"github.com/ethereum/go-ethereum/rlp"
+	polycomm "github.com/polynetwork/poly/common"
+)
+
+// RateLimit caps the volume of a route (source chain, destination chain, asset) within a rolling
+// window of `Window` seconds, transfers exceeding it are queued and released after `Delay` seconds.
+// the limit of an empty asset caps the total volume of all assets between the chains.
+type RateLimit struct {
+	Window uint64
+	Limit  *big.Int
+	Delay  uint64
+}
+
+// RouteVolume records the volume of a route in the current and the previous window, the volume of
+// the rolling window is estimated by weighting the previous window with its overlap.
+type RouteVolume struct {
+	WindowStart uint64
+	Current     *big.Int
+	Previous    *big.Int
+}
+
+// roll moves the volume to the window containing `now`.
+func (v *RouteVolume) roll(now, window uint64) {
+	start := now - now%window
+	switch {
+	case start == v.WindowStart:
+	case start == v.WindowStart+window:
+		v.Previous, v.Current = v.Current, new(big.Int)
+	default:
+		v.Previous, v.Current = new(big.Int), new(big.Int)
+	}
+	v.WindowStart = start
+}
+
+// volume returns the estimated volume of the rolling window ending at `now`.
+func (v *RouteVolume) volume(now, window uint64) *big.Int {
+	overlap := new(big.Int).SetUint64(window - (now - v.WindowStart))
+	weighted := new(big.Int).Mul(v.Previous, overlap)
+	weighted.Div(weighted, new(big.Int).SetUint64(window))
+	return weighted.Add(weighted, v.Current)
+}
+
+// QueuedTransfer is a transfer exceeding its route limit, anyone can release it after `ReleaseTime`
+// unless the route has been paused in the meantime. `Relayer` committed the transfer at `CommitTime`,
+// it earns the relayer fee of the transfer once released.
+type QueuedTransfer struct {
+	SourceChainID uint64
+	ReleaseTime   uint64
+	Relayer       common.Address
+	CommitTime    uint64
+	Param         *scom.MakeTxParam
+}
+
+// transferVolume decodes the asset and amount of a transfer, zion lock proxies use rlp encoded
+// args while other lock proxies use the poly args layout. it returns a nil amount if the args
+// can not be decoded, such transfers are only subject to route pausing. the relayer fee of zion
+// transfers stays escrowed on the source chain, it is not part of the volume.
+func transferVolume(router uint64, args []byte) ([]byte, *big.Int) {
+	if router == utils.ZION_ROUTER {
+		txArgs, err := zutils.DecodeTxArgs(args)
+		if err != nil || txArgs.Amount == nil {
+			return nil, nil
+		}
+		return txArgs.ToAssetHash, new(big.Int).Set(txArgs.Amount)
+	}
+
+	source := polycomm.NewZeroCopySource(args)
+	asset, eof := source.NextVarBytes()
+	if eof {
+		return nil, nil
+	}
+	if _, eof = source.NextVarBytes(); eof {
+		return nil, nil
+	}
+	raw, eof := source.NextBytes(32)
+	if eof {
+		return nil, nil
+	}
+	// amount is a little endian uint256
+	amount := make([]byte, len(raw))
+	for i := range raw {
+		amount[len(raw)-1-i] = raw[i]
+	}
+	return asset, new(big.Int).SetBytes(amount)
+}
+
+// unwinding returns true for the zion lock proxy messages which unwind or settle transfers already
+// admitted by the circuit breaker, i.e. refunds and relayer fee settlements. they are neither paused
+// nor limited, otherwise the funds of admitted transfers are stuck with the route.
+func unwinding(srcChain *side_chain_manager.SideChain, txParam *scom.MakeTxParam) bool {
+	return srcChain.Router == utils.ZION_ROUTER && (txParam.Method == "refund" || txParam.Method == "settle")
+}
+
+// checkRouteLimit applies the circuit breaker of the transfer route. it fails if the route is paused,
+// and returns true if the transfer exceeds the limit of the asset or the limit of all assets between
+// the chains, and has been queued for delayed release. the volume is only accounted to the limits if
+// the transfer is not queued.
+func checkRouteLimit(s *native.NativeContract, srcChain *side_chain_manager.SideChain, txParam *scom.MakeTxParam) (bool, error) {
+	srcChainID, dstChainID := srcChain.ChainId, txParam.ToChainID
+	asset, amount := transferVolume(srcChain.Router, txParam.Args)
+	if err := checkRoutePaused(s, srcChainID, dstChainID, asset); err != nil {
+		return false, err
+	}
+	if amount == nil {
+		return false, nil
+	}
+
+	routes := [][]byte{nil}
+	if len(asset) > 0 {
+		routes = append(routes, asset)
+	}
+	var (
+		now     = s.ContractRef().BlockTime()
+		exceed  = false
+		delay   = uint64(0)
+		limited = make([][]byte, 0, len(routes))
+		volumes = make([]*RouteVolume, 0, len(routes))
+	)
+	for _, route := range routes {
+		limit, err := getRateLimit(s, srcChainID, dstChainID, route)
+		if err != nil {
+			return false, fmt.Errorf("getRateLimit error: %v", err)
+		}
+		if limit == nil {
+			continue
+		}
+		volume, err := getRouteVolume(s, srcChainID, dstChainID, route)
+		if err != nil {
+			return false, fmt.Errorf("getRouteVolume error: %v", err)
+		}
+		volume.roll(now, limit.Window)
+		if new(big.Int).Add(volume.volume(now, limit.Window), amount).Cmp(limit.Limit) > 0 {
+			exceed = true
+			if limit.Delay > delay {
+				delay = limit.Delay
+			}
+		}
+		limited = append(limited, route)
+		volumes = append(volumes, volume)
+	}
+	if !exceed {
+		for i, volume := range volumes {
+			volume.Current = new(big.Int).Add(volume.Current, amount)
+			if err := putRouteVolume(s, srcChainID, dstChainID, limited[i], volume); err != nil {
+				return false, fmt.Errorf("putRouteVolume error: %v", err)
+			}
+		}
+		return false, nil
+	}
+
+	queued := &QueuedTransfer{
+		SourceChainID: srcChainID,
+		ReleaseTime:   now + delay,
+		Relayer:       s.ContractRef().TxOrigin(),
+		CommitTime:    now,
+		Param:         txParam,
+	}
+	if exist, err := getQueuedTransfer(s, srcChainID, txParam.CrossChainID); err != nil {
+		return false, fmt.Errorf("getQueuedTransfer error: %v", err)
+	} else if exist != nil {
+		return false, fmt.Errorf("transfer %x is already queued", txParam.CrossChainID)
+	}
+	if err := putQueuedTransfer(s, queued); err != nil {
+		return false, fmt.Errorf("putQueuedTransfer error: %v", err)
+	}
+	if err := s.AddNotify(scom.ABI, []string{scom.EventQueueTransfer}, srcChainID, txParam.CrossChainID, queued.ReleaseTime); err != nil {
+		return false, fmt.Errorf("AddNotify error: %v", err)
+	}
+	return true, nil
+}
+
+// checkRoutePaused fails if either the route of the asset or all routes between the chains are paused.
+func checkRoutePaused(s *native.NativeContract, srcChainID, dstChainID uint64, asset []byte) error {
+	for _, key := range [][]byte{nil, asset} {
+		paused, err := s.GetCacheDB().Get(routeKey(PAUSED_ROUTE, srcChainID, dstChainID, key))
+		if err != nil {
+			return fmt.Errorf("get paused route error: %v", err)
+		}
+		if paused != nil {
+			return fmt.Errorf("route from chain %d to chain %d is paused", srcChainID, dstChainID)
+		}
+	}
+	return nil
+}
+
+func putRoutePaused(s *native.NativeContract, srcChainID, dstChainID uint64, asset []byte) {
+	s.GetCacheDB().Put(routeKey(PAUSED_ROUTE, srcChainID, dstChainID, asset), utils.BYTE_TRUE)
+}
+
+func removeRoutePaused(s *native.NativeContract, srcChainID, dstChainID uint64, asset []byte) {
+	s.GetCacheDB().Delete(routeKey(PAUSED_ROUTE, srcChainID, dstChainID, asset))
+}
+
+func getRateLimit(s *native.NativeContract, srcChainID, dstChainID uint64, asset []byte) (*RateLimit, error) {
+	blob, err := s.GetCacheDB().Get(routeKey(RATE_LIMIT, srcChainID, dstChainID, asset))
+	if err != nil {
+		return nil, err
+	}
+	if blob == nil {
+		return nil, nil
+	}
+	limit := new(RateLimit)
+	if err := rlp.DecodeBytes(blob, limit); err != nil {
+		return nil, err
+	}
+	return limit, nil
+}
+
+func putRateLimit(s *native.NativeContract, srcChainID, dstChainID uint64, asset []byte, limit *RateLimit) error {
+	blob, err := rlp.EncodeToBytes(limit)
+	if err != nil {
+		return err
+	}
+	s.GetCacheDB().Put(routeKey(RATE_LIMIT, srcChainID, dstChainID, asset), blob)
+	// volume of the previous limit is meaningless for the new window
+	s.GetCacheDB().Delete(routeKey(ROUTE_VOLUME, srcChainID, dstChainID, asset))
+	return nil
+}
+
+func removeRateLimit(s *native.NativeContract, srcChainID, dstChainID uint64, asset []byte) {
+	s.GetCacheDB().Delete(routeKey(RATE_LIMIT, srcChainID, dstChainID, asset))
+	s.GetCacheDB().Delete(routeKey(ROUTE_VOLUME, srcChainID, dstChainID, asset))
+}
+
+func getRouteVolume(s *native.NativeContract, srcChainID, dstChainID uint64, asset []byte) (*RouteVolume, error) {
+	volume := &RouteVolume{Current: new(big.Int), Previous: new(big.Int)}
+	blob, err := s.GetCacheDB().Get(routeKey(ROUTE_VOLUME, srcChainID, dstChainID, asset))
+	if err != nil {
+		return nil, err
+	}
+	if blob == nil {
+		return volume, nil
+	}
+	if err := rlp.DecodeBytes(blob, volume); err != nil {
+		return nil, err
+	}
+	return volume, nil
+}
+
+func putRouteVolume(s *native.NativeContract, srcChainID, dstChainID uint64, asset []byte, volume *RouteVolume) error {
+	blob, err := rlp.EncodeToBytes(volume)
+	if err != nil {
+		return err
+	}
+	s.GetCacheDB().Put(routeKey(ROUTE_VOLUME, srcChainID, dstChainID, asset), blob)
+	return nil
+}
+
+func getQueuedTransfer(s *native.NativeContract, srcChainID uint64, crossChainID []byte) (*QueuedTransfer, error) {
+	blob, err := s.GetCacheDB().Get(queuedTransferKey(srcChainID, crossChainID))
+	if err != nil {
+		return nil, err
+	}
+	if blob == nil {
+		return nil, nil
+	}
+	queued := new(QueuedTransfer)
+	if err := rlp.DecodeBytes(blob, queued); err != nil {
+		return nil, err
+	}
+	return queued, nil
+}
+
+func putQueuedTransfer(s *native.NativeContract, queued *QueuedTransfer) error {
+	blob, err := rlp.EncodeToBytes(queued)
+	if err != nil {
+		return err
+	}
+	s.GetCacheDB().Put(queuedTransferKey(queued.SourceChainID, queued.Param.CrossChainID), blob)
+	return nil
+}
+
+func removeQueuedTransfer(s *native.NativeContract, srcChainID uint64, crossChainID []byte) {
+	s.GetCacheDB().Delete(queuedTransferKey(srcChainID, crossChainID))
+}
+
+func getGuardian(s *native.NativeContract) (common.Address, error) {
+	blob, err := s.GetCacheDB().Get(utils.ConcatKey(this, []byte(GUARDIAN)))
+	if err != nil {
+		return common.EmptyAddress, err
+	}
+	return common.BytesToAddress(blob), nil
+}
+
+func putGuardian(s *native.NativeContract, guardian common.Address) {
+	if guardian == common.EmptyAddress {
+		s.GetCacheDB().Delete(utils.ConcatKey(this, []byte(GUARDIAN)))
+		return
+	}
+	s.GetCacheDB().Put(utils.ConcatKey(this, []byte(GUARDIAN)), guardian.Bytes())
+}
+
+func routeKey(prefix string, srcChainID, dstChainID uint64, asset []byte) []byte {
+	return utils.ConcatKey(this, []byte(prefix), utils.GetUint64Bytes(srcChainID), utils.GetUint64Bytes(dstChainID), asset)
+}
+
+func queuedTransferKey(srcChainID uint64, crossChainID []byte) []byte {
+	return utils.ConcatKey(this, []byte(QUEUED_TRANSFER), utils.GetUint64Bytes(srcChainID), crossChainID)
+}
@@ -0,0 +1,341 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package cross_chain_manager
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/contracts/native"
+	scom "github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/common"
+	zutils "github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/zion/utils"
+	"github.com/ethereum/go-ethereum/contracts/native/governance/side_chain_manager"
+	"github.com/ethereum/go-ethereum/contracts/native/utils"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+	polycomm "github.com/polynetwork/poly/common"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testSrcChainID = uint64(100)
+	testDstChainID = uint64(101)
+)
+
+var testAsset = common.HexToAddress("0xa").Bytes()
+
+func newTestStateDB(t *testing.T) *state.StateDB {
+	sdb, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	assert.NoError(t, err)
+	return sdb
+}
+
+func newTestContract(sdb *state.StateDB, caller common.Address, blockTime uint64, payload []byte) *native.NativeContract {
+	ref := native.NewContractRef(sdb, caller, caller, big.NewInt(1), common.Hash{}, 0, nil)
+	ref.SetBlockTime(blockTime)
+	ref.PushContext(&native.Context{Caller: caller, ContractAddress: this, Payload: payload})
+	return native.NewNativeContract(sdb, ref)
+}
+
+func testPolyTxArgs(asset []byte, amount *big.Int) []byte {
+	sink := polycomm.NewZeroCopySink(nil)
+	sink.WriteVarBytes(asset)
+	sink.WriteVarBytes(common.HexToAddress("0xb").Bytes())
+	raw := make([]byte, 32)
+	for i, b := range common.LeftPadBytes(amount.Bytes(), 32) {
+		raw[31-i] = b
+	}
+	sink.WriteBytes(raw)
+	return sink.Bytes()
+}
+
+func testTxParam(crossChainID byte, amount *big.Int) *scom.MakeTxParam {
+	return &scom.MakeTxParam{
+		TxHash:              []byte{crossChainID},
+		CrossChainID:        []byte{crossChainID},
+		FromContractAddress: common.HexToAddress("0xc").Bytes(),
+		ToChainID:           testDstChainID,
+		ToContractAddress:   common.HexToAddress("0xd").Bytes(),
+		Method:              "unlock",
+		Args:                testPolyTxArgs(testAsset, amount),
+	}
+}
+
+func TestRouteVolume(t *testing.T) {
+	window := uint64(100)
+	volume := &RouteVolume{Current: new(big.Int), Previous: new(big.Int)}
+
+	volume.roll(1010, window)
+	volume.Current.Add(volume.Current, big.NewInt(100))
+	assert.Equal(t, uint64(1000), volume.WindowStart)
+	assert.Equal(t, big.NewInt(100), volume.volume(1050, window))
+
+	// half of the previous window overlaps with the rolling window
+	volume.roll(1150, window)
+	assert.Equal(t, big.NewInt(100), volume.Previous)
+	assert.Equal(t, big.NewInt(50), volume.volume(1150, window))
+
+	// windows without transfer reset the volume
+	volume.roll(1350, window)
+	assert.Equal(t, 0, volume.volume(1350, window).Sign())
+}
+
+func TestTransferVolume(t *testing.T) {
+	asset, amount := transferVolume(utils.ETH_ROUTER, testPolyTxArgs(testAsset, big.NewInt(12345)))
+	assert.Equal(t, testAsset, asset)
+	assert.Equal(t, big.NewInt(12345), amount)
+
+	args, err := rlp.EncodeToBytes(&scom.TxArgs{ToAssetHash: common.EmptyAddress.Bytes(), ToAddress: testAsset, Amount: big.NewInt(100), Fee: big.NewInt(3)})
+	assert.NoError(t, err)
+	asset, amount = transferVolume(utils.ZION_ROUTER, args)
+	assert.Equal(t, common.EmptyAddress.Bytes(), asset)
+	assert.Equal(t, big.NewInt(100), amount)
+
+	_, amount = transferVolume(utils.ETH_ROUTER, []byte{0x01})
+	assert.Nil(t, amount)
+}
+
+func TestUnwinding(t *testing.T) {
+	zion := &side_chain_manager.SideChain{ChainId: testSrcChainID, Router: utils.ZION_ROUTER}
+	for method, expect := range map[string]bool{"refund": true, "settle": true, "unlock": false, "mint": false} {
+		param := testTxParam(1, big.NewInt(1))
+		param.Method = method
+		assert.Equal(t, expect, unwinding(zion, param), method)
+	}
+
+	// only zion lock proxy messages are recognized
+	param := testTxParam(1, big.NewInt(1))
+	param.Method = "refund"
+	assert.False(t, unwinding(&side_chain_manager.SideChain{ChainId: testSrcChainID, Router: utils.ETH_ROUTER}, param))
+}
+
+func TestCheckRouteLimit(t *testing.T) {
+	sdb := newTestStateDB(t)
+	s := newTestContract(sdb, common.Address{}, 1000, nil)
+	srcChain := &side_chain_manager.SideChain{ChainId: testSrcChainID, Router: utils.ETH_ROUTER}
+	limit := &RateLimit{Window: 100, Limit: big.NewInt(100), Delay: 50}
+	assert.NoError(t, putRateLimit(s, testSrcChainID, testDstChainID, testAsset, limit))
+
+	queued, err := checkRouteLimit(s, srcChain, testTxParam(1, big.NewInt(60)))
+	assert.NoError(t, err)
+	assert.False(t, queued)
+
+	// exceeds the limit within the rolling window
+	queued, err = checkRouteLimit(s, srcChain, testTxParam(2, big.NewInt(60)))
+	assert.NoError(t, err)
+	assert.True(t, queued)
+
+	got, err := getQueuedTransfer(s, testSrcChainID, []byte{2})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1050), got.ReleaseTime)
+	assert.Equal(t, testTxParam(2, big.NewInt(60)), got.Param)
+
+	// the volume of the previous window fades out
+	s = newTestContract(sdb, common.Address{}, 1180, nil)
+	queued, err = checkRouteLimit(s, srcChain, testTxParam(3, big.NewInt(60)))
+	assert.NoError(t, err)
+	assert.False(t, queued)
+
+	// other assets are not limited
+	param := testTxParam(4, big.NewInt(1000))
+	param.Args = testPolyTxArgs(common.HexToAddress("0xe").Bytes(), big.NewInt(1000))
+	queued, err = checkRouteLimit(s, srcChain, param)
+	assert.NoError(t, err)
+	assert.False(t, queued)
+}
+
+func TestCheckChainPairLimit(t *testing.T) {
+	sdb := newTestStateDB(t)
+	s := newTestContract(sdb, common.Address{}, 1000, nil)
+	srcChain := &side_chain_manager.SideChain{ChainId: testSrcChainID, Router: utils.ZION_ROUTER}
+	pairLimit := &RateLimit{Window: 100, Limit: big.NewInt(100), Delay: 50}
+	assetLimit := &RateLimit{Window: 100, Limit: big.NewInt(1000), Delay: 80}
+	assert.NoError(t, putRateLimit(s, testSrcChainID, testDstChainID, nil, pairLimit))
+	assert.NoError(t, putRateLimit(s, testSrcChainID, testDstChainID, testAsset, assetLimit))
+
+	// the zion native asset is the zero address rather than an empty asset
+	zionTxParam := func(crossChainID byte, asset []byte, amount *big.Int) *scom.MakeTxParam {
+		param := testTxParam(crossChainID, amount)
+		args, err := zutils.EncodeTxArgs(asset, common.HexToAddress("0xb").Bytes(), amount)
+		assert.NoError(t, err)
+		param.Args = args
+		return param
+	}
+	queued, err := checkRouteLimit(s, srcChain, zionTxParam(1, common.EmptyAddress.Bytes(), big.NewInt(60)))
+	assert.NoError(t, err)
+	assert.False(t, queued)
+
+	// the volume of all assets is accounted to the chain pair limit
+	queued, err = checkRouteLimit(s, srcChain, zionTxParam(2, testAsset, big.NewInt(60)))
+	assert.NoError(t, err)
+	assert.True(t, queued)
+	got, err := getQueuedTransfer(s, testSrcChainID, []byte{2})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1050), got.ReleaseTime)
+
+	// queued transfers are not accounted to any limit
+	volume, err := getRouteVolume(s, testSrcChainID, testDstChainID, testAsset)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, volume.Current.Sign())
+	volume, err = getRouteVolume(s, testSrcChainID, testDstChainID, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(60), volume.Current)
+
+	// both limits are accounted once the chain pair volume fades out
+	s = newTestContract(sdb, common.Address{}, 1200, nil)
+	queued, err = checkRouteLimit(s, srcChain, zionTxParam(3, testAsset, big.NewInt(40)))
+	assert.NoError(t, err)
+	assert.False(t, queued)
+	for _, route := range [][]byte{nil, testAsset} {
+		volume, err := getRouteVolume(s, testSrcChainID, testDstChainID, route)
+		assert.NoError(t, err)
+		assert.Equal(t, big.NewInt(40), volume.Current)
+	}
+
+	// the longest delay of the exceeded limits applies
+	assert.NoError(t, putRateLimit(s, testSrcChainID, testDstChainID, testAsset, &RateLimit{Window: 100, Limit: big.NewInt(50), Delay: 80}))
+	queued, err = checkRouteLimit(s, srcChain, zionTxParam(4, testAsset, big.NewInt(70)))
+	assert.NoError(t, err)
+	assert.True(t, queued)
+	got, err = getQueuedTransfer(s, testSrcChainID, []byte{4})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1280), got.ReleaseTime)
+}
+
+func TestPauseRoute(t *testing.T) {
+	sdb := newTestStateDB(t)
+	guardian := common.HexToAddress("0x1234")
+	srcChain := &side_chain_manager.SideChain{ChainId: testSrcChainID, Router: utils.ETH_ROUTER}
+	payload, err := utils.PackMethod(scom.ABI, scom.MethodPauseRoute, testSrcChainID, testDstChainID, []byte{})
+	assert.NoError(t, err)
+
+	_, err = PauseRoute(newTestContract(sdb, guardian, 1000, payload))
+	assert.Error(t, err)
+
+	putGuardian(newTestContract(sdb, common.Address{}, 1000, nil), guardian)
+	_, err = PauseRoute(newTestContract(sdb, common.HexToAddress("0x1"), 1000, payload))
+	assert.Error(t, err)
+	_, err = PauseRoute(newTestContract(sdb, guardian, 1000, payload))
+	assert.NoError(t, err)
+
+	// an empty asset pauses all the assets between the chains
+	_, err = checkRouteLimit(newTestContract(sdb, common.Address{}, 1000, nil), srcChain, testTxParam(1, big.NewInt(1)))
+	assert.Error(t, err)
+
+	removeRoutePaused(newTestContract(sdb, common.Address{}, 1000, nil), testSrcChainID, testDstChainID, nil)
+	_, err = checkRouteLimit(newTestContract(sdb, common.Address{}, 1000, nil), srcChain, testTxParam(1, big.NewInt(1)))
+	assert.NoError(t, err)
+}
+
+func TestReleaseTransfer(t *testing.T) {
+	sdb := newTestStateDB(t)
+	s := newTestContract(sdb, common.Address{}, 1000, nil)
+	for _, chainID := range []uint64{testSrcChainID, testDstChainID} {
+		assert.NoError(t, side_chain_manager.PutSideChain(s, &side_chain_manager.SideChain{ChainId: chainID, Router: utils.ETH_ROUTER}))
+	}
+	srcChain := &side_chain_manager.SideChain{ChainId: testSrcChainID, Router: utils.ETH_ROUTER}
+	limit := &RateLimit{Window: 100, Limit: big.NewInt(100), Delay: 50}
+	assert.NoError(t, putRateLimit(s, testSrcChainID, testDstChainID, testAsset, limit))
+
+	// the relayer committing the transfer is recorded for the relayer fee
+	relayer := common.HexToAddress("0x8")
+	queued, err := checkRouteLimit(newTestContract(sdb, relayer, 1000, nil), srcChain, testTxParam(1, big.NewInt(200)))
+	assert.NoError(t, err)
+	assert.True(t, queued)
+	got, err := getQueuedTransfer(s, testSrcChainID, []byte{1})
+	assert.NoError(t, err)
+	assert.Equal(t, relayer, got.Relayer)
+	assert.Equal(t, uint64(1000), got.CommitTime)
+
+	payload, err := utils.PackMethod(scom.ABI, scom.MethodReleaseTransfer, testSrcChainID, []byte{1})
+	assert.NoError(t, err)
+	_, err = ReleaseTransfer(newTestContract(sdb, common.Address{}, 1049, payload))
+	assert.Error(t, err)
+
+	// paused while queued
+	putRoutePaused(s, testSrcChainID, testDstChainID, testAsset)
+	_, err = ReleaseTransfer(newTestContract(sdb, common.Address{}, 1050, payload))
+	assert.Error(t, err)
+
+	removeRoutePaused(s, testSrcChainID, testDstChainID, testAsset)
+	_, err = ReleaseTransfer(newTestContract(sdb, common.Address{}, 1050, payload))
+	assert.NoError(t, err)
+
+	got, err = getQueuedTransfer(s, testSrcChainID, []byte{1})
+	assert.NoError(t, err)
+	assert.Nil(t, got)
+	_, err = ReleaseTransfer(newTestContract(sdb, common.Address{}, 1050, payload))
+	assert.Error(t, err)
+}
+
+func TestCircuitBreakerBeforeVersion(t *testing.T) {
+	InitCrossChainManager()
+	sdb := newTestStateDB(t)
+	nativeCall := func(upgrades []*params.NativeUpgrade, payload []byte) error {
+		ref := native.NewContractRef(sdb, common.Address{}, common.Address{}, big.NewInt(1), common.Hash{}, 1000000, nil)
+		ref.SetBlockTime(1000)
+		ref.SetNativeUpgrades(upgrades)
+		_, _, err := ref.NativeCall(common.Address{}, this, payload)
+		return err
+	}
+
+	// the circuit breaker methods are not registered before `VersionCircuitBreaker`
+	payloads := make([][]byte, 0)
+	for _, pack := range []func() ([]byte, error){
+		func() ([]byte, error) {
+			return utils.PackMethod(scom.ABI, scom.MethodGetRateLimit, testSrcChainID, testDstChainID, testAsset)
+		},
+		func() ([]byte, error) { return utils.PackMethod(scom.ABI, scom.MethodGetGuardian) },
+		func() ([]byte, error) {
+			return utils.PackMethod(scom.ABI, scom.MethodPauseRoute, testSrcChainID, testDstChainID, testAsset)
+		},
+		func() ([]byte, error) {
+			return utils.PackMethod(scom.ABI, scom.MethodReleaseTransfer, testSrcChainID, []byte{1})
+		},
+	} {
+		payload, err := pack()
+		assert.NoError(t, err)
+		payloads = append(payloads, payload)
+	}
+	for _, payload := range payloads {
+		err := nativeCall(nil, payload)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "failed to find method")
+		}
+	}
+
+	upgrades := []*params.NativeUpgrade{{Contract: this, Version: VersionCircuitBreaker, Block: common.Big0}}
+	limit := &RateLimit{Window: 100, Limit: big.NewInt(100), Delay: 50}
+	assert.NoError(t, putRateLimit(newTestContract(sdb, common.Address{}, 1000, nil), testSrcChainID, testDstChainID, testAsset, limit))
+	assert.NoError(t, nativeCall(upgrades, payloads[0]))
+	assert.NoError(t, nativeCall(upgrades, payloads[1]))
+
+	// the route limits are not checked on import before the upgrade
+	s := newTestContract(sdb, common.Address{}, 1000, nil)
+	enabled, err := versionEnabled(s, VersionCircuitBreaker)
+	assert.NoError(t, err)
+	assert.False(t, enabled)
+	s.ContractRef().SetNativeUpgrades(upgrades)
+	enabled, err = versionEnabled(s, VersionCircuitBreaker)
+	assert.NoError(t, err)
+	assert.True(t, enabled)
+}
@@ -0,0 +1,109 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package cometbft
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/contracts/native"
+	scom "github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/common"
+	"github.com/ethereum/go-ethereum/contracts/native/governance/side_chain_manager"
+	"github.com/ethereum/go-ethereum/contracts/native/header_sync/cometbft"
+	"github.com/ethereum/go-ethereum/contracts/native/utils"
+)
+
+type Handler struct{}
+
+func NewHandler() *Handler {
+	return &Handler{}
+}
+
+// MakeDepositProposal verifies the cross chain transaction committed in the app hash of
+// a synced CometBFT header. The side chain keeps the transactions in the module store
+// named with its `CCMCAddress`, under the key of the cross chain id, and the relayer
+// submits the encoded `MakeTxParam` in `Extra` with the ICS23 `MerkleProof` in `Proof`.
+// The optional `HeaderOrCrossChainMsg` is a light client header of the proof height,
+// which is verified and synced before the proof.
+func (h *Handler) MakeDepositProposal(service *native.NativeContract) (*scom.MakeTxParam, error) {
+	ctx := service.ContractRef().CurrentContext()
+	params := &scom.EntranceParam{}
+	if err := utils.UnpackMethod(scom.ABI, scom.MethodImportOuterTransfer, params, ctx.Payload); err != nil {
+		return nil, err
+	}
+
+	if len(params.HeaderOrCrossChainMsg) > 0 {
+		header, err := cometbft.DecodeLightHeader(params.HeaderOrCrossChainMsg)
+		if err != nil {
+			return nil, fmt.Errorf("CometBFT MakeDepositProposal, decode header error: %v", err)
+		}
+		// the client frozen here is reverted with the transaction, misbehaviour should be
+		// submitted by header sync to take effect
+		frozen, err := cometbft.UpdateClient(service, params.SourceChainID, header)
+		if err != nil {
+			return nil, fmt.Errorf("CometBFT MakeDepositProposal, update client error: %v", err)
+		}
+		if frozen {
+			return nil, fmt.Errorf("CometBFT MakeDepositProposal, header conflicts with trusted states")
+		}
+	}
+
+	client, err := cometbft.GetClientState(service, params.SourceChainID)
+	if err != nil {
+		return nil, fmt.Errorf("CometBFT MakeDepositProposal, get client error: %v", err)
+	}
+	if client == nil {
+		return nil, cometbft.ErrClientNotFound
+	}
+	if client.Frozen() {
+		return nil, cometbft.ErrClientFrozen
+	}
+	state, err := cometbft.GetTrustedState(service, params.SourceChainID, uint64(params.Height))
+	if err != nil {
+		return nil, fmt.Errorf("CometBFT MakeDepositProposal, get trusted state error: %v", err)
+	}
+	if state == nil {
+		return nil, fmt.Errorf("CometBFT MakeDepositProposal, header of height %d is not synced", params.Height)
+	}
+
+	sideChain, err := side_chain_manager.GetSideChain(service, params.SourceChainID)
+	if err != nil {
+		return nil, fmt.Errorf("CometBFT MakeDepositProposal, get side chain error: %v", err)
+	}
+	if sideChain == nil || len(sideChain.CCMCAddress) == 0 {
+		return nil, fmt.Errorf("CometBFT MakeDepositProposal, store of side chain %d is not registered", params.SourceChainID)
+	}
+
+	txParam, err := scom.DecodeTxParam(params.Extra)
+	if err != nil {
+		return nil, fmt.Errorf("CometBFT MakeDepositProposal, deserialize merkleValue error: %v", err)
+	}
+	// CometBFT app hash of height H commits the state after block H-1, the proof at height
+	// H is against the app hash of the header H+1 that the relayer has to sync
+	err = VerifyMembership(state.AppHash, params.Proof, string(sideChain.CCMCAddress), txParam.CrossChainID, params.Extra)
+	if err != nil {
+		return nil, fmt.Errorf("CometBFT MakeDepositProposal, verify proof error: %v", err)
+	}
+	if err := scom.CheckDoneTx(service, txParam.CrossChainID, params.SourceChainID); err != nil {
+		return nil, fmt.Errorf("CometBFT MakeDepositProposal, check done transaction error: %v", err)
+	}
+	if err := scom.PutDoneTx(service, txParam.CrossChainID, params.SourceChainID); err != nil {
+		return nil, fmt.Errorf("CometBFT MakeDepositProposal, PutDoneTx error: %v", err)
+	}
+	return txParam, nil
+}
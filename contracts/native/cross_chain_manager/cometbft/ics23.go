@@ -0,0 +1,367 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package cometbft
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// The ICS23 proofs are decoded from `cosmos/ics23/v1/proofs.proto`, only the existence
+// proofs of sha256 hashed IAVL and tendermint trees are supported, which are the
+// proofs of the cosmos-sdk multistore.
+
+type hashOp uint64
+
+const (
+	hashNoHash hashOp = 0
+	hashSHA256 hashOp = 1
+)
+
+type lengthOp uint64
+
+const (
+	lengthNoPrefix lengthOp = 0
+	lengthVarProto lengthOp = 1
+)
+
+type leafOp struct {
+	hash         hashOp
+	prehashKey   hashOp
+	prehashValue hashOp
+	length       lengthOp
+	prefix       []byte
+}
+
+type innerOp struct {
+	hash   hashOp
+	prefix []byte
+	suffix []byte
+}
+
+type existenceProof struct {
+	key   []byte
+	value []byte
+	leaf  *leafOp
+	path  []*innerOp
+}
+
+type proofSpec struct {
+	leaf            leafOp
+	childSize       int
+	minPrefixLength int
+	maxPrefixLength int
+	iavl            bool
+}
+
+var (
+	// iavlSpec is the spec of the module stores of cosmos-sdk
+	iavlSpec = &proofSpec{
+		leaf:            leafOp{hash: hashSHA256, prehashKey: hashNoHash, prehashValue: hashSHA256, length: lengthVarProto, prefix: []byte{0}},
+		childSize:       33,
+		minPrefixLength: 4,
+		maxPrefixLength: 12,
+		iavl:            true,
+	}
+	// tendermintSpec is the spec of the simple merkle tree of the store roots
+	tendermintSpec = &proofSpec{
+		leaf:            leafOp{hash: hashSHA256, prehashKey: hashNoHash, prehashValue: hashSHA256, length: lengthVarProto, prefix: []byte{0}},
+		childSize:       32,
+		minPrefixLength: 1,
+		maxPrefixLength: 1,
+	}
+)
+
+// forEachField calls fn with each field of the message in wire format, the value of
+// varint field is passed in v and the value of length delimited field in b.
+func forEachField(msg []byte, fn func(num protowire.Number, v uint64, b []byte) error) error {
+	for len(msg) > 0 {
+		num, typ, n := protowire.ConsumeTag(msg)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		msg = msg[n:]
+		var (
+			v uint64
+			b []byte
+		)
+		switch typ {
+		case protowire.VarintType:
+			v, n = protowire.ConsumeVarint(msg)
+		case protowire.BytesType:
+			b, n = protowire.ConsumeBytes(msg)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, msg)
+		}
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		msg = msg[n:]
+		if err := fn(num, v, b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func decodeLeafOp(msg []byte) (*leafOp, error) {
+	op := new(leafOp)
+	return op, forEachField(msg, func(num protowire.Number, v uint64, b []byte) error {
+		switch num {
+		case 1:
+			op.hash = hashOp(v)
+		case 2:
+			op.prehashKey = hashOp(v)
+		case 3:
+			op.prehashValue = hashOp(v)
+		case 4:
+			op.length = lengthOp(v)
+		case 5:
+			op.prefix = b
+		}
+		return nil
+	})
+}
+
+func decodeInnerOp(msg []byte) (*innerOp, error) {
+	op := new(innerOp)
+	return op, forEachField(msg, func(num protowire.Number, v uint64, b []byte) error {
+		switch num {
+		case 1:
+			op.hash = hashOp(v)
+		case 2:
+			op.prefix = b
+		case 3:
+			op.suffix = b
+		}
+		return nil
+	})
+}
+
+func decodeExistenceProof(msg []byte) (*existenceProof, error) {
+	proof := new(existenceProof)
+	err := forEachField(msg, func(num protowire.Number, v uint64, b []byte) (err error) {
+		switch num {
+		case 1:
+			proof.key = b
+		case 2:
+			proof.value = b
+		case 3:
+			proof.leaf, err = decodeLeafOp(b)
+		case 4:
+			var op *innerOp
+			if op, err = decodeInnerOp(b); err == nil {
+				proof.path = append(proof.path, op)
+			}
+		}
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+	if proof.leaf == nil {
+		return nil, fmt.Errorf("existence proof without leaf")
+	}
+	return proof, nil
+}
+
+// decodeMerkleProof decodes the `MerkleProof` of IBC commitment, which is the list of
+// `CommitmentProof` from the leaf store to the app hash.
+func decodeMerkleProof(msg []byte) ([]*existenceProof, error) {
+	var proofs []*existenceProof
+	err := forEachField(msg, func(num protowire.Number, v uint64, b []byte) error {
+		if num != 1 {
+			return nil
+		}
+		return forEachField(b, func(num protowire.Number, v uint64, b []byte) error {
+			if num != 1 {
+				return fmt.Errorf("only existence proof is supported, given commitment proof %d", num)
+			}
+			proof, err := decodeExistenceProof(b)
+			if err != nil {
+				return err
+			}
+			proofs = append(proofs, proof)
+			return nil
+		})
+	})
+	return proofs, err
+}
+
+func doHash(op hashOp, data []byte) ([]byte, error) {
+	switch op {
+	case hashNoHash:
+		return data, nil
+	case hashSHA256:
+		sum := sha256.Sum256(data)
+		return sum[:], nil
+	default:
+		return nil, fmt.Errorf("unsupported hash op %d", op)
+	}
+}
+
+func doLength(op lengthOp, data []byte) ([]byte, error) {
+	switch op {
+	case lengthNoPrefix:
+		return data, nil
+	case lengthVarProto:
+		return append(protowire.AppendVarint(nil, uint64(len(data))), data...), nil
+	default:
+		return nil, fmt.Errorf("unsupported length op %d", op)
+	}
+}
+
+func (op *leafOp) apply(key, value []byte) ([]byte, error) {
+	if len(key) == 0 || len(value) == 0 {
+		return nil, fmt.Errorf("leaf op needs key and value")
+	}
+	data := append([]byte{}, op.prefix...)
+	for _, item := range []struct {
+		prehash hashOp
+		data    []byte
+	}{{op.prehashKey, key}, {op.prehashValue, value}} {
+		hashed, err := doHash(item.prehash, item.data)
+		if err != nil {
+			return nil, err
+		}
+		if hashed, err = doLength(op.length, hashed); err != nil {
+			return nil, err
+		}
+		data = append(data, hashed...)
+	}
+	return doHash(op.hash, data)
+}
+
+func (op *innerOp) apply(child []byte) ([]byte, error) {
+	if len(child) == 0 {
+		return nil, fmt.Errorf("inner op needs child value")
+	}
+	data := append(append(append([]byte{}, op.prefix...), child...), op.suffix...)
+	return doHash(op.hash, data)
+}
+
+// validateIavlOp checks the prefix of the IAVL node encodes its height, size and version,
+// and the left child hash for the inner node.
+func validateIavlOp(prefix []byte, layer int) error {
+	r := bytes.NewReader(prefix)
+	height, err := binary.ReadVarint(r)
+	if err != nil || height < int64(layer) {
+		return fmt.Errorf("invalid iavl height of layer %d", layer)
+	}
+	if size, err := binary.ReadVarint(r); err != nil || size < 0 {
+		return fmt.Errorf("invalid iavl size of layer %d", layer)
+	}
+	if version, err := binary.ReadVarint(r); err != nil || version < 0 {
+		return fmt.Errorf("invalid iavl version of layer %d", layer)
+	}
+	if layer == 0 && r.Len() != 0 {
+		return fmt.Errorf("invalid iavl leaf prefix")
+	}
+	if layer > 0 && r.Len() != 1 && r.Len() != 34 {
+		return fmt.Errorf("invalid iavl inner prefix of layer %d", layer)
+	}
+	return nil
+}
+
+func (p *existenceProof) checkAgainstSpec(spec *proofSpec) error {
+	leaf := p.leaf
+	if leaf.hash != spec.leaf.hash || leaf.prehashKey != spec.leaf.prehashKey ||
+		leaf.prehashValue != spec.leaf.prehashValue || leaf.length != spec.leaf.length ||
+		!bytes.HasPrefix(leaf.prefix, spec.leaf.prefix) {
+		return fmt.Errorf("leaf op mismatches the proof spec")
+	}
+	if spec.iavl {
+		if err := validateIavlOp(leaf.prefix, 0); err != nil {
+			return err
+		}
+	}
+	maxPrefixLength := spec.maxPrefixLength + spec.childSize // binary tree with one left child at most
+	for i, op := range p.path {
+		if op.hash != hashSHA256 {
+			return fmt.Errorf("inner op %d has unsupported hash %d", i, op.hash)
+		}
+		if bytes.HasPrefix(op.prefix, spec.leaf.prefix) {
+			return fmt.Errorf("inner op %d has leaf prefix", i)
+		}
+		if len(op.prefix) < spec.minPrefixLength || len(op.prefix) > maxPrefixLength {
+			return fmt.Errorf("inner op %d has invalid prefix length %d", i, len(op.prefix))
+		}
+		if len(op.suffix)%spec.childSize != 0 {
+			return fmt.Errorf("inner op %d has invalid suffix length %d", i, len(op.suffix))
+		}
+		if spec.iavl {
+			if err := validateIavlOp(op.prefix, i+1); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// calculate returns the root hash of the proof after checking it with the spec and
+// the key value proved.
+func (p *existenceProof) calculate(spec *proofSpec, key, value []byte) ([]byte, error) {
+	if !bytes.Equal(p.key, key) {
+		return nil, fmt.Errorf("proof of key %x, expect %x", p.key, key)
+	}
+	if !bytes.Equal(p.value, value) {
+		return nil, fmt.Errorf("proof of value %x, expect %x", p.value, value)
+	}
+	if err := p.checkAgainstSpec(spec); err != nil {
+		return nil, err
+	}
+	hash, err := p.leaf.apply(p.key, p.value)
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range p.path {
+		if hash, err = op.apply(hash); err != nil {
+			return nil, err
+		}
+	}
+	return hash, nil
+}
+
+// VerifyMembership verifies the value is stored under the key of the module store in
+// the app hash, with the `MerkleProof` of the IAVL store and the multistore.
+func VerifyMembership(appHash, proof []byte, store string, key, value []byte) error {
+	proofs, err := decodeMerkleProof(proof)
+	if err != nil {
+		return fmt.Errorf("invalid merkle proof: %v", err)
+	}
+	if len(proofs) != 2 {
+		return fmt.Errorf("merkle proof must have 2 proofs of store and multistore, given %d", len(proofs))
+	}
+	storeRoot, err := proofs[0].calculate(iavlSpec, key, value)
+	if err != nil {
+		return fmt.Errorf("invalid store proof: %v", err)
+	}
+	root, err := proofs[1].calculate(tendermintSpec, []byte(store), storeRoot)
+	if err != nil {
+		return fmt.Errorf("invalid multistore proof: %v", err)
+	}
+	if !bytes.Equal(root, appHash) {
+		return fmt.Errorf("proof root %x mismatches app hash %x", root, appHash)
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package cometbft
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func sha(data ...[]byte) []byte {
+	h := sha256.New()
+	for _, d := range data {
+		h.Write(d)
+	}
+	return h.Sum(nil)
+}
+
+func varints(values ...int64) []byte {
+	var b []byte
+	for _, v := range values {
+		buf := make([]byte, binary.MaxVarintLen64)
+		b = append(b, buf[:binary.PutVarint(buf, v)]...)
+	}
+	return b
+}
+
+func appendField(b []byte, num protowire.Number, v []byte) []byte {
+	return protowire.AppendBytes(protowire.AppendTag(b, num, protowire.BytesType), v)
+}
+
+func appendVarint(b []byte, num protowire.Number, v uint64) []byte {
+	return protowire.AppendVarint(protowire.AppendTag(b, num, protowire.VarintType), v)
+}
+
+func encodeExistenceProof(p *existenceProof) []byte {
+	leaf := appendVarint(nil, 1, uint64(p.leaf.hash))
+	leaf = appendVarint(leaf, 2, uint64(p.leaf.prehashKey))
+	leaf = appendVarint(leaf, 3, uint64(p.leaf.prehashValue))
+	leaf = appendVarint(leaf, 4, uint64(p.leaf.length))
+	leaf = appendField(leaf, 5, p.leaf.prefix)
+
+	b := appendField(nil, 1, p.key)
+	b = appendField(b, 2, p.value)
+	b = appendField(b, 3, leaf)
+	for _, op := range p.path {
+		inner := appendVarint(nil, 1, uint64(op.hash))
+		inner = appendField(inner, 2, op.prefix)
+		inner = appendField(inner, 3, op.suffix)
+		b = appendField(b, 4, inner)
+	}
+	return b
+}
+
+func encodeMerkleProof(proofs ...*existenceProof) []byte {
+	var b []byte
+	for _, p := range proofs {
+		b = appendField(b, 1, appendField(nil, 1, encodeExistenceProof(p)))
+	}
+	return b
+}
+
+// testProofs makes the proofs of the key as the right child of IAVL root, and the
+// store as the left child of the multistore root.
+func testProofs(store string, key, value []byte) (appHash []byte, proofs []*existenceProof) {
+	leaf := &leafOp{hash: hashSHA256, prehashValue: hashSHA256, length: lengthVarProto, prefix: varints(0, 1, 7)}
+	leafHash, _ := leaf.apply(key, value)
+	sibling := sha([]byte("sibling"))
+	iavlPrefix := append(append(append(varints(1, 2, 7), 32), sibling...), 32)
+	storeRoot := sha(iavlPrefix, leafHash)
+
+	storeLeaf := &leafOp{hash: hashSHA256, prehashValue: hashSHA256, length: lengthVarProto, prefix: []byte{0}}
+	storeHash, _ := storeLeaf.apply([]byte(store), storeRoot)
+	otherStore := sha([]byte("other store"))
+	appHash = sha([]byte{1}, storeHash, otherStore)
+
+	return appHash, []*existenceProof{
+		{key: key, value: value, leaf: leaf, path: []*innerOp{{hash: hashSHA256, prefix: iavlPrefix}}},
+		{key: []byte(store), value: storeRoot, leaf: storeLeaf, path: []*innerOp{{hash: hashSHA256, prefix: []byte{1}, suffix: otherStore}}},
+	}
+}
+
+func TestVerifyMembership(t *testing.T) {
+	store, key, value := "ccm", []byte("cross chain id"), []byte("make tx param")
+	appHash, proofs := testProofs(store, key, value)
+	proof := encodeMerkleProof(proofs...)
+
+	if err := VerifyMembership(appHash, proof, store, key, value); err != nil {
+		t.Fatalf("valid proof: %v", err)
+	}
+	if err := VerifyMembership(appHash, proof, store, key, []byte("forged")); err == nil {
+		t.Fatal("proof of another value is accepted")
+	}
+	if err := VerifyMembership(appHash, proof, "bank", key, value); err == nil {
+		t.Fatal("proof of another store is accepted")
+	}
+	if err := VerifyMembership(sha(appHash), proof, store, key, value); err == nil {
+		t.Fatal("proof of another app hash is accepted")
+	}
+	if err := VerifyMembership(appHash, encodeMerkleProof(proofs[0]), store, key, value); err == nil {
+		t.Fatal("proof without multistore is accepted")
+	}
+
+	// inner node disguised as leaf to prove the hash of its children
+	proofs[1].path[0].prefix = []byte{0}
+	if err := VerifyMembership(appHash, encodeMerkleProof(proofs...), store, key, value); err == nil {
+		t.Fatal("proof violating the spec is accepted")
+	}
+}
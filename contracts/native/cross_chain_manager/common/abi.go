@@ -19,9 +19,11 @@ package common
 
 import (
 	"fmt"
+	"math/big"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/contracts/native/go_abi/cross_chain_manager_abi"
 )
 
@@ -31,6 +33,24 @@ var (
 	MethodMultiSign           = cross_chain_manager_abi.MethodMultiSign
 	MethodBlackChain          = cross_chain_manager_abi.MethodBlackChain
 	MethodWhiteChain          = cross_chain_manager_abi.MethodWhiteChain
+	MethodGetVoteProgress     = cross_chain_manager_abi.MethodGetVoteProgress
+	MethodSetRateLimit        = cross_chain_manager_abi.MethodSetRateLimit
+	MethodGetRateLimit        = cross_chain_manager_abi.MethodGetRateLimit
+	MethodSetGuardian         = cross_chain_manager_abi.MethodSetGuardian
+	MethodGetGuardian         = cross_chain_manager_abi.MethodGetGuardian
+	MethodPauseRoute          = cross_chain_manager_abi.MethodPauseRoute
+	MethodUnpauseRoute        = cross_chain_manager_abi.MethodUnpauseRoute
+	MethodReleaseTransfer     = cross_chain_manager_abi.MethodReleaseTransfer
+	MethodGetQueuedTransfer   = cross_chain_manager_abi.MethodGetQueuedTransfer
+)
+
+var (
+	EventSetRateLimit    = MethodSetRateLimit
+	EventSetGuardian     = MethodSetGuardian
+	EventPauseRoute      = MethodPauseRoute
+	EventUnpauseRoute    = MethodUnpauseRoute
+	EventQueueTransfer   = "queueTransfer"
+	EventReleaseTransfer = MethodReleaseTransfer
 )
 
 var ABI *abi.ABI
@@ -50,3 +70,33 @@ func GetABI() *abi.ABI {
 type BlackChainParam struct {
 	ChainID uint64
 }
+
+type GetVoteProgressParam struct {
+	SourceChainID uint64
+	Height        uint32
+	Extra         []byte
+}
+
+type RateLimitParam struct {
+	SrcChainID uint64
+	DstChainID uint64
+	Asset      []byte
+	Window     uint64
+	Limit      *big.Int
+	Delay      uint64
+}
+
+type RouteParam struct {
+	SrcChainID uint64
+	DstChainID uint64
+	Asset      []byte
+}
+
+type SetGuardianParam struct {
+	Guardian common.Address
+}
+
+type QueuedTransferParam struct {
+	SourceChainID uint64
+	CrossChainID  []byte
+}
@@ -324,14 +324,34 @@ func (this *MultiSignParam) Deserialization(source *polycomm.ZeroCopySource) err
 	return nil
 }
 
+// TxArgs is the transfer carried by zion lock proxy cross chain transactions. Fee is the relayer
+// fee escrowed with the transfer on its source chain, it is paid to the relayer delivering the
+// transfer before FeeDeadline (unix seconds) once the target chain settles it, or refunded to the
+// payer by the source chain afterwards. TransferID identifies the transfer on its source chain,
+// which refunds it if the target chain fails to execute it.
+// Transfers without fee and id keep the original three fields encoding.
 type TxArgs struct {
 	ToAssetHash []byte
 	ToAddress   []byte
 	Amount      *big.Int
+	Fee         *big.Int
+	FeeDeadline uint64
+	TransferID  []byte
 }
 
 func (tx *TxArgs) EncodeRLP(w io.Writer) error {
-	return rlp.Encode(w, []interface{}{tx.ToAssetHash, tx.ToAddress, tx.Amount})
+	switch {
+	case len(tx.TransferID) > 0:
+		fee := tx.Fee
+		if fee == nil {
+			fee = new(big.Int)
+		}
+		return rlp.Encode(w, []interface{}{tx.ToAssetHash, tx.ToAddress, tx.Amount, fee, tx.FeeDeadline, tx.TransferID})
+	case tx.HasFee():
+		return rlp.Encode(w, []interface{}{tx.ToAssetHash, tx.ToAddress, tx.Amount, tx.Fee, tx.FeeDeadline})
+	default:
+		return rlp.Encode(w, []interface{}{tx.ToAssetHash, tx.ToAddress, tx.Amount})
+	}
 }
 
 func (tx *TxArgs) DecodeRLP(s *rlp.Stream) error {
@@ -339,11 +359,20 @@ func (tx *TxArgs) DecodeRLP(s *rlp.Stream) error {
 		ToAssetHash []byte
 		ToAddress   []byte
 		Amount      *big.Int
+		Fee         *big.Int `rlp:"optional"`
+		FeeDeadline uint64   `rlp:"optional"`
+		TransferID  []byte   `rlp:"optional"`
 	}
 
 	if err := s.Decode(&data); err != nil {
 		return err
 	}
 	tx.ToAssetHash, tx.ToAddress, tx.Amount = data.ToAssetHash, data.ToAddress, data.Amount
+	tx.Fee, tx.FeeDeadline, tx.TransferID = data.Fee, data.FeeDeadline, data.TransferID
 	return nil
 }
+
+// HasFee returns true if the transfer carries a relayer fee.
+func (tx *TxArgs) HasFee() bool {
+	return tx.Fee != nil && tx.Fee.Sign() > 0
+}
@@ -24,6 +24,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -57,3 +58,53 @@ func TestUint256ToBytes(t *testing.T) {
 		assert.Equal(t, v.Expect, gotNum)
 	}
 }
+
+func TestTxArgsEncoding(t *testing.T) {
+	legacy := &TxArgs{
+		ToAssetHash: common.HexToAddress("0x01").Bytes(),
+		ToAddress:   common.HexToAddress("0x02").Bytes(),
+		Amount:      big.NewInt(100),
+	}
+	enc, err := rlp.EncodeToBytes(legacy)
+	assert.NoError(t, err)
+
+	// transfers without fee keep the three fields encoding
+	expect, err := rlp.EncodeToBytes([]interface{}{legacy.ToAssetHash, legacy.ToAddress, legacy.Amount})
+	assert.NoError(t, err)
+	assert.Equal(t, expect, enc)
+
+	got := new(TxArgs)
+	assert.NoError(t, rlp.DecodeBytes(enc, got))
+	assert.Equal(t, legacy, got)
+	assert.False(t, got.HasFee())
+
+	withFee := &TxArgs{
+		ToAssetHash: legacy.ToAssetHash,
+		ToAddress:   legacy.ToAddress,
+		Amount:      legacy.Amount,
+		Fee:         big.NewInt(3),
+		FeeDeadline: 1650000000,
+	}
+	enc, err = rlp.EncodeToBytes(withFee)
+	assert.NoError(t, err)
+
+	got = new(TxArgs)
+	assert.NoError(t, rlp.DecodeBytes(enc, got))
+	assert.Equal(t, withFee, got)
+	assert.True(t, got.HasFee())
+
+	withID := &TxArgs{
+		ToAssetHash: legacy.ToAssetHash,
+		ToAddress:   legacy.ToAddress,
+		Amount:      legacy.Amount,
+		TransferID:  []byte{0x01, 0x02},
+	}
+	enc, err = rlp.EncodeToBytes(withID)
+	assert.NoError(t, err)
+
+	got = new(TxArgs)
+	assert.NoError(t, rlp.DecodeBytes(enc, got))
+	assert.Equal(t, withID.TransferID, got.TransferID)
+	assert.Equal(t, withID.Amount, got.Amount)
+	assert.False(t, got.HasFee())
+}
@@ -0,0 +1,242 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package consensus_vote
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/contracts/native"
+	"github.com/ethereum/go-ethereum/contracts/native/governance/node_manager"
+	"github.com/ethereum/go-ethereum/contracts/native/governance/side_chain_manager"
+	"github.com/ethereum/go-ethereum/contracts/native/utils"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/assert"
+)
+
+const testChainID = uint64(100)
+
+// voter committees are enabled by the side chain manager upgrade
+var testUpgrades = []*params.NativeUpgrade{{
+	Contract: utils.SideChainManagerContractAddress,
+	Version:  side_chain_manager.VersionVoterCommittee,
+	Block:    common.Big0,
+}}
+
+func newTestStateDB(t *testing.T) *state.StateDB {
+	sdb, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	assert.NoError(t, err)
+	return sdb
+}
+
+func newTestContract(sdb *state.StateDB, caller common.Address, height int64) *native.NativeContract {
+	return newTestContractWithUpgrades(sdb, caller, height, testUpgrades)
+}
+
+func newTestContractWithUpgrades(sdb *state.StateDB, caller common.Address, height int64, upgrades []*params.NativeUpgrade) *native.NativeContract {
+	ref := native.NewContractRef(sdb, caller, caller, big.NewInt(height), common.Hash{}, 0, nil)
+	ref.SetNativeUpgrades(upgrades)
+	ref.PushContext(&native.Context{Caller: caller, ContractAddress: utils.CrossChainManagerContractAddress})
+	return native.NewNativeContract(sdb, ref)
+}
+
+func testVoters(n int) []common.Address {
+	voters := make([]common.Address, n)
+	for i := range voters {
+		voters[i] = common.BigToAddress(big.NewInt(int64(i + 1)))
+	}
+	return voters
+}
+
+func TestCheckCommitteeSigns(t *testing.T) {
+	sdb := newTestStateDB(t)
+	voters := testVoters(3)
+	committee := &side_chain_manager.VoterCommittee{ChainId: testChainID, Voters: voters, Threshold: 2}
+	side_chain_manager.PutVoterCommittee(newTestContract(sdb, common.Address{}, 1), committee)
+	input := voteInput(testChainID, 12345, []byte{0x01, 0x02})
+
+	_, err := CheckCommitteeSigns(newTestContract(sdb, common.HexToAddress("0xff"), 1), committee, input)
+	assert.Equal(t, node_manager.ErrInvalidAuthority, err)
+
+	ok, err := CheckCommitteeSigns(newTestContract(sdb, voters[0], 1), committee, input)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	_, err = CheckCommitteeSigns(newTestContract(sdb, voters[0], 2), committee, input)
+	assert.Equal(t, node_manager.ErrDuplicateSigner, err)
+
+	ok, err = CheckCommitteeSigns(newTestContract(sdb, voters[1], 2), committee, input)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	// votes after the threshold is reached are recorded but never pass again
+	ok, err = CheckCommitteeSigns(newTestContract(sdb, voters[2], 3), committee, input)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	progress, err := GetVoteProgress(newTestContract(sdb, common.Address{}, 3), testChainID, 12345, []byte{0x01, 0x02})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), progress.StartHeight)
+	assert.Equal(t, uint64(2), progress.EndHeight)
+	assert.Equal(t, uint64(0), progress.ExpireHeight)
+	assert.Equal(t, uint64(2), progress.Threshold)
+	assert.Equal(t, voters, progress.Signers)
+}
+
+func TestCommitteeVoteExpiry(t *testing.T) {
+	sdb := newTestStateDB(t)
+	voters := testVoters(3)
+	committee := &side_chain_manager.VoterCommittee{ChainId: testChainID, Voters: voters, Threshold: 2, VoteExpiry: 10}
+	side_chain_manager.PutVoterCommittee(newTestContract(sdb, common.Address{}, 1), committee)
+	input := voteInput(testChainID, 12345, []byte{0x01})
+
+	ok, err := CheckCommitteeSigns(newTestContract(sdb, voters[0], 1), committee, input)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	progress, err := GetVoteProgress(newTestContract(sdb, common.Address{}, 5), testChainID, 12345, []byte{0x01})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(11), progress.ExpireHeight)
+
+	// the first vote expired, the second one starts a new round
+	ok, err = CheckCommitteeSigns(newTestContract(sdb, voters[1], 11), committee, input)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	progress, err = GetVoteProgress(newTestContract(sdb, common.Address{}, 11), testChainID, 12345, []byte{0x01})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(11), progress.StartHeight)
+	assert.Equal(t, uint64(21), progress.ExpireHeight)
+	assert.Equal(t, []common.Address{voters[1]}, progress.Signers)
+
+	ok, err = CheckCommitteeSigns(newTestContract(sdb, voters[0], 12), committee, input)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestCommitteeRotation(t *testing.T) {
+	sdb := newTestStateDB(t)
+	voters := testVoters(4)
+	old := &side_chain_manager.VoterCommittee{ChainId: testChainID, Voters: voters[:3], Threshold: 2}
+	input := voteInput(testChainID, 1, []byte{0x01})
+
+	ok, err := CheckCommitteeSigns(newTestContract(sdb, voters[0], 1), old, input)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	// voters[0] is rotated out, its pending vote no longer counts
+	rotated := &side_chain_manager.VoterCommittee{ChainId: testChainID, Voters: voters[1:], Threshold: 2}
+	ok, err = CheckCommitteeSigns(newTestContract(sdb, voters[1], 2), rotated, input)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	ok, err = CheckCommitteeSigns(newTestContract(sdb, voters[3], 3), rotated, input)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestCommitteeBeforeVersion(t *testing.T) {
+	node_manager.InitABI()
+	sdb := newTestStateDB(t)
+	validators := make([]common.Address, 4)
+	alloc := make(core.GenesisAlloc)
+	for i := range validators {
+		key, err := crypto.GenerateKey()
+		assert.NoError(t, err)
+		validators[i] = crypto.PubkeyToAddress(key.PublicKey)
+		alloc[validators[i]] = core.GenesisAccount{PublicKey: crypto.CompressPubkey(&key.PublicKey), Balance: common.Big0}
+	}
+	assert.NoError(t, core.RegGenesis(sdb, alloc))
+
+	voters := testVoters(3)
+	committee := &side_chain_manager.VoterCommittee{ChainId: testChainID, Voters: voters, Threshold: 2, VoteExpiry: 10}
+	side_chain_manager.PutVoterCommittee(newTestContract(sdb, common.Address{}, 1), committee)
+	input := voteInput(testChainID, 12345, []byte{0x01})
+	newContract := func(caller common.Address, height int64) *native.NativeContract {
+		return newTestContractWithUpgrades(sdb, caller, height, nil)
+	}
+
+	// the committee is ignored and all zion validators vote before the upgrade
+	_, err := CheckCommitteeSigns(newContract(voters[0], 1), committee, input)
+	assert.Equal(t, node_manager.ErrInvalidAuthority, err)
+	ok, err := CheckCommitteeSigns(newContract(validators[0], 1), committee, input)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	progress, err := GetVoteProgress(newContract(common.Address{}, 1), testChainID, 12345, []byte{0x01})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), progress.Threshold)
+	assert.Equal(t, uint64(0), progress.ExpireHeight)
+
+	// pending votes never expire before the upgrade
+	ok, err = CheckCommitteeSigns(newContract(validators[1], 20), committee, input)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	ok, err = CheckCommitteeSigns(newContract(validators[2], 21), committee, input)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	progress, err = GetVoteProgress(newContract(common.Address{}, 21), testChainID, 12345, []byte{0x01})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), progress.StartHeight)
+	assert.Equal(t, uint64(21), progress.EndHeight)
+	assert.Equal(t, validators[:3], progress.Signers)
+}
+
+func TestVoterCommitteeMethodsBeforeVersion(t *testing.T) {
+	side_chain_manager.InitSideChainManager()
+	sdb := newTestStateDB(t)
+	payload, err := utils.PackMethod(side_chain_manager.ABI, side_chain_manager.MethodGetVoterCommittee, testChainID)
+	assert.NoError(t, err)
+	nativeCall := func(upgrades []*params.NativeUpgrade) error {
+		ref := native.NewContractRef(sdb, common.Address{}, common.Address{}, big.NewInt(1), common.Hash{}, 1000000, nil)
+		ref.SetNativeUpgrades(upgrades)
+		_, _, err := ref.NativeCall(common.Address{}, utils.SideChainManagerContractAddress, payload)
+		return err
+	}
+
+	// the voter committee methods are not registered before `VersionVoterCommittee`
+	err = nativeCall(nil)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "failed to find method")
+	}
+	assert.NoError(t, nativeCall(testUpgrades))
+}
+
+func TestVoteProgressEncoding(t *testing.T) {
+	progress := &VoteProgress{
+		StartHeight:  1,
+		EndHeight:    2,
+		ExpireHeight: 0,
+		Threshold:    3,
+		Signers:      testVoters(2),
+	}
+	enc, err := rlp.EncodeToBytes(progress)
+	assert.NoError(t, err)
+
+	got := new(VoteProgress)
+	assert.NoError(t, rlp.DecodeBytes(enc, got))
+	assert.Equal(t, progress, got)
+}
@@ -22,6 +22,8 @@ import (
 	"errors"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/contracts/native"
+	"github.com/ethereum/go-ethereum/contracts/native/governance/node_manager"
+	"github.com/ethereum/go-ethereum/contracts/native/governance/side_chain_manager"
 	"github.com/ethereum/go-ethereum/contracts/native/utils"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/rlp"
@@ -93,6 +95,62 @@ func storeSignerAndCheckQuorum(s *native.NativeContract, hash common.Hash, signe
 	return flag, nil
 }
 
+// storeCommitteeSignerAndCheckQuorum works like storeSignerAndCheckQuorum for side chains with a
+// voter committee: pending votes older than the committee vote expiry are discarded before the new
+// signer is recorded, and only signers in the current committee count toward the threshold.
+func storeCommitteeSignerAndCheckQuorum(s *native.NativeContract, hash common.Hash, signer common.Address,
+	committee *side_chain_manager.VoterCommittee) (bool, error) {
+	height := s.ContractRef().BlockHeight().Uint64()
+	data, err := getSignerList(s, hash)
+	if err != nil {
+		if err.Error() != ErrEof.Error() {
+			return false, err
+		}
+		data = nil
+	}
+	if data != nil && data.EndHeight == 0 && committee.VoteExpiry > 0 && height >= data.StartHeight+committee.VoteExpiry {
+		data = nil
+	}
+	if data == nil {
+		data = &SignerList{
+			StartHeight: height,
+			SignerList:  make([]*SignerInfo, 0),
+		}
+	}
+
+	for _, v := range data.SignerList {
+		if v.Address == signer {
+			return false, node_manager.ErrDuplicateSigner
+		}
+	}
+	data.SignerList = append(data.SignerList, &SignerInfo{Address: signer, SignHeight: height})
+
+	flag := false
+	//check threshold with the signers still in committee and store quorum height
+	if data.EndHeight == 0 {
+		count := uint64(0)
+		for _, v := range data.SignerList {
+			if committee.IsVoter(v.Address) {
+				count++
+			}
+		}
+		if count >= committee.Threshold {
+			data.EndHeight = height
+			flag = true
+		}
+	}
+
+	//store signer map
+	key := signerMapKey(hash)
+	value, err := rlp.EncodeToBytes(data)
+	if err != nil {
+		return false, err
+	}
+	set(s, key, value)
+
+	return flag, nil
+}
+
 func findSigner(s *native.NativeContract, hash common.Hash, signer common.Address) bool {
 	signerList, err := getSignerList(s, hash)
 	if err != nil {
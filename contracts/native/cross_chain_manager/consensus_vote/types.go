@@ -76,6 +76,40 @@ func (m *SignerInfo) DecodeRLP(s *rlp.Stream) error {
 	return nil
 }
 
+// VoteProgress describes the votes collected for a transfer imported through the vote router.
+// ExpireHeight is zero if the pending votes never expire or the quorum is already reached.
+type VoteProgress struct {
+	StartHeight  uint64
+	EndHeight    uint64
+	ExpireHeight uint64
+	Threshold    uint64
+	Signers      []common.Address
+}
+
+func (m *VoteProgress) EncodeRLP(w io.Writer) error {
+	return rlp.Encode(w, []interface{}{m.StartHeight, m.EndHeight, m.ExpireHeight, m.Threshold, m.Signers})
+}
+
+func (m *VoteProgress) DecodeRLP(s *rlp.Stream) error {
+	var data struct {
+		StartHeight  uint64
+		EndHeight    uint64
+		ExpireHeight uint64
+		Threshold    uint64
+		Signers      []common.Address
+	}
+
+	if err := s.Decode(&data); err != nil {
+		return err
+	}
+	m.StartHeight = data.StartHeight
+	m.EndHeight = data.EndHeight
+	m.ExpireHeight = data.ExpireHeight
+	m.Threshold = data.Threshold
+	m.Signers = data.Signers
+	return nil
+}
+
 type VoteMessage struct {
 	Input []byte
 	hash  atomic.Value
@@ -19,8 +19,10 @@
 package consensus_vote
 
 import (
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/contracts/native"
 	"github.com/ethereum/go-ethereum/contracts/native/governance/node_manager"
+	"github.com/ethereum/go-ethereum/contracts/native/governance/side_chain_manager"
 	"github.com/ethereum/go-ethereum/log"
 )
 
@@ -46,19 +48,8 @@ func CheckConsensusSigns(s *native.NativeContract, input []byte) (bool, error) {
 
 	// get or set consensus sign info
 	msg := &VoteMessage{Input: input}
-	if exist, err := getVoteMessage(s, msg.Hash()); err != nil {
-		if err.Error() == "EOF" {
-			if err := storeVoteMessage(s, msg); err != nil {
-				log.Trace("checkConsensusSign", "store sign failed", err, "hash", msg.Hash().Hex())
-				return false, node_manager.ErrStorage
-			}
-		} else {
-			log.Trace("checkConsensusSign", "get sign failed", err, "hash", msg.Hash().Hex())
-			return false, node_manager.ErrConsensusSignNotExist
-		}
-	} else if exist.Hash() != msg.Hash() {
-		log.Trace("checkConsensusSign", "check sign hash failed, expect", exist.Hash().Hex(), "got", msg.Hash().Hex())
-		return false, node_manager.ErrInvalidSign
+	if err := getOrStoreVoteMessage(s, msg); err != nil {
+		return false, err
 	}
 
 	// check duplicate signature
@@ -76,3 +67,112 @@ func CheckConsensusSigns(s *native.NativeContract, input []byte) (bool, error) {
 
 	return ok, nil
 }
+
+// CheckCommitteeSigns is the CheckConsensusSigns of side chains with a voter committee configured
+// in side chain manager, only committee voters may vote and the committee threshold is the quorum.
+// the votes are checked by CheckConsensusSigns until the voter committees are activated, so the
+// committee threshold and vote expiry rules never apply before the side chain manager upgrade.
+func CheckCommitteeSigns(s *native.NativeContract, committee *side_chain_manager.VoterCommittee, input []byte) (bool, error) {
+	ctx := s.ContractRef().CurrentContext()
+	caller := ctx.Caller
+
+	if enabled, err := side_chain_manager.VoterCommitteeEnabled(s); err != nil {
+		return false, err
+	} else if !enabled {
+		return CheckConsensusSigns(s, input)
+	}
+
+	// check authority
+	if !committee.IsVoter(caller) {
+		log.Trace("checkCommitteeSign", "caller is not voter of chain", committee.ChainId, "caller", caller.Hex())
+		return false, node_manager.ErrInvalidAuthority
+	}
+
+	// get or set consensus sign info
+	msg := &VoteMessage{Input: input}
+	if err := getOrStoreVoteMessage(s, msg); err != nil {
+		return false, err
+	}
+
+	// store signer address and check threshold, expired pending votes are dropped here
+	ok, err := storeCommitteeSignerAndCheckQuorum(s, msg.Hash(), caller, committee)
+	if err == node_manager.ErrDuplicateSigner {
+		log.Trace("checkCommitteeSign", "signer already exist", caller.Hex(), "hash", msg.Hash().Hex())
+		return false, err
+	} else if err != nil {
+		log.Trace("checkCommitteeSign", "store signer failed", err, "hash", msg.Hash().Hex())
+		return false, node_manager.ErrStorage
+	}
+
+	return ok, nil
+}
+
+// GetVoteProgress returns the votes collected for the transfer identified by source chain id,
+// height and extra, as used by VoteHandler.MakeDepositProposal.
+func GetVoteProgress(s *native.NativeContract, sourceChainID uint64, height uint32, extra []byte) (*VoteProgress, error) {
+	committee, err := getVoterCommittee(s, sourceChainID)
+	if err != nil {
+		return nil, err
+	}
+
+	progress := &VoteProgress{Signers: make([]common.Address, 0)}
+	if committee == nil {
+		epochBytes, err := node_manager.GetCurrentEpoch(s)
+		if err != nil {
+			return nil, node_manager.ErrEpochNotExist
+		}
+		output := new(node_manager.MethodEpochOutput)
+		if err := output.Decode(epochBytes); err != nil {
+			return nil, err
+		}
+		progress.Threshold = uint64(output.Epoch.QuorumSize())
+	} else {
+		progress.Threshold = committee.Threshold
+	}
+
+	msg := &VoteMessage{Input: voteInput(sourceChainID, height, extra)}
+	signerList, err := getSignerList(s, msg.Hash())
+	if err != nil {
+		if err.Error() == ErrEof.Error() {
+			return progress, nil
+		}
+		return nil, err
+	}
+	progress.StartHeight = signerList.StartHeight
+	progress.EndHeight = signerList.EndHeight
+	if committee != nil && committee.VoteExpiry > 0 && signerList.EndHeight == 0 {
+		progress.ExpireHeight = signerList.StartHeight + committee.VoteExpiry
+	}
+	for _, v := range signerList.SignerList {
+		progress.Signers = append(progress.Signers, v.Address)
+	}
+	return progress, nil
+}
+
+// getVoterCommittee returns the voter committee of a side chain, nil returned before the voter
+// committees are activated.
+func getVoterCommittee(s *native.NativeContract, chainID uint64) (*side_chain_manager.VoterCommittee, error) {
+	enabled, err := side_chain_manager.VoterCommitteeEnabled(s)
+	if err != nil || !enabled {
+		return nil, err
+	}
+	return side_chain_manager.GetVoterCommittee(s, chainID)
+}
+
+func getOrStoreVoteMessage(s *native.NativeContract, msg *VoteMessage) error {
+	if exist, err := getVoteMessage(s, msg.Hash()); err != nil {
+		if err.Error() == "EOF" {
+			if err := storeVoteMessage(s, msg); err != nil {
+				log.Trace("checkConsensusSign", "store sign failed", err, "hash", msg.Hash().Hex())
+				return node_manager.ErrStorage
+			}
+		} else {
+			log.Trace("checkConsensusSign", "get sign failed", err, "hash", msg.Hash().Hex())
+			return node_manager.ErrConsensusSignNotExist
+		}
+	} else if exist.Hash() != msg.Hash() {
+		log.Trace("checkConsensusSign", "check sign hash failed, expect", exist.Hash().Hex(), "got", msg.Hash().Hex())
+		return node_manager.ErrInvalidSign
+	}
+	return nil
+}
@@ -41,18 +41,23 @@ func (this *VoteHandler) MakeDepositProposal(service *native.NativeContract) (*s
 		return nil, err
 	}
 
-	//use sourcechainid, height, extra as unique id
-	unique := &scom.EntranceParam{
-		SourceChainID: params.SourceChainID,
-		Height:        params.Height,
-		Extra:         params.Extra,
+	committee, err := getVoterCommittee(service, params.SourceChainID)
+	if err != nil {
+		return nil, fmt.Errorf("vote MakeDepositProposal, getVoterCommittee error: %v", err)
 	}
-	sink := polycomm.NewZeroCopySink(nil)
-	unique.Serialization(sink)
 
-	ok, err := CheckConsensusSigns(service, sink.Bytes())
-	if err != nil {
-		return nil, fmt.Errorf("vote MakeDepositProposal, CheckConsensusSigns error: %v", err)
+	input := voteInput(params.SourceChainID, params.Height, params.Extra)
+	var ok bool
+	if committee == nil {
+		ok, err = CheckConsensusSigns(service, input)
+		if err != nil {
+			return nil, fmt.Errorf("vote MakeDepositProposal, CheckConsensusSigns error: %v", err)
+		}
+	} else {
+		ok, err = CheckCommitteeSigns(service, committee, input)
+		if err != nil {
+			return nil, fmt.Errorf("vote MakeDepositProposal, CheckCommitteeSigns error: %v", err)
+		}
 	}
 	if ok {
 		txParam, err := scom.DecodeTxParam(params.Extra)
@@ -69,3 +74,15 @@ func (this *VoteHandler) MakeDepositProposal(service *native.NativeContract) (*s
 	}
 	return nil, nil
 }
+
+// voteInput uses sourcechainid, height, extra as the unique id of a voted transfer
+func voteInput(sourceChainID uint64, height uint32, extra []byte) []byte {
+	unique := &scom.EntranceParam{
+		SourceChainID: sourceChainID,
+		Height:        height,
+		Extra:         extra,
+	}
+	sink := polycomm.NewZeroCopySink(nil)
+	unique.Serialization(sink)
+	return sink.Bytes()
+}
@@ -21,8 +21,11 @@ package cross_chain_manager
 import (
 	"fmt"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/contracts/native"
 	"github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/bsc"
+	"github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/clique"
+	"github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/cometbft"
 	scom "github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/common"
 	"github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/consensus_vote"
 	"github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/cosmos"
@@ -30,6 +33,7 @@ import (
 	"github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/heco"
 	"github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/msc"
 	"github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/okex"
+	"github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/optimism"
 	"github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/polygon"
 	"github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/quorum"
 	"github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/zilliqa"
@@ -38,12 +42,18 @@ import (
 	"github.com/ethereum/go-ethereum/contracts/native/governance/node_manager"
 	"github.com/ethereum/go-ethereum/contracts/native/governance/side_chain_manager"
 	"github.com/ethereum/go-ethereum/contracts/native/utils"
+	"github.com/ethereum/go-ethereum/rlp"
 )
 
 const contractName = "cross chain manager"
 
 const (
-	BLACKED_CHAIN = "BlackedChain"
+	BLACKED_CHAIN   = "BlackedChain"
+	RATE_LIMIT      = "RateLimit"
+	ROUTE_VOLUME    = "RouteVolume"
+	PAUSED_ROUTE    = "PausedRoute"
+	QUEUED_TRANSFER = "QueuedTransfer"
+	GUARDIAN        = "Guardian"
 )
 
 var (
@@ -54,11 +64,29 @@ var (
 		scom.MethodMultiSign:           100000,
 		scom.MethodBlackChain:          0,
 		scom.MethodWhiteChain:          0,
+		scom.MethodGetVoteProgress:     0,
+		scom.MethodSetRateLimit:        0,
+		scom.MethodGetRateLimit:        0,
+		scom.MethodSetGuardian:         0,
+		scom.MethodGetGuardian:         0,
+		scom.MethodPauseRoute:          0,
+		scom.MethodUnpauseRoute:        0,
+		scom.MethodReleaseTransfer:     0,
+		scom.MethodGetQueuedTransfer:   0,
 	}
 )
 
+// cross chain manager implementation versions, activated by native contract upgrades of the cross
+// chain manager.
+const (
+	// VersionCircuitBreaker limits the transfer volume of the routes, the guardian pauses the routes
+	// and the transfers exceeding the limits are queued for delayed release
+	VersionCircuitBreaker = uint64(1)
+)
+
 func InitCrossChainManager() {
-	native.Contracts[this] = RegisterCrossChainManagerContract
+	native.Register(this, RegisterCrossChainManagerContract)
+	native.RegisterVersion(this, VersionCircuitBreaker, RegisterCrossChainManagerContractV1)
 }
 
 func RegisterCrossChainManagerContract(s *native.NativeContract) {
@@ -68,6 +96,30 @@ func RegisterCrossChainManagerContract(s *native.NativeContract) {
 	s.Register(scom.MethodImportOuterTransfer, ImportOuterTransfer)
 	s.Register(scom.MethodBlackChain, BlackChain)
 	s.Register(scom.MethodWhiteChain, WhiteChain)
+	s.Register(scom.MethodGetVoteProgress, GetVoteProgress)
+}
+
+// RegisterCrossChainManagerContractV1 registers the cross chain manager with the circuit breaker, the
+// route limits, the guardian and the queued transfers are managed in addition to the initial methods.
+func RegisterCrossChainManagerContractV1(s *native.NativeContract) {
+	RegisterCrossChainManagerContract(s)
+	s.Register(scom.MethodSetRateLimit, SetRateLimit)
+	s.Register(scom.MethodGetRateLimit, GetRateLimit)
+	s.Register(scom.MethodSetGuardian, SetGuardian)
+	s.Register(scom.MethodGetGuardian, GetGuardian)
+	s.Register(scom.MethodPauseRoute, PauseRoute)
+	s.Register(scom.MethodUnpauseRoute, UnpauseRoute)
+	s.Register(scom.MethodReleaseTransfer, ReleaseTransfer)
+	s.Register(scom.MethodGetQueuedTransfer, GetQueuedTransfer)
+}
+
+// versionEnabled returns whether the cross chain manager implementation `version` is active at current block.
+func versionEnabled(s *native.NativeContract, version uint64) (bool, error) {
+	active, err := s.ActiveVersion(this)
+	if err != nil {
+		return false, fmt.Errorf("failed to get cross chain manager version, err: %v", err)
+	}
+	return active >= version, nil
 }
 
 func GetChainHandler(router uint64) (scom.ChainHandler, error) {
@@ -94,6 +146,12 @@ func GetChainHandler(router uint64) (scom.ChainHandler, error) {
 		return zilliqa.NewHandler(), nil
 	case utils.ZION_ROUTER:
 		return sidechain.NewHandler(), nil
+	case utils.COMETBFT_ROUTER:
+		return cometbft.NewHandler(), nil
+	case utils.CLIQUE_ROUTER:
+		return clique.NewHandler(), nil
+	case utils.OPTIMISM_ROUTER:
+		return optimism.NewHandler(), nil
 	default:
 		return nil, fmt.Errorf("not a supported router:%d", router)
 	}
@@ -111,7 +169,7 @@ func ImportOuterTransfer(s *native.NativeContract) ([]byte, error) {
 	}
 
 	srcChainID := params.SourceChainID
-	if native.IsMainChain(srcChainID) {
+	if s.ContractRef().IsMainChain(srcChainID) {
 		return nil, fmt.Errorf("ImportExTransfer, source chain CAN'T be main chain")
 	}
 
@@ -146,41 +204,117 @@ func ImportOuterTransfer(s *native.NativeContract) ([]byte, error) {
 		return utils.PackOutputs(scom.ABI, scom.MethodImportOuterTransfer, true)
 	}
 
-	// transfer outcome for main chain
-	dstChainID := txParam.ToChainID
-	if srcChain.Router == utils.ZION_ROUTER && native.IsMainChain(dstChainID) {
-		if err := lock_proxy.Unlock(s, params.SourceChainID, txParam); err != nil {
-			return nil, err
-		} else {
+	// zion lock proxy transfers to an unavailable target chain are refunded by `executeTransfer`
+	ok, err := refundable(s, srcChain, txParam)
+	if err != nil {
+		return nil, fmt.Errorf("ImportExTransfer, %v", err)
+	}
+	targetErr := checkTargetChain(s, srcChain, txParam.ToChainID)
+	if targetErr != nil && !ok {
+		return nil, fmt.Errorf("ImportExTransfer, %v", targetErr)
+	}
+
+	// circuit breaker, transfers exceeding the route limit are queued for delayed release. the failed
+	// transfers refunded to the source chain and the unwinding messages are not subject to it.
+	breaker, err := versionEnabled(s, VersionCircuitBreaker)
+	if err != nil {
+		return nil, fmt.Errorf("ImportExTransfer, %v", err)
+	}
+	if breaker && targetErr == nil && !unwinding(srcChain, txParam) {
+		queued, err := checkRouteLimit(s, srcChain, txParam)
+		if err != nil {
+			return nil, fmt.Errorf("ImportExTransfer, %v", err)
+		}
+		if queued {
 			return utils.PackOutputs(scom.ABI, scom.MethodImportOuterTransfer, true)
 		}
 	}
 
+	relayer := &lock_proxy.Relayer{Address: s.ContractRef().TxOrigin(), Time: s.ContractRef().BlockTime()}
+	if err := executeTransfer(s, srcChain, txParam, relayer); err != nil {
+		return nil, err
+	}
+	return utils.PackOutputs(scom.ABI, scom.MethodImportOuterTransfer, true)
+}
+
+func checkTargetChain(s *native.NativeContract, srcChain *side_chain_manager.SideChain, dstChainID uint64) error {
+	// transfer outcome for main chain
+	if srcChain.Router == utils.ZION_ROUTER && s.ContractRef().IsMainChain(dstChainID) {
+		return nil
+	}
+
 	//check target chain
-	if blacked, err = CheckIfChainBlacked(s, dstChainID); err != nil {
-		return nil, fmt.Errorf("ImportExTransfer, CheckIfChainBlacked error: %v", err)
+	blacked, err := CheckIfChainBlacked(s, dstChainID)
+	if err != nil {
+		return fmt.Errorf("CheckIfChainBlacked error: %v", err)
 	}
 	if blacked {
-		return nil, fmt.Errorf("ImportExTransfer, target chain is blacked")
+		return fmt.Errorf("target chain is blacked")
 	}
 
 	dstChain, err := side_chain_manager.GetSideChain(s, dstChainID)
 	if err != nil {
-		return nil, fmt.Errorf("ImportExTransfer, side_chain_manager.GetSideChain error: %v", err)
+		return fmt.Errorf("side_chain_manager.GetSideChain error: %v", err)
 	}
 	if dstChain == nil {
-		return nil, fmt.Errorf("ImportExTransfer, side chain %d is not registered", dstChainID)
+		return fmt.Errorf("side chain %d is not registered", dstChainID)
 	}
 	if dstChain.Router == utils.BTC_ROUTER {
-		return nil, fmt.Errorf("btc is not supported")
+		return fmt.Errorf("btc is not supported")
 	}
+	return nil
+}
 
-	//NOTE, you need to store the tx in this
-	if err := scom.MakeTransaction(s, txParam, srcChainID); err != nil {
-		return nil, err
+// executeTransfer delivers the transfer committed by `relayer` to its target chain. zion lock proxy
+// transfers which can not be delivered are reverted and acknowledged as failed, the source side chain
+// refunds them.
+func executeTransfer(s *native.NativeContract, srcChain *side_chain_manager.SideChain, txParam *scom.MakeTxParam, relayer *lock_proxy.Relayer) error {
+	snapshot := s.StateDB().Snapshot()
+	err := checkTargetChain(s, srcChain, txParam.ToChainID)
+	if err == nil {
+		err = deliverTransfer(s, srcChain, txParam, relayer)
+	}
+	if err == nil {
+		return nil
+	}
+	if ok, rerr := refundable(s, srcChain, txParam); rerr != nil {
+		return rerr
+	} else if !ok {
+		return err
 	}
 
-	return utils.PackOutputs(scom.ABI, scom.MethodImportOuterTransfer, true)
+	s.StateDB().RevertToSnapshot(snapshot)
+	return lock_proxy.FailTransfer(s, srcChain.ChainId, txParam, err)
+}
+
+func deliverTransfer(s *native.NativeContract, srcChain *side_chain_manager.SideChain, txParam *scom.MakeTxParam, relayer *lock_proxy.Relayer) error {
+	if srcChain.Router == utils.ZION_ROUTER {
+		switch {
+		case s.ContractRef().IsMainChain(txParam.ToChainID) && txParam.Method == "refund":
+			return lock_proxy.Refund(s, srcChain.ChainId, txParam)
+		case s.ContractRef().IsMainChain(txParam.ToChainID) && txParam.Method == "settle":
+			return lock_proxy.Settle(s, srcChain.ChainId, txParam)
+		case s.ContractRef().IsMainChain(txParam.ToChainID):
+			return lock_proxy.Unlock(s, srcChain.ChainId, txParam, relayer)
+		default:
+			// side chain to side chain lock proxy transfer
+			if ok, err := lock_proxy.Refundable(s, txParam); err != nil {
+				return err
+			} else if ok {
+				return lock_proxy.Transfer(s, srcChain.ChainId, txParam, relayer)
+			}
+		}
+	}
+
+	//NOTE, you need to store the tx in this
+	return scom.MakeTransaction(s, txParam, srcChain.ChainId)
+}
+
+func refundable(s *native.NativeContract, srcChain *side_chain_manager.SideChain, txParam *scom.MakeTxParam) (bool, error) {
+	if srcChain.Router != utils.ZION_ROUTER {
+		return false, nil
+	}
+	return lock_proxy.Refundable(s, txParam)
 }
 
 func BlackChain(s *native.NativeContract) ([]byte, error) {
@@ -190,7 +324,7 @@ func BlackChain(s *native.NativeContract) ([]byte, error) {
 		return nil, err
 	}
 
-	if native.IsMainChain(params.ChainID) {
+	if s.ContractRef().IsMainChain(params.ChainID) {
 		return nil, fmt.Errorf("BlackChain, zion relay chain not supported")
 	}
 
@@ -214,7 +348,7 @@ func WhiteChain(s *native.NativeContract) ([]byte, error) {
 		return nil, err
 	}
 
-	if native.IsMainChain(params.ChainID) {
+	if s.ContractRef().IsMainChain(params.ChainID) {
 		return nil, fmt.Errorf("WhiteChain, zion relay chain not supported")
 	}
 
@@ -230,3 +364,248 @@ func WhiteChain(s *native.NativeContract) ([]byte, error) {
 	RemoveBlackChain(s, params.ChainID)
 	return utils.PackOutputs(scom.ABI, scom.MethodWhiteChain, true)
 }
+
+// GetVoteProgress returns the rlp encoded vote progress of a transfer imported through the
+// vote router, identified by its source chain id, height and extra.
+func GetVoteProgress(s *native.NativeContract) ([]byte, error) {
+	ctx := s.ContractRef().CurrentContext()
+	params := &scom.GetVoteProgressParam{}
+	if err := utils.UnpackMethod(scom.ABI, scom.MethodGetVoteProgress, params, ctx.Payload); err != nil {
+		return nil, err
+	}
+
+	progress, err := consensus_vote.GetVoteProgress(s, params.SourceChainID, params.Height, params.Extra)
+	if err != nil {
+		return nil, fmt.Errorf("GetVoteProgress, %v", err)
+	}
+	enc, err := rlp.EncodeToBytes(progress)
+	if err != nil {
+		return nil, fmt.Errorf("GetVoteProgress, encode vote progress error: %v", err)
+	}
+	return utils.PackOutputs(scom.ABI, scom.MethodGetVoteProgress, enc)
+}
+
+// SetRateLimit sets the volume limit of a route once the consensus signatures are collected,
+// a zero limit removes the limit of the route.
+func SetRateLimit(s *native.NativeContract) ([]byte, error) {
+	ctx := s.ContractRef().CurrentContext()
+	params := &scom.RateLimitParam{}
+	if err := utils.UnpackMethod(scom.ABI, scom.MethodSetRateLimit, params, ctx.Payload); err != nil {
+		return nil, err
+	}
+
+	if s.ContractRef().IsMainChain(params.SrcChainID) {
+		return nil, fmt.Errorf("SetRateLimit, source chain CAN'T be main chain")
+	}
+	remove := params.Limit == nil || params.Limit.Sign() == 0
+	if !remove && (params.Limit.Sign() < 0 || params.Window == 0) {
+		return nil, fmt.Errorf("SetRateLimit, invalid limit %v in window %d", params.Limit, params.Window)
+	}
+
+	ok, err := node_manager.CheckConsensusSigns(s, scom.MethodSetRateLimit, ctx.Payload, s.ContractRef().MsgSender())
+	if err != nil {
+		return nil, fmt.Errorf("SetRateLimit, CheckConsensusSigns error: %v", err)
+	}
+	if !ok {
+		return utils.PackOutputs(scom.ABI, scom.MethodSetRateLimit, true)
+	}
+
+	if remove {
+		removeRateLimit(s, params.SrcChainID, params.DstChainID, params.Asset)
+	} else {
+		limit := &RateLimit{Window: params.Window, Limit: params.Limit, Delay: params.Delay}
+		if err := putRateLimit(s, params.SrcChainID, params.DstChainID, params.Asset, limit); err != nil {
+			return nil, fmt.Errorf("SetRateLimit, putRateLimit error: %v", err)
+		}
+	}
+	if err := s.AddNotify(scom.ABI, []string{scom.EventSetRateLimit}, params.SrcChainID, params.DstChainID, params.Asset,
+		params.Window, params.Limit, params.Delay); err != nil {
+		return nil, fmt.Errorf("SetRateLimit, AddNotify error: %v", err)
+	}
+	return utils.PackOutputs(scom.ABI, scom.MethodSetRateLimit, true)
+}
+
+// GetRateLimit returns the rlp encoded volume limit of a route.
+func GetRateLimit(s *native.NativeContract) ([]byte, error) {
+	ctx := s.ContractRef().CurrentContext()
+	params := &scom.RouteParam{}
+	if err := utils.UnpackMethod(scom.ABI, scom.MethodGetRateLimit, params, ctx.Payload); err != nil {
+		return nil, err
+	}
+
+	limit, err := getRateLimit(s, params.SrcChainID, params.DstChainID, params.Asset)
+	if err != nil {
+		return nil, fmt.Errorf("GetRateLimit, getRateLimit error: %v", err)
+	}
+	if limit == nil {
+		return nil, fmt.Errorf("GetRateLimit, route from chain %d to chain %d has no limit", params.SrcChainID, params.DstChainID)
+	}
+	enc, err := rlp.EncodeToBytes(limit)
+	if err != nil {
+		return nil, fmt.Errorf("GetRateLimit, encode rate limit error: %v", err)
+	}
+	return utils.PackOutputs(scom.ABI, scom.MethodGetRateLimit, enc)
+}
+
+// SetGuardian sets the guardian who is able to pause routes instantly once the consensus signatures
+// are collected, an empty address removes the guardian.
+func SetGuardian(s *native.NativeContract) ([]byte, error) {
+	ctx := s.ContractRef().CurrentContext()
+	params := &scom.SetGuardianParam{}
+	if err := utils.UnpackMethod(scom.ABI, scom.MethodSetGuardian, params, ctx.Payload); err != nil {
+		return nil, err
+	}
+
+	ok, err := node_manager.CheckConsensusSigns(s, scom.MethodSetGuardian, ctx.Payload, s.ContractRef().MsgSender())
+	if err != nil {
+		return nil, fmt.Errorf("SetGuardian, CheckConsensusSigns error: %v", err)
+	}
+	if !ok {
+		return utils.PackOutputs(scom.ABI, scom.MethodSetGuardian, true)
+	}
+
+	putGuardian(s, params.Guardian)
+	if err := s.AddNotify(scom.ABI, []string{scom.EventSetGuardian}, params.Guardian); err != nil {
+		return nil, fmt.Errorf("SetGuardian, AddNotify error: %v", err)
+	}
+	return utils.PackOutputs(scom.ABI, scom.MethodSetGuardian, true)
+}
+
+func GetGuardian(s *native.NativeContract) ([]byte, error) {
+	guardian, err := getGuardian(s)
+	if err != nil {
+		return nil, fmt.Errorf("GetGuardian, getGuardian error: %v", err)
+	}
+	return utils.PackOutputs(scom.ABI, scom.MethodGetGuardian, guardian)
+}
+
+// PauseRoute pauses a route without waiting for consensus signatures, only the guardian can call it.
+// an empty asset pauses all the routes between the chains.
+func PauseRoute(s *native.NativeContract) ([]byte, error) {
+	ctx := s.ContractRef().CurrentContext()
+	params := &scom.RouteParam{}
+	if err := utils.UnpackMethod(scom.ABI, scom.MethodPauseRoute, params, ctx.Payload); err != nil {
+		return nil, err
+	}
+
+	guardian, err := getGuardian(s)
+	if err != nil {
+		return nil, fmt.Errorf("PauseRoute, getGuardian error: %v", err)
+	}
+	if guardian == common.EmptyAddress || s.ContractRef().MsgSender() != guardian {
+		return nil, fmt.Errorf("PauseRoute, caller is not the guardian")
+	}
+
+	putRoutePaused(s, params.SrcChainID, params.DstChainID, params.Asset)
+	if err := s.AddNotify(scom.ABI, []string{scom.EventPauseRoute}, params.SrcChainID, params.DstChainID, params.Asset); err != nil {
+		return nil, fmt.Errorf("PauseRoute, AddNotify error: %v", err)
+	}
+	return utils.PackOutputs(scom.ABI, scom.MethodPauseRoute, true)
+}
+
+// UnpauseRoute resumes a paused route once the consensus signatures are collected.
+func UnpauseRoute(s *native.NativeContract) ([]byte, error) {
+	ctx := s.ContractRef().CurrentContext()
+	params := &scom.RouteParam{}
+	if err := utils.UnpackMethod(scom.ABI, scom.MethodUnpauseRoute, params, ctx.Payload); err != nil {
+		return nil, err
+	}
+
+	ok, err := node_manager.CheckConsensusSigns(s, scom.MethodUnpauseRoute, ctx.Payload, s.ContractRef().MsgSender())
+	if err != nil {
+		return nil, fmt.Errorf("UnpauseRoute, CheckConsensusSigns error: %v", err)
+	}
+	if !ok {
+		return utils.PackOutputs(scom.ABI, scom.MethodUnpauseRoute, true)
+	}
+
+	removeRoutePaused(s, params.SrcChainID, params.DstChainID, params.Asset)
+	if err := s.AddNotify(scom.ABI, []string{scom.EventUnpauseRoute}, params.SrcChainID, params.DstChainID, params.Asset); err != nil {
+		return nil, fmt.Errorf("UnpauseRoute, AddNotify error: %v", err)
+	}
+	return utils.PackOutputs(scom.ABI, scom.MethodUnpauseRoute, true)
+}
+
+// ReleaseTransfer executes a queued transfer once its delay has passed, anyone can call it.
+func ReleaseTransfer(s *native.NativeContract) ([]byte, error) {
+	ctx := s.ContractRef().CurrentContext()
+	params := &scom.QueuedTransferParam{}
+	if err := utils.UnpackMethod(scom.ABI, scom.MethodReleaseTransfer, params, ctx.Payload); err != nil {
+		return nil, err
+	}
+
+	queued, err := getQueuedTransfer(s, params.SourceChainID, params.CrossChainID)
+	if err != nil {
+		return nil, fmt.Errorf("ReleaseTransfer, getQueuedTransfer error: %v", err)
+	}
+	if queued == nil {
+		return nil, fmt.Errorf("ReleaseTransfer, transfer %x is not queued", params.CrossChainID)
+	}
+	if now := s.ContractRef().BlockTime(); now < queued.ReleaseTime {
+		return nil, fmt.Errorf("ReleaseTransfer, transfer is queued until %d, now %d", queued.ReleaseTime, now)
+	}
+
+	blacked, err := CheckIfChainBlacked(s, queued.SourceChainID)
+	if err != nil {
+		return nil, fmt.Errorf("ReleaseTransfer, CheckIfChainBlacked err: %v", err)
+	}
+	if blacked {
+		return nil, fmt.Errorf("ReleaseTransfer, source chain is blacked")
+	}
+	srcChain, err := side_chain_manager.GetSideChain(s, queued.SourceChainID)
+	if err != nil {
+		return nil, fmt.Errorf("ReleaseTransfer, side_chain_manager.GetSideChain err: %v", err)
+	} else if srcChain == nil {
+		return nil, fmt.Errorf("ReleaseTransfer, side chain %d is not registered", queued.SourceChainID)
+	}
+
+	// the route may be paused or the target chain blacked while the transfer was queued, the transfers
+	// refunded to the source chain are not held by the paused route.
+	ok, err := refundable(s, srcChain, queued.Param)
+	if err != nil {
+		return nil, fmt.Errorf("ReleaseTransfer, %v", err)
+	}
+	targetErr := checkTargetChain(s, srcChain, queued.Param.ToChainID)
+	if targetErr != nil && !ok {
+		return nil, fmt.Errorf("ReleaseTransfer, %v", targetErr)
+	}
+	if targetErr == nil {
+		asset, _ := transferVolume(srcChain.Router, queued.Param.Args)
+		if err := checkRoutePaused(s, queued.SourceChainID, queued.Param.ToChainID, asset); err != nil {
+			return nil, fmt.Errorf("ReleaseTransfer, %v", err)
+		}
+	}
+
+	// the relayer fee is earned by the relayer who committed the transfer in time, not the caller
+	removeQueuedTransfer(s, queued.SourceChainID, params.CrossChainID)
+	relayer := &lock_proxy.Relayer{Address: queued.Relayer, Time: queued.CommitTime}
+	if err := executeTransfer(s, srcChain, queued.Param, relayer); err != nil {
+		return nil, err
+	}
+	if err := s.AddNotify(scom.ABI, []string{scom.EventReleaseTransfer}, queued.SourceChainID, params.CrossChainID); err != nil {
+		return nil, fmt.Errorf("ReleaseTransfer, AddNotify error: %v", err)
+	}
+	return utils.PackOutputs(scom.ABI, scom.MethodReleaseTransfer, true)
+}
+
+// GetQueuedTransfer returns the rlp encoded transfer queued by the circuit breaker.
+func GetQueuedTransfer(s *native.NativeContract) ([]byte, error) {
+	ctx := s.ContractRef().CurrentContext()
+	params := &scom.QueuedTransferParam{}
+	if err := utils.UnpackMethod(scom.ABI, scom.MethodGetQueuedTransfer, params, ctx.Payload); err != nil {
+		return nil, err
+	}
+
+	queued, err := getQueuedTransfer(s, params.SourceChainID, params.CrossChainID)
+	if err != nil {
+		return nil, fmt.Errorf("GetQueuedTransfer, getQueuedTransfer error: %v", err)
+	}
+	if queued == nil {
+		return nil, fmt.Errorf("GetQueuedTransfer, transfer %x is not queued", params.CrossChainID)
+	}
+	enc, err := rlp.EncodeToBytes(queued)
+	if err != nil {
+		return nil, fmt.Errorf("GetQueuedTransfer, encode queued transfer error: %v", err)
+	}
+	return utils.PackOutputs(scom.ABI, scom.MethodGetQueuedTransfer, enc)
+}
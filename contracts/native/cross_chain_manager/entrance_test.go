@@ -0,0 +1,88 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package cross_chain_manager
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/contracts/native"
+	scom "github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/common"
+	"github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/zion/mainchain/lock_proxy"
+	zutils "github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/zion/utils"
+	"github.com/ethereum/go-ethereum/contracts/native/governance/side_chain_manager"
+	"github.com/ethereum/go-ethereum/contracts/native/utils"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteTransferRefund(t *testing.T) {
+	lock_proxy.InitABI()
+	sdb := newTestStateDB(t)
+	// refunds and side chain routing are enabled by the lock proxy upgrades
+	upgrades := []*params.NativeUpgrade{{Contract: utils.LockProxyContractAddress, Version: lock_proxy.VersionRoute, Block: common.Big0}}
+	newContract := func(payload []byte) *native.NativeContract {
+		s := newTestContract(sdb, common.Address{}, 1000, payload)
+		s.ContractRef().SetNativeUpgrades(upgrades)
+		return s
+	}
+	s := newContract(nil)
+	relayer := &lock_proxy.Relayer{}
+	srcChain := &side_chain_manager.SideChain{ChainId: testSrcChainID, Router: utils.ZION_ROUTER}
+	assert.NoError(t, side_chain_manager.PutSideChain(s, srcChain))
+
+	args, err := zutils.EncodeTxArgs(common.EmptyAddress.Bytes(), common.HexToAddress("0xb").Bytes(), big.NewInt(100))
+	assert.NoError(t, err)
+	txParam := &scom.MakeTxParam{
+		TxHash:              []byte{1},
+		CrossChainID:        []byte{1},
+		FromContractAddress: utils.LockProxyContractAddress.Bytes(),
+		ToChainID:           params.LegacyRelayChainID,
+		ToContractAddress:   utils.LockProxyContractAddress.Bytes(),
+		Method:              "unlock",
+		Args:                args,
+	}
+
+	// the initial lock proxy implementation does not refund the failed unlock
+	assert.Error(t, executeTransfer(newTestContract(sdb, common.Address{}, 1000, nil), srcChain, txParam, relayer))
+
+	// nothing is locked for the source chain, the failed unlock is sent back for refund
+	assert.NoError(t, executeTransfer(s, srcChain, txParam, relayer))
+	payload, err := (&lock_proxy.MethodGetFailedReceiptInput{FromChainId: testSrcChainID, CrossChainId: []byte{1}}).Encode()
+	assert.NoError(t, err)
+	_, err = lock_proxy.GetFailedReceipt(newTestContract(sdb, common.Address{}, 1000, payload))
+	assert.NoError(t, err)
+
+	// refunds are not refundable
+	txParam.CrossChainID, txParam.Method = []byte{2}, "refund"
+	assert.Error(t, executeTransfer(newContract(nil), srcChain, txParam, relayer))
+
+	// side chain transfers are refunded if the main chain can not route them
+	txParam.CrossChainID, txParam.Method, txParam.ToChainID = []byte{3}, "mint", testDstChainID
+	assert.NoError(t, executeTransfer(newContract(nil), srcChain, txParam, relayer))
+	payload, err = (&lock_proxy.MethodGetFailedReceiptInput{FromChainId: testSrcChainID, CrossChainId: []byte{3}}).Encode()
+	assert.NoError(t, err)
+	_, err = lock_proxy.GetFailedReceipt(newTestContract(sdb, common.Address{}, 1000, payload))
+	assert.NoError(t, err)
+
+	// other transfers still fail on unavailable target chains
+	txParam = testTxParam(4, big.NewInt(1))
+	assert.Error(t, executeTransfer(newContract(nil), &side_chain_manager.SideChain{ChainId: testSrcChainID, Router: utils.ETH_ROUTER}, txParam, relayer))
+}
@@ -0,0 +1,182 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package eth
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	scom "github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/common"
+	"github.com/ethereum/go-ethereum/contracts/native/governance/side_chain_manager"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/light"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// Proof modes of the side chain, the storage mode proves the request kept in the
+// storage of the cross chain manager contract, while the receipt mode proves the
+// `CrossChainEvent` log emitted by it.
+const (
+	PROOF_MODE_STORAGE = "storage"
+	PROOF_MODE_RECEIPT = "receipt"
+)
+
+// ProofModeInfo is read from the json `ExtraInfo` of the side chain, along with the
+// fields of the router.
+type ProofModeInfo struct {
+	ProofMode string `json:"proofMode"`
+}
+
+// IsReceiptMode returns whether the requests of the side chain are proved by receipts,
+// the side chain without extra info is in storage mode.
+func IsReceiptMode(sideChain *side_chain_manager.SideChain) (bool, error) {
+	if sideChain == nil {
+		return false, fmt.Errorf("side chain not found")
+	}
+	if len(sideChain.ExtraInfo) == 0 {
+		return false, nil
+	}
+	info := new(ProofModeInfo)
+	if err := json.Unmarshal(sideChain.ExtraInfo, info); err != nil {
+		return false, fmt.Errorf("deserialize extra info error: %v", err)
+	}
+	switch info.ProofMode {
+	case "", PROOF_MODE_STORAGE:
+		return false, nil
+	case PROOF_MODE_RECEIPT:
+		return true, nil
+	default:
+		return false, fmt.Errorf("unknown proof mode %s", info.ProofMode)
+	}
+}
+
+// ReceiptProof is the proof of the receipt of the transaction in the receipt trie.
+type ReceiptProof struct {
+	TxIndex uint64   `json:"txIndex"`
+	Proof   []string `json:"proof"`
+}
+
+// CrossChainEvent is emitted by the cross chain manager contract with the raw param
+// of the request:
+// event CrossChainEvent(address indexed sender, bytes txId, address proxyOrAssetContract, uint64 toChainId, bytes toContract, bytes rawdata)
+var CrossChainEvent abi.Event
+
+func init() {
+	const eventABI = `[{"anonymous":false,"inputs":[{"indexed":true,"name":"sender","type":"address"},{"indexed":false,"name":"txId","type":"bytes"},{"indexed":false,"name":"proxyOrAssetContract","type":"address"},{"indexed":false,"name":"toChainId","type":"uint64"},{"indexed":false,"name":"toContract","type":"bytes"},{"indexed":false,"name":"rawdata","type":"bytes"}],"name":"CrossChainEvent","type":"event"}]`
+	parsed, err := abi.JSON(strings.NewReader(eventABI))
+	if err != nil {
+		panic(err)
+	}
+	CrossChainEvent = parsed.Events["CrossChainEvent"]
+}
+
+// receiptRLP is the consensus encoding of the receipt, typed receipts are prefixed
+// with the type byte.
+type receiptRLP struct {
+	PostStateOrStatus []byte
+	CumulativeGasUsed uint64
+	Bloom             types.Bloom
+	Logs              []*types.Log
+}
+
+// decodeReceipt decodes the consensus encoding of legacy and EIP-2718 typed receipts.
+func decodeReceipt(raw []byte) (*receiptRLP, error) {
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("empty receipt")
+	}
+	// typed receipt starts with the type byte in [0, 0x7f], legacy one is a rlp list
+	if raw[0] <= 0x7f {
+		raw = raw[1:]
+	}
+	receipt := new(receiptRLP)
+	if err := rlp.DecodeBytes(raw, receipt); err != nil {
+		return nil, err
+	}
+	return receipt, nil
+}
+
+// VerifyReceiptProof verifies the receipt proof against the receipt root, and returns
+// the logs of the successful receipt.
+func VerifyReceiptProof(proof *ReceiptProof, receiptHash common.Hash) ([]*types.Log, error) {
+	nodeList := new(light.NodeList)
+	for _, s := range proof.Proof {
+		nodeList.Put(nil, common.Hex2Bytes(scom.Replace0x(s)))
+	}
+	key, err := rlp.EncodeToBytes(proof.TxIndex)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := trie.VerifyProof(receiptHash, key, nodeList.NodeSet())
+	if err != nil {
+		return nil, fmt.Errorf("verify receipt proof error: %v", err)
+	}
+	if raw == nil {
+		return nil, fmt.Errorf("receipt of tx %d not found", proof.TxIndex)
+	}
+	receipt, err := decodeReceipt(raw)
+	if err != nil {
+		return nil, fmt.Errorf("decode receipt error: %v", err)
+	}
+	if !bytes.Equal(receipt.PostStateOrStatus, []byte{0x01}) {
+		return nil, fmt.Errorf("receipt of tx %d is not successful", proof.TxIndex)
+	}
+	return receipt.Logs, nil
+}
+
+// VerifyFromReceipt verifies the `CrossChainEvent` of the extra is emitted by the
+// contract in the receipt of the proof, and decodes the cross chain transaction.
+func VerifyFromReceipt(proof []byte, receiptHash common.Hash, contractAddr, extra []byte) (*scom.MakeTxParam, error) {
+	receiptProof := new(ReceiptProof)
+	if err := json.Unmarshal(proof, receiptProof); err != nil {
+		return nil, fmt.Errorf("VerifyFromReceipt, unmarshal proof error:%s", err)
+	}
+	logs, err := VerifyReceiptProof(receiptProof, receiptHash)
+	if err != nil {
+		return nil, fmt.Errorf("VerifyFromReceipt, %v", err)
+	}
+
+	found := false
+	for _, l := range logs {
+		if !bytes.Equal(l.Address.Bytes(), contractAddr) || len(l.Topics) == 0 || l.Topics[0] != CrossChainEvent.ID {
+			continue
+		}
+		values, err := CrossChainEvent.Inputs.NonIndexed().Unpack(l.Data)
+		if err != nil {
+			return nil, fmt.Errorf("VerifyFromReceipt, unpack CrossChainEvent error:%s", err)
+		}
+		if rawdata, ok := values[len(values)-1].([]byte); ok && bytes.Equal(rawdata, extra) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("VerifyFromReceipt, no CrossChainEvent of extra %x from contract %x in receipt", extra, contractAddr)
+	}
+
+	txParam, err := scom.DecodeTxParam(extra)
+	if err != nil {
+		return nil, fmt.Errorf("VerifyFromReceipt, deserialize merkleValue error:%s", err)
+	}
+	return txParam, nil
+}
@@ -0,0 +1,167 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package eth
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	scom "github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/common"
+	"github.com/ethereum/go-ethereum/contracts/native/governance/side_chain_manager"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// dynamicFeeTxType is the EIP-1559 transaction type of the source chains.
+const dynamicFeeTxType = 0x02
+
+var testCCMC = common.HexToAddress("0x0000000000000000000000000000000000008888")
+
+func crossChainLog(t *testing.T, contract common.Address, rawdata []byte) *types.Log {
+	data, err := CrossChainEvent.Inputs.NonIndexed().Pack([]byte{0x01}, common.HexToAddress("0x02"), uint64(3), []byte{0x04}, rawdata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &types.Log{
+		Address: contract,
+		Topics:  []common.Hash{CrossChainEvent.ID, common.BytesToHash(common.HexToAddress("0x05").Bytes())},
+		Data:    data,
+	}
+}
+
+// encodeReceipt returns the consensus encoding of the receipt, the type 2 receipts
+// are encoded the same as access list ones.
+func encodeReceipt(t *testing.T, typ byte, status uint64, logs []*types.Log) []byte {
+	var buf bytes.Buffer
+	if typ != types.LegacyTxType {
+		buf.WriteByte(typ)
+	}
+	statusEnc := []byte{}
+	if status == types.ReceiptStatusSuccessful {
+		statusEnc = []byte{0x01}
+	}
+	if err := rlp.Encode(&buf, &receiptRLP{statusEnc, 21000, types.Bloom{}, logs}); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// receiptTrie commits the receipts into the trie, and returns the root with the proof
+// of the receipt of the index.
+func receiptTrie(t *testing.T, receipts [][]byte, index uint64) (common.Hash, []byte) {
+	tr, _ := trie.New(common.Hash{}, trie.NewDatabase(rawdb.NewMemoryDatabase()))
+	for i, receipt := range receipts {
+		key, _ := rlp.EncodeToBytes(uint64(i))
+		tr.Update(key, receipt)
+	}
+	key, _ := rlp.EncodeToBytes(index)
+	proofDb := memorydb.New()
+	if err := tr.Prove(key, 0, proofDb); err != nil {
+		t.Fatal(err)
+	}
+	proof := &ReceiptProof{TxIndex: index}
+	it := proofDb.NewIterator(nil, nil)
+	for it.Next() {
+		proof.Proof = append(proof.Proof, hexutil.Encode(it.Value()))
+	}
+	it.Release()
+	raw, _ := json.Marshal(proof)
+	return tr.Hash(), raw
+}
+
+func TestVerifyFromReceipt(t *testing.T) {
+	extra, err := scom.EncodeTxParam(&scom.MakeTxParam{
+		TxHash:              []byte{0x01},
+		CrossChainID:        []byte{0x02},
+		FromContractAddress: []byte{0x03},
+		ToChainID:           4,
+		ToContractAddress:   []byte{0x05},
+		Method:              "unlock",
+		Args:                []byte{0x06},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	other := common.HexToAddress("0x9999")
+	receipts := [][]byte{
+		encodeReceipt(t, types.LegacyTxType, types.ReceiptStatusSuccessful, []*types.Log{crossChainLog(t, testCCMC, extra)}),
+		encodeReceipt(t, dynamicFeeTxType, types.ReceiptStatusSuccessful, []*types.Log{
+			{Address: testCCMC, Topics: []common.Hash{common.HexToHash("0x01")}},
+			crossChainLog(t, testCCMC, extra),
+		}),
+		encodeReceipt(t, dynamicFeeTxType, types.ReceiptStatusFailed, []*types.Log{crossChainLog(t, testCCMC, extra)}),
+		encodeReceipt(t, types.AccessListTxType, types.ReceiptStatusSuccessful, []*types.Log{crossChainLog(t, other, extra)}),
+	}
+
+	for _, index := range []uint64{0, 1} {
+		root, proof := receiptTrie(t, receipts, index)
+		param, err := VerifyFromReceipt(proof, root, testCCMC.Bytes(), extra)
+		if err != nil {
+			t.Fatalf("receipt %d: %v", index, err)
+		}
+		if param.Method != "unlock" || param.ToChainID != 4 {
+			t.Fatalf("unexpected param: %+v", param)
+		}
+	}
+
+	root, proof := receiptTrie(t, receipts, 1)
+	if _, err := VerifyFromReceipt(proof, root, testCCMC.Bytes(), append(extra, 0x00)); err == nil {
+		t.Fatal("receipt without the event of extra should fail")
+	}
+	if _, err := VerifyFromReceipt(proof, common.HexToHash("0x01"), testCCMC.Bytes(), extra); err == nil {
+		t.Fatal("proof against other root should fail")
+	}
+	root, proof = receiptTrie(t, receipts, 2)
+	if _, err := VerifyFromReceipt(proof, root, testCCMC.Bytes(), extra); err == nil {
+		t.Fatal("failed receipt should fail")
+	}
+	root, proof = receiptTrie(t, receipts, 3)
+	if _, err := VerifyFromReceipt(proof, root, testCCMC.Bytes(), extra); err == nil {
+		t.Fatal("event of other contract should fail")
+	}
+}
+
+func TestIsReceiptMode(t *testing.T) {
+	for _, c := range []struct {
+		extra   string
+		receipt bool
+		fail    bool
+	}{
+		{"", false, false},
+		{`{"chainID":56}`, false, false},
+		{`{"proofMode":"storage"}`, false, false},
+		{`{"ChainID":56,"proofMode":"receipt"}`, true, false},
+		{`{"proofMode":"log"}`, false, true},
+		{`invalid`, false, true},
+	} {
+		receipt, err := IsReceiptMode(&side_chain_manager.SideChain{ChainId: 2, ExtraInfo: []byte(c.extra)})
+		if (err != nil) != c.fail || receipt != c.receipt {
+			t.Fatalf("extra %q: receipt %v, err %v", c.extra, receipt, err)
+		}
+	}
+	if _, err := IsReceiptMode(nil); err == nil {
+		t.Fatal("nil side chain should fail")
+	}
+}
@@ -52,6 +52,14 @@ func verifyFromEthTx(native *native.NativeContract, proof, extra []byte, fromCha
 		return nil, fmt.Errorf("VerifyFromEthProof, get header by height, height:%d, error:%s", height, err)
 	}
 
+	receiptMode, err := IsReceiptMode(sideChain)
+	if err != nil {
+		return nil, fmt.Errorf("VerifyFromEthProof, %v", err)
+	}
+	if receiptMode {
+		return VerifyFromReceipt(proof, blockData.ReceiptHash, sideChain.CCMCAddress, extra)
+	}
+
 	ethProof := new(ETHProof)
 	err = json.Unmarshal(proof, ethProof)
 	if err != nil {
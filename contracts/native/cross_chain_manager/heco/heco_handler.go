@@ -27,6 +27,7 @@ import (
 	ecommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/contracts/native"
 	scom "github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/common"
+	eth2 "github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/eth"
 	"github.com/ethereum/go-ethereum/contracts/native/governance/side_chain_manager"
 	"github.com/ethereum/go-ethereum/contracts/native/header_sync/eth"
 	"github.com/ethereum/go-ethereum/contracts/native/header_sync/heco"
@@ -91,6 +92,14 @@ func verifyFromHecoTx(native *native.NativeContract, proof, extra []byte, fromCh
 		return nil, fmt.Errorf("verifyFromHecoTx, GetCanonicalHeader height:%d, error:%s", height, err)
 	}
 
+	receiptMode, err := eth2.IsReceiptMode(sideChain)
+	if err != nil {
+		return nil, fmt.Errorf("verifyFromHecoTx, %v", err)
+	}
+	if receiptMode {
+		return eth2.VerifyFromReceipt(proof, headerWithSum.Header.ReceiptHash, sideChain.CCMCAddress, extra)
+	}
+
 	hecoProof := new(Proof)
 	err = json.Unmarshal(proof, hecoProof)
 	if err != nil {
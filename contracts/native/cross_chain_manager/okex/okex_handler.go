@@ -24,6 +24,7 @@ import (
 	ethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/contracts/native"
 	scom "github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/common"
+	eth2 "github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/eth"
 	"github.com/ethereum/go-ethereum/contracts/native/governance/side_chain_manager"
 	"github.com/ethereum/go-ethereum/contracts/native/header_sync/okex"
 	"github.com/ethereum/go-ethereum/contracts/native/utils"
@@ -99,6 +100,10 @@ func (this *OKHandler) MakeDepositProposal(service *native.NativeContract) (*sco
 	if err != nil {
 		return nil, fmt.Errorf("okex MakeDepositProposal, side_chain_manager.GetSideChain error: %v", err)
 	}
+	// the tendermint header of okex commits no receipt root, only the storage is provable
+	if receiptMode, _ := eth2.IsReceiptMode(sideChain); receiptMode {
+		return nil, fmt.Errorf("okex MakeDepositProposal, receipt proof mode is not supported")
+	}
 	if len(proof.Ops) != 2 {
 		return nil, fmt.Errorf("proof size wrong")
 	}
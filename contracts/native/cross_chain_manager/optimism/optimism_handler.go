@@ -0,0 +1,99 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package optimism
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/contracts/native"
+	scom "github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/common"
+	eth2 "github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/eth"
+	"github.com/ethereum/go-ethereum/contracts/native/governance/side_chain_manager"
+	"github.com/ethereum/go-ethereum/contracts/native/header_sync/eth"
+	"github.com/ethereum/go-ethereum/contracts/native/header_sync/optimism"
+	"github.com/ethereum/go-ethereum/contracts/native/utils"
+)
+
+type Handler struct {
+}
+
+func NewHandler() *Handler {
+	return &Handler{}
+}
+
+// MakeDepositProposal verifies the L2 storage proof of the cross chain transaction against
+// the state root of the finalized output synced by the optimism router, the height of the
+// params is the L2 block number of the output.
+func (h *Handler) MakeDepositProposal(service *native.NativeContract) (*scom.MakeTxParam, error) {
+	ctx := service.ContractRef().CurrentContext()
+	params := &scom.EntranceParam{}
+	if err := utils.UnpackMethod(scom.ABI, scom.MethodImportOuterTransfer, params, ctx.Payload); err != nil {
+		return nil, err
+	}
+
+	sideChain, err := side_chain_manager.GetSideChain(service, params.SourceChainID)
+	if err != nil {
+		return nil, fmt.Errorf("optimism MakeDepositProposal, side_chain_manager.GetSideChain error: %v", err)
+	}
+
+	value, err := verifyFromTx(service, params.Proof, params.Extra, params.SourceChainID, params.Height, sideChain)
+	if err != nil {
+		return nil, fmt.Errorf("optimism MakeDepositProposal, verifyFromTx error: %s", err)
+	}
+	if err := scom.CheckDoneTx(service, value.CrossChainID, params.SourceChainID); err != nil {
+		return nil, fmt.Errorf("optimism MakeDepositProposal, check done transaction error:%s", err)
+	}
+	if err := scom.PutDoneTx(service, value.CrossChainID, params.SourceChainID); err != nil {
+		return nil, fmt.Errorf("optimism MakeDepositProposal, PutDoneTx error:%s", err)
+	}
+	return value, nil
+}
+
+func verifyFromTx(native *native.NativeContract, proof, extra []byte, fromChainID uint64, height uint32, sideChain *side_chain_manager.SideChain) (*scom.MakeTxParam, error) {
+	output, err := optimism.GetOutput(native, fromChainID, uint64(height))
+	if err != nil {
+		return nil, fmt.Errorf("verifyFromTx, get output of height %d, error:%s", height, err)
+	}
+
+	ethProof := new(eth2.ETHProof)
+	if err := json.Unmarshal(proof, ethProof); err != nil {
+		return nil, fmt.Errorf("verifyFromTx, unmarshal proof error:%s", err)
+	}
+	if len(ethProof.StorageProofs) != 1 {
+		return nil, fmt.Errorf("verifyFromTx, incorrect proof format")
+	}
+
+	proofResult, err := eth2.VerifyMerkleProof(ethProof, &eth.Header{Root: output.StateRoot}, sideChain.CCMCAddress)
+	if err != nil {
+		return nil, fmt.Errorf("verifyFromTx, verifyMerkleProof error:%v", err)
+	}
+	if proofResult == nil {
+		return nil, fmt.Errorf("verifyFromTx, verifyMerkleProof failed")
+	}
+	if !eth2.CheckProofResult(proofResult, extra) {
+		return nil, fmt.Errorf("verifyFromTx, verify proof value hash failed, proof result:%x, extra:%x", proofResult, extra)
+	}
+
+	txParam, err := scom.DecodeTxParam(extra)
+	if err != nil {
+		return nil, fmt.Errorf("verifyFromTx, deserialize merkleValue error:%s", err)
+	}
+	return txParam, nil
+}
@@ -70,14 +70,15 @@ func (this *QuorumHandler) MakeDepositProposal(ns *native.NativeContract) (*comm
 	if err != nil {
 		return nil, fmt.Errorf("Quorum MakeDepositProposal, failed to get current validators height: %v", err)
 	}
-	if header.Number.Uint64() < valh {
-		return nil, fmt.Errorf("Quorum MakeDepositProposal, height of header %d is less than epoch height %d", header.Number.Uint64(), valh)
-	}
 	vs, err := quorum.GetValSet(ns, params.SourceChainID)
 	if err != nil {
 		return nil, fmt.Errorf("Quorum MakeDepositProposal, failed to get quorum validators: %v", err)
 	}
-	if _, err := quorum.VerifyQuorumHeader(vs, header, false); err != nil {
+	info, err := quorum.GetConsensusInfo(ns, params.SourceChainID)
+	if err != nil {
+		return nil, fmt.Errorf("Quorum MakeDepositProposal, %v", err)
+	}
+	if err := info.VerifyHeader(vs, valh, header); err != nil {
 		return nil, fmt.Errorf("Quorum MakeDepositProposal, failed to verify quorum header %s: %v", header.Hash().String(), err)
 	}
 
@@ -129,6 +129,15 @@ func SafeTransferFromContract(s *native.NativeContract, entrance, to common.Addr
 	return nativeTransfer(s, this, to, amount)
 }
 
+// RefundFromContract transfers the native token escrowed by the lock proxy back to `to`, the refund
+// must be requested by a tx sent to the lock proxy directly.
+func RefundFromContract(s *native.NativeContract, to common.Address, amount *big.Int) error {
+	if err := checkRefund(s, to, amount); err != nil {
+		return err
+	}
+	return nativeTransfer(s, this, to, amount)
+}
+
 func SubBalance(s *native.NativeContract, from common.Address, amount *big.Int) error {
 	isWrapperCaller, err := checkOutcome(s, from, amount)
 	if err != nil {
@@ -161,6 +170,29 @@ func AddBalance(s *native.NativeContract, entrance, to common.Address, amount *b
 	return nil
 }
 
+// RefundBalance re-mints the native token burned by the lock proxy to `to`, the refund must be
+// requested by a tx sent to the lock proxy directly.
+func RefundBalance(s *native.NativeContract, to common.Address, amount *big.Int) error {
+	if err := checkRefund(s, to, amount); err != nil {
+		return err
+	}
+	s.StateDB().AddBalance(to, amount)
+	return nil
+}
+
+func checkRefund(s *native.NativeContract, to common.Address, amount *big.Int) error {
+	if txTo := s.ContractRef().TxTo(); txTo != this {
+		return fmt.Errorf("the tx.to should be lock proxy contract address, tx.to %s", txTo.Hex())
+	}
+	if to == common.EmptyAddress {
+		return fmt.Errorf("invalid dest account")
+	}
+	if amount == nil || amount.Cmp(common.Big0) <= 0 {
+		return fmt.Errorf("invalid amount")
+	}
+	return nil
+}
+
 // checkOutcome return isWrapper caller and error
 func checkOutcome(s *native.NativeContract, from common.Address, amount *big.Int) (bool, error) {
 	ctx := s.ContractRef().CurrentContext()
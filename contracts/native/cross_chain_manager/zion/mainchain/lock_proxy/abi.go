@@ -78,6 +78,68 @@ func (i *MethodLockInput) Decode(payload []byte) error {
 	return utils.UnpackMethod(ABI, MethodLock, i, payload)
 }
 
+// function lockWithFee
+type MethodLockWithFeeInput struct {
+	ToChainId uint64
+	ToAddress common.Address
+	Amount    *big.Int
+	Fee       *big.Int
+}
+
+func (i *MethodLockWithFeeInput) Encode() ([]byte, error) {
+	return utils.PackMethod(ABI, MethodLockWithFee, i.ToChainId, i.ToAddress, i.Amount, i.Fee)
+}
+func (i *MethodLockWithFeeInput) Decode(payload []byte) error {
+	return utils.UnpackMethod(ABI, MethodLockWithFee, i, payload)
+}
+
+type MethodGetRelayerFeeInput struct {
+	CrossChainId []byte
+}
+
+func (i *MethodGetRelayerFeeInput) Encode() ([]byte, error) {
+	return utils.PackMethod(ABI, MethodGetRelayerFee, i.CrossChainId)
+}
+func (i *MethodGetRelayerFeeInput) Decode(payload []byte) error {
+	return utils.UnpackMethod(ABI, MethodGetRelayerFee, i, payload)
+}
+
+type MethodGetUnsettledRelayerFeesInput struct {
+	ToChainId uint64
+	Start     uint64
+	Limit     uint64
+}
+
+func (i *MethodGetUnsettledRelayerFeesInput) Encode() ([]byte, error) {
+	return utils.PackMethod(ABI, MethodGetUnsettledRelayerFees, i.ToChainId, i.Start, i.Limit)
+}
+func (i *MethodGetUnsettledRelayerFeesInput) Decode(payload []byte) error {
+	return utils.UnpackMethod(ABI, MethodGetUnsettledRelayerFees, i, payload)
+}
+
+type MethodRefundRelayerFeeInput struct {
+	CrossChainId []byte
+}
+
+func (i *MethodRefundRelayerFeeInput) Encode() ([]byte, error) {
+	return utils.PackMethod(ABI, MethodRefundRelayerFee, i.CrossChainId)
+}
+func (i *MethodRefundRelayerFeeInput) Decode(payload []byte) error {
+	return utils.UnpackMethod(ABI, MethodRefundRelayerFee, i, payload)
+}
+
+type MethodGetFailedReceiptInput struct {
+	FromChainId  uint64
+	CrossChainId []byte
+}
+
+func (i *MethodGetFailedReceiptInput) Encode() ([]byte, error) {
+	return utils.PackMethod(ABI, MethodGetFailedReceipt, i.FromChainId, i.CrossChainId)
+}
+func (i *MethodGetFailedReceiptInput) Decode(payload []byte) error {
+	return utils.UnpackMethod(ABI, MethodGetFailedReceipt, i, payload)
+}
+
 type MethodGetSideChainLockAmountInput struct {
 	ChainId uint64
 }
@@ -103,6 +165,21 @@ func emitUnlockEvent(s *native.NativeContract, toAssetHash, toAddress common.Add
 	return s.AddNotify(ABI, []string{EventUnlockEvent}, toAssetHash, toAddress, amount)
 }
 
+//event RelayerFeeEvent(address toAddress, uint256 fee, bool refunded);
+func emitRelayerFeeEvent(s *native.NativeContract, toAddress common.Address, fee *big.Int, refunded bool) error {
+	return s.AddNotify(ABI, []string{EventRelayerFeeEvent}, toAddress, fee, refunded)
+}
+
+//event RefundEvent(address toAddress, uint256 amount);
+func emitRefundEvent(s *native.NativeContract, toAddress common.Address, amount *big.Int) error {
+	return s.AddNotify(ABI, []string{EventRefundEvent}, toAddress, amount)
+}
+
+//event TransferFailedEvent(uint64 fromChainId, bytes crossChainId, string reason);
+func emitTransferFailedEvent(s *native.NativeContract, fromChainID uint64, crossChainID []byte, reason string) error {
+	return s.AddNotify(ABI, []string{EventTransferFailedEvent}, fromChainID, crossChainID, reason)
+}
+
 //event CrossChainEvent(address indexed sender, bytes txId, address proxyOrAssetContract, uint64 toChainId, bytes toContract, bytes rawdata);
 func emitCrossChainEvent(s *native.NativeContract,
 	sender common.Address,
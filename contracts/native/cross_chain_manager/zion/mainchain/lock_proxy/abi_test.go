@@ -65,3 +65,15 @@ func TestABIMethodGetSideChainLockAmountInput(t *testing.T) {
 
 	assert.Equal(t, expect, got)
 }
+
+func TestABIMethodGetUnsettledRelayerFeesInput(t *testing.T) {
+	expect := &MethodGetUnsettledRelayerFeesInput{ToChainId: 12, Start: 3, Limit: 20}
+
+	payload, err := expect.Encode()
+	assert.NoError(t, err)
+
+	got := new(MethodGetUnsettledRelayerFeesInput)
+	assert.NoError(t, got.Decode(payload))
+
+	assert.Equal(t, expect, got)
+}
@@ -20,6 +20,7 @@ package lock_proxy
 
 import (
 	"fmt"
+	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/contracts/native"
@@ -29,22 +30,46 @@ import (
 	. "github.com/ethereum/go-ethereum/contracts/native/go_abi/main_chain_lock_proxy_abi"
 	"github.com/ethereum/go-ethereum/contracts/native/governance/side_chain_manager"
 	"github.com/ethereum/go-ethereum/contracts/native/utils"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
 )
 
 var (
 	gasTable = map[string]uint64{
-		MethodName:                   0,
-		MethodLock:                   10000,
-		MethodGetSideChainLockAmount: 0,
-		MethodApprove:                10000,
-		MethodAllowance:              0,
+		MethodName:                    0,
+		MethodLock:                    10000,
+		MethodLockWithFee:             10000,
+		MethodGetRelayerFee:           0,
+		MethodRefundRelayerFee:        10000,
+		MethodGetFailedReceipt:        0,
+		MethodGetSideChainLockAmount:  0,
+		MethodGetUnsettledRelayerFees: 0,
+		MethodApprove:                 10000,
+		MethodAllowance:               0,
 	}
 )
 
+// maxUnsettledRelayerFees is the maximum number of unsettled relayer fees listed in one query.
+const maxUnsettledRelayerFees = uint64(100)
+
+// lock proxy implementation versions, activated by native contract upgrades of the lock proxy.
+const (
+	// VersionRefund refunds transfers which can not be delivered through failure receipts
+	VersionRefund = uint64(1)
+	// VersionRoute routes the side chain burns for other side chains into mints on the target chains
+	VersionRoute = uint64(2)
+	// VersionRelayerFee escrows the relayer fees of the transfers until the target chains settle them
+	VersionRelayerFee = uint64(3)
+)
+
 func InitLockProxy() {
 	InitABI()
 	delegate.InitABI(ABI)
-	native.Contracts[this] = RegisterLockProxyContract
+	registry := native.RoleRegistry(params.ZionRoleMain)
+	registry.Register(this, RegisterLockProxyContract)
+	registry.RegisterVersion(this, VersionRefund, RegisterLockProxyContractV1)
+	registry.RegisterVersion(this, VersionRoute, RegisterLockProxyContractV2)
+	registry.RegisterVersion(this, VersionRelayerFee, RegisterLockProxyContractV3)
 }
 
 func RegisterLockProxyContract(s *native.NativeContract) {
@@ -57,91 +82,222 @@ func RegisterLockProxyContract(s *native.NativeContract) {
 	s.Register(MethodAllowance, delegate.Allowance)
 }
 
+// RegisterLockProxyContractV1 registers the lock proxy which refunds undeliverable transfers, the
+// failure receipts are exposed in addition to the methods of the initial implementation.
+func RegisterLockProxyContractV1(s *native.NativeContract) {
+	RegisterLockProxyContract(s)
+	s.Register(MethodGetFailedReceipt, GetFailedReceipt)
+}
+
+// RegisterLockProxyContractV2 registers the lock proxy which routes transfers between side chains,
+// the methods are the same as the ones of `VersionRefund`. The main chain lock proxy should be
+// upgraded before the side chains burn for other side chains.
+func RegisterLockProxyContractV2(s *native.NativeContract) {
+	RegisterLockProxyContractV1(s)
+}
+
+// RegisterLockProxyContractV3 registers the lock proxy which escrows relayer fees, the fees are locked
+// with `lockWithFee` and settled by the target chains or refunded to the payers after the deadline.
+func RegisterLockProxyContractV3(s *native.NativeContract) {
+	RegisterLockProxyContractV2(s)
+	s.Register(MethodLockWithFee, LockWithFee)
+	s.Register(MethodGetRelayerFee, GetRelayerFee)
+	s.Register(MethodRefundRelayerFee, RefundRelayerFee)
+	s.Register(MethodGetUnsettledRelayerFees, GetUnsettledRelayerFees)
+}
+
+// versionEnabled returns whether the lock proxy implementation `version` is active at current block.
+func versionEnabled(s *native.NativeContract, version uint64) (bool, error) {
+	active, err := s.ActiveVersion(this)
+	if err != nil {
+		return false, fmt.Errorf("failed to get lock proxy version, err: %v", err)
+	}
+	return active >= version, nil
+}
+
+// checkRelayerFee returns an error if the transfer carries a relayer fee before `VersionRelayerFee`,
+// the side chain lock proxy should not escrow relayer fees before the main chain is upgraded.
+func checkRelayerFee(s *native.NativeContract, args *scom.TxArgs) error {
+	if !args.HasFee() {
+		return nil
+	}
+	if ok, err := versionEnabled(s, VersionRelayerFee); err != nil {
+		return err
+	} else if !ok {
+		return fmt.Errorf("relayer fee is not supported before lock proxy version %d", VersionRelayerFee)
+	}
+	if len(args.TransferID) == 0 {
+		return fmt.Errorf("transfer id of relayer fee is empty")
+	}
+	return nil
+}
+
 func Name(s *native.NativeContract) ([]byte, error) {
 	return new(MethodContractNameOutput).Encode()
 }
 
 func Lock(s *native.NativeContract) ([]byte, error) {
 	ctx := s.ContractRef().CurrentContext()
-	sourceChainID := native.ZionMainChainID
-	owner := s.ContractRef().TxOrigin()
-	msgSender := s.ContractRef().MsgSender()
 
 	input := new(MethodLockInput)
 	if err := input.Decode(ctx.Payload); err != nil {
 		return utils.ByteFailed, fmt.Errorf("LockProxy.Lock, failed to decode params, err: %v", err)
 	}
-	if input.ToChainId == 0 || input.ToChainId == sourceChainID {
-		return utils.ByteFailed, fmt.Errorf("LockProxy.Lock, target chain id invalid")
+	if err := lock(s, input.ToChainId, input.ToAddress, input.Amount, nil); err != nil {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.Lock, %v", err)
+	}
+	return utils.PackOutputs(ABI, MethodLock, true)
+}
+
+// LockWithFee locks `amount` plus a relayer `fee`. The fee stays escrowed on the main chain and is paid
+// to the relayer once the target chain settles the transfer delivered before the fee deadline, the
+// payer refunds the fee with `RefundRelayerFee` if the transfer is not settled in time.
+func LockWithFee(s *native.NativeContract) ([]byte, error) {
+	ctx := s.ContractRef().CurrentContext()
+
+	input := new(MethodLockWithFeeInput)
+	if err := input.Decode(ctx.Payload); err != nil {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.LockWithFee, failed to decode params, err: %v", err)
+	}
+	if input.Fee == nil || input.Fee.Sign() <= 0 {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.LockWithFee, fee invalid")
+	}
+	if err := lock(s, input.ToChainId, input.ToAddress, input.Amount, input.Fee); err != nil {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.LockWithFee, %v", err)
 	}
-	if input.ToAddress == common.EmptyAddress {
-		return utils.ByteFailed, fmt.Errorf("LockProxy.Lock, target address invalid")
+	return utils.PackOutputs(ABI, MethodLockWithFee, true)
+}
+
+func lock(s *native.NativeContract, toChainID uint64, toAddress common.Address, amount, fee *big.Int) error {
+	sourceChainID := s.ContractRef().RelayChainID()
+	owner := s.ContractRef().TxOrigin()
+	msgSender := s.ContractRef().MsgSender()
+
+	if toChainID == 0 || toChainID == sourceChainID {
+		return fmt.Errorf("target chain id invalid")
+	}
+	if toAddress == common.EmptyAddress {
+		return fmt.Errorf("target address invalid")
 	}
-	if input.Amount == nil || input.Amount.Cmp(common.Big0) == 0 {
-		return utils.ByteFailed, fmt.Errorf("LockProxy.Lock, amount invalid")
+	if amount == nil || amount.Cmp(common.Big0) == 0 {
+		return fmt.Errorf("amount invalid")
 	}
 
-	// input fields alias, caller is proxy itself and `toContract` is `sideChain` proxy, which has the same address
+	// input fields alias
 	fromAsset := common.EmptyAddress
 	toAsset := common.EmptyAddress.Bytes()
-	toAddr := input.ToAddress.Bytes()
-	amount := input.Amount
-	toChainID := input.ToChainId
-	caller := this
-	toContract := this
+	toAddr := toAddress.Bytes()
 	toMethod := "mint"
 
 	// check side chain registered
 	if sideChain, err := side_chain_manager.GetSideChain(s, toChainID); err != nil {
-		return utils.ByteFailed, fmt.Errorf("LockProxy.Lock, failed to get side chain %d, err: %v", toChainID, err)
+		return fmt.Errorf("failed to get side chain %d, err: %v", toChainID, err)
 	} else if sideChain == nil {
-		return utils.ByteFailed, fmt.Errorf("LockProxy.Lock, side chain %d is nil", toChainID)
+		return fmt.Errorf("side chain %d is nil", toChainID)
 	} else if sideChain.Router != utils.ZION_ROUTER {
-		return utils.ByteFailed, fmt.Errorf("LockProxy.Lock, side chain %d router is not zion", toChainID)
+		return fmt.Errorf("side chain %d router is not zion", toChainID)
 	}
 
-	// lock token into lock proxy
-	if err := delegate.SafeTransfer2Contract(s, owner, amount); err != nil {
-		return utils.ByteFailed, fmt.Errorf("LockProxy.Lock, failed to transfer token to lock proxy, err: %v", err)
+	// lock token and relayer fee into lock proxy
+	total := amount
+	if fee != nil {
+		total = new(big.Int).Add(amount, fee)
+	}
+	if err := delegate.SafeTransfer2Contract(s, owner, total); err != nil {
+		return fmt.Errorf("failed to transfer token to lock proxy, err: %v", err)
 	}
 
-	// set total amount
+	// set total amount, the relayer fee is never minted on the side chain
 	addTotalAmount(s, toChainID, amount)
 
-	// serialize tx args
-	txData, err := zutils.EncodeTxArgs(toAsset, toAddr, amount)
+	paramTxHash, crossChainID, err := nextCrossChainID(s)
+	if err != nil {
+		return err
+	}
+	refund, err := versionEnabled(s, VersionRefund)
+	if err != nil {
+		return err
+	}
+
+	// serialize tx args, since `VersionRefund` the cross chain id travels as transfer id so that
+	// the side chain is able to send the transfer back for refund if it fails to mint it, and the
+	// side chain settles the relayer fee of the transfer with it.
+	args := &scom.TxArgs{
+		ToAssetHash: toAsset,
+		ToAddress:   toAddr,
+		Amount:      amount,
+	}
+	if refund || fee != nil {
+		args.TransferID = crossChainID
+	}
+	if fee != nil {
+		args.Fee = fee
+		args.FeeDeadline = s.ContractRef().BlockTime() + zutils.RelayerFeeTimeout
+	}
+	txData, err := rlp.EncodeToBytes(args)
 	if err != nil {
-		return utils.ByteFailed, fmt.Errorf("LockProxy.Lock, failed to encode txArgs, err: %v", err)
+		return fmt.Errorf("failed to encode txArgs, err: %v", err)
 	}
 
-	// get and set tx index
+	// record the lock owner and the escrowed relayer fee
+	if refund {
+		if err := storeLockRecord(s, crossChainID, &LockRecord{Owner: owner, ToChainID: toChainID, Amount: amount}); err != nil {
+			return fmt.Errorf("failed to store lock record, err: %v", err)
+		}
+	}
+	if fee != nil {
+		record := &RelayerFee{Payer: owner, ToChainID: toChainID, Fee: fee, Deadline: args.FeeDeadline}
+		if err := storeRelayerFee(s, crossChainID, record); err != nil {
+			return fmt.Errorf("failed to store relayer fee, err: %v", err)
+		}
+		if err := addUnsettledRelayerFee(s, toChainID, crossChainID); err != nil {
+			return fmt.Errorf("failed to index relayer fee, err: %v", err)
+		}
+	}
+
+	if err := makeTransaction(s, owner, paramTxHash, crossChainID, toChainID, toMethod, txData); err != nil {
+		return err
+	}
+	if err := emitLockEvent(s, fromAsset, msgSender, toChainID, toAsset, toAddr, amount); err != nil {
+		return fmt.Errorf("failed to emit `LockEvent` log, err: %v", err)
+	}
+	return nil
+}
+
+// nextCrossChainID generates the tx hash and cross chain id of the next lock proxy transaction.
+func nextCrossChainID(s *native.NativeContract) ([]byte, []byte, error) {
 	txIndex, err := getNextTxIndex(s)
 	if err != nil {
-		return utils.ByteFailed, fmt.Errorf("LockProxy.Lock, failed to get next tx index, err: %v", err)
+		return nil, nil, fmt.Errorf("failed to get next tx index, err: %v", err)
 	}
 	paramTxHash := scom.Uint256ToBytes(txIndex)
-	crossChainID := zutils.GenerateCrossChainID(this, paramTxHash)
+	return paramTxHash, zutils.GenerateCrossChainID(this, paramTxHash), nil
+}
+
+// makeTransaction stores the cross chain transaction to the side chain proxy, caller is proxy itself
+// and `toContract` is `sideChain` proxy, which has the same address.
+func makeTransaction(s *native.NativeContract, sender common.Address, paramTxHash, crossChainID []byte,
+	toChainID uint64, toMethod string, txData []byte) error {
+
+	sourceChainID := s.ContractRef().RelayChainID()
 
 	// check and store `doneTx`
 	if err := scom.CheckDoneTx(s, crossChainID, sourceChainID); err != nil {
-		return nil, fmt.Errorf("LockProxy.Lock, failed to check cross transaction, err: %v", err)
+		return fmt.Errorf("failed to check cross transaction, err: %v", err)
 	}
 	if err := scom.PutDoneTx(s, crossChainID, sourceChainID); err != nil {
-		return nil, fmt.Errorf("LockProxy.Lock, faield to store cross transaction, err: %v", err)
+		return fmt.Errorf("faield to store cross transaction, err: %v", err)
 	}
 
 	// assemble tx, generate and store cross chain transaction proof
-	txParams, rawTx, err := zutils.EncodeMakeTxParams(paramTxHash, crossChainID, caller[:], toChainID, toContract[:], toMethod, txData)
+	txParams, rawTx, err := zutils.EncodeMakeTxParams(paramTxHash, crossChainID, this[:], toChainID, this[:], toMethod, txData)
 	if err != nil {
-		return utils.ByteFailed, fmt.Errorf("LockProxy.Lock, failed to encode `makeTxParams`, err: %v", err)
+		return fmt.Errorf("failed to encode `makeTxParams`, err: %v", err)
 	}
 
 	// emit event log
-	if err := emitCrossChainEvent(s, owner, paramTxHash, caller, toChainID, toAsset, rawTx); err != nil {
-		return utils.ByteFailed, fmt.Errorf("LockProxy.Lock, failed to emit `CrossChainEvent` log, err: %v", err)
-	}
-	if err := emitLockEvent(s, fromAsset, msgSender, toChainID, toAsset, toAddr, amount); err != nil {
-		return utils.ByteFailed, fmt.Errorf("LockProxy.Lock, failed to emit `LockEvent` log, err: %v", err)
+	if err := emitCrossChainEvent(s, sender, paramTxHash, this, toChainID, common.EmptyAddress.Bytes(), rawTx); err != nil {
+		return fmt.Errorf("failed to emit `CrossChainEvent` log, err: %v", err)
 	}
 
 	// zion main chain DONT need a `relayer` to commit proof but directly stores the lock request.
@@ -152,22 +308,22 @@ func Lock(s *native.NativeContract) ([]byte, error) {
 		Payload:         nil,
 	})
 	if err := scom.MakeTransaction(s, txParams, sourceChainID); err != nil {
-		return utils.ByteFailed, fmt.Errorf("LockProxy.Lock, failed to makeTransaction, err: %v", err)
+		return fmt.Errorf("failed to makeTransaction, err: %v", err)
 	}
-	return utils.PackOutputs(ABI, MethodLock, true)
+	return nil
 }
 
-func Unlock(s *native.NativeContract, sourceChainID uint64, txParams *scom.MakeTxParam) error {
+func Unlock(s *native.NativeContract, sourceChainID uint64, txParams *scom.MakeTxParam, relayer *Relayer) error {
 	s.ContractRef().PushContext(&native.Context{
 		Caller:          utils.CrossChainManagerContractAddress,
 		ContractAddress: this,
 		Payload:         nil,
 	})
 
-	if sourceChainID == native.ZionMainChainID || sourceChainID == 0 {
+	if sourceChainID == s.ContractRef().RelayChainID() || sourceChainID == 0 {
 		return fmt.Errorf("LockProxy.Unlock, source chain id invalid")
 	}
-	if txParams.ToChainID != native.ZionMainChainID {
+	if txParams.ToChainID != s.ContractRef().RelayChainID() {
 		return fmt.Errorf("LockProxy.Unlock, target chain id invalid")
 	}
 
@@ -213,6 +369,11 @@ func Unlock(s *native.NativeContract, sourceChainID uint64, txParams *scom.MakeT
 		return fmt.Errorf("LockProxy.Unlock, target address is invalid")
 	}
 
+	// the relayer fee stays escrowed on the side chain, which settles it with the transfer id
+	if err := checkRelayerFee(s, args); err != nil {
+		return fmt.Errorf("LockProxy.Unlock, %v", err)
+	}
+
 	// reconciliation, check total amount
 	curLockedAmount := getTotalAmount(s, sourceChainID)
 	if curLockedAmount.Cmp(args.Amount) < 0 {
@@ -242,6 +403,447 @@ func Unlock(s *native.NativeContract, sourceChainID uint64, txParams *scom.MakeT
 		return fmt.Errorf("LockProxy.Unlock, failed to emit `VerifyHeaderAndExecuteTxEvent`, err: %v", err)
 	}
 
+	// the relayer who committed the proof in time earns the relayer fee on the side chain
+	if err := settleSourceFee(s, sourceChainID, args, relayer); err != nil {
+		return fmt.Errorf("LockProxy.Unlock, %v", err)
+	}
+
+	return nil
+}
+
+// Transfer routes a burn on side chain `sourceChainID` directly into a mint on the target side chain.
+// The locked amount moves between the two side chains and the mint is sent by the main chain lock
+// proxy itself, so the target side chain refunds it like a locked transfer if it fails to mint. The
+// relayer fee is settled with the source side chain rather than forwarded.
+func Transfer(s *native.NativeContract, sourceChainID uint64, txParams *scom.MakeTxParam, relayer *Relayer) error {
+	s.ContractRef().PushContext(&native.Context{
+		Caller:          utils.CrossChainManagerContractAddress,
+		ContractAddress: this,
+		Payload:         nil,
+	})
+
+	if ok, err := versionEnabled(s, VersionRoute); err != nil {
+		return fmt.Errorf("LockProxy.Transfer, %v", err)
+	} else if !ok {
+		return fmt.Errorf("LockProxy.Transfer, routing is not supported before lock proxy version %d", VersionRoute)
+	}
+	toChainID := txParams.ToChainID
+	if sourceChainID == s.ContractRef().RelayChainID() || sourceChainID == 0 {
+		return fmt.Errorf("LockProxy.Transfer, source chain id invalid")
+	}
+	if toChainID == s.ContractRef().RelayChainID() || toChainID == 0 || toChainID == sourceChainID {
+		return fmt.Errorf("LockProxy.Transfer, target chain id invalid")
+	}
+
+	// check side chains registered
+	for _, chainID := range []uint64{sourceChainID, toChainID} {
+		if sideChain, err := side_chain_manager.GetSideChain(s, chainID); err != nil {
+			return fmt.Errorf("LockProxy.Transfer, failed to get side chain %d, err: %v", chainID, err)
+		} else if sideChain == nil {
+			return fmt.Errorf("LockProxy.Transfer, side chain %d is nil", chainID)
+		} else if sideChain.Router != utils.ZION_ROUTER {
+			return fmt.Errorf("LockProxy.Transfer, side chain %d router is not zion", chainID)
+		}
+	}
+
+	// check contracts
+	if txParams.ToContractAddress == nil || common.BytesToAddress(txParams.ToContractAddress) != this {
+		return fmt.Errorf("LockProxy.Transfer, target contract is invalid")
+	}
+	if txParams.FromContractAddress == nil || common.BytesToAddress(txParams.FromContractAddress) != this {
+		return fmt.Errorf("LockProxy.Transfer, source contract is invalid")
+	}
+	if txParams.Method != "mint" {
+		return fmt.Errorf("LockProxy.Transfer, method is invalid")
+	}
+
+	args, err := zutils.DecodeTxArgs(txParams.Args)
+	if err != nil {
+		return fmt.Errorf("LockProxy.Transfer, failed to decode txArgs, err: %v", err)
+	}
+	if args.ToAddress == nil || args.ToAssetHash == nil || args.Amount == nil || args.Amount.Sign() <= 0 {
+		return fmt.Errorf("LockProxy.Transfer, invalid arg fields")
+	}
+	if toAsset := common.BytesToAddress(args.ToAssetHash); toAsset != common.EmptyAddress {
+		return fmt.Errorf("LockProxy.Transfer, to asset invalid, %s", toAsset.Hex())
+	}
+	owner := common.BytesToAddress(args.ToAddress)
+	if owner == common.EmptyAddress {
+		return fmt.Errorf("LockProxy.Transfer, target address is invalid")
+	}
+
+	// the relayer fee stays escrowed on the source side chain, which settles it with the transfer id
+	if err := checkRelayerFee(s, args); err != nil {
+		return fmt.Errorf("LockProxy.Transfer, %v", err)
+	}
+
+	// reconciliation, only the amount moves to the target side chain
+	if err := subTotalAmount(s, sourceChainID, args.Amount); err != nil {
+		return fmt.Errorf("LockProxy.Transfer, failed to sub total amount, err: %v", err)
+	}
+	addTotalAmount(s, toChainID, args.Amount)
+
+	// burn sends the transfer to the burner itself, who owns the transfer on both side chains
+	paramTxHash, crossChainID, err := nextCrossChainID(s)
+	if err != nil {
+		return fmt.Errorf("LockProxy.Transfer, %v", err)
+	}
+	txData, err := rlp.EncodeToBytes(&scom.TxArgs{
+		ToAssetHash: args.ToAssetHash,
+		ToAddress:   args.ToAddress,
+		Amount:      args.Amount,
+		TransferID:  crossChainID,
+	})
+	if err != nil {
+		return fmt.Errorf("LockProxy.Transfer, failed to encode txArgs, err: %v", err)
+	}
+	record := &LockRecord{Owner: owner, ToChainID: toChainID, Amount: args.Amount, FromChainID: sourceChainID, SourceTransferID: args.TransferID}
+	if err := storeLockRecord(s, crossChainID, record); err != nil {
+		return fmt.Errorf("LockProxy.Transfer, failed to store lock record, err: %v", err)
+	}
+	if err := makeTransaction(s, owner, paramTxHash, crossChainID, toChainID, "mint", txData); err != nil {
+		return fmt.Errorf("LockProxy.Transfer, %v", err)
+	}
+
+	crossChainTxHash := s.ContractRef().TxHash()
+	if err := emitVerifyHeaderAndExecuteTxEvent(s,
+		sourceChainID,
+		this[:],
+		crossChainTxHash[:],
+		txParams.TxHash,
+	); err != nil {
+		return fmt.Errorf("LockProxy.Transfer, failed to emit `VerifyHeaderAndExecuteTxEvent`, err: %v", err)
+	}
+
+	// the relayer who committed the proof in time earns the relayer fee on the source side chain
+	if err := settleSourceFee(s, sourceChainID, args, relayer); err != nil {
+		return fmt.Errorf("LockProxy.Transfer, %v", err)
+	}
+	return nil
+}
+
+// Refund settles the failure acknowledgement of a side chain which failed to mint a locked transfer,
+// the locked amount is returned to the owner of the lock together with the unsettled relayer fee, or
+// sent back to the side chain a routed transfer was burned on.
+func Refund(s *native.NativeContract, sourceChainID uint64, txParams *scom.MakeTxParam) error {
+	s.ContractRef().PushContext(&native.Context{
+		Caller:          utils.CrossChainManagerContractAddress,
+		ContractAddress: this,
+		Payload:         nil,
+	})
+
+	if ok, err := versionEnabled(s, VersionRefund); err != nil {
+		return fmt.Errorf("LockProxy.Refund, %v", err)
+	} else if !ok {
+		return fmt.Errorf("LockProxy.Refund, refund is not supported before lock proxy version %d", VersionRefund)
+	}
+	if sourceChainID == s.ContractRef().RelayChainID() || sourceChainID == 0 {
+		return fmt.Errorf("LockProxy.Refund, source chain id invalid")
+	}
+	if txParams.ToChainID != s.ContractRef().RelayChainID() {
+		return fmt.Errorf("LockProxy.Refund, target chain id invalid")
+	}
+
+	// check side chain registered
+	if sideChain, err := side_chain_manager.GetSideChain(s, sourceChainID); err != nil {
+		return fmt.Errorf("LockProxy.Refund, failed to get side chain %d, err: %v", sourceChainID, err)
+	} else if sideChain == nil {
+		return fmt.Errorf("LockProxy.Refund, side chain %d is nil", sourceChainID)
+	} else if sideChain.Router != utils.ZION_ROUTER {
+		return fmt.Errorf("LockProxy.Refund, side chain %d router is not zion", sourceChainID)
+	}
+
+	// check contracts
+	if txParams.ToContractAddress == nil || common.BytesToAddress(txParams.ToContractAddress) != this {
+		return fmt.Errorf("LockProxy.Refund, target contract is invalid")
+	}
+	if txParams.FromContractAddress == nil || common.BytesToAddress(txParams.FromContractAddress) != this {
+		return fmt.Errorf("LockProxy.Refund, source contract is invalid")
+	}
+	if txParams.Method != "refund" {
+		return fmt.Errorf("LockProxy.Refund, method is invalid")
+	}
+
+	args, err := zutils.DecodeTxArgs(txParams.Args)
+	if err != nil {
+		return fmt.Errorf("LockProxy.Refund, failed to decode txArgs, err: %v", err)
+	}
+	if len(args.TransferID) == 0 {
+		return fmt.Errorf("LockProxy.Refund, transfer id is empty")
+	}
+
+	// the lock record is deleted after refund, which prevents refunding the same transfer twice
+	record, err := getLockRecord(s, args.TransferID)
+	if err != nil {
+		return fmt.Errorf("LockProxy.Refund, failed to get lock record, err: %v", err)
+	}
+	if record == nil {
+		return fmt.Errorf("LockProxy.Refund, transfer %x is not refundable", args.TransferID)
+	}
+	if record.ToChainID != sourceChainID {
+		return fmt.Errorf("LockProxy.Refund, transfer %x was locked to chain %d", args.TransferID, record.ToChainID)
+	}
+	deleteLockRecord(s, args.TransferID)
+
+	// the transfer was never delivered, refund the relayer fee as well
+	if fee, err := getRelayerFee(s, args.TransferID); err != nil {
+		return fmt.Errorf("LockProxy.Refund, failed to get relayer fee, err: %v", err)
+	} else if fee != nil && !fee.Settled {
+		entrance := utils.CrossChainManagerContractAddress
+		if err := delegate.SafeTransferFromContract(s, entrance, fee.Payer, fee.Fee); err != nil {
+			return fmt.Errorf("LockProxy.Refund, failed to transfer relayer fee, err: %v", err)
+		}
+		if err := settleRelayerFee(s, args.TransferID, fee, fee.Payer, true); err != nil {
+			return fmt.Errorf("LockProxy.Refund, %v", err)
+		}
+	}
+
+	// reverse the side chain locked amount
+	if err := subTotalAmount(s, sourceChainID, record.Amount); err != nil {
+		return fmt.Errorf("LockProxy.Refund, failed to sub total amount, err: %v", err)
+	}
+	if record.FromChainID != 0 {
+		// routed transfer, send it back to the side chain it was burned on with the transfer id of the
+		// burn, so that the source side chain refunds the relayer fee escrowed for the transfer
+		addTotalAmount(s, record.FromChainID, record.Amount)
+		refundArgs, err := rlp.EncodeToBytes(&scom.TxArgs{
+			ToAssetHash: common.EmptyAddress.Bytes(),
+			ToAddress:   record.Owner.Bytes(),
+			Amount:      record.Amount,
+			TransferID:  record.SourceTransferID,
+		})
+		if err != nil {
+			return fmt.Errorf("LockProxy.Refund, failed to encode txArgs, err: %v", err)
+		}
+		paramTxHash, crossChainID, err := nextCrossChainID(s)
+		if err != nil {
+			return fmt.Errorf("LockProxy.Refund, %v", err)
+		}
+		if err := makeTransaction(s, record.Owner, paramTxHash, crossChainID, record.FromChainID, "refund", refundArgs); err != nil {
+			return fmt.Errorf("LockProxy.Refund, %v", err)
+		}
+	} else {
+		entrance := utils.CrossChainManagerContractAddress
+		if err := delegate.SafeTransferFromContract(s, entrance, record.Owner, record.Amount); err != nil {
+			return fmt.Errorf("LockProxy.Refund, failed to transfer native token, err: %v", err)
+		}
+	}
+
+	// emit event logs
+	if err := emitRefundEvent(s, record.Owner, record.Amount); err != nil {
+		return fmt.Errorf("LockProxy.Refund, failed to emit `RefundEvent`, err: %v", err)
+	}
+	crossChainTxHash := s.ContractRef().TxHash()
+	if err := emitVerifyHeaderAndExecuteTxEvent(s,
+		sourceChainID,
+		this[:],
+		crossChainTxHash[:],
+		txParams.TxHash,
+	); err != nil {
+		return fmt.Errorf("LockProxy.Refund, failed to emit `VerifyHeaderAndExecuteTxEvent`, err: %v", err)
+	}
+
+	return nil
+}
+
+// Settle pays the relayer fee escrowed by a `lockWithFee` transfer to the relayer, the side chain
+// settles the transfer once it is minted before the fee deadline. The fee is paid only once, the
+// settlement of a fee which is already refunded to the payer is ignored.
+func Settle(s *native.NativeContract, sourceChainID uint64, txParams *scom.MakeTxParam) error {
+	s.ContractRef().PushContext(&native.Context{
+		Caller:          utils.CrossChainManagerContractAddress,
+		ContractAddress: this,
+		Payload:         nil,
+	})
+
+	if ok, err := versionEnabled(s, VersionRelayerFee); err != nil {
+		return fmt.Errorf("LockProxy.Settle, %v", err)
+	} else if !ok {
+		return fmt.Errorf("LockProxy.Settle, relayer fee is not supported before lock proxy version %d", VersionRelayerFee)
+	}
+	if sourceChainID == s.ContractRef().RelayChainID() || sourceChainID == 0 {
+		return fmt.Errorf("LockProxy.Settle, source chain id invalid")
+	}
+	if txParams.ToChainID != s.ContractRef().RelayChainID() {
+		return fmt.Errorf("LockProxy.Settle, target chain id invalid")
+	}
+
+	// check contracts
+	if txParams.ToContractAddress == nil || common.BytesToAddress(txParams.ToContractAddress) != this {
+		return fmt.Errorf("LockProxy.Settle, target contract is invalid")
+	}
+	if txParams.FromContractAddress == nil || common.BytesToAddress(txParams.FromContractAddress) != this {
+		return fmt.Errorf("LockProxy.Settle, source contract is invalid")
+	}
+	if txParams.Method != "settle" {
+		return fmt.Errorf("LockProxy.Settle, method is invalid")
+	}
+
+	args, err := zutils.DecodeTxArgs(txParams.Args)
+	if err != nil {
+		return fmt.Errorf("LockProxy.Settle, failed to decode txArgs, err: %v", err)
+	}
+	if len(args.TransferID) == 0 || args.ToAddress == nil {
+		return fmt.Errorf("LockProxy.Settle, invalid arg fields")
+	}
+	relayer := common.BytesToAddress(args.ToAddress)
+	if relayer == common.EmptyAddress {
+		return fmt.Errorf("LockProxy.Settle, relayer address is invalid")
+	}
+
+	fee, err := getRelayerFee(s, args.TransferID)
+	if err != nil {
+		return fmt.Errorf("LockProxy.Settle, failed to get relayer fee, err: %v", err)
+	}
+	if fee == nil {
+		return fmt.Errorf("LockProxy.Settle, relayer fee of %x not exist", args.TransferID)
+	}
+	if fee.ToChainID != sourceChainID {
+		return fmt.Errorf("LockProxy.Settle, transfer %x was locked to chain %d", args.TransferID, fee.ToChainID)
+	}
+	if !fee.Settled {
+		entrance := utils.CrossChainManagerContractAddress
+		if err := delegate.SafeTransferFromContract(s, entrance, relayer, fee.Fee); err != nil {
+			return fmt.Errorf("LockProxy.Settle, failed to transfer relayer fee, err: %v", err)
+		}
+		if err := settleRelayerFee(s, args.TransferID, fee, relayer, false); err != nil {
+			return fmt.Errorf("LockProxy.Settle, %v", err)
+		}
+	}
+
+	crossChainTxHash := s.ContractRef().TxHash()
+	if err := emitVerifyHeaderAndExecuteTxEvent(s,
+		sourceChainID,
+		this[:],
+		crossChainTxHash[:],
+		txParams.TxHash,
+	); err != nil {
+		return fmt.Errorf("LockProxy.Settle, failed to emit `VerifyHeaderAndExecuteTxEvent`, err: %v", err)
+	}
+	return nil
+}
+
+// RefundRelayerFee refunds the relayer fee of a `lockWithFee` transfer to the payer, if the target
+// chain did not settle it before the fee deadline plus the refund delay.
+func RefundRelayerFee(s *native.NativeContract) ([]byte, error) {
+	ctx := s.ContractRef().CurrentContext()
+
+	input := new(MethodRefundRelayerFeeInput)
+	if err := input.Decode(ctx.Payload); err != nil {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.RefundRelayerFee, failed to decode params, err: %v", err)
+	}
+
+	fee, err := getRelayerFee(s, input.CrossChainId)
+	if err != nil {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.RefundRelayerFee, failed to get relayer fee, err: %v", err)
+	}
+	if fee == nil {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.RefundRelayerFee, relayer fee of %x not exist", input.CrossChainId)
+	}
+	if fee.Payer != s.ContractRef().TxOrigin() {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.RefundRelayerFee, only payer %s is able to refund", fee.Payer.Hex())
+	}
+	if fee.Settled {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.RefundRelayerFee, relayer fee of %x already settled", input.CrossChainId)
+	}
+	if !zutils.RelayerFeeRefundable(fee.Deadline, s.ContractRef().BlockTime()) {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.RefundRelayerFee, relayer fee is not refundable before %d", fee.Deadline+zutils.RelayerFeeRefundDelay)
+	}
+	if err := delegate.RefundFromContract(s, fee.Payer, fee.Fee); err != nil {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.RefundRelayerFee, failed to transfer relayer fee, err: %v", err)
+	}
+	if err := settleRelayerFee(s, input.CrossChainId, fee, fee.Payer, true); err != nil {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.RefundRelayerFee, %v", err)
+	}
+	return utils.PackOutputs(ABI, MethodRefundRelayerFee, true)
+}
+
+// settleRelayerFee marks the relayer fee of transfer `crossChainID` settled after it was paid to `payee`.
+func settleRelayerFee(s *native.NativeContract, crossChainID []byte, fee *RelayerFee, payee common.Address, refunded bool) error {
+	fee.Settled = true
+	if err := storeRelayerFee(s, crossChainID, fee); err != nil {
+		return fmt.Errorf("failed to store relayer fee, err: %v", err)
+	}
+	if err := removeUnsettledRelayerFee(s, fee.ToChainID, crossChainID); err != nil {
+		return fmt.Errorf("failed to remove relayer fee index, err: %v", err)
+	}
+	if err := emitRelayerFeeEvent(s, payee, fee.Fee, refunded); err != nil {
+		return fmt.Errorf("failed to emit `RelayerFeeEvent`, err: %v", err)
+	}
+	return nil
+}
+
+// Relayer is the relayer who committed the proof of a side chain transfer at block time `Time`. A
+// transfer queued by the circuit breaker keeps the relayer committed it, not the one releasing it.
+type Relayer struct {
+	Address common.Address
+	Time    uint64
+}
+
+// settleSourceFee sends the settlement of the relayer fee escrowed on side chain `sourceChainID` back
+// to it, if the proof is committed before the fee deadline. The relayer committing the proof earns
+// the fee, late deliveries are not settled and the payer refunds the fee on the side chain.
+func settleSourceFee(s *native.NativeContract, sourceChainID uint64, args *scom.TxArgs, relayer *Relayer) error {
+	if !zutils.RelayerFeeEarned(args, relayer.Time) {
+		return nil
+	}
+	txData, err := zutils.EncodeSettleArgs(relayer.Address, args.Fee, args.TransferID)
+	if err != nil {
+		return fmt.Errorf("failed to encode settle args, err: %v", err)
+	}
+	paramTxHash, crossChainID, err := nextCrossChainID(s)
+	if err != nil {
+		return err
+	}
+	return makeTransaction(s, relayer.Address, paramTxHash, crossChainID, sourceChainID, "settle", txData)
+}
+
+// Refundable returns true if the transfer is sent by a side chain lock proxy, which refunds it once
+// the main chain acknowledges the failure with `FailTransfer`. Unlocks are refundable since
+// `VersionRefund` of the lock proxy, and the transfers routed to other side chains since `VersionRoute`.
+func Refundable(s *native.NativeContract, txParams *scom.MakeTxParam) (bool, error) {
+	if common.BytesToAddress(txParams.FromContractAddress) != this ||
+		common.BytesToAddress(txParams.ToContractAddress) != this {
+		return false, nil
+	}
+	switch txParams.Method {
+	case "unlock":
+		return versionEnabled(s, VersionRefund)
+	case "mint":
+		return versionEnabled(s, VersionRoute)
+	}
+	return false, nil
+}
+
+// FailTransfer acknowledges a side chain transfer which can not be executed on the main chain. It
+// records the failed receipt and sends the transfer back to the source side chain lock proxy, which
+// refunds the burned amount. The caller should revert the state changes of the failed execution.
+func FailTransfer(s *native.NativeContract, sourceChainID uint64, txParams *scom.MakeTxParam, reason error) error {
+	if ok, err := Refundable(s, txParams); err != nil {
+		return fmt.Errorf("LockProxy.FailTransfer, %v", err)
+	} else if !ok {
+		return fmt.Errorf("LockProxy.FailTransfer, transfer is not refundable")
+	}
+	if receipt, err := getFailedReceipt(s, sourceChainID, txParams.CrossChainID); err != nil {
+		return fmt.Errorf("LockProxy.FailTransfer, failed to get failed receipt, err: %v", err)
+	} else if receipt != nil {
+		return fmt.Errorf("LockProxy.FailTransfer, transfer %x already failed", txParams.CrossChainID)
+	}
+
+	paramTxHash, crossChainID, err := nextCrossChainID(s)
+	if err != nil {
+		return fmt.Errorf("LockProxy.FailTransfer, %v", err)
+	}
+	receipt := &FailedReceipt{Reason: reason.Error(), RefundCrossChainID: crossChainID}
+	if err := storeFailedReceipt(s, sourceChainID, txParams.CrossChainID, receipt); err != nil {
+		return fmt.Errorf("LockProxy.FailTransfer, failed to store failed receipt, err: %v", err)
+	}
+	if err := emitTransferFailedEvent(s, sourceChainID, txParams.CrossChainID, receipt.Reason); err != nil {
+		return fmt.Errorf("LockProxy.FailTransfer, failed to emit `TransferFailedEvent`, err: %v", err)
+	}
+	if err := makeTransaction(s, s.ContractRef().TxOrigin(), paramTxHash, crossChainID, sourceChainID, "refund", txParams.Args); err != nil {
+		return fmt.Errorf("LockProxy.FailTransfer, %v", err)
+	}
 	return nil
 }
 
@@ -258,3 +860,81 @@ func GetSideChainLockAmount(s *native.NativeContract) ([]byte, error) {
 	amount := getTotalAmount(s, input.ChainId)
 	return utils.PackOutputs(ABI, MethodGetSideChainLockAmount, amount)
 }
+
+func GetRelayerFee(s *native.NativeContract) ([]byte, error) {
+	ctx := s.ContractRef().CurrentContext()
+
+	input := new(MethodGetRelayerFeeInput)
+	if err := input.Decode(ctx.Payload); err != nil {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.GetRelayerFee, failed to decode params, err: %v", err)
+	}
+
+	fee, err := getRelayerFee(s, input.CrossChainId)
+	if err != nil {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.GetRelayerFee, failed to get relayer fee, err: %v", err)
+	}
+	if fee == nil {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.GetRelayerFee, relayer fee of %x not exist", input.CrossChainId)
+	}
+	enc, err := rlp.EncodeToBytes(fee)
+	if err != nil {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.GetRelayerFee, failed to encode relayer fee, err: %v", err)
+	}
+	return utils.PackOutputs(ABI, MethodGetRelayerFee, enc)
+}
+
+// GetUnsettledRelayerFees returns the rlp encoded `UnsettledRelayerFees` of the transfers to the side
+// chain, at most `limit` fees from the position `start` of the list.
+func GetUnsettledRelayerFees(s *native.NativeContract) ([]byte, error) {
+	ctx := s.ContractRef().CurrentContext()
+
+	input := new(MethodGetUnsettledRelayerFeesInput)
+	if err := input.Decode(ctx.Payload); err != nil {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.GetUnsettledRelayerFees, failed to decode params, err: %v", err)
+	}
+	if input.Limit == 0 || input.Limit > maxUnsettledRelayerFees {
+		input.Limit = maxUnsettledRelayerFees
+	}
+
+	list, err := getUnsettledRelayerFees(s, input.ToChainId)
+	if err != nil {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.GetUnsettledRelayerFees, failed to get relayer fees, err: %v", err)
+	}
+	output := &UnsettledRelayerFees{Total: uint64(len(list))}
+	for i := input.Start; i < output.Total && i-input.Start < input.Limit; i++ {
+		fee, err := getRelayerFee(s, list[i])
+		if err != nil {
+			return utils.ByteFailed, fmt.Errorf("LockProxy.GetUnsettledRelayerFees, failed to get relayer fee, err: %v", err)
+		}
+		output.CrossChainIDs = append(output.CrossChainIDs, list[i])
+		output.Fees = append(output.Fees, fee)
+	}
+	enc, err := rlp.EncodeToBytes(output)
+	if err != nil {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.GetUnsettledRelayerFees, failed to encode relayer fees, err: %v", err)
+	}
+	return utils.PackOutputs(ABI, MethodGetUnsettledRelayerFees, enc)
+}
+
+// GetFailedReceipt returns the rlp encoded receipt of a side chain transfer which failed on the main chain.
+func GetFailedReceipt(s *native.NativeContract) ([]byte, error) {
+	ctx := s.ContractRef().CurrentContext()
+
+	input := new(MethodGetFailedReceiptInput)
+	if err := input.Decode(ctx.Payload); err != nil {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.GetFailedReceipt, failed to decode params, err: %v", err)
+	}
+
+	receipt, err := getFailedReceipt(s, input.FromChainId, input.CrossChainId)
+	if err != nil {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.GetFailedReceipt, failed to get failed receipt, err: %v", err)
+	}
+	if receipt == nil {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.GetFailedReceipt, failed receipt of %x not exist", input.CrossChainId)
+	}
+	enc, err := rlp.EncodeToBytes(receipt)
+	if err != nil {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.GetFailedReceipt, failed to encode failed receipt, err: %v", err)
+	}
+	return utils.PackOutputs(ABI, MethodGetFailedReceipt, enc)
+}
@@ -19,10 +19,12 @@
 package lock_proxy
 
 import (
+	"fmt"
 	"math/big"
 	"math/rand"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/contracts/native"
 	scom "github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/common"
@@ -30,6 +32,8 @@ import (
 	nm "github.com/ethereum/go-ethereum/contracts/native/governance/node_manager"
 	"github.com/ethereum/go-ethereum/contracts/native/governance/side_chain_manager"
 	nu "github.com/ethereum/go-ethereum/contracts/native/utils"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -56,11 +60,11 @@ func TestLockAndUnlock(t *testing.T) {
 
 		txArgs, err := utils.EncodeTxArgs(fromAsset.Bytes(), sender.Bytes(), amount)
 		assert.NoError(t, err)
-		
+
 		txParams := &scom.MakeTxParam{
 			CrossChainID:        []byte{'1', 'a'},
 			FromContractAddress: this[:],
-			ToChainID:           native.ZionMainChainID,
+			ToChainID:           params.LegacyRelayChainID,
 			ToContractAddress:   this.Bytes(),
 			Method:              "unlock",
 			Args:                txArgs,
@@ -74,11 +78,471 @@ func TestLockAndUnlock(t *testing.T) {
 	}
 }
 
+func TestLockWithFeeAndUnlock(t *testing.T) {
+	targetChainID := uint64(13)
+	sender := common.HexToAddress("0x6")
+	receiver := common.HexToAddress("0x7")
+	relayer := common.HexToAddress("0x8")
+	amount := big.NewInt(1000)
+	fee := big.NewInt(10)
+	blockTime := uint64(1650000000)
+
+	testStateDB.SetBalance(this, new(big.Int).Mul(amount, big.NewInt(10)))
+	testStateDB.SetBalance(sender, new(big.Int).Mul(amount, big.NewInt(10)))
+	assert.NoError(t, testSetSideChain(targetChainID))
+
+	// escrow amount and fee twice, the fee is not counted into the side chain locked amount
+	for i := 0; i < 2; i++ {
+		ctx, _, err := testLockWithFee(sender, receiver, targetChainID, amount, fee, blockTime)
+		assert.NoError(t, err)
+
+		crossChainID := utils.GenerateCrossChainID(this, scom.Uint256ToBytes(getTxIndex(ctx)))
+		record, err := testGetRelayerFee(crossChainID)
+		assert.NoError(t, err)
+		assert.Equal(t, &RelayerFee{Payer: sender, ToChainID: targetChainID, Fee: fee, Deadline: blockTime + utils.RelayerFeeTimeout}, record)
+	}
+	assertLockAmount(t, targetChainID, new(big.Int).Mul(amount, big.NewInt(2)))
+
+	// the side chain escrows the relayer fee of its burn under the transfer id
+	deadline := blockTime + utils.RelayerFeeTimeout
+	txArgs, err := rlp.EncodeToBytes(&scom.TxArgs{
+		ToAssetHash: common.EmptyAddress.Bytes(),
+		ToAddress:   receiver.Bytes(),
+		Amount:      amount,
+		Fee:         fee,
+		FeeDeadline: deadline,
+		TransferID:  []byte("side chain transfer"),
+	})
+	assert.NoError(t, err)
+	txParams := &scom.MakeTxParam{
+		CrossChainID:        []byte{'2', 'a'},
+		FromContractAddress: this[:],
+		ToChainID:           params.LegacyRelayChainID,
+		ToContractAddress:   this.Bytes(),
+		Method:              "unlock",
+		Args:                txArgs,
+	}
+
+	// delivered in time, the settlement of the relayer fee is sent back to the side chain
+	txIndex := getTxIndex(generateTestCallCtx(nil))
+	_, err = testUnlockAt(relayer, targetChainID, txParams, deadline)
+	assert.NoError(t, err)
+	assert.Equal(t, amount, testStateDB.GetBalance(receiver))
+	assert.Equal(t, 0, testStateDB.GetBalance(relayer).Sign())
+	assert.Equal(t, new(big.Int).Add(txIndex, common.Big1), getTxIndex(generateTestCallCtx(nil)))
+
+	// delivered too late, the fee is not settled and the payer refunds it on the side chain
+	_, err = testUnlockAt(relayer, targetChainID, txParams, deadline+1)
+	assert.NoError(t, err)
+	assert.Equal(t, new(big.Int).Mul(amount, big.NewInt(2)), testStateDB.GetBalance(receiver))
+	assert.Equal(t, 0, testStateDB.GetBalance(relayer).Sign())
+	assert.Equal(t, new(big.Int).Add(txIndex, common.Big1), getTxIndex(generateTestCallCtx(nil)))
+	assertLockAmount(t, targetChainID, common.Big0)
+
+	// committed in time but released late by another caller, the fee is still settled to the relayer
+	addTotalAmount(generateTestCallCtx(nil), targetChainID, amount)
+	ctx := generateTestSenderTx(sender, nu.CrossChainManagerContractAddress, nil)
+	ctx.ContractRef().SetTo(nu.CrossChainManagerContractAddress)
+	ctx.ContractRef().SetBlockTime(deadline + 100)
+	assert.NoError(t, Unlock(ctx, targetChainID, txParams, &Relayer{Address: relayer, Time: deadline}))
+	assert.Equal(t, new(big.Int).Add(txIndex, common.Big2), getTxIndex(generateTestCallCtx(nil)))
+
+	// the relayer fee can not be settled without transfer id
+	addTotalAmount(generateTestCallCtx(nil), targetChainID, amount)
+	txParams.Args, err = rlp.EncodeToBytes(&scom.TxArgs{
+		ToAssetHash: common.EmptyAddress.Bytes(),
+		ToAddress:   receiver.Bytes(),
+		Amount:      amount,
+		Fee:         fee,
+		FeeDeadline: deadline,
+	})
+	assert.NoError(t, err)
+	_, err = testUnlockAt(relayer, targetChainID, txParams, deadline)
+	assert.Error(t, err)
+}
+
+func TestSettleAndRefundRelayerFee(t *testing.T) {
+	targetChainID := uint64(20)
+	sender := common.HexToAddress("0x10")
+	receiver := common.HexToAddress("0x11")
+	relayer := common.HexToAddress("0x12")
+	amount := big.NewInt(1000)
+	fee := big.NewInt(10)
+	blockTime := uint64(1650000000)
+	deadline := blockTime + utils.RelayerFeeTimeout
+	refundTime := deadline + utils.RelayerFeeRefundDelay + 1
+	balance := new(big.Int).Mul(amount, big.NewInt(10))
+
+	testStateDB.SetBalance(this, balance)
+	testStateDB.SetBalance(sender, balance)
+	assert.NoError(t, testSetSideChain(targetChainID))
+	assert.NoError(t, testSetSideChain(targetChainID+1))
+
+	ctx, _, err := testLockWithFee(sender, receiver, targetChainID, amount, fee, blockTime)
+	assert.NoError(t, err)
+	settled := utils.GenerateCrossChainID(this, scom.Uint256ToBytes(getTxIndex(ctx)))
+	ctx, _, err = testLockWithFee(sender, receiver, targetChainID, amount, fee, blockTime)
+	assert.NoError(t, err)
+	refunded := utils.GenerateCrossChainID(this, scom.Uint256ToBytes(getTxIndex(ctx)))
+
+	// the unsettled fees are listed by the target chain
+	fees, err := testGetUnsettledRelayerFees(targetChainID, 0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), fees.Total)
+	assert.Equal(t, [][]byte{settled, refunded}, fees.CrossChainIDs)
+	assert.Equal(t, sender, fees.Fees[1].Payer)
+	assert.Equal(t, fee, fees.Fees[1].Fee)
+	fees, err = testGetUnsettledRelayerFees(targetChainID, 1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), fees.Total)
+	assert.Equal(t, [][]byte{refunded}, fees.CrossChainIDs)
+	fees, err = testGetUnsettledRelayerFees(targetChainID+1, 0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), fees.Total)
+	assert.Empty(t, fees.CrossChainIDs)
+
+	// the side chain settles the transfer minted in time
+	settleArgs, err := utils.EncodeSettleArgs(relayer, fee, settled)
+	assert.NoError(t, err)
+	txParams := &scom.MakeTxParam{
+		CrossChainID:        []byte{'9', 'a'},
+		FromContractAddress: this[:],
+		ToChainID:           params.LegacyRelayChainID,
+		ToContractAddress:   this.Bytes(),
+		Method:              "settle",
+		Args:                settleArgs,
+	}
+	assert.Error(t, testSettle(relayer, targetChainID+1, txParams))
+	assert.NoError(t, testSettle(relayer, targetChainID, txParams))
+	assert.Equal(t, fee, testStateDB.GetBalance(relayer))
+	record, err := testGetRelayerFee(settled)
+	assert.NoError(t, err)
+	assert.True(t, record.Settled)
+	fees, err = testGetUnsettledRelayerFees(targetChainID, 0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{refunded}, fees.CrossChainIDs)
+
+	// the fee is paid only once
+	assert.NoError(t, testSettle(relayer, targetChainID, txParams))
+	assert.Equal(t, fee, testStateDB.GetBalance(relayer))
+	assert.Error(t, testRefundRelayerFee(sender, settled, refundTime))
+
+	// the payer refunds the unsettled fee after the deadline and the refund delay
+	paid := testStateDB.GetBalance(sender)
+	assert.Error(t, testRefundRelayerFee(sender, refunded, refundTime-1))
+	assert.Error(t, testRefundRelayerFee(receiver, refunded, refundTime))
+	assert.NoError(t, testRefundRelayerFee(sender, refunded, refundTime))
+	assert.Equal(t, new(big.Int).Add(paid, fee), testStateDB.GetBalance(sender))
+	assert.Error(t, testRefundRelayerFee(sender, refunded, refundTime))
+	fees, err = testGetUnsettledRelayerFees(targetChainID, 0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), fees.Total)
+
+	// the settlement arriving after the refund is ignored
+	txParams.CrossChainID = []byte{'9', 'b'}
+	txParams.Args, err = utils.EncodeSettleArgs(relayer, fee, refunded)
+	assert.NoError(t, err)
+	assert.NoError(t, testSettle(relayer, targetChainID, txParams))
+	assert.Equal(t, fee, testStateDB.GetBalance(relayer))
+	assert.Equal(t, new(big.Int).Add(paid, fee), testStateDB.GetBalance(sender))
+}
+
+func TestLockAndRefund(t *testing.T) {
+	targetChainID := uint64(14)
+	sender := common.HexToAddress("0x9")
+	receiver := common.HexToAddress("0xa")
+	amount := big.NewInt(1000)
+	fee := big.NewInt(10)
+	blockTime := uint64(1650000000)
+	balance := new(big.Int).Mul(amount, big.NewInt(10))
+
+	testStateDB.SetBalance(this, balance)
+	testStateDB.SetBalance(sender, balance)
+	assert.NoError(t, testSetSideChain(targetChainID))
+	assert.NoError(t, testSetSideChain(targetChainID+1))
+
+	ctx, _, err := testLockWithFee(sender, receiver, targetChainID, amount, fee, blockTime)
+	assert.NoError(t, err)
+	crossChainID := utils.GenerateCrossChainID(this, scom.Uint256ToBytes(getTxIndex(ctx)))
+
+	// side chain sends the transfer back with the lock args
+	txArgs, err := rlp.EncodeToBytes(&scom.TxArgs{
+		ToAssetHash: common.EmptyAddress.Bytes(),
+		ToAddress:   receiver.Bytes(),
+		Amount:      amount,
+		Fee:         fee,
+		FeeDeadline: blockTime + utils.RelayerFeeTimeout,
+		TransferID:  crossChainID,
+	})
+	assert.NoError(t, err)
+	txParams := &scom.MakeTxParam{
+		CrossChainID:        []byte{'3', 'a'},
+		FromContractAddress: this[:],
+		ToChainID:           params.LegacyRelayChainID,
+		ToContractAddress:   this.Bytes(),
+		Method:              "refund",
+		Args:                txArgs,
+	}
+
+	// only the side chain of the lock can refund it
+	assert.Error(t, testRefund(receiver, targetChainID+1, txParams))
+
+	// amount and relayer fee are returned to the owner instead of the receiver
+	assert.NoError(t, testRefund(receiver, targetChainID, txParams))
+	assert.Equal(t, new(big.Int).Add(balance, new(big.Int).Add(amount, fee)), testStateDB.GetBalance(sender))
+	assert.Equal(t, 0, testStateDB.GetBalance(receiver).Sign())
+	total, err := testGetLockAmount(targetChainID)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, total.Sign())
+	record, err := testGetRelayerFee(crossChainID)
+	assert.NoError(t, err)
+	assert.True(t, record.Settled)
+
+	// refund only once
+	assert.Error(t, testRefund(receiver, targetChainID, txParams))
+	assert.Error(t, testRefundRelayerFee(sender, crossChainID, blockTime+utils.RelayerFeeTimeout+utils.RelayerFeeRefundDelay+1))
+}
+
+func TestFailTransfer(t *testing.T) {
+	srcChainID := uint64(16)
+	receiver := common.HexToAddress("0xb")
+	txArgs, err := utils.EncodeTxArgs(common.EmptyAddress.Bytes(), receiver.Bytes(), big.NewInt(100))
+	assert.NoError(t, err)
+	txParams := &scom.MakeTxParam{
+		CrossChainID:        []byte{'4', 'a'},
+		FromContractAddress: this[:],
+		ToChainID:           params.LegacyRelayChainID,
+		ToContractAddress:   this.Bytes(),
+		Method:              "unlock",
+		Args:                txArgs,
+	}
+	reason := fmt.Errorf("total locked amount not enough")
+
+	ctx := generateTestSenderTx(receiver, nu.CrossChainManagerContractAddress, nil)
+	assert.NoError(t, FailTransfer(ctx, srcChainID, txParams, reason))
+	crossChainID := utils.GenerateCrossChainID(this, scom.Uint256ToBytes(getTxIndex(ctx)))
+
+	receipt, err := testGetFailedReceipt(srcChainID, txParams.CrossChainID)
+	assert.NoError(t, err)
+	assert.Equal(t, &FailedReceipt{Reason: reason.Error(), RefundCrossChainID: crossChainID}, receipt)
+
+	// acknowledged only once
+	assert.Error(t, FailTransfer(generateTestSenderTx(receiver, nu.CrossChainManagerContractAddress, nil), srcChainID, txParams, reason))
+
+	// refunds are never sent back
+	txParams.CrossChainID, txParams.Method = []byte{'5', 'a'}, "refund"
+	assert.Error(t, FailTransfer(generateTestSenderTx(receiver, nu.CrossChainManagerContractAddress, nil), srcChainID, txParams, reason))
+}
+
+func TestLockBeforeRefundVersion(t *testing.T) {
+	upgrades := testNativeUpgrades
+	testNativeUpgrades = nil
+	defer func() { testNativeUpgrades = upgrades }()
+
+	targetChainID := uint64(19)
+	sender := common.HexToAddress("0xe")
+	receiver := common.HexToAddress("0xf")
+	amount := big.NewInt(1000)
+	testStateDB.SetBalance(sender, amount)
+	assert.NoError(t, testSetSideChain(targetChainID))
+
+	// the initial implementation neither records the lock nor sends a transfer id
+	ctx, _, err := testLock(sender, receiver, targetChainID, amount)
+	assert.NoError(t, err)
+	crossChainID := utils.GenerateCrossChainID(this, scom.Uint256ToBytes(getTxIndex(ctx)))
+	record, err := getLockRecord(ctx, crossChainID)
+	assert.NoError(t, err)
+	assert.Nil(t, record)
+
+	txArgs, err := rlp.EncodeToBytes(&scom.TxArgs{
+		ToAssetHash: common.EmptyAddress.Bytes(),
+		ToAddress:   receiver.Bytes(),
+		Amount:      amount,
+		TransferID:  crossChainID,
+	})
+	assert.NoError(t, err)
+	txParams := &scom.MakeTxParam{
+		CrossChainID:        []byte{'8', 'a'},
+		FromContractAddress: this[:],
+		ToChainID:           params.LegacyRelayChainID,
+		ToContractAddress:   this.Bytes(),
+		Method:              "refund",
+		Args:                txArgs,
+	}
+	assert.Error(t, testRefund(receiver, targetChainID, txParams))
+
+	// failed transfers are not sent back
+	txParams.Method = "unlock"
+	reason := fmt.Errorf("total locked amount not enough")
+	assert.Error(t, FailTransfer(generateTestSenderTx(receiver, nu.CrossChainManagerContractAddress, nil), targetChainID, txParams, reason))
+	_, err = testGetFailedReceipt(targetChainID, txParams.CrossChainID)
+	assert.Error(t, err)
+}
+
+func TestRelayerFeeBeforeFeeVersion(t *testing.T) {
+	upgrades := testNativeUpgrades
+	testNativeUpgrades = []*params.NativeUpgrade{{Contract: this, Version: VersionRoute, Block: common.Big0}}
+	defer func() { testNativeUpgrades = upgrades }()
+
+	srcChainID := uint64(21)
+	sender := common.HexToAddress("0x10")
+	receiver := common.HexToAddress("0x11")
+	amount := big.NewInt(1000)
+	fee := big.NewInt(10)
+	testStateDB.SetBalance(sender, new(big.Int).Add(amount, fee))
+	assert.NoError(t, testSetSideChain(srcChainID))
+
+	// the relayer fee methods are not registered before `VersionRelayerFee`
+	inputs := []interface{ Encode() ([]byte, error) }{
+		&MethodLockWithFeeInput{ToChainId: srcChainID, ToAddress: receiver, Amount: amount, Fee: fee},
+		&MethodGetRelayerFeeInput{CrossChainId: []byte{1}},
+		&MethodRefundRelayerFeeInput{CrossChainId: []byte{1}},
+		&MethodGetUnsettledRelayerFeesInput{ToChainId: srcChainID},
+	}
+	for _, input := range inputs {
+		payload, err := input.Encode()
+		assert.NoError(t, err)
+		_, _, err = generateTestSenderTx(sender, sender, nil).ContractRef().NativeCall(sender, this, payload)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "failed to find method")
+		}
+	}
+	payload, err := (&MethodGetUnsettledRelayerFeesInput{ToChainId: srcChainID}).Encode()
+	assert.NoError(t, err)
+	testNativeUpgrades = upgrades
+	_, _, err = generateTestSenderTx(sender, sender, nil).ContractRef().NativeCall(sender, this, payload)
+	assert.NoError(t, err)
+	testNativeUpgrades = []*params.NativeUpgrade{{Contract: this, Version: VersionRoute, Block: common.Big0}}
+
+	// the side chain transfers carrying relayer fees are neither unlocked nor settled
+	addTotalAmount(generateTestCallCtx(nil), srcChainID, amount)
+	txArgs, err := rlp.EncodeToBytes(&scom.TxArgs{
+		ToAssetHash: common.EmptyAddress.Bytes(),
+		ToAddress:   receiver.Bytes(),
+		Amount:      amount,
+		Fee:         fee,
+		FeeDeadline: uint64(1650000000),
+		TransferID:  []byte("fee transfer"),
+	})
+	assert.NoError(t, err)
+	txParams := &scom.MakeTxParam{
+		CrossChainID:        []byte{'9', 'a'},
+		FromContractAddress: this[:],
+		ToChainID:           params.LegacyRelayChainID,
+		ToContractAddress:   this.Bytes(),
+		Method:              "unlock",
+		Args:                txArgs,
+	}
+	_, err = testUnlockAt(sender, srcChainID, txParams, uint64(1650000000))
+	assert.Error(t, err)
+	assertLockAmount(t, srcChainID, amount)
+
+	settleArgs, err := utils.EncodeSettleArgs(sender, fee, []byte("fee transfer"))
+	assert.NoError(t, err)
+	txParams.Method, txParams.Args = "settle", settleArgs
+	assert.Error(t, testSettle(sender, srcChainID, txParams))
+}
+
+func TestSideChainTransferAndRefund(t *testing.T) {
+	fromChainID, toChainID := uint64(17), uint64(18)
+	owner := common.HexToAddress("0xc")
+	relayer := common.HexToAddress("0xd")
+	locked := big.NewInt(1000)
+	amount := big.NewInt(500)
+	fee := big.NewInt(10)
+	deadline := uint64(1650000000)
+
+	assert.NoError(t, testSetSideChain(fromChainID))
+	assert.NoError(t, testSetSideChain(toChainID))
+	addTotalAmount(generateTestCallCtx(nil), fromChainID, locked)
+
+	txArgs, err := rlp.EncodeToBytes(&scom.TxArgs{
+		ToAssetHash: common.EmptyAddress.Bytes(),
+		ToAddress:   owner.Bytes(),
+		Amount:      amount,
+		Fee:         fee,
+		FeeDeadline: deadline,
+		TransferID:  []byte("side chain transfer"),
+	})
+	assert.NoError(t, err)
+	txParams := &scom.MakeTxParam{
+		CrossChainID:        []byte{'6', 'a'},
+		FromContractAddress: this[:],
+		ToChainID:           toChainID,
+		ToContractAddress:   this.Bytes(),
+		Method:              "mint",
+		Args:                txArgs,
+	}
+
+	// the transfer is neither routed nor refundable before `VersionRoute`
+	upgrades := testNativeUpgrades
+	testNativeUpgrades = []*params.NativeUpgrade{{Contract: this, Version: VersionRefund, Block: common.Big0}}
+	_, err = testTransfer(relayer, fromChainID, txParams)
+	assert.Error(t, err)
+	refundable, err := Refundable(generateTestCallCtx(nil), txParams)
+	assert.NoError(t, err)
+	assert.False(t, refundable)
+	testNativeUpgrades = upgrades
+	assertLockAmount(t, fromChainID, locked)
+
+	// the locked amount moves to the target side chain, the relayer fee is settled with the source
+	// side chain by the cross chain transaction following the mint
+	ctx, err := testTransfer(relayer, fromChainID, txParams)
+	assert.NoError(t, err)
+	assertLockAmount(t, fromChainID, new(big.Int).Sub(locked, amount))
+	assertLockAmount(t, toChainID, amount)
+
+	txIndex := getTxIndex(ctx)
+	crossChainID := utils.GenerateCrossChainID(this, scom.Uint256ToBytes(new(big.Int).Sub(txIndex, common.Big1)))
+	record, err := getLockRecord(ctx, crossChainID)
+	assert.NoError(t, err)
+	assert.Equal(t, &LockRecord{Owner: owner, ToChainID: toChainID, Amount: amount, FromChainID: fromChainID, SourceTransferID: []byte("side chain transfer")}, record)
+
+	// can not route more than the source side chain locked
+	txParams.Args, err = utils.EncodeTxArgs(common.EmptyAddress.Bytes(), owner.Bytes(), locked)
+	assert.NoError(t, err)
+	_, err = testTransfer(relayer, fromChainID, txParams)
+	assert.Error(t, err)
+
+	// the target side chain fails to mint, the transfer goes back to the source side chain
+	refundArgs, err := rlp.EncodeToBytes(&scom.TxArgs{
+		ToAssetHash: common.EmptyAddress.Bytes(),
+		ToAddress:   owner.Bytes(),
+		Amount:      amount,
+		TransferID:  crossChainID,
+	})
+	assert.NoError(t, err)
+	refundParams := &scom.MakeTxParam{
+		CrossChainID:        []byte{'7', 'a'},
+		FromContractAddress: this[:],
+		ToChainID:           params.LegacyRelayChainID,
+		ToContractAddress:   this.Bytes(),
+		Method:              "refund",
+		Args:                refundArgs,
+	}
+	assert.NoError(t, testRefund(relayer, toChainID, refundParams))
+	assertLockAmount(t, fromChainID, locked)
+	assertLockAmount(t, toChainID, common.Big0)
+	assert.Equal(t, 0, testStateDB.GetBalance(owner).Sign())
+
+	// the refund carries the transfer id of the burn, with which the source side chain refunds the
+	// relayer fee escrowed for the transfer
+	refundTx, err := testLastCrossChainTx()
+	assert.NoError(t, err)
+	assert.Equal(t, fromChainID, refundTx.ToChainID)
+	assert.Equal(t, "refund", refundTx.Method)
+	args, err := utils.DecodeTxArgs(refundTx.Args)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("side chain transfer"), args.TransferID)
+	assert.Equal(t, owner.Bytes(), args.ToAddress)
+	assert.Equal(t, 0, amount.Cmp(args.Amount))
+}
+
 func testLock(sender, toAddress common.Address, toChainID uint64, amount *big.Int) (*native.NativeContract, []byte, error) {
 	input := &MethodLockInput{
-		ToChainId:     toChainID,
-		ToAddress:     toAddress,
-		Amount:        amount,
+		ToChainId: toChainID,
+		ToAddress: toAddress,
+		Amount:    amount,
 	}
 	payload, err := input.Encode()
 	if err != nil {
@@ -95,12 +559,184 @@ func testLock(sender, toAddress common.Address, toChainID uint64, amount *big.In
 	}
 }
 
+func testLockWithFee(sender, toAddress common.Address, toChainID uint64, amount, fee *big.Int, blockTime uint64) (*native.NativeContract, []byte, error) {
+	input := &MethodLockWithFeeInput{
+		ToChainId: toChainID,
+		ToAddress: toAddress,
+		Amount:    amount,
+		Fee:       fee,
+	}
+	payload, err := input.Encode()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctx := generateTestSenderTx(sender, sender, payload)
+	ctx.ContractRef().SetValue(new(big.Int).Add(amount, fee))
+	ctx.ContractRef().SetTo(this)
+	ctx.ContractRef().SetBlockTime(blockTime)
+	if ret, err := LockWithFee(ctx); err != nil {
+		return nil, nil, err
+	} else {
+		return ctx, ret, nil
+	}
+}
+
+func testUnlockAt(relayer common.Address, srcChainID uint64, makeTxParams *scom.MakeTxParam, blockTime uint64) (*native.NativeContract, error) {
+	entrance := nu.CrossChainManagerContractAddress
+	ctx := generateTestSenderTx(relayer, entrance, nil)
+	ctx.ContractRef().SetTo(entrance)
+	ctx.ContractRef().SetBlockTime(blockTime)
+	if err := Unlock(ctx, srcChainID, makeTxParams, &Relayer{Address: relayer, Time: blockTime}); err != nil {
+		return nil, err
+	} else {
+		return ctx, nil
+	}
+}
+
+func testGetRelayerFee(crossChainID []byte) (*RelayerFee, error) {
+	input := &MethodGetRelayerFeeInput{CrossChainId: crossChainID}
+	payload, err := input.Encode()
+	if err != nil {
+		return nil, err
+	}
+	ctx := generateTestCallCtx(payload)
+
+	enc, err := GetRelayerFee(ctx)
+	if err != nil {
+		return nil, err
+	}
+	output := new(struct{ Fee []byte })
+	if err := nu.UnpackOutputs(ABI, "getRelayerFee", output, enc); err != nil {
+		return nil, err
+	}
+	fee := new(RelayerFee)
+	if err := rlp.DecodeBytes(output.Fee, fee); err != nil {
+		return nil, err
+	}
+	return fee, nil
+}
+
+func testTransfer(relayer common.Address, srcChainID uint64, makeTxParams *scom.MakeTxParam) (*native.NativeContract, error) {
+	entrance := nu.CrossChainManagerContractAddress
+	ctx := generateTestSenderTx(relayer, entrance, nil)
+	ctx.ContractRef().SetTo(entrance)
+	if err := Transfer(ctx, srcChainID, makeTxParams, &Relayer{Address: relayer, Time: ctx.ContractRef().BlockTime()}); err != nil {
+		return nil, err
+	}
+	return ctx, nil
+}
+
+func assertLockAmount(t *testing.T, chainID uint64, expect *big.Int) {
+	total, err := testGetLockAmount(chainID)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, expect.Cmp(total), "chain %d locked %v, expect %v", chainID, total, expect)
+}
+
+func testRefund(relayer common.Address, srcChainID uint64, makeTxParams *scom.MakeTxParam) error {
+	entrance := nu.CrossChainManagerContractAddress
+	ctx := generateTestSenderTx(relayer, entrance, nil)
+	ctx.ContractRef().SetTo(entrance)
+	return Refund(ctx, srcChainID, makeTxParams)
+}
+
+func testSettle(relayer common.Address, srcChainID uint64, makeTxParams *scom.MakeTxParam) error {
+	entrance := nu.CrossChainManagerContractAddress
+	ctx := generateTestSenderTx(relayer, entrance, nil)
+	ctx.ContractRef().SetTo(entrance)
+	return Settle(ctx, srcChainID, makeTxParams)
+}
+
+func testRefundRelayerFee(sender common.Address, crossChainID []byte, blockTime uint64) error {
+	input := &MethodRefundRelayerFeeInput{CrossChainId: crossChainID}
+	payload, err := input.Encode()
+	if err != nil {
+		return err
+	}
+	ctx := generateTestSenderTx(sender, sender, payload)
+	ctx.ContractRef().SetTo(this)
+	ctx.ContractRef().SetBlockTime(blockTime)
+	_, err = RefundRelayerFee(ctx)
+	return err
+}
+
+func testGetUnsettledRelayerFees(toChainID, start, limit uint64) (*UnsettledRelayerFees, error) {
+	input := &MethodGetUnsettledRelayerFeesInput{ToChainId: toChainID, Start: start, Limit: limit}
+	payload, err := input.Encode()
+	if err != nil {
+		return nil, err
+	}
+	ctx := generateTestCallCtx(payload)
+
+	enc, err := GetUnsettledRelayerFees(ctx)
+	if err != nil {
+		return nil, err
+	}
+	output := new(struct{ Fees []byte })
+	if err := nu.UnpackOutputs(ABI, "getUnsettledRelayerFees", output, enc); err != nil {
+		return nil, err
+	}
+	fees := new(UnsettledRelayerFees)
+	if err := rlp.DecodeBytes(output.Fees, fees); err != nil {
+		return nil, err
+	}
+	return fees, nil
+}
+
+func testLastCrossChainTx() (*scom.MakeTxParam, error) {
+	logs := testStateDB.Logs()
+	for i := len(logs) - 1; i >= 0; i-- {
+		if len(logs[i].Topics) == 0 || logs[i].Topics[0] != ABI.Events["CrossChainEvent"].ID {
+			continue
+		}
+		// native events pack the indexed inputs into the data as well
+		inputs := make(abi.Arguments, 0)
+		for _, input := range ABI.Events["CrossChainEvent"].Inputs {
+			input.Indexed = false
+			inputs = append(inputs, input)
+		}
+		values, err := inputs.Unpack(logs[i].Data)
+		if err != nil {
+			return nil, err
+		}
+		tx := new(scom.MakeTxParam)
+		if err := rlp.DecodeBytes(values[len(values)-1].([]byte), tx); err != nil {
+			return nil, err
+		}
+		return tx, nil
+	}
+	return nil, fmt.Errorf("no cross chain event")
+}
+
+func testGetFailedReceipt(fromChainID uint64, crossChainID []byte) (*FailedReceipt, error) {
+	input := &MethodGetFailedReceiptInput{FromChainId: fromChainID, CrossChainId: crossChainID}
+	payload, err := input.Encode()
+	if err != nil {
+		return nil, err
+	}
+	ctx := generateTestCallCtx(payload)
+
+	enc, err := GetFailedReceipt(ctx)
+	if err != nil {
+		return nil, err
+	}
+	output := new(struct{ Receipt []byte })
+	if err := nu.UnpackOutputs(ABI, "getFailedReceipt", output, enc); err != nil {
+		return nil, err
+	}
+	receipt := new(FailedReceipt)
+	if err := rlp.DecodeBytes(output.Receipt, receipt); err != nil {
+		return nil, err
+	}
+	return receipt, nil
+}
+
 func testUnlock(sender common.Address, srcChainID uint64, makeTxParams *scom.MakeTxParam, amount *big.Int) (*native.NativeContract, error) {
 	entrance := nu.CrossChainManagerContractAddress
-	ctx := generateTestSenderTx(sender, entrance,nil)
+	ctx := generateTestSenderTx(sender, entrance, nil)
 	ctx.ContractRef().SetValue(amount)
 	ctx.ContractRef().SetTo(entrance)
-	if err := Unlock(ctx, srcChainID, makeTxParams); err != nil {
+	if err := Unlock(ctx, srcChainID, makeTxParams, &Relayer{Address: sender, Time: ctx.ContractRef().BlockTime()}); err != nil {
 		return nil, err
 	} else {
 		return ctx, nil
@@ -125,6 +761,7 @@ func testGetLockAmount(chainID uint64) (*big.Int, error) {
 func generateTestSenderTx(sender, caller common.Address, payload []byte) *native.NativeContract {
 	txHash := nm.GenerateTestHash(rand.Int())
 	ref := native.NewContractRef(testStateDB, sender, caller, big.NewInt(testBlockNum), txHash, testSupplyGas, nil)
+	ref.SetNativeUpgrades(testNativeUpgrades)
 	ref.PushContext(&native.Context{
 		Caller:          sender,
 		ContractAddress: this,
@@ -137,6 +774,7 @@ func generateTestCallCtx(payload []byte) *native.NativeContract {
 	caller := common.EmptyAddress
 	txHash := nm.GenerateTestHash(rand.Int())
 	ref := native.NewContractRef(testStateDB, caller, caller, big.NewInt(testBlockNum), txHash, testSupplyGas, nil)
+	ref.SetNativeUpgrades(testNativeUpgrades)
 	ref.PushContext(&native.Context{
 		Caller:          caller,
 		ContractAddress: this,
@@ -19,6 +19,7 @@
 package lock_proxy
 
 import (
+	"bytes"
 	"fmt"
 	"math/big"
 
@@ -26,11 +27,16 @@ import (
 	"github.com/ethereum/go-ethereum/contracts/native"
 	scom "github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/common"
 	"github.com/ethereum/go-ethereum/contracts/native/utils"
+	"github.com/ethereum/go-ethereum/rlp"
 )
 
 const (
-	SKP_TX_INDEX     = "st_tx_index"
-	SKP_TOTAL_AMOUNT = "st_amt"
+	SKP_TX_INDEX       = "st_tx_index"
+	SKP_TOTAL_AMOUNT   = "st_amt"
+	SKP_RELAYER_FEE    = "st_relayer_fee"
+	SKP_UNSETTLED_FEE  = "st_unsettled_fee"
+	SKP_LOCK_RECORD    = "st_lock_record"
+	SKP_FAILED_RECEIPT = "st_failed_receipt"
 )
 
 func getNextTxIndex(s *native.NativeContract) (*big.Int, error) {
@@ -86,6 +92,169 @@ func storeTotalAmount(s *native.NativeContract, sideChainID uint64, amount *big.
 	s.GetCacheDB().Put(totalAmountKey(sideChainID), amount.Bytes())
 }
 
+// RelayerFee records the relayer fee escrowed by a `lockWithFee` transfer. The fee is settled once,
+// either paid to the relayer when the target chain acknowledges the delivery in time, or refunded
+// to the payer.
+type RelayerFee struct {
+	Payer     common.Address
+	ToChainID uint64
+	Fee       *big.Int
+	Deadline  uint64
+	Settled   bool `rlp:"optional"`
+}
+
+func getRelayerFee(s *native.NativeContract, crossChainID []byte) (*RelayerFee, error) {
+	blob, err := s.GetCacheDB().Get(relayerFeeKey(crossChainID))
+	if err != nil {
+		return nil, err
+	}
+	if blob == nil {
+		return nil, nil
+	}
+	fee := new(RelayerFee)
+	if err := rlp.DecodeBytes(blob, fee); err != nil {
+		return nil, err
+	}
+	return fee, nil
+}
+
+func storeRelayerFee(s *native.NativeContract, crossChainID []byte, fee *RelayerFee) error {
+	blob, err := rlp.EncodeToBytes(fee)
+	if err != nil {
+		return err
+	}
+	s.GetCacheDB().Put(relayerFeeKey(crossChainID), blob)
+	return nil
+}
+
+// UnsettledRelayerFees lists the unsettled relayer fees escrowed by the transfers to a side chain, in
+// the order of the transfers locked. Total is the number of all the unsettled fees of the side chain.
+type UnsettledRelayerFees struct {
+	Total         uint64
+	CrossChainIDs [][]byte
+	Fees          []*RelayerFee
+}
+
+func getUnsettledRelayerFees(s *native.NativeContract, toChainID uint64) ([][]byte, error) {
+	blob, err := s.GetCacheDB().Get(unsettledFeeKey(toChainID))
+	if err != nil {
+		return nil, err
+	}
+	if blob == nil {
+		return nil, nil
+	}
+	var list [][]byte
+	if err := rlp.DecodeBytes(blob, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func storeUnsettledRelayerFees(s *native.NativeContract, toChainID uint64, list [][]byte) error {
+	if len(list) == 0 {
+		s.GetCacheDB().Delete(unsettledFeeKey(toChainID))
+		return nil
+	}
+	blob, err := rlp.EncodeToBytes(list)
+	if err != nil {
+		return err
+	}
+	s.GetCacheDB().Put(unsettledFeeKey(toChainID), blob)
+	return nil
+}
+
+func addUnsettledRelayerFee(s *native.NativeContract, toChainID uint64, crossChainID []byte) error {
+	list, err := getUnsettledRelayerFees(s, toChainID)
+	if err != nil {
+		return err
+	}
+	return storeUnsettledRelayerFees(s, toChainID, append(list, crossChainID))
+}
+
+func removeUnsettledRelayerFee(s *native.NativeContract, toChainID uint64, crossChainID []byte) error {
+	list, err := getUnsettledRelayerFees(s, toChainID)
+	if err != nil {
+		return err
+	}
+	for i, id := range list {
+		if bytes.Equal(id, crossChainID) {
+			return storeUnsettledRelayerFees(s, toChainID, append(list[:i], list[i+1:]...))
+		}
+	}
+	return nil
+}
+
+// LockRecord records the owner of a locked transfer, the locked amount is refunded to the owner
+// if the side chain fails to mint it. FromChainID is the side chain a routed transfer was burned
+// on, which gets the refund instead of the main chain, and SourceTransferID is the transfer id of
+// the burn, with which the source side chain refunds the relayer fee escrowed for the transfer.
+type LockRecord struct {
+	Owner            common.Address
+	ToChainID        uint64
+	Amount           *big.Int
+	FromChainID      uint64 `rlp:"optional"`
+	SourceTransferID []byte `rlp:"optional"`
+}
+
+func getLockRecord(s *native.NativeContract, crossChainID []byte) (*LockRecord, error) {
+	blob, err := s.GetCacheDB().Get(lockRecordKey(crossChainID))
+	if err != nil {
+		return nil, err
+	}
+	if blob == nil {
+		return nil, nil
+	}
+	record := new(LockRecord)
+	if err := rlp.DecodeBytes(blob, record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+func storeLockRecord(s *native.NativeContract, crossChainID []byte, record *LockRecord) error {
+	blob, err := rlp.EncodeToBytes(record)
+	if err != nil {
+		return err
+	}
+	s.GetCacheDB().Put(lockRecordKey(crossChainID), blob)
+	return nil
+}
+
+func deleteLockRecord(s *native.NativeContract, crossChainID []byte) {
+	s.GetCacheDB().Delete(lockRecordKey(crossChainID))
+}
+
+// FailedReceipt records a side chain transfer which the main chain failed to execute, the transfer
+// is sent back to the source side chain by the cross chain transaction `RefundCrossChainID`.
+type FailedReceipt struct {
+	Reason             string
+	RefundCrossChainID []byte
+}
+
+func getFailedReceipt(s *native.NativeContract, fromChainID uint64, crossChainID []byte) (*FailedReceipt, error) {
+	blob, err := s.GetCacheDB().Get(failedReceiptKey(fromChainID, crossChainID))
+	if err != nil {
+		return nil, err
+	}
+	if blob == nil {
+		return nil, nil
+	}
+	receipt := new(FailedReceipt)
+	if err := rlp.DecodeBytes(blob, receipt); err != nil {
+		return nil, err
+	}
+	return receipt, nil
+}
+
+func storeFailedReceipt(s *native.NativeContract, fromChainID uint64, crossChainID []byte, receipt *FailedReceipt) error {
+	blob, err := rlp.EncodeToBytes(receipt)
+	if err != nil {
+		return err
+	}
+	s.GetCacheDB().Put(failedReceiptKey(fromChainID, crossChainID), blob)
+	return nil
+}
+
 // ====================================================================
 //
 // storage keys
@@ -98,3 +267,19 @@ func txIndexKey() []byte {
 func totalAmountKey(chainID uint64) []byte {
 	return utils.ConcatKey(this, []byte(SKP_TOTAL_AMOUNT), utils.Uint64Bytes(chainID))
 }
+
+func relayerFeeKey(crossChainID []byte) []byte {
+	return utils.ConcatKey(this, []byte(SKP_RELAYER_FEE), crossChainID)
+}
+
+func unsettledFeeKey(chainID uint64) []byte {
+	return utils.ConcatKey(this, []byte(SKP_UNSETTLED_FEE), utils.Uint64Bytes(chainID))
+}
+
+func lockRecordKey(crossChainID []byte) []byte {
+	return utils.ConcatKey(this, []byte(SKP_LOCK_RECORD), crossChainID)
+}
+
+func failedReceiptKey(chainID uint64, crossChainID []byte) []byte {
+	return utils.ConcatKey(this, []byte(SKP_FAILED_RECEIPT), utils.Uint64Bytes(chainID), crossChainID)
+}
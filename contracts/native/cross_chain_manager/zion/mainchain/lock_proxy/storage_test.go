@@ -29,6 +29,7 @@ import (
 	"github.com/ethereum/go-ethereum/contracts/native"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -40,6 +41,9 @@ var (
 	testBlockNum         = int64(12)
 	testTxHash           = common.EmptyHash
 	testCaller           = common.EmptyAddress
+
+	// testNativeUpgrades upgrades declared in the chain config of test contract refs
+	testNativeUpgrades = []*params.NativeUpgrade{{Contract: this, Version: VersionRelayerFee, Block: common.Big0}}
 )
 
 func TestMain(m *testing.M) {
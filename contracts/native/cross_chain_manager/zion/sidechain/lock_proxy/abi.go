@@ -77,6 +77,20 @@ func (i *MethodBurnInput) Decode(payload []byte) error {
 	return utils.UnpackMethod(ABI, MethodBurn, i, payload)
 }
 
+//function burnWithFee(uint64 toChainId, uint256 amount, uint256 fee) external returns (bool);
+type MethodBurnWithFeeInput struct {
+	ToChainId uint64
+	Amount    *big.Int
+	Fee       *big.Int
+}
+
+func (i *MethodBurnWithFeeInput) Encode() ([]byte, error) {
+	return utils.PackMethod(ABI, MethodBurnWithFee, i.ToChainId, i.Amount, i.Fee)
+}
+func (i *MethodBurnWithFeeInput) Decode(payload []byte) error {
+	return utils.UnpackMethod(ABI, MethodBurnWithFee, i, payload)
+}
+
 //function mint(bytes calldata argsBs, bytes calldata fromContractAddr, uint64 fromChainId) external returns (bool);
 type MethodMintInput struct {
 	ArgsBs           []byte
@@ -91,6 +105,58 @@ func (i *MethodMintInput) Decode(payload []byte) error {
 	return utils.UnpackMethod(ABI, MethodMint, i, payload)
 }
 
+//function refund(bytes calldata argsBs, bytes calldata fromContractAddr, uint64 fromChainId) external returns (bool);
+type MethodRefundInput struct {
+	ArgsBs           []byte
+	FromContractAddr []byte
+	FromChainId      uint64
+}
+
+func (i *MethodRefundInput) Encode() ([]byte, error) {
+	return utils.PackMethod(ABI, MethodRefund, i.ArgsBs, i.FromContractAddr, i.FromChainId)
+}
+func (i *MethodRefundInput) Decode(payload []byte) error {
+	return utils.UnpackMethod(ABI, MethodRefund, i, payload)
+}
+
+//function settle(bytes calldata argsBs, bytes calldata fromContractAddr, uint64 fromChainId) external returns (bool);
+type MethodSettleInput struct {
+	ArgsBs           []byte
+	FromContractAddr []byte
+	FromChainId      uint64
+}
+
+func (i *MethodSettleInput) Encode() ([]byte, error) {
+	return utils.PackMethod(ABI, MethodSettle, i.ArgsBs, i.FromContractAddr, i.FromChainId)
+}
+func (i *MethodSettleInput) Decode(payload []byte) error {
+	return utils.UnpackMethod(ABI, MethodSettle, i, payload)
+}
+
+//function refundRelayerFee(bytes calldata transferId) external returns (bool);
+type MethodRefundRelayerFeeInput struct {
+	TransferId []byte
+}
+
+func (i *MethodRefundRelayerFeeInput) Encode() ([]byte, error) {
+	return utils.PackMethod(ABI, MethodRefundRelayerFee, i.TransferId)
+}
+func (i *MethodRefundRelayerFeeInput) Decode(payload []byte) error {
+	return utils.UnpackMethod(ABI, MethodRefundRelayerFee, i, payload)
+}
+
+//function getRelayerFee(bytes calldata transferId) external view returns (bytes memory);
+type MethodGetRelayerFeeInput struct {
+	TransferId []byte
+}
+
+func (i *MethodGetRelayerFeeInput) Encode() ([]byte, error) {
+	return utils.PackMethod(ABI, MethodGetRelayerFee, i.TransferId)
+}
+func (i *MethodGetRelayerFeeInput) Decode(payload []byte) error {
+	return utils.UnpackMethod(ABI, MethodGetRelayerFee, i, payload)
+}
+
 //event BurnEvent(address fromAssetHash, address fromAddress, uint64 toChainId, bytes toAssetHash, bytes toAddress, uint256 amount);
 func emitBurnEvent(s *native.NativeContract, fromAsset, fromAddr common.Address, toChainID uint64, toAsset, toAddr []byte, amount *big.Int) error {
 	return s.AddNotify(ABI, []string{EventBurnEvent}, fromAsset, fromAddr, toChainID, toAsset, toAddr, amount)
@@ -100,3 +166,18 @@ func emitBurnEvent(s *native.NativeContract, fromAsset, fromAddr common.Address,
 func emitMintEvent(s *native.NativeContract, toAsset, toAddr common.Address, amount *big.Int) error {
 	return s.AddNotify(ABI, []string{EventMintEvent}, toAsset, toAddr, amount)
 }
+
+//event RelayerFeeEvent(address toAddress, uint256 fee, bool refunded);
+func emitRelayerFeeEvent(s *native.NativeContract, toAddr common.Address, fee *big.Int, refunded bool) error {
+	return s.AddNotify(ABI, []string{EventRelayerFeeEvent}, toAddr, fee, refunded)
+}
+
+//event MintFailedEvent(uint64 fromChainId, bytes transferId, string reason);
+func emitMintFailedEvent(s *native.NativeContract, fromChainID uint64, transferID []byte, reason string) error {
+	return s.AddNotify(ABI, []string{EventMintFailedEvent}, fromChainID, transferID, reason)
+}
+
+//event RefundEvent(address toAddress, uint256 amount);
+func emitRefundEvent(s *native.NativeContract, toAddr common.Address, amount *big.Int) error {
+	return s.AddNotify(ABI, []string{EventRefundEvent}, toAddr, amount)
+}
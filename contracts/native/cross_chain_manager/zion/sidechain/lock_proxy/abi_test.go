@@ -133,6 +133,60 @@ func TestABIMethodMintInput(t *testing.T) {
 	assert.Equal(t, namePayload, id)
 }
 
+func TestABIMethodRefundInput(t *testing.T) {
+	expect := &MethodRefundInput{
+		ArgsBs:           []byte{'a'},
+		FromContractAddr: []byte{'x'},
+		FromChainId:      12,
+	}
+
+	payload, err := expect.Encode()
+	assert.NoError(t, err)
+
+	got := new(MethodRefundInput)
+	assert.NoError(t, got.Decode(payload))
+
+	assert.Equal(t, expect, got)
+
+	// eccm calls refund with the same arguments as mint
+	id := crypto.Keccak256(utils.EncodePacked([]byte("refund"), []byte("(bytes,bytes,uint64)")))[:4]
+	args := abi.Arguments{
+		{Type: zutils.BytesTy, Name: "_argsBs"},
+		{Type: zutils.BytesTy, Name: "_fromContractAddr"},
+		{Type: zutils.Uint64Ty, Name: "_fromChainId"},
+	}
+	data, err := args.Pack(expect.ArgsBs, expect.FromContractAddr, expect.FromChainId)
+	assert.NoError(t, err)
+	assert.Equal(t, payload, utils.EncodePacked(id, data))
+}
+
+func TestABIMethodSettleInput(t *testing.T) {
+	expect := &MethodSettleInput{
+		ArgsBs:           []byte{'a'},
+		FromContractAddr: []byte{'x'},
+		FromChainId:      12,
+	}
+
+	payload, err := expect.Encode()
+	assert.NoError(t, err)
+
+	got := new(MethodSettleInput)
+	assert.NoError(t, got.Decode(payload))
+
+	assert.Equal(t, expect, got)
+
+	// eccm calls settle with the same arguments as mint
+	id := crypto.Keccak256(utils.EncodePacked([]byte("settle"), []byte("(bytes,bytes,uint64)")))[:4]
+	args := abi.Arguments{
+		{Type: zutils.BytesTy, Name: "_argsBs"},
+		{Type: zutils.BytesTy, Name: "_fromContractAddr"},
+		{Type: zutils.Uint64Ty, Name: "_fromChainId"},
+	}
+	data, err := args.Pack(expect.ArgsBs, expect.FromContractAddr, expect.FromChainId)
+	assert.NoError(t, err)
+	assert.Equal(t, payload, utils.EncodePacked(id, data))
+}
+
 func TestEmitBurn(t *testing.T) {
 	resetTestContext()
 	s := testEmptyCtx
@@ -20,32 +20,56 @@ package lock_proxy
 
 import (
 	"fmt"
+	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/contracts/native"
+	scom "github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/common"
 	"github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/zion/delegate"
 	zutils "github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/zion/utils"
 	. "github.com/ethereum/go-ethereum/contracts/native/go_abi/side_chain_lock_proxy_abi"
 	"github.com/ethereum/go-ethereum/contracts/native/utils"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
 )
 
 var (
 	gasTable = map[string]uint64{
-		MethodName:      0,
-		MethodBurn:      10000,
-		MethodMint:      10000,
-		MethodApprove:   10000,
-		MethodAllowance: 0,
+		MethodName:             0,
+		MethodBurn:             10000,
+		MethodBurnWithFee:      10000,
+		MethodMint:             10000,
+		MethodRefund:           10000,
+		MethodSettle:           10000,
+		MethodRefundRelayerFee: 10000,
+		MethodGetRelayerFee:    0,
+		MethodApprove:          10000,
+		MethodAllowance:        0,
 	}
 
 	ccmp = common.HexToAddress("0xc6195336878Fc34B1b5A13895015a97c1aD9cc25")
 )
 
+// lock proxy implementation versions, activated by native contract upgrades of the lock proxy.
+// the main chain lock proxy should be upgraded to the same version first.
+const (
+	// VersionRefund sends the transfers which can not be minted back to the main chain for refund
+	VersionRefund = uint64(1)
+	// VersionRoute burns for other side chains, the main chain routes the transfers into mints
+	VersionRoute = uint64(2)
+	// VersionRelayerFee escrows the relayer fees of the burns and settles the fees escrowed on the main chain
+	VersionRelayerFee = uint64(3)
+)
+
 func InitLockProxy() {
 	InitABI()
 	delegate.InitABI(ABI)
 
-	native.Contracts[this] = RegisterLockProxyContract
+	registry := native.RoleRegistry(params.ZionRoleSide)
+	registry.Register(this, RegisterLockProxyContract)
+	registry.RegisterVersion(this, VersionRefund, RegisterLockProxyContractV1)
+	registry.RegisterVersion(this, VersionRoute, RegisterLockProxyContractV2)
+	registry.RegisterVersion(this, VersionRelayerFee, RegisterLockProxyContractV3)
 }
 
 func RegisterLockProxyContract(s *native.NativeContract) {
@@ -58,52 +82,139 @@ func RegisterLockProxyContract(s *native.NativeContract) {
 	s.Register(MethodAllowance, delegate.Allowance)
 }
 
+// RegisterLockProxyContractV1 registers the lock proxy which takes back the refunds of the main
+// chain, in addition to the methods of the initial implementation.
+func RegisterLockProxyContractV1(s *native.NativeContract) {
+	RegisterLockProxyContract(s)
+	s.Register(MethodRefund, Refund)
+}
+
+// RegisterLockProxyContractV2 registers the lock proxy which burns for other side chains, the
+// methods are the same as the ones of `VersionRefund`.
+func RegisterLockProxyContractV2(s *native.NativeContract) {
+	RegisterLockProxyContractV1(s)
+}
+
+// RegisterLockProxyContractV3 registers the lock proxy which escrows relayer fees, the fees are burned
+// with `burnWithFee` and settled by the main chain or refunded to the payers after the deadline.
+func RegisterLockProxyContractV3(s *native.NativeContract) {
+	RegisterLockProxyContractV2(s)
+	s.Register(MethodBurnWithFee, BurnWithFee)
+	s.Register(MethodSettle, Settle)
+	s.Register(MethodRefundRelayerFee, RefundRelayerFee)
+	s.Register(MethodGetRelayerFee, GetRelayerFee)
+}
+
+// versionEnabled returns whether the lock proxy implementation `version` is active at current block.
+func versionEnabled(s *native.NativeContract, version uint64) (bool, error) {
+	active, err := s.ActiveVersion(this)
+	if err != nil {
+		return false, fmt.Errorf("failed to get lock proxy version, err: %v", err)
+	}
+	return active >= version, nil
+}
+
 func Name(s *native.NativeContract) ([]byte, error) {
 	return new(MethodContractNameOutput).Encode()
 }
 
 func Burn(s *native.NativeContract) ([]byte, error) {
 	ctx := s.ContractRef().CurrentContext()
-	from := s.ContractRef().TxOrigin()
 
 	input := new(MethodBurnInput)
 	if err := input.Decode(ctx.Payload); err != nil {
 		return utils.ByteFailed, fmt.Errorf("LockProxy.Burn, failed to decode params, err: %v", err)
 	}
-	if input.Amount == nil || input.Amount.Cmp(common.Big0) <= 0 {
-		return utils.ByteFailed, fmt.Errorf("LockProxy.Burn, invalid amount")
+	if err := burn(s, input.ToChainId, input.Amount, nil); err != nil {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.Burn, %v", err)
 	}
-	if input.ToChainId != native.ZionMainChainID {
-		return utils.ByteFailed, fmt.Errorf("LockProxy.Burn, dest chain id invalid")
+	return utils.PackOutputs(ABI, MethodBurn, true)
+}
+
+// BurnWithFee burns `amount` plus a relayer `fee`, the fee stays escrowed on the side chain and is
+// minted to the relayer once the main chain settles the transfer delivered before the fee deadline,
+// the payer refunds the fee with `RefundRelayerFee` if the transfer is not settled in time.
+func BurnWithFee(s *native.NativeContract) ([]byte, error) {
+	ctx := s.ContractRef().CurrentContext()
+
+	input := new(MethodBurnWithFeeInput)
+	if err := input.Decode(ctx.Payload); err != nil {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.BurnWithFee, failed to decode params, err: %v", err)
+	}
+	if input.Fee == nil || input.Fee.Sign() <= 0 {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.BurnWithFee, invalid fee")
+	}
+	if err := burn(s, input.ToChainId, input.Amount, input.Fee); err != nil {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.BurnWithFee, %v", err)
+	}
+	return utils.PackOutputs(ABI, MethodBurnWithFee, true)
+}
+
+func burn(s *native.NativeContract, toChainID uint64, amount, fee *big.Int) error {
+	from := s.ContractRef().TxOrigin()
+
+	if amount == nil || amount.Cmp(common.Big0) <= 0 {
+		return fmt.Errorf("invalid amount")
+	}
+	if toChainID == 0 || toChainID == s.ContractRef().ZionConfig().CrossChainID {
+		return fmt.Errorf("dest chain id invalid")
+	}
+
+	// the main chain unlocks the transfer, or routes it into a mint on the target side chain
+	// since `VersionRoute`
+	method := "unlock"
+	if toChainID != s.ContractRef().RelayChainID() {
+		if ok, err := versionEnabled(s, VersionRoute); err != nil {
+			return err
+		} else if !ok {
+			return fmt.Errorf("dest chain id invalid")
+		}
+		method = "mint"
 	}
 
-	amount := input.Amount
 	asset := common.EmptyAddress
 	toAddr := from[:]
-	toChainID := input.ToChainId
 
-	// check and sub balance
-	if err := delegate.SubBalance(s, from, amount); err != nil {
-		return utils.ByteFailed, fmt.Errorf("LockProxy.Burn, failed to sub balance, err: %v", err)
+	// check and sub balance, the relayer fee is burned together with the amount
+	total := amount
+	if fee != nil {
+		total = new(big.Int).Add(amount, fee)
+	}
+	if err := delegate.SubBalance(s, from, total); err != nil {
+		return fmt.Errorf("failed to sub balance, err: %v", err)
 	}
 
-	rawArgs, err := zutils.EncodeTxArgs(asset[:], toAddr, amount)
+	// the relayer fee is recorded with a transfer id, which the main chain settles it with
+	args := &scom.TxArgs{
+		ToAssetHash: asset[:],
+		ToAddress:   toAddr,
+		Amount:      amount,
+	}
+	if fee != nil {
+		args.Fee = fee
+		args.FeeDeadline = s.ContractRef().BlockTime() + zutils.RelayerFeeTimeout
+		args.TransferID = nextTransferID(s)
+		record := &RelayerFee{Payer: from, ToChainID: toChainID, Fee: fee, Deadline: args.FeeDeadline}
+		if err := storeRelayerFee(s, args.TransferID, record); err != nil {
+			return fmt.Errorf("failed to store relayer fee, err: %v", err)
+		}
+	}
+	rawArgs, err := rlp.EncodeToBytes(args)
 	if err != nil {
-		return utils.ByteFailed, fmt.Errorf("LockProxy.Burn, failed to encode txArgs, err: %v", err)
+		return fmt.Errorf("failed to encode txArgs, err: %v", err)
 	}
 	eccm, err := getEthCrossChainManager(s, ccmp)
 	if err != nil {
-		return utils.ByteFailed, fmt.Errorf("LockProxy.Burn, failed to get eccm address, err: %v", err)
+		return fmt.Errorf("failed to get eccm address, err: %v", err)
 	}
-	if err := crossChain(s, eccm, this, toChainID, "unlock", rawArgs); err != nil {
-		return utils.ByteFailed, fmt.Errorf("LockProxy.Burn, failed to ")
+	if err := crossChain(s, eccm, this, toChainID, method, rawArgs); err != nil {
+		return fmt.Errorf("failed to call eccm crossChain, err: %v", err)
 	}
 
 	if err := emitBurnEvent(s, asset, from, toChainID, asset[:], toAddr, amount); err != nil {
-		return utils.ByteFailed, fmt.Errorf("LockProxy.Burn, emit `BurnEvent` failed, err: %v", err)
+		return fmt.Errorf("emit `BurnEvent` failed, err: %v", err)
 	}
-
-	return utils.PackOutputs(ABI, MethodBurn, true)
+	return nil
 }
 
 func Mint(s *native.NativeContract) ([]byte, error) {
@@ -130,26 +241,274 @@ func Mint(s *native.NativeContract) ([]byte, error) {
 	if err != nil {
 		return utils.ByteFailed, fmt.Errorf("LockProxy.Mint, failed to decode args, err: %v", err)
 	}
-	if args == nil || args.ToAssetHash == nil || args.ToAddress == nil || args.Amount == nil {
+	if args == nil {
 		return utils.ByteFailed, fmt.Errorf("LockProxy.Mint, args field invalid")
 	}
 
+	// transfers carrying a transfer id are refunded by the main chain lock proxy if they can not be minted
+	snapshot := s.StateDB().Snapshot()
+	err = mint(s, eccm, input.FromChainId, args)
+	if err == nil {
+		return utils.PackOutputs(ABI, MethodMint, true)
+	}
+	if len(args.TransferID) == 0 || input.FromChainId != s.ContractRef().RelayChainID() {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.Mint, %v", err)
+	}
+	if ok, verr := versionEnabled(s, VersionRefund); verr != nil {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.Mint, %v", verr)
+	} else if !ok {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.Mint, %v", err)
+	}
+	s.StateDB().RevertToSnapshot(snapshot)
+	if err := failMint(s, eccm, input.FromChainId, input.ArgsBs, args.TransferID, err); err != nil {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.Mint, %v", err)
+	}
+	return utils.PackOutputs(ABI, MethodMint, true)
+}
+
+func mint(s *native.NativeContract, eccm common.Address, fromChainID uint64, args *scom.TxArgs) error {
+	if args.ToAssetHash == nil || args.ToAddress == nil || args.Amount == nil {
+		return fmt.Errorf("args field invalid")
+	}
+
 	toAddr := common.BytesToAddress(args.ToAddress)
 	asset := common.EmptyAddress
 	amount := args.Amount
 	if common.BytesToAddress(args.ToAssetHash) != asset {
-		return utils.ByteFailed, fmt.Errorf("LockProxy.Mint, target asset invalid")
+		return fmt.Errorf("target asset invalid")
 	}
 	if amount.Cmp(common.Big0) <= 0 {
-		return utils.ByteFailed, fmt.Errorf("LockProxy.Mint, source amount invalid")
+		return fmt.Errorf("source amount invalid")
 	}
 
 	if err := delegate.AddBalance(s, eccm, toAddr, amount); err != nil {
-		return utils.ByteFailed, fmt.Errorf("LockProxy.Mint, failed to add balance, err: %v", err)
+		return fmt.Errorf("failed to add balance, err: %v", err)
 	}
 
 	if err := emitMintEvent(s, asset, toAddr, amount); err != nil {
-		return utils.ByteFailed, fmt.Errorf("LockProxy.Mint, failed to emit `MintEvent`, err: %v", err)
+		return fmt.Errorf("failed to emit `MintEvent`, err: %v", err)
 	}
-	return utils.PackOutputs(ABI, MethodMint, true)
+
+	// the relayer who delivered the transfer in time earns the relayer fee escrowed on the main chain,
+	// the fee is left for the payer to refund before `VersionRelayerFee`
+	if fromChainID == s.ContractRef().RelayChainID() && zutils.RelayerFeeEarned(args, s.ContractRef().BlockTime()) {
+		if ok, err := versionEnabled(s, VersionRelayerFee); err != nil {
+			return err
+		} else if !ok {
+			return nil
+		}
+		txData, err := zutils.EncodeSettleArgs(s.ContractRef().TxOrigin(), args.Fee, args.TransferID)
+		if err != nil {
+			return fmt.Errorf("failed to encode settle args, err: %v", err)
+		}
+		if err := crossChain(s, eccm, this, fromChainID, "settle", txData); err != nil {
+			return fmt.Errorf("failed to call eccm crossChain, err: %v", err)
+		}
+	}
+	return nil
+}
+
+// failMint records the failed receipt of a transfer which can not be minted, and sends the transfer
+// back to the main chain lock proxy which refunds the owner of the lock.
+func failMint(s *native.NativeContract, eccm common.Address, fromChainID uint64, argsBs, transferID []byte, reason error) error {
+	key := failedMintKey(transferID)
+	if blob, err := s.GetCacheDB().Get(key); err != nil {
+		return fmt.Errorf("failed to get failed receipt, err: %v", err)
+	} else if blob != nil {
+		return fmt.Errorf("transfer %x already failed", transferID)
+	}
+	s.GetCacheDB().Put(key, []byte(reason.Error()))
+
+	if err := crossChain(s, eccm, this, fromChainID, "refund", argsBs); err != nil {
+		return fmt.Errorf("failed to call eccm crossChain, err: %v", err)
+	}
+	if err := emitMintFailedEvent(s, fromChainID, transferID, reason.Error()); err != nil {
+		return fmt.Errorf("failed to emit `MintFailedEvent`, err: %v", err)
+	}
+	return nil
+}
+
+// Refund re-mints a burned transfer which the main chain failed to execute, the main chain lock proxy
+// sends it back through eccm just like a `mint`.
+func Refund(s *native.NativeContract) ([]byte, error) {
+	ctx := s.ContractRef().CurrentContext()
+	caller := ctx.Caller
+
+	input := new(MethodRefundInput)
+	if err := input.Decode(ctx.Payload); err != nil {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.Refund, failed to decode params, err: %v", err)
+	}
+	if input.ArgsBs == nil || input.FromContractAddr == nil || input.FromChainId == 0 {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.Refund, invalid params")
+	}
+	if input.FromChainId != s.ContractRef().RelayChainID() || common.BytesToAddress(input.FromContractAddr) != this {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.Refund, refund is not sent by main chain lock proxy")
+	}
+
+	eccm, err := getEthCrossChainManager(s, ccmp)
+	if err != nil {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.Refund, failed to get eccm contract address, err: %v", err)
+	}
+	if caller != eccm {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.Refund, DANGER! caller is not eccm!")
+	}
+
+	args, err := zutils.DecodeTxArgs(input.ArgsBs)
+	if err != nil {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.Refund, failed to decode args, err: %v", err)
+	}
+	if args.ToAddress == nil || args.Amount == nil || args.Amount.Cmp(common.Big0) <= 0 {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.Refund, args field invalid")
+	}
+
+	// burn sends the transfer to the burner itself
+	owner := common.BytesToAddress(args.ToAddress)
+	if err := delegate.AddBalance(s, eccm, owner, args.Amount); err != nil {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.Refund, failed to add balance, err: %v", err)
+	}
+	if err := emitRefundEvent(s, owner, args.Amount); err != nil {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.Refund, failed to emit `RefundEvent`, err: %v", err)
+	}
+
+	// the transfer was never delivered, refund the unsettled relayer fee burned with it as well
+	if len(args.TransferID) > 0 {
+		if fee, err := getRelayerFee(s, args.TransferID); err != nil {
+			return utils.ByteFailed, fmt.Errorf("LockProxy.Refund, failed to get relayer fee, err: %v", err)
+		} else if fee != nil && !fee.Settled {
+			if err := delegate.AddBalance(s, eccm, fee.Payer, fee.Fee); err != nil {
+				return utils.ByteFailed, fmt.Errorf("LockProxy.Refund, failed to add relayer fee balance, err: %v", err)
+			}
+			if err := settleRelayerFee(s, args.TransferID, fee, fee.Payer, true); err != nil {
+				return utils.ByteFailed, fmt.Errorf("LockProxy.Refund, %v", err)
+			}
+		}
+	}
+	return utils.PackOutputs(ABI, MethodRefund, true)
+}
+
+// Settle mints the relayer fee escrowed by a `burnWithFee` transfer to the relayer, the main chain
+// lock proxy settles the transfer through eccm once it is executed before the fee deadline. The fee
+// is paid only once, the settlement of a fee which is already refunded to the payer is ignored.
+func Settle(s *native.NativeContract) ([]byte, error) {
+	ctx := s.ContractRef().CurrentContext()
+	caller := ctx.Caller
+
+	input := new(MethodSettleInput)
+	if err := input.Decode(ctx.Payload); err != nil {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.Settle, failed to decode params, err: %v", err)
+	}
+	if input.ArgsBs == nil || input.FromContractAddr == nil || input.FromChainId == 0 {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.Settle, invalid params")
+	}
+	if input.FromChainId != s.ContractRef().RelayChainID() || common.BytesToAddress(input.FromContractAddr) != this {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.Settle, settlement is not sent by main chain lock proxy")
+	}
+
+	eccm, err := getEthCrossChainManager(s, ccmp)
+	if err != nil {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.Settle, failed to get eccm contract address, err: %v", err)
+	}
+	if caller != eccm {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.Settle, DANGER! caller is not eccm!")
+	}
+
+	args, err := zutils.DecodeTxArgs(input.ArgsBs)
+	if err != nil {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.Settle, failed to decode args, err: %v", err)
+	}
+	if len(args.TransferID) == 0 || args.ToAddress == nil {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.Settle, args field invalid")
+	}
+	relayer := common.BytesToAddress(args.ToAddress)
+	if relayer == common.EmptyAddress {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.Settle, relayer address is invalid")
+	}
+
+	fee, err := getRelayerFee(s, args.TransferID)
+	if err != nil {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.Settle, failed to get relayer fee, err: %v", err)
+	}
+	if fee == nil {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.Settle, relayer fee of %x not exist", args.TransferID)
+	}
+	if fee.Settled {
+		return utils.PackOutputs(ABI, MethodSettle, true)
+	}
+	if err := delegate.AddBalance(s, eccm, relayer, fee.Fee); err != nil {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.Settle, failed to add relayer fee balance, err: %v", err)
+	}
+	if err := settleRelayerFee(s, args.TransferID, fee, relayer, false); err != nil {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.Settle, %v", err)
+	}
+	return utils.PackOutputs(ABI, MethodSettle, true)
+}
+
+// RefundRelayerFee refunds the relayer fee of a `burnWithFee` transfer to the payer, if the main chain
+// did not settle it before the fee deadline plus the refund delay.
+func RefundRelayerFee(s *native.NativeContract) ([]byte, error) {
+	ctx := s.ContractRef().CurrentContext()
+
+	input := new(MethodRefundRelayerFeeInput)
+	if err := input.Decode(ctx.Payload); err != nil {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.RefundRelayerFee, failed to decode params, err: %v", err)
+	}
+
+	fee, err := getRelayerFee(s, input.TransferId)
+	if err != nil {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.RefundRelayerFee, failed to get relayer fee, err: %v", err)
+	}
+	if fee == nil {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.RefundRelayerFee, relayer fee of %x not exist", input.TransferId)
+	}
+	if fee.Payer != s.ContractRef().TxOrigin() {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.RefundRelayerFee, only payer %s is able to refund", fee.Payer.Hex())
+	}
+	if fee.Settled {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.RefundRelayerFee, relayer fee of %x already settled", input.TransferId)
+	}
+	if !zutils.RelayerFeeRefundable(fee.Deadline, s.ContractRef().BlockTime()) {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.RefundRelayerFee, relayer fee is not refundable before %d", fee.Deadline+zutils.RelayerFeeRefundDelay)
+	}
+	if err := delegate.RefundBalance(s, fee.Payer, fee.Fee); err != nil {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.RefundRelayerFee, failed to add balance, err: %v", err)
+	}
+	if err := settleRelayerFee(s, input.TransferId, fee, fee.Payer, true); err != nil {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.RefundRelayerFee, %v", err)
+	}
+	return utils.PackOutputs(ABI, MethodRefundRelayerFee, true)
+}
+
+// GetRelayerFee returns the rlp encoded relayer fee escrowed by a `burnWithFee` transfer.
+func GetRelayerFee(s *native.NativeContract) ([]byte, error) {
+	ctx := s.ContractRef().CurrentContext()
+
+	input := new(MethodGetRelayerFeeInput)
+	if err := input.Decode(ctx.Payload); err != nil {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.GetRelayerFee, failed to decode params, err: %v", err)
+	}
+
+	fee, err := getRelayerFee(s, input.TransferId)
+	if err != nil {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.GetRelayerFee, failed to get relayer fee, err: %v", err)
+	}
+	if fee == nil {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.GetRelayerFee, relayer fee of %x not exist", input.TransferId)
+	}
+	enc, err := rlp.EncodeToBytes(fee)
+	if err != nil {
+		return utils.ByteFailed, fmt.Errorf("LockProxy.GetRelayerFee, failed to encode relayer fee, err: %v", err)
+	}
+	return utils.PackOutputs(ABI, MethodGetRelayerFee, enc)
+}
+
+// settleRelayerFee marks the relayer fee of transfer `transferID` settled after it was paid to `payee`.
+func settleRelayerFee(s *native.NativeContract, transferID []byte, fee *RelayerFee, payee common.Address, refunded bool) error {
+	fee.Settled = true
+	if err := storeRelayerFee(s, transferID, fee); err != nil {
+		return fmt.Errorf("failed to store relayer fee, err: %v", err)
+	}
+	if err := emitRelayerFeeEvent(s, payee, fee.Fee, refunded); err != nil {
+		return fmt.Errorf("failed to emit `RelayerFeeEvent`, err: %v", err)
+	}
+	return nil
 }
@@ -0,0 +1,95 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package lock_proxy
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/contracts/native"
+	scom "github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/common"
+	zutils "github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/zion/utils"
+	"github.com/ethereum/go-ethereum/contracts/native/utils"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+const (
+	SKP_TX_INDEX    = "st_tx_index"
+	SKP_RELAYER_FEE = "st_relayer_fee"
+)
+
+// nextTransferID generates the id of the next transfer burned with a relayer fee, the side chain id
+// is part of the id so that it never collides with the transfer ids of the main chain.
+func nextTransferID(s *native.NativeContract) []byte {
+	txIndex := new(big.Int).Add(getTxIndex(s), common.Big1)
+	s.GetCacheDB().Put(txIndexKey(), scom.Uint256ToBytes(txIndex))
+	chainID := utils.Uint64Bytes(s.ContractRef().ZionConfig().CrossChainID)
+	return zutils.GenerateCrossChainID(this, utils.EncodePacked(chainID, scom.Uint256ToBytes(txIndex)))
+}
+
+func getTxIndex(s *native.NativeContract) *big.Int {
+	blob, _ := s.GetCacheDB().Get(txIndexKey())
+	if blob == nil {
+		return common.Big0
+	}
+	return new(big.Int).SetBytes(blob)
+}
+
+// RelayerFee records the relayer fee escrowed by a `burnWithFee` transfer, it shares the layout of the
+// main chain lock proxy record. The fee is settled once, either minted to the relayer when the main
+// chain acknowledges the delivery in time, or refunded to the payer.
+type RelayerFee struct {
+	Payer     common.Address
+	ToChainID uint64
+	Fee       *big.Int
+	Deadline  uint64
+	Settled   bool `rlp:"optional"`
+}
+
+func getRelayerFee(s *native.NativeContract, transferID []byte) (*RelayerFee, error) {
+	blob, err := s.GetCacheDB().Get(relayerFeeKey(transferID))
+	if err != nil {
+		return nil, err
+	}
+	if blob == nil {
+		return nil, nil
+	}
+	fee := new(RelayerFee)
+	if err := rlp.DecodeBytes(blob, fee); err != nil {
+		return nil, err
+	}
+	return fee, nil
+}
+
+func storeRelayerFee(s *native.NativeContract, transferID []byte, fee *RelayerFee) error {
+	blob, err := rlp.EncodeToBytes(fee)
+	if err != nil {
+		return err
+	}
+	s.GetCacheDB().Put(relayerFeeKey(transferID), blob)
+	return nil
+}
+
+func txIndexKey() []byte {
+	return utils.ConcatKey(this, []byte(SKP_TX_INDEX))
+}
+
+func relayerFeeKey(transferID []byte) []byte {
+	return utils.ConcatKey(this, []byte(SKP_RELAYER_FEE), transferID)
+}
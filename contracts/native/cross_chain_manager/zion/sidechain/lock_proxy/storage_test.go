@@ -0,0 +1,144 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package lock_proxy
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/contracts/native"
+	zutils "github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/zion/utils"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextTransferID(t *testing.T) {
+	resetTestContext()
+	s := testEmptyCtx
+
+	first := nextTransferID(s)
+	second := nextTransferID(s)
+	assert.NotEqual(t, first, second)
+	assert.Equal(t, big.NewInt(2), getTxIndex(s))
+}
+
+func TestRefundRelayerFee(t *testing.T) {
+	resetTestContext()
+	payer := common.HexToAddress("0x12")
+	fee := big.NewInt(10)
+	deadline := uint64(1650000000)
+	refundTime := deadline + zutils.RelayerFeeRefundDelay + 1
+
+	transferID := nextTransferID(testEmptyCtx)
+	record := &RelayerFee{Payer: payer, ToChainID: params.LegacyRelayChainID, Fee: fee, Deadline: deadline}
+	assert.NoError(t, storeRelayerFee(testEmptyCtx, transferID, record))
+
+	// only the payer refunds, after the deadline and the refund delay
+	assert.Error(t, testRefundRelayerFee(payer, transferID, refundTime-1))
+	assert.Error(t, testRefundRelayerFee(common.HexToAddress("0x13"), transferID, refundTime))
+	assert.NoError(t, testRefundRelayerFee(payer, transferID, refundTime))
+	assert.Equal(t, fee, testStateDB.GetBalance(payer))
+
+	got, err := testGetRelayerFee(transferID)
+	assert.NoError(t, err)
+	assert.True(t, got.Settled)
+
+	// the fee is refunded only once
+	assert.Error(t, testRefundRelayerFee(payer, transferID, refundTime))
+	assert.Equal(t, fee, testStateDB.GetBalance(payer))
+}
+
+func TestRelayerFeeBeforeFeeVersion(t *testing.T) {
+	resetTestContext()
+	payer := common.HexToAddress("0x14")
+	transferID := nextTransferID(testEmptyCtx)
+	record := &RelayerFee{Payer: payer, ToChainID: params.LegacyRelayChainID, Fee: big.NewInt(10), Deadline: 1650000000}
+	assert.NoError(t, storeRelayerFee(testEmptyCtx, transferID, record))
+
+	zion := &params.ZionConfig{Role: params.ZionRoleSide, CrossChainID: 1001, RelayChainID: params.LegacyRelayChainID}
+	nativeCall := func(upgrades []*params.NativeUpgrade, input interface{ Encode() ([]byte, error) }) error {
+		payload, err := input.Encode()
+		if err != nil {
+			return err
+		}
+		ref := native.NewContractRef(testStateDB, payer, payer, big.NewInt(testBlockNum), testTxHash, testSupplyGas, nil)
+		ref.SetZionConfig(zion)
+		ref.SetNativeUpgrades(upgrades)
+		_, _, err = ref.NativeCall(payer, this, payload)
+		return err
+	}
+
+	// the relayer fee methods are not registered before `VersionRelayerFee`
+	upgrades := []*params.NativeUpgrade{{Contract: this, Version: VersionRoute, Block: common.Big0}}
+	inputs := []interface{ Encode() ([]byte, error) }{
+		&MethodBurnWithFeeInput{ToChainId: params.LegacyRelayChainID, Amount: big.NewInt(100), Fee: big.NewInt(10)},
+		&MethodSettleInput{ArgsBs: []byte{1}, FromContractAddr: this[:], FromChainId: params.LegacyRelayChainID},
+		&MethodRefundRelayerFeeInput{TransferId: transferID},
+		&MethodGetRelayerFeeInput{TransferId: transferID},
+	}
+	for _, input := range inputs {
+		err := nativeCall(upgrades, input)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "failed to find method")
+		}
+	}
+
+	upgrades = []*params.NativeUpgrade{{Contract: this, Version: VersionRelayerFee, Block: common.Big0}}
+	assert.NoError(t, nativeCall(upgrades, &MethodGetRelayerFeeInput{TransferId: transferID}))
+}
+
+func testRefundRelayerFee(sender common.Address, transferID []byte, blockTime uint64) error {
+	input := &MethodRefundRelayerFeeInput{TransferId: transferID}
+	payload, err := input.Encode()
+	if err != nil {
+		return err
+	}
+	ref := native.NewContractRef(testStateDB, sender, sender, big.NewInt(testBlockNum), testTxHash, testSupplyGas, nil)
+	ref.PushContext(&native.Context{Caller: sender, ContractAddress: this, Payload: payload})
+	ref.SetTo(this)
+	ref.SetBlockTime(blockTime)
+	_, err = RefundRelayerFee(native.NewNativeContract(testStateDB, ref))
+	return err
+}
+
+func testGetRelayerFee(transferID []byte) (*RelayerFee, error) {
+	input := &MethodGetRelayerFeeInput{TransferId: transferID}
+	payload, err := input.Encode()
+	if err != nil {
+		return nil, err
+	}
+	ref := generateContractRef()
+	ref.PushContext(&native.Context{Caller: testCaller, ContractAddress: this, Payload: payload})
+
+	enc, err := GetRelayerFee(native.NewNativeContract(testStateDB, ref))
+	if err != nil {
+		return nil, err
+	}
+	output, err := ABI.Unpack("getRelayerFee", enc)
+	if err != nil {
+		return nil, err
+	}
+	fee := new(RelayerFee)
+	if err := rlp.DecodeBytes(output[0].([]byte), fee); err != nil {
+		return nil, err
+	}
+	return fee, nil
+}
@@ -38,6 +38,13 @@ var (
 	}
 )
 
+const SKP_FAILED_MINT = "st_failed_mint"
+
+// failedMintKey is the storage key of the failed receipt of a transfer which can not be minted.
+func failedMintKey(transferID []byte) []byte {
+	return utils.ConcatKey(this, []byte(SKP_FAILED_MINT), transferID)
+}
+
 func getEthCrossChainManager(s *native.NativeContract, ccmp common.Address) (common.Address, error) {
 	gas := s.ContractRef().GasLeft()
 	payload := midGetEthCrossChainManager
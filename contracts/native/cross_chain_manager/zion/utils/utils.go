@@ -63,6 +63,39 @@ func EncodeTxArgs(toAssetHash, toAddress []byte, amount *big.Int) ([]byte, error
 	return rlp.EncodeToBytes(args)
 }
 
+// RelayerFeeTimeout is the time in seconds a relayer has to deliver a fee-bearing transfer
+// in order to earn the relayer fee.
+const RelayerFeeTimeout uint64 = 24 * 3600
+
+// RelayerFeeRefundDelay is the time in seconds after the fee deadline before the payer is able to
+// refund an unsettled relayer fee, which leaves the relayer time to carry the settlement of a transfer
+// delivered in time back to the source chain.
+const RelayerFeeRefundDelay uint64 = 24 * 3600
+
+// EncodeSettleArgs encodes the settlement of the relayer fee escrowed on the source chain by transfer
+// `transferID`, which pays the `fee` to the `relayer` who delivered the transfer in time.
+func EncodeSettleArgs(relayer common.Address, fee *big.Int, transferID []byte) ([]byte, error) {
+	args := &scom.TxArgs{
+		ToAssetHash: common.EmptyAddress.Bytes(),
+		ToAddress:   relayer.Bytes(),
+		Amount:      fee,
+		TransferID:  transferID,
+	}
+	return rlp.EncodeToBytes(args)
+}
+
+// RelayerFeeEarned returns true if the transfer carries a relayer fee and is delivered before the fee
+// deadline at `now`, the target chain settles the fee with the source chain then.
+func RelayerFeeEarned(args *scom.TxArgs, now uint64) bool {
+	return args.HasFee() && len(args.TransferID) > 0 && now <= args.FeeDeadline
+}
+
+// RelayerFeeRefundable returns true if the payer is able to refund the unsettled relayer fee of
+// `deadline` at `now`.
+func RelayerFeeRefundable(deadline, now uint64) bool {
+	return now > deadline+RelayerFeeRefundDelay
+}
+
 func DecodeTxArgs(payload []byte) (*scom.TxArgs, error) {
 	args := new(scom.TxArgs)
 	if err := rlp.DecodeBytes(payload, args); err != nil {
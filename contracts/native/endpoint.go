@@ -23,6 +23,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
 )
 
 // support native functions to evm functions.
@@ -40,8 +41,14 @@ type ContractRef struct {
 	gasLeft     uint64
 	value       *big.Int
 	txTo        common.Address
+	blockTime   uint64
+	upgrades    []*params.NativeUpgrade
+	zion        *params.ZionConfig
 }
 
+// defaultZionConfig chain role of contract refs which not bound to an chain config
+var defaultZionConfig = (*params.ChainConfig)(nil).ZionConfig()
+
 func NewContractRef(
 	db *state.StateDB,
 	origin common.Address,
@@ -105,6 +112,45 @@ func (s *ContractRef) Value() *big.Int {
 	return s.value
 }
 
+// SetNativeUpgrades bind the native contract upgrades declared in chain config.
+func (s *ContractRef) SetNativeUpgrades(upgrades []*params.NativeUpgrade) {
+	s.upgrades = upgrades
+}
+
+// NativeUpgrades retrieve the native contract upgrades declared in chain config.
+func (s *ContractRef) NativeUpgrades() []*params.NativeUpgrade {
+	return s.upgrades
+}
+
+// SetZionConfig bind the zion chain role declared in chain config, native calls are
+// dispatched to the contracts of the role.
+func (s *ContractRef) SetZionConfig(config *params.ZionConfig) {
+	s.zion = config
+}
+
+// ZionConfig retrieve the zion chain role which native contracts running on.
+func (s *ContractRef) ZionConfig() *params.ZionConfig {
+	if s.zion == nil {
+		return defaultZionConfig
+	}
+	return s.zion
+}
+
+// Registry retrieve the native contracts registry of the chain role.
+func (s *ContractRef) Registry() *Registry {
+	return RoleRegistry(s.ZionConfig().Role)
+}
+
+// RelayChainID returns the cross chain id of zion relay chain.
+func (s *ContractRef) RelayChainID() uint64 {
+	return s.ZionConfig().RelayChainID
+}
+
+// IsMainChain returns whether the cross chain id denotes zion relay chain.
+func (s *ContractRef) IsMainChain(chainID uint64) bool {
+	return chainID == s.ZionConfig().RelayChainID
+}
+
 func (s *ContractRef) SetTo(to common.Address) {
 	if to != common.EmptyAddress {
 		s.txTo = to
@@ -116,6 +162,15 @@ func (s *ContractRef) TxTo() common.Address {
 	return s.txTo
 }
 
+func (s *ContractRef) SetBlockTime(time uint64) {
+	s.blockTime = time
+}
+
+// BlockTime retrieve the timestamp of current block
+func (s *ContractRef) BlockTime() uint64 {
+	return s.blockTime
+}
+
 func (s *ContractRef) StateDB() *state.StateDB {
 	return s.stateDB
 }
@@ -33,21 +33,48 @@ var (
 
 	MethodWhiteChain = "WhiteChain"
 
+	MethodGetGuardian = "getGuardian"
+
+	MethodGetQueuedTransfer = "getQueuedTransfer"
+
+	MethodGetRateLimit = "getRateLimit"
+
+	MethodGetVoteProgress = "getVoteProgress"
+
 	MethodImportOuterTransfer = "importOuterTransfer"
 
 	MethodName = "name"
+
+	MethodPauseRoute = "pauseRoute"
+
+	MethodReleaseTransfer = "releaseTransfer"
+
+	MethodSetGuardian = "setGuardian"
+
+	MethodSetRateLimit = "setRateLimit"
+
+	MethodUnpauseRoute = "unpauseRoute"
 )
 
 // CrossChainManagerABI is the input ABI used to generate the binding from.
-const CrossChainManagerABI = "[{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"TxHash\",\"type\":\"bytes\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"MultiSign\",\"type\":\"bytes\"}],\"name\":\"btcTxMultiSignEvent\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"FromChainID\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"ChainID\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"buf\",\"type\":\"string\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"FromTxHash\",\"type\":\"string\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"RedeemKey\",\"type\":\"string\"}],\"name\":\"btcTxToRelayEvent\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"string\",\"name\":\"rk\",\"type\":\"string\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"buf\",\"type\":\"string\"},{\"indexed\":false,\"internalType\":\"uint64[]\",\"name\":\"amts\",\"type\":\"uint64[]\"}],\"name\":\"makeBtcTxEvent\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"string\",\"name\":\"merkleValueHex\",\"type\":\"string\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"BlockHeight\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"key\",\"type\":\"string\"}],\"name\":\"makeProof\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"ChainID\",\"type\":\"uint64\"}],\"name\":\"BlackChain\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"success\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"ChainID\",\"type\":\"uint64\"},{\"internalType\":\"string\",\"name\":\"RedeemKey\",\"type\":\"string\"},{\"internalType\":\"bytes\",\"name\":\"TxHash\",\"type\":\"bytes\"},{\"internalType\":\"string\",\"name\":\"Address\",\"type\":\"string\"},{\"internalType\":\"bytes[]\",\"name\":\"Signs\",\"type\":\"bytes[]\"}],\"name\":\"MultiSign\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"success\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"ChainID\",\"type\":\"uint64\"}],\"name\":\"WhiteChain\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"success\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"SourceChainID\",\"type\":\"uint64\"},{\"internalType\":\"uint32\",\"name\":\"Height\",\"type\":\"uint32\"},{\"internalType\":\"bytes\",\"name\":\"Proof\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"RelayerAddress\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"Extra\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"HeaderOrCrossChainMsg\",\"type\":\"bytes\"}],\"name\":\"importOuterTransfer\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"success\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"name\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"Name\",\"type\":\"string\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]"
+const CrossChainManagerABI = "[{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"TxHash\",\"type\":\"bytes\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"MultiSign\",\"type\":\"bytes\"}],\"name\":\"btcTxMultiSignEvent\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"FromChainID\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"ChainID\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"buf\",\"type\":\"string\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"FromTxHash\",\"type\":\"string\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"RedeemKey\",\"type\":\"string\"}],\"name\":\"btcTxToRelayEvent\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"SrcChainID\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"DstChainID\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"Asset\",\"type\":\"bytes\"}],\"name\":\"evtPauseRoute\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"SourceChainID\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"CrossChainID\",\"type\":\"bytes\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"ReleaseTime\",\"type\":\"uint64\"}],\"name\":\"evtQueueTransfer\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"SourceChainID\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"CrossChainID\",\"type\":\"bytes\"}],\"name\":\"evtReleaseTransfer\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"address\",\"name\":\"Guardian\",\"type\":\"address\"}],\"name\":\"evtSetGuardian\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"SrcChainID\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"DstChainID\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"Asset\",\"type\":\"bytes\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"Window\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"Limit\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"Delay\",\"type\":\"uint64\"}],\"name\":\"evtSetRateLimit\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"SrcChainID\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"DstChainID\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"Asset\",\"type\":\"bytes\"}],\"name\":\"evtUnpauseRoute\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"string\",\"name\":\"rk\",\"type\":\"string\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"buf\",\"type\":\"string\"},{\"indexed\":false,\"internalType\":\"uint64[]\",\"name\":\"amts\",\"type\":\"uint64[]\"}],\"name\":\"makeBtcTxEvent\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"string\",\"name\":\"merkleValueHex\",\"type\":\"string\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"BlockHeight\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"key\",\"type\":\"string\"}],\"name\":\"makeProof\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"ChainID\",\"type\":\"uint64\"}],\"name\":\"BlackChain\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"success\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"ChainID\",\"type\":\"uint64\"},{\"internalType\":\"string\",\"name\":\"RedeemKey\",\"type\":\"string\"},{\"internalType\":\"bytes\",\"name\":\"TxHash\",\"type\":\"bytes\"},{\"internalType\":\"string\",\"name\":\"Address\",\"type\":\"string\"},{\"internalType\":\"bytes[]\",\"name\":\"Signs\",\"type\":\"bytes[]\"}],\"name\":\"MultiSign\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"success\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"ChainID\",\"type\":\"uint64\"}],\"name\":\"WhiteChain\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"success\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getGuardian\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"Guardian\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"SourceChainID\",\"type\":\"uint64\"},{\"internalType\":\"bytes\",\"name\":\"CrossChainID\",\"type\":\"bytes\"}],\"name\":\"getQueuedTransfer\",\"outputs\":[{\"internalType\":\"bytes\",\"name\":\"QueuedTransfer\",\"type\":\"bytes\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"SrcChainID\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"DstChainID\",\"type\":\"uint64\"},{\"internalType\":\"bytes\",\"name\":\"Asset\",\"type\":\"bytes\"}],\"name\":\"getRateLimit\",\"outputs\":[{\"internalType\":\"bytes\",\"name\":\"RateLimit\",\"type\":\"bytes\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"SourceChainID\",\"type\":\"uint64\"},{\"internalType\":\"uint32\",\"name\":\"Height\",\"type\":\"uint32\"},{\"internalType\":\"bytes\",\"name\":\"Extra\",\"type\":\"bytes\"}],\"name\":\"getVoteProgress\",\"outputs\":[{\"internalType\":\"bytes\",\"name\":\"VoteProgress\",\"type\":\"bytes\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"SourceChainID\",\"type\":\"uint64\"},{\"internalType\":\"uint32\",\"name\":\"Height\",\"type\":\"uint32\"},{\"internalType\":\"bytes\",\"name\":\"Proof\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"RelayerAddress\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"Extra\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"HeaderOrCrossChainMsg\",\"type\":\"bytes\"}],\"name\":\"importOuterTransfer\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"success\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"name\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"Name\",\"type\":\"string\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"SrcChainID\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"DstChainID\",\"type\":\"uint64\"},{\"internalType\":\"bytes\",\"name\":\"Asset\",\"type\":\"bytes\"}],\"name\":\"pauseRoute\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"success\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"SourceChainID\",\"type\":\"uint64\"},{\"internalType\":\"bytes\",\"name\":\"CrossChainID\",\"type\":\"bytes\"}],\"name\":\"releaseTransfer\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"success\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"Guardian\",\"type\":\"address\"}],\"name\":\"setGuardian\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"success\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"SrcChainID\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"DstChainID\",\"type\":\"uint64\"},{\"internalType\":\"bytes\",\"name\":\"Asset\",\"type\":\"bytes\"},{\"internalType\":\"uint64\",\"name\":\"Window\",\"type\":\"uint64\"},{\"internalType\":\"uint256\",\"name\":\"Limit\",\"type\":\"uint256\"},{\"internalType\":\"uint64\",\"name\":\"Delay\",\"type\":\"uint64\"}],\"name\":\"setRateLimit\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"success\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"SrcChainID\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"DstChainID\",\"type\":\"uint64\"},{\"internalType\":\"bytes\",\"name\":\"Asset\",\"type\":\"bytes\"}],\"name\":\"unpauseRoute\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"success\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]"
 
 // CrossChainManagerFuncSigs maps the 4-byte function signature to its string representation.
 var CrossChainManagerFuncSigs = map[string]string{
 	"8a449f03": "BlackChain(uint64)",
 	"48c79d9d": "MultiSign(uint64,string,bytes,string,bytes[])",
 	"99d0e87a": "WhiteChain(uint64)",
+	"a75b87d2": "getGuardian()",
+	"d03c1c9c": "getQueuedTransfer(uint64,bytes)",
+	"e20a9e76": "getRateLimit(uint64,uint64,bytes)",
+	"c23879ab": "getVoteProgress(uint64,uint32,bytes)",
 	"5b60b01e": "importOuterTransfer(uint64,uint32,bytes,bytes,bytes,bytes)",
 	"06fdde03": "name()",
+	"177e4d3e": "pauseRoute(uint64,uint64,bytes)",
+	"2c69ec3e": "releaseTransfer(uint64,bytes)",
+	"8a0dac4a": "setGuardian(address)",
+	"d607a91a": "setRateLimit(uint64,uint64,bytes,uint64,uint256,uint64)",
+	"07ba98e6": "unpauseRoute(uint64,uint64,bytes)",
 }
 
 // CrossChainManagerBin is the compiled bytecode used for deploying new contracts.
@@ -209,6 +236,130 @@ func (_CrossChainManager *CrossChainManagerTransactorRaw) Transact(opts *bind.Tr
 	return _CrossChainManager.Contract.contract.Transact(opts, method, params...)
 }
 
+// GetGuardian is a free data retrieval call binding the contract method 0xa75b87d2.
+//
+// Solidity: function getGuardian() view returns(address Guardian)
+func (_CrossChainManager *CrossChainManagerCaller) GetGuardian(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _CrossChainManager.contract.Call(opts, &out, "getGuardian")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// GetGuardian is a free data retrieval call binding the contract method 0xa75b87d2.
+//
+// Solidity: function getGuardian() view returns(address Guardian)
+func (_CrossChainManager *CrossChainManagerSession) GetGuardian() (common.Address, error) {
+	return _CrossChainManager.Contract.GetGuardian(&_CrossChainManager.CallOpts)
+}
+
+// GetGuardian is a free data retrieval call binding the contract method 0xa75b87d2.
+//
+// Solidity: function getGuardian() view returns(address Guardian)
+func (_CrossChainManager *CrossChainManagerCallerSession) GetGuardian() (common.Address, error) {
+	return _CrossChainManager.Contract.GetGuardian(&_CrossChainManager.CallOpts)
+}
+
+// GetQueuedTransfer is a free data retrieval call binding the contract method 0xd03c1c9c.
+//
+// Solidity: function getQueuedTransfer(uint64 SourceChainID, bytes CrossChainID) view returns(bytes QueuedTransfer)
+func (_CrossChainManager *CrossChainManagerCaller) GetQueuedTransfer(opts *bind.CallOpts, SourceChainID uint64, CrossChainID []byte) ([]byte, error) {
+	var out []interface{}
+	err := _CrossChainManager.contract.Call(opts, &out, "getQueuedTransfer", SourceChainID, CrossChainID)
+
+	if err != nil {
+		return *new([]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]byte)).(*[]byte)
+
+	return out0, err
+
+}
+
+// GetQueuedTransfer is a free data retrieval call binding the contract method 0xd03c1c9c.
+//
+// Solidity: function getQueuedTransfer(uint64 SourceChainID, bytes CrossChainID) view returns(bytes QueuedTransfer)
+func (_CrossChainManager *CrossChainManagerSession) GetQueuedTransfer(SourceChainID uint64, CrossChainID []byte) ([]byte, error) {
+	return _CrossChainManager.Contract.GetQueuedTransfer(&_CrossChainManager.CallOpts, SourceChainID, CrossChainID)
+}
+
+// GetQueuedTransfer is a free data retrieval call binding the contract method 0xd03c1c9c.
+//
+// Solidity: function getQueuedTransfer(uint64 SourceChainID, bytes CrossChainID) view returns(bytes QueuedTransfer)
+func (_CrossChainManager *CrossChainManagerCallerSession) GetQueuedTransfer(SourceChainID uint64, CrossChainID []byte) ([]byte, error) {
+	return _CrossChainManager.Contract.GetQueuedTransfer(&_CrossChainManager.CallOpts, SourceChainID, CrossChainID)
+}
+
+// GetRateLimit is a free data retrieval call binding the contract method 0xe20a9e76.
+//
+// Solidity: function getRateLimit(uint64 SrcChainID, uint64 DstChainID, bytes Asset) view returns(bytes RateLimit)
+func (_CrossChainManager *CrossChainManagerCaller) GetRateLimit(opts *bind.CallOpts, SrcChainID uint64, DstChainID uint64, Asset []byte) ([]byte, error) {
+	var out []interface{}
+	err := _CrossChainManager.contract.Call(opts, &out, "getRateLimit", SrcChainID, DstChainID, Asset)
+
+	if err != nil {
+		return *new([]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]byte)).(*[]byte)
+
+	return out0, err
+
+}
+
+// GetRateLimit is a free data retrieval call binding the contract method 0xe20a9e76.
+//
+// Solidity: function getRateLimit(uint64 SrcChainID, uint64 DstChainID, bytes Asset) view returns(bytes RateLimit)
+func (_CrossChainManager *CrossChainManagerSession) GetRateLimit(SrcChainID uint64, DstChainID uint64, Asset []byte) ([]byte, error) {
+	return _CrossChainManager.Contract.GetRateLimit(&_CrossChainManager.CallOpts, SrcChainID, DstChainID, Asset)
+}
+
+// GetRateLimit is a free data retrieval call binding the contract method 0xe20a9e76.
+//
+// Solidity: function getRateLimit(uint64 SrcChainID, uint64 DstChainID, bytes Asset) view returns(bytes RateLimit)
+func (_CrossChainManager *CrossChainManagerCallerSession) GetRateLimit(SrcChainID uint64, DstChainID uint64, Asset []byte) ([]byte, error) {
+	return _CrossChainManager.Contract.GetRateLimit(&_CrossChainManager.CallOpts, SrcChainID, DstChainID, Asset)
+}
+
+// GetVoteProgress is a free data retrieval call binding the contract method 0xc23879ab.
+//
+// Solidity: function getVoteProgress(uint64 SourceChainID, uint32 Height, bytes Extra) view returns(bytes VoteProgress)
+func (_CrossChainManager *CrossChainManagerCaller) GetVoteProgress(opts *bind.CallOpts, SourceChainID uint64, Height uint32, Extra []byte) ([]byte, error) {
+	var out []interface{}
+	err := _CrossChainManager.contract.Call(opts, &out, "getVoteProgress", SourceChainID, Height, Extra)
+
+	if err != nil {
+		return *new([]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]byte)).(*[]byte)
+
+	return out0, err
+
+}
+
+// GetVoteProgress is a free data retrieval call binding the contract method 0xc23879ab.
+//
+// Solidity: function getVoteProgress(uint64 SourceChainID, uint32 Height, bytes Extra) view returns(bytes VoteProgress)
+func (_CrossChainManager *CrossChainManagerSession) GetVoteProgress(SourceChainID uint64, Height uint32, Extra []byte) ([]byte, error) {
+	return _CrossChainManager.Contract.GetVoteProgress(&_CrossChainManager.CallOpts, SourceChainID, Height, Extra)
+}
+
+// GetVoteProgress is a free data retrieval call binding the contract method 0xc23879ab.
+//
+// Solidity: function getVoteProgress(uint64 SourceChainID, uint32 Height, bytes Extra) view returns(bytes VoteProgress)
+func (_CrossChainManager *CrossChainManagerCallerSession) GetVoteProgress(SourceChainID uint64, Height uint32, Extra []byte) ([]byte, error) {
+	return _CrossChainManager.Contract.GetVoteProgress(&_CrossChainManager.CallOpts, SourceChainID, Height, Extra)
+}
+
 // BlackChain is a paid mutator transaction binding the contract method 0x8a449f03.
 //
 // Solidity: function BlackChain(uint64 ChainID) returns(bool success)
@@ -314,6 +465,111 @@ func (_CrossChainManager *CrossChainManagerTransactorSession) Name() (*types.Tra
 	return _CrossChainManager.Contract.Name(&_CrossChainManager.TransactOpts)
 }
 
+// PauseRoute is a paid mutator transaction binding the contract method 0x177e4d3e.
+//
+// Solidity: function pauseRoute(uint64 SrcChainID, uint64 DstChainID, bytes Asset) returns(bool success)
+func (_CrossChainManager *CrossChainManagerTransactor) PauseRoute(opts *bind.TransactOpts, SrcChainID uint64, DstChainID uint64, Asset []byte) (*types.Transaction, error) {
+	return _CrossChainManager.contract.Transact(opts, "pauseRoute", SrcChainID, DstChainID, Asset)
+}
+
+// PauseRoute is a paid mutator transaction binding the contract method 0x177e4d3e.
+//
+// Solidity: function pauseRoute(uint64 SrcChainID, uint64 DstChainID, bytes Asset) returns(bool success)
+func (_CrossChainManager *CrossChainManagerSession) PauseRoute(SrcChainID uint64, DstChainID uint64, Asset []byte) (*types.Transaction, error) {
+	return _CrossChainManager.Contract.PauseRoute(&_CrossChainManager.TransactOpts, SrcChainID, DstChainID, Asset)
+}
+
+// PauseRoute is a paid mutator transaction binding the contract method 0x177e4d3e.
+//
+// Solidity: function pauseRoute(uint64 SrcChainID, uint64 DstChainID, bytes Asset) returns(bool success)
+func (_CrossChainManager *CrossChainManagerTransactorSession) PauseRoute(SrcChainID uint64, DstChainID uint64, Asset []byte) (*types.Transaction, error) {
+	return _CrossChainManager.Contract.PauseRoute(&_CrossChainManager.TransactOpts, SrcChainID, DstChainID, Asset)
+}
+
+// ReleaseTransfer is a paid mutator transaction binding the contract method 0x2c69ec3e.
+//
+// Solidity: function releaseTransfer(uint64 SourceChainID, bytes CrossChainID) returns(bool success)
+func (_CrossChainManager *CrossChainManagerTransactor) ReleaseTransfer(opts *bind.TransactOpts, SourceChainID uint64, CrossChainID []byte) (*types.Transaction, error) {
+	return _CrossChainManager.contract.Transact(opts, "releaseTransfer", SourceChainID, CrossChainID)
+}
+
+// ReleaseTransfer is a paid mutator transaction binding the contract method 0x2c69ec3e.
+//
+// Solidity: function releaseTransfer(uint64 SourceChainID, bytes CrossChainID) returns(bool success)
+func (_CrossChainManager *CrossChainManagerSession) ReleaseTransfer(SourceChainID uint64, CrossChainID []byte) (*types.Transaction, error) {
+	return _CrossChainManager.Contract.ReleaseTransfer(&_CrossChainManager.TransactOpts, SourceChainID, CrossChainID)
+}
+
+// ReleaseTransfer is a paid mutator transaction binding the contract method 0x2c69ec3e.
+//
+// Solidity: function releaseTransfer(uint64 SourceChainID, bytes CrossChainID) returns(bool success)
+func (_CrossChainManager *CrossChainManagerTransactorSession) ReleaseTransfer(SourceChainID uint64, CrossChainID []byte) (*types.Transaction, error) {
+	return _CrossChainManager.Contract.ReleaseTransfer(&_CrossChainManager.TransactOpts, SourceChainID, CrossChainID)
+}
+
+// SetGuardian is a paid mutator transaction binding the contract method 0x8a0dac4a.
+//
+// Solidity: function setGuardian(address Guardian) returns(bool success)
+func (_CrossChainManager *CrossChainManagerTransactor) SetGuardian(opts *bind.TransactOpts, Guardian common.Address) (*types.Transaction, error) {
+	return _CrossChainManager.contract.Transact(opts, "setGuardian", Guardian)
+}
+
+// SetGuardian is a paid mutator transaction binding the contract method 0x8a0dac4a.
+//
+// Solidity: function setGuardian(address Guardian) returns(bool success)
+func (_CrossChainManager *CrossChainManagerSession) SetGuardian(Guardian common.Address) (*types.Transaction, error) {
+	return _CrossChainManager.Contract.SetGuardian(&_CrossChainManager.TransactOpts, Guardian)
+}
+
+// SetGuardian is a paid mutator transaction binding the contract method 0x8a0dac4a.
+//
+// Solidity: function setGuardian(address Guardian) returns(bool success)
+func (_CrossChainManager *CrossChainManagerTransactorSession) SetGuardian(Guardian common.Address) (*types.Transaction, error) {
+	return _CrossChainManager.Contract.SetGuardian(&_CrossChainManager.TransactOpts, Guardian)
+}
+
+// SetRateLimit is a paid mutator transaction binding the contract method 0xd607a91a.
+//
+// Solidity: function setRateLimit(uint64 SrcChainID, uint64 DstChainID, bytes Asset, uint64 Window, uint256 Limit, uint64 Delay) returns(bool success)
+func (_CrossChainManager *CrossChainManagerTransactor) SetRateLimit(opts *bind.TransactOpts, SrcChainID uint64, DstChainID uint64, Asset []byte, Window uint64, Limit *big.Int, Delay uint64) (*types.Transaction, error) {
+	return _CrossChainManager.contract.Transact(opts, "setRateLimit", SrcChainID, DstChainID, Asset, Window, Limit, Delay)
+}
+
+// SetRateLimit is a paid mutator transaction binding the contract method 0xd607a91a.
+//
+// Solidity: function setRateLimit(uint64 SrcChainID, uint64 DstChainID, bytes Asset, uint64 Window, uint256 Limit, uint64 Delay) returns(bool success)
+func (_CrossChainManager *CrossChainManagerSession) SetRateLimit(SrcChainID uint64, DstChainID uint64, Asset []byte, Window uint64, Limit *big.Int, Delay uint64) (*types.Transaction, error) {
+	return _CrossChainManager.Contract.SetRateLimit(&_CrossChainManager.TransactOpts, SrcChainID, DstChainID, Asset, Window, Limit, Delay)
+}
+
+// SetRateLimit is a paid mutator transaction binding the contract method 0xd607a91a.
+//
+// Solidity: function setRateLimit(uint64 SrcChainID, uint64 DstChainID, bytes Asset, uint64 Window, uint256 Limit, uint64 Delay) returns(bool success)
+func (_CrossChainManager *CrossChainManagerTransactorSession) SetRateLimit(SrcChainID uint64, DstChainID uint64, Asset []byte, Window uint64, Limit *big.Int, Delay uint64) (*types.Transaction, error) {
+	return _CrossChainManager.Contract.SetRateLimit(&_CrossChainManager.TransactOpts, SrcChainID, DstChainID, Asset, Window, Limit, Delay)
+}
+
+// UnpauseRoute is a paid mutator transaction binding the contract method 0x07ba98e6.
+//
+// Solidity: function unpauseRoute(uint64 SrcChainID, uint64 DstChainID, bytes Asset) returns(bool success)
+func (_CrossChainManager *CrossChainManagerTransactor) UnpauseRoute(opts *bind.TransactOpts, SrcChainID uint64, DstChainID uint64, Asset []byte) (*types.Transaction, error) {
+	return _CrossChainManager.contract.Transact(opts, "unpauseRoute", SrcChainID, DstChainID, Asset)
+}
+
+// UnpauseRoute is a paid mutator transaction binding the contract method 0x07ba98e6.
+//
+// Solidity: function unpauseRoute(uint64 SrcChainID, uint64 DstChainID, bytes Asset) returns(bool success)
+func (_CrossChainManager *CrossChainManagerSession) UnpauseRoute(SrcChainID uint64, DstChainID uint64, Asset []byte) (*types.Transaction, error) {
+	return _CrossChainManager.Contract.UnpauseRoute(&_CrossChainManager.TransactOpts, SrcChainID, DstChainID, Asset)
+}
+
+// UnpauseRoute is a paid mutator transaction binding the contract method 0x07ba98e6.
+//
+// Solidity: function unpauseRoute(uint64 SrcChainID, uint64 DstChainID, bytes Asset) returns(bool success)
+func (_CrossChainManager *CrossChainManagerTransactorSession) UnpauseRoute(SrcChainID uint64, DstChainID uint64, Asset []byte) (*types.Transaction, error) {
+	return _CrossChainManager.Contract.UnpauseRoute(&_CrossChainManager.TransactOpts, SrcChainID, DstChainID, Asset)
+}
+
 // CrossChainManagerBtcTxMultiSignEventIterator is returned from FilterBtcTxMultiSignEvent and is used to iterate over the raw logs and unpacked data for BtcTxMultiSignEvent events raised by the CrossChainManager contract.
 type CrossChainManagerBtcTxMultiSignEventIterator struct {
 	Event *CrossChainManagerBtcTxMultiSignEvent // Event containing the contract specifics and raw log
@@ -587,6 +843,822 @@ func (_CrossChainManager *CrossChainManagerFilterer) ParseBtcTxToRelayEvent(log
 	return event, nil
 }
 
+// CrossChainManagerEvtPauseRouteIterator is returned from FilterEvtPauseRoute and is used to iterate over the raw logs and unpacked data for EvtPauseRoute events raised by the CrossChainManager contract.
+type CrossChainManagerEvtPauseRouteIterator struct {
+	Event *CrossChainManagerEvtPauseRoute // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *CrossChainManagerEvtPauseRouteIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(CrossChainManagerEvtPauseRoute)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(CrossChainManagerEvtPauseRoute)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *CrossChainManagerEvtPauseRouteIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *CrossChainManagerEvtPauseRouteIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// CrossChainManagerEvtPauseRoute represents a EvtPauseRoute event raised by the CrossChainManager contract.
+type CrossChainManagerEvtPauseRoute struct {
+	SrcChainID uint64
+	DstChainID uint64
+	Asset      []byte
+	Raw        types.Log // Blockchain specific contextual infos
+}
+
+// FilterEvtPauseRoute is a free log retrieval operation binding the contract event 0x8366aeaf123d9a2aa898eb3899dcbcff05becd99781fe09b3dec274f3a792173.
+//
+// Solidity: event evtPauseRoute(uint64 SrcChainID, uint64 DstChainID, bytes Asset)
+func (_CrossChainManager *CrossChainManagerFilterer) FilterEvtPauseRoute(opts *bind.FilterOpts) (*CrossChainManagerEvtPauseRouteIterator, error) {
+
+	logs, sub, err := _CrossChainManager.contract.FilterLogs(opts, "evtPauseRoute")
+	if err != nil {
+		return nil, err
+	}
+	return &CrossChainManagerEvtPauseRouteIterator{contract: _CrossChainManager.contract, event: "evtPauseRoute", logs: logs, sub: sub}, nil
+}
+
+// WatchEvtPauseRoute is a free log subscription operation binding the contract event 0x8366aeaf123d9a2aa898eb3899dcbcff05becd99781fe09b3dec274f3a792173.
+//
+// Solidity: event evtPauseRoute(uint64 SrcChainID, uint64 DstChainID, bytes Asset)
+func (_CrossChainManager *CrossChainManagerFilterer) WatchEvtPauseRoute(opts *bind.WatchOpts, sink chan<- *CrossChainManagerEvtPauseRoute) (event.Subscription, error) {
+
+	logs, sub, err := _CrossChainManager.contract.WatchLogs(opts, "evtPauseRoute")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(CrossChainManagerEvtPauseRoute)
+				if err := _CrossChainManager.contract.UnpackLog(event, "evtPauseRoute", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseEvtPauseRoute is a log parse operation binding the contract event 0x8366aeaf123d9a2aa898eb3899dcbcff05becd99781fe09b3dec274f3a792173.
+//
+// Solidity: event evtPauseRoute(uint64 SrcChainID, uint64 DstChainID, bytes Asset)
+func (_CrossChainManager *CrossChainManagerFilterer) ParseEvtPauseRoute(log types.Log) (*CrossChainManagerEvtPauseRoute, error) {
+	event := new(CrossChainManagerEvtPauseRoute)
+	if err := _CrossChainManager.contract.UnpackLog(event, "evtPauseRoute", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// CrossChainManagerEvtQueueTransferIterator is returned from FilterEvtQueueTransfer and is used to iterate over the raw logs and unpacked data for EvtQueueTransfer events raised by the CrossChainManager contract.
+type CrossChainManagerEvtQueueTransferIterator struct {
+	Event *CrossChainManagerEvtQueueTransfer // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *CrossChainManagerEvtQueueTransferIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(CrossChainManagerEvtQueueTransfer)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(CrossChainManagerEvtQueueTransfer)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *CrossChainManagerEvtQueueTransferIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *CrossChainManagerEvtQueueTransferIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// CrossChainManagerEvtQueueTransfer represents a EvtQueueTransfer event raised by the CrossChainManager contract.
+type CrossChainManagerEvtQueueTransfer struct {
+	SourceChainID uint64
+	CrossChainID  []byte
+	ReleaseTime   uint64
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterEvtQueueTransfer is a free log retrieval operation binding the contract event 0x6042ace47b0371487fb02307f804a9d3f557e9a883024f6abfb891d287585273.
+//
+// Solidity: event evtQueueTransfer(uint64 SourceChainID, bytes CrossChainID, uint64 ReleaseTime)
+func (_CrossChainManager *CrossChainManagerFilterer) FilterEvtQueueTransfer(opts *bind.FilterOpts) (*CrossChainManagerEvtQueueTransferIterator, error) {
+
+	logs, sub, err := _CrossChainManager.contract.FilterLogs(opts, "evtQueueTransfer")
+	if err != nil {
+		return nil, err
+	}
+	return &CrossChainManagerEvtQueueTransferIterator{contract: _CrossChainManager.contract, event: "evtQueueTransfer", logs: logs, sub: sub}, nil
+}
+
+// WatchEvtQueueTransfer is a free log subscription operation binding the contract event 0x6042ace47b0371487fb02307f804a9d3f557e9a883024f6abfb891d287585273.
+//
+// Solidity: event evtQueueTransfer(uint64 SourceChainID, bytes CrossChainID, uint64 ReleaseTime)
+func (_CrossChainManager *CrossChainManagerFilterer) WatchEvtQueueTransfer(opts *bind.WatchOpts, sink chan<- *CrossChainManagerEvtQueueTransfer) (event.Subscription, error) {
+
+	logs, sub, err := _CrossChainManager.contract.WatchLogs(opts, "evtQueueTransfer")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(CrossChainManagerEvtQueueTransfer)
+				if err := _CrossChainManager.contract.UnpackLog(event, "evtQueueTransfer", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseEvtQueueTransfer is a log parse operation binding the contract event 0x6042ace47b0371487fb02307f804a9d3f557e9a883024f6abfb891d287585273.
+//
+// Solidity: event evtQueueTransfer(uint64 SourceChainID, bytes CrossChainID, uint64 ReleaseTime)
+func (_CrossChainManager *CrossChainManagerFilterer) ParseEvtQueueTransfer(log types.Log) (*CrossChainManagerEvtQueueTransfer, error) {
+	event := new(CrossChainManagerEvtQueueTransfer)
+	if err := _CrossChainManager.contract.UnpackLog(event, "evtQueueTransfer", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// CrossChainManagerEvtReleaseTransferIterator is returned from FilterEvtReleaseTransfer and is used to iterate over the raw logs and unpacked data for EvtReleaseTransfer events raised by the CrossChainManager contract.
+type CrossChainManagerEvtReleaseTransferIterator struct {
+	Event *CrossChainManagerEvtReleaseTransfer // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *CrossChainManagerEvtReleaseTransferIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(CrossChainManagerEvtReleaseTransfer)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(CrossChainManagerEvtReleaseTransfer)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *CrossChainManagerEvtReleaseTransferIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *CrossChainManagerEvtReleaseTransferIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// CrossChainManagerEvtReleaseTransfer represents a EvtReleaseTransfer event raised by the CrossChainManager contract.
+type CrossChainManagerEvtReleaseTransfer struct {
+	SourceChainID uint64
+	CrossChainID  []byte
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterEvtReleaseTransfer is a free log retrieval operation binding the contract event 0x89ae5217ae4051c59fabf09788837606a9e583eb35115d51f44ee2039c9c9bfc.
+//
+// Solidity: event evtReleaseTransfer(uint64 SourceChainID, bytes CrossChainID)
+func (_CrossChainManager *CrossChainManagerFilterer) FilterEvtReleaseTransfer(opts *bind.FilterOpts) (*CrossChainManagerEvtReleaseTransferIterator, error) {
+
+	logs, sub, err := _CrossChainManager.contract.FilterLogs(opts, "evtReleaseTransfer")
+	if err != nil {
+		return nil, err
+	}
+	return &CrossChainManagerEvtReleaseTransferIterator{contract: _CrossChainManager.contract, event: "evtReleaseTransfer", logs: logs, sub: sub}, nil
+}
+
+// WatchEvtReleaseTransfer is a free log subscription operation binding the contract event 0x89ae5217ae4051c59fabf09788837606a9e583eb35115d51f44ee2039c9c9bfc.
+//
+// Solidity: event evtReleaseTransfer(uint64 SourceChainID, bytes CrossChainID)
+func (_CrossChainManager *CrossChainManagerFilterer) WatchEvtReleaseTransfer(opts *bind.WatchOpts, sink chan<- *CrossChainManagerEvtReleaseTransfer) (event.Subscription, error) {
+
+	logs, sub, err := _CrossChainManager.contract.WatchLogs(opts, "evtReleaseTransfer")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(CrossChainManagerEvtReleaseTransfer)
+				if err := _CrossChainManager.contract.UnpackLog(event, "evtReleaseTransfer", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseEvtReleaseTransfer is a log parse operation binding the contract event 0x89ae5217ae4051c59fabf09788837606a9e583eb35115d51f44ee2039c9c9bfc.
+//
+// Solidity: event evtReleaseTransfer(uint64 SourceChainID, bytes CrossChainID)
+func (_CrossChainManager *CrossChainManagerFilterer) ParseEvtReleaseTransfer(log types.Log) (*CrossChainManagerEvtReleaseTransfer, error) {
+	event := new(CrossChainManagerEvtReleaseTransfer)
+	if err := _CrossChainManager.contract.UnpackLog(event, "evtReleaseTransfer", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// CrossChainManagerEvtSetGuardianIterator is returned from FilterEvtSetGuardian and is used to iterate over the raw logs and unpacked data for EvtSetGuardian events raised by the CrossChainManager contract.
+type CrossChainManagerEvtSetGuardianIterator struct {
+	Event *CrossChainManagerEvtSetGuardian // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *CrossChainManagerEvtSetGuardianIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(CrossChainManagerEvtSetGuardian)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(CrossChainManagerEvtSetGuardian)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *CrossChainManagerEvtSetGuardianIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *CrossChainManagerEvtSetGuardianIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// CrossChainManagerEvtSetGuardian represents a EvtSetGuardian event raised by the CrossChainManager contract.
+type CrossChainManagerEvtSetGuardian struct {
+	Guardian common.Address
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterEvtSetGuardian is a free log retrieval operation binding the contract event 0x6b660ea216fd62593d6066ffabf4dbf3112fdd9e36f52bbdcb5e5a394663af0e.
+//
+// Solidity: event evtSetGuardian(address Guardian)
+func (_CrossChainManager *CrossChainManagerFilterer) FilterEvtSetGuardian(opts *bind.FilterOpts) (*CrossChainManagerEvtSetGuardianIterator, error) {
+
+	logs, sub, err := _CrossChainManager.contract.FilterLogs(opts, "evtSetGuardian")
+	if err != nil {
+		return nil, err
+	}
+	return &CrossChainManagerEvtSetGuardianIterator{contract: _CrossChainManager.contract, event: "evtSetGuardian", logs: logs, sub: sub}, nil
+}
+
+// WatchEvtSetGuardian is a free log subscription operation binding the contract event 0x6b660ea216fd62593d6066ffabf4dbf3112fdd9e36f52bbdcb5e5a394663af0e.
+//
+// Solidity: event evtSetGuardian(address Guardian)
+func (_CrossChainManager *CrossChainManagerFilterer) WatchEvtSetGuardian(opts *bind.WatchOpts, sink chan<- *CrossChainManagerEvtSetGuardian) (event.Subscription, error) {
+
+	logs, sub, err := _CrossChainManager.contract.WatchLogs(opts, "evtSetGuardian")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(CrossChainManagerEvtSetGuardian)
+				if err := _CrossChainManager.contract.UnpackLog(event, "evtSetGuardian", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseEvtSetGuardian is a log parse operation binding the contract event 0x6b660ea216fd62593d6066ffabf4dbf3112fdd9e36f52bbdcb5e5a394663af0e.
+//
+// Solidity: event evtSetGuardian(address Guardian)
+func (_CrossChainManager *CrossChainManagerFilterer) ParseEvtSetGuardian(log types.Log) (*CrossChainManagerEvtSetGuardian, error) {
+	event := new(CrossChainManagerEvtSetGuardian)
+	if err := _CrossChainManager.contract.UnpackLog(event, "evtSetGuardian", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// CrossChainManagerEvtSetRateLimitIterator is returned from FilterEvtSetRateLimit and is used to iterate over the raw logs and unpacked data for EvtSetRateLimit events raised by the CrossChainManager contract.
+type CrossChainManagerEvtSetRateLimitIterator struct {
+	Event *CrossChainManagerEvtSetRateLimit // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *CrossChainManagerEvtSetRateLimitIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(CrossChainManagerEvtSetRateLimit)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(CrossChainManagerEvtSetRateLimit)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *CrossChainManagerEvtSetRateLimitIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *CrossChainManagerEvtSetRateLimitIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// CrossChainManagerEvtSetRateLimit represents a EvtSetRateLimit event raised by the CrossChainManager contract.
+type CrossChainManagerEvtSetRateLimit struct {
+	SrcChainID uint64
+	DstChainID uint64
+	Asset      []byte
+	Window     uint64
+	Limit      *big.Int
+	Delay      uint64
+	Raw        types.Log // Blockchain specific contextual infos
+}
+
+// FilterEvtSetRateLimit is a free log retrieval operation binding the contract event 0x4ac743c0d2965c390f968f1712808385e18bc781c5bca21db9636517b4e7342c.
+//
+// Solidity: event evtSetRateLimit(uint64 SrcChainID, uint64 DstChainID, bytes Asset, uint64 Window, uint256 Limit, uint64 Delay)
+func (_CrossChainManager *CrossChainManagerFilterer) FilterEvtSetRateLimit(opts *bind.FilterOpts) (*CrossChainManagerEvtSetRateLimitIterator, error) {
+
+	logs, sub, err := _CrossChainManager.contract.FilterLogs(opts, "evtSetRateLimit")
+	if err != nil {
+		return nil, err
+	}
+	return &CrossChainManagerEvtSetRateLimitIterator{contract: _CrossChainManager.contract, event: "evtSetRateLimit", logs: logs, sub: sub}, nil
+}
+
+// WatchEvtSetRateLimit is a free log subscription operation binding the contract event 0x4ac743c0d2965c390f968f1712808385e18bc781c5bca21db9636517b4e7342c.
+//
+// Solidity: event evtSetRateLimit(uint64 SrcChainID, uint64 DstChainID, bytes Asset, uint64 Window, uint256 Limit, uint64 Delay)
+func (_CrossChainManager *CrossChainManagerFilterer) WatchEvtSetRateLimit(opts *bind.WatchOpts, sink chan<- *CrossChainManagerEvtSetRateLimit) (event.Subscription, error) {
+
+	logs, sub, err := _CrossChainManager.contract.WatchLogs(opts, "evtSetRateLimit")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(CrossChainManagerEvtSetRateLimit)
+				if err := _CrossChainManager.contract.UnpackLog(event, "evtSetRateLimit", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseEvtSetRateLimit is a log parse operation binding the contract event 0x4ac743c0d2965c390f968f1712808385e18bc781c5bca21db9636517b4e7342c.
+//
+// Solidity: event evtSetRateLimit(uint64 SrcChainID, uint64 DstChainID, bytes Asset, uint64 Window, uint256 Limit, uint64 Delay)
+func (_CrossChainManager *CrossChainManagerFilterer) ParseEvtSetRateLimit(log types.Log) (*CrossChainManagerEvtSetRateLimit, error) {
+	event := new(CrossChainManagerEvtSetRateLimit)
+	if err := _CrossChainManager.contract.UnpackLog(event, "evtSetRateLimit", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// CrossChainManagerEvtUnpauseRouteIterator is returned from FilterEvtUnpauseRoute and is used to iterate over the raw logs and unpacked data for EvtUnpauseRoute events raised by the CrossChainManager contract.
+type CrossChainManagerEvtUnpauseRouteIterator struct {
+	Event *CrossChainManagerEvtUnpauseRoute // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *CrossChainManagerEvtUnpauseRouteIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(CrossChainManagerEvtUnpauseRoute)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(CrossChainManagerEvtUnpauseRoute)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *CrossChainManagerEvtUnpauseRouteIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *CrossChainManagerEvtUnpauseRouteIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// CrossChainManagerEvtUnpauseRoute represents a EvtUnpauseRoute event raised by the CrossChainManager contract.
+type CrossChainManagerEvtUnpauseRoute struct {
+	SrcChainID uint64
+	DstChainID uint64
+	Asset      []byte
+	Raw        types.Log // Blockchain specific contextual infos
+}
+
+// FilterEvtUnpauseRoute is a free log retrieval operation binding the contract event 0xdfbc02659870505b1da5869fd222e17edb5f4ae97b92b8e6f4cef69bf79098d2.
+//
+// Solidity: event evtUnpauseRoute(uint64 SrcChainID, uint64 DstChainID, bytes Asset)
+func (_CrossChainManager *CrossChainManagerFilterer) FilterEvtUnpauseRoute(opts *bind.FilterOpts) (*CrossChainManagerEvtUnpauseRouteIterator, error) {
+
+	logs, sub, err := _CrossChainManager.contract.FilterLogs(opts, "evtUnpauseRoute")
+	if err != nil {
+		return nil, err
+	}
+	return &CrossChainManagerEvtUnpauseRouteIterator{contract: _CrossChainManager.contract, event: "evtUnpauseRoute", logs: logs, sub: sub}, nil
+}
+
+// WatchEvtUnpauseRoute is a free log subscription operation binding the contract event 0xdfbc02659870505b1da5869fd222e17edb5f4ae97b92b8e6f4cef69bf79098d2.
+//
+// Solidity: event evtUnpauseRoute(uint64 SrcChainID, uint64 DstChainID, bytes Asset)
+func (_CrossChainManager *CrossChainManagerFilterer) WatchEvtUnpauseRoute(opts *bind.WatchOpts, sink chan<- *CrossChainManagerEvtUnpauseRoute) (event.Subscription, error) {
+
+	logs, sub, err := _CrossChainManager.contract.WatchLogs(opts, "evtUnpauseRoute")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(CrossChainManagerEvtUnpauseRoute)
+				if err := _CrossChainManager.contract.UnpackLog(event, "evtUnpauseRoute", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseEvtUnpauseRoute is a log parse operation binding the contract event 0xdfbc02659870505b1da5869fd222e17edb5f4ae97b92b8e6f4cef69bf79098d2.
+//
+// Solidity: event evtUnpauseRoute(uint64 SrcChainID, uint64 DstChainID, bytes Asset)
+func (_CrossChainManager *CrossChainManagerFilterer) ParseEvtUnpauseRoute(log types.Log) (*CrossChainManagerEvtUnpauseRoute, error) {
+	event := new(CrossChainManagerEvtUnpauseRoute)
+	if err := _CrossChainManager.contract.UnpackLog(event, "evtUnpauseRoute", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
 // CrossChainManagerMakeBtcTxEventIterator is returned from FilterMakeBtcTxEvent and is used to iterate over the raw logs and unpacked data for MakeBtcTxEvent events raised by the CrossChainManager contract.
 type CrossChainManagerMakeBtcTxEventIterator struct {
 	Event *CrossChainManagerMakeBtcTxEvent // Event containing the contract specifics and raw log
@@ -31,10 +31,20 @@ var (
 
 	MethodLock = "lock"
 
+	MethodLockWithFee = "lockWithFee"
+
+	MethodRefundRelayerFee = "refundRelayerFee"
+
 	MethodAllowance = "allowance"
 
+	MethodGetFailedReceipt = "getFailedReceipt"
+
+	MethodGetRelayerFee = "getRelayerFee"
+
 	MethodGetSideChainLockAmount = "getSideChainLockAmount"
 
+	MethodGetUnsettledRelayerFees = "getUnsettledRelayerFees"
+
 	MethodName = "name"
 
 	EventApproval = "Approval"
@@ -43,21 +53,32 @@ var (
 
 	EventLockEvent = "LockEvent"
 
+	EventRefundEvent = "RefundEvent"
+
+	EventRelayerFeeEvent = "RelayerFeeEvent"
+
+	EventTransferFailedEvent = "TransferFailedEvent"
+
 	EventUnlockEvent = "UnlockEvent"
 
 	EventVerifyHeaderAndExecuteTxEvent = "VerifyHeaderAndExecuteTxEvent"
 )
 
 // IMainChainLockProxyABI is the input ABI used to generate the binding from.
-const IMainChainLockProxyABI = "[{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"Approval\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"txId\",\"type\":\"bytes\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"proxyOrAssetContract\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"toChainId\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"toContract\",\"type\":\"bytes\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"rawdata\",\"type\":\"bytes\"}],\"name\":\"CrossChainEvent\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"address\",\"name\":\"fromAssetHash\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"fromAddress\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"toChainId\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"toAssetHash\",\"type\":\"bytes\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"toAddress\",\"type\":\"bytes\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"LockEvent\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"address\",\"name\":\"toAssetHash\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"toAddress\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"UnlockEvent\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"fromChainID\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"toContract\",\"type\":\"bytes\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"crossChainTxHash\",\"type\":\"bytes\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"fromChainTxHash\",\"type\":\"bytes\"}],\"name\":\"VerifyHeaderAndExecuteTxEvent\",\"type\":\"event\"},{\"constant\":true,\"inputs\":[{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"}],\"name\":\"allowance\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"approve\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"chainId\",\"type\":\"uint64\"}],\"name\":\"getSideChainLockAmount\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"toChainId\",\"type\":\"uint64\"},{\"internalType\":\"address\",\"name\":\"toAddress\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"lock\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"payable\":true,\"stateMutability\":\"payable\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"name\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"}]"
+const IMainChainLockProxyABI = "[{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"Approval\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"txId\",\"type\":\"bytes\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"proxyOrAssetContract\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"toChainId\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"toContract\",\"type\":\"bytes\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"rawdata\",\"type\":\"bytes\"}],\"name\":\"CrossChainEvent\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"address\",\"name\":\"fromAssetHash\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"fromAddress\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"toChainId\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"toAssetHash\",\"type\":\"bytes\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"toAddress\",\"type\":\"bytes\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"LockEvent\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"address\",\"name\":\"toAddress\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"RefundEvent\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"address\",\"name\":\"toAddress\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"fee\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"bool\",\"name\":\"refunded\",\"type\":\"bool\"}],\"name\":\"RelayerFeeEvent\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"fromChainId\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"crossChainId\",\"type\":\"bytes\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"reason\",\"type\":\"string\"}],\"name\":\"TransferFailedEvent\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"address\",\"name\":\"toAssetHash\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"toAddress\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"UnlockEvent\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"fromChainID\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"toContract\",\"type\":\"bytes\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"crossChainTxHash\",\"type\":\"bytes\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"fromChainTxHash\",\"type\":\"bytes\"}],\"name\":\"VerifyHeaderAndExecuteTxEvent\",\"type\":\"event\"},{\"constant\":true,\"inputs\":[{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"}],\"name\":\"allowance\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"approve\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"fromChainId\",\"type\":\"uint64\"},{\"internalType\":\"bytes\",\"name\":\"crossChainId\",\"type\":\"bytes\"}],\"name\":\"getFailedReceipt\",\"outputs\":[{\"internalType\":\"bytes\",\"name\":\"\",\"type\":\"bytes\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"crossChainId\",\"type\":\"bytes\"}],\"name\":\"getRelayerFee\",\"outputs\":[{\"internalType\":\"bytes\",\"name\":\"\",\"type\":\"bytes\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"chainId\",\"type\":\"uint64\"}],\"name\":\"getSideChainLockAmount\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"toChainId\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"start\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"limit\",\"type\":\"uint64\"}],\"name\":\"getUnsettledRelayerFees\",\"outputs\":[{\"internalType\":\"bytes\",\"name\":\"\",\"type\":\"bytes\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"toChainId\",\"type\":\"uint64\"},{\"internalType\":\"address\",\"name\":\"toAddress\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"lock\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"payable\":true,\"stateMutability\":\"payable\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"toChainId\",\"type\":\"uint64\"},{\"internalType\":\"address\",\"name\":\"toAddress\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"fee\",\"type\":\"uint256\"}],\"name\":\"lockWithFee\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"payable\":true,\"stateMutability\":\"payable\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"name\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"crossChainId\",\"type\":\"bytes\"}],\"name\":\"refundRelayerFee\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]"
 
 // IMainChainLockProxyFuncSigs maps the 4-byte function signature to its string representation.
 var IMainChainLockProxyFuncSigs = map[string]string{
 	"dd62ed3e": "allowance(address,address)",
 	"095ea7b3": "approve(address,uint256)",
+	"b62aa54c": "getFailedReceipt(uint64,bytes)",
+	"6d83c839": "getRelayerFee(bytes)",
 	"50d06e71": "getSideChainLockAmount(uint64)",
+	"3ab59133": "getUnsettledRelayerFees(uint64,uint64,uint64)",
 	"4bc68823": "lock(uint64,address,uint256)",
+	"cd8a7d32": "lockWithFee(uint64,address,uint256,uint256)",
 	"06fdde03": "name()",
+	"058c4de6": "refundRelayerFee(bytes)",
 }
 
 // IMainChainLockProxy is an auto generated Go binding around an Ethereum contract.
@@ -233,6 +254,68 @@ func (_IMainChainLockProxy *IMainChainLockProxyCallerSession) Allowance(owner co
 	return _IMainChainLockProxy.Contract.Allowance(&_IMainChainLockProxy.CallOpts, owner, spender)
 }
 
+// GetFailedReceipt is a free data retrieval call binding the contract method 0xb62aa54c.
+//
+// Solidity: function getFailedReceipt(uint64 fromChainId, bytes crossChainId) view returns(bytes)
+func (_IMainChainLockProxy *IMainChainLockProxyCaller) GetFailedReceipt(opts *bind.CallOpts, fromChainId uint64, crossChainId []byte) ([]byte, error) {
+	var out []interface{}
+	err := _IMainChainLockProxy.contract.Call(opts, &out, "getFailedReceipt", fromChainId, crossChainId)
+
+	if err != nil {
+		return *new([]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]byte)).(*[]byte)
+
+	return out0, err
+
+}
+
+// GetFailedReceipt is a free data retrieval call binding the contract method 0xb62aa54c.
+//
+// Solidity: function getFailedReceipt(uint64 fromChainId, bytes crossChainId) view returns(bytes)
+func (_IMainChainLockProxy *IMainChainLockProxySession) GetFailedReceipt(fromChainId uint64, crossChainId []byte) ([]byte, error) {
+	return _IMainChainLockProxy.Contract.GetFailedReceipt(&_IMainChainLockProxy.CallOpts, fromChainId, crossChainId)
+}
+
+// GetFailedReceipt is a free data retrieval call binding the contract method 0xb62aa54c.
+//
+// Solidity: function getFailedReceipt(uint64 fromChainId, bytes crossChainId) view returns(bytes)
+func (_IMainChainLockProxy *IMainChainLockProxyCallerSession) GetFailedReceipt(fromChainId uint64, crossChainId []byte) ([]byte, error) {
+	return _IMainChainLockProxy.Contract.GetFailedReceipt(&_IMainChainLockProxy.CallOpts, fromChainId, crossChainId)
+}
+
+// GetRelayerFee is a free data retrieval call binding the contract method 0x6d83c839.
+//
+// Solidity: function getRelayerFee(bytes crossChainId) view returns(bytes)
+func (_IMainChainLockProxy *IMainChainLockProxyCaller) GetRelayerFee(opts *bind.CallOpts, crossChainId []byte) ([]byte, error) {
+	var out []interface{}
+	err := _IMainChainLockProxy.contract.Call(opts, &out, "getRelayerFee", crossChainId)
+
+	if err != nil {
+		return *new([]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]byte)).(*[]byte)
+
+	return out0, err
+
+}
+
+// GetRelayerFee is a free data retrieval call binding the contract method 0x6d83c839.
+//
+// Solidity: function getRelayerFee(bytes crossChainId) view returns(bytes)
+func (_IMainChainLockProxy *IMainChainLockProxySession) GetRelayerFee(crossChainId []byte) ([]byte, error) {
+	return _IMainChainLockProxy.Contract.GetRelayerFee(&_IMainChainLockProxy.CallOpts, crossChainId)
+}
+
+// GetRelayerFee is a free data retrieval call binding the contract method 0x6d83c839.
+//
+// Solidity: function getRelayerFee(bytes crossChainId) view returns(bytes)
+func (_IMainChainLockProxy *IMainChainLockProxyCallerSession) GetRelayerFee(crossChainId []byte) ([]byte, error) {
+	return _IMainChainLockProxy.Contract.GetRelayerFee(&_IMainChainLockProxy.CallOpts, crossChainId)
+}
+
 // GetSideChainLockAmount is a free data retrieval call binding the contract method 0x50d06e71.
 //
 // Solidity: function getSideChainLockAmount(uint64 chainId) view returns(uint256)
@@ -264,6 +347,37 @@ func (_IMainChainLockProxy *IMainChainLockProxyCallerSession) GetSideChainLockAm
 	return _IMainChainLockProxy.Contract.GetSideChainLockAmount(&_IMainChainLockProxy.CallOpts, chainId)
 }
 
+// GetUnsettledRelayerFees is a free data retrieval call binding the contract method 0x3ab59133.
+//
+// Solidity: function getUnsettledRelayerFees(uint64 toChainId, uint64 start, uint64 limit) view returns(bytes)
+func (_IMainChainLockProxy *IMainChainLockProxyCaller) GetUnsettledRelayerFees(opts *bind.CallOpts, toChainId uint64, start uint64, limit uint64) ([]byte, error) {
+	var out []interface{}
+	err := _IMainChainLockProxy.contract.Call(opts, &out, "getUnsettledRelayerFees", toChainId, start, limit)
+
+	if err != nil {
+		return *new([]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]byte)).(*[]byte)
+
+	return out0, err
+
+}
+
+// GetUnsettledRelayerFees is a free data retrieval call binding the contract method 0x3ab59133.
+//
+// Solidity: function getUnsettledRelayerFees(uint64 toChainId, uint64 start, uint64 limit) view returns(bytes)
+func (_IMainChainLockProxy *IMainChainLockProxySession) GetUnsettledRelayerFees(toChainId uint64, start uint64, limit uint64) ([]byte, error) {
+	return _IMainChainLockProxy.Contract.GetUnsettledRelayerFees(&_IMainChainLockProxy.CallOpts, toChainId, start, limit)
+}
+
+// GetUnsettledRelayerFees is a free data retrieval call binding the contract method 0x3ab59133.
+//
+// Solidity: function getUnsettledRelayerFees(uint64 toChainId, uint64 start, uint64 limit) view returns(bytes)
+func (_IMainChainLockProxy *IMainChainLockProxyCallerSession) GetUnsettledRelayerFees(toChainId uint64, start uint64, limit uint64) ([]byte, error) {
+	return _IMainChainLockProxy.Contract.GetUnsettledRelayerFees(&_IMainChainLockProxy.CallOpts, toChainId, start, limit)
+}
+
 // Name is a free data retrieval call binding the contract method 0x06fdde03.
 //
 // Solidity: function name() view returns(string)
@@ -337,6 +451,48 @@ func (_IMainChainLockProxy *IMainChainLockProxyTransactorSession) Lock(toChainId
 	return _IMainChainLockProxy.Contract.Lock(&_IMainChainLockProxy.TransactOpts, toChainId, toAddress, amount)
 }
 
+// LockWithFee is a paid mutator transaction binding the contract method 0xcd8a7d32.
+//
+// Solidity: function lockWithFee(uint64 toChainId, address toAddress, uint256 amount, uint256 fee) payable returns(bool)
+func (_IMainChainLockProxy *IMainChainLockProxyTransactor) LockWithFee(opts *bind.TransactOpts, toChainId uint64, toAddress common.Address, amount *big.Int, fee *big.Int) (*types.Transaction, error) {
+	return _IMainChainLockProxy.contract.Transact(opts, "lockWithFee", toChainId, toAddress, amount, fee)
+}
+
+// LockWithFee is a paid mutator transaction binding the contract method 0xcd8a7d32.
+//
+// Solidity: function lockWithFee(uint64 toChainId, address toAddress, uint256 amount, uint256 fee) payable returns(bool)
+func (_IMainChainLockProxy *IMainChainLockProxySession) LockWithFee(toChainId uint64, toAddress common.Address, amount *big.Int, fee *big.Int) (*types.Transaction, error) {
+	return _IMainChainLockProxy.Contract.LockWithFee(&_IMainChainLockProxy.TransactOpts, toChainId, toAddress, amount, fee)
+}
+
+// LockWithFee is a paid mutator transaction binding the contract method 0xcd8a7d32.
+//
+// Solidity: function lockWithFee(uint64 toChainId, address toAddress, uint256 amount, uint256 fee) payable returns(bool)
+func (_IMainChainLockProxy *IMainChainLockProxyTransactorSession) LockWithFee(toChainId uint64, toAddress common.Address, amount *big.Int, fee *big.Int) (*types.Transaction, error) {
+	return _IMainChainLockProxy.Contract.LockWithFee(&_IMainChainLockProxy.TransactOpts, toChainId, toAddress, amount, fee)
+}
+
+// RefundRelayerFee is a paid mutator transaction binding the contract method 0x058c4de6.
+//
+// Solidity: function refundRelayerFee(bytes crossChainId) returns(bool)
+func (_IMainChainLockProxy *IMainChainLockProxyTransactor) RefundRelayerFee(opts *bind.TransactOpts, crossChainId []byte) (*types.Transaction, error) {
+	return _IMainChainLockProxy.contract.Transact(opts, "refundRelayerFee", crossChainId)
+}
+
+// RefundRelayerFee is a paid mutator transaction binding the contract method 0x058c4de6.
+//
+// Solidity: function refundRelayerFee(bytes crossChainId) returns(bool)
+func (_IMainChainLockProxy *IMainChainLockProxySession) RefundRelayerFee(crossChainId []byte) (*types.Transaction, error) {
+	return _IMainChainLockProxy.Contract.RefundRelayerFee(&_IMainChainLockProxy.TransactOpts, crossChainId)
+}
+
+// RefundRelayerFee is a paid mutator transaction binding the contract method 0x058c4de6.
+//
+// Solidity: function refundRelayerFee(bytes crossChainId) returns(bool)
+func (_IMainChainLockProxy *IMainChainLockProxyTransactorSession) RefundRelayerFee(crossChainId []byte) (*types.Transaction, error) {
+	return _IMainChainLockProxy.Contract.RefundRelayerFee(&_IMainChainLockProxy.TransactOpts, crossChainId)
+}
+
 // IMainChainLockProxyApprovalIterator is returned from FilterApproval and is used to iterate over the raw logs and unpacked data for Approval events raised by the IMainChainLockProxy contract.
 type IMainChainLockProxyApprovalIterator struct {
 	Event *IMainChainLockProxyApproval // Event containing the contract specifics and raw log
@@ -779,6 +935,413 @@ func (_IMainChainLockProxy *IMainChainLockProxyFilterer) ParseLockEvent(log type
 	return event, nil
 }
 
+// IMainChainLockProxyRefundEventIterator is returned from FilterRefundEvent and is used to iterate over the raw logs and unpacked data for RefundEvent events raised by the IMainChainLockProxy contract.
+type IMainChainLockProxyRefundEventIterator struct {
+	Event *IMainChainLockProxyRefundEvent // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *IMainChainLockProxyRefundEventIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(IMainChainLockProxyRefundEvent)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(IMainChainLockProxyRefundEvent)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *IMainChainLockProxyRefundEventIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *IMainChainLockProxyRefundEventIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// IMainChainLockProxyRefundEvent represents a RefundEvent event raised by the IMainChainLockProxy contract.
+type IMainChainLockProxyRefundEvent struct {
+	ToAddress common.Address
+	Amount    *big.Int
+	Raw       types.Log // Blockchain specific contextual infos
+}
+
+// FilterRefundEvent is a free log retrieval operation binding the contract event 0xbc0482372603ca741e5519a33f61f01dcd0dd79215c58d17fa6977248f68c4bb.
+//
+// Solidity: event RefundEvent(address toAddress, uint256 amount)
+func (_IMainChainLockProxy *IMainChainLockProxyFilterer) FilterRefundEvent(opts *bind.FilterOpts) (*IMainChainLockProxyRefundEventIterator, error) {
+
+	logs, sub, err := _IMainChainLockProxy.contract.FilterLogs(opts, "RefundEvent")
+	if err != nil {
+		return nil, err
+	}
+	return &IMainChainLockProxyRefundEventIterator{contract: _IMainChainLockProxy.contract, event: "RefundEvent", logs: logs, sub: sub}, nil
+}
+
+// WatchRefundEvent is a free log subscription operation binding the contract event 0xbc0482372603ca741e5519a33f61f01dcd0dd79215c58d17fa6977248f68c4bb.
+//
+// Solidity: event RefundEvent(address toAddress, uint256 amount)
+func (_IMainChainLockProxy *IMainChainLockProxyFilterer) WatchRefundEvent(opts *bind.WatchOpts, sink chan<- *IMainChainLockProxyRefundEvent) (event.Subscription, error) {
+
+	logs, sub, err := _IMainChainLockProxy.contract.WatchLogs(opts, "RefundEvent")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(IMainChainLockProxyRefundEvent)
+				if err := _IMainChainLockProxy.contract.UnpackLog(event, "RefundEvent", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseRefundEvent is a log parse operation binding the contract event 0xbc0482372603ca741e5519a33f61f01dcd0dd79215c58d17fa6977248f68c4bb.
+//
+// Solidity: event RefundEvent(address toAddress, uint256 amount)
+func (_IMainChainLockProxy *IMainChainLockProxyFilterer) ParseRefundEvent(log types.Log) (*IMainChainLockProxyRefundEvent, error) {
+	event := new(IMainChainLockProxyRefundEvent)
+	if err := _IMainChainLockProxy.contract.UnpackLog(event, "RefundEvent", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// IMainChainLockProxyRelayerFeeEventIterator is returned from FilterRelayerFeeEvent and is used to iterate over the raw logs and unpacked data for RelayerFeeEvent events raised by the IMainChainLockProxy contract.
+type IMainChainLockProxyRelayerFeeEventIterator struct {
+	Event *IMainChainLockProxyRelayerFeeEvent // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *IMainChainLockProxyRelayerFeeEventIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(IMainChainLockProxyRelayerFeeEvent)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(IMainChainLockProxyRelayerFeeEvent)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *IMainChainLockProxyRelayerFeeEventIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *IMainChainLockProxyRelayerFeeEventIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// IMainChainLockProxyRelayerFeeEvent represents a RelayerFeeEvent event raised by the IMainChainLockProxy contract.
+type IMainChainLockProxyRelayerFeeEvent struct {
+	ToAddress common.Address
+	Fee       *big.Int
+	Refunded  bool
+	Raw       types.Log // Blockchain specific contextual infos
+}
+
+// FilterRelayerFeeEvent is a free log retrieval operation binding the contract event 0xe7e8dff2d4bf6a19efd8f48fdee3b8f729e1cab58b6af260dd8d1a69360069c1.
+//
+// Solidity: event RelayerFeeEvent(address toAddress, uint256 fee, bool refunded)
+func (_IMainChainLockProxy *IMainChainLockProxyFilterer) FilterRelayerFeeEvent(opts *bind.FilterOpts) (*IMainChainLockProxyRelayerFeeEventIterator, error) {
+
+	logs, sub, err := _IMainChainLockProxy.contract.FilterLogs(opts, "RelayerFeeEvent")
+	if err != nil {
+		return nil, err
+	}
+	return &IMainChainLockProxyRelayerFeeEventIterator{contract: _IMainChainLockProxy.contract, event: "RelayerFeeEvent", logs: logs, sub: sub}, nil
+}
+
+// WatchRelayerFeeEvent is a free log subscription operation binding the contract event 0xe7e8dff2d4bf6a19efd8f48fdee3b8f729e1cab58b6af260dd8d1a69360069c1.
+//
+// Solidity: event RelayerFeeEvent(address toAddress, uint256 fee, bool refunded)
+func (_IMainChainLockProxy *IMainChainLockProxyFilterer) WatchRelayerFeeEvent(opts *bind.WatchOpts, sink chan<- *IMainChainLockProxyRelayerFeeEvent) (event.Subscription, error) {
+
+	logs, sub, err := _IMainChainLockProxy.contract.WatchLogs(opts, "RelayerFeeEvent")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(IMainChainLockProxyRelayerFeeEvent)
+				if err := _IMainChainLockProxy.contract.UnpackLog(event, "RelayerFeeEvent", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseRelayerFeeEvent is a log parse operation binding the contract event 0xe7e8dff2d4bf6a19efd8f48fdee3b8f729e1cab58b6af260dd8d1a69360069c1.
+//
+// Solidity: event RelayerFeeEvent(address toAddress, uint256 fee, bool refunded)
+func (_IMainChainLockProxy *IMainChainLockProxyFilterer) ParseRelayerFeeEvent(log types.Log) (*IMainChainLockProxyRelayerFeeEvent, error) {
+	event := new(IMainChainLockProxyRelayerFeeEvent)
+	if err := _IMainChainLockProxy.contract.UnpackLog(event, "RelayerFeeEvent", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// IMainChainLockProxyTransferFailedEventIterator is returned from FilterTransferFailedEvent and is used to iterate over the raw logs and unpacked data for TransferFailedEvent events raised by the IMainChainLockProxy contract.
+type IMainChainLockProxyTransferFailedEventIterator struct {
+	Event *IMainChainLockProxyTransferFailedEvent // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *IMainChainLockProxyTransferFailedEventIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(IMainChainLockProxyTransferFailedEvent)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(IMainChainLockProxyTransferFailedEvent)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *IMainChainLockProxyTransferFailedEventIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *IMainChainLockProxyTransferFailedEventIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// IMainChainLockProxyTransferFailedEvent represents a TransferFailedEvent event raised by the IMainChainLockProxy contract.
+type IMainChainLockProxyTransferFailedEvent struct {
+	FromChainId  uint64
+	CrossChainId []byte
+	Reason       string
+	Raw          types.Log // Blockchain specific contextual infos
+}
+
+// FilterTransferFailedEvent is a free log retrieval operation binding the contract event 0x0c9b87c95acfe666da87aac3e04a4a042d01c082f78bd31f4fa96afe9450a915.
+//
+// Solidity: event TransferFailedEvent(uint64 fromChainId, bytes crossChainId, string reason)
+func (_IMainChainLockProxy *IMainChainLockProxyFilterer) FilterTransferFailedEvent(opts *bind.FilterOpts) (*IMainChainLockProxyTransferFailedEventIterator, error) {
+
+	logs, sub, err := _IMainChainLockProxy.contract.FilterLogs(opts, "TransferFailedEvent")
+	if err != nil {
+		return nil, err
+	}
+	return &IMainChainLockProxyTransferFailedEventIterator{contract: _IMainChainLockProxy.contract, event: "TransferFailedEvent", logs: logs, sub: sub}, nil
+}
+
+// WatchTransferFailedEvent is a free log subscription operation binding the contract event 0x0c9b87c95acfe666da87aac3e04a4a042d01c082f78bd31f4fa96afe9450a915.
+//
+// Solidity: event TransferFailedEvent(uint64 fromChainId, bytes crossChainId, string reason)
+func (_IMainChainLockProxy *IMainChainLockProxyFilterer) WatchTransferFailedEvent(opts *bind.WatchOpts, sink chan<- *IMainChainLockProxyTransferFailedEvent) (event.Subscription, error) {
+
+	logs, sub, err := _IMainChainLockProxy.contract.WatchLogs(opts, "TransferFailedEvent")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(IMainChainLockProxyTransferFailedEvent)
+				if err := _IMainChainLockProxy.contract.UnpackLog(event, "TransferFailedEvent", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseTransferFailedEvent is a log parse operation binding the contract event 0x0c9b87c95acfe666da87aac3e04a4a042d01c082f78bd31f4fa96afe9450a915.
+//
+// Solidity: event TransferFailedEvent(uint64 fromChainId, bytes crossChainId, string reason)
+func (_IMainChainLockProxy *IMainChainLockProxyFilterer) ParseTransferFailedEvent(log types.Log) (*IMainChainLockProxyTransferFailedEvent, error) {
+	event := new(IMainChainLockProxyTransferFailedEvent)
+	if err := _IMainChainLockProxy.contract.UnpackLog(event, "TransferFailedEvent", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
 // IMainChainLockProxyUnlockEventIterator is returned from FilterUnlockEvent and is used to iterate over the raw logs and unpacked data for UnlockEvent events raised by the IMainChainLockProxy contract.
 type IMainChainLockProxyUnlockEventIterator struct {
 	Event *IMainChainLockProxyUnlockEvent // Event containing the contract specifics and raw log
@@ -27,6 +27,8 @@ var (
 )
 
 var (
+	MethodApproveNativeUpgrade = "approveNativeUpgrade"
+
 	MethodPropose = "propose"
 
 	MethodVote = "vote"
@@ -37,6 +39,8 @@ var (
 
 	MethodGetEpochByID = "getEpochByID"
 
+	MethodGetNativeUpgrades = "getNativeUpgrades"
+
 	MethodName = "name"
 
 	MethodProof = "proof"
@@ -45,19 +49,23 @@ var (
 
 	EventEpochChanged = "EpochChanged"
 
+	EventNativeUpgradeApproved = "NativeUpgradeApproved"
+
 	EventProposed = "Proposed"
 
 	EventVoted = "Voted"
 )
 
 // INodeManagerABI is the input ABI used to generate the binding from.
-const INodeManagerABI = "[{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"string\",\"name\":\"method\",\"type\":\"string\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"input\",\"type\":\"bytes\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"signer\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"size\",\"type\":\"uint64\"}],\"name\":\"ConsensusSigned\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"epoch\",\"type\":\"bytes\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"nextEpoch\",\"type\":\"bytes\"}],\"name\":\"EpochChanged\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"epoch\",\"type\":\"bytes\"}],\"name\":\"Proposed\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"epochID\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"epochHash\",\"type\":\"bytes\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"votedNumber\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"groupSize\",\"type\":\"uint64\"}],\"name\":\"Voted\",\"type\":\"event\"},{\"constant\":true,\"inputs\":[],\"name\":\"epoch\",\"outputs\":[{\"internalType\":\"bytes\",\"name\":\"\",\"type\":\"bytes\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"getChangingEpoch\",\"outputs\":[{\"internalType\":\"bytes\",\"name\":\"\",\"type\":\"bytes\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"epochID\",\"type\":\"uint64\"}],\"name\":\"getEpochByID\",\"outputs\":[{\"internalType\":\"bytes\",\"name\":\"\",\"type\":\"bytes\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"name\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"epochID\",\"type\":\"uint64\"}],\"name\":\"proof\",\"outputs\":[{\"internalType\":\"bytes\",\"name\":\"\",\"type\":\"bytes\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"startHeight\",\"type\":\"uint64\"},{\"internalType\":\"bytes\",\"name\":\"peers\",\"type\":\"bytes\"}],\"name\":\"propose\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"epochID\",\"type\":\"uint64\"},{\"internalType\":\"bytes\",\"name\":\"epochHash\",\"type\":\"bytes\"}],\"name\":\"vote\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]"
+const INodeManagerABI = "[{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"string\",\"name\":\"method\",\"type\":\"string\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"input\",\"type\":\"bytes\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"signer\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"size\",\"type\":\"uint64\"}],\"name\":\"ConsensusSigned\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"epoch\",\"type\":\"bytes\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"nextEpoch\",\"type\":\"bytes\"}],\"name\":\"EpochChanged\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"address\",\"name\":\"contractAddress\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"version\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"height\",\"type\":\"uint64\"}],\"name\":\"NativeUpgradeApproved\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"epoch\",\"type\":\"bytes\"}],\"name\":\"Proposed\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"epochID\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"epochHash\",\"type\":\"bytes\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"votedNumber\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"groupSize\",\"type\":\"uint64\"}],\"name\":\"Voted\",\"type\":\"event\"},{\"constant\":false,\"inputs\":[{\"internalType\":\"address\",\"name\":\"contractAddress\",\"type\":\"address\"},{\"internalType\":\"uint64\",\"name\":\"version\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"height\",\"type\":\"uint64\"}],\"name\":\"approveNativeUpgrade\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"epoch\",\"outputs\":[{\"internalType\":\"bytes\",\"name\":\"\",\"type\":\"bytes\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"getChangingEpoch\",\"outputs\":[{\"internalType\":\"bytes\",\"name\":\"\",\"type\":\"bytes\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"epochID\",\"type\":\"uint64\"}],\"name\":\"getEpochByID\",\"outputs\":[{\"internalType\":\"bytes\",\"name\":\"\",\"type\":\"bytes\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"internalType\":\"address\",\"name\":\"contractAddress\",\"type\":\"address\"}],\"name\":\"getNativeUpgrades\",\"outputs\":[{\"internalType\":\"bytes\",\"name\":\"\",\"type\":\"bytes\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"name\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"epochID\",\"type\":\"uint64\"}],\"name\":\"proof\",\"outputs\":[{\"internalType\":\"bytes\",\"name\":\"\",\"type\":\"bytes\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"startHeight\",\"type\":\"uint64\"},{\"internalType\":\"bytes\",\"name\":\"peers\",\"type\":\"bytes\"}],\"name\":\"propose\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"epochID\",\"type\":\"uint64\"},{\"internalType\":\"bytes\",\"name\":\"epochHash\",\"type\":\"bytes\"}],\"name\":\"vote\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]"
 
 // INodeManagerFuncSigs maps the 4-byte function signature to its string representation.
 var INodeManagerFuncSigs = map[string]string{
+	"ed21ecea": "approveNativeUpgrade(address,uint64,uint64)",
 	"900cf0cf": "epoch()",
 	"76b85cd9": "getChangingEpoch()",
 	"b9dda35e": "getEpochByID(uint64)",
+	"45ae75c4": "getNativeUpgrades(address)",
 	"06fdde03": "name()",
 	"418f9899": "proof(uint64)",
 	"bcc12328": "propose(uint64,bytes)",
@@ -299,6 +307,37 @@ func (_INodeManager *INodeManagerCallerSession) GetEpochByID(epochID uint64) ([]
 	return _INodeManager.Contract.GetEpochByID(&_INodeManager.CallOpts, epochID)
 }
 
+// GetNativeUpgrades is a free data retrieval call binding the contract method 0x45ae75c4.
+//
+// Solidity: function getNativeUpgrades(address contractAddress) view returns(bytes)
+func (_INodeManager *INodeManagerCaller) GetNativeUpgrades(opts *bind.CallOpts, contractAddress common.Address) ([]byte, error) {
+	var out []interface{}
+	err := _INodeManager.contract.Call(opts, &out, "getNativeUpgrades", contractAddress)
+
+	if err != nil {
+		return *new([]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]byte)).(*[]byte)
+
+	return out0, err
+
+}
+
+// GetNativeUpgrades is a free data retrieval call binding the contract method 0x45ae75c4.
+//
+// Solidity: function getNativeUpgrades(address contractAddress) view returns(bytes)
+func (_INodeManager *INodeManagerSession) GetNativeUpgrades(contractAddress common.Address) ([]byte, error) {
+	return _INodeManager.Contract.GetNativeUpgrades(&_INodeManager.CallOpts, contractAddress)
+}
+
+// GetNativeUpgrades is a free data retrieval call binding the contract method 0x45ae75c4.
+//
+// Solidity: function getNativeUpgrades(address contractAddress) view returns(bytes)
+func (_INodeManager *INodeManagerCallerSession) GetNativeUpgrades(contractAddress common.Address) ([]byte, error) {
+	return _INodeManager.Contract.GetNativeUpgrades(&_INodeManager.CallOpts, contractAddress)
+}
+
 // Name is a free data retrieval call binding the contract method 0x06fdde03.
 //
 // Solidity: function name() view returns(string)
@@ -361,6 +400,27 @@ func (_INodeManager *INodeManagerCallerSession) Proof(epochID uint64) ([]byte, e
 	return _INodeManager.Contract.Proof(&_INodeManager.CallOpts, epochID)
 }
 
+// ApproveNativeUpgrade is a paid mutator transaction binding the contract method 0xed21ecea.
+//
+// Solidity: function approveNativeUpgrade(address contractAddress, uint64 version, uint64 height) returns(bool)
+func (_INodeManager *INodeManagerTransactor) ApproveNativeUpgrade(opts *bind.TransactOpts, contractAddress common.Address, version uint64, height uint64) (*types.Transaction, error) {
+	return _INodeManager.contract.Transact(opts, "approveNativeUpgrade", contractAddress, version, height)
+}
+
+// ApproveNativeUpgrade is a paid mutator transaction binding the contract method 0xed21ecea.
+//
+// Solidity: function approveNativeUpgrade(address contractAddress, uint64 version, uint64 height) returns(bool)
+func (_INodeManager *INodeManagerSession) ApproveNativeUpgrade(contractAddress common.Address, version uint64, height uint64) (*types.Transaction, error) {
+	return _INodeManager.Contract.ApproveNativeUpgrade(&_INodeManager.TransactOpts, contractAddress, version, height)
+}
+
+// ApproveNativeUpgrade is a paid mutator transaction binding the contract method 0xed21ecea.
+//
+// Solidity: function approveNativeUpgrade(address contractAddress, uint64 version, uint64 height) returns(bool)
+func (_INodeManager *INodeManagerTransactorSession) ApproveNativeUpgrade(contractAddress common.Address, version uint64, height uint64) (*types.Transaction, error) {
+	return _INodeManager.Contract.ApproveNativeUpgrade(&_INodeManager.TransactOpts, contractAddress, version, height)
+}
+
 // Propose is a paid mutator transaction binding the contract method 0xbcc12328.
 //
 // Solidity: function propose(uint64 startHeight, bytes peers) returns(bool)
@@ -675,6 +735,142 @@ func (_INodeManager *INodeManagerFilterer) ParseEpochChanged(log types.Log) (*IN
 	return event, nil
 }
 
+// INodeManagerNativeUpgradeApprovedIterator is returned from FilterNativeUpgradeApproved and is used to iterate over the raw logs and unpacked data for NativeUpgradeApproved events raised by the INodeManager contract.
+type INodeManagerNativeUpgradeApprovedIterator struct {
+	Event *INodeManagerNativeUpgradeApproved // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *INodeManagerNativeUpgradeApprovedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(INodeManagerNativeUpgradeApproved)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(INodeManagerNativeUpgradeApproved)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *INodeManagerNativeUpgradeApprovedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *INodeManagerNativeUpgradeApprovedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// INodeManagerNativeUpgradeApproved represents a NativeUpgradeApproved event raised by the INodeManager contract.
+type INodeManagerNativeUpgradeApproved struct {
+	ContractAddress common.Address
+	Version         uint64
+	Height          uint64
+	Raw             types.Log // Blockchain specific contextual infos
+}
+
+// FilterNativeUpgradeApproved is a free log retrieval operation binding the contract event 0x3c792e6b8ba3e0987492e466b6d8059426e3a2c68708423007a241ce9ad77bc7.
+//
+// Solidity: event NativeUpgradeApproved(address contractAddress, uint64 version, uint64 height)
+func (_INodeManager *INodeManagerFilterer) FilterNativeUpgradeApproved(opts *bind.FilterOpts) (*INodeManagerNativeUpgradeApprovedIterator, error) {
+
+	logs, sub, err := _INodeManager.contract.FilterLogs(opts, "NativeUpgradeApproved")
+	if err != nil {
+		return nil, err
+	}
+	return &INodeManagerNativeUpgradeApprovedIterator{contract: _INodeManager.contract, event: "NativeUpgradeApproved", logs: logs, sub: sub}, nil
+}
+
+// WatchNativeUpgradeApproved is a free log subscription operation binding the contract event 0x3c792e6b8ba3e0987492e466b6d8059426e3a2c68708423007a241ce9ad77bc7.
+//
+// Solidity: event NativeUpgradeApproved(address contractAddress, uint64 version, uint64 height)
+func (_INodeManager *INodeManagerFilterer) WatchNativeUpgradeApproved(opts *bind.WatchOpts, sink chan<- *INodeManagerNativeUpgradeApproved) (event.Subscription, error) {
+
+	logs, sub, err := _INodeManager.contract.WatchLogs(opts, "NativeUpgradeApproved")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(INodeManagerNativeUpgradeApproved)
+				if err := _INodeManager.contract.UnpackLog(event, "NativeUpgradeApproved", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseNativeUpgradeApproved is a log parse operation binding the contract event 0x3c792e6b8ba3e0987492e466b6d8059426e3a2c68708423007a241ce9ad77bc7.
+//
+// Solidity: event NativeUpgradeApproved(address contractAddress, uint64 version, uint64 height)
+func (_INodeManager *INodeManagerFilterer) ParseNativeUpgradeApproved(log types.Log) (*INodeManagerNativeUpgradeApproved, error) {
+	event := new(INodeManagerNativeUpgradeApproved)
+	if err := _INodeManager.contract.UnpackLog(event, "NativeUpgradeApproved", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
 // INodeManagerProposedIterator is returned from FilterProposed and is used to iterate over the raw logs and unpacked data for Proposed events raised by the INodeManager contract.
 type INodeManagerProposedIterator struct {
 	Event *INodeManagerProposed // Event containing the contract specifics and raw log
@@ -31,10 +31,20 @@ var (
 
 	MethodBurn = "burn"
 
+	MethodBurnWithFee = "burnWithFee"
+
 	MethodMint = "mint"
 
+	MethodRefund = "refund"
+
+	MethodRefundRelayerFee = "refundRelayerFee"
+
+	MethodSettle = "settle"
+
 	MethodAllowance = "allowance"
 
+	MethodGetRelayerFee = "getRelayerFee"
+
 	MethodName = "name"
 
 	EventApproval = "Approval"
@@ -42,18 +52,29 @@ var (
 	EventBurnEvent = "BurnEvent"
 
 	EventMintEvent = "MintEvent"
+
+	EventMintFailedEvent = "MintFailedEvent"
+
+	EventRefundEvent = "RefundEvent"
+
+	EventRelayerFeeEvent = "RelayerFeeEvent"
 )
 
 // ISideChainLockProxyABI is the input ABI used to generate the binding from.
-const ISideChainLockProxyABI = "[{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"Approval\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"address\",\"name\":\"fromAssetHash\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"fromAddress\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"toChainId\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"toAssetHash\",\"type\":\"bytes\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"toAddress\",\"type\":\"bytes\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"BurnEvent\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"address\",\"name\":\"toAssetHash\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"toAddress\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"MintEvent\",\"type\":\"event\"},{\"constant\":true,\"inputs\":[{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"}],\"name\":\"allowance\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"approve\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"toChainId\",\"type\":\"uint64\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"burn\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"argsBs\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"fromContractAddr\",\"type\":\"bytes\"},{\"internalType\":\"uint64\",\"name\":\"fromChainId\",\"type\":\"uint64\"}],\"name\":\"mint\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"name\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"}]"
+const ISideChainLockProxyABI = "[{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"Approval\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"address\",\"name\":\"fromAssetHash\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"fromAddress\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"toChainId\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"toAssetHash\",\"type\":\"bytes\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"toAddress\",\"type\":\"bytes\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"BurnEvent\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"address\",\"name\":\"toAssetHash\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"toAddress\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"MintEvent\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"fromChainId\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"transferId\",\"type\":\"bytes\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"reason\",\"type\":\"string\"}],\"name\":\"MintFailedEvent\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"address\",\"name\":\"toAddress\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"RefundEvent\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"address\",\"name\":\"toAddress\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"fee\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"bool\",\"name\":\"refunded\",\"type\":\"bool\"}],\"name\":\"RelayerFeeEvent\",\"type\":\"event\"},{\"constant\":true,\"inputs\":[{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"}],\"name\":\"allowance\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"approve\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"toChainId\",\"type\":\"uint64\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"burn\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"toChainId\",\"type\":\"uint64\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"fee\",\"type\":\"uint256\"}],\"name\":\"burnWithFee\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"transferId\",\"type\":\"bytes\"}],\"name\":\"getRelayerFee\",\"outputs\":[{\"internalType\":\"bytes\",\"name\":\"\",\"type\":\"bytes\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"argsBs\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"fromContractAddr\",\"type\":\"bytes\"},{\"internalType\":\"uint64\",\"name\":\"fromChainId\",\"type\":\"uint64\"}],\"name\":\"mint\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"name\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"argsBs\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"fromContractAddr\",\"type\":\"bytes\"},{\"internalType\":\"uint64\",\"name\":\"fromChainId\",\"type\":\"uint64\"}],\"name\":\"refund\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"transferId\",\"type\":\"bytes\"}],\"name\":\"refundRelayerFee\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"argsBs\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"fromContractAddr\",\"type\":\"bytes\"},{\"internalType\":\"uint64\",\"name\":\"fromChainId\",\"type\":\"uint64\"}],\"name\":\"settle\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]"
 
 // ISideChainLockProxyFuncSigs maps the 4-byte function signature to its string representation.
 var ISideChainLockProxyFuncSigs = map[string]string{
 	"dd62ed3e": "allowance(address,address)",
 	"095ea7b3": "approve(address,uint256)",
 	"1a6e5f3b": "burn(uint64,uint256)",
+	"5a3c4ae0": "burnWithFee(uint64,uint256,uint256)",
+	"6d83c839": "getRelayerFee(bytes)",
 	"48e6dbbb": "mint(bytes,bytes,uint64)",
 	"06fdde03": "name()",
+	"d5ab9ddd": "refund(bytes,bytes,uint64)",
+	"058c4de6": "refundRelayerFee(bytes)",
+	"f212f5f1": "settle(bytes,bytes,uint64)",
 }
 
 // ISideChainLockProxy is an auto generated Go binding around an Ethereum contract.
@@ -229,6 +250,37 @@ func (_ISideChainLockProxy *ISideChainLockProxyCallerSession) Allowance(owner co
 	return _ISideChainLockProxy.Contract.Allowance(&_ISideChainLockProxy.CallOpts, owner, spender)
 }
 
+// GetRelayerFee is a free data retrieval call binding the contract method 0x6d83c839.
+//
+// Solidity: function getRelayerFee(bytes transferId) view returns(bytes)
+func (_ISideChainLockProxy *ISideChainLockProxyCaller) GetRelayerFee(opts *bind.CallOpts, transferId []byte) ([]byte, error) {
+	var out []interface{}
+	err := _ISideChainLockProxy.contract.Call(opts, &out, "getRelayerFee", transferId)
+
+	if err != nil {
+		return *new([]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]byte)).(*[]byte)
+
+	return out0, err
+
+}
+
+// GetRelayerFee is a free data retrieval call binding the contract method 0x6d83c839.
+//
+// Solidity: function getRelayerFee(bytes transferId) view returns(bytes)
+func (_ISideChainLockProxy *ISideChainLockProxySession) GetRelayerFee(transferId []byte) ([]byte, error) {
+	return _ISideChainLockProxy.Contract.GetRelayerFee(&_ISideChainLockProxy.CallOpts, transferId)
+}
+
+// GetRelayerFee is a free data retrieval call binding the contract method 0x6d83c839.
+//
+// Solidity: function getRelayerFee(bytes transferId) view returns(bytes)
+func (_ISideChainLockProxy *ISideChainLockProxyCallerSession) GetRelayerFee(transferId []byte) ([]byte, error) {
+	return _ISideChainLockProxy.Contract.GetRelayerFee(&_ISideChainLockProxy.CallOpts, transferId)
+}
+
 // Name is a free data retrieval call binding the contract method 0x06fdde03.
 //
 // Solidity: function name() view returns(string)
@@ -302,6 +354,27 @@ func (_ISideChainLockProxy *ISideChainLockProxyTransactorSession) Burn(toChainId
 	return _ISideChainLockProxy.Contract.Burn(&_ISideChainLockProxy.TransactOpts, toChainId, amount)
 }
 
+// BurnWithFee is a paid mutator transaction binding the contract method 0x5a3c4ae0.
+//
+// Solidity: function burnWithFee(uint64 toChainId, uint256 amount, uint256 fee) returns(bool)
+func (_ISideChainLockProxy *ISideChainLockProxyTransactor) BurnWithFee(opts *bind.TransactOpts, toChainId uint64, amount *big.Int, fee *big.Int) (*types.Transaction, error) {
+	return _ISideChainLockProxy.contract.Transact(opts, "burnWithFee", toChainId, amount, fee)
+}
+
+// BurnWithFee is a paid mutator transaction binding the contract method 0x5a3c4ae0.
+//
+// Solidity: function burnWithFee(uint64 toChainId, uint256 amount, uint256 fee) returns(bool)
+func (_ISideChainLockProxy *ISideChainLockProxySession) BurnWithFee(toChainId uint64, amount *big.Int, fee *big.Int) (*types.Transaction, error) {
+	return _ISideChainLockProxy.Contract.BurnWithFee(&_ISideChainLockProxy.TransactOpts, toChainId, amount, fee)
+}
+
+// BurnWithFee is a paid mutator transaction binding the contract method 0x5a3c4ae0.
+//
+// Solidity: function burnWithFee(uint64 toChainId, uint256 amount, uint256 fee) returns(bool)
+func (_ISideChainLockProxy *ISideChainLockProxyTransactorSession) BurnWithFee(toChainId uint64, amount *big.Int, fee *big.Int) (*types.Transaction, error) {
+	return _ISideChainLockProxy.Contract.BurnWithFee(&_ISideChainLockProxy.TransactOpts, toChainId, amount, fee)
+}
+
 // Mint is a paid mutator transaction binding the contract method 0x48e6dbbb.
 //
 // Solidity: function mint(bytes argsBs, bytes fromContractAddr, uint64 fromChainId) returns(bool)
@@ -323,6 +396,69 @@ func (_ISideChainLockProxy *ISideChainLockProxyTransactorSession) Mint(argsBs []
 	return _ISideChainLockProxy.Contract.Mint(&_ISideChainLockProxy.TransactOpts, argsBs, fromContractAddr, fromChainId)
 }
 
+// Refund is a paid mutator transaction binding the contract method 0xd5ab9ddd.
+//
+// Solidity: function refund(bytes argsBs, bytes fromContractAddr, uint64 fromChainId) returns(bool)
+func (_ISideChainLockProxy *ISideChainLockProxyTransactor) Refund(opts *bind.TransactOpts, argsBs []byte, fromContractAddr []byte, fromChainId uint64) (*types.Transaction, error) {
+	return _ISideChainLockProxy.contract.Transact(opts, "refund", argsBs, fromContractAddr, fromChainId)
+}
+
+// Refund is a paid mutator transaction binding the contract method 0xd5ab9ddd.
+//
+// Solidity: function refund(bytes argsBs, bytes fromContractAddr, uint64 fromChainId) returns(bool)
+func (_ISideChainLockProxy *ISideChainLockProxySession) Refund(argsBs []byte, fromContractAddr []byte, fromChainId uint64) (*types.Transaction, error) {
+	return _ISideChainLockProxy.Contract.Refund(&_ISideChainLockProxy.TransactOpts, argsBs, fromContractAddr, fromChainId)
+}
+
+// Refund is a paid mutator transaction binding the contract method 0xd5ab9ddd.
+//
+// Solidity: function refund(bytes argsBs, bytes fromContractAddr, uint64 fromChainId) returns(bool)
+func (_ISideChainLockProxy *ISideChainLockProxyTransactorSession) Refund(argsBs []byte, fromContractAddr []byte, fromChainId uint64) (*types.Transaction, error) {
+	return _ISideChainLockProxy.Contract.Refund(&_ISideChainLockProxy.TransactOpts, argsBs, fromContractAddr, fromChainId)
+}
+
+// RefundRelayerFee is a paid mutator transaction binding the contract method 0x058c4de6.
+//
+// Solidity: function refundRelayerFee(bytes transferId) returns(bool)
+func (_ISideChainLockProxy *ISideChainLockProxyTransactor) RefundRelayerFee(opts *bind.TransactOpts, transferId []byte) (*types.Transaction, error) {
+	return _ISideChainLockProxy.contract.Transact(opts, "refundRelayerFee", transferId)
+}
+
+// RefundRelayerFee is a paid mutator transaction binding the contract method 0x058c4de6.
+//
+// Solidity: function refundRelayerFee(bytes transferId) returns(bool)
+func (_ISideChainLockProxy *ISideChainLockProxySession) RefundRelayerFee(transferId []byte) (*types.Transaction, error) {
+	return _ISideChainLockProxy.Contract.RefundRelayerFee(&_ISideChainLockProxy.TransactOpts, transferId)
+}
+
+// RefundRelayerFee is a paid mutator transaction binding the contract method 0x058c4de6.
+//
+// Solidity: function refundRelayerFee(bytes transferId) returns(bool)
+func (_ISideChainLockProxy *ISideChainLockProxyTransactorSession) RefundRelayerFee(transferId []byte) (*types.Transaction, error) {
+	return _ISideChainLockProxy.Contract.RefundRelayerFee(&_ISideChainLockProxy.TransactOpts, transferId)
+}
+
+// Settle is a paid mutator transaction binding the contract method 0xf212f5f1.
+//
+// Solidity: function settle(bytes argsBs, bytes fromContractAddr, uint64 fromChainId) returns(bool)
+func (_ISideChainLockProxy *ISideChainLockProxyTransactor) Settle(opts *bind.TransactOpts, argsBs []byte, fromContractAddr []byte, fromChainId uint64) (*types.Transaction, error) {
+	return _ISideChainLockProxy.contract.Transact(opts, "settle", argsBs, fromContractAddr, fromChainId)
+}
+
+// Settle is a paid mutator transaction binding the contract method 0xf212f5f1.
+//
+// Solidity: function settle(bytes argsBs, bytes fromContractAddr, uint64 fromChainId) returns(bool)
+func (_ISideChainLockProxy *ISideChainLockProxySession) Settle(argsBs []byte, fromContractAddr []byte, fromChainId uint64) (*types.Transaction, error) {
+	return _ISideChainLockProxy.Contract.Settle(&_ISideChainLockProxy.TransactOpts, argsBs, fromContractAddr, fromChainId)
+}
+
+// Settle is a paid mutator transaction binding the contract method 0xf212f5f1.
+//
+// Solidity: function settle(bytes argsBs, bytes fromContractAddr, uint64 fromChainId) returns(bool)
+func (_ISideChainLockProxy *ISideChainLockProxyTransactorSession) Settle(argsBs []byte, fromContractAddr []byte, fromChainId uint64) (*types.Transaction, error) {
+	return _ISideChainLockProxy.Contract.Settle(&_ISideChainLockProxy.TransactOpts, argsBs, fromContractAddr, fromChainId)
+}
+
 // ISideChainLockProxyApprovalIterator is returned from FilterApproval and is used to iterate over the raw logs and unpacked data for Approval events raised by the ISideChainLockProxy contract.
 type ISideChainLockProxyApprovalIterator struct {
 	Event *ISideChainLockProxyApproval // Event containing the contract specifics and raw log
@@ -752,3 +888,409 @@ func (_ISideChainLockProxy *ISideChainLockProxyFilterer) ParseMintEvent(log type
 	return event, nil
 }
 
+// ISideChainLockProxyMintFailedEventIterator is returned from FilterMintFailedEvent and is used to iterate over the raw logs and unpacked data for MintFailedEvent events raised by the ISideChainLockProxy contract.
+type ISideChainLockProxyMintFailedEventIterator struct {
+	Event *ISideChainLockProxyMintFailedEvent // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ISideChainLockProxyMintFailedEventIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ISideChainLockProxyMintFailedEvent)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ISideChainLockProxyMintFailedEvent)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ISideChainLockProxyMintFailedEventIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ISideChainLockProxyMintFailedEventIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ISideChainLockProxyMintFailedEvent represents a MintFailedEvent event raised by the ISideChainLockProxy contract.
+type ISideChainLockProxyMintFailedEvent struct {
+	FromChainId uint64
+	TransferId  []byte
+	Reason      string
+	Raw         types.Log // Blockchain specific contextual infos
+}
+
+// FilterMintFailedEvent is a free log retrieval operation binding the contract event 0x7a5333a20b1458d646b8bfbd2171eca3e565840125fa424357e75dc6d22c514e.
+//
+// Solidity: event MintFailedEvent(uint64 fromChainId, bytes transferId, string reason)
+func (_ISideChainLockProxy *ISideChainLockProxyFilterer) FilterMintFailedEvent(opts *bind.FilterOpts) (*ISideChainLockProxyMintFailedEventIterator, error) {
+
+	logs, sub, err := _ISideChainLockProxy.contract.FilterLogs(opts, "MintFailedEvent")
+	if err != nil {
+		return nil, err
+	}
+	return &ISideChainLockProxyMintFailedEventIterator{contract: _ISideChainLockProxy.contract, event: "MintFailedEvent", logs: logs, sub: sub}, nil
+}
+
+// WatchMintFailedEvent is a free log subscription operation binding the contract event 0x7a5333a20b1458d646b8bfbd2171eca3e565840125fa424357e75dc6d22c514e.
+//
+// Solidity: event MintFailedEvent(uint64 fromChainId, bytes transferId, string reason)
+func (_ISideChainLockProxy *ISideChainLockProxyFilterer) WatchMintFailedEvent(opts *bind.WatchOpts, sink chan<- *ISideChainLockProxyMintFailedEvent) (event.Subscription, error) {
+
+	logs, sub, err := _ISideChainLockProxy.contract.WatchLogs(opts, "MintFailedEvent")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ISideChainLockProxyMintFailedEvent)
+				if err := _ISideChainLockProxy.contract.UnpackLog(event, "MintFailedEvent", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseMintFailedEvent is a log parse operation binding the contract event 0x7a5333a20b1458d646b8bfbd2171eca3e565840125fa424357e75dc6d22c514e.
+//
+// Solidity: event MintFailedEvent(uint64 fromChainId, bytes transferId, string reason)
+func (_ISideChainLockProxy *ISideChainLockProxyFilterer) ParseMintFailedEvent(log types.Log) (*ISideChainLockProxyMintFailedEvent, error) {
+	event := new(ISideChainLockProxyMintFailedEvent)
+	if err := _ISideChainLockProxy.contract.UnpackLog(event, "MintFailedEvent", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// ISideChainLockProxyRefundEventIterator is returned from FilterRefundEvent and is used to iterate over the raw logs and unpacked data for RefundEvent events raised by the ISideChainLockProxy contract.
+type ISideChainLockProxyRefundEventIterator struct {
+	Event *ISideChainLockProxyRefundEvent // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ISideChainLockProxyRefundEventIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ISideChainLockProxyRefundEvent)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ISideChainLockProxyRefundEvent)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ISideChainLockProxyRefundEventIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ISideChainLockProxyRefundEventIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ISideChainLockProxyRefundEvent represents a RefundEvent event raised by the ISideChainLockProxy contract.
+type ISideChainLockProxyRefundEvent struct {
+	ToAddress common.Address
+	Amount    *big.Int
+	Raw       types.Log // Blockchain specific contextual infos
+}
+
+// FilterRefundEvent is a free log retrieval operation binding the contract event 0xbc0482372603ca741e5519a33f61f01dcd0dd79215c58d17fa6977248f68c4bb.
+//
+// Solidity: event RefundEvent(address toAddress, uint256 amount)
+func (_ISideChainLockProxy *ISideChainLockProxyFilterer) FilterRefundEvent(opts *bind.FilterOpts) (*ISideChainLockProxyRefundEventIterator, error) {
+
+	logs, sub, err := _ISideChainLockProxy.contract.FilterLogs(opts, "RefundEvent")
+	if err != nil {
+		return nil, err
+	}
+	return &ISideChainLockProxyRefundEventIterator{contract: _ISideChainLockProxy.contract, event: "RefundEvent", logs: logs, sub: sub}, nil
+}
+
+// WatchRefundEvent is a free log subscription operation binding the contract event 0xbc0482372603ca741e5519a33f61f01dcd0dd79215c58d17fa6977248f68c4bb.
+//
+// Solidity: event RefundEvent(address toAddress, uint256 amount)
+func (_ISideChainLockProxy *ISideChainLockProxyFilterer) WatchRefundEvent(opts *bind.WatchOpts, sink chan<- *ISideChainLockProxyRefundEvent) (event.Subscription, error) {
+
+	logs, sub, err := _ISideChainLockProxy.contract.WatchLogs(opts, "RefundEvent")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ISideChainLockProxyRefundEvent)
+				if err := _ISideChainLockProxy.contract.UnpackLog(event, "RefundEvent", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseRefundEvent is a log parse operation binding the contract event 0xbc0482372603ca741e5519a33f61f01dcd0dd79215c58d17fa6977248f68c4bb.
+//
+// Solidity: event RefundEvent(address toAddress, uint256 amount)
+func (_ISideChainLockProxy *ISideChainLockProxyFilterer) ParseRefundEvent(log types.Log) (*ISideChainLockProxyRefundEvent, error) {
+	event := new(ISideChainLockProxyRefundEvent)
+	if err := _ISideChainLockProxy.contract.UnpackLog(event, "RefundEvent", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// ISideChainLockProxyRelayerFeeEventIterator is returned from FilterRelayerFeeEvent and is used to iterate over the raw logs and unpacked data for RelayerFeeEvent events raised by the ISideChainLockProxy contract.
+type ISideChainLockProxyRelayerFeeEventIterator struct {
+	Event *ISideChainLockProxyRelayerFeeEvent // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ISideChainLockProxyRelayerFeeEventIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ISideChainLockProxyRelayerFeeEvent)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ISideChainLockProxyRelayerFeeEvent)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ISideChainLockProxyRelayerFeeEventIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ISideChainLockProxyRelayerFeeEventIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ISideChainLockProxyRelayerFeeEvent represents a RelayerFeeEvent event raised by the ISideChainLockProxy contract.
+type ISideChainLockProxyRelayerFeeEvent struct {
+	ToAddress common.Address
+	Fee       *big.Int
+	Refunded  bool
+	Raw       types.Log // Blockchain specific contextual infos
+}
+
+// FilterRelayerFeeEvent is a free log retrieval operation binding the contract event 0xe7e8dff2d4bf6a19efd8f48fdee3b8f729e1cab58b6af260dd8d1a69360069c1.
+//
+// Solidity: event RelayerFeeEvent(address toAddress, uint256 fee, bool refunded)
+func (_ISideChainLockProxy *ISideChainLockProxyFilterer) FilterRelayerFeeEvent(opts *bind.FilterOpts) (*ISideChainLockProxyRelayerFeeEventIterator, error) {
+
+	logs, sub, err := _ISideChainLockProxy.contract.FilterLogs(opts, "RelayerFeeEvent")
+	if err != nil {
+		return nil, err
+	}
+	return &ISideChainLockProxyRelayerFeeEventIterator{contract: _ISideChainLockProxy.contract, event: "RelayerFeeEvent", logs: logs, sub: sub}, nil
+}
+
+// WatchRelayerFeeEvent is a free log subscription operation binding the contract event 0xe7e8dff2d4bf6a19efd8f48fdee3b8f729e1cab58b6af260dd8d1a69360069c1.
+//
+// Solidity: event RelayerFeeEvent(address toAddress, uint256 fee, bool refunded)
+func (_ISideChainLockProxy *ISideChainLockProxyFilterer) WatchRelayerFeeEvent(opts *bind.WatchOpts, sink chan<- *ISideChainLockProxyRelayerFeeEvent) (event.Subscription, error) {
+
+	logs, sub, err := _ISideChainLockProxy.contract.WatchLogs(opts, "RelayerFeeEvent")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ISideChainLockProxyRelayerFeeEvent)
+				if err := _ISideChainLockProxy.contract.UnpackLog(event, "RelayerFeeEvent", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseRelayerFeeEvent is a log parse operation binding the contract event 0xe7e8dff2d4bf6a19efd8f48fdee3b8f729e1cab58b6af260dd8d1a69360069c1.
+//
+// Solidity: event RelayerFeeEvent(address toAddress, uint256 fee, bool refunded)
+func (_ISideChainLockProxy *ISideChainLockProxyFilterer) ParseRelayerFeeEvent(log types.Log) (*ISideChainLockProxyRelayerFeeEvent, error) {
+	event := new(ISideChainLockProxyRelayerFeeEvent)
+	if err := _ISideChainLockProxy.contract.UnpackLog(event, "RelayerFeeEvent", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -40,6 +40,12 @@ var (
 
 	MethodApproveUpdateSideChain = "approveUpdateSideChain"
 
+	MethodApproveUpdateVoterCommittee = "approveUpdateVoterCommittee"
+
+	MethodGetSideChain = "getSideChain"
+
+	MethodGetVoterCommittee = "getVoterCommittee"
+
 	MethodName = "name"
 
 	MethodQuitSideChain = "quitSideChain"
@@ -51,22 +57,28 @@ var (
 	MethodSetBtcTxParam = "setBtcTxParam"
 
 	MethodUpdateSideChain = "updateSideChain"
+
+	MethodUpdateVoterCommittee = "updateVoterCommittee"
 )
 
 // SideChainManagerABI is the input ABI used to generate the binding from.
-const SideChainManagerABI = "[{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"ChainId\",\"type\":\"uint64\"}],\"name\":\"evtApproveQuitSideChain\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"ChainId\",\"type\":\"uint64\"}],\"name\":\"evtApproveRegisterSideChain\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"ChainId\",\"type\":\"uint64\"}],\"name\":\"evtApproveUpdateSideChain\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"ChainId\",\"type\":\"uint64\"}],\"name\":\"evtQuitSideChain\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"string\",\"name\":\"rk\",\"type\":\"string\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"ContractAddress\",\"type\":\"string\"}],\"name\":\"evtRegisterRedeem\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"ChainId\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"Router\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"Name\",\"type\":\"string\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"BlocksToWait\",\"type\":\"uint64\"}],\"name\":\"evtRegisterSideChain\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"string\",\"name\":\"rk\",\"type\":\"string\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"RedeemChainId\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"FeeRate\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"MinChange\",\"type\":\"uint64\"}],\"name\":\"evtSetBtcTxParam\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"ChainId\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"Router\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"Name\",\"type\":\"string\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"BlocksToWait\",\"type\":\"uint64\"}],\"name\":\"evtUpdateSideChain\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"Chainid\",\"type\":\"uint64\"},{\"internalType\":\"address\",\"name\":\"Address\",\"type\":\"address\"}],\"name\":\"approveQuitSideChain\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"success\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"Chainid\",\"type\":\"uint64\"},{\"internalType\":\"address\",\"name\":\"Address\",\"type\":\"address\"}],\"name\":\"approveRegisterSideChain\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"success\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"Chainid\",\"type\":\"uint64\"},{\"internalType\":\"address\",\"name\":\"Address\",\"type\":\"address\"}],\"name\":\"approveUpdateSideChain\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"success\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"name\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"Name\",\"type\":\"string\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"Chainid\",\"type\":\"uint64\"},{\"internalType\":\"address\",\"name\":\"Address\",\"type\":\"address\"}],\"name\":\"quitSideChain\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"success\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"RedeemChainID\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"ContractChainID\",\"type\":\"uint64\"},{\"internalType\":\"bytes\",\"name\":\"Redeem\",\"type\":\"bytes\"},{\"internalType\":\"uint64\",\"name\":\"CVersion\",\"type\":\"uint64\"},{\"internalType\":\"bytes\",\"name\":\"ContractAddress\",\"type\":\"bytes\"},{\"internalType\":\"bytes[]\",\"name\":\"Signs\",\"type\":\"bytes[]\"}],\"name\":\"registerRedeem\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"success\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"Address\",\"type\":\"address\"},{\"internalType\":\"uint64\",\"name\":\"ChainId\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"Router\",\"type\":\"uint64\"},{\"internalType\":\"string\",\"name\":\"Name\",\"type\":\"string\"},{\"internalType\":\"uint64\",\"name\":\"BlocksToWait\",\"type\":\"uint64\"},{\"internalType\":\"bytes\",\"name\":\"CCMCAddress\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"ExtraInfo\",\"type\":\"bytes\"}],\"name\":\"registerSideChain\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"success\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"Redeem\",\"type\":\"bytes\"},{\"internalType\":\"uint64\",\"name\":\"RedeemChainId\",\"type\":\"uint64\"},{\"internalType\":\"bytes[]\",\"name\":\"Sigs\",\"type\":\"bytes[]\"},{\"components\":[{\"internalType\":\"uint64\",\"name\":\"PVersion\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"FeeRate\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"MinChange\",\"type\":\"uint64\"}],\"internalType\":\"structside_chain_manager.BtcTxParamDetial\",\"name\":\"Detial\",\"type\":\"tuple\"}],\"name\":\"setBtcTxParam\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"success\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"Address\",\"type\":\"address\"},{\"internalType\":\"uint64\",\"name\":\"ChainId\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"Router\",\"type\":\"uint64\"},{\"internalType\":\"string\",\"name\":\"Name\",\"type\":\"string\"},{\"internalType\":\"uint64\",\"name\":\"BlocksToWait\",\"type\":\"uint64\"},{\"internalType\":\"bytes\",\"name\":\"CCMCAddress\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"ExtraInfo\",\"type\":\"bytes\"}],\"name\":\"updateSideChain\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"success\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]"
+const SideChainManagerABI = "[{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"ChainId\",\"type\":\"uint64\"}],\"name\":\"evtApproveQuitSideChain\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"ChainId\",\"type\":\"uint64\"}],\"name\":\"evtApproveRegisterSideChain\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"ChainId\",\"type\":\"uint64\"}],\"name\":\"evtApproveUpdateSideChain\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"ChainId\",\"type\":\"uint64\"}],\"name\":\"evtApproveUpdateVoterCommittee\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"ChainId\",\"type\":\"uint64\"}],\"name\":\"evtQuitSideChain\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"string\",\"name\":\"rk\",\"type\":\"string\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"ContractAddress\",\"type\":\"string\"}],\"name\":\"evtRegisterRedeem\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"ChainId\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"Router\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"Name\",\"type\":\"string\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"BlocksToWait\",\"type\":\"uint64\"}],\"name\":\"evtRegisterSideChain\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"string\",\"name\":\"rk\",\"type\":\"string\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"RedeemChainId\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"FeeRate\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"MinChange\",\"type\":\"uint64\"}],\"name\":\"evtSetBtcTxParam\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"ChainId\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"Router\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"Name\",\"type\":\"string\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"BlocksToWait\",\"type\":\"uint64\"}],\"name\":\"evtUpdateSideChain\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"ChainId\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"Threshold\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"VoteExpiry\",\"type\":\"uint64\"}],\"name\":\"evtUpdateVoterCommittee\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"Chainid\",\"type\":\"uint64\"},{\"internalType\":\"address\",\"name\":\"Address\",\"type\":\"address\"}],\"name\":\"approveQuitSideChain\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"success\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"Chainid\",\"type\":\"uint64\"},{\"internalType\":\"address\",\"name\":\"Address\",\"type\":\"address\"}],\"name\":\"approveRegisterSideChain\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"success\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"Chainid\",\"type\":\"uint64\"},{\"internalType\":\"address\",\"name\":\"Address\",\"type\":\"address\"}],\"name\":\"approveUpdateSideChain\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"success\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"Chainid\",\"type\":\"uint64\"},{\"internalType\":\"address\",\"name\":\"Address\",\"type\":\"address\"}],\"name\":\"approveUpdateVoterCommittee\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"success\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"ChainId\",\"type\":\"uint64\"}],\"name\":\"getSideChain\",\"outputs\":[{\"internalType\":\"bytes\",\"name\":\"SideChain\",\"type\":\"bytes\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"ChainId\",\"type\":\"uint64\"}],\"name\":\"getVoterCommittee\",\"outputs\":[{\"internalType\":\"bytes\",\"name\":\"VoterCommittee\",\"type\":\"bytes\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"name\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"Name\",\"type\":\"string\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"Chainid\",\"type\":\"uint64\"},{\"internalType\":\"address\",\"name\":\"Address\",\"type\":\"address\"}],\"name\":\"quitSideChain\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"success\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"RedeemChainID\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"ContractChainID\",\"type\":\"uint64\"},{\"internalType\":\"bytes\",\"name\":\"Redeem\",\"type\":\"bytes\"},{\"internalType\":\"uint64\",\"name\":\"CVersion\",\"type\":\"uint64\"},{\"internalType\":\"bytes\",\"name\":\"ContractAddress\",\"type\":\"bytes\"},{\"internalType\":\"bytes[]\",\"name\":\"Signs\",\"type\":\"bytes[]\"}],\"name\":\"registerRedeem\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"success\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"Address\",\"type\":\"address\"},{\"internalType\":\"uint64\",\"name\":\"ChainId\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"Router\",\"type\":\"uint64\"},{\"internalType\":\"string\",\"name\":\"Name\",\"type\":\"string\"},{\"internalType\":\"uint64\",\"name\":\"BlocksToWait\",\"type\":\"uint64\"},{\"internalType\":\"bytes\",\"name\":\"CCMCAddress\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"ExtraInfo\",\"type\":\"bytes\"}],\"name\":\"registerSideChain\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"success\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"Redeem\",\"type\":\"bytes\"},{\"internalType\":\"uint64\",\"name\":\"RedeemChainId\",\"type\":\"uint64\"},{\"internalType\":\"bytes[]\",\"name\":\"Sigs\",\"type\":\"bytes[]\"},{\"components\":[{\"internalType\":\"uint64\",\"name\":\"PVersion\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"FeeRate\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"MinChange\",\"type\":\"uint64\"}],\"internalType\":\"structside_chain_manager.BtcTxParamDetial\",\"name\":\"Detial\",\"type\":\"tuple\"}],\"name\":\"setBtcTxParam\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"success\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"Address\",\"type\":\"address\"},{\"internalType\":\"uint64\",\"name\":\"ChainId\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"Router\",\"type\":\"uint64\"},{\"internalType\":\"string\",\"name\":\"Name\",\"type\":\"string\"},{\"internalType\":\"uint64\",\"name\":\"BlocksToWait\",\"type\":\"uint64\"},{\"internalType\":\"bytes\",\"name\":\"CCMCAddress\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"ExtraInfo\",\"type\":\"bytes\"}],\"name\":\"updateSideChain\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"success\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"Address\",\"type\":\"address\"},{\"internalType\":\"uint64\",\"name\":\"ChainId\",\"type\":\"uint64\"},{\"internalType\":\"address[]\",\"name\":\"Voters\",\"type\":\"address[]\"},{\"internalType\":\"uint64\",\"name\":\"Threshold\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"VoteExpiry\",\"type\":\"uint64\"}],\"name\":\"updateVoterCommittee\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"success\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]"
 
 // SideChainManagerFuncSigs maps the 4-byte function signature to its string representation.
 var SideChainManagerFuncSigs = map[string]string{
 	"6c8ac5c1": "approveQuitSideChain(uint64,address)",
 	"65764e16": "approveRegisterSideChain(uint64,address)",
 	"805b508e": "approveUpdateSideChain(uint64,address)",
+	"69bae6f3": "approveUpdateVoterCommittee(uint64,address)",
+	"84838fb8": "getSideChain(uint64)",
+	"48130bdc": "getVoterCommittee(uint64)",
 	"06fdde03": "name()",
 	"7460736e": "quitSideChain(uint64,address)",
 	"33e1d41a": "registerRedeem(uint64,uint64,bytes,uint64,bytes,bytes[])",
 	"ab7a2037": "registerSideChain(address,uint64,uint64,string,uint64,bytes,bytes)",
 	"ee9891e3": "setBtcTxParam(bytes,uint64,bytes[],(uint64,uint64,uint64))",
 	"f7782f81": "updateSideChain(address,uint64,uint64,string,uint64,bytes,bytes)",
+	"a6411865": "updateVoterCommittee(address,uint64,address[],uint64,uint64)",
 }
 
 // SideChainManagerBin is the compiled bytecode used for deploying new contracts.
@@ -228,6 +240,68 @@ func (_SideChainManager *SideChainManagerTransactorRaw) Transact(opts *bind.Tran
 	return _SideChainManager.Contract.contract.Transact(opts, method, params...)
 }
 
+// GetSideChain is a free data retrieval call binding the contract method 0x84838fb8.
+//
+// Solidity: function getSideChain(uint64 ChainId) view returns(bytes SideChain)
+func (_SideChainManager *SideChainManagerCaller) GetSideChain(opts *bind.CallOpts, ChainId uint64) ([]byte, error) {
+	var out []interface{}
+	err := _SideChainManager.contract.Call(opts, &out, "getSideChain", ChainId)
+
+	if err != nil {
+		return *new([]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]byte)).(*[]byte)
+
+	return out0, err
+
+}
+
+// GetSideChain is a free data retrieval call binding the contract method 0x84838fb8.
+//
+// Solidity: function getSideChain(uint64 ChainId) view returns(bytes SideChain)
+func (_SideChainManager *SideChainManagerSession) GetSideChain(ChainId uint64) ([]byte, error) {
+	return _SideChainManager.Contract.GetSideChain(&_SideChainManager.CallOpts, ChainId)
+}
+
+// GetSideChain is a free data retrieval call binding the contract method 0x84838fb8.
+//
+// Solidity: function getSideChain(uint64 ChainId) view returns(bytes SideChain)
+func (_SideChainManager *SideChainManagerCallerSession) GetSideChain(ChainId uint64) ([]byte, error) {
+	return _SideChainManager.Contract.GetSideChain(&_SideChainManager.CallOpts, ChainId)
+}
+
+// GetVoterCommittee is a free data retrieval call binding the contract method 0x48130bdc.
+//
+// Solidity: function getVoterCommittee(uint64 ChainId) view returns(bytes VoterCommittee)
+func (_SideChainManager *SideChainManagerCaller) GetVoterCommittee(opts *bind.CallOpts, ChainId uint64) ([]byte, error) {
+	var out []interface{}
+	err := _SideChainManager.contract.Call(opts, &out, "getVoterCommittee", ChainId)
+
+	if err != nil {
+		return *new([]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]byte)).(*[]byte)
+
+	return out0, err
+
+}
+
+// GetVoterCommittee is a free data retrieval call binding the contract method 0x48130bdc.
+//
+// Solidity: function getVoterCommittee(uint64 ChainId) view returns(bytes VoterCommittee)
+func (_SideChainManager *SideChainManagerSession) GetVoterCommittee(ChainId uint64) ([]byte, error) {
+	return _SideChainManager.Contract.GetVoterCommittee(&_SideChainManager.CallOpts, ChainId)
+}
+
+// GetVoterCommittee is a free data retrieval call binding the contract method 0x48130bdc.
+//
+// Solidity: function getVoterCommittee(uint64 ChainId) view returns(bytes VoterCommittee)
+func (_SideChainManager *SideChainManagerCallerSession) GetVoterCommittee(ChainId uint64) ([]byte, error) {
+	return _SideChainManager.Contract.GetVoterCommittee(&_SideChainManager.CallOpts, ChainId)
+}
+
 // ApproveQuitSideChain is a paid mutator transaction binding the contract method 0x6c8ac5c1.
 //
 // Solidity: function approveQuitSideChain(uint64 Chainid, address Address) returns(bool success)
@@ -291,6 +365,27 @@ func (_SideChainManager *SideChainManagerTransactorSession) ApproveUpdateSideCha
 	return _SideChainManager.Contract.ApproveUpdateSideChain(&_SideChainManager.TransactOpts, Chainid, Address)
 }
 
+// ApproveUpdateVoterCommittee is a paid mutator transaction binding the contract method 0x69bae6f3.
+//
+// Solidity: function approveUpdateVoterCommittee(uint64 Chainid, address Address) returns(bool success)
+func (_SideChainManager *SideChainManagerTransactor) ApproveUpdateVoterCommittee(opts *bind.TransactOpts, Chainid uint64, Address common.Address) (*types.Transaction, error) {
+	return _SideChainManager.contract.Transact(opts, "approveUpdateVoterCommittee", Chainid, Address)
+}
+
+// ApproveUpdateVoterCommittee is a paid mutator transaction binding the contract method 0x69bae6f3.
+//
+// Solidity: function approveUpdateVoterCommittee(uint64 Chainid, address Address) returns(bool success)
+func (_SideChainManager *SideChainManagerSession) ApproveUpdateVoterCommittee(Chainid uint64, Address common.Address) (*types.Transaction, error) {
+	return _SideChainManager.Contract.ApproveUpdateVoterCommittee(&_SideChainManager.TransactOpts, Chainid, Address)
+}
+
+// ApproveUpdateVoterCommittee is a paid mutator transaction binding the contract method 0x69bae6f3.
+//
+// Solidity: function approveUpdateVoterCommittee(uint64 Chainid, address Address) returns(bool success)
+func (_SideChainManager *SideChainManagerTransactorSession) ApproveUpdateVoterCommittee(Chainid uint64, Address common.Address) (*types.Transaction, error) {
+	return _SideChainManager.Contract.ApproveUpdateVoterCommittee(&_SideChainManager.TransactOpts, Chainid, Address)
+}
+
 // Name is a paid mutator transaction binding the contract method 0x06fdde03.
 //
 // Solidity: function name() returns(string Name)
@@ -417,6 +512,27 @@ func (_SideChainManager *SideChainManagerTransactorSession) UpdateSideChain(Addr
 	return _SideChainManager.Contract.UpdateSideChain(&_SideChainManager.TransactOpts, Address, ChainId, Router, Name, BlocksToWait, CCMCAddress, ExtraInfo)
 }
 
+// UpdateVoterCommittee is a paid mutator transaction binding the contract method 0xa6411865.
+//
+// Solidity: function updateVoterCommittee(address Address, uint64 ChainId, address[] Voters, uint64 Threshold, uint64 VoteExpiry) returns(bool success)
+func (_SideChainManager *SideChainManagerTransactor) UpdateVoterCommittee(opts *bind.TransactOpts, Address common.Address, ChainId uint64, Voters []common.Address, Threshold uint64, VoteExpiry uint64) (*types.Transaction, error) {
+	return _SideChainManager.contract.Transact(opts, "updateVoterCommittee", Address, ChainId, Voters, Threshold, VoteExpiry)
+}
+
+// UpdateVoterCommittee is a paid mutator transaction binding the contract method 0xa6411865.
+//
+// Solidity: function updateVoterCommittee(address Address, uint64 ChainId, address[] Voters, uint64 Threshold, uint64 VoteExpiry) returns(bool success)
+func (_SideChainManager *SideChainManagerSession) UpdateVoterCommittee(Address common.Address, ChainId uint64, Voters []common.Address, Threshold uint64, VoteExpiry uint64) (*types.Transaction, error) {
+	return _SideChainManager.Contract.UpdateVoterCommittee(&_SideChainManager.TransactOpts, Address, ChainId, Voters, Threshold, VoteExpiry)
+}
+
+// UpdateVoterCommittee is a paid mutator transaction binding the contract method 0xa6411865.
+//
+// Solidity: function updateVoterCommittee(address Address, uint64 ChainId, address[] Voters, uint64 Threshold, uint64 VoteExpiry) returns(bool success)
+func (_SideChainManager *SideChainManagerTransactorSession) UpdateVoterCommittee(Address common.Address, ChainId uint64, Voters []common.Address, Threshold uint64, VoteExpiry uint64) (*types.Transaction, error) {
+	return _SideChainManager.Contract.UpdateVoterCommittee(&_SideChainManager.TransactOpts, Address, ChainId, Voters, Threshold, VoteExpiry)
+}
+
 // SideChainManagerApproveQuitSideChainIterator is returned from FilterApproveQuitSideChain and is used to iterate over the raw logs and unpacked data for ApproveQuitSideChain events raised by the SideChainManager contract.
 type SideChainManagerApproveQuitSideChainIterator struct {
 	Event *SideChainManagerApproveQuitSideChain // Event containing the contract specifics and raw log
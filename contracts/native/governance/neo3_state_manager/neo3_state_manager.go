@@ -50,7 +50,7 @@ var (
 
 func InitNeo3StateManager() {
 	ABI = GetABI()
-	native.Contracts[this] = RegisterNeo3StateManagerContract
+	native.Register(this, RegisterNeo3StateManagerContract)
 }
 
 func RegisterNeo3StateManagerContract(s *native.NativeContract) {
@@ -221,6 +221,83 @@ func (m *MethodProofOutput) Decode(payload []byte) error {
 	return nil
 }
 
+type MethodApproveNativeUpgradeInput struct {
+	Contract common.Address
+	Version  uint64
+	Height   uint64
+}
+
+func (m *MethodApproveNativeUpgradeInput) Encode() ([]byte, error) {
+	return utils.PackMethod(ABI, MethodApproveNativeUpgrade, m.Contract, m.Version, m.Height)
+}
+func (m *MethodApproveNativeUpgradeInput) Decode(payload []byte) error {
+	var data struct {
+		ContractAddress common.Address
+		Version         uint64
+		Height          uint64
+	}
+	if err := utils.UnpackMethod(ABI, MethodApproveNativeUpgrade, &data, payload); err != nil {
+		return err
+	}
+	m.Contract, m.Version, m.Height = data.ContractAddress, data.Version, data.Height
+	return nil
+}
+
+type MethodApproveNativeUpgradeOutput struct {
+	Success bool
+}
+
+func (m *MethodApproveNativeUpgradeOutput) Encode() ([]byte, error) {
+	return utils.PackOutputs(ABI, MethodApproveNativeUpgrade, m.Success)
+}
+func (m *MethodApproveNativeUpgradeOutput) Decode(payload []byte) error {
+	return utils.UnpackOutputs(ABI, MethodApproveNativeUpgrade, m, payload)
+}
+
+type MethodGetNativeUpgradesInput struct {
+	Contract common.Address
+}
+
+func (m *MethodGetNativeUpgradesInput) Encode() ([]byte, error) {
+	return utils.PackMethod(ABI, MethodGetNativeUpgrades, m.Contract)
+}
+func (m *MethodGetNativeUpgradesInput) Decode(payload []byte) error {
+	var data struct {
+		ContractAddress common.Address
+	}
+	if err := utils.UnpackMethod(ABI, MethodGetNativeUpgrades, &data, payload); err != nil {
+		return err
+	}
+	m.Contract = data.ContractAddress
+	return nil
+}
+
+type MethodGetNativeUpgradesOutput struct {
+	Upgrades []*native.Upgrade
+}
+
+func (m *MethodGetNativeUpgradesOutput) Encode() ([]byte, error) {
+	enc, err := rlp.EncodeToBytes(&native.UpgradeList{List: m.Upgrades})
+	if err != nil {
+		return nil, err
+	}
+	return utils.PackOutputs(ABI, MethodGetNativeUpgrades, enc)
+}
+func (m *MethodGetNativeUpgradesOutput) Decode(payload []byte) error {
+	var data struct {
+		Upgrades []byte
+	}
+	if err := utils.UnpackOutputs(ABI, MethodGetNativeUpgrades, &data, payload); err != nil {
+		return err
+	}
+	var list native.UpgradeList
+	if err := rlp.DecodeBytes(data.Upgrades, &list); err != nil {
+		return err
+	}
+	m.Upgrades = list.List
+	return nil
+}
+
 func emitEventProposed(s *native.NativeContract, epoch *EpochInfo) error {
 	enc, err := rlp.EncodeToBytes(epoch)
 	if err != nil {
@@ -248,3 +325,7 @@ func emitEpochChange(s *native.NativeContract, curEpoch, nextEpoch *EpochInfo) e
 func emitConsensusSign(s *native.NativeContract, sign *ConsensusSign, signer common.Address, num int) error {
 	return s.AddNotify(ABI, []string{EventConsensusSigned}, sign.Method, sign.Input, signer, uint64(num))
 }
+
+func emitNativeUpgradeApproved(s *native.NativeContract, contract common.Address, version, height uint64) error {
+	return s.AddNotify(ABI, []string{EventNativeUpgradeApproved}, contract, version, height)
+}
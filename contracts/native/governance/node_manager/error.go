@@ -62,4 +62,6 @@ var (
 	ErrStorage = errors.New("store key value failed")
 
 	ErrEmitLog = errors.New("emit log failed")
+
+	ErrInvalidUpgrade = errors.New("invalid native upgrade")
 )
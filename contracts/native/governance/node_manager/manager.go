@@ -48,6 +48,9 @@ var (
 		MethodGetEpochByID:     0,
 		MethodProof:            0,
 		MethodGetChangingEpoch: 0,
+
+		MethodApproveNativeUpgrade: 30000,
+		MethodGetNativeUpgrades:    0,
 	}
 )
 
@@ -70,7 +73,7 @@ const (
 
 func InitNodeManager() {
 	InitABI()
-	native.Contracts[this] = RegisterNodeManagerContract
+	native.Register(this, RegisterNodeManagerContract)
 }
 
 func RegisterNodeManagerContract(s *native.NativeContract) {
@@ -83,6 +86,8 @@ func RegisterNodeManagerContract(s *native.NativeContract) {
 	s.Register(MethodGetEpochByID, GetEpochByID)
 	s.Register(MethodProof, GetEpochProof)
 	s.Register(MethodGetChangingEpoch, GetChangingEpoch)
+	s.Register(MethodApproveNativeUpgrade, ApproveNativeUpgrade)
+	s.Register(MethodGetNativeUpgrades, GetNativeUpgrades)
 }
 
 func Name(s *native.NativeContract) ([]byte, error) {
@@ -632,7 +632,9 @@ func generateNativeContractRef(origin common.Address, blockNum int) *native.Cont
 	token := make([]byte, common.HashLength)
 	rand.Read(token)
 	hash := common.BytesToHash(token)
-	return native.NewContractRef(testStateDB, origin, origin, big.NewInt(int64(blockNum)), hash, testSupplyGas, nil)
+	ref := native.NewContractRef(testStateDB, origin, origin, big.NewInt(int64(blockNum)), hash, testSupplyGas, nil)
+	ref.SetNativeUpgrades(testNativeUpgrades)
+	return ref
 }
 
 func generateNativeContract(origin common.Address, blockNum int) *native.NativeContract {
@@ -509,6 +509,11 @@ func epochKey(epochHash common.Hash) []byte {
 	return utils.ConcatKey(this, []byte(SKP_EPOCH), epochHash.Bytes())
 }
 
+// EpochProofKey returns the storage key of the epoch proof, relayers prove it with `eth_getProof`
+func EpochProofKey(epochID uint64) []byte {
+	return epochProofKey(EpochProofHash(epochID))
+}
+
 func epochProofKey(proofHashKey common.Hash) []byte {
 	return utils.ConcatKey(this, []byte(SKP_PROOF), proofHashKey.Bytes())
 }
@@ -536,3 +541,15 @@ func signKey(hash common.Hash) []byte {
 func signerKey(hash common.Hash) []byte {
 	return utils.ConcatKey(this, []byte(SKP_SIGNER), hash.Bytes())
 }
+
+// VoteStorageKey returns the raw storage key of the voter list for proposal `epochHash` without
+// contract address prefix, which can be queried by rpc `eth_getStorageAtCacheDB`.
+func VoteStorageKey(epochHash common.Hash) []byte {
+	return voteKey(epochHash)[common.AddressLength:]
+}
+
+// SignerStorageKey returns the raw storage key of the signer list for consensus sign `hash`
+// without contract address prefix, which can be queried by rpc `eth_getStorageAtCacheDB`.
+func SignerStorageKey(hash common.Hash) []byte {
+	return signerKey(hash)[common.AddressLength:]
+}
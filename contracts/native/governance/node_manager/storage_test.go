@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -56,6 +57,11 @@ func TestStorageEpochProof(t *testing.T) {
 	assert.NoError(t, err)
 
 	assert.Equal(t, expect, got)
+
+	// the exported key should address the same storage for `eth_getProof`
+	raw, err := testEmptyCtx.GetCacheDB().Get(EpochProofKey(epochID))
+	assert.NoError(t, err)
+	assert.Equal(t, expect, common.BytesToHash(raw))
 }
 
 func TestStorageProposal(t *testing.T) {
@@ -159,4 +165,11 @@ func TestSigns(t *testing.T) {
 	gotSigners, err := getSigners(testEmptyCtx, expectSign.Hash())
 	assert.NoError(t, err)
 	assert.Equal(t, expectSigners, gotSigners)
+
+	// signers can be read from raw storage by external key
+	enc, err := testEmptyCtx.GetCacheDB().Get(append(this.Bytes(), SignerStorageKey(expectSign.Hash())...))
+	assert.NoError(t, err)
+	var rawSigners *AddressList
+	assert.NoError(t, rlp.DecodeBytes(enc, &rawSigners))
+	assert.Equal(t, expectSigners, rawSigners.List)
 }
@@ -0,0 +1,114 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package node_manager
+
+import (
+	"github.com/ethereum/go-ethereum/contracts/native"
+	. "github.com/ethereum/go-ethereum/contracts/native/go_abi/node_manager_abi"
+	"github.com/ethereum/go-ethereum/contracts/native/utils"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// ApproveNativeUpgrade validators sign to activate implementation `version` of an native
+// contract from block `height`. the upgrade is stored after 2/3 validators signed the
+// same schema, and all nodes switch at the coordinated height. the approval must not depend
+// on the implementations of local node, nodes which do not support the version are halted
+// at the activation height instead.
+func ApproveNativeUpgrade(s *native.NativeContract) ([]byte, error) {
+	ctx := s.ContractRef().CurrentContext()
+	height := s.ContractRef().BlockHeight().Uint64()
+	signer := s.ContractRef().TxOrigin()
+
+	// decode input
+	input := new(MethodApproveNativeUpgradeInput)
+	if err := input.Decode(ctx.Payload); err != nil {
+		log.Trace("approveNativeUpgrade", "decode input failed", err)
+		return utils.ByteFailed, ErrInvalidInput
+	}
+
+	// check upgrade schema, the version and activation height should be increased
+	if !native.IsNativeContract(input.Contract) {
+		log.Trace("approveNativeUpgrade", "invalid native contract", input.Contract.Hex())
+		return utils.ByteFailed, ErrInvalidUpgrade
+	}
+	if input.Version == 0 || input.Height <= height {
+		log.Trace("approveNativeUpgrade", "invalid version", input.Version, "activation height", input.Height, "current height", height)
+		return utils.ByteFailed, ErrInvalidUpgrade
+	}
+	upgrades, err := native.GetUpgrades(s.GetCacheDB(), s.ContractRef().NativeUpgrades(), input.Contract)
+	if err != nil {
+		log.Trace("approveNativeUpgrade", "get upgrades failed", err)
+		return utils.ByteFailed, ErrStorage
+	}
+	for _, v := range upgrades {
+		if v.Version >= input.Version || v.Height >= input.Height {
+			log.Trace("approveNativeUpgrade", "upgrade conflict, version", v.Version, "height", v.Height)
+			return utils.ByteFailed, ErrInvalidUpgrade
+		}
+	}
+
+	// check consensus signs
+	enc, err := rlp.EncodeToBytes(input)
+	if err != nil {
+		return utils.ByteFailed, ErrInvalidInput
+	}
+	ok, err := CheckConsensusSigns(s, MethodApproveNativeUpgrade, enc, signer)
+	if err != nil {
+		return utils.ByteFailed, err
+	}
+	if !ok {
+		return new(MethodApproveNativeUpgradeOutput).Encode()
+	}
+
+	upgrade := &native.Upgrade{Version: input.Version, Height: input.Height}
+	if err := native.StoreUpgrade(s.GetCacheDB(), input.Contract, upgrade); err != nil {
+		log.Trace("approveNativeUpgrade", "store upgrade failed", err)
+		return utils.ByteFailed, ErrStorage
+	}
+	if err := emitNativeUpgradeApproved(s, input.Contract, input.Version, input.Height); err != nil {
+		log.Trace("approveNativeUpgrade", "emit upgrade log failed", err)
+		return utils.ByteFailed, ErrEmitLog
+	}
+	log.Info("approveNativeUpgrade", "contract", input.Contract.Hex(), "version", input.Version, "activation height", input.Height)
+
+	output := &MethodApproveNativeUpgradeOutput{Success: true}
+	return output.Encode()
+}
+
+// GetNativeUpgrades retrieve all upgrades of the native contract, include those declared
+// in chain config and approved by validators.
+func GetNativeUpgrades(s *native.NativeContract) ([]byte, error) {
+	ctx := s.ContractRef().CurrentContext()
+
+	// decode input
+	input := new(MethodGetNativeUpgradesInput)
+	if err := input.Decode(ctx.Payload); err != nil {
+		log.Trace("getNativeUpgrades", "decode input failed", err)
+		return utils.ByteFailed, ErrInvalidInput
+	}
+
+	upgrades, err := native.GetUpgrades(s.GetCacheDB(), s.ContractRef().NativeUpgrades(), input.Contract)
+	if err != nil {
+		log.Trace("getNativeUpgrades", "get upgrades failed", err)
+		return utils.ByteFailed, ErrStorage
+	}
+	output := &MethodGetNativeUpgradesOutput{Upgrades: upgrades}
+	return output.Encode()
+}
@@ -0,0 +1,213 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package node_manager
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/contracts/native"
+	. "github.com/ethereum/go-ethereum/contracts/native/go_abi/node_manager_abi"
+	"github.com/ethereum/go-ethereum/contracts/native/utils"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/assert"
+)
+
+// testNativeUpgrades upgrades declared in the chain config of test contract refs
+var testNativeUpgrades []*params.NativeUpgrade
+
+func testImplementation(name string) native.RegisterService {
+	return func(s *native.NativeContract) {
+		s.Prepare(ABI, gasTable)
+		s.Register(MethodName, func(s *native.NativeContract) ([]byte, error) {
+			return utils.PackOutputs(ABI, MethodName, name)
+		})
+	}
+}
+
+func approveNativeUpgrade(origin common.Address, blockNum int, input *MethodApproveNativeUpgradeInput) (bool, error) {
+	payload, err := input.Encode()
+	if err != nil {
+		return false, err
+	}
+	ref := generateNativeContractRef(origin, blockNum)
+	enc, _, err := ref.NativeCall(origin, this, payload)
+	if err != nil {
+		return false, err
+	}
+	output := new(MethodApproveNativeUpgradeOutput)
+	if err := output.Decode(enc); err != nil {
+		return false, err
+	}
+	return output.Success, nil
+}
+
+func getNativeUpgrades(t *testing.T, contract common.Address) []*native.Upgrade {
+	payload, err := (&MethodGetNativeUpgradesInput{Contract: contract}).Encode()
+	assert.NoError(t, err)
+	ref := generateNativeContractRef(common.EmptyAddress, 1)
+	enc, _, err := ref.NativeCall(common.EmptyAddress, this, payload)
+	assert.NoError(t, err)
+	output := new(MethodGetNativeUpgradesOutput)
+	assert.NoError(t, output.Decode(enc))
+	return output.Upgrades
+}
+
+// go test -v -count=1 github.com/ethereum/go-ethereum/contracts/native/governance/node_manager -run TestApproveNativeUpgrade
+func TestApproveNativeUpgrade(t *testing.T) {
+	resetTestContext()
+
+	contract := native.NativeContractAddrMap[native.NativeExtra5]
+	native.RegisterVersion(contract, 1, testImplementation("v1"))
+	peers := testGenesisEpoch.Peers.List
+	quorum := testGenesisEpoch.QuorumSize()
+	input := &MethodApproveNativeUpgradeInput{Contract: contract, Version: 1, Height: 100}
+
+	// invalid upgrades should be rejected before signing
+	invalid := []*MethodApproveNativeUpgradeInput{
+		{Contract: common.HexToAddress("0x01"), Version: 1, Height: 100},
+		{Contract: contract, Version: 0, Height: 100},
+		{Contract: contract, Version: 1, Height: 10},
+	}
+	for _, v := range invalid {
+		_, err := approveNativeUpgrade(peers[0].Address, 10, v)
+		assert.Error(t, err)
+	}
+
+	// only validator is allowed to sign
+	_, err := approveNativeUpgrade(GenerateTestAddress(99), 10, input)
+	assert.Error(t, err)
+
+	for i := 0; i < quorum-1; i++ {
+		ok, err := approveNativeUpgrade(peers[i].Address, 10, input)
+		assert.NoError(t, err)
+		assert.False(t, ok)
+		assert.Len(t, getNativeUpgrades(t, contract), 0)
+	}
+
+	// duplicate signer
+	_, err = approveNativeUpgrade(peers[0].Address, 10, input)
+	assert.Error(t, err)
+
+	ok, err := approveNativeUpgrade(peers[quorum-1].Address, 10, input)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	upgrades := getNativeUpgrades(t, contract)
+	assert.Len(t, upgrades, 1)
+	assert.Equal(t, uint64(1), upgrades[0].Version)
+	assert.Equal(t, uint64(100), upgrades[0].Height)
+
+	// version and activation height should be increased
+	for _, v := range []*MethodApproveNativeUpgradeInput{
+		{Contract: contract, Version: 1, Height: 200},
+		{Contract: contract, Version: 2, Height: 100},
+	} {
+		_, err := approveNativeUpgrade(peers[0].Address, 11, v)
+		assert.Error(t, err)
+	}
+}
+
+// go test -v -count=1 github.com/ethereum/go-ethereum/contracts/native/governance/node_manager -run TestApproveUnsupportedNativeUpgrade
+func TestApproveUnsupportedNativeUpgrade(t *testing.T) {
+	resetTestContext()
+
+	// the approval does not depend on the implementations of local node
+	contract := native.NativeContractAddrMap[native.NativeExtra7]
+	input := &MethodApproveNativeUpgradeInput{Contract: contract, Version: 3, Height: 100}
+	for i := 0; i < testGenesisEpoch.QuorumSize(); i++ {
+		_, err := approveNativeUpgrade(testGenesisEpoch.Peers.List[i].Address, 10, input)
+		assert.NoError(t, err)
+	}
+	assert.Len(t, getNativeUpgrades(t, contract), 1)
+
+	// the node halts at the activation height instead
+	db := (*state.CacheDB)(testStateDB)
+	registry := native.RoleRegistry(params.ZionRoleMain)
+	assert.NoError(t, registry.CheckActiveVersions(db, nil, 99))
+	assert.ErrorIs(t, registry.CheckActiveVersions(db, nil, 100), native.ErrUnsupportedVersion)
+}
+
+// go test -v -count=1 github.com/ethereum/go-ethereum/contracts/native/governance/node_manager -run TestNativeUpgradeDispatch
+func TestNativeUpgradeDispatch(t *testing.T) {
+	resetTestContext()
+	defer func() { testNativeUpgrades = nil }()
+
+	contract := native.NativeContractAddrMap[native.NativeExtra6]
+	native.Register(contract, testImplementation("v0"))
+	native.RegisterVersion(contract, 1, testImplementation("v1"))
+	native.RegisterVersion(contract, 2, testImplementation("v2"))
+
+	// version 1 declared in chain config and version 2 approved by validators
+	testNativeUpgrades = []*params.NativeUpgrade{
+		{Contract: contract, Version: 1, Block: big.NewInt(50)},
+	}
+	input := &MethodApproveNativeUpgradeInput{Contract: contract, Version: 2, Height: 100}
+	for i := 0; i < testGenesisEpoch.QuorumSize(); i++ {
+		_, err := approveNativeUpgrade(testGenesisEpoch.Peers.List[i].Address, 10, input)
+		assert.NoError(t, err)
+	}
+	// version 3 is not supported by current node
+	testNativeUpgrades = append(testNativeUpgrades, &params.NativeUpgrade{Contract: contract, Version: 3, Block: big.NewInt(200)})
+
+	payload, err := new(MethodContractNameInput).Encode()
+	assert.NoError(t, err)
+	for _, c := range []struct {
+		height int
+		name   string
+	}{
+		{0, "v0"},
+		{49, "v0"},
+		{50, "v1"},
+		{99, "v1"},
+		{100, "v2"},
+		{199, "v2"},
+	} {
+		ref := generateNativeContractRef(common.EmptyAddress, c.height)
+		enc, _, err := ref.NativeCall(common.EmptyAddress, contract, payload)
+		assert.NoError(t, err)
+		output := new(MethodContractNameOutput)
+		assert.NoError(t, output.Decode(enc))
+		assert.Equal(t, c.name, output.Name, "height %d", c.height)
+	}
+
+	ref := generateNativeContractRef(common.EmptyAddress, 200)
+	_, _, err = ref.NativeCall(common.EmptyAddress, contract, payload)
+	assert.Error(t, err)
+
+	// another chain in the same process without the config upgrade only follows the approved one
+	testNativeUpgrades = nil
+	for _, c := range []struct {
+		height int
+		name   string
+	}{
+		{50, "v0"},
+		{100, "v2"},
+		{200, "v2"},
+	} {
+		ref := generateNativeContractRef(common.EmptyAddress, c.height)
+		enc, _, err := ref.NativeCall(common.EmptyAddress, contract, payload)
+		assert.NoError(t, err)
+		output := new(MethodContractNameOutput)
+		assert.NoError(t, output.Decode(enc))
+		assert.Equal(t, c.name, output.Name, "height %d", c.height)
+	}
+}
@@ -60,7 +60,7 @@ var (
 
 func InitRelayerManager() {
 	ABI = GetABI()
-	native.Contracts[this] = RegisterRelayerManagerContract
+	native.Register(this, RegisterRelayerManagerContract)
 }
 
 func RegisterRelayerManagerContract(s *native.NativeContract) {
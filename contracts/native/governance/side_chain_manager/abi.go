@@ -28,13 +28,15 @@ import (
 )
 
 var (
-	EventRegisterSideChain        = side_chain_manager_abi.MethodRegisterSideChain
-	EventApproveRegisterSideChain = side_chain_manager_abi.MethodApproveRegisterSideChain
-	EventUpdateSideChain          = side_chain_manager_abi.MethodUpdateSideChain
-	EventApproveUpdateSideChain   = side_chain_manager_abi.MethodApproveUpdateSideChain
-	EventQuitSideChain            = side_chain_manager_abi.MethodQuitSideChain
-	EventApproveQuitSideChain     = side_chain_manager_abi.MethodApproveQuitSideChain
-	EventRegisterRedeem           = side_chain_manager_abi.MethodRegisterRedeem
+	EventRegisterSideChain           = side_chain_manager_abi.MethodRegisterSideChain
+	EventApproveRegisterSideChain    = side_chain_manager_abi.MethodApproveRegisterSideChain
+	EventUpdateSideChain             = side_chain_manager_abi.MethodUpdateSideChain
+	EventApproveUpdateSideChain      = side_chain_manager_abi.MethodApproveUpdateSideChain
+	EventQuitSideChain               = side_chain_manager_abi.MethodQuitSideChain
+	EventApproveQuitSideChain        = side_chain_manager_abi.MethodApproveQuitSideChain
+	EventRegisterRedeem              = side_chain_manager_abi.MethodRegisterRedeem
+	EventUpdateVoterCommittee        = side_chain_manager_abi.MethodUpdateVoterCommittee
+	EventApproveUpdateVoterCommittee = side_chain_manager_abi.MethodApproveUpdateVoterCommittee
 )
 
 func GetABI() *abi.ABI {
@@ -55,11 +57,23 @@ type RegisterSideChainParam struct {
 	ExtraInfo    []byte
 }
 
+type GetSideChainParam struct {
+	ChainId uint64
+}
+
 type ChainidParam struct {
 	Chainid uint64
 	Address common.Address
 }
 
+type UpdateVoterCommitteeParam struct {
+	Address    common.Address
+	ChainId    uint64
+	Voters     []common.Address
+	Threshold  uint64
+	VoteExpiry uint64
+}
+
 type RegisterRedeemParam struct {
 	RedeemChainID   uint64
 	ContractChainID uint64
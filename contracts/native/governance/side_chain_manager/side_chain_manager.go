@@ -35,47 +35,66 @@ const contractName = "side chain manager"
 
 const (
 	//function name
-	MethodContractName             = "name"
-	MethodRegisterSideChain        = "registerSideChain"
-	MethodApproveRegisterSideChain = "approveRegisterSideChain"
-	MethodUpdateSideChain          = "updateSideChain"
-	MethodApproveUpdateSideChain   = "approveUpdateSideChain"
-	MethodQuitSideChain            = "quitSideChain"
-	MethodApproveQuitSideChain     = "approveQuitSideChain"
-	MethodRegisterRedeem           = "registerRedeem"
-	MethodSetBtcTxParam            = "setBtcTxParam"
+	MethodContractName                = "name"
+	MethodRegisterSideChain           = "registerSideChain"
+	MethodApproveRegisterSideChain    = "approveRegisterSideChain"
+	MethodUpdateSideChain             = "updateSideChain"
+	MethodApproveUpdateSideChain      = "approveUpdateSideChain"
+	MethodQuitSideChain               = "quitSideChain"
+	MethodApproveQuitSideChain        = "approveQuitSideChain"
+	MethodRegisterRedeem              = "registerRedeem"
+	MethodSetBtcTxParam               = "setBtcTxParam"
+	MethodGetSideChain                = "getSideChain"
+	MethodUpdateVoterCommittee        = "updateVoterCommittee"
+	MethodApproveUpdateVoterCommittee = "approveUpdateVoterCommittee"
+	MethodGetVoterCommittee           = "getVoterCommittee"
 
 	//key prefix
-	SIDE_CHAIN_APPLY          = "sideChainApply"
-	UPDATE_SIDE_CHAIN_REQUEST = "updateSideChainRequest"
-	QUIT_SIDE_CHAIN_REQUEST   = "quitSideChainRequest"
-	SIDE_CHAIN                = "sideChain"
-	REDEEM_BIND               = "redeemBind"
-	BIND_SIGN_INFO            = "bindSignInfo"
-	BTC_TX_PARAM              = "btcTxParam"
-	REDEEM_SCRIPT             = "redeemScript"
+	SIDE_CHAIN_APPLY               = "sideChainApply"
+	UPDATE_SIDE_CHAIN_REQUEST      = "updateSideChainRequest"
+	QUIT_SIDE_CHAIN_REQUEST        = "quitSideChainRequest"
+	SIDE_CHAIN                     = "sideChain"
+	REDEEM_BIND                    = "redeemBind"
+	BIND_SIGN_INFO                 = "bindSignInfo"
+	BTC_TX_PARAM                   = "btcTxParam"
+	REDEEM_SCRIPT                  = "redeemScript"
+	VOTER_COMMITTEE                = "voterCommittee"
+	UPDATE_VOTER_COMMITTEE_REQUEST = "updateVoterCommitteeRequest"
 )
 
 var (
 	this     = native.NativeContractAddrMap[native.NativeSideChainManager]
 	gasTable = map[string]uint64{
 		// MethodContractName:             0,
-		MethodRegisterSideChain:        0,
-		MethodApproveRegisterSideChain: 100000,
-		MethodUpdateSideChain:          0,
-		MethodApproveUpdateSideChain:   0,
-		MethodQuitSideChain:            0,
-		MethodApproveQuitSideChain:     0,
-		MethodRegisterRedeem:           0,
-		MethodSetBtcTxParam:            0,
+		MethodRegisterSideChain:           0,
+		MethodApproveRegisterSideChain:    100000,
+		MethodUpdateSideChain:             0,
+		MethodApproveUpdateSideChain:      0,
+		MethodQuitSideChain:               0,
+		MethodApproveQuitSideChain:        0,
+		MethodRegisterRedeem:              0,
+		MethodSetBtcTxParam:               0,
+		MethodGetSideChain:                0,
+		MethodUpdateVoterCommittee:        0,
+		MethodApproveUpdateVoterCommittee: 0,
+		MethodGetVoterCommittee:           0,
 	}
 
 	ABI *abi.ABI
 )
 
+// side chain manager implementation versions, activated by native contract upgrades of the side
+// chain manager.
+const (
+	// VersionVoterCommittee allows the side chains using the vote router to be voted by a voter
+	// committee instead of all zion validators
+	VersionVoterCommittee = uint64(1)
+)
+
 func InitSideChainManager() {
 	ABI = GetABI()
-	native.Contracts[this] = RegisterSideChainManagerContract
+	native.Register(this, RegisterSideChainManagerContract)
+	native.RegisterVersion(this, VersionVoterCommittee, RegisterSideChainManagerContractV1)
 }
 
 func RegisterSideChainManagerContract(s *native.NativeContract) {
@@ -90,6 +109,16 @@ func RegisterSideChainManagerContract(s *native.NativeContract) {
 	s.Register(MethodApproveQuitSideChain, ApproveQuitSideChain)
 	s.Register(MethodRegisterRedeem, RegisterRedeem)
 	s.Register(MethodSetBtcTxParam, SetBtcTxParam)
+	s.Register(MethodGetSideChain, GetSideChainInfo)
+}
+
+// RegisterSideChainManagerContractV1 registers the side chain manager with the voter committees
+// managed in addition to the initial methods.
+func RegisterSideChainManagerContractV1(s *native.NativeContract) {
+	RegisterSideChainManagerContract(s)
+	s.Register(MethodUpdateVoterCommittee, UpdateVoterCommittee)
+	s.Register(MethodApproveUpdateVoterCommittee, ApproveUpdateVoterCommittee)
+	s.Register(MethodGetVoterCommittee, GetVoterCommitteeInfo)
 }
 
 func Name(s *native.NativeContract) ([]byte, error) {
@@ -103,7 +132,7 @@ func RegisterSideChain(s *native.NativeContract) ([]byte, error) {
 		return nil, err
 	}
 
-	if native.IsMainChain(params.ChainId) {
+	if s.ContractRef().IsMainChain(params.ChainId) {
 		return nil, fmt.Errorf("RegisterSideChain, relay chain `register` is forbidden")
 	}
 
@@ -155,7 +184,7 @@ func ApproveRegisterSideChain(s *native.NativeContract) ([]byte, error) {
 		return nil, err
 	}
 
-	if native.IsMainChain(params.Chainid) {
+	if s.ContractRef().IsMainChain(params.Chainid) {
 		return nil, fmt.Errorf("ApproveRegisterSideChain, relay chain `register approve` is forbidden")
 	}
 
@@ -202,7 +231,7 @@ func UpdateSideChain(s *native.NativeContract) ([]byte, error) {
 		return nil, err
 	}
 
-	if native.IsMainChain(params.ChainId) {
+	if s.ContractRef().IsMainChain(params.ChainId) {
 		return nil, fmt.Errorf("ApproveRegisterSideChain, relay chain `update` is forbidden")
 	}
 
@@ -250,7 +279,7 @@ func ApproveUpdateSideChain(s *native.NativeContract) ([]byte, error) {
 		return nil, err
 	}
 
-	if native.IsMainChain(params.Chainid) {
+	if s.ContractRef().IsMainChain(params.Chainid) {
 		return nil, fmt.Errorf("ApproveUpdateSideChain, relay chain `update approve` is forbidden")
 	}
 
@@ -300,7 +329,7 @@ func QuitSideChain(s *native.NativeContract) ([]byte, error) {
 		return nil, err
 	}
 
-	if native.IsMainChain(params.Chainid) {
+	if s.ContractRef().IsMainChain(params.Chainid) {
 		return nil, fmt.Errorf("QuitSideChain, relay chain `quit` is forbidden")
 	}
 
@@ -339,7 +368,7 @@ func ApproveQuitSideChain(s *native.NativeContract) ([]byte, error) {
 		return nil, err
 	}
 
-	if native.IsMainChain(params.Chainid) {
+	if s.ContractRef().IsMainChain(params.Chainid) {
 		return nil, fmt.Errorf("ApproveQuitSideChain, relay chain `quit approve` is forbidden")
 	}
 
@@ -375,6 +404,151 @@ func ApproveQuitSideChain(s *native.NativeContract) ([]byte, error) {
 	return utils.PackOutputs(ABI, MethodApproveQuitSideChain, true)
 }
 
+// GetSideChainInfo returns the serialized side chain record, an empty bytes returned if
+// the side chain not registered.
+func GetSideChainInfo(s *native.NativeContract) ([]byte, error) {
+	ctx := s.ContractRef().CurrentContext()
+	params := &GetSideChainParam{}
+	if err := utils.UnpackMethod(ABI, MethodGetSideChain, params, ctx.Payload); err != nil {
+		return nil, err
+	}
+
+	sideChain, err := GetSideChain(s, params.ChainId)
+	if err != nil {
+		return nil, fmt.Errorf("GetSideChainInfo, GetSideChain error: %v", err)
+	}
+	if sideChain == nil {
+		return utils.PackOutputs(ABI, MethodGetSideChain, []byte{})
+	}
+	sink := common.NewZeroCopySink(nil)
+	if err := sideChain.Serialization(sink); err != nil {
+		return nil, fmt.Errorf("GetSideChainInfo, sideChain.Serialization error: %v", err)
+	}
+	return utils.PackOutputs(ABI, MethodGetSideChain, sink.Bytes())
+}
+
+// UpdateVoterCommittee requests a new voter committee for a side chain using the vote router,
+// an empty voter list requests to remove the committee and fall back to all zion validators.
+func UpdateVoterCommittee(s *native.NativeContract) ([]byte, error) {
+	ctx := s.ContractRef().CurrentContext()
+	params := &UpdateVoterCommitteeParam{}
+	if err := utils.UnpackMethod(ABI, MethodUpdateVoterCommittee, params, ctx.Payload); err != nil {
+		return nil, err
+	}
+
+	if s.ContractRef().IsMainChain(params.ChainId) {
+		return nil, fmt.Errorf("UpdateVoterCommittee, relay chain `update voter committee` is forbidden")
+	}
+
+	//check witness
+	err := contract.ValidateOwner(s, params.Address)
+	if err != nil {
+		return nil, fmt.Errorf("UpdateVoterCommittee, checkWitness error: %v", err)
+	}
+
+	sideChain, err := GetSideChain(s, params.ChainId)
+	if err != nil {
+		return nil, fmt.Errorf("UpdateVoterCommittee, getSideChain error: %v", err)
+	}
+	if sideChain == nil {
+		return nil, fmt.Errorf("UpdateVoterCommittee, side chain is not registered")
+	}
+	if sideChain.Address != params.Address {
+		return nil, fmt.Errorf("UpdateVoterCommittee, side chain owner is wrong")
+	}
+	if sideChain.Router != utils.VOTE_ROUTER {
+		return nil, fmt.Errorf("UpdateVoterCommittee, side chain router %d is not vote router", sideChain.Router)
+	}
+
+	committee := &VoterCommittee{
+		ChainId:    params.ChainId,
+		Voters:     params.Voters,
+		Threshold:  params.Threshold,
+		VoteExpiry: params.VoteExpiry,
+	}
+	if err := committee.Validate(); err != nil {
+		return nil, fmt.Errorf("UpdateVoterCommittee, invalid committee: %v", err)
+	}
+	putUpdateVoterCommittee(s, committee)
+
+	err = s.AddNotify(ABI, []string{EventUpdateVoterCommittee}, params.ChainId, params.Threshold, params.VoteExpiry)
+	if err != nil {
+		return nil, fmt.Errorf("UpdateVoterCommittee, AddNotify error: %v", err)
+	}
+	return utils.PackOutputs(ABI, MethodUpdateVoterCommittee, true)
+}
+
+func ApproveUpdateVoterCommittee(s *native.NativeContract) ([]byte, error) {
+	ctx := s.ContractRef().CurrentContext()
+	params := &ChainidParam{}
+	if err := utils.UnpackMethod(ABI, MethodApproveUpdateVoterCommittee, params, ctx.Payload); err != nil {
+		return nil, err
+	}
+
+	if s.ContractRef().IsMainChain(params.Chainid) {
+		return nil, fmt.Errorf("ApproveUpdateVoterCommittee, relay chain `update voter committee approve` is forbidden")
+	}
+
+	//check witness
+	err := contract.ValidateOwner(s, params.Address)
+	if err != nil {
+		return nil, fmt.Errorf("ApproveUpdateVoterCommittee, checkWitness error: %v", err)
+	}
+	committee, err := getUpdateVoterCommittee(s, params.Chainid)
+	if err != nil {
+		return nil, fmt.Errorf("ApproveUpdateVoterCommittee, getUpdateVoterCommittee error: %v", err)
+	}
+	if committee == nil {
+		return nil, fmt.Errorf("ApproveUpdateVoterCommittee, chainid is not requested voter committee update")
+	}
+
+	//check consensus signs, validators approve the exact committee requested
+	sink := common.NewZeroCopySink(nil)
+	committee.Serialization(sink)
+	ok, err := node_manager.CheckConsensusSigns(s, MethodApproveUpdateVoterCommittee, sink.Bytes(), params.Address)
+	if err != nil {
+		return nil, fmt.Errorf("ApproveUpdateVoterCommittee, CheckConsensusSigns error: %v", err)
+	}
+	if !ok {
+		return utils.PackOutputs(ABI, MethodApproveUpdateVoterCommittee, true)
+	}
+
+	chainidByte := utils.GetUint64Bytes(params.Chainid)
+	if len(committee.Voters) == 0 {
+		s.GetCacheDB().Delete(utils.ConcatKey(utils.SideChainManagerContractAddress, []byte(VOTER_COMMITTEE), chainidByte))
+	} else {
+		PutVoterCommittee(s, committee)
+	}
+	s.GetCacheDB().Delete(utils.ConcatKey(utils.SideChainManagerContractAddress, []byte(UPDATE_VOTER_COMMITTEE_REQUEST), chainidByte))
+
+	err = s.AddNotify(ABI, []string{EventApproveUpdateVoterCommittee}, params.Chainid)
+	if err != nil {
+		return nil, fmt.Errorf("ApproveUpdateVoterCommittee, AddNotify error: %v", err)
+	}
+	return utils.PackOutputs(ABI, MethodApproveUpdateVoterCommittee, true)
+}
+
+// GetVoterCommitteeInfo returns the serialized voter committee of a side chain, an empty bytes
+// returned if the side chain is voted by all zion validators.
+func GetVoterCommitteeInfo(s *native.NativeContract) ([]byte, error) {
+	ctx := s.ContractRef().CurrentContext()
+	params := &GetSideChainParam{}
+	if err := utils.UnpackMethod(ABI, MethodGetVoterCommittee, params, ctx.Payload); err != nil {
+		return nil, err
+	}
+
+	committee, err := GetVoterCommittee(s, params.ChainId)
+	if err != nil {
+		return nil, fmt.Errorf("GetVoterCommitteeInfo, GetVoterCommittee error: %v", err)
+	}
+	if committee == nil {
+		return utils.PackOutputs(ABI, MethodGetVoterCommittee, []byte{})
+	}
+	sink := common.NewZeroCopySink(nil)
+	committee.Serialization(sink)
+	return utils.PackOutputs(ABI, MethodGetVoterCommittee, sink.Bytes())
+}
+
 func RegisterRedeem(native *native.NativeContract) ([]byte, error) {
 	ctx := native.ContractRef().CurrentContext()
 	params := &RegisterRedeemParam{}
@@ -152,3 +152,84 @@ func (this *ContractBinded) Deserialization(source *common.ZeroCopySource) error
 	}
 	return nil
 }
+
+// VoterCommittee is the set of addresses allowed to vote cross chain transactions of a side
+// chain using the vote router, instead of all zion validators.
+type VoterCommittee struct {
+	ChainId    uint64
+	Voters     []ethcomm.Address
+	Threshold  uint64
+	VoteExpiry uint64 // blocks after the first vote before pending votes are discarded, 0 never expires
+}
+
+func (this *VoterCommittee) Validate() error {
+	if len(this.Voters) == 0 {
+		return nil
+	}
+	seen := make(map[ethcomm.Address]struct{}, len(this.Voters))
+	for _, v := range this.Voters {
+		if v == (ethcomm.Address{}) {
+			return fmt.Errorf("empty voter address")
+		}
+		if _, ok := seen[v]; ok {
+			return fmt.Errorf("duplicate voter %s", v.Hex())
+		}
+		seen[v] = struct{}{}
+	}
+	if this.Threshold == 0 || this.Threshold > uint64(len(this.Voters)) {
+		return fmt.Errorf("threshold %d out of range [1, %d]", this.Threshold, len(this.Voters))
+	}
+	return nil
+}
+
+func (this *VoterCommittee) IsVoter(addr ethcomm.Address) bool {
+	for _, v := range this.Voters {
+		if v == addr {
+			return true
+		}
+	}
+	return false
+}
+
+func (this *VoterCommittee) Serialization(sink *common.ZeroCopySink) {
+	sink.WriteVarUint(this.ChainId)
+	sink.WriteVarUint(uint64(len(this.Voters)))
+	for _, v := range this.Voters {
+		sink.WriteVarBytes(v[:])
+	}
+	sink.WriteVarUint(this.Threshold)
+	sink.WriteVarUint(this.VoteExpiry)
+}
+
+func (this *VoterCommittee) Deserialization(source *common.ZeroCopySource) error {
+	var eof bool
+	this.ChainId, eof = source.NextVarUint()
+	if eof {
+		return fmt.Errorf("VoterCommittee deserialize chainid error")
+	}
+	n, eof := source.NextVarUint()
+	if eof {
+		return fmt.Errorf("VoterCommittee deserialize voters length error")
+	}
+	voters := make([]ethcomm.Address, 0, n)
+	for i := uint64(0); i < n; i++ {
+		v, eof := source.NextVarBytes()
+		if eof {
+			return fmt.Errorf("VoterCommittee deserialize voter error")
+		}
+		if len(v) != ethcomm.AddressLength {
+			return fmt.Errorf("VoterCommittee deserialize voter, invalid address length %d", len(v))
+		}
+		voters = append(voters, ethcomm.BytesToAddress(v))
+	}
+	this.Voters = voters
+	this.Threshold, eof = source.NextVarUint()
+	if eof {
+		return fmt.Errorf("VoterCommittee deserialize threshold error")
+	}
+	this.VoteExpiry, eof = source.NextVarUint()
+	if eof {
+		return fmt.Errorf("VoterCommittee deserialize vote expiry error")
+	}
+	return nil
+}
@@ -173,6 +173,74 @@ func putQuitSideChain(native *native.NativeContract, chainid uint64) error {
 	return nil
 }
 
+func getVoterCommitteeByPrefix(native *native.NativeContract, prefix string, chainID uint64) (*VoterCommittee, error) {
+	contract := utils.SideChainManagerContractAddress
+	chainIDByte := utils.GetUint64Bytes(chainID)
+
+	store, err := native.GetCacheDB().Get(utils.ConcatKey(contract, []byte(prefix), chainIDByte))
+	if err != nil {
+		return nil, fmt.Errorf("get voter committee store error: %v", err)
+	}
+	if store == nil {
+		return nil, nil
+	}
+	committeeBytes, err := cstates.GetValueFromRawStorageItem(store)
+	if err != nil {
+		return nil, fmt.Errorf("deserialize from raw storage item err:%v", err)
+	}
+	committee := new(VoterCommittee)
+	if err := committee.Deserialization(common.NewZeroCopySource(committeeBytes)); err != nil {
+		return nil, fmt.Errorf("deserialize voter committee error: %v", err)
+	}
+	return committee, nil
+}
+
+func putVoterCommitteeByPrefix(native *native.NativeContract, prefix string, committee *VoterCommittee) {
+	contract := utils.SideChainManagerContractAddress
+	chainIDByte := utils.GetUint64Bytes(committee.ChainId)
+
+	sink := common.NewZeroCopySink(nil)
+	committee.Serialization(sink)
+	native.GetCacheDB().Put(utils.ConcatKey(contract, []byte(prefix), chainIDByte),
+		cstates.GenRawStorageItem(sink.Bytes()))
+}
+
+// VoterCommitteeEnabled returns whether the voter committees are active at current block, side chains
+// using the vote router are voted by all zion validators before.
+func VoterCommitteeEnabled(native *native.NativeContract) (bool, error) {
+	active, err := native.ActiveVersion(this)
+	if err != nil {
+		return false, fmt.Errorf("failed to get side chain manager version, err: %v", err)
+	}
+	return active >= VersionVoterCommittee, nil
+}
+
+// GetVoterCommittee returns the voter committee of a side chain, nil returned if the side
+// chain is voted by all zion validators.
+func GetVoterCommittee(native *native.NativeContract, chainID uint64) (*VoterCommittee, error) {
+	committee, err := getVoterCommitteeByPrefix(native, VOTER_COMMITTEE, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("GetVoterCommittee, %v", err)
+	}
+	return committee, nil
+}
+
+func PutVoterCommittee(native *native.NativeContract, committee *VoterCommittee) {
+	putVoterCommitteeByPrefix(native, VOTER_COMMITTEE, committee)
+}
+
+func getUpdateVoterCommittee(native *native.NativeContract, chainID uint64) (*VoterCommittee, error) {
+	committee, err := getVoterCommitteeByPrefix(native, UPDATE_VOTER_COMMITTEE_REQUEST, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("getUpdateVoterCommittee, %v", err)
+	}
+	return committee, nil
+}
+
+func putUpdateVoterCommittee(native *native.NativeContract, committee *VoterCommittee) {
+	putVoterCommitteeByPrefix(native, UPDATE_VOTER_COMMITTEE_REQUEST, committee)
+}
+
 func GetContractBind(native *native.NativeContract, redeemChainID, contractChainID uint64,
 	redeemKey []byte) (*ContractBinded, error) {
 	contract := utils.SideChainManagerContractAddress
@@ -0,0 +1,206 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package clique
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/contracts/native"
+	"github.com/ethereum/go-ethereum/contracts/native/governance/node_manager"
+	"github.com/ethereum/go-ethereum/contracts/native/governance/side_chain_manager"
+	scom "github.com/ethereum/go-ethereum/contracts/native/header_sync/common"
+	"github.com/ethereum/go-ethereum/contracts/native/header_sync/eth"
+	"github.com/ethereum/go-ethereum/contracts/native/utils"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// ExtraInfo is the json `ExtraInfo` of the clique side chain, the same as the
+// `clique` section of its chain config.
+type ExtraInfo struct {
+	Period uint64 `json:"period"` // Number of seconds between blocks to enforce
+	Epoch  uint64 `json:"epoch"`  // Epoch length to reset votes and checkpoint
+}
+
+// Handler syncs the headers of the proof-of-authority chains running clique.
+type Handler struct {
+}
+
+func NewHandler() *Handler {
+	return &Handler{}
+}
+
+func getExtraInfo(ns *native.NativeContract, chainID uint64) (*ExtraInfo, error) {
+	side, err := side_chain_manager.GetSideChain(ns, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("get side chain error: %v", err)
+	}
+	if side == nil {
+		return nil, fmt.Errorf("side chain %d not found", chainID)
+	}
+	info := new(ExtraInfo)
+	if err := json.Unmarshal(side.ExtraInfo, info); err != nil {
+		return nil, fmt.Errorf("deserialize extra info error: %v", err)
+	}
+	if info.Epoch == 0 {
+		return nil, fmt.Errorf("invalid epoch")
+	}
+	return info, nil
+}
+
+func (h *Handler) SyncGenesisHeader(ns *native.NativeContract) error {
+	ctx := ns.ContractRef().CurrentContext()
+	params := &scom.SyncGenesisHeaderParam{}
+	if err := utils.UnpackMethod(scom.ABI, scom.MethodSyncGenesisHeader, params, ctx.Payload); err != nil {
+		return fmt.Errorf("clique Handler SyncGenesisHeader, contract params deserialize error: %v", err)
+	}
+	info, err := getExtraInfo(ns, params.ChainID)
+	if err != nil {
+		return fmt.Errorf("clique Handler SyncGenesisHeader, %v", err)
+	}
+
+	// Get current epoch operator
+	ok, err := node_manager.CheckConsensusSigns(ns, scom.MethodSyncGenesisHeader, ctx.Payload, ns.ContractRef().MsgSender())
+	if err != nil {
+		return fmt.Errorf("clique Handler SyncGenesisHeader, CheckConsensusSigns error: %v", err)
+	}
+	if !ok {
+		return nil
+	}
+
+	// can only store once
+	stored, err := isGenesisStored(ns, params.ChainID)
+	if err != nil {
+		return fmt.Errorf("clique Handler SyncGenesisHeader, isGenesisStored error: %v", err)
+	}
+	if stored {
+		return fmt.Errorf("clique Handler SyncGenesisHeader, genesis header had been initialized")
+	}
+
+	header := new(eth.Header)
+	if err := json.Unmarshal(params.GenesisHeader, header); err != nil {
+		return fmt.Errorf("clique Handler SyncGenesisHeader, deserialize header err: %v", err)
+	}
+	if err := storeGenesis(ns, params.ChainID, header, info); err != nil {
+		return fmt.Errorf("clique Handler SyncGenesisHeader, %v", err)
+	}
+	return nil
+}
+
+// storeGenesis stores the checkpoint header which the signers are trusted from.
+func storeGenesis(ns *native.NativeContract, chainID uint64, header *eth.Header, info *ExtraInfo) error {
+	if header.Number == nil || header.Difficulty == nil {
+		return errUnknownBlock
+	}
+	if header.Number.Uint64()%info.Epoch != 0 {
+		return fmt.Errorf("genesis header %d is not checkpoint", header.Number.Uint64())
+	}
+	signers, err := checkpointSigners(header)
+	if err != nil {
+		return err
+	}
+	if err := putGenesisHeader(ns, chainID, header); err != nil {
+		return fmt.Errorf("put genesis header error: %v", err)
+	}
+	return putSnapshot(ns, chainID, newSnapshot(header.Number.Uint64(), header.Hash(), signers))
+}
+
+func (h *Handler) SyncBlockHeader(ns *native.NativeContract) error {
+	params := &scom.SyncBlockHeaderParam{}
+	{
+		ctx := ns.ContractRef().CurrentContext()
+		if err := utils.UnpackMethod(scom.ABI, scom.MethodSyncBlockHeader, params, ctx.Payload); err != nil {
+			return err
+		}
+	}
+	info, err := getExtraInfo(ns, params.ChainID)
+	if err != nil {
+		return fmt.Errorf("clique Handler SyncBlockHeader, %v", err)
+	}
+
+	for i, v := range params.Headers {
+		header := new(eth.Header)
+		if err := json.Unmarshal(v, header); err != nil {
+			return fmt.Errorf("clique Handler SyncBlockHeader, deserialize No.%d header err: %v", i, err)
+		}
+		if err := syncHeader(ns, params.ChainID, header, info); err != nil {
+			return fmt.Errorf("clique Handler SyncBlockHeader, failed to sync No.%d header %s: %v", i, header.Hash().String(), err)
+		}
+	}
+	return nil
+}
+
+// syncHeader verifies the header on top of its synced parent, and moves the canonical
+// chain to the fork of the header if it has more total difficulty.
+func syncHeader(ns *native.NativeContract, chainID uint64, header *eth.Header, info *ExtraInfo) error {
+	hash := header.Hash()
+	exist, err := eth.IsHeaderExist(ns, hash.Bytes(), chainID)
+	if err != nil {
+		return err
+	}
+	if exist {
+		log.Warnf("clique Handler SyncBlockHeader, header has exist. Header: %s", hash.String())
+		return nil
+	}
+	parent, parentTd, err := eth.GetHeaderByHash(ns, header.ParentHash.Bytes(), chainID)
+	if err != nil {
+		return fmt.Errorf("get parent header error: %v", err)
+	}
+	snap, err := GetSnapshot(ns, chainID, header.ParentHash)
+	if err != nil {
+		return err
+	}
+
+	if err := verifyHeader(header, parent, info, ns.ContractRef().BlockTime()); err != nil {
+		return err
+	}
+	signer, err := verifySeal(snap, header, info)
+	if err != nil {
+		return err
+	}
+	if snap, err = snap.apply(header, signer, info.Epoch); err != nil {
+		return err
+	}
+
+	td := new(big.Int).Add(parentTd, header.Difficulty)
+	if err := putHeader(ns, chainID, header, td); err != nil {
+		return fmt.Errorf("put header error: %v", err)
+	}
+	if err := putSnapshot(ns, chainID, snap); err != nil {
+		return fmt.Errorf("put snapshot error: %v", err)
+	}
+
+	current, currentTd, err := eth.GetCurrentHeader(ns, chainID)
+	if err != nil {
+		return fmt.Errorf("get current header error: %v", err)
+	}
+	if current.Hash() == header.ParentHash {
+		putCanonical(ns, chainID, header.Number.Uint64(), hash)
+	} else if td.Cmp(currentTd) > 0 {
+		if err := eth.RestructChain(ns, current, header, chainID); err != nil {
+			return fmt.Errorf("reorg error: %v", err)
+		}
+	}
+	return nil
+}
+
+func (h *Handler) SyncCrossChainMsg(ns *native.NativeContract) error {
+	return nil
+}
@@ -0,0 +1,306 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package clique
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"math/big"
+	"os"
+	"sort"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/clique"
+	"github.com/ethereum/go-ethereum/contracts/native"
+	scom "github.com/ethereum/go-ethereum/contracts/native/header_sync/common"
+	"github.com/ethereum/go-ethereum/contracts/native/header_sync/eth"
+	"github.com/ethereum/go-ethereum/contracts/native/utils"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	testChainID     = uint64(19)
+	testGenesisTime = uint64(1600000000)
+)
+
+var testInfo = &ExtraInfo{Period: 5, Epoch: 30}
+
+func TestMain(m *testing.M) {
+	scom.ABI = scom.GetABI()
+	os.Exit(m.Run())
+}
+
+func newTestContext() *native.NativeContract {
+	db := state.NewDatabase(rawdb.NewMemoryDatabase())
+	sdb, _ := state.New(common.Hash{}, db, nil)
+	ref := native.NewContractRef(sdb, common.Address{}, common.Address{}, big.NewInt(1), common.Hash{}, 0, nil)
+	ref.PushContext(&native.Context{ContractAddress: utils.HeaderSyncContractAddress})
+	ref.SetBlockTime(testGenesisTime + 3600)
+	return native.NewNativeContract(sdb, ref)
+}
+
+// testSigners are the keys sorted by address, so that the signer of index i is in
+// turn at the height of n*i.
+func testSigners(n int) []*ecdsa.PrivateKey {
+	keys := make([]*ecdsa.PrivateKey, n)
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(crypto.PubkeyToAddress(keys[i].PublicKey).Bytes(), crypto.PubkeyToAddress(keys[j].PublicKey).Bytes()) < 0
+	})
+	return keys
+}
+
+func sealHeader(t *testing.T, header *eth.Header, key *ecdsa.PrivateKey) *eth.Header {
+	sig, err := crypto.Sign(SealHash(header).Bytes(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	copy(header.Extra[len(header.Extra)-extraSeal:], sig)
+	return header
+}
+
+func genesisHeader(t *testing.T, signers []*ecdsa.PrivateKey) *eth.Header {
+	extra := make([]byte, extraVanity)
+	for _, key := range signers {
+		extra = append(extra, crypto.PubkeyToAddress(key.PublicKey).Bytes()...)
+	}
+	return &eth.Header{
+		UncleHash:  uncleHash,
+		Difficulty: big.NewInt(1),
+		Number:     big.NewInt(0),
+		GasLimit:   8000000,
+		Time:       testGenesisTime,
+		Extra:      append(extra, make([]byte, extraSeal)...),
+	}
+}
+
+// childHeader makes the child header signed by the key, with the difficulty of the
+// signer turn among the count of signers.
+func childHeader(t *testing.T, parent *eth.Header, key *ecdsa.PrivateKey, inturn bool, vote *common.Address, authorize bool) *eth.Header {
+	header := &eth.Header{
+		ParentHash: parent.Hash(),
+		UncleHash:  uncleHash,
+		Difficulty: big.NewInt(1),
+		Number:     new(big.Int).Add(parent.Number, common.Big1),
+		GasLimit:   parent.GasLimit,
+		Time:       parent.Time + testInfo.Period,
+		Extra:      make([]byte, extraVanity+extraSeal),
+	}
+	if inturn {
+		header.Difficulty = big.NewInt(2)
+	}
+	if vote != nil {
+		header.Coinbase = *vote
+		if authorize {
+			copy(header.Nonce[:], nonceAuthVote)
+		}
+	}
+	return sealHeader(t, header, key)
+}
+
+func initChain(t *testing.T, ns *native.NativeContract, signers []*ecdsa.PrivateKey) *eth.Header {
+	genesis := genesisHeader(t, signers)
+	if err := storeGenesis(ns, testChainID, genesis, testInfo); err != nil {
+		t.Fatal(err)
+	}
+	return genesis
+}
+
+func currentHeader(t *testing.T, ns *native.NativeContract) *eth.Header {
+	header, _, err := eth.GetCurrentHeader(ns, testChainID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return header
+}
+
+// the seal hash of the legacy header is the same as the one of clique engine
+func TestSealHash(t *testing.T) {
+	header := childHeader(t, genesisHeader(t, testSigners(1)), testSigners(1)[0], true, nil, false)
+	legacy := &types.Header{
+		ParentHash:  header.ParentHash,
+		UncleHash:   header.UncleHash,
+		Coinbase:    header.Coinbase,
+		Root:        header.Root,
+		TxHash:      header.TxHash,
+		ReceiptHash: header.ReceiptHash,
+		Bloom:       header.Bloom,
+		Difficulty:  header.Difficulty,
+		Number:      header.Number,
+		GasLimit:    header.GasLimit,
+		GasUsed:     header.GasUsed,
+		Time:        header.Time,
+		Extra:       header.Extra,
+		MixDigest:   header.MixDigest,
+		Nonce:       header.Nonce,
+	}
+	if SealHash(header) != clique.SealHash(legacy) {
+		t.Fatalf("seal hash %s, expect %s", SealHash(header).String(), clique.SealHash(legacy).String())
+	}
+	header.BaseFee = big.NewInt(eth.InitialBaseFee)
+	if SealHash(header) == clique.SealHash(legacy) {
+		t.Fatal("base fee should be sealed")
+	}
+}
+
+func TestSyncHeader(t *testing.T) {
+	ns := newTestContext()
+	signers := testSigners(3)
+	parent := initChain(t, ns, signers)
+
+	// signed in turn by the signers of height % 3
+	for i := 1; i <= 4; i++ {
+		header := childHeader(t, parent, signers[i%3], true, nil, false)
+		if err := syncHeader(ns, testChainID, header, testInfo); err != nil {
+			t.Fatalf("header %d: %v", i, err)
+		}
+		parent = header
+	}
+	if current := currentHeader(t, ns); current.Hash() != parent.Hash() {
+		t.Fatalf("current header %d, expect %d", current.Number.Uint64(), parent.Number.Uint64())
+	}
+
+	// signer 1 signed the block 4, it's not allowed to sign block 5
+	if err := syncHeader(ns, testChainID, childHeader(t, parent, signers[1], false, nil, false), testInfo); err != errRecentlySigned {
+		t.Fatalf("err %v, expect %v", err, errRecentlySigned)
+	}
+	// out of turn signer claims in-turn difficulty
+	if err := syncHeader(ns, testChainID, childHeader(t, parent, signers[0], true, nil, false), testInfo); err != errWrongDifficulty {
+		t.Fatalf("err %v, expect %v", err, errWrongDifficulty)
+	}
+	// unknown signer
+	if err := syncHeader(ns, testChainID, childHeader(t, parent, testSigners(1)[0], false, nil, false), testInfo); err != errUnauthorizedSigner {
+		t.Fatalf("err %v, expect %v", err, errUnauthorizedSigner)
+	}
+	// too close to the parent
+	header := childHeader(t, parent, signers[2], true, nil, false)
+	header.Time = parent.Time + 1
+	if err := syncHeader(ns, testChainID, sealHeader(t, header, signers[2]), testInfo); err != errInvalidTimestamp {
+		t.Fatalf("err %v, expect %v", err, errInvalidTimestamp)
+	}
+	// unknown parent
+	header = childHeader(t, parent, signers[2], true, nil, false)
+	header.ParentHash = common.Hash{0x01}
+	if err := syncHeader(ns, testChainID, sealHeader(t, header, signers[2]), testInfo); err == nil {
+		t.Fatal("header of unknown parent should fail")
+	}
+}
+
+func TestSyncHeaderVotes(t *testing.T) {
+	ns := newTestContext()
+	signers := testSigners(3)
+	parent := initChain(t, ns, signers)
+	candidate := crypto.PubkeyToAddress(testSigners(1)[0].PublicKey)
+
+	// two of the three signers vote to authorize the candidate
+	for i := 1; i <= 2; i++ {
+		header := childHeader(t, parent, signers[i%3], true, &candidate, true)
+		if err := syncHeader(ns, testChainID, header, testInfo); err != nil {
+			t.Fatalf("header %d: %v", i, err)
+		}
+		parent = header
+	}
+	snap, err := GetSnapshot(ns, testChainID, parent.Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := snap.Signers[candidate]; !ok || len(snap.Signers) != 4 {
+		t.Fatalf("candidate should be authorized: %v", snap.signers())
+	}
+	if len(snap.Votes) != 0 || len(snap.Tally) != 0 {
+		t.Fatalf("votes should be discarded: %v, %v", snap.Votes, snap.Tally)
+	}
+}
+
+func TestSyncHeaderCheckpoint(t *testing.T) {
+	ns := newTestContext()
+	signers := testSigners(1)
+	info := &ExtraInfo{Period: 5, Epoch: 2}
+	genesis := genesisHeader(t, signers)
+	if err := storeGenesis(ns, testChainID, genesis, info); err != nil {
+		t.Fatal(err)
+	}
+	header := childHeader(t, genesis, signers[0], true, nil, false)
+	if err := syncHeader(ns, testChainID, header, info); err != nil {
+		t.Fatal(err)
+	}
+
+	// the checkpoint lists the signers
+	checkpoint := childHeader(t, header, signers[0], true, nil, false)
+	checkpoint.Extra = genesisHeader(t, signers).Extra
+	if err := syncHeader(ns, testChainID, sealHeader(t, checkpoint, signers[0]), info); err != nil {
+		t.Fatal(err)
+	}
+	checkpoint = childHeader(t, header, signers[0], true, nil, false)
+	checkpoint.Extra = genesisHeader(t, testSigners(1)).Extra
+	if err := syncHeader(ns, testChainID, sealHeader(t, checkpoint, signers[0]), info); err != errMismatchingCheckpointSigners {
+		t.Fatalf("err %v, expect %v", err, errMismatchingCheckpointSigners)
+	}
+	// genesis must be a checkpoint
+	genesis = childHeader(t, header, signers[0], true, nil, false)
+	if err := storeGenesis(newTestContext(), testChainID, genesis, info); err == nil {
+		t.Fatal("non checkpoint genesis should fail")
+	}
+}
+
+func TestSyncHeaderFork(t *testing.T) {
+	ns := newTestContext()
+	signers := testSigners(3)
+	genesis := initChain(t, ns, signers)
+
+	// the chain signed out of turn by signer 0 and 1
+	b1 := childHeader(t, genesis, signers[0], false, nil, false)
+	b2 := childHeader(t, b1, signers[1], false, nil, false)
+	// the fork signed in turn
+	f1 := childHeader(t, genesis, signers[1], true, nil, false)
+	f2 := childHeader(t, f1, signers[2], true, nil, false)
+	f3 := childHeader(t, f2, signers[0], true, nil, false)
+
+	for _, header := range []*eth.Header{b1, b2, f1} {
+		if err := syncHeader(ns, testChainID, header, testInfo); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// total difficulty of the fork is the same, the canonical chain is kept
+	if current := currentHeader(t, ns); current.Hash() != b2.Hash() {
+		t.Fatalf("current header %s, expect %s", current.Hash().String(), b2.Hash().String())
+	}
+	// the fork is heavier
+	if err := syncHeader(ns, testChainID, f2, testInfo); err != nil {
+		t.Fatal(err)
+	}
+	if current := currentHeader(t, ns); current.Hash() != f2.Hash() {
+		t.Fatalf("current header %s, expect %s", current.Hash().String(), f2.Hash().String())
+	}
+	if header, _, err := eth.GetHeaderByHeight(ns, 1, testChainID); err != nil || header.Hash() != f1.Hash() {
+		t.Fatalf("canonical header of height 1: %v, %v", header, err)
+	}
+	if err := syncHeader(ns, testChainID, f3, testInfo); err != nil {
+		t.Fatal(err)
+	}
+	if current := currentHeader(t, ns); current.Hash() != f3.Hash() {
+		t.Fatalf("current header %s, expect %s", current.Hash().String(), f3.Hash().String())
+	}
+}
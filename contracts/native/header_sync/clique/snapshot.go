@@ -0,0 +1,259 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package clique
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/contracts/native/header_sync/eth"
+)
+
+// Vote represents a single vote that an authorized signer made to modify the
+// list of authorizations.
+type Vote struct {
+	Signer    common.Address `json:"signer"`    // Authorized signer that cast this vote
+	Block     uint64         `json:"block"`     // Block number the vote was cast in (expire old votes)
+	Address   common.Address `json:"address"`   // Account being voted on to change its authorization
+	Authorize bool           `json:"authorize"` // Whether to authorize or deauthorize the voted account
+}
+
+// Tally is a simple vote tally to keep the current score of votes. Votes that
+// go against the proposal aren't counted since it's equivalent to not voting.
+type Tally struct {
+	Authorize bool `json:"authorize"` // Whether the vote is about authorizing or kicking someone
+	Votes     int  `json:"votes"`     // Number of votes until now wanting to pass the proposal
+}
+
+// Snapshot is the state of the authorization voting at a given point in time, it's
+// kept for every synced header so that forks can be verified from their parents.
+type Snapshot struct {
+	Number  uint64                      `json:"number"`  // Block number where the snapshot was created
+	Hash    common.Hash                 `json:"hash"`    // Block hash where the snapshot was created
+	Signers map[common.Address]struct{} `json:"signers"` // Set of authorized signers at this moment
+	Recents map[uint64]common.Address   `json:"recents"` // Set of recent signers for spam protections
+	Votes   []*Vote                     `json:"votes"`   // List of votes cast in chronological order
+	Tally   map[common.Address]Tally    `json:"tally"`   // Current vote tally to avoid recalculating
+}
+
+// copy from clique
+func newSnapshot(number uint64, hash common.Hash, signers []common.Address) *Snapshot {
+	snap := &Snapshot{
+		Number:  number,
+		Hash:    hash,
+		Signers: make(map[common.Address]struct{}),
+		Recents: make(map[uint64]common.Address),
+		Tally:   make(map[common.Address]Tally),
+	}
+	for _, signer := range signers {
+		snap.Signers[signer] = struct{}{}
+	}
+	return snap
+}
+
+// copy from clique
+func (s *Snapshot) copy() *Snapshot {
+	cpy := &Snapshot{
+		Number:  s.Number,
+		Hash:    s.Hash,
+		Signers: make(map[common.Address]struct{}),
+		Recents: make(map[uint64]common.Address),
+		Votes:   make([]*Vote, len(s.Votes)),
+		Tally:   make(map[common.Address]Tally),
+	}
+	for signer := range s.Signers {
+		cpy.Signers[signer] = struct{}{}
+	}
+	for block, signer := range s.Recents {
+		cpy.Recents[block] = signer
+	}
+	for address, tally := range s.Tally {
+		cpy.Tally[address] = tally
+	}
+	copy(cpy.Votes, s.Votes)
+
+	return cpy
+}
+
+// validVote returns whether it makes sense to cast the specified vote in the
+// given snapshot context (e.g. don't try to add an already authorized signer).
+func (s *Snapshot) validVote(address common.Address, authorize bool) bool {
+	_, signer := s.Signers[address]
+	return (signer && !authorize) || (!signer && authorize)
+}
+
+// cast adds a new vote into the tally.
+func (s *Snapshot) cast(address common.Address, authorize bool) bool {
+	// Ensure the vote is meaningful
+	if !s.validVote(address, authorize) {
+		return false
+	}
+	// Cast the vote into an existing or new tally
+	if old, ok := s.Tally[address]; ok {
+		old.Votes++
+		s.Tally[address] = old
+	} else {
+		s.Tally[address] = Tally{Authorize: authorize, Votes: 1}
+	}
+	return true
+}
+
+// uncast removes a previously cast vote from the tally.
+func (s *Snapshot) uncast(address common.Address, authorize bool) bool {
+	// If there's no tally, it's a dangling vote, just drop
+	tally, ok := s.Tally[address]
+	if !ok {
+		return false
+	}
+	// Ensure we only revert counted votes
+	if tally.Authorize != authorize {
+		return false
+	}
+	// Otherwise revert the vote
+	if tally.Votes > 1 {
+		tally.Votes--
+		s.Tally[address] = tally
+	} else {
+		delete(s.Tally, address)
+	}
+	return true
+}
+
+// apply creates a new authorization snapshot by applying the child header signed
+// by the signer to the original one, the same as clique does for a single header.
+func (s *Snapshot) apply(header *eth.Header, signer common.Address, epoch uint64) (*Snapshot, error) {
+	number := header.Number.Uint64()
+	if number != s.Number+1 || header.ParentHash != s.Hash {
+		return nil, errInvalidVotingChain
+	}
+	snap := s.copy()
+
+	// Remove any votes on checkpoint blocks
+	if number%epoch == 0 {
+		snap.Votes = nil
+		snap.Tally = make(map[common.Address]Tally)
+	}
+	// Delete the oldest signer from the recent list to allow it signing again
+	if limit := uint64(len(snap.Signers)/2 + 1); number >= limit {
+		delete(snap.Recents, number-limit)
+	}
+	if _, ok := snap.Signers[signer]; !ok {
+		return nil, errUnauthorizedSigner
+	}
+	for _, recent := range snap.Recents {
+		if recent == signer {
+			return nil, errRecentlySigned
+		}
+	}
+	snap.Recents[number] = signer
+
+	// Header authorized, discard any previous votes from the signer
+	for i, vote := range snap.Votes {
+		if vote.Signer == signer && vote.Address == header.Coinbase {
+			// Uncast the vote from the cached tally
+			snap.uncast(vote.Address, vote.Authorize)
+
+			// Uncast the vote from the chronological list
+			snap.Votes = append(snap.Votes[:i], snap.Votes[i+1:]...)
+			break // only one vote allowed
+		}
+	}
+	// Tally up the new vote from the signer
+	var authorize bool
+	switch {
+	case bytes.Equal(header.Nonce[:], nonceAuthVote):
+		authorize = true
+	case bytes.Equal(header.Nonce[:], nonceDropVote):
+		authorize = false
+	default:
+		return nil, errInvalidVote
+	}
+	if snap.cast(header.Coinbase, authorize) {
+		snap.Votes = append(snap.Votes, &Vote{
+			Signer:    signer,
+			Block:     number,
+			Address:   header.Coinbase,
+			Authorize: authorize,
+		})
+	}
+	// If the vote passed, update the list of signers
+	if tally := snap.Tally[header.Coinbase]; tally.Votes > len(snap.Signers)/2 {
+		if tally.Authorize {
+			snap.Signers[header.Coinbase] = struct{}{}
+		} else {
+			delete(snap.Signers, header.Coinbase)
+
+			// Signer list shrunk, delete any leftover recent caches
+			if limit := uint64(len(snap.Signers)/2 + 1); number >= limit {
+				delete(snap.Recents, number-limit)
+			}
+			// Discard any previous votes the deauthorized signer cast
+			for i := 0; i < len(snap.Votes); i++ {
+				if snap.Votes[i].Signer == header.Coinbase {
+					// Uncast the vote from the cached tally
+					snap.uncast(snap.Votes[i].Address, snap.Votes[i].Authorize)
+
+					// Uncast the vote from the chronological list
+					snap.Votes = append(snap.Votes[:i], snap.Votes[i+1:]...)
+
+					i--
+				}
+			}
+		}
+		// Discard any previous votes around the just changed account
+		for i := 0; i < len(snap.Votes); i++ {
+			if snap.Votes[i].Address == header.Coinbase {
+				snap.Votes = append(snap.Votes[:i], snap.Votes[i+1:]...)
+				i--
+			}
+		}
+		delete(snap.Tally, header.Coinbase)
+	}
+	snap.Number = number
+	snap.Hash = header.Hash()
+
+	return snap, nil
+}
+
+// signersAscending implements the sort interface to allow sorting a list of addresses
+type signersAscending []common.Address
+
+func (s signersAscending) Len() int           { return len(s) }
+func (s signersAscending) Less(i, j int) bool { return bytes.Compare(s[i][:], s[j][:]) < 0 }
+func (s signersAscending) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+// signers retrieves the list of authorized signers in ascending order.
+func (s *Snapshot) signers() []common.Address {
+	sigs := make([]common.Address, 0, len(s.Signers))
+	for sig := range s.Signers {
+		sigs = append(sigs, sig)
+	}
+	sort.Sort(signersAscending(sigs))
+	return sigs
+}
+
+// inturn returns if a signer at a given block height is in-turn or not.
+func (s *Snapshot) inturn(number uint64, signer common.Address) bool {
+	signers, offset := s.signers(), 0
+	for offset < len(signers) && signers[offset] != signer {
+		offset++
+	}
+	return (number % uint64(len(signers))) == uint64(offset)
+}
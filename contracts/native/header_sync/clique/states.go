@@ -0,0 +1,112 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package clique
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/contracts/native"
+	scom "github.com/ethereum/go-ethereum/contracts/native/header_sync/common"
+	"github.com/ethereum/go-ethereum/contracts/native/header_sync/eth"
+	"github.com/ethereum/go-ethereum/contracts/native/utils"
+	cstates "github.com/polynetwork/poly/core/states"
+)
+
+// The headers are stored in the same layout as the eth router, so the getters of
+// `header_sync/eth` are used to read the canonical chain.
+
+func genesisKey(chainID uint64) []byte {
+	return utils.ConcatKey(utils.HeaderSyncContractAddress, []byte(scom.GENESIS_HEADER), utils.GetUint64Bytes(chainID))
+}
+
+func headerKey(chainID uint64, hash common.Hash) []byte {
+	return utils.ConcatKey(utils.HeaderSyncContractAddress, []byte(scom.HEADER_INDEX), utils.GetUint64Bytes(chainID), hash.Bytes())
+}
+
+func snapshotKey(chainID uint64, hash common.Hash) []byte {
+	return utils.ConcatKey(utils.HeaderSyncContractAddress, []byte(scom.CLIQUE_SNAPSHOT), utils.GetUint64Bytes(chainID), hash.Bytes())
+}
+
+func isGenesisStored(ns *native.NativeContract, chainID uint64) (bool, error) {
+	store, err := ns.GetCacheDB().Get(genesisKey(chainID))
+	if err != nil {
+		return false, err
+	}
+	return store != nil, nil
+}
+
+func putGenesisHeader(ns *native.NativeContract, chainID uint64, header *eth.Header) error {
+	blob, err := json.Marshal(&eth.HeaderWithDifficultySum{Header: *header, DifficultySum: header.Difficulty})
+	if err != nil {
+		return err
+	}
+	ns.GetCacheDB().Put(genesisKey(chainID), cstates.GenRawStorageItem(blob))
+	ns.GetCacheDB().Put(headerKey(chainID, header.Hash()), cstates.GenRawStorageItem(blob))
+	putCanonical(ns, chainID, header.Number.Uint64(), header.Hash())
+	return nil
+}
+
+func putHeader(ns *native.NativeContract, chainID uint64, header *eth.Header, td *big.Int) error {
+	blob, err := json.Marshal(&eth.HeaderWithDifficultySum{Header: *header, DifficultySum: td})
+	if err != nil {
+		return err
+	}
+	ns.GetCacheDB().Put(headerKey(chainID, header.Hash()), cstates.GenRawStorageItem(blob))
+	return nil
+}
+
+func putCanonical(ns *native.NativeContract, chainID, height uint64, hash common.Hash) {
+	ns.GetCacheDB().Put(utils.ConcatKey(utils.HeaderSyncContractAddress, []byte(scom.MAIN_CHAIN), utils.GetUint64Bytes(chainID), utils.GetUint64Bytes(height)),
+		cstates.GenRawStorageItem(hash.Bytes()))
+	ns.GetCacheDB().Put(utils.ConcatKey(utils.HeaderSyncContractAddress, []byte(scom.CURRENT_HEADER_HEIGHT), utils.GetUint64Bytes(chainID)),
+		cstates.GenRawStorageItem(utils.GetUint64Bytes(height)))
+	scom.NotifyPutHeader(ns, chainID, height, hash.String())
+}
+
+func putSnapshot(ns *native.NativeContract, chainID uint64, snap *Snapshot) error {
+	blob, err := json.Marshal(snap)
+	if err != nil {
+		return err
+	}
+	ns.GetCacheDB().Put(snapshotKey(chainID, snap.Hash), cstates.GenRawStorageItem(blob))
+	return nil
+}
+
+// GetSnapshot returns the signers voting state after the header of the hash.
+func GetSnapshot(ns *native.NativeContract, chainID uint64, hash common.Hash) (*Snapshot, error) {
+	store, err := ns.GetCacheDB().Get(snapshotKey(chainID, hash))
+	if err != nil {
+		return nil, fmt.Errorf("GetSnapshot, get snapshot error: %v", err)
+	}
+	if store == nil {
+		return nil, fmt.Errorf("GetSnapshot, snapshot of %s not found", hash.String())
+	}
+	blob, err := cstates.GetValueFromRawStorageItem(store)
+	if err != nil {
+		return nil, fmt.Errorf("GetSnapshot, deserialize from raw storage item err: %v", err)
+	}
+	snap := new(Snapshot)
+	if err := json.Unmarshal(blob, snap); err != nil {
+		return nil, fmt.Errorf("GetSnapshot, deserialize snapshot error: %v", err)
+	}
+	return snap, nil
+}
@@ -0,0 +1,264 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package clique
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/contracts/native/header_sync/eth"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	// allowedFutureBlockTime is the max time from the current block of zion that
+	// the synced header is allowed to be.
+	allowedFutureBlockTime = 15
+
+	maxGasLimit = uint64(0x7fffffffffffffff) // Maximum the gas limit (2^63-1).
+)
+
+var (
+	extraVanity = 32                     // Fixed number of extra-data prefix bytes reserved for signer vanity
+	extraSeal   = crypto.SignatureLength // Fixed number of extra-data suffix bytes reserved for signer seal
+
+	nonceAuthVote = hexutil.MustDecode("0xffffffffffffffff") // Magic nonce number to vote on adding a new signer
+	nonceDropVote = hexutil.MustDecode("0x0000000000000000") // Magic nonce number to vote on removing a signer.
+
+	uncleHash = types.CalcUncleHash(nil) // Always Keccak256(RLP([])) as uncles are meaningless outside of PoW.
+
+	diffInTurn = big.NewInt(2) // Block difficulty for in-turn signatures
+	diffNoTurn = big.NewInt(1) // Block difficulty for out-of-turn signatures
+)
+
+// Various error messages to mark blocks invalid, the same as clique.
+var (
+	errUnknownBlock                 = errors.New("unknown block")
+	errInvalidCheckpointBeneficiary = errors.New("beneficiary in checkpoint block non-zero")
+	errInvalidVote                  = errors.New("vote nonce not 0x00..0 or 0xff..f")
+	errInvalidCheckpointVote        = errors.New("vote nonce in checkpoint block non-zero")
+	errMissingVanity                = errors.New("extra-data 32 byte vanity prefix missing")
+	errMissingSignature             = errors.New("extra-data 65 byte signature suffix missing")
+	errExtraSigners                 = errors.New("non-checkpoint block contains extra signer list")
+	errInvalidCheckpointSigners     = errors.New("invalid signer list on checkpoint block")
+	errMismatchingCheckpointSigners = errors.New("mismatching signer list on checkpoint block")
+	errInvalidMixDigest             = errors.New("non-zero mix digest")
+	errInvalidUncleHash             = errors.New("non empty uncle hash")
+	errInvalidDifficulty            = errors.New("invalid difficulty")
+	errWrongDifficulty              = errors.New("wrong difficulty")
+	errInvalidTimestamp             = errors.New("invalid timestamp")
+	errInvalidVotingChain           = errors.New("invalid voting chain")
+	errUnauthorizedSigner           = errors.New("unauthorized signer")
+	errRecentlySigned               = errors.New("recently signed")
+	errFutureBlock                  = errors.New("block in the future")
+	errUnknownAncestor              = errors.New("unknown ancestor")
+)
+
+// verifyHeader checks the header against its parent like `clique.verifyHeader`
+// and `clique.verifyCascadingFields`, except the signers which are checked in
+// verifySeal.
+func verifyHeader(header, parent *eth.Header, info *ExtraInfo, now uint64) error {
+	if header.Number == nil {
+		return errUnknownBlock
+	}
+	number := header.Number.Uint64()
+
+	// Don't waste time checking blocks from the future
+	if header.Time > now+allowedFutureBlockTime {
+		return errFutureBlock
+	}
+	// Checkpoint blocks need to enforce zero beneficiary
+	checkpoint := (number % info.Epoch) == 0
+	if checkpoint && header.Coinbase != (common.Address{}) {
+		return errInvalidCheckpointBeneficiary
+	}
+	// Nonces must be 0x00..0 or 0xff..f, zeroes enforced on checkpoints
+	if !bytes.Equal(header.Nonce[:], nonceAuthVote) && !bytes.Equal(header.Nonce[:], nonceDropVote) {
+		return errInvalidVote
+	}
+	if checkpoint && !bytes.Equal(header.Nonce[:], nonceDropVote) {
+		return errInvalidCheckpointVote
+	}
+	// Check that the extra-data contains both the vanity and signature
+	if len(header.Extra) < extraVanity {
+		return errMissingVanity
+	}
+	if len(header.Extra) < extraVanity+extraSeal {
+		return errMissingSignature
+	}
+	// Ensure that the extra-data contains a signer list on checkpoint, but none otherwise
+	signersBytes := len(header.Extra) - extraVanity - extraSeal
+	if !checkpoint && signersBytes != 0 {
+		return errExtraSigners
+	}
+	if checkpoint && signersBytes%common.AddressLength != 0 {
+		return errInvalidCheckpointSigners
+	}
+	// Ensure that the mix digest is zero as we don't have fork protection currently
+	if header.MixDigest != (common.Hash{}) {
+		return errInvalidMixDigest
+	}
+	// Ensure that the block doesn't contain any uncles which are meaningless in PoA
+	if header.UncleHash != uncleHash {
+		return errInvalidUncleHash
+	}
+	// Ensure that the block's difficulty is meaningful (may not be correct at this point)
+	if number > 0 && (header.Difficulty == nil || (header.Difficulty.Cmp(diffInTurn) != 0 && header.Difficulty.Cmp(diffNoTurn) != 0)) {
+		return errInvalidDifficulty
+	}
+
+	// Ensure that the block's timestamp isn't too close to its parent
+	if parent.Number.Uint64() != number-1 || parent.Hash() != header.ParentHash {
+		return errUnknownAncestor
+	}
+	if parent.Time+info.Period > header.Time {
+		return errInvalidTimestamp
+	}
+	// Verify that the gasUsed is <= gasLimit
+	if header.GasUsed > header.GasLimit {
+		return errors.New("invalid gasUsed")
+	}
+	if header.GasLimit > maxGasLimit {
+		return errors.New("invalid gasLimit")
+	}
+	if header.BaseFee != nil {
+		return eth.VerifyEip1559Header(parent, header)
+	}
+	return eth.VerifyGaslimit(parent.GasLimit, header.GasLimit)
+}
+
+// verifySeal checks the header is signed by the authorized signer of the parent
+// snapshot like `clique.verifySeal`, and returns the signer.
+func verifySeal(snap *Snapshot, header *eth.Header, info *ExtraInfo) (common.Address, error) {
+	number := header.Number.Uint64()
+	if number == 0 {
+		return common.Address{}, errUnknownBlock
+	}
+	// If the block is a checkpoint block, verify the signer list
+	if number%info.Epoch == 0 {
+		signers := make([]byte, len(snap.Signers)*common.AddressLength)
+		for i, signer := range snap.signers() {
+			copy(signers[i*common.AddressLength:], signer[:])
+		}
+		extraSuffix := len(header.Extra) - extraSeal
+		if !bytes.Equal(header.Extra[extraVanity:extraSuffix], signers) {
+			return common.Address{}, errMismatchingCheckpointSigners
+		}
+	}
+
+	// Resolve the authorization key and check against signers
+	signer, err := ecrecover(header)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if _, ok := snap.Signers[signer]; !ok {
+		return common.Address{}, errUnauthorizedSigner
+	}
+	for seen, recent := range snap.Recents {
+		if recent == signer {
+			// Signer is among recents, only fail if the current block doesn't shift it out
+			if limit := uint64(len(snap.Signers)/2 + 1); seen > number-limit {
+				return common.Address{}, errRecentlySigned
+			}
+		}
+	}
+	// Ensure that the difficulty corresponds to the turn-ness of the signer
+	inturn := snap.inturn(number, signer)
+	if inturn && header.Difficulty.Cmp(diffInTurn) != 0 {
+		return common.Address{}, errWrongDifficulty
+	}
+	if !inturn && header.Difficulty.Cmp(diffNoTurn) != 0 {
+		return common.Address{}, errWrongDifficulty
+	}
+	return signer, nil
+}
+
+// checkpointSigners returns the signers listed in the extra data of the checkpoint header.
+func checkpointSigners(header *eth.Header) ([]common.Address, error) {
+	signersBytes := len(header.Extra) - extraVanity - extraSeal
+	if signersBytes <= 0 || signersBytes%common.AddressLength != 0 {
+		return nil, errInvalidCheckpointSigners
+	}
+	signers := make([]common.Address, signersBytes/common.AddressLength)
+	for i := range signers {
+		copy(signers[i][:], header.Extra[extraVanity+i*common.AddressLength:])
+	}
+	return signers, nil
+}
+
+// ecrecover extracts the Ethereum account address from a signed header.
+func ecrecover(header *eth.Header) (common.Address, error) {
+	// Retrieve the signature from the header extra-data
+	if len(header.Extra) < extraSeal {
+		return common.Address{}, errMissingSignature
+	}
+	signature := header.Extra[len(header.Extra)-extraSeal:]
+
+	// Recover the public key and the Ethereum address
+	pubkey, err := crypto.Ecrecover(SealHash(header).Bytes(), signature)
+	if err != nil {
+		return common.Address{}, err
+	}
+	var signer common.Address
+	copy(signer[:], crypto.Keccak256(pubkey[1:])[12:])
+
+	return signer, nil
+}
+
+// SealHash returns the hash of a block prior to it being sealed, the base fee is
+// included since london.
+func SealHash(header *eth.Header) (hash common.Hash) {
+	hasher := sha3.NewLegacyKeccak256()
+	encodeSigHeader(hasher, header)
+	hasher.Sum(hash[:0])
+	return hash
+}
+
+func encodeSigHeader(w io.Writer, header *eth.Header) {
+	enc := []interface{}{
+		header.ParentHash,
+		header.UncleHash,
+		header.Coinbase,
+		header.Root,
+		header.TxHash,
+		header.ReceiptHash,
+		header.Bloom,
+		header.Difficulty,
+		header.Number,
+		header.GasLimit,
+		header.GasUsed,
+		header.Time,
+		header.Extra[:len(header.Extra)-crypto.SignatureLength], // Yes, this will panic if extra is too short
+		header.MixDigest,
+		header.Nonce,
+	}
+	if header.BaseFee != nil {
+		enc = append(enc, header.BaseFee)
+	}
+	if err := rlp.Encode(w, enc); err != nil {
+		panic("can't encode: " + err.Error())
+	}
+}
@@ -0,0 +1,162 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package cometbft
+
+import (
+	"crypto/sha256"
+	"math/bits"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const precommitType = 2 // SignedMsgType of precommit votes
+
+// HashFromByteSlices computes the RFC 6962 merkle root of the items, the same as
+// `crypto/merkle.HashFromByteSlices` of CometBFT.
+func HashFromByteSlices(items [][]byte) []byte {
+	switch len(items) {
+	case 0:
+		sum := sha256.Sum256(nil)
+		return sum[:]
+	case 1:
+		return leafHash(items[0])
+	default:
+		k := splitPoint(len(items))
+		return innerHash(HashFromByteSlices(items[:k]), HashFromByteSlices(items[k:]))
+	}
+}
+
+func leafHash(leaf []byte) []byte {
+	sum := sha256.Sum256(append([]byte{0}, leaf...))
+	return sum[:]
+}
+
+func innerHash(left, right []byte) []byte {
+	data := make([]byte, 0, 1+len(left)+len(right))
+	data = append(append(append(data, 1), left...), right...)
+	sum := sha256.Sum256(data)
+	return sum[:]
+}
+
+// splitPoint returns the largest power of 2 less than length.
+func splitPoint(length int) int {
+	k := 1 << uint(bits.Len(uint(length))-1)
+	if k == length {
+		k >>= 1
+	}
+	return k
+}
+
+func appendVarintField(b []byte, num protowire.Number, v uint64) []byte {
+	if v == 0 {
+		return b
+	}
+	return protowire.AppendVarint(protowire.AppendTag(b, num, protowire.VarintType), v)
+}
+
+func appendFixed64Field(b []byte, num protowire.Number, v uint64) []byte {
+	if v == 0 {
+		return b
+	}
+	return protowire.AppendFixed64(protowire.AppendTag(b, num, protowire.Fixed64Type), v)
+}
+
+func appendBytesField(b []byte, num protowire.Number, v []byte) []byte {
+	if len(v) == 0 {
+		return b
+	}
+	return protowire.AppendBytes(protowire.AppendTag(b, num, protowire.BytesType), v)
+}
+
+// appendMessageField appends the embedded message even if it's empty, as the
+// non-nullable fields are always encoded by gogoproto.
+func appendMessageField(b []byte, num protowire.Number, msg []byte) []byte {
+	return protowire.AppendBytes(protowire.AppendTag(b, num, protowire.BytesType), msg)
+}
+
+func (t Timestamp) encode() []byte {
+	b := appendVarintField(nil, 1, uint64(t.Seconds))
+	return appendVarintField(b, 2, uint64(t.Nanos))
+}
+
+func (p PartSetHeader) encode() []byte {
+	b := appendVarintField(nil, 1, uint64(p.Total))
+	return appendBytesField(b, 2, p.Hash)
+}
+
+func (id BlockID) encode() []byte {
+	b := appendBytesField(nil, 1, id.Hash)
+	return appendMessageField(b, 2, id.PartSetHeader.encode())
+}
+
+func (c Consensus) encode() []byte {
+	b := appendVarintField(nil, 1, c.Block)
+	return appendVarintField(b, 2, c.App)
+}
+
+// Hash returns the merkle root of the header fields, each field is encoded on its own
+// and the primitive fields are wrapped by the protobuf well-known value types.
+func (h *Header) Hash() []byte {
+	if h == nil || len(h.ValidatorsHash) == 0 {
+		return nil
+	}
+	return HashFromByteSlices([][]byte{
+		h.Version.encode(),
+		appendBytesField(nil, 1, []byte(h.ChainID)),
+		appendVarintField(nil, 1, uint64(h.Height)),
+		h.Time.encode(),
+		h.LastBlockID.encode(),
+		appendBytesField(nil, 1, h.LastCommitHash),
+		appendBytesField(nil, 1, h.DataHash),
+		appendBytesField(nil, 1, h.ValidatorsHash),
+		appendBytesField(nil, 1, h.NextValidatorsHash),
+		appendBytesField(nil, 1, h.ConsensusHash),
+		appendBytesField(nil, 1, h.AppHash),
+		appendBytesField(nil, 1, h.LastResultsHash),
+		appendBytesField(nil, 1, h.EvidenceHash),
+		appendBytesField(nil, 1, h.ProposerAddress),
+	})
+}
+
+// Hash returns the merkle root of the validators encoded as `SimpleValidator`.
+func (set *ValidatorSet) Hash() []byte {
+	items := make([][]byte, len(set.Validators))
+	for i, v := range set.Validators {
+		pubKey := appendBytesField(nil, 1, v.PubKey)
+		b := appendMessageField(nil, 1, pubKey)
+		items[i] = appendVarintField(b, 2, uint64(v.VotingPower))
+	}
+	return HashFromByteSlices(items)
+}
+
+// VoteSignBytes returns the length delimited `CanonicalVote` signed by the validator
+// of the idx-th signature in the commit.
+func (c *Commit) VoteSignBytes(chainID string, idx int) []byte {
+	sig := c.Signatures[idx]
+	b := appendVarintField(nil, 1, precommitType)
+	b = appendFixed64Field(b, 2, uint64(c.Height))
+	b = appendFixed64Field(b, 3, uint64(int64(c.Round)))
+	if sig.BlockIDFlag == BlockIDFlagCommit && !c.BlockID.IsZero() {
+		// CanonicalBlockID has the same layout as BlockID
+		b = appendMessageField(b, 4, c.BlockID.encode())
+	}
+	b = appendMessageField(b, 5, sig.Timestamp.encode())
+	b = appendBytesField(b, 6, []byte(chainID))
+	return protowire.AppendBytes(nil, b)
+}
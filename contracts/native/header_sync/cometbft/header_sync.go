@@ -0,0 +1,235 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package cometbft
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/contracts/native"
+	"github.com/ethereum/go-ethereum/contracts/native/governance/node_manager"
+	scom "github.com/ethereum/go-ethereum/contracts/native/header_sync/common"
+	"github.com/ethereum/go-ethereum/contracts/native/utils"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	ErrClientNotFound = errors.New("light client is not created")
+	ErrClientFrozen   = errors.New("light client is frozen for misbehaviour")
+)
+
+// Handler is the light client of CometBFT chains, the headers and validator sets are
+// synced in protobuf form as the `Header` of IBC tendermint client.
+type Handler struct {
+}
+
+func NewHandler() *Handler {
+	return &Handler{}
+}
+
+func (h *Handler) SyncGenesisHeader(s *native.NativeContract) error {
+	ctx := s.ContractRef().CurrentContext()
+	params := &scom.SyncGenesisHeaderParam{}
+	if err := utils.UnpackMethod(scom.ABI, scom.MethodSyncGenesisHeader, params, ctx.Payload); err != nil {
+		return fmt.Errorf("CometBFTHandler SyncGenesisHeader, contract params deserialize err: %v", err)
+	}
+
+	ok, err := node_manager.CheckConsensusSigns(s, scom.MethodSyncGenesisHeader, ctx.Payload, s.ContractRef().MsgSender())
+	if err != nil {
+		return fmt.Errorf("CometBFTHandler SyncGenesisHeader, CheckConsensusSigns err: %v", err)
+	}
+	if !ok {
+		return nil
+	}
+
+	if client, err := GetClientState(s, params.ChainID); err != nil {
+		return fmt.Errorf("CometBFTHandler SyncGenesisHeader, get client err: %v", err)
+	} else if client != nil {
+		return fmt.Errorf("CometBFTHandler SyncGenesisHeader, genesis header had been initialized")
+	}
+	genesis, err := DecodeGenesis(params.GenesisHeader)
+	if err != nil {
+		return fmt.Errorf("CometBFTHandler SyncGenesisHeader, decode genesis err: %v", err)
+	}
+	client, state, err := verifyGenesis(genesis)
+	if err != nil {
+		return fmt.Errorf("CometBFTHandler SyncGenesisHeader, verify genesis err: %v", err)
+	}
+	if err := putClientState(s, params.ChainID, client); err != nil {
+		return fmt.Errorf("CometBFTHandler SyncGenesisHeader, put client err: %v", err)
+	}
+	if err := putTrustedState(s, params.ChainID, state); err != nil {
+		return fmt.Errorf("CometBFTHandler SyncGenesisHeader, put consensus state err: %v", err)
+	}
+	log.Debug("CometBFTHandler SyncGenesisHeader", "chainID", params.ChainID, "height", state.Height)
+	return nil
+}
+
+// verifyGenesis checks the client parameters and the genesis header, which is trusted
+// by the consensus of the main chain instead of verified with a trusted state.
+func verifyGenesis(genesis *Genesis) (*ClientState, *TrustedState, error) {
+	params, header := genesis.Params, genesis.Header
+	if err := ValidateTrustLevel(params.TrustLevel); err != nil {
+		return nil, nil, err
+	}
+	if params.TrustingPeriod <= 0 || params.TrustingPeriod >= params.UnbondingPeriod {
+		return nil, nil, fmt.Errorf("trusting period %v must be positive and less than unbonding period %v",
+			params.TrustingPeriod, params.UnbondingPeriod)
+	}
+	if params.MaxClockDrift <= 0 {
+		return nil, nil, fmt.Errorf("max clock drift must be positive")
+	}
+	if err := header.SignedHeader.ValidateBasic(params.ChainID); err != nil {
+		return nil, nil, err
+	}
+	if err := header.ValidatorSet.ValidateBasic(); err != nil {
+		return nil, nil, err
+	}
+	if hash := header.ValidatorSet.Hash(); !bytes.Equal(hash, header.SignedHeader.Header.ValidatorsHash) {
+		return nil, nil, fmt.Errorf("%w: hash %x, header expects %x", ErrInvalidValidatorSet, hash, header.SignedHeader.Header.ValidatorsHash)
+	}
+	if err := VerifyCommitLight(params.ChainID, header.ValidatorSet, header.SignedHeader.Commit); err != nil {
+		return nil, nil, err
+	}
+	state := trustedStateOf(header.SignedHeader.Header)
+	client := &ClientState{
+		ChainID:          params.ChainID,
+		TrustNumerator:   params.TrustLevel.Numerator,
+		TrustDenominator: params.TrustLevel.Denominator,
+		TrustingPeriod:   uint64(params.TrustingPeriod),
+		UnbondingPeriod:  uint64(params.UnbondingPeriod),
+		MaxClockDrift:    uint64(params.MaxClockDrift),
+		LatestHeight:     state.Height,
+	}
+	return client, state, nil
+}
+
+func trustedStateOf(header *Header) *TrustedState {
+	return &TrustedState{
+		Height:             uint64(header.Height),
+		Time:               header.Time.Time(),
+		AppHash:            header.AppHash,
+		NextValidatorsHash: header.NextValidatorsHash,
+		BlockHash:          header.Hash(),
+	}
+}
+
+// SyncBlockHeader verifies the headers in order, each of them is verified with the
+// trusted state at its trusted height, so the relayer is free to skip the headers
+// between them as long as the trusted validators sign enough of the new one, and it
+// bisects the range otherwise.
+func (h *Handler) SyncBlockHeader(s *native.NativeContract) error {
+	ctx := s.ContractRef().CurrentContext()
+	params := &scom.SyncBlockHeaderParam{}
+	if err := utils.UnpackMethod(scom.ABI, scom.MethodSyncBlockHeader, params, ctx.Payload); err != nil {
+		return err
+	}
+
+	for i, v := range params.Headers {
+		header, err := DecodeLightHeader(v)
+		if err != nil {
+			return fmt.Errorf("CometBFTHandler SyncBlockHeader, deserialize No.%d header err: %v", i, err)
+		}
+		frozen, err := UpdateClient(s, params.ChainID, header)
+		if err != nil {
+			return fmt.Errorf("CometBFTHandler SyncBlockHeader, update client with No.%d header err: %v", i, err)
+		}
+		if frozen {
+			// keep the frozen client instead of reverting it with an error
+			return nil
+		}
+	}
+	return nil
+}
+
+// UpdateClient verifies the header and stores its state, it returns true if the header
+// is valid but conflicts with the trusted states, which is the evidence of misbehaviour
+// of the counterparty validators, and the client is frozen.
+func UpdateClient(s *native.NativeContract, chainID uint64, header *LightHeader) (bool, error) {
+	client, err := GetClientState(s, chainID)
+	if err != nil {
+		return false, err
+	}
+	if client == nil {
+		return false, ErrClientNotFound
+	}
+	if client.Frozen() {
+		return false, ErrClientFrozen
+	}
+
+	untrusted := header.SignedHeader.Header
+	height := uint64(untrusted.Height)
+	existing, err := GetTrustedState(s, chainID, height)
+	if err != nil {
+		return false, err
+	}
+	if existing != nil && bytes.Equal(existing.BlockHash, untrusted.Hash()) {
+		return false, nil
+	}
+
+	trusted, err := GetTrustedState(s, chainID, header.TrustedHeight.RevisionHeight)
+	if err != nil {
+		return false, err
+	}
+	if trusted == nil {
+		return false, fmt.Errorf("trusted height %d is not synced", header.TrustedHeight.RevisionHeight)
+	}
+	now := time.Unix(int64(s.ContractRef().BlockTime()), 0)
+	if err := Verify(client.Params(), trusted, header.TrustedValidators, header.SignedHeader, header.ValidatorSet, now); err != nil {
+		return false, err
+	}
+
+	// The header signed by enough trusted validators conflicts with the trusted block of
+	// the same height, or violates the monotonic block time of the latest one
+	misbehaviour := existing != nil
+	if !misbehaviour && height != client.LatestHeight {
+		latest, err := GetTrustedState(s, chainID, client.LatestHeight)
+		if err != nil {
+			return false, err
+		}
+		if latest != nil {
+			untrustedTime := untrusted.Time.Time()
+			misbehaviour = (height > latest.Height && !untrustedTime.After(latest.Time)) ||
+				(height < latest.Height && !untrustedTime.Before(latest.Time))
+		}
+	}
+	if misbehaviour {
+		client.FrozenHeight = height
+		log.Warn("CometBFT light client frozen for misbehaviour", "chainID", chainID, "height", height)
+		return true, putClientState(s, chainID, client)
+	}
+
+	if err := putTrustedState(s, chainID, trustedStateOf(untrusted)); err != nil {
+		return false, err
+	}
+	if height > client.LatestHeight {
+		client.LatestHeight = height
+		if err := putClientState(s, chainID, client); err != nil {
+			return false, err
+		}
+	}
+	log.Debug("CometBFT light client updated", "chainID", chainID, "height", height, "trusted", trusted.Height)
+	return false, nil
+}
+
+func (h *Handler) SyncCrossChainMsg(s *native.NativeContract) error {
+	return nil
+}
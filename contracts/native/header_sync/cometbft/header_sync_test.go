@@ -0,0 +1,356 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package cometbft
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/contracts/native"
+	scom "github.com/ethereum/go-ethereum/contracts/native/header_sync/common"
+	"github.com/ethereum/go-ethereum/contracts/native/utils"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const (
+	testChainID     = "cosmoshub-4"
+	testSideChainID = uint64(8)
+	testGenesisTime = int64(1700000000)
+)
+
+func TestMain(m *testing.M) {
+	scom.ABI = scom.GetABI()
+	os.Exit(m.Run())
+}
+
+func newTestContext(blockTime int64) *native.NativeContract {
+	db := state.NewDatabase(rawdb.NewMemoryDatabase())
+	sdb, _ := state.New(common.Hash{}, db, nil)
+	ref := native.NewContractRef(sdb, common.Address{}, common.Address{}, big.NewInt(1), common.Hash{}, 0, nil)
+	ref.PushContext(&native.Context{ContractAddress: utils.HeaderSyncContractAddress})
+	ref.SetBlockTime(uint64(blockTime))
+	return native.NewNativeContract(sdb, ref)
+}
+
+func sum(s string) []byte {
+	h := sha256.Sum256([]byte(s))
+	return h[:]
+}
+
+// the vectors are taken from the tests of cometbft `types` package
+func TestHeaderHash(t *testing.T) {
+	header := &Header{
+		Version:            Consensus{Block: 1, App: 2},
+		ChainID:            "chainId",
+		Height:             3,
+		Time:               Timestamp{Seconds: time.Date(2019, 10, 13, 16, 14, 44, 0, time.UTC).Unix()},
+		LastBlockID:        BlockID{Hash: make([]byte, 32), PartSetHeader: PartSetHeader{Total: 6, Hash: make([]byte, 32)}},
+		LastCommitHash:     sum("last_commit_hash"),
+		DataHash:           sum("data_hash"),
+		ValidatorsHash:     sum("validators_hash"),
+		NextValidatorsHash: sum("next_validators_hash"),
+		ConsensusHash:      sum("consensus_hash"),
+		AppHash:            sum("app_hash"),
+		LastResultsHash:    sum("last_results_hash"),
+		EvidenceHash:       sum("evidence_hash"),
+		ProposerAddress:    sum("proposer_address")[:20],
+	}
+	expect, _ := hex.DecodeString("F740121F553B5418C3EFBD343C2DBFE9E007BB67B0D020A0741374BAB65242A4")
+	if hash := header.Hash(); !bytes.Equal(hash, expect) {
+		t.Fatalf("header hash %x, expect %x", hash, expect)
+	}
+	decoded, err := DecodeHeader(encodeHeader(header))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash := decoded.Hash(); !bytes.Equal(hash, expect) {
+		t.Fatalf("decoded header hash %x, expect %x", hash, expect)
+	}
+}
+
+func TestVoteSignBytes(t *testing.T) {
+	zero := time.Time{}
+	commit := &Commit{
+		Height:     1,
+		Round:      1,
+		Signatures: []CommitSig{{BlockIDFlag: BlockIDFlagCommit, Timestamp: Timestamp{Seconds: zero.Unix()}}},
+	}
+	expect := []byte{
+		0x21, 0x8, 0x2, 0x11, 0x1, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x19, 0x1, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0,
+		0x2a, 0xb, 0x8, 0x80, 0x92, 0xb8, 0xc3, 0x98, 0xfe, 0xff, 0xff, 0xff, 0x1,
+	}
+	if b := commit.VoteSignBytes("", 0); !bytes.Equal(b, expect) {
+		t.Fatalf("vote sign bytes %x, expect %x", b, expect)
+	}
+}
+
+type testValidator struct {
+	key   ed25519.PrivateKey
+	power int64
+}
+
+func newTestValidators(seed byte, powers ...int64) []testValidator {
+	vals := make([]testValidator, len(powers))
+	for i, power := range powers {
+		s := make([]byte, ed25519.SeedSize)
+		s[0], s[1] = seed, byte(i)
+		vals[i] = testValidator{key: ed25519.NewKeyFromSeed(s), power: power}
+	}
+	return vals
+}
+
+func validatorSet(vals []testValidator) *ValidatorSet {
+	set := new(ValidatorSet)
+	for _, v := range vals {
+		pk := v.key.Public().(ed25519.PublicKey)
+		addr := sha256.Sum256(pk)
+		set.Validators = append(set.Validators, &Validator{Address: addr[:20], PubKey: pk, VotingPower: v.power})
+		set.TotalVotingPower += v.power
+	}
+	set.Proposer = set.Validators[0]
+	return set
+}
+
+// signedHeader makes the header of the height signed by the validators of given indexes.
+func signedHeader(height int64, vals, next []testValidator, appHash []byte, signers ...int) *SignedHeader {
+	set := validatorSet(vals)
+	header := &Header{
+		Version:            Consensus{Block: 11},
+		ChainID:            testChainID,
+		Height:             height,
+		Time:               Timestamp{Seconds: testGenesisTime + height*5},
+		LastBlockID:        BlockID{Hash: sum("last"), PartSetHeader: PartSetHeader{Total: 1, Hash: sum("parts")}},
+		ValidatorsHash:     set.Hash(),
+		NextValidatorsHash: validatorSet(next).Hash(),
+		AppHash:            appHash,
+		ProposerAddress:    set.Proposer.Address,
+	}
+	commit := &Commit{
+		Height:  height,
+		BlockID: BlockID{Hash: header.Hash(), PartSetHeader: PartSetHeader{Total: 1, Hash: sum("block parts")}},
+	}
+	for range set.Validators {
+		commit.Signatures = append(commit.Signatures, CommitSig{BlockIDFlag: BlockIDFlagAbsent, Timestamp: Timestamp{Seconds: zeroSeconds}})
+	}
+	for _, i := range signers {
+		commit.Signatures[i] = CommitSig{
+			BlockIDFlag:      BlockIDFlagCommit,
+			ValidatorAddress: set.Validators[i].Address,
+			Timestamp:        header.Time,
+		}
+		commit.Signatures[i].Signature = ed25519.Sign(vals[i].key, commit.VoteSignBytes(testChainID, i))
+	}
+	return &SignedHeader{Header: header, Commit: commit}
+}
+
+var zeroSeconds = time.Time{}.Unix()
+
+func encodeHeader(h *Header) []byte {
+	b := appendMessageField(nil, 1, h.Version.encode())
+	b = appendBytesField(b, 2, []byte(h.ChainID))
+	b = appendVarintField(b, 3, uint64(h.Height))
+	b = appendMessageField(b, 4, h.Time.encode())
+	b = appendMessageField(b, 5, h.LastBlockID.encode())
+	for i, v := range [][]byte{h.LastCommitHash, h.DataHash, h.ValidatorsHash, h.NextValidatorsHash, h.ConsensusHash,
+		h.AppHash, h.LastResultsHash, h.EvidenceHash, h.ProposerAddress} {
+		b = appendBytesField(b, protowire.Number(6+i), v)
+	}
+	return b
+}
+
+func encodeCommit(c *Commit) []byte {
+	b := appendVarintField(nil, 1, uint64(c.Height))
+	b = appendVarintField(b, 2, uint64(c.Round))
+	b = appendMessageField(b, 3, c.BlockID.encode())
+	for _, sig := range c.Signatures {
+		s := appendVarintField(nil, 1, uint64(sig.BlockIDFlag))
+		s = appendBytesField(s, 2, sig.ValidatorAddress)
+		s = appendMessageField(s, 3, sig.Timestamp.encode())
+		s = appendBytesField(s, 4, sig.Signature)
+		b = appendMessageField(b, 4, s)
+	}
+	return b
+}
+
+func encodeValidator(v *Validator) []byte {
+	b := appendBytesField(nil, 1, v.Address)
+	b = appendMessageField(b, 2, appendBytesField(nil, 1, v.PubKey))
+	b = appendVarintField(b, 3, uint64(v.VotingPower))
+	return appendVarintField(b, 4, uint64(v.ProposerPriority))
+}
+
+func encodeValidatorSet(set *ValidatorSet) []byte {
+	var b []byte
+	for _, v := range set.Validators {
+		b = appendMessageField(b, 1, encodeValidator(v))
+	}
+	b = appendMessageField(b, 2, encodeValidator(set.Proposer))
+	return appendVarintField(b, 3, uint64(set.TotalVotingPower))
+}
+
+func encodeLightHeader(sh *SignedHeader, vals []testValidator, trustedHeight uint64, trustedVals []testValidator) []byte {
+	signed := appendMessageField(nil, 1, encodeHeader(sh.Header))
+	signed = appendMessageField(signed, 2, encodeCommit(sh.Commit))
+	b := appendMessageField(nil, 1, signed)
+	b = appendMessageField(b, 2, encodeValidatorSet(validatorSet(vals)))
+	b = appendMessageField(b, 3, appendVarintField(appendVarintField(nil, 1, 4), 2, trustedHeight))
+	if trustedVals != nil {
+		b = appendMessageField(b, 4, encodeValidatorSet(validatorSet(trustedVals)))
+	}
+	return b
+}
+
+func encodeDuration(d time.Duration) []byte {
+	b := appendVarintField(nil, 1, uint64(d/time.Second))
+	return appendVarintField(b, 2, uint64(d%time.Second))
+}
+
+func encodeGenesis(params *ClientParams, header []byte) []byte {
+	p := appendBytesField(nil, 1, []byte(params.ChainID))
+	p = appendMessageField(p, 2, appendVarintField(appendVarintField(nil, 1, params.TrustLevel.Numerator), 2, params.TrustLevel.Denominator))
+	p = appendMessageField(p, 3, encodeDuration(params.TrustingPeriod))
+	p = appendMessageField(p, 4, encodeDuration(params.UnbondingPeriod))
+	p = appendMessageField(p, 5, encodeDuration(params.MaxClockDrift))
+	return appendMessageField(appendMessageField(nil, 1, p), 2, header)
+}
+
+// initClient syncs the genesis header of height 1 signed by the validators.
+func initClient(t *testing.T, s *native.NativeContract, vals []testValidator) {
+	params := &ClientParams{
+		ChainID:         testChainID,
+		TrustLevel:      Fraction{Numerator: 1, Denominator: 3},
+		TrustingPeriod:  14 * 24 * time.Hour,
+		UnbondingPeriod: 21 * 24 * time.Hour,
+		MaxClockDrift:   10 * time.Second,
+	}
+	sh := signedHeader(1, vals, vals, sum("app 1"), 0, 1, 2, 3)
+	genesis, err := DecodeGenesis(encodeGenesis(params, encodeLightHeader(sh, vals, 0, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, trusted, err := verifyGenesis(genesis)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := putClientState(s, testSideChainID, client); err != nil {
+		t.Fatal(err)
+	}
+	if err := putTrustedState(s, testSideChainID, trusted); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func updateClient(t *testing.T, s *native.NativeContract, raw []byte) (bool, error) {
+	header, err := DecodeLightHeader(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return UpdateClient(s, testSideChainID, header)
+}
+
+func TestUpdateClient(t *testing.T) {
+	vals := newTestValidators(1, 10, 10, 10, 10)
+	// three of the validators stay in the new set
+	rotated := append(newTestValidators(1, 10, 10, 10), newTestValidators(2, 10)...)
+	others := newTestValidators(3, 10, 10, 10, 10)
+
+	s := newTestContext(testGenesisTime + 100)
+	initClient(t, s, vals)
+
+	// adjacent header signed by 3 of 4 validators
+	sh := signedHeader(2, vals, rotated, sum("app 2"), 0, 1, 3)
+	if frozen, err := updateClient(t, s, encodeLightHeader(sh, vals, 1, nil)); err != nil || frozen {
+		t.Fatalf("adjacent header: frozen %v, err %v", frozen, err)
+	}
+	// resubmitted header is ignored
+	if frozen, err := updateClient(t, s, encodeLightHeader(sh, vals, 1, nil)); err != nil || frozen {
+		t.Fatalf("resubmitted header: frozen %v, err %v", frozen, err)
+	}
+
+	// skipping header signed by validators unknown to the trusted set
+	sh = signedHeader(10, others, others, sum("app 10"), 0, 1, 2, 3)
+	if _, err := updateClient(t, s, encodeLightHeader(sh, others, 2, rotated)); !errors.Is(err, ErrNotEnoughTrust) {
+		t.Fatalf("untrusted header: err %v, expect %v", err, ErrNotEnoughTrust)
+	}
+	// adjacent header signed by less than 2/3 of the validators
+	sh = signedHeader(3, rotated, rotated, sum("app 3"), 0, 1)
+	if _, err := updateClient(t, s, encodeLightHeader(sh, rotated, 2, nil)); !errors.Is(err, ErrNotEnoughVotingPower) {
+		t.Fatalf("header lacking votes: err %v, expect %v", err, ErrNotEnoughVotingPower)
+	}
+	// skipping header signed by enough trusted validators
+	sh = signedHeader(10, rotated, rotated, sum("app 10"), 0, 1, 2, 3)
+	if frozen, err := updateClient(t, s, encodeLightHeader(sh, rotated, 2, rotated)); err != nil || frozen {
+		t.Fatalf("skipping header: frozen %v, err %v", frozen, err)
+	}
+
+	client, err := GetClientState(s, testSideChainID)
+	if err != nil || client.LatestHeight != 10 {
+		t.Fatalf("latest height: %v, err %v", client, err)
+	}
+	trusted, err := GetTrustedState(s, testSideChainID, 10)
+	if err != nil || trusted == nil || !bytes.Equal(trusted.AppHash, sum("app 10")) {
+		t.Fatalf("trusted state of height 10: %v, err %v", trusted, err)
+	}
+}
+
+func TestUpdateClientExpired(t *testing.T) {
+	vals := newTestValidators(1, 10, 10, 10, 10)
+	s := newTestContext(testGenesisTime + int64(15*24*time.Hour/time.Second))
+	initClient(t, s, vals)
+
+	sh := signedHeader(2, vals, vals, sum("app 2"), 0, 1, 2, 3)
+	if _, err := updateClient(t, s, encodeLightHeader(sh, vals, 1, nil)); !errors.Is(err, ErrHeaderExpired) {
+		t.Fatalf("err %v, expect %v", err, ErrHeaderExpired)
+	}
+}
+
+func TestUpdateClientMisbehaviour(t *testing.T) {
+	vals := newTestValidators(1, 10, 10, 10, 10)
+	s := newTestContext(testGenesisTime + 100)
+	initClient(t, s, vals)
+
+	sh := signedHeader(2, vals, vals, sum("app 2"), 0, 1, 2, 3)
+	if frozen, err := updateClient(t, s, encodeLightHeader(sh, vals, 1, nil)); err != nil || frozen {
+		t.Fatalf("frozen %v, err %v", frozen, err)
+	}
+	// the validators sign another block of the same height
+	sh = signedHeader(2, vals, vals, sum("fork"), 0, 1, 2, 3)
+	if frozen, err := updateClient(t, s, encodeLightHeader(sh, vals, 1, nil)); err != nil || !frozen {
+		t.Fatalf("conflicting header: frozen %v, err %v", frozen, err)
+	}
+	client, err := GetClientState(s, testSideChainID)
+	if err != nil || client.FrozenHeight != 2 {
+		t.Fatalf("client %v, err %v", client, err)
+	}
+
+	sh = signedHeader(3, vals, vals, sum("app 3"), 0, 1, 2, 3)
+	if _, err := updateClient(t, s, encodeLightHeader(sh, vals, 2, nil)); err != ErrClientFrozen {
+		t.Fatalf("err %v, expect %v", err, ErrClientFrozen)
+	}
+}
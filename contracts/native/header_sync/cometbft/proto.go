@@ -0,0 +1,514 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package cometbft
+
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// The messages below are decoded from the protobuf wire format of CometBFT and IBC,
+// field numbers follow `tendermint/types/*.proto` and `ibc/lightclients/tendermint/v1`.
+
+type Timestamp struct {
+	Seconds int64
+	Nanos   int32
+}
+
+func (t Timestamp) Time() time.Time {
+	return time.Unix(t.Seconds, int64(t.Nanos)).UTC()
+}
+
+type PartSetHeader struct {
+	Total uint32
+	Hash  []byte
+}
+
+type BlockID struct {
+	Hash          []byte
+	PartSetHeader PartSetHeader
+}
+
+func (id BlockID) IsZero() bool {
+	return len(id.Hash) == 0 && id.PartSetHeader.Total == 0 && len(id.PartSetHeader.Hash) == 0
+}
+
+type Consensus struct {
+	Block uint64
+	App   uint64
+}
+
+type Header struct {
+	Version            Consensus
+	ChainID            string
+	Height             int64
+	Time               Timestamp
+	LastBlockID        BlockID
+	LastCommitHash     []byte
+	DataHash           []byte
+	ValidatorsHash     []byte
+	NextValidatorsHash []byte
+	ConsensusHash      []byte
+	AppHash            []byte
+	LastResultsHash    []byte
+	EvidenceHash       []byte
+	ProposerAddress    []byte
+}
+
+type BlockIDFlag uint64
+
+const (
+	BlockIDFlagAbsent BlockIDFlag = 1
+	BlockIDFlagCommit BlockIDFlag = 2
+	BlockIDFlagNil    BlockIDFlag = 3
+)
+
+type CommitSig struct {
+	BlockIDFlag      BlockIDFlag
+	ValidatorAddress []byte
+	Timestamp        Timestamp
+	Signature        []byte
+}
+
+type Commit struct {
+	Height     int64
+	Round      int32
+	BlockID    BlockID
+	Signatures []CommitSig
+}
+
+type Validator struct {
+	Address          []byte
+	PubKey           []byte // ed25519 public key, other key types are not supported
+	VotingPower      int64
+	ProposerPriority int64
+}
+
+type ValidatorSet struct {
+	Validators       []*Validator
+	Proposer         *Validator
+	TotalVotingPower int64
+}
+
+type SignedHeader struct {
+	Header *Header
+	Commit *Commit
+}
+
+type Height struct {
+	RevisionNumber uint64
+	RevisionHeight uint64
+}
+
+// LightHeader is the `Header` message of IBC tendermint client, which carries the signed
+// header with its validator set, and the trusted height with its next validator set the
+// header is verified against.
+type LightHeader struct {
+	SignedHeader      *SignedHeader
+	ValidatorSet      *ValidatorSet
+	TrustedHeight     Height
+	TrustedValidators *ValidatorSet
+}
+
+type Fraction struct {
+	Numerator   uint64
+	Denominator uint64
+}
+
+// ClientParams is the subset of IBC tendermint `ClientState` used by the light client.
+type ClientParams struct {
+	ChainID         string
+	TrustLevel      Fraction
+	TrustingPeriod  time.Duration
+	UnbondingPeriod time.Duration
+	MaxClockDrift   time.Duration
+}
+
+// Genesis is the input of `syncGenesisHeader`, it's encoded as
+//
+//	message Genesis {
+//	    ibc.lightclients.tendermint.v1.ClientState client_state = 1;
+//	    ibc.lightclients.tendermint.v1.Header header = 2;
+//	}
+//
+// where the trusted height and validators of the header are omitted.
+type Genesis struct {
+	Params *ClientParams
+	Header *LightHeader
+}
+
+// protoField is a field of the message in wire format, the value of length delimited
+// field is kept in bytes and the value of others in varint.
+type protoField struct {
+	num    protowire.Number
+	typ    protowire.Type
+	varint uint64
+	bytes  []byte
+}
+
+func decodeFields(b []byte) ([]protoField, error) {
+	var fields []protoField
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		b = b[n:]
+		field := protoField{num: num, typ: typ}
+		switch typ {
+		case protowire.VarintType:
+			field.varint, n = protowire.ConsumeVarint(b)
+		case protowire.Fixed64Type:
+			field.varint, n = protowire.ConsumeFixed64(b)
+		case protowire.Fixed32Type:
+			var v uint32
+			v, n = protowire.ConsumeFixed32(b)
+			field.varint = uint64(v)
+		case protowire.BytesType:
+			field.bytes, n = protowire.ConsumeBytes(b)
+		default:
+			return nil, fmt.Errorf("unsupported wire type %d of field %d", typ, num)
+		}
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		b = b[n:]
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// decodeMessage calls the decoder for each field, and checks the wire type of the
+// fields known by the decoder.
+func decodeMessage(b []byte, types map[protowire.Number]protowire.Type, decode func(f protoField) error) error {
+	fields, err := decodeFields(b)
+	if err != nil {
+		return err
+	}
+	for _, f := range fields {
+		typ, ok := types[f.num]
+		if !ok {
+			continue
+		}
+		if typ != f.typ {
+			return fmt.Errorf("wrong wire type %d of field %d", f.typ, f.num)
+		}
+		if err := decode(f); err != nil {
+			return fmt.Errorf("field %d: %v", f.num, err)
+		}
+	}
+	return nil
+}
+
+const (
+	wireVarint = protowire.VarintType
+	wireBytes  = protowire.BytesType
+)
+
+func decodeTimestamp(b []byte) (t Timestamp, err error) {
+	err = decodeMessage(b, map[protowire.Number]protowire.Type{1: wireVarint, 2: wireVarint}, func(f protoField) error {
+		switch f.num {
+		case 1:
+			t.Seconds = int64(f.varint)
+		case 2:
+			t.Nanos = int32(f.varint)
+		}
+		return nil
+	})
+	return
+}
+
+func decodeDuration(b []byte) (time.Duration, error) {
+	t, err := decodeTimestamp(b) // same layout as timestamp
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(t.Seconds)*time.Second + time.Duration(t.Nanos), nil
+}
+
+func decodeBlockID(b []byte) (id BlockID, err error) {
+	err = decodeMessage(b, map[protowire.Number]protowire.Type{1: wireBytes, 2: wireBytes}, func(f protoField) error {
+		switch f.num {
+		case 1:
+			id.Hash = f.bytes
+		case 2:
+			return decodeMessage(f.bytes, map[protowire.Number]protowire.Type{1: wireVarint, 2: wireBytes}, func(f protoField) error {
+				switch f.num {
+				case 1:
+					id.PartSetHeader.Total = uint32(f.varint)
+				case 2:
+					id.PartSetHeader.Hash = f.bytes
+				}
+				return nil
+			})
+		}
+		return nil
+	})
+	return
+}
+
+func DecodeHeader(b []byte) (*Header, error) {
+	h := new(Header)
+	types := map[protowire.Number]protowire.Type{1: wireBytes, 2: wireBytes, 3: wireVarint, 4: wireBytes, 5: wireBytes}
+	for i := protowire.Number(6); i <= 14; i++ {
+		types[i] = wireBytes
+	}
+	err := decodeMessage(b, types, func(f protoField) (err error) {
+		switch f.num {
+		case 1:
+			return decodeMessage(f.bytes, map[protowire.Number]protowire.Type{1: wireVarint, 2: wireVarint}, func(f protoField) error {
+				if f.num == 1 {
+					h.Version.Block = f.varint
+				} else {
+					h.Version.App = f.varint
+				}
+				return nil
+			})
+		case 2:
+			h.ChainID = string(f.bytes)
+		case 3:
+			h.Height = int64(f.varint)
+		case 4:
+			h.Time, err = decodeTimestamp(f.bytes)
+		case 5:
+			h.LastBlockID, err = decodeBlockID(f.bytes)
+		case 6:
+			h.LastCommitHash = f.bytes
+		case 7:
+			h.DataHash = f.bytes
+		case 8:
+			h.ValidatorsHash = f.bytes
+		case 9:
+			h.NextValidatorsHash = f.bytes
+		case 10:
+			h.ConsensusHash = f.bytes
+		case 11:
+			h.AppHash = f.bytes
+		case 12:
+			h.LastResultsHash = f.bytes
+		case 13:
+			h.EvidenceHash = f.bytes
+		case 14:
+			h.ProposerAddress = f.bytes
+		}
+		return
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid header: %v", err)
+	}
+	return h, nil
+}
+
+func decodeCommitSig(b []byte) (sig CommitSig, err error) {
+	err = decodeMessage(b, map[protowire.Number]protowire.Type{1: wireVarint, 2: wireBytes, 3: wireBytes, 4: wireBytes}, func(f protoField) (err error) {
+		switch f.num {
+		case 1:
+			sig.BlockIDFlag = BlockIDFlag(f.varint)
+		case 2:
+			sig.ValidatorAddress = f.bytes
+		case 3:
+			sig.Timestamp, err = decodeTimestamp(f.bytes)
+		case 4:
+			sig.Signature = f.bytes
+		}
+		return
+	})
+	return
+}
+
+func DecodeCommit(b []byte) (*Commit, error) {
+	c := new(Commit)
+	err := decodeMessage(b, map[protowire.Number]protowire.Type{1: wireVarint, 2: wireVarint, 3: wireBytes, 4: wireBytes}, func(f protoField) (err error) {
+		switch f.num {
+		case 1:
+			c.Height = int64(f.varint)
+		case 2:
+			c.Round = int32(f.varint)
+		case 3:
+			c.BlockID, err = decodeBlockID(f.bytes)
+		case 4:
+			var sig CommitSig
+			if sig, err = decodeCommitSig(f.bytes); err == nil {
+				c.Signatures = append(c.Signatures, sig)
+			}
+		}
+		return
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid commit: %v", err)
+	}
+	return c, nil
+}
+
+func decodeValidator(b []byte) (*Validator, error) {
+	v := new(Validator)
+	err := decodeMessage(b, map[protowire.Number]protowire.Type{1: wireBytes, 2: wireBytes, 3: wireVarint, 4: wireVarint}, func(f protoField) error {
+		switch f.num {
+		case 1:
+			v.Address = f.bytes
+		case 2:
+			return decodeMessage(f.bytes, map[protowire.Number]protowire.Type{1: wireBytes, 2: wireBytes}, func(f protoField) error {
+				if f.num != 1 {
+					return fmt.Errorf("unsupported public key type %d", f.num)
+				}
+				v.PubKey = f.bytes
+				return nil
+			})
+		case 3:
+			v.VotingPower = int64(f.varint)
+		case 4:
+			v.ProposerPriority = int64(f.varint)
+		}
+		return nil
+	})
+	return v, err
+}
+
+func DecodeValidatorSet(b []byte) (*ValidatorSet, error) {
+	set := new(ValidatorSet)
+	err := decodeMessage(b, map[protowire.Number]protowire.Type{1: wireBytes, 2: wireBytes, 3: wireVarint}, func(f protoField) error {
+		switch f.num {
+		case 1:
+			v, err := decodeValidator(f.bytes)
+			if err != nil {
+				return err
+			}
+			set.Validators = append(set.Validators, v)
+		case 2:
+			v, err := decodeValidator(f.bytes)
+			if err != nil {
+				return err
+			}
+			set.Proposer = v
+		case 3:
+			set.TotalVotingPower = int64(f.varint)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid validator set: %v", err)
+	}
+	return set, nil
+}
+
+func DecodeSignedHeader(b []byte) (*SignedHeader, error) {
+	sh := new(SignedHeader)
+	err := decodeMessage(b, map[protowire.Number]protowire.Type{1: wireBytes, 2: wireBytes}, func(f protoField) (err error) {
+		if f.num == 1 {
+			sh.Header, err = DecodeHeader(f.bytes)
+		} else {
+			sh.Commit, err = DecodeCommit(f.bytes)
+		}
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+	if sh.Header == nil || sh.Commit == nil {
+		return nil, fmt.Errorf("signed header without header or commit")
+	}
+	return sh, nil
+}
+
+func decodeHeight(b []byte) (h Height, err error) {
+	err = decodeMessage(b, map[protowire.Number]protowire.Type{1: wireVarint, 2: wireVarint}, func(f protoField) error {
+		if f.num == 1 {
+			h.RevisionNumber = f.varint
+		} else {
+			h.RevisionHeight = f.varint
+		}
+		return nil
+	})
+	return
+}
+
+func DecodeLightHeader(b []byte) (*LightHeader, error) {
+	h := new(LightHeader)
+	err := decodeMessage(b, map[protowire.Number]protowire.Type{1: wireBytes, 2: wireBytes, 3: wireBytes, 4: wireBytes}, func(f protoField) (err error) {
+		switch f.num {
+		case 1:
+			h.SignedHeader, err = DecodeSignedHeader(f.bytes)
+		case 2:
+			h.ValidatorSet, err = DecodeValidatorSet(f.bytes)
+		case 3:
+			h.TrustedHeight, err = decodeHeight(f.bytes)
+		case 4:
+			h.TrustedValidators, err = DecodeValidatorSet(f.bytes)
+		}
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+	if h.SignedHeader == nil || h.ValidatorSet == nil {
+		return nil, fmt.Errorf("light header without signed header or validator set")
+	}
+	return h, nil
+}
+
+func decodeClientParams(b []byte) (*ClientParams, error) {
+	p := new(ClientParams)
+	err := decodeMessage(b, map[protowire.Number]protowire.Type{1: wireBytes, 2: wireBytes, 3: wireBytes, 4: wireBytes, 5: wireBytes}, func(f protoField) (err error) {
+		switch f.num {
+		case 1:
+			p.ChainID = string(f.bytes)
+		case 2:
+			err = decodeMessage(f.bytes, map[protowire.Number]protowire.Type{1: wireVarint, 2: wireVarint}, func(f protoField) error {
+				if f.num == 1 {
+					p.TrustLevel.Numerator = f.varint
+				} else {
+					p.TrustLevel.Denominator = f.varint
+				}
+				return nil
+			})
+		case 3:
+			p.TrustingPeriod, err = decodeDuration(f.bytes)
+		case 4:
+			p.UnbondingPeriod, err = decodeDuration(f.bytes)
+		case 5:
+			p.MaxClockDrift, err = decodeDuration(f.bytes)
+		}
+		return
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid client state: %v", err)
+	}
+	return p, nil
+}
+
+func DecodeGenesis(b []byte) (*Genesis, error) {
+	g := new(Genesis)
+	err := decodeMessage(b, map[protowire.Number]protowire.Type{1: wireBytes, 2: wireBytes}, func(f protoField) (err error) {
+		if f.num == 1 {
+			g.Params, err = decodeClientParams(f.bytes)
+		} else {
+			g.Header, err = DecodeLightHeader(f.bytes)
+		}
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+	if g.Params == nil || g.Header == nil {
+		return nil, fmt.Errorf("genesis without client state or header")
+	}
+	return g, nil
+}
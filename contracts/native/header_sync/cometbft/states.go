@@ -0,0 +1,137 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package cometbft
+
+import (
+	"encoding/hex"
+	"time"
+
+	"github.com/ethereum/go-ethereum/contracts/native"
+	scom "github.com/ethereum/go-ethereum/contracts/native/header_sync/common"
+	"github.com/ethereum/go-ethereum/contracts/native/utils"
+	"github.com/ethereum/go-ethereum/rlp"
+	cstates "github.com/polynetwork/poly/core/states"
+)
+
+// ClientState is the light client of the counterparty chain.
+type ClientState struct {
+	ChainID          string
+	TrustNumerator   uint64
+	TrustDenominator uint64
+	TrustingPeriod   uint64 // in nanoseconds
+	UnbondingPeriod  uint64 // in nanoseconds
+	MaxClockDrift    uint64 // in nanoseconds
+	LatestHeight     uint64
+	FrozenHeight     uint64 // height of the conflicting header, the client is frozen if it's not zero
+}
+
+func (c *ClientState) Params() *ClientParams {
+	return &ClientParams{
+		ChainID:         c.ChainID,
+		TrustLevel:      Fraction{Numerator: c.TrustNumerator, Denominator: c.TrustDenominator},
+		TrustingPeriod:  time.Duration(c.TrustingPeriod),
+		UnbondingPeriod: time.Duration(c.UnbondingPeriod),
+		MaxClockDrift:   time.Duration(c.MaxClockDrift),
+	}
+}
+
+func (c *ClientState) Frozen() bool {
+	return c.FrozenHeight != 0
+}
+
+// storedState is the rlp form of TrustedState.
+type storedState struct {
+	Time               uint64 // in unix nanoseconds
+	AppHash            []byte
+	NextValidatorsHash []byte
+	BlockHash          []byte
+}
+
+func putClientState(s *native.NativeContract, chainID uint64, client *ClientState) error {
+	blob, err := rlp.EncodeToBytes(client)
+	if err != nil {
+		return err
+	}
+	s.GetCacheDB().Put(clientKey(chainID), cstates.GenRawStorageItem(blob))
+	return nil
+}
+
+// GetClientState returns the light client of the chain, or nil if it's not created.
+func GetClientState(s *native.NativeContract, chainID uint64) (*ClientState, error) {
+	blob, err := s.GetCacheDB().Get(clientKey(chainID))
+	if err != nil || len(blob) == 0 {
+		return nil, err
+	}
+	enc, err := cstates.GetValueFromRawStorageItem(blob)
+	if err != nil {
+		return nil, err
+	}
+	client := new(ClientState)
+	if err := rlp.DecodeBytes(enc, client); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+func putTrustedState(s *native.NativeContract, chainID uint64, state *TrustedState) error {
+	blob, err := rlp.EncodeToBytes(&storedState{
+		Time:               uint64(state.Time.UnixNano()),
+		AppHash:            state.AppHash,
+		NextValidatorsHash: state.NextValidatorsHash,
+		BlockHash:          state.BlockHash,
+	})
+	if err != nil {
+		return err
+	}
+	s.GetCacheDB().Put(consensusKey(chainID, state.Height), cstates.GenRawStorageItem(blob))
+	scom.NotifyPutHeader(s, chainID, state.Height, hex.EncodeToString(state.BlockHash))
+	return nil
+}
+
+// GetTrustedState returns the verified state of the chain at the height, or nil if
+// the header of the height is not verified.
+func GetTrustedState(s *native.NativeContract, chainID, height uint64) (*TrustedState, error) {
+	blob, err := s.GetCacheDB().Get(consensusKey(chainID, height))
+	if err != nil || len(blob) == 0 {
+		return nil, err
+	}
+	enc, err := cstates.GetValueFromRawStorageItem(blob)
+	if err != nil {
+		return nil, err
+	}
+	var stored storedState
+	if err := rlp.DecodeBytes(enc, &stored); err != nil {
+		return nil, err
+	}
+	return &TrustedState{
+		Height:             height,
+		Time:               time.Unix(0, int64(stored.Time)).UTC(),
+		AppHash:            stored.AppHash,
+		NextValidatorsHash: stored.NextValidatorsHash,
+		BlockHash:          stored.BlockHash,
+	}, nil
+}
+
+func clientKey(chainID uint64) []byte {
+	return utils.ConcatKey(utils.HeaderSyncContractAddress, []byte(scom.COMETBFT_CLIENT), utils.GetUint64Bytes(chainID))
+}
+
+func consensusKey(chainID, height uint64) []byte {
+	return utils.ConcatKey(utils.HeaderSyncContractAddress, []byte(scom.COMETBFT_CONSENSUS), utils.GetUint64Bytes(chainID), utils.GetUint64Bytes(height))
+}
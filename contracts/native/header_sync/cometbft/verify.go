@@ -0,0 +1,228 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package cometbft
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+var (
+	ErrHeaderExpired        = errors.New("trusted header is expired")
+	ErrNotEnoughTrust       = errors.New("not enough trusted validators signed the header")
+	ErrInvalidValidatorSet  = errors.New("invalid validator set")
+	ErrNotEnoughVotingPower = errors.New("not enough voting power signed the header")
+)
+
+// TrustedState is the verified state of the counterparty chain at a height, which
+// is the consensus state of IBC tendermint client.
+type TrustedState struct {
+	Height             uint64
+	Time               time.Time
+	AppHash            []byte
+	NextValidatorsHash []byte
+	BlockHash          []byte
+}
+
+// ValidateBasic checks the validator set, each validator must have an ed25519 key
+// matching its address and positive voting power.
+func (set *ValidatorSet) ValidateBasic() error {
+	if set == nil || len(set.Validators) == 0 {
+		return fmt.Errorf("%w: empty set", ErrInvalidValidatorSet)
+	}
+	for i, v := range set.Validators {
+		if len(v.PubKey) != ed25519.PublicKeySize {
+			return fmt.Errorf("%w: validator %d has invalid ed25519 key", ErrInvalidValidatorSet, i)
+		}
+		if addr := sha256.Sum256(v.PubKey); !bytes.Equal(v.Address, addr[:20]) {
+			return fmt.Errorf("%w: validator %d address mismatch", ErrInvalidValidatorSet, i)
+		}
+		if v.VotingPower <= 0 {
+			return fmt.Errorf("%w: validator %d has no voting power", ErrInvalidValidatorSet, i)
+		}
+	}
+	return nil
+}
+
+func (set *ValidatorSet) totalVotingPower() *big.Int {
+	total := new(big.Int)
+	for _, v := range set.Validators {
+		total.Add(total, big.NewInt(v.VotingPower))
+	}
+	return total
+}
+
+func (set *ValidatorSet) getByAddress(addr []byte) *Validator {
+	for _, v := range set.Validators {
+		if bytes.Equal(v.Address, addr) {
+			return v
+		}
+	}
+	return nil
+}
+
+// ValidateBasic checks the signed header is the block of the chain committed by
+// the commit.
+func (sh *SignedHeader) ValidateBasic(chainID string) error {
+	if sh.Header.ChainID != chainID {
+		return fmt.Errorf("header belongs to chain %q, not %q", sh.Header.ChainID, chainID)
+	}
+	if sh.Header.Height <= 0 {
+		return fmt.Errorf("invalid header height %d", sh.Header.Height)
+	}
+	if sh.Commit.Height != sh.Header.Height {
+		return fmt.Errorf("commit height %d mismatches header height %d", sh.Commit.Height, sh.Header.Height)
+	}
+	if hash := sh.Header.Hash(); !bytes.Equal(sh.Commit.BlockID.Hash, hash) {
+		return fmt.Errorf("commit signs block %x, header is %x", sh.Commit.BlockID.Hash, hash)
+	}
+	for i, sig := range sh.Commit.Signatures {
+		switch sig.BlockIDFlag {
+		case BlockIDFlagAbsent:
+		case BlockIDFlagCommit, BlockIDFlagNil:
+			if len(sig.ValidatorAddress) != 20 || len(sig.Signature) == 0 || len(sig.Signature) > 64 {
+				return fmt.Errorf("invalid commit signature %d", i)
+			}
+		default:
+			return fmt.Errorf("unknown block id flag %d of commit signature %d", sig.BlockIDFlag, i)
+		}
+	}
+	return nil
+}
+
+// VerifyCommitLight checks more than 2/3 of the voting power of the validator set
+// signed the commit, which must have the signatures in the order of the set.
+func VerifyCommitLight(chainID string, set *ValidatorSet, commit *Commit) error {
+	if len(set.Validators) != len(commit.Signatures) {
+		return fmt.Errorf("%w: %d validators with %d signatures", ErrInvalidValidatorSet, len(set.Validators), len(commit.Signatures))
+	}
+	needed := new(big.Int).Mul(set.totalVotingPower(), big.NewInt(2))
+	needed.Div(needed, big.NewInt(3))
+
+	tallied := new(big.Int)
+	for idx, sig := range commit.Signatures {
+		if sig.BlockIDFlag != BlockIDFlagCommit {
+			continue
+		}
+		val := set.Validators[idx]
+		if !bytes.Equal(val.Address, sig.ValidatorAddress) {
+			return fmt.Errorf("signature %d is signed by %x, expect %x", idx, sig.ValidatorAddress, val.Address)
+		}
+		if !ed25519.Verify(val.PubKey, commit.VoteSignBytes(chainID, idx), sig.Signature) {
+			return fmt.Errorf("invalid signature %d of validator %x", idx, val.Address)
+		}
+		if tallied.Add(tallied, big.NewInt(val.VotingPower)).Cmp(needed) > 0 {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %v of %v needed", ErrNotEnoughVotingPower, tallied, needed)
+}
+
+// VerifyCommitLightTrusting checks the validators of the trusted set signing the
+// commit have more than trustLevel of its voting power, the signatures can be in
+// any order as the trusted set may differ from the signing set.
+func VerifyCommitLightTrusting(chainID string, trusted *ValidatorSet, commit *Commit, trustLevel Fraction) error {
+	needed := new(big.Int).Mul(trusted.totalVotingPower(), new(big.Int).SetUint64(trustLevel.Numerator))
+	needed.Div(needed, new(big.Int).SetUint64(trustLevel.Denominator))
+
+	tallied := new(big.Int)
+	seen := make(map[string]bool)
+	for idx, sig := range commit.Signatures {
+		if sig.BlockIDFlag != BlockIDFlagCommit {
+			continue
+		}
+		val := trusted.getByAddress(sig.ValidatorAddress)
+		if val == nil {
+			continue
+		}
+		if seen[string(val.Address)] {
+			return fmt.Errorf("double vote of validator %x", val.Address)
+		}
+		seen[string(val.Address)] = true
+
+		if !ed25519.Verify(val.PubKey, commit.VoteSignBytes(chainID, idx), sig.Signature) {
+			return fmt.Errorf("invalid signature %d of validator %x", idx, val.Address)
+		}
+		if tallied.Add(tallied, big.NewInt(val.VotingPower)).Cmp(needed) > 0 {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %v of %v needed", ErrNotEnoughTrust, tallied, needed)
+}
+
+// ValidateTrustLevel checks the trust level is within [1/3, 1].
+func ValidateTrustLevel(lvl Fraction) error {
+	if lvl.Denominator == 0 || lvl.Numerator*3 < lvl.Denominator || lvl.Numerator > lvl.Denominator {
+		return fmt.Errorf("trust level must be within [1/3, 1], given %d/%d", lvl.Numerator, lvl.Denominator)
+	}
+	return nil
+}
+
+// Verify verifies the untrusted header against the trusted state, with the trusted
+// validators of the next block of trusted state. The adjacent header must be signed
+// by the trusted validators, while the non-adjacent one is accepted if the trusted
+// validators signing it have more than trust level of the voting power, which is
+// the skipping verification of the light client.
+func Verify(params *ClientParams, trusted *TrustedState, trustedVals *ValidatorSet, untrusted *SignedHeader, untrustedVals *ValidatorSet, now time.Time) error {
+	if !trusted.Time.Add(params.TrustingPeriod).After(now) {
+		return fmt.Errorf("%w: trusted at %v, now %v", ErrHeaderExpired, trusted.Time, now)
+	}
+	if err := untrusted.ValidateBasic(params.ChainID); err != nil {
+		return err
+	}
+	header := untrusted.Header
+	if uint64(header.Height) <= trusted.Height {
+		return fmt.Errorf("header height %d is not higher than trusted height %d", header.Height, trusted.Height)
+	}
+	if untrustedTime := header.Time.Time(); !untrustedTime.After(trusted.Time) {
+		return fmt.Errorf("header time %v is not after trusted time %v", untrustedTime, trusted.Time)
+	} else if !untrustedTime.Before(now.Add(params.MaxClockDrift)) {
+		return fmt.Errorf("header time %v is from the future, now %v, max clock drift %v", untrustedTime, now, params.MaxClockDrift)
+	}
+	if err := untrustedVals.ValidateBasic(); err != nil {
+		return err
+	}
+	if hash := untrustedVals.Hash(); !bytes.Equal(header.ValidatorsHash, hash) {
+		return fmt.Errorf("%w: hash %x, header expects %x", ErrInvalidValidatorSet, hash, header.ValidatorsHash)
+	}
+
+	if uint64(header.Height) == trusted.Height+1 {
+		if !bytes.Equal(header.ValidatorsHash, trusted.NextValidatorsHash) {
+			return fmt.Errorf("%w: adjacent header validators %x, trusted next validators %x",
+				ErrInvalidValidatorSet, header.ValidatorsHash, trusted.NextValidatorsHash)
+		}
+	} else {
+		if err := trustedVals.ValidateBasic(); err != nil {
+			return err
+		}
+		if hash := trustedVals.Hash(); !bytes.Equal(hash, trusted.NextValidatorsHash) {
+			return fmt.Errorf("%w: trusted validators %x, trusted next validators %x",
+				ErrInvalidValidatorSet, hash, trusted.NextValidatorsHash)
+		}
+		if err := VerifyCommitLightTrusting(params.ChainID, trustedVals, untrusted.Commit, params.TrustLevel); err != nil {
+			return err
+		}
+	}
+	return VerifyCommitLight(params.ChainID, untrustedVals, untrusted.Commit)
+}
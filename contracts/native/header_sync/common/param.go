@@ -42,6 +42,10 @@ const (
 	SYNC_HEADER_NAME_EVENT      = "syncHeader"
 	SYNC_CROSSCHAIN_MSG         = "syncCrossChainMsg"
 	POLYGON_SPAN                = "polygonSpan"
+	COMETBFT_CLIENT             = "cometbftClient"
+	COMETBFT_CONSENSUS          = "cometbftConsensus"
+	CLIQUE_SNAPSHOT             = "cliqueSnapshot"
+	OPTIMISM_OUTPUT             = "optimismOutput"
 )
 
 type HeaderSyncHandler interface {
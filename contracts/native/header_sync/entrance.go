@@ -23,12 +23,15 @@ import (
 	"github.com/ethereum/go-ethereum/contracts/native"
 	"github.com/ethereum/go-ethereum/contracts/native/governance/side_chain_manager"
 	"github.com/ethereum/go-ethereum/contracts/native/header_sync/bsc"
+	"github.com/ethereum/go-ethereum/contracts/native/header_sync/clique"
+	"github.com/ethereum/go-ethereum/contracts/native/header_sync/cometbft"
 	hscommon "github.com/ethereum/go-ethereum/contracts/native/header_sync/common"
 	"github.com/ethereum/go-ethereum/contracts/native/header_sync/cosmos"
 	"github.com/ethereum/go-ethereum/contracts/native/header_sync/eth"
 	"github.com/ethereum/go-ethereum/contracts/native/header_sync/heco"
 	"github.com/ethereum/go-ethereum/contracts/native/header_sync/msc"
 	"github.com/ethereum/go-ethereum/contracts/native/header_sync/okex"
+	"github.com/ethereum/go-ethereum/contracts/native/header_sync/optimism"
 	"github.com/ethereum/go-ethereum/contracts/native/header_sync/polygon"
 	"github.com/ethereum/go-ethereum/contracts/native/header_sync/quorum"
 	"github.com/ethereum/go-ethereum/contracts/native/header_sync/zilliqa"
@@ -43,7 +46,7 @@ var (
 )
 
 func InitHeaderSync() {
-	native.Contracts[this] = RegisterHeaderSyncContract
+	native.Register(this, RegisterHeaderSyncContract)
 	hscommon.ABI = hscommon.GetABI()
 }
 
@@ -68,7 +71,7 @@ func SyncGenesisHeader(s *native.NativeContract) ([]byte, error) {
 	}
 	chainID := params.ChainID
 
-	if native.IsMainChain(chainID) {
+	if s.ContractRef().IsMainChain(chainID) {
 		return nil, fmt.Errorf("SyncGenesisHeader, sync relay chain's genesis header is NOT allowed!")
 	}
 
@@ -102,7 +105,7 @@ func SyncBlockHeader(s *native.NativeContract) ([]byte, error) {
 	}
 
 	chainID := params.ChainID
-	if native.IsMainChain(chainID) {
+	if s.ContractRef().IsMainChain(chainID) {
 		return nil, fmt.Errorf("SyncBlockHeader, sync relay chain's header is NOT allowed!")
 	}
 
@@ -136,7 +139,7 @@ func SyncCrossChainMsg(s *native.NativeContract) ([]byte, error) {
 	}
 
 	chainID := params.ChainID
-	if native.IsMainChain(chainID) {
+	if s.ContractRef().IsMainChain(chainID) {
 		return nil, fmt.Errorf("SyncCrossChainMsg, sync relay chain's cross chain message is NOT allowed")
 	}
 
@@ -185,6 +188,12 @@ func GetChainHandler(router uint64) (hscommon.HeaderSyncHandler, error) {
 		return zilliqa.NewHandler(), nil
 	case utils.ZION_ROUTER:
 		return zion.NewHandler(), nil
+	case utils.COMETBFT_ROUTER:
+		return cometbft.NewHandler(), nil
+	case utils.CLIQUE_ROUTER:
+		return clique.NewHandler(), nil
+	case utils.OPTIMISM_ROUTER:
+		return optimism.NewHandler(), nil
 	default:
 		return nil, fmt.Errorf("not a supported router:%d", router)
 	}
@@ -143,6 +143,7 @@ func To1559(h *otypes.Header) *Header {
 		Extra:       h.Extra,
 		MixDigest:   h.MixDigest,
 		Nonce:       h.Nonce,
+		BaseFee:     h.BaseFee,
 	}
 }
 
@@ -49,6 +49,7 @@ func (h Header) MarshalJSON() ([]byte, error) {
 		Extra       hexutil.Bytes    `json:"extraData"        gencodec:"required"`
 		MixDigest   common.Hash      `json:"mixHash"`
 		Nonce       types.BlockNonce `json:"nonce"`
+		BaseFee     *hexutil.Big     `json:"baseFeePerGas" rlp:"optional"`
 		Hash        common.Hash      `json:"hash"`
 	}
 	var enc Header
@@ -67,6 +68,7 @@ func (h Header) MarshalJSON() ([]byte, error) {
 	enc.Extra = h.Extra
 	enc.MixDigest = h.MixDigest
 	enc.Nonce = h.Nonce
+	enc.BaseFee = (*hexutil.Big)(h.BaseFee)
 	enc.Hash = h.Hash()
 	return json.Marshal(&enc)
 }
@@ -89,6 +91,7 @@ func (h *Header) UnmarshalJSON(input []byte) error {
 		Extra       *hexutil.Bytes    `json:"extraData"        gencodec:"required"`
 		MixDigest   *common.Hash      `json:"mixHash"`
 		Nonce       *types.BlockNonce `json:"nonce"`
+		BaseFee     *hexutil.Big      `json:"baseFeePerGas" rlp:"optional"`
 	}
 	var dec Header
 	if err := json.Unmarshal(input, &dec); err != nil {
@@ -152,5 +155,8 @@ func (h *Header) UnmarshalJSON(input []byte) error {
 	if dec.Nonce != nil {
 		h.Nonce = *dec.Nonce
 	}
+	if dec.BaseFee != nil {
+		h.BaseFee = (*big.Int)(dec.BaseFee)
+	}
 	return nil
 }
@@ -0,0 +1,128 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package optimism
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/contracts/native"
+	"github.com/ethereum/go-ethereum/contracts/native/governance/side_chain_manager"
+	scom "github.com/ethereum/go-ethereum/contracts/native/header_sync/common"
+	"github.com/ethereum/go-ethereum/contracts/native/utils"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// ExtraInfo is the json `ExtraInfo` of the rollup side chain, it points to the output
+// oracle of the rollup on the L1 chain synced by the eth router.
+type ExtraInfo struct {
+	L1ChainID          uint64         `json:"l1ChainID"`          // Side chain id of the L1 chain
+	OutputOracle       common.Address `json:"outputOracle"`       // Address of the L2OutputOracle on L1
+	OutputsSlot        uint64         `json:"outputsSlot"`        // Storage slot of the `l2Outputs` array
+	FinalizationPeriod uint64         `json:"finalizationPeriod"` // Seconds of the challenge window
+}
+
+// Handler syncs the finalized outputs of the OP-stack rollups from their L1 output oracle.
+type Handler struct {
+}
+
+func NewHandler() *Handler {
+	return &Handler{}
+}
+
+// getContext returns the extra info of the rollup together with the L1 side chain.
+func getContext(ns *native.NativeContract, chainID uint64) (*ExtraInfo, *side_chain_manager.SideChain, error) {
+	side, err := side_chain_manager.GetSideChain(ns, chainID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get side chain error: %v", err)
+	}
+	if side == nil {
+		return nil, nil, fmt.Errorf("side chain %d not found", chainID)
+	}
+	info := new(ExtraInfo)
+	if err := json.Unmarshal(side.ExtraInfo, info); err != nil {
+		return nil, nil, fmt.Errorf("deserialize extra info error: %v", err)
+	}
+	if info.FinalizationPeriod == 0 {
+		return nil, nil, fmt.Errorf("invalid finalization period")
+	}
+	if info.OutputOracle == (common.Address{}) {
+		return nil, nil, fmt.Errorf("invalid output oracle")
+	}
+
+	l1, err := side_chain_manager.GetSideChain(ns, info.L1ChainID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get L1 side chain error: %v", err)
+	}
+	if l1 == nil {
+		return nil, nil, fmt.Errorf("L1 side chain %d not found", info.L1ChainID)
+	}
+	if l1.Router != utils.ETH_ROUTER {
+		return nil, nil, fmt.Errorf("L1 side chain %d is not synced by eth router", info.L1ChainID)
+	}
+	return info, l1, nil
+}
+
+// SyncGenesisHeader is not supported, the outputs are trusted from the L1 headers
+// which are synced by the eth router.
+func (h *Handler) SyncGenesisHeader(ns *native.NativeContract) error {
+	return fmt.Errorf("optimism Handler SyncGenesisHeader, no genesis header needed, sync the L1 chain instead")
+}
+
+func (h *Handler) SyncBlockHeader(ns *native.NativeContract) error {
+	params := &scom.SyncBlockHeaderParam{}
+	{
+		ctx := ns.ContractRef().CurrentContext()
+		if err := utils.UnpackMethod(scom.ABI, scom.MethodSyncBlockHeader, params, ctx.Payload); err != nil {
+			return err
+		}
+	}
+	info, l1, err := getContext(ns, params.ChainID)
+	if err != nil {
+		return fmt.Errorf("optimism Handler SyncBlockHeader, %v", err)
+	}
+
+	for i, v := range params.Headers {
+		proof := new(OutputProof)
+		if err := json.Unmarshal(v, proof); err != nil {
+			return fmt.Errorf("optimism Handler SyncBlockHeader, deserialize No.%d output proof err: %v", i, err)
+		}
+		output, err := verifyOutput(ns, proof, info, l1)
+		if err != nil {
+			return fmt.Errorf("optimism Handler SyncBlockHeader, failed to verify No.%d output %d: %v", i, proof.Index, err)
+		}
+		exist, err := isOutputExist(ns, params.ChainID, output.L2BlockNumber)
+		if err != nil {
+			return fmt.Errorf("optimism Handler SyncBlockHeader, %v", err)
+		}
+		if exist {
+			log.Warnf("optimism Handler SyncBlockHeader, output has exist. L2 height: %d", output.L2BlockNumber)
+			continue
+		}
+		if err := putOutput(ns, params.ChainID, output); err != nil {
+			return fmt.Errorf("optimism Handler SyncBlockHeader, put output error: %v", err)
+		}
+	}
+	return nil
+}
+
+func (h *Handler) SyncCrossChainMsg(ns *native.NativeContract) error {
+	return nil
+}
@@ -0,0 +1,260 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package optimism
+
+import (
+	"encoding/json"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/contracts/native"
+	eth2 "github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/eth"
+	"github.com/ethereum/go-ethereum/contracts/native/governance/side_chain_manager"
+	scom "github.com/ethereum/go-ethereum/contracts/native/header_sync/common"
+	"github.com/ethereum/go-ethereum/contracts/native/header_sync/eth"
+	"github.com/ethereum/go-ethereum/contracts/native/utils"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/crypto"
+	cstates "github.com/polynetwork/poly/core/states"
+)
+
+const (
+	testL1ChainID     = uint64(2)
+	testRollupChainID = uint64(10)
+
+	testOutputsSlot        = uint64(3)
+	testFinalizationPeriod = uint64(600)
+)
+
+var (
+	testOracle = common.HexToAddress("0x0000000000000000000000000000000000007777")
+	testCCM    = common.HexToAddress("0x0000000000000000000000000000000000008888")
+)
+
+func TestMain(m *testing.M) {
+	scom.ABI = scom.GetABI()
+	os.Exit(m.Run())
+}
+
+func newTestContext(t *testing.T) *native.NativeContract {
+	db := state.NewDatabase(rawdb.NewMemoryDatabase())
+	sdb, _ := state.New(common.Hash{}, db, nil)
+	ref := native.NewContractRef(sdb, common.Address{}, common.Address{}, big.NewInt(1), common.Hash{}, 0, nil)
+	ref.PushContext(&native.Context{ContractAddress: utils.HeaderSyncContractAddress})
+	ns := native.NewNativeContract(sdb, ref)
+
+	if err := side_chain_manager.PutSideChain(ns, &side_chain_manager.SideChain{
+		ChainId: testL1ChainID, Router: utils.ETH_ROUTER, Name: "l1", BlocksToWait: 2,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	info, _ := json.Marshal(&ExtraInfo{
+		L1ChainID:          testL1ChainID,
+		OutputOracle:       testOracle,
+		OutputsSlot:        testOutputsSlot,
+		FinalizationPeriod: testFinalizationPeriod,
+	})
+	if err := side_chain_manager.PutSideChain(ns, &side_chain_manager.SideChain{
+		ChainId: testRollupChainID, Router: utils.OPTIMISM_ROUTER, Name: "rollup", CCMCAddress: testCCM.Bytes(), ExtraInfo: info,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return ns
+}
+
+// putL1Header writes the header into the canonical chain of the eth router.
+func putL1Header(t *testing.T, ns *native.NativeContract, header *eth.Header) {
+	blob, err := json.Marshal(&eth.HeaderWithDifficultySum{Header: *header, DifficultySum: header.Difficulty})
+	if err != nil {
+		t.Fatal(err)
+	}
+	chainID, height := utils.GetUint64Bytes(testL1ChainID), utils.GetUint64Bytes(header.Number.Uint64())
+	ns.GetCacheDB().Put(utils.ConcatKey(utils.HeaderSyncContractAddress, []byte(scom.HEADER_INDEX), chainID, header.Hash().Bytes()),
+		cstates.GenRawStorageItem(blob))
+	ns.GetCacheDB().Put(utils.ConcatKey(utils.HeaderSyncContractAddress, []byte(scom.MAIN_CHAIN), chainID, height),
+		cstates.GenRawStorageItem(header.Hash().Bytes()))
+	ns.GetCacheDB().Put(utils.ConcatKey(utils.HeaderSyncContractAddress, []byte(scom.CURRENT_HEADER_HEIGHT), chainID),
+		cstates.GenRawStorageItem(height))
+}
+
+// contractState commits the storage of the contract, and returns the state root with
+// the storage proofs of the keys.
+func contractState(t *testing.T, contract common.Address, storage map[common.Hash][]byte, keys []common.Hash) (common.Hash, *eth2.ETHProof) {
+	sdb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	sdb.SetNonce(contract, 1)
+	sdb.SetCode(contract, []byte{0x60, 0x00})
+	for key, value := range storage {
+		sdb.SetState(contract, key, value)
+	}
+	root, err := sdb.Commit(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	accountProof, err := sdb.GetProof(contract)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proof := &eth2.ETHProof{
+		Address:     contract.Hex(),
+		Balance:     "0x0",
+		CodeHash:    sdb.GetCodeHash(contract).Hex(),
+		Nonce:       "0x1",
+		StorageHash: sdb.StorageTrie(contract).Hash().Hex(),
+	}
+	for _, node := range accountProof {
+		proof.AccountProof = append(proof.AccountProof, hexutil.Encode(node))
+	}
+	for _, key := range keys {
+		nodes, err := sdb.GetStorageProof(contract, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sp := eth2.StorageProof{Key: key.Hex()}
+		for _, node := range nodes {
+			sp.Proof = append(sp.Proof, hexutil.Encode(node))
+		}
+		proof.StorageProofs = append(proof.StorageProofs, sp)
+	}
+	return root, proof
+}
+
+// proposeOutput commits the output of the index into the oracle at the L1 height, and
+// returns the proof to relay.
+func proposeOutput(t *testing.T, ns *native.NativeContract, l1Height, l1Time, index uint64, output *Output) *OutputProof {
+	mpRoot := common.HexToHash("0x01")
+	root := OutputRootV0(output.StateRoot, mpRoot, output.BlockHash)
+	packed := new(big.Int).Lsh(new(big.Int).SetUint64(output.L2BlockNumber), 128)
+	packed.Or(packed, new(big.Int).SetUint64(output.Timestamp))
+
+	rootSlot, infoSlot := outputSlots(testOutputsSlot, index)
+	l1Root, proof := contractState(t, testOracle, map[common.Hash][]byte{
+		rootSlot: root.Bytes(),
+		infoSlot: packed.Bytes(),
+	}, []common.Hash{rootSlot, infoSlot})
+
+	for h := l1Height; h <= l1Height+1; h++ {
+		putL1Header(t, ns, &eth.Header{Number: new(big.Int).SetUint64(h), Difficulty: big.NewInt(1), Root: l1Root, Time: l1Time})
+	}
+	return &OutputProof{
+		L1Height:                 l1Height,
+		Index:                    index,
+		StateRoot:                output.StateRoot,
+		MessagePasserStorageRoot: mpRoot,
+		BlockHash:                output.BlockHash,
+		Proof:                    proof,
+	}
+}
+
+func TestOutputSlots(t *testing.T) {
+	rootSlot, infoSlot := outputSlots(3, 1)
+	base := new(big.Int).SetBytes(crypto.Keccak256(common.BigToHash(big.NewInt(3)).Bytes()))
+	if rootSlot != common.BigToHash(new(big.Int).Add(base, big.NewInt(2))) || infoSlot != common.BigToHash(new(big.Int).Add(base, big.NewInt(3))) {
+		t.Fatalf("unexpected slots of output 1: %s, %s", rootSlot.String(), infoSlot.String())
+	}
+}
+
+func TestVerifyOutput(t *testing.T) {
+	ns := newTestContext(t)
+	info, l1, err := getContext(ns, testRollupChainID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the cross chain transaction kept in the L2 state of the output
+	extra := []byte("cross chain tx")
+	txKey := common.HexToHash("0xabcd")
+	stateRoot, txProof := contractState(t, testCCM, map[common.Hash][]byte{txKey: crypto.Keccak256(extra)}, []common.Hash{txKey})
+
+	output := &Output{StateRoot: stateRoot, BlockHash: common.HexToHash("0x02"), L2BlockNumber: 1800, Timestamp: 10000}
+	proof := proposeOutput(t, ns, 100, output.Timestamp+testFinalizationPeriod, 5, output)
+	verified, err := verifyOutput(ns, proof, info, l1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if verified.L2BlockNumber != output.L2BlockNumber || verified.Timestamp != output.Timestamp || verified.StateRoot != stateRoot {
+		t.Fatalf("unexpected output: %+v", verified)
+	}
+	if err := putOutput(ns, testRollupChainID, verified); err != nil {
+		t.Fatal(err)
+	}
+	stored, err := GetOutput(ns, testRollupChainID, output.L2BlockNumber)
+	if err != nil || *stored != *verified {
+		t.Fatalf("unexpected stored output: %+v, %v", stored, err)
+	}
+
+	result, err := eth2.VerifyMerkleProof(txProof, &eth.Header{Root: stored.StateRoot}, testCCM.Bytes())
+	if err != nil || !eth2.CheckProofResult(result, extra) {
+		t.Fatalf("failed to verify L2 storage against output: %v", err)
+	}
+}
+
+func TestVerifyOutputInvalid(t *testing.T) {
+	ns := newTestContext(t)
+	info, l1, err := getContext(ns, testRollupChainID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	output := &Output{StateRoot: common.HexToHash("0x03"), BlockHash: common.HexToHash("0x02"), L2BlockNumber: 1800, Timestamp: 10000}
+
+	// proposed in the challenge window
+	proof := proposeOutput(t, ns, 100, output.Timestamp+testFinalizationPeriod-1, 5, output)
+	if _, err := verifyOutput(ns, proof, info, l1); err == nil {
+		t.Fatal("output in challenge window should fail")
+	}
+
+	proof = proposeOutput(t, ns, 100, output.Timestamp+testFinalizationPeriod, 5, output)
+	proof.StateRoot = common.HexToHash("0x04")
+	if _, err := verifyOutput(ns, proof, info, l1); err == nil {
+		t.Fatal("mismatched state root should fail")
+	}
+
+	proof = proposeOutput(t, ns, 100, output.Timestamp+testFinalizationPeriod, 5, output)
+	proof.Index = 6
+	if _, err := verifyOutput(ns, proof, info, l1); err == nil {
+		t.Fatal("proof of other index should fail")
+	}
+
+	// the L1 header is not confirmed
+	proof = proposeOutput(t, ns, 100, output.Timestamp+testFinalizationPeriod, 5, output)
+	proof.L1Height = 101
+	if _, err := verifyOutput(ns, proof, info, l1); err == nil {
+		t.Fatal("unconfirmed L1 header should fail")
+	}
+}
+
+func TestGetContext(t *testing.T) {
+	ns := newTestContext(t)
+	if _, _, err := getContext(ns, testL1ChainID); err == nil {
+		t.Fatal("side chain without extra info should fail")
+	}
+	info, _ := json.Marshal(&ExtraInfo{L1ChainID: testRollupChainID, OutputOracle: testOracle, FinalizationPeriod: 1})
+	if err := side_chain_manager.PutSideChain(ns, &side_chain_manager.SideChain{
+		ChainId: 11, Router: utils.OPTIMISM_ROUTER, Name: "rollup of rollup", ExtraInfo: info,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := getContext(ns, 11); err == nil {
+		t.Fatal("L1 chain not synced by eth router should fail")
+	}
+}
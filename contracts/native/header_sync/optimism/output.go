@@ -0,0 +1,150 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package optimism
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/contracts/native"
+	eth2 "github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/eth"
+	"github.com/ethereum/go-ethereum/contracts/native/governance/side_chain_manager"
+	"github.com/ethereum/go-ethereum/contracts/native/header_sync/eth"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// OutputProof is relayed to prove the output proposal of the index in the oracle at
+// the L1 height, it carries the preimage of the output root so that the L2 state root
+// can be extracted.
+type OutputProof struct {
+	L1Height                 uint64         `json:"l1Height"`
+	Index                    uint64         `json:"index"`
+	Version                  common.Hash    `json:"version"`
+	StateRoot                common.Hash    `json:"stateRoot"`
+	MessagePasserStorageRoot common.Hash    `json:"messagePasserStorageRoot"`
+	BlockHash                common.Hash    `json:"blockHash"`
+	Proof                    *eth2.ETHProof `json:"proof"` // Storage of the output root and the packed timestamp and L2 block number
+}
+
+// Output is the finalized output proposal of the rollup.
+type Output struct {
+	OutputRoot    common.Hash `json:"outputRoot"`
+	StateRoot     common.Hash `json:"stateRoot"`
+	BlockHash     common.Hash `json:"blockHash"`
+	L2BlockNumber uint64      `json:"l2BlockNumber"`
+	Timestamp     uint64      `json:"timestamp"`
+	L1Height      uint64      `json:"l1Height"`
+}
+
+// OutputRootV0 computes the output root of version 0 like `rollup.ComputeL2OutputRoot`.
+func OutputRootV0(stateRoot, messagePasserStorageRoot, blockHash common.Hash) common.Hash {
+	return crypto.Keccak256Hash(common.Hash{}.Bytes(), stateRoot.Bytes(), messagePasserStorageRoot.Bytes(), blockHash.Bytes())
+}
+
+// outputSlots returns the storage slots of the output proposal of the index, the proposal
+// `{bytes32 outputRoot; uint128 timestamp; uint128 l2BlockNumber}` takes two slots.
+func outputSlots(outputsSlot, index uint64) (common.Hash, common.Hash) {
+	base := new(big.Int).SetBytes(crypto.Keccak256(common.BigToHash(new(big.Int).SetUint64(outputsSlot)).Bytes()))
+	root := new(big.Int).Add(base, new(big.Int).SetUint64(2*index))
+	return common.BigToHash(root), common.BigToHash(root.Add(root, big.NewInt(1)))
+}
+
+// verifyOutput verifies the output proposal against the confirmed L1 header, and that
+// the challenge window of the proposal has passed at the time of the L1 header.
+func verifyOutput(ns *native.NativeContract, proof *OutputProof, info *ExtraInfo, l1 *side_chain_manager.SideChain) (*Output, error) {
+	if proof.Proof == nil || len(proof.Proof.StorageProofs) != 2 {
+		return nil, fmt.Errorf("incorrect proof format")
+	}
+	if proof.Version != (common.Hash{}) {
+		return nil, fmt.Errorf("unsupported output version %s", proof.Version.String())
+	}
+
+	bestHeight, err := eth.GetCurrentHeaderHeight(ns, l1.ChainId)
+	if err != nil {
+		return nil, fmt.Errorf("get current L1 header height fail, error:%s", err)
+	}
+	if bestHeight < proof.L1Height || bestHeight-proof.L1Height+1 < l1.BlocksToWait {
+		return nil, fmt.Errorf("L1 header is not confirmed, current height: %d, input height: %d", bestHeight, proof.L1Height)
+	}
+	header, _, err := eth.GetHeaderByHeight(ns, proof.L1Height, l1.ChainId)
+	if err != nil {
+		return nil, fmt.Errorf("get L1 header by height, height:%d, error:%s", proof.L1Height, err)
+	}
+
+	rootSlot, infoSlot := outputSlots(info.OutputsSlot, proof.Index)
+	outputRoot, err := storageValue(proof.Proof, 0, header, info.OutputOracle, rootSlot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify output root: %v", err)
+	}
+	packed, err := storageValue(proof.Proof, 1, header, info.OutputOracle, infoSlot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify output block: %v", err)
+	}
+
+	root := common.BigToHash(outputRoot)
+	if root == (common.Hash{}) {
+		return nil, fmt.Errorf("output %d not proposed at L1 height %d", proof.Index, proof.L1Height)
+	}
+	if expect := OutputRootV0(proof.StateRoot, proof.MessagePasserStorageRoot, proof.BlockHash); root != expect {
+		return nil, fmt.Errorf("output root mismatch, proved: %s, computed: %s", root.String(), expect.String())
+	}
+	timestamp := new(big.Int).And(packed, new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1)))
+	l2BlockNumber := new(big.Int).Rsh(packed, 128)
+	if !timestamp.IsUint64() || !l2BlockNumber.IsUint64() {
+		return nil, fmt.Errorf("invalid output block, timestamp: %s, number: %s", timestamp.String(), l2BlockNumber.String())
+	}
+	output := &Output{
+		OutputRoot:    root,
+		StateRoot:     proof.StateRoot,
+		BlockHash:     proof.BlockHash,
+		L2BlockNumber: l2BlockNumber.Uint64(),
+		Timestamp:     timestamp.Uint64(),
+		L1Height:      proof.L1Height,
+	}
+	// the output could be deleted by the challenger until it is finalized
+	if header.Time < output.Timestamp+info.FinalizationPeriod {
+		return nil, fmt.Errorf("output is in challenge window until %d, L1 header time: %d", output.Timestamp+info.FinalizationPeriod, header.Time)
+	}
+	return output, nil
+}
+
+// storageValue verifies the storage proof of the index in the proof against the header.
+func storageValue(proof *eth2.ETHProof, idx int, header *eth.Header, contract common.Address, slot common.Hash) (*big.Int, error) {
+	sp := proof.StorageProofs[idx]
+	if key := common.HexToHash(sp.Key); key != slot {
+		return nil, fmt.Errorf("storage proof %d is of slot %s, expect %s", idx, key.String(), slot.String())
+	}
+	single := *proof
+	single.StorageProofs = proof.StorageProofs[idx : idx+1]
+	raw, err := eth2.VerifyMerkleProof(&single, header, contract.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	value := new(big.Int)
+	if len(raw) == 0 {
+		return value, nil
+	}
+	var content []byte
+	if err := rlp.DecodeBytes(raw, &content); err != nil {
+		return nil, err
+	}
+	return value.SetBytes(content), nil
+}
@@ -0,0 +1,71 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package optimism
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/contracts/native"
+	scom "github.com/ethereum/go-ethereum/contracts/native/header_sync/common"
+	"github.com/ethereum/go-ethereum/contracts/native/utils"
+	cstates "github.com/polynetwork/poly/core/states"
+)
+
+func outputKey(chainID, l2BlockNumber uint64) []byte {
+	return utils.ConcatKey(utils.HeaderSyncContractAddress, []byte(scom.OPTIMISM_OUTPUT), utils.GetUint64Bytes(chainID), utils.GetUint64Bytes(l2BlockNumber))
+}
+
+func isOutputExist(ns *native.NativeContract, chainID, l2BlockNumber uint64) (bool, error) {
+	store, err := ns.GetCacheDB().Get(outputKey(chainID, l2BlockNumber))
+	if err != nil {
+		return false, fmt.Errorf("get output error: %v", err)
+	}
+	return store != nil, nil
+}
+
+func putOutput(ns *native.NativeContract, chainID uint64, output *Output) error {
+	blob, err := json.Marshal(output)
+	if err != nil {
+		return err
+	}
+	ns.GetCacheDB().Put(outputKey(chainID, output.L2BlockNumber), cstates.GenRawStorageItem(blob))
+	scom.NotifyPutHeader(ns, chainID, output.L2BlockNumber, output.OutputRoot.String())
+	return nil
+}
+
+// GetOutput returns the finalized output of the rollup at the L2 block number.
+func GetOutput(ns *native.NativeContract, chainID, l2BlockNumber uint64) (*Output, error) {
+	store, err := ns.GetCacheDB().Get(outputKey(chainID, l2BlockNumber))
+	if err != nil {
+		return nil, fmt.Errorf("GetOutput, get output error: %v", err)
+	}
+	if store == nil {
+		return nil, fmt.Errorf("GetOutput, output of L2 height %d not found", l2BlockNumber)
+	}
+	blob, err := cstates.GetValueFromRawStorageItem(store)
+	if err != nil {
+		return nil, fmt.Errorf("GetOutput, deserialize from raw storage item err: %v", err)
+	}
+	output := new(Output)
+	if err := json.Unmarshal(blob, output); err != nil {
+		return nil, fmt.Errorf("GetOutput, deserialize output error: %v", err)
+	}
+	return output, nil
+}
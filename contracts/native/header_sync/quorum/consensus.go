@@ -0,0 +1,248 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package quorum
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/contracts/native"
+	eth2 "github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/eth"
+	"github.com/ethereum/go-ethereum/contracts/native/governance/side_chain_manager"
+	"github.com/ethereum/go-ethereum/contracts/native/header_sync/eth/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+const (
+	CONSENSUS_IBFT = "ibft"
+	CONSENSUS_QBFT = "qbft"
+
+	// maxContractValidators bounds the storage proofs of the validator contract
+	maxContractValidators = 256
+)
+
+// ConsensusInfo is the json `ExtraInfo` of the quorum side chain, which selects the
+// consensus of the chain, Istanbul is used if it's empty. QBFT validators are listed
+// in the header extra data, or kept by the validator contract if it's set, in the
+// dynamic `address[]` at `ValidatorSlot` of the contract storage.
+type ConsensusInfo struct {
+	Consensus         string          `json:"consensus"`
+	ValidatorContract *common.Address `json:"validatorContract,omitempty"`
+	ValidatorSlot     uint64          `json:"validatorSlot"`
+}
+
+// QBFTHeader is the header of QBFT in validator contract mode, with the storage
+// proof of the validators in the contract at the state of the header.
+type QBFTHeader struct {
+	Header         *types.Header  `json:"header"`
+	ValidatorProof *eth2.ETHProof `json:"validatorProof"`
+}
+
+func GetConsensusInfo(ns *native.NativeContract, chainID uint64) (*ConsensusInfo, error) {
+	sideChain, err := side_chain_manager.GetSideChain(ns, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("GetConsensusInfo, get side chain error: %v", err)
+	}
+	if sideChain == nil {
+		return nil, fmt.Errorf("GetConsensusInfo, side chain %d not found", chainID)
+	}
+	info := &ConsensusInfo{Consensus: CONSENSUS_IBFT}
+	if len(sideChain.ExtraInfo) > 0 {
+		if err := json.Unmarshal(sideChain.ExtraInfo, info); err != nil {
+			return nil, fmt.Errorf("GetConsensusInfo, deserialize extra info error: %v", err)
+		}
+	}
+	switch info.Consensus {
+	case CONSENSUS_IBFT:
+		if info.ValidatorContract != nil {
+			return nil, fmt.Errorf("GetConsensusInfo, validator contract is only supported by %s", CONSENSUS_QBFT)
+		}
+	case CONSENSUS_QBFT:
+	default:
+		return nil, fmt.Errorf("GetConsensusInfo, unknown consensus %s", info.Consensus)
+	}
+	return info, nil
+}
+
+func (info *ConsensusInfo) IsQBFT() bool {
+	return info.Consensus == CONSENSUS_QBFT
+}
+
+// ContractMode returns true if the validators are kept by the validator contract, the
+// validators of the state at block N sign the block N+1.
+func (info *ConsensusInfo) ContractMode() bool {
+	return info.IsQBFT() && info.ValidatorContract != nil
+}
+
+// storageValue verifies the storage proof of the slot in the contract against the state
+// root of header.
+func (info *ConsensusInfo) storageValue(proof *eth2.ETHProof, idx int, hdr *types.Header, slot common.Hash) (*big.Int, error) {
+	sp := proof.StorageProofs[idx]
+	if key := common.HexToHash(sp.Key); key != slot {
+		return nil, fmt.Errorf("storage proof %d is of slot %s, expect %s", idx, key.String(), slot.String())
+	}
+	single := *proof
+	single.StorageProofs = proof.StorageProofs[idx : idx+1]
+	raw, err := eth2.VerifyMerkleProofLegacy(&single, hdr, info.ValidatorContract.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	value := new(big.Int)
+	if len(raw) == 0 {
+		return value, nil
+	}
+	var content []byte
+	if err := rlp.DecodeBytes(raw, &content); err != nil {
+		return nil, err
+	}
+	return value.SetBytes(content), nil
+}
+
+// ValidatorsAt returns the validators kept by the validator contract at the state of
+// the header, the proof contains the storage of the array length followed by the ones
+// of each element.
+func (info *ConsensusInfo) ValidatorsAt(hdr *types.Header, proof *eth2.ETHProof) (QuorumValSet, error) {
+	if proof == nil || len(proof.StorageProofs) == 0 {
+		return nil, fmt.Errorf("no storage proof of validator contract")
+	}
+	slot := common.BigToHash(new(big.Int).SetUint64(info.ValidatorSlot))
+	length, err := info.storageValue(proof, 0, hdr, slot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify validators length: %v", err)
+	}
+	if length.Sign() == 0 || length.Cmp(big.NewInt(maxContractValidators)) > 0 {
+		return nil, fmt.Errorf("invalid number of contract validators: %s", length.String())
+	}
+	n := int(length.Int64())
+	if len(proof.StorageProofs) != n+1 {
+		return nil, fmt.Errorf("%d storage proofs for %d validators", len(proof.StorageProofs), n)
+	}
+
+	base := new(big.Int).SetBytes(crypto.Keccak256(slot.Bytes()))
+	vs := make(QuorumValSet, n)
+	for i := 0; i < n; i++ {
+		elem := common.BigToHash(new(big.Int).Add(base, big.NewInt(int64(i))))
+		value, err := info.storageValue(proof, i+1, hdr, elem)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify No.%d validator: %v", i, err)
+		}
+		vs[i] = common.BigToAddress(value)
+	}
+	return vs, nil
+}
+
+// VerifyEpochHeader verifies the header changing the validators after height, and
+// returns the new validators.
+func (info *ConsensusInfo) VerifyEpochHeader(vs QuorumValSet, height uint64, raw []byte) (*types.Header, QuorumValSet, error) {
+	if info.ContractMode() {
+		qh := new(QBFTHeader)
+		if err := json.Unmarshal(raw, qh); err != nil {
+			return nil, nil, fmt.Errorf("deserialize header err: %v", err)
+		}
+		if qh.Header == nil {
+			return nil, nil, fmt.Errorf("no header")
+		}
+		if err := info.VerifyHeader(vs, height, qh.Header); err != nil {
+			return nil, nil, err
+		}
+		next, err := info.ValidatorsAt(qh.Header, qh.ValidatorProof)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !vs.IfChanged(next) {
+			return nil, nil, fmt.Errorf("header %s is not epoch header supposed to change validators", GetQBFTHeaderHash(qh.Header).String())
+		}
+		return qh.Header, next, nil
+	}
+
+	header := &types.Header{}
+	if err := json.Unmarshal(raw, header); err != nil {
+		return nil, nil, fmt.Errorf("deserialize header err: %v", err)
+	}
+	if header.Number.Uint64() <= height {
+		return nil, nil, fmt.Errorf("wrong height of header: (curr: %d, commit: %d)", height, header.Number.Uint64())
+	}
+	if info.IsQBFT() {
+		extra, err := VerifyQBFTHeader(vs, header, true)
+		if err != nil {
+			return nil, nil, err
+		}
+		return header, extra.Validators, nil
+	}
+	extra, err := VerifyQuorumHeader(vs, header, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	return header, extra.Validators, nil
+}
+
+// VerifyHeader verifies the header is committed by the validators synced at height.
+func (info *ConsensusInfo) VerifyHeader(vs QuorumValSet, height uint64, hdr *types.Header) error {
+	h := hdr.Number.Uint64()
+	if h < height || (info.ContractMode() && h == height) {
+		return fmt.Errorf("height of header %d is not signed by validators of height %d", h, height)
+	}
+	if info.IsQBFT() {
+		extra, err := ExtractQBFTExtra(hdr)
+		if err != nil {
+			return fmt.Errorf("extract qbft extra from header %s error: %v", GetQBFTHeaderHash(hdr).String(), err)
+		}
+		if err := vs.VerifyQBFTCommittedSeals(hdr, extra); err != nil {
+			return fmt.Errorf("verify committed seals failed for header %s: %v", GetQBFTHeaderHash(hdr).String(), err)
+		}
+		return nil
+	}
+	_, err := VerifyQuorumHeader(vs, hdr, false)
+	return err
+}
+
+// GenesisValidators returns the validators of the genesis header.
+func (info *ConsensusInfo) GenesisValidators(raw []byte) (*types.Header, QuorumValSet, error) {
+	if info.ContractMode() {
+		qh := new(QBFTHeader)
+		if err := json.Unmarshal(raw, qh); err != nil {
+			return nil, nil, fmt.Errorf("deserialize header err: %v", err)
+		}
+		if qh.Header == nil {
+			return nil, nil, fmt.Errorf("no header")
+		}
+		vs, err := info.ValidatorsAt(qh.Header, qh.ValidatorProof)
+		return qh.Header, vs, err
+	}
+
+	header := &types.Header{}
+	if err := json.Unmarshal(raw, header); err != nil {
+		return nil, nil, fmt.Errorf("deserialize header err: %v", err)
+	}
+	if info.IsQBFT() {
+		extra, err := ExtractQBFTExtra(header)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to ExtractQBFTExtra: %v", err)
+		}
+		return header, extra.Validators, nil
+	}
+	extra, err := ExtractIstanbulExtra(header)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to ExtractIstanbulExtra: %v", err)
+	}
+	return header, extra.Validators, nil
+}
@@ -17,13 +17,11 @@
 package quorum
 
 import (
-	"encoding/json"
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/contracts/native"
 	"github.com/ethereum/go-ethereum/contracts/native/governance/node_manager"
 	"github.com/ethereum/go-ethereum/contracts/native/header_sync/common"
-	"github.com/ethereum/go-ethereum/contracts/native/header_sync/eth/types"
 	"github.com/ethereum/go-ethereum/contracts/native/utils"
 )
 
@@ -49,16 +47,16 @@ func (h *QuorumHandler) SyncGenesisHeader(ns *native.NativeContract) error {
 		return nil
 	}
 
-	header := &types.Header{}
-	if err = json.Unmarshal(params.GenesisHeader, header); err != nil {
-		return fmt.Errorf("QuorumHandler SyncGenesisHeader, deserialize header err: %v", err)
+	info, err := GetConsensusInfo(ns, params.ChainID)
+	if err != nil {
+		return fmt.Errorf("QuorumHandler SyncGenesisHeader, %v", err)
 	}
-	extra, err := ExtractIstanbulExtra(header)
+	header, vs, err := info.GenesisValidators(params.GenesisHeader)
 	if err != nil {
-		return fmt.Errorf("QuorumHandler SyncGenesisHeader, failed to ExtractIstanbulExtra: %v", err)
+		return fmt.Errorf("QuorumHandler SyncGenesisHeader, %v", err)
 	}
 
-	putValSet(ns, params.ChainID, header.Number.Uint64(), extra.Validators)
+	putValSet(ns, params.ChainID, header.Number.Uint64(), vs)
 	return nil
 }
 
@@ -79,22 +77,17 @@ func (h *QuorumHandler) SyncBlockHeader(ns *native.NativeContract) error {
 	if err != nil {
 		return fmt.Errorf("QuorumHandler SyncBlockHeader, failed to get validators: %v", err)
 	}
-	header := &types.Header{}
+	info, err := GetConsensusInfo(ns, params.ChainID)
+	if err != nil {
+		return fmt.Errorf("QuorumHandler SyncBlockHeader, %v", err)
+	}
 	for i, v := range params.Headers {
-		if err := json.Unmarshal(v, header); err != nil {
-			return fmt.Errorf("QuorumHandler SyncBlockHeader, deserialize No.%d header err: %v", i, err)
-		}
-		h := header.Number.Uint64()
-		if currh >= h {
-			return fmt.Errorf("QuorumHandler SyncBlockHeader, wrong height of No.%d header: (curr: %d, commit: %d)", i, currh, h)
-		}
-
-		extra, err := VerifyQuorumHeader(vs, header, true)
+		header, next, err := info.VerifyEpochHeader(vs, currh, v)
 		if err != nil {
-			return fmt.Errorf("QuorumHandler SyncBlockHeader, failed to verify No.%d quorum header %s: %v", i, GetQuorumHeaderHash(header).String(), err)
+			return fmt.Errorf("QuorumHandler SyncBlockHeader, failed to verify No.%d header: %v", i, err)
 		}
 
-		currh, vs = h, extra.Validators
+		currh, vs = header.Number.Uint64(), next
 	}
 
 	putValSet(ns, params.ChainID, currh, vs)
@@ -0,0 +1,162 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package quorum
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/contracts/native/header_sync/eth/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// ValidatorVote is the vote of the proposer to add or remove the validator in QBFT
+// block header validator selection mode.
+type ValidatorVote struct {
+	RecipientAddress common.Address
+	VoteType         byte
+}
+
+// QBFTExtra is the extra data of QBFT header, unlike Istanbul the vanity is a part
+// of the rlp list and there is no proposer seal, the proposer is the coinbase.
+type QBFTExtra struct {
+	VanityData    []byte
+	Validators    []common.Address
+	Vote          *ValidatorVote `rlp:"nil"`
+	Round         uint32
+	CommittedSeal [][]byte
+}
+
+// copy from quorum
+func ExtractQBFTExtra(h *types.Header) (*QBFTExtra, error) {
+	qbftExtra := new(QBFTExtra)
+	if err := rlp.DecodeBytes(h.Extra, qbftExtra); err != nil {
+		return nil, err
+	}
+	return qbftExtra, nil
+}
+
+// copy from quorum
+func QBFTFilteredHeaderWithRound(h *types.Header, round uint32) *types.Header {
+	newHeader := CopyHeader(h)
+	qbftExtra, err := ExtractQBFTExtra(newHeader)
+	if err != nil {
+		return nil
+	}
+
+	qbftExtra.CommittedSeal = [][]byte{}
+	qbftExtra.Round = round
+
+	payload, err := rlp.EncodeToBytes(qbftExtra)
+	if err != nil {
+		return nil
+	}
+	newHeader.Extra = payload
+	return newHeader
+}
+
+// GetQBFTHeaderHash returns the block hash of QBFT header, which excludes the round
+// and the committed seals.
+func GetQBFTHeaderHash(h *types.Header) common.Hash {
+	if filtered := QBFTFilteredHeaderWithRound(h, 0); filtered != nil {
+		return filtered.Hash()
+	}
+	return h.Hash()
+}
+
+// GetQBFTSigners recovers the validators committed the header in the round, QBFT
+// validators sign the hash of the header with the round directly.
+func GetQBFTSigners(h *types.Header, extra *QBFTExtra) ([]common.Address, error) {
+	filtered := QBFTFilteredHeaderWithRound(h, extra.Round)
+	if filtered == nil {
+		return nil, errors.New("failed to filter qbft header")
+	}
+	hash := filtered.Hash()
+	addrs := make([]common.Address, 0, len(extra.CommittedSeal))
+	for _, seal := range extra.CommittedSeal {
+		pubkey, err := crypto.SigToPub(hash.Bytes(), seal)
+		if err != nil {
+			return nil, err
+		}
+		addrs = append(addrs, crypto.PubkeyToAddress(*pubkey))
+	}
+	return addrs, nil
+}
+
+// QBFTQuorumSize is the number of committed seals required by QBFT.
+func (vs QuorumValSet) QBFTQuorumSize() int {
+	return int(math.Ceil(float64(2*len(vs)) / 3))
+}
+
+// VerifyQBFTCommittedSeals checks the header is proposed and committed by enough
+// validators of the set.
+func (vs QuorumValSet) VerifyQBFTCommittedSeals(hdr *types.Header, extra *QBFTExtra) error {
+	if hdr.MixDigest != IstanbulDigest {
+		return fmt.Errorf("invalid mix digest %s", hdr.MixDigest.String())
+	}
+	if !vs.Exist(hdr.Coinbase) {
+		return fmt.Errorf("proposer %s is not in validators", hdr.Coinbase.Hex())
+	}
+	addrs, err := GetQBFTSigners(hdr, extra)
+	if err != nil {
+		return fmt.Errorf("failed to recover committed seals: %v", err)
+	}
+	seen := make(map[common.Address]bool, len(addrs))
+	for _, v := range addrs {
+		if !vs.Exist(v) {
+			return fmt.Errorf("addess %s is not in validators", v.String())
+		}
+		if seen[v] {
+			return fmt.Errorf("duplicated committed seal of %s", v.String())
+		}
+		seen[v] = true
+	}
+	if len(seen) < vs.QBFTQuorumSize() {
+		return fmt.Errorf("valid seal not enough: (%d found, %d required)", len(seen), vs.QBFTQuorumSize())
+	}
+	return nil
+}
+
+// VerifyQBFTHeader verifies the QBFT header in block header validator selection mode,
+// where the validators of the block are listed in its extra data, the same as Istanbul.
+func VerifyQBFTHeader(vs QuorumValSet, hdr *types.Header, isEpoch bool) (*QBFTExtra, error) {
+	extra, err := ExtractQBFTExtra(hdr)
+	if err != nil {
+		return nil, fmt.Errorf("extract qbft extra from header %s error: %v", GetQBFTHeaderHash(hdr).String(), err)
+	}
+
+	checker := vs
+	if isEpoch {
+		if !vs.IfChanged(extra.Validators) {
+			return nil, fmt.Errorf("header %s is not epoch header supposed to contains new validators", GetQBFTHeaderHash(hdr).String())
+		}
+		if err := vs.JustOneChanged(extra.Validators); err != nil {
+			return nil, err
+		}
+		checker = extra.Validators
+	}
+
+	if err := checker.VerifyQBFTCommittedSeals(hdr, extra); err != nil {
+		return nil, fmt.Errorf("verify committed seals failed for header %s: %v", GetQBFTHeaderHash(hdr).String(), err)
+	}
+	return extra, nil
+}
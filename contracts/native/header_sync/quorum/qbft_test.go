@@ -0,0 +1,507 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package quorum
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/json"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/contracts/native"
+	eth2 "github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/eth"
+	"github.com/ethereum/go-ethereum/contracts/native/governance/side_chain_manager"
+	"github.com/ethereum/go-ethereum/contracts/native/header_sync/eth/types"
+	"github.com/ethereum/go-ethereum/contracts/native/utils"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+const testSideChainID = uint64(9)
+
+var testValidatorContract = common.HexToAddress("0x0000000000000000000000000000000000008888")
+
+func newTestContext() *native.NativeContract {
+	db := state.NewDatabase(rawdb.NewMemoryDatabase())
+	sdb, _ := state.New(common.Hash{}, db, nil)
+	ref := native.NewContractRef(sdb, common.Address{}, common.Address{}, big.NewInt(1), common.Hash{}, 0, nil)
+	ref.PushContext(&native.Context{ContractAddress: utils.HeaderSyncContractAddress})
+	return native.NewNativeContract(sdb, ref)
+}
+
+func newTestKeys(seed byte, n int) []*ecdsa.PrivateKey {
+	keys := make([]*ecdsa.PrivateKey, n)
+	for i := range keys {
+		d := make([]byte, 32)
+		d[0], d[31] = seed, byte(i+1)
+		keys[i], _ = crypto.ToECDSA(d)
+	}
+	return keys
+}
+
+func addresses(keys []*ecdsa.PrivateKey) QuorumValSet {
+	vs := make(QuorumValSet, len(keys))
+	for i, k := range keys {
+		vs[i] = crypto.PubkeyToAddress(k.PublicKey)
+	}
+	return vs
+}
+
+// qbftHeader makes the header listing the validators, which is proposed by the first
+// signer and committed by all the signers in the round.
+func qbftHeader(t *testing.T, number uint64, root common.Hash, vals QuorumValSet, round uint32, signers ...*ecdsa.PrivateKey) *types.Header {
+	extra := &QBFTExtra{
+		VanityData:    make([]byte, 32),
+		Validators:    vals,
+		Round:         round,
+		CommittedSeal: [][]byte{},
+	}
+	raw, err := rlp.EncodeToBytes(extra)
+	if err != nil {
+		t.Fatal(err)
+	}
+	header := &types.Header{
+		Coinbase:   crypto.PubkeyToAddress(signers[0].PublicKey),
+		Root:       root,
+		Difficulty: big.NewInt(1),
+		Number:     new(big.Int).SetUint64(number),
+		GasLimit:   700000000,
+		Time:       1600000000 + number,
+		Extra:      raw,
+		MixDigest:  IstanbulDigest,
+	}
+	hash := QBFTFilteredHeaderWithRound(header, round).Hash()
+	for _, key := range signers {
+		seal, err := crypto.Sign(hash.Bytes(), key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		extra.CommittedSeal = append(extra.CommittedSeal, seal)
+	}
+	if header.Extra, err = rlp.EncodeToBytes(extra); err != nil {
+		t.Fatal(err)
+	}
+	return header
+}
+
+func putTestSideChain(t *testing.T, ns *native.NativeContract, info *ConsensusInfo) {
+	sideChain := &side_chain_manager.SideChain{ChainId: testSideChainID, Router: utils.QUORUM_ROUTER, Name: "qbft"}
+	if info != nil {
+		raw, err := json.Marshal(info)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sideChain.ExtraInfo = raw
+	}
+	if err := side_chain_manager.PutSideChain(ns, sideChain); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// the fixture is the extra data of QBFT in the layout of quorum: vanity, validators,
+// vote, round and committed seals.
+func TestExtractQBFTExtra(t *testing.T) {
+	vals := addresses(newTestKeys(1, 2))
+	vote := &ValidatorVote{RecipientAddress: vals[1], VoteType: 0xff}
+	raw, _ := rlp.EncodeToBytes([]interface{}{
+		bytes.Repeat([]byte{0x01}, 32),
+		[]common.Address(vals),
+		[]interface{}{vote.RecipientAddress, vote.VoteType},
+		uint32(3),
+		[][]byte{{0x0a}, {0x0b}},
+	})
+
+	extra, err := ExtractQBFTExtra(&types.Header{Extra: raw})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(extra.VanityData, bytes.Repeat([]byte{0x01}, 32)) || extra.Round != 3 || len(extra.CommittedSeal) != 2 {
+		t.Fatalf("unexpected extra %+v", extra)
+	}
+	if vals.IfChanged(extra.Validators) || extra.Vote == nil || *extra.Vote != *vote {
+		t.Fatalf("unexpected validators %v or vote %+v", extra.Validators, extra.Vote)
+	}
+
+	// istanbul extra data is rejected
+	istanbul, _ := rlp.EncodeToBytes(&IstanbulExtra{Validators: vals, Seal: []byte{}, CommittedSeal: [][]byte{}})
+	if _, err := ExtractQBFTExtra(&types.Header{Extra: append(make([]byte, IstanbulExtraVanity), istanbul...)}); err == nil {
+		t.Fatal("istanbul extra should not be decoded as qbft")
+	}
+}
+
+func TestQBFTHeaderHash(t *testing.T) {
+	keys := newTestKeys(1, 4)
+	vals := addresses(keys)
+
+	h1 := qbftHeader(t, 10, common.Hash{}, vals, 0, keys[0], keys[1], keys[2])
+	h2 := qbftHeader(t, 10, common.Hash{}, vals, 2, keys[0], keys[2], keys[3])
+	// the block hash excludes the round and the committed seals
+	if GetQBFTHeaderHash(h1) != GetQBFTHeaderHash(h2) {
+		t.Fatalf("hash of the same block differs: %s, %s", GetQBFTHeaderHash(h1).String(), GetQBFTHeaderHash(h2).String())
+	}
+	if GetQBFTHeaderHash(h1) == h1.Hash() {
+		t.Fatal("committed seals should be excluded from the block hash")
+	}
+
+	signers, err := GetQBFTSigners(h2, mustExtract(t, h2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(signers) != 3 || signers[1] != vals[2] || signers[2] != vals[3] {
+		t.Fatalf("unexpected signers %v", signers)
+	}
+}
+
+func mustExtract(t *testing.T, h *types.Header) *QBFTExtra {
+	extra, err := ExtractQBFTExtra(h)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return extra
+}
+
+func TestVerifyQBFTHeader(t *testing.T) {
+	keys := newTestKeys(1, 4)
+	vals := addresses(keys)
+	others := newTestKeys(2, 1)
+
+	// committed by 3 of 4 validators
+	if _, err := VerifyQBFTHeader(vals, qbftHeader(t, 10, common.Hash{}, vals, 1, keys[0], keys[1], keys[3]), false); err != nil {
+		t.Fatal(err)
+	}
+	// committed by 2 of 4 validators
+	if _, err := VerifyQBFTHeader(vals, qbftHeader(t, 10, common.Hash{}, vals, 0, keys[0], keys[1]), false); err == nil {
+		t.Fatal("header lacking committed seals should fail")
+	}
+	// duplicated committed seals
+	if _, err := VerifyQBFTHeader(vals, qbftHeader(t, 10, common.Hash{}, vals, 0, keys[0], keys[1], keys[1]), false); err == nil {
+		t.Fatal("header with duplicated committed seals should fail")
+	}
+	// committed by unknown validator
+	if _, err := VerifyQBFTHeader(vals, qbftHeader(t, 10, common.Hash{}, vals, 0, keys[0], keys[1], others[0]), false); err == nil {
+		t.Fatal("header committed by unknown validator should fail")
+	}
+	// tampered round
+	header := qbftHeader(t, 10, common.Hash{}, vals, 1, keys[0], keys[1], keys[2])
+	extra := mustExtract(t, header)
+	extra.Round = 0
+	header.Extra, _ = rlp.EncodeToBytes(extra)
+	if _, err := VerifyQBFTHeader(vals, header, false); err == nil {
+		t.Fatal("header with tampered round should fail")
+	}
+
+	// epoch header adding one validator, committed by 4 of the 5 new validators
+	next := append(QuorumValSet{}, vals...)
+	next = append(next, crypto.PubkeyToAddress(others[0].PublicKey))
+	extra, err := VerifyQBFTHeader(vals, qbftHeader(t, 20, common.Hash{}, next, 0, keys[0], keys[1], keys[2], others[0]), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vals.IfChanged(extra.Validators[:4]) || len(extra.Validators) != 5 {
+		t.Fatalf("unexpected new validators %v", extra.Validators)
+	}
+	if _, err := VerifyQBFTHeader(vals, qbftHeader(t, 20, common.Hash{}, vals, 0, keys[0], keys[1], keys[2]), true); err == nil {
+		t.Fatal("header without validators change is not epoch header")
+	}
+}
+
+func TestGetConsensusInfo(t *testing.T) {
+	ns := newTestContext()
+	putTestSideChain(t, ns, nil)
+	info, err := GetConsensusInfo(ns, testSideChainID)
+	if err != nil || info.IsQBFT() {
+		t.Fatalf("side chain without extra info should be istanbul: %+v, %v", info, err)
+	}
+
+	putTestSideChain(t, ns, &ConsensusInfo{Consensus: CONSENSUS_QBFT, ValidatorContract: &testValidatorContract})
+	if info, err = GetConsensusInfo(ns, testSideChainID); err != nil || !info.ContractMode() {
+		t.Fatalf("expect qbft in validator contract mode: %+v, %v", info, err)
+	}
+
+	for _, invalid := range []*ConsensusInfo{
+		{Consensus: "clique"},
+		{Consensus: CONSENSUS_IBFT, ValidatorContract: &testValidatorContract},
+	} {
+		putTestSideChain(t, ns, invalid)
+		if _, err := GetConsensusInfo(ns, testSideChainID); err == nil {
+			t.Fatalf("invalid consensus info %+v should fail", invalid)
+		}
+	}
+	if _, err := GetConsensusInfo(ns, testSideChainID+1); err == nil {
+		t.Fatal("unknown side chain should fail")
+	}
+}
+
+// validatorContractState commits the validators into the storage of the validator
+// contract, and returns the state root with the storage proof of the validators.
+func validatorContractState(t *testing.T, vals QuorumValSet, slot uint64) (common.Hash, *eth2.ETHProof) {
+	sdb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	sdb.SetNonce(testValidatorContract, 1)
+	sdb.SetCode(testValidatorContract, []byte{0x60, 0x00})
+
+	lengthKey := common.BigToHash(new(big.Int).SetUint64(slot))
+	keys := []common.Hash{lengthKey}
+	sdb.SetState(testValidatorContract, lengthKey, big.NewInt(int64(len(vals))).Bytes())
+	base := new(big.Int).SetBytes(crypto.Keccak256(lengthKey.Bytes()))
+	for i, v := range vals {
+		key := common.BigToHash(new(big.Int).Add(base, big.NewInt(int64(i))))
+		sdb.SetState(testValidatorContract, key, v.Bytes())
+		keys = append(keys, key)
+	}
+	root, err := sdb.Commit(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	accountProof, err := sdb.GetProof(testValidatorContract)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proof := &eth2.ETHProof{
+		Address:     testValidatorContract.Hex(),
+		Balance:     "0x0",
+		CodeHash:    sdb.GetCodeHash(testValidatorContract).Hex(),
+		Nonce:       "0x1",
+		StorageHash: sdb.StorageTrie(testValidatorContract).Hash().Hex(),
+	}
+	for _, node := range accountProof {
+		proof.AccountProof = append(proof.AccountProof, hexutil.Encode(node))
+	}
+	for _, key := range keys {
+		nodes, err := sdb.GetStorageProof(testValidatorContract, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sp := eth2.StorageProof{Key: key.Hex()}
+		for _, node := range nodes {
+			sp.Proof = append(sp.Proof, hexutil.Encode(node))
+		}
+		proof.StorageProofs = append(proof.StorageProofs, sp)
+	}
+	return root, proof
+}
+
+func TestQBFTValidatorContract(t *testing.T) {
+	keys := newTestKeys(1, 4)
+	vals := addresses(keys)
+	added := newTestKeys(2, 2)
+	next := append(append(QuorumValSet{}, vals...), addresses(added)...)
+	info := &ConsensusInfo{Consensus: CONSENSUS_QBFT, ValidatorContract: &testValidatorContract, ValidatorSlot: 3}
+
+	// validators are kept by the contract since genesis
+	root, proof := validatorContractState(t, vals, info.ValidatorSlot)
+	genesis, _ := json.Marshal(&QBFTHeader{Header: qbftHeader(t, 0, root, nil, 0, keys[0]), ValidatorProof: proof})
+	header, vs, err := info.GenesisValidators(genesis)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header.Number.Uint64() != 0 || vals.IfChanged(vs) {
+		t.Fatalf("unexpected genesis validators %v", vs)
+	}
+
+	// the validators of the genesis state sign the block 1
+	if err := info.VerifyHeader(vs, 0, qbftHeader(t, 0, root, nil, 0, keys[0], keys[1], keys[2])); err == nil {
+		t.Fatal("the header of synced height should not be signed by its own validators")
+	}
+	if err := info.VerifyHeader(vs, 0, qbftHeader(t, 1, root, nil, 0, keys[0], keys[1], keys[2])); err != nil {
+		t.Fatal(err)
+	}
+
+	// the contract adds two validators at block 5
+	root, proof = validatorContractState(t, next, info.ValidatorSlot)
+	raw, _ := json.Marshal(&QBFTHeader{Header: qbftHeader(t, 5, root, nil, 0, keys[0], keys[1], keys[2]), ValidatorProof: proof})
+	header, vs, err = info.VerifyEpochHeader(vals, 0, raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header.Number.Uint64() != 5 || next.IfChanged(vs) {
+		t.Fatalf("unexpected validators %v at %d", vs, header.Number.Uint64())
+	}
+
+	// the proof of another slot is rejected
+	other := *info
+	other.ValidatorSlot = 4
+	if _, _, err := other.VerifyEpochHeader(vals, 0, raw); err == nil {
+		t.Fatal("proof of another slot should fail")
+	}
+	// the proof against another state is rejected
+	raw, _ = json.Marshal(&QBFTHeader{Header: qbftHeader(t, 6, common.Hash{0x01}, nil, 0, keys[0], keys[1], keys[2]), ValidatorProof: proof})
+	if _, _, err := info.VerifyEpochHeader(vals, 5, raw); err == nil {
+		t.Fatal("proof against another state root should fail")
+	}
+	// a header without validators change is not an epoch header
+	root, proof = validatorContractState(t, vals, info.ValidatorSlot)
+	raw, _ = json.Marshal(&QBFTHeader{Header: qbftHeader(t, 6, root, nil, 0, keys[0], keys[1], keys[2]), ValidatorProof: proof})
+	if _, _, err := info.VerifyEpochHeader(vals, 5, raw); err == nil {
+		t.Fatal("header without validators change should fail")
+	}
+	// dropped storage proof of a validator
+	root, proof = validatorContractState(t, next, info.ValidatorSlot)
+	proof.StorageProofs = proof.StorageProofs[:len(proof.StorageProofs)-1]
+	raw, _ = json.Marshal(&QBFTHeader{Header: qbftHeader(t, 6, root, nil, 0, keys[0], keys[1], keys[2]), ValidatorProof: proof})
+	if _, _, err := info.VerifyEpochHeader(vals, 5, raw); err == nil {
+		t.Fatal("incomplete storage proofs should fail")
+	}
+}
+
+// loadQBFTFixture reads the headers of eth_getBlockByNumber, in the validator contract
+// mode each of them comes with the eth_getProof of the validators.
+func loadQBFTFixture(t *testing.T, name string) []json.RawMessage {
+	raw, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var blocks []json.RawMessage
+	if err := json.Unmarshal(raw, &blocks); err != nil {
+		t.Fatal(err)
+	}
+	return blocks
+}
+
+// checkQBFTBlockHash checks the block hash reported by the node is the one excluding
+// the round and the committed seals.
+func checkQBFTBlockHash(t *testing.T, raw []byte, header *types.Header) {
+	var block struct {
+		Hash common.Hash `json:"hash"`
+	}
+	if err := json.Unmarshal(raw, &block); err != nil {
+		t.Fatal(err)
+	}
+	if hash := GetQBFTHeaderHash(header); hash != block.Hash {
+		t.Fatalf("hash of block %d is %s, expect %s", header.Number.Uint64(), hash.String(), block.Hash.String())
+	}
+}
+
+// the blocks of go-quorum list the validators in the header, the block 499 proposed
+// in round 2 votes for a new validator, which is added by and signs the block 500.
+func TestGoQuorumQBFTFixture(t *testing.T) {
+	blocks := loadQBFTFixture(t, "goquorum_qbft.json")
+	info := &ConsensusInfo{Consensus: CONSENSUS_QBFT}
+
+	genesis, vs, err := info.GenesisValidators(blocks[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkQBFTBlockHash(t, blocks[0], genesis)
+	if len(vs) != 4 {
+		t.Fatalf("unexpected genesis validators %v", vs)
+	}
+
+	header := new(types.Header)
+	if err := json.Unmarshal(blocks[1], header); err != nil {
+		t.Fatal(err)
+	}
+	checkQBFTBlockHash(t, blocks[1], header)
+	extra := mustExtract(t, header)
+	if extra.Round != 2 || extra.Vote == nil || extra.Vote.VoteType != 0xff {
+		t.Fatalf("unexpected extra %+v", extra)
+	}
+	if err := info.VerifyHeader(vs, 0, header); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := info.VerifyEpochHeader(vs, 0, blocks[1]); err == nil {
+		t.Fatal("header without validators change is not epoch header")
+	}
+
+	epoch, next, err := info.VerifyEpochHeader(vs, 0, blocks[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkQBFTBlockHash(t, blocks[2], epoch)
+	if len(next) != 5 || !containsAll(next, vs) || !containsAll(next, QuorumValSet{extra.Vote.RecipientAddress}) {
+		t.Fatalf("unexpected validators %v after voting for %s", next, extra.Vote.RecipientAddress.String())
+	}
+	if _, _, err := info.VerifyEpochHeader(vs, epoch.Number.Uint64(), blocks[2]); err == nil {
+		t.Fatal("epoch header of synced height should fail")
+	}
+}
+
+func containsAll(vs, sub QuorumValSet) bool {
+	for _, v := range sub {
+		found := false
+		for _, w := range vs {
+			found = found || v == w
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// the blocks of besu keep the validators in the contract since the london fork, so
+// the headers carry the base fee, the validators at the state of block 1000 add one
+// and sign the block 1001.
+func TestBesuQBFTContractFixture(t *testing.T) {
+	blocks := loadQBFTFixture(t, "besu_qbft_contract.json")
+	info := &ConsensusInfo{Consensus: CONSENSUS_QBFT, ValidatorContract: &testValidatorContract}
+	headerOf := func(raw []byte) []byte {
+		var qh struct {
+			Header json.RawMessage `json:"header"`
+		}
+		if err := json.Unmarshal(raw, &qh); err != nil {
+			t.Fatal(err)
+		}
+		return qh.Header
+	}
+
+	genesis, vs, err := info.GenesisValidators(blocks[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkQBFTBlockHash(t, headerOf(blocks[0]), genesis)
+	if len(vs) != 4 || genesis.BaseFee == nil {
+		t.Fatalf("unexpected genesis validators %v or base fee %v", vs, genesis.BaseFee)
+	}
+	if extra := mustExtract(t, genesis); len(extra.Validators) != 0 || extra.Vote != nil {
+		t.Fatalf("unexpected extra %+v in validator contract mode", extra)
+	}
+
+	epoch, next, err := info.VerifyEpochHeader(vs, 0, blocks[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkQBFTBlockHash(t, headerOf(blocks[1]), epoch)
+	if len(next) != 5 || !containsAll(next, vs) || mustExtract(t, epoch).Round != 1 {
+		t.Fatalf("unexpected validators %v at %d", next, epoch.Number.Uint64())
+	}
+
+	header := new(types.Header)
+	if err := json.Unmarshal(headerOf(blocks[2]), header); err != nil {
+		t.Fatal(err)
+	}
+	checkQBFTBlockHash(t, headerOf(blocks[2]), header)
+	if err := info.VerifyHeader(vs, epoch.Number.Uint64(), header); err == nil {
+		t.Fatal("header should not be signed by the previous validators")
+	}
+	if err := info.VerifyHeader(next, epoch.Number.Uint64(), header); err != nil {
+		t.Fatal(err)
+	}
+	// the committed seals cover the base fee
+	header.BaseFee = nil
+	if err := info.VerifyHeader(next, epoch.Number.Uint64(), header); err == nil {
+		t.Fatal("header without base fee should fail")
+	}
+}
@@ -6,6 +6,12 @@ contract cross_chain_manager {
     event btcTxToRelayEvent(uint64 FromChainID, uint64 ChainID, string buf, string FromTxHash, string RedeemKey);
     event makeBtcTxEvent(string rk, string buf, uint64[] amts);
     event makeProof(string merkleValueHex, uint64 BlockHeight, string key);
+    event evtSetRateLimit(uint64 SrcChainID, uint64 DstChainID, bytes Asset, uint64 Window, uint256 Limit, uint64 Delay);
+    event evtSetGuardian(address Guardian);
+    event evtPauseRoute(uint64 SrcChainID, uint64 DstChainID, bytes Asset);
+    event evtUnpauseRoute(uint64 SrcChainID, uint64 DstChainID, bytes Asset);
+    event evtQueueTransfer(uint64 SourceChainID, bytes CrossChainID, uint64 ReleaseTime);
+    event evtReleaseTransfer(uint64 SourceChainID, bytes CrossChainID);
 
     function name() public returns(string memory Name) {
         return Name;
@@ -27,4 +33,40 @@ contract cross_chain_manager {
     function WhiteChain(uint64 ChainID) public returns(bool success) {
         return success;
     }
+
+    function getVoteProgress(uint64 SourceChainID, uint32 Height, bytes memory Extra) public view returns(bytes memory VoteProgress) {
+        return VoteProgress;
+    }
+
+    function setRateLimit(uint64 SrcChainID, uint64 DstChainID, bytes memory Asset, uint64 Window, uint256 Limit, uint64 Delay) public returns(bool success) {
+        return success;
+    }
+
+    function getRateLimit(uint64 SrcChainID, uint64 DstChainID, bytes memory Asset) public view returns(bytes memory RateLimit) {
+        return RateLimit;
+    }
+
+    function setGuardian(address Guardian) public returns(bool success) {
+        return success;
+    }
+
+    function getGuardian() public view returns(address Guardian) {
+        return Guardian;
+    }
+
+    function pauseRoute(uint64 SrcChainID, uint64 DstChainID, bytes memory Asset) public returns(bool success) {
+        return success;
+    }
+
+    function unpauseRoute(uint64 SrcChainID, uint64 DstChainID, bytes memory Asset) public returns(bool success) {
+        return success;
+    }
+
+    function releaseTransfer(uint64 SourceChainID, bytes memory CrossChainID) public returns(bool success) {
+        return success;
+    }
+
+    function getQueuedTransfer(uint64 SourceChainID, bytes memory CrossChainID) public view returns(bytes memory QueuedTransfer) {
+        return QueuedTransfer;
+    }
 }
@@ -17,6 +17,8 @@ contract side_chain_manager {
     event evtApproveQuitSideChain(uint64 ChainId);
     event evtRegisterRedeem(string rk, string ContractAddress);
     event evtSetBtcTxParam(string rk, uint64 RedeemChainId, uint64 FeeRate, uint64 MinChange);
+    event evtUpdateVoterCommittee(uint64 ChainId, uint64 Threshold, uint64 VoteExpiry);
+    event evtApproveUpdateVoterCommittee(uint64 ChainId);
     
     function name() public returns(string memory Name) {
         return Name;
@@ -55,4 +57,20 @@ contract side_chain_manager {
     function setBtcTxParam(bytes memory Redeem, uint64 RedeemChainId, bytes[] memory Sigs, BtcTxParamDetial memory Detial) public returns (bool success) {
 	    return success;
     }
+
+    function getSideChain(uint64 ChainId) public view returns (bytes memory SideChain) {
+	    return SideChain;
+    }
+
+    function updateVoterCommittee(address Address, uint64 ChainId, address[] memory Voters, uint64 Threshold, uint64 VoteExpiry) public returns (bool success) {
+	    return success;
+    }
+
+    function approveUpdateVoterCommittee(uint64 Chainid, address Address) public returns (bool success) {
+	    return success;
+    }
+
+    function getVoterCommittee(uint64 ChainId) public view returns (bytes memory VoterCommittee) {
+	    return VoterCommittee;
+    }
 }
@@ -18,20 +18,16 @@
 package native
 
 import (
+	"fmt"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/contracts/native/utils"
+	"github.com/ethereum/go-ethereum/params"
 )
 
 // FailedTxGasUsage tx's gas usage should not be greater than an minimum fixed value if it execute failed.
 const FailedTxGasUsage = uint64(100)
 
-// ZionMainChainID fix value for zion relay chain
-const ZionMainChainID = uint64(1)
-
-func IsMainChain(chainID uint64) bool {
-	return chainID == ZionMainChainID
-}
-
 const (
 	NativeGovernance       = "governance"
 	NativeSyncHeader       = "sync_header"
@@ -94,3 +90,42 @@ func IsNativeContract(addr common.Address) bool {
 	}
 	return false
 }
+
+// EnabledContracts returns the native contracts deployed in genesis. all native
+// contracts are enabled on main chain and only node manager and lock proxy are
+// enabled on side chain if the list is not declared.
+func EnabledContracts(config *params.ZionConfig) (map[string]common.Address, error) {
+	names := config.NativeContracts
+	if len(names) == 0 {
+		if config.IsMainChain() {
+			return NativeContractAddrMap, nil
+		}
+		names = []string{NativeNodeManager, NativeLockProxy}
+	}
+	enabled := make(map[string]common.Address)
+	for _, name := range names {
+		addr, ok := NativeContractAddrMap[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown native contract %s", name)
+		}
+		enabled[name] = addr
+	}
+	return enabled, nil
+}
+
+// IsEnabledContract returns whether the native contract is deployed in genesis of the chain.
+func IsEnabledContract(config *params.ZionConfig, addr common.Address) bool {
+	names := config.NativeContracts
+	if len(names) == 0 {
+		if config.IsMainChain() {
+			return IsNativeContract(addr)
+		}
+		names = []string{NativeNodeManager, NativeLockProxy}
+	}
+	for _, name := range names {
+		if NativeContractAddrMap[name] == addr {
+			return true
+		}
+	}
+	return false
+}
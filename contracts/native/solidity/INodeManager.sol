@@ -8,9 +8,12 @@ interface INodeManager {
     function getChangingEpoch() external view returns (bytes memory);
     function getEpochByID(uint64 epochID) external view returns (bytes memory);
     function proof(uint64 epochID) external view returns (bytes memory);
+    function approveNativeUpgrade(address contractAddress, uint64 version, uint64 height) external returns (bool);
+    function getNativeUpgrades(address contractAddress) external view returns (bytes memory);
     
     event Proposed(bytes epoch);
     event Voted(uint64 epochID, bytes epochHash, uint64 votedNumber, uint64 groupSize);
     event EpochChanged(bytes epoch, bytes nextEpoch);
     event ConsensusSigned(string method, bytes input, address signer, uint64 size);
+    event NativeUpgradeApproved(address contractAddress, uint64 version, uint64 height);
 }
\ No newline at end of file
@@ -0,0 +1,207 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package native
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/contracts/native/utils"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// SKP_UPGRADE storage key prefix of governance scheduled native contract upgrades,
+// the list is stored in the node manager contract storage space.
+const SKP_UPGRADE = "st_native_upgrade"
+
+// Upgrade denotes that the implementation `Version` of an native contract becomes
+// active from block `Height`. version 0 is the implementation registered by `Register`.
+type Upgrade struct {
+	Version uint64
+	Height  uint64
+}
+
+type UpgradeList struct {
+	List []*Upgrade
+}
+
+// ErrUnsupportedVersion is returned if an native contract implementation activated by
+// chain config or governance is not supported by current node.
+var ErrUnsupportedVersion = errors.New("unsupported native contract version, node should be upgraded")
+
+// RegisterVersion bind an new implementation to the native contract address on all chain
+// roles. the implementation will not be used until an upgrade of the same version is
+// scheduled in chain config or approved by node manager.
+func RegisterVersion(addr common.Address, version uint64, register RegisterService) {
+	for _, r := range registries {
+		r.RegisterVersion(addr, version, register)
+	}
+}
+
+// RegisterVersion bind an new implementation to the native contract address.
+func (r *Registry) RegisterVersion(addr common.Address, version uint64, register RegisterService) {
+	if version == 0 {
+		panic("native contract version 0 should be registered by `Register`")
+	}
+	if _, ok := r.versions[addr]; !ok {
+		r.versions[addr] = make(map[uint64]RegisterService)
+	}
+	r.versions[addr][version] = register
+}
+
+// HasVersion returns whether the implementation `version` of native contract is supported
+// by current node.
+func (r *Registry) HasVersion(addr common.Address, version uint64) bool {
+	_, ok := r.lookup(addr, version)
+	return ok
+}
+
+func (r *Registry) lookup(addr common.Address, version uint64) (RegisterService, bool) {
+	if version == 0 {
+		register, ok := r.contracts[addr]
+		return register, ok
+	}
+	register, ok := r.versions[addr][version]
+	return register, ok
+}
+
+// StoreUpgrade persist an governance approved upgrade of native contract `addr` in state.
+func StoreUpgrade(db *state.CacheDB, addr common.Address, upgrade *Upgrade) error {
+	list, err := getStoredUpgrades(db, addr)
+	if err != nil {
+		return err
+	}
+	list = append(list, upgrade)
+	value, err := rlp.EncodeToBytes(&UpgradeList{List: list})
+	if err != nil {
+		return err
+	}
+	db.Put(upgradeKey(addr), value)
+	return nil
+}
+
+// GetUpgrades returns all upgrades of native contract `addr` which scheduled in chain
+// config or approved by governance, the result sorted by activation height and version.
+func GetUpgrades(db *state.CacheDB, scheduled []*params.NativeUpgrade, addr common.Address) ([]*Upgrade, error) {
+	stored, err := getStoredUpgrades(db, addr)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*Upgrade, 0, len(scheduled)+len(stored))
+	for _, v := range scheduled {
+		if v.Contract == addr && v.Block != nil {
+			list = append(list, &Upgrade{Version: v.Version, Height: v.Block.Uint64()})
+		}
+	}
+	list = append(list, stored...)
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].Height != list[j].Height {
+			return list[i].Height < list[j].Height
+		}
+		return list[i].Version < list[j].Version
+	})
+	return list, nil
+}
+
+// ActiveVersion returns the implementation version of native contract `addr` at block `height`.
+func ActiveVersion(db *state.CacheDB, scheduled []*params.NativeUpgrade, addr common.Address, height uint64) (uint64, error) {
+	list, err := GetUpgrades(db, scheduled, addr)
+	if err != nil {
+		return 0, err
+	}
+	version := uint64(0)
+	for _, v := range list {
+		if v.Height > height {
+			break
+		}
+		version = v.Version
+	}
+	return version, nil
+}
+
+// ActiveVersion returns the implementation version of native contract `addr` at current block,
+// implementations shared by several versions use it to switch the upgraded behaviours.
+func (s *NativeContract) ActiveVersion(addr common.Address) (uint64, error) {
+	height := uint64(0)
+	if num := s.ref.BlockHeight(); num != nil {
+		height = num.Uint64()
+	}
+	return ActiveVersion(s.GetCacheDB(), s.ref.NativeUpgrades(), addr, height)
+}
+
+// CheckActiveVersions returns an error if any native contract implementation active at
+// block `height` is not supported by current node, the node should be upgraded before it
+// processes the block, otherwise its state will diverge from the upgraded nodes.
+func (r *Registry) CheckActiveVersions(db *state.CacheDB, scheduled []*params.NativeUpgrade, height uint64) error {
+	if r == nil {
+		return fmt.Errorf("%w: unknown zion chain role", ErrUnsupportedVersion)
+	}
+	for _, addr := range NativeContractAddrMap {
+		version, err := ActiveVersion(db, scheduled, addr, height)
+		if err != nil {
+			return err
+		}
+		if version != 0 && !r.HasVersion(addr, version) {
+			return fmt.Errorf("%w: version %d of %s is active at block %d", ErrUnsupportedVersion, version, addr.Hex(), height)
+		}
+	}
+	return nil
+}
+
+func (s *NativeContract) lookupContract(addr common.Address) (RegisterService, error) {
+	registry := s.ref.Registry()
+	if registry == nil || !IsEnabledContract(s.ref.ZionConfig(), addr) {
+		return nil, fmt.Errorf("failed to find contract: [%x]", addr)
+	}
+	version, err := s.ActiveVersion(addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active version of contract [%x]: %v", addr, err)
+	}
+	register, ok := registry.lookup(addr, version)
+	if !ok {
+		if version == 0 {
+			return nil, fmt.Errorf("failed to find contract: [%x]", addr)
+		}
+		return nil, fmt.Errorf("failed to find contract: [%x] version %d, node should be upgraded", addr, version)
+	}
+	return register, nil
+}
+
+func getStoredUpgrades(db *state.CacheDB, addr common.Address) ([]*Upgrade, error) {
+	value, err := db.Get(upgradeKey(addr))
+	if err != nil {
+		return nil, err
+	}
+	if len(value) == 0 {
+		return nil, nil
+	}
+	var list UpgradeList
+	if err := rlp.DecodeBytes(value, &list); err != nil {
+		return nil, err
+	}
+	return list.List, nil
+}
+
+func upgradeKey(addr common.Address) []byte {
+	return utils.ConcatKey(utils.NodeManagerContractAddress, []byte(SKP_UPGRADE), addr.Bytes())
+}
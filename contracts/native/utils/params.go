@@ -60,4 +60,7 @@ var (
 	POLYGON_HEIMDALL_ROUTER = uint64(15)
 	POLYGON_BOR_ROUTER      = uint64(16)
 	ZION_ROUTER             = uint64(17)
+	COMETBFT_ROUTER         = uint64(18)
+	CLIQUE_ROUTER           = uint64(19)
+	OPTIMISM_ROUTER         = uint64(20)
 )
@@ -48,9 +48,10 @@ import (
 )
 
 var (
-	headBlockGauge     = metrics.NewRegisteredGauge("chain/head/block", nil)
-	headHeaderGauge    = metrics.NewRegisteredGauge("chain/head/header", nil)
-	headFastBlockGauge = metrics.NewRegisteredGauge("chain/head/receipt", nil)
+	headBlockGauge          = metrics.NewRegisteredGauge("chain/head/block", nil)
+	headHeaderGauge         = metrics.NewRegisteredGauge("chain/head/header", nil)
+	headFastBlockGauge      = metrics.NewRegisteredGauge("chain/head/receipt", nil)
+	headFinalizedBlockGauge = metrics.NewRegisteredGauge("chain/head/finalized", nil)
 
 	accountReadTimer   = metrics.NewRegisteredTimer("chain/account/reads", nil)
 	accountHashTimer   = metrics.NewRegisteredTimer("chain/account/hashes", nil)
@@ -178,6 +179,7 @@ type BlockChain struct {
 	chainFeed     event.Feed
 	chainSideFeed event.Feed
 	chainHeadFeed event.Feed
+	finalizedFeed event.Feed
 	logsFeed      event.Feed
 	blockProcFeed event.Feed
 	scope         event.SubscriptionScope
@@ -187,6 +189,7 @@ type BlockChain struct {
 
 	currentBlock     atomic.Value // Current head of the block chain
 	currentFastBlock atomic.Value // Current head of the fast-sync chain (may be above the block chain!)
+	currentFinalized atomic.Value // Current head of the committed chain, only tracked by HotStuff
 
 	stateCache    state.Database // State database to reuse between imports (contains state cache)
 	bodyCache     *lru.Cache     // Cache for the most recent block bodies
@@ -263,6 +266,7 @@ func NewBlockChain(db ethdb.Database, cacheConfig *CacheConfig, chainConfig *par
 	var nilBlock *types.Block
 	bc.currentBlock.Store(nilBlock)
 	bc.currentFastBlock.Store(nilBlock)
+	bc.currentFinalized.Store(nilBlock)
 
 	// Initialize the chain with ancient data if it isn't empty.
 	var txIndexBlock uint64
@@ -459,6 +463,21 @@ func (bc *BlockChain) loadLastState() error {
 			headFastBlockGauge.Update(int64(block.NumberU64()))
 		}
 	}
+	// Restore the last known finalized block, which never runs ahead of the
+	// head block even if the chain was rewound beneath it
+	if _, ok := bc.engine.(consensus.HotStuff); ok {
+		finalized := bc.genesisBlock
+		if head := rawdb.ReadHeadFinalizedBlockHash(bc.db); head != (common.Hash{}) {
+			if block := bc.GetBlockByHash(head); block != nil {
+				finalized = block
+			}
+		}
+		if finalized.NumberU64() > currentBlock.NumberU64() {
+			finalized = currentBlock
+		}
+		bc.currentFinalized.Store(finalized)
+		headFinalizedBlockGauge.Update(int64(finalized.NumberU64()))
+	}
 	// Issue a status log for the user
 	currentFastBlock := bc.CurrentFastBlock()
 
@@ -571,6 +590,14 @@ func (bc *BlockChain) SetHeadBeyondRoot(head uint64, root common.Hash) (uint64,
 			bc.currentFastBlock.Store(newHeadFastBlock)
 			headFastBlockGauge.Update(int64(newHeadFastBlock.NumberU64()))
 		}
+		// Rewind the finalized block, which never runs ahead of the head block
+		if currentFinalized := bc.CurrentFinalizedBlock(); currentFinalized != nil && currentFinalized.NumberU64() > bc.CurrentBlock().NumberU64() {
+			newFinalized := bc.CurrentBlock()
+			rawdb.WriteHeadFinalizedBlockHash(db, newFinalized.Hash())
+
+			bc.currentFinalized.Store(newFinalized)
+			headFinalizedBlockGauge.Update(int64(newFinalized.NumberU64()))
+		}
 		head := bc.CurrentBlock().NumberU64()
 
 		// If setHead underflown the freezer threshold and the block processing
@@ -674,6 +701,13 @@ func (bc *BlockChain) CurrentFastBlock() *types.Block {
 	return bc.currentFastBlock.Load().(*types.Block)
 }
 
+// CurrentFinalizedBlock retrieves the latest block whose committed seals have
+// been verified. It returns nil if the consensus engine has no notion of
+// finality.
+func (bc *BlockChain) CurrentFinalizedBlock() *types.Block {
+	return bc.currentFinalized.Load().(*types.Block)
+}
+
 // Validator returns the current validator.
 func (bc *BlockChain) Validator() Validator {
 	return bc.validator
@@ -731,6 +765,11 @@ func (bc *BlockChain) ResetWithGenesisBlock(genesis *types.Block) error {
 	bc.hc.SetCurrentHeader(bc.genesisBlock.Header())
 	bc.currentFastBlock.Store(bc.genesisBlock)
 	headFastBlockGauge.Update(int64(bc.genesisBlock.NumberU64()))
+	if _, ok := bc.engine.(consensus.HotStuff); ok {
+		rawdb.WriteHeadFinalizedBlockHash(bc.db, bc.genesisBlock.Hash())
+		bc.currentFinalized.Store(bc.genesisBlock)
+		headFinalizedBlockGauge.Update(int64(bc.genesisBlock.NumberU64()))
+	}
 	return nil
 }
 
@@ -1443,7 +1482,29 @@ func (bc *BlockChain) WriteBlockWithState(block *types.Block, receipts []*types.
 	bc.chainmu.Lock()
 	defer bc.chainmu.Unlock()
 
-	return bc.writeBlockWithState(block, receipts, logs, state, emitHeadEvent)
+	status, err = bc.writeBlockWithState(block, receipts, logs, state, emitHeadEvent)
+	if err == nil && status == CanonStatTy {
+		// Blocks handed over by the sealer carry the committed seals of the
+		// local consensus round.
+		bc.writeFinalizedBlock(block)
+	}
+	return status, err
+}
+
+// writeFinalizedBlock advances the finalized head to the given block if the
+// consensus engine provides finality. It expects the chain mutex to be held.
+func (bc *BlockChain) writeFinalizedBlock(block *types.Block) {
+	if _, ok := bc.engine.(consensus.HotStuff); !ok {
+		return
+	}
+	if current := bc.CurrentFinalizedBlock(); current != nil && current.NumberU64() >= block.NumberU64() {
+		return
+	}
+	rawdb.WriteHeadFinalizedBlockHash(bc.db, block.Hash())
+	bc.currentFinalized.Store(block)
+	headFinalizedBlockGauge.Update(int64(block.NumberU64()))
+
+	bc.finalizedFeed.Send(FinalizedHeadEvent{Block: block})
 }
 
 // writeBlockWithState writes the block and all associated state to the database,
@@ -1677,10 +1738,14 @@ func (bc *BlockChain) insertChain(chain types.Blocks, verifySeals bool) (int, er
 		stats     = insertStats{startTime: mclock.Now()}
 		lastCanon *types.Block
 	)
-	// Fire a single chain head event if we've progressed the chain
+	// Fire a single chain head event if we've progressed the chain. Blocks
+	// with verified seals are final as well.
 	defer func() {
 		if lastCanon != nil && bc.CurrentBlock().Hash() == lastCanon.Hash() {
 			bc.chainHeadFeed.Send(ChainHeadEvent{lastCanon})
+			if verifySeals {
+				bc.writeFinalizedBlock(lastCanon)
+			}
 		}
 	}()
 	// Start the parallel header verifier
@@ -2498,6 +2563,11 @@ func (bc *BlockChain) SubscribeChainHeadEvent(ch chan<- ChainHeadEvent) event.Su
 	return bc.scope.Track(bc.chainHeadFeed.Subscribe(ch))
 }
 
+// SubscribeFinalizedHeadEvent registers a subscription of FinalizedHeadEvent.
+func (bc *BlockChain) SubscribeFinalizedHeadEvent(ch chan<- FinalizedHeadEvent) event.Subscription {
+	return bc.scope.Track(bc.finalizedFeed.Subscribe(ch))
+}
+
 // SubscribeChainSideEvent registers a subscription of ChainSideEvent.
 func (bc *BlockChain) SubscribeChainSideEvent(ch chan<- ChainSideEvent) event.Subscription {
 	return bc.scope.Track(bc.chainSideFeed.Subscribe(ch))
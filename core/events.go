@@ -41,3 +41,6 @@ type ChainSideEvent struct {
 }
 
 type ChainHeadEvent struct{ Block *types.Block }
+
+// FinalizedHeadEvent is posted when the finalized head of a HotStuff chain advances.
+type FinalizedHeadEvent struct{ Block *types.Block }
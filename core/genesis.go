@@ -29,7 +29,6 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/contracts/native"
-	"github.com/ethereum/go-ethereum/contracts/native/utils"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -163,6 +162,11 @@ func SetupGenesisBlockWithOverride(db ethdb.Database, genesis *Genesis, override
 	if genesis != nil && genesis.Config == nil {
 		return params.AllEthashProtocolChanges, common.Hash{}, errGenesisNoConfig
 	}
+	if genesis != nil {
+		if err := validateZionConfig(genesis.Config); err != nil {
+			return genesis.Config, common.Hash{}, err
+		}
+	}
 	// Just commit the new block if there is no stored genesis block.
 	stored := rawdb.ReadCanonicalHash(db, 0)
 	if (stored == common.Hash{}) {
@@ -223,6 +227,10 @@ func SetupGenesisBlockWithOverride(db ethdb.Database, genesis *Genesis, override
 	if genesis == nil && stored != params.MainnetGenesisHash {
 		return storedcfg, stored, nil
 	}
+	// The zion chain role is part of the genesis state, it can't be changed at any height.
+	if err := storedcfg.CheckZionCompatible(newcfg); err != nil {
+		return newcfg, stored, err
+	}
 	// Check config compatibility and write the config. Compatibility errors
 	// are returned to the caller unless we're already at block zero.
 	height := rawdb.ReadHeaderNumber(db, rawdb.ReadHeadHeaderHash(db))
@@ -279,8 +287,11 @@ func (g *Genesis) ToBlock(db ethdb.Database) *types.Block {
 	g.mintNativeToken(statedb)
 
 	// create native contracts
+	zionConfig := g.Config.ZionConfig()
 	for _, v := range native.NativeContractAddrMap {
-		g.createNativeContract(statedb, v)
+		if native.IsEnabledContract(zionConfig, v) {
+			g.createNativeContract(statedb, v)
+		}
 	}
 	RegGenesis(statedb, g.Alloc)
 
@@ -298,7 +309,7 @@ func (g *Genesis) ToBlock(db ethdb.Database) *types.Block {
 		Coinbase:   g.Coinbase,
 		Root:       root,
 	}
-	if g.Config.HotStuff != nil {
+	if g.Config != nil && g.Config.HotStuff != nil {
 		head.MixDigest = types.HotstuffDigest
 	}
 	if g.GasLimit == 0 {
@@ -314,24 +325,22 @@ func (g *Genesis) ToBlock(db ethdb.Database) *types.Block {
 }
 
 func (g *Genesis) createNativeContract(db *state.StateDB, addr common.Address) {
-	if params.IsMainChain(g.Config.ChainID.Uint64()) || addr == utils.LockProxyContractAddress || addr == utils.NodeManagerContractAddress {
-		db.CreateAccount(addr)
-		db.SetCode(addr, addr[:])
-		initBlockNumber := big.NewInt(0)
-		if g.Config.IsEIP158(initBlockNumber) {
-			db.SetNonce(addr, 1)
-		}
+	db.CreateAccount(addr)
+	db.SetCode(addr, addr[:])
+	initBlockNumber := big.NewInt(0)
+	if g.Config != nil && g.Config.IsEIP158(initBlockNumber) {
+		db.SetNonce(addr, 1)
 	}
 }
 
 func (g *Genesis) mintNativeToken(statedb *state.StateDB) {
-	if params.IsMainChain(g.Config.ChainID.Uint64()) {
+	if g.Config.ZionConfig().IsMainChain() {
 		for addr, account := range g.Alloc {
 			statedb.AddBalance(addr, account.Balance)
 			statedb.SetCode(addr, account.Code)
 			statedb.SetNonce(addr, account.Nonce)
 			for key, value := range account.Storage {
-				statedb.SetState(addr, key, value)
+				statedb.SetState(addr, key, value[:])
 			}
 		}
 	}
@@ -340,6 +349,9 @@ func (g *Genesis) mintNativeToken(statedb *state.StateDB) {
 // Commit writes the block and state of a genesis specification to the database.
 // The block is committed as the canonical head block.
 func (g *Genesis) Commit(db ethdb.Database) (*types.Block, error) {
+	if err := validateZionConfig(g.Config); err != nil {
+		return nil, err
+	}
 	block := g.ToBlock(db)
 	if block.Number().Sign() != 0 {
 		return nil, fmt.Errorf("can't commit genesis block with number > 0")
@@ -362,6 +374,17 @@ func (g *Genesis) Commit(db ethdb.Database) (*types.Block, error) {
 	return block, nil
 }
 
+// validateZionConfig checks the zion chain role and the native contracts enabled in
+// genesis, the genesis block can't be built on an invalid one.
+func validateZionConfig(config *params.ChainConfig) error {
+	zionConfig := config.ZionConfig()
+	if err := zionConfig.Validate(); err != nil {
+		return err
+	}
+	_, err := native.EnabledContracts(zionConfig)
+	return err
+}
+
 // MustCommit writes the genesis block and state to db, panicking on error.
 // The block is committed as the canonical head block.
 func (g *Genesis) MustCommit(db ethdb.Database) *types.Block {
@@ -25,6 +25,8 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/params"
@@ -197,3 +199,54 @@ func TestGenesisHashes(t *testing.T) {
 		}
 	}
 }
+
+// TestSetupGenesisZionRole checks that the zion chain role and cross chain ids declared
+// in genesis can't be changed, even if the chain is still at block zero.
+func TestSetupGenesisZionRole(t *testing.T) {
+	if RegGenesis == nil {
+		RegGenesis = func(db *state.StateDB, data GenesisAlloc) error { return nil }
+		defer func() { RegGenesis = nil }()
+	}
+	if StoreGenesis == nil {
+		StoreGenesis = func(db ethdb.Database, header *types.Header) error { return nil }
+		defer func() { StoreGenesis = nil }()
+	}
+	genesis := func(role string, crossChainID, relayChainID uint64) *Genesis {
+		return &Genesis{Config: &params.ChainConfig{
+			ChainID: big.NewInt(1),
+			Zion:    &params.ZionConfig{Role: role, CrossChainID: crossChainID, RelayChainID: relayChainID},
+		}}
+	}
+	tests := []struct {
+		name string
+		new  *Genesis
+		fail bool
+	}{
+		{"same role", genesis(params.ZionRoleSide, 2, 1), false},
+		{"role changed", genesis(params.ZionRoleMain, 2, 2), true},
+		{"cross chain id changed", genesis(params.ZionRoleSide, 3, 1), true},
+		{"relay chain id changed", genesis(params.ZionRoleSide, 2, 3), true},
+		{"unknown native contract", &Genesis{Config: &params.ChainConfig{
+			ChainID: big.NewInt(1),
+			Zion:    &params.ZionConfig{Role: params.ZionRoleSide, CrossChainID: 2, RelayChainID: 1, NativeContracts: []string{"unknown"}},
+		}}, true},
+	}
+	for _, test := range tests {
+		db := rawdb.NewMemoryDatabase()
+		if _, _, err := SetupGenesisBlock(db, genesis(params.ZionRoleSide, 2, 1)); err != nil {
+			t.Fatalf("%s: failed to setup genesis: %v", test.name, err)
+		}
+		_, _, err := SetupGenesisBlock(db, test.new)
+		if test.fail && err == nil {
+			t.Errorf("%s: expected error", test.name)
+		}
+		if !test.fail && err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+		}
+		// the stored config should never be overwritten by an incompatible one
+		stored := rawdb.ReadChainConfig(db, rawdb.ReadCanonicalHash(db, 0))
+		if have := stored.ZionConfig(); have.Role != params.ZionRoleSide || have.CrossChainID != 2 || have.RelayChainID != 1 {
+			t.Errorf("%s: stored zion config changed to %+v", test.name, have)
+		}
+	}
+}
@@ -28,6 +28,7 @@ import (
 var (
 	keyCurEpoch    = []byte("hs-cur-ep-ht")
 	keyEpochPrefix = []byte("hs-ep")
+	keyLockedState = []byte("hs-locked-st")
 )
 
 func WriteCurrentEpochHeight(db ethdb.KeyValueWriter, height uint64) error {
@@ -52,6 +53,22 @@ func ReadEpoch(db ethdb.Reader, height uint64) ([]byte, error) {
 	return db.Get(key)
 }
 
+// WriteLockedState persist the consensus round state which should be recovered after restart.
+func WriteLockedState(db ethdb.KeyValueWriter, blob []byte) error {
+	return db.Put(keyLockedState, blob)
+}
+
+// ReadLockedState retrieves the consensus round state persisted by WriteLockedState,
+// returning an error if none has been stored.
+func ReadLockedState(db ethdb.Reader) ([]byte, error) {
+	return db.Get(keyLockedState)
+}
+
+// DeleteLockedState removes the persisted consensus round state.
+func DeleteLockedState(db ethdb.KeyValueWriter) error {
+	return db.Delete(keyLockedState)
+}
+
 func keyHeight(height uint64) []byte {
 	dat := uint64Bytes(height)
 	return append(keyEpochPrefix, dat...)
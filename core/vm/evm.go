@@ -448,6 +448,11 @@ func (evm *EVM) nativeCall(caller, toContract common.Address, input []byte, supp
 	if evm.To != common.EmptyAddress {
 		contractRef.SetTo(evm.To)
 	}
+	if evm.Context.Time != nil {
+		contractRef.SetBlockTime(evm.Context.Time.Uint64())
+	}
+	contractRef.SetNativeUpgrades(evm.ChainConfig().NativeUpgrades)
+	contractRef.SetZionConfig(evm.ChainConfig().ZionConfig())
 
 	ret, leftOverGas, err = contractRef.NativeCall(caller, toContract, input)
 	return
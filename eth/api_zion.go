@@ -0,0 +1,171 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package eth
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	scom "github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/common"
+	"github.com/ethereum/go-ethereum/contracts/native/governance/node_manager"
+	"github.com/ethereum/go-ethereum/contracts/native/utils"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var (
+	errCrossChainTxNotFound = errors.New("cross chain transaction not found")
+	errNoMakeProofLog       = errors.New("no makeProof log in transaction receipt")
+)
+
+// CrossChainProof is the proof bundle of a cross chain transaction, fields are encoded as
+// the `header_sync/zion` and `cross_chain_manager/zion/sidechain` contracts verify them:
+// `Header` and `EpochHeader` are json encoded headers, `Proof` is the json encoded account
+// result passed as `Proof` of `importOuterTransfer` and `Extra` is the raw request whose
+// hash is stored by `PutRequest`.
+type CrossChainProof struct {
+	Height      hexutil.Uint64        `json:"height"`
+	Header      *types.Header         `json:"header"`
+	EpochID     hexutil.Uint64        `json:"epochID"`
+	EpochHeader *types.Header         `json:"epochHeader"`
+	EpochProof  *ethapi.AccountResult `json:"epochProof"`
+	Key         hexutil.Bytes         `json:"key"`
+	Proof       *ethapi.AccountResult `json:"proof"`
+	Extra       hexutil.Bytes         `json:"extra"`
+}
+
+// PublicZionAPI provides the zion cross chain APIs for relayers.
+type PublicZionAPI struct {
+	e *Ethereum
+}
+
+// NewPublicZionAPI creates a new zion API instance.
+func NewPublicZionAPI(e *Ethereum) *PublicZionAPI {
+	return &PublicZionAPI{e}
+}
+
+// GetCrossChainProof returns the proof bundle of the cross chain request made by the given
+// transaction. Both the `makeProof` and the lock proxy `CrossChainEvent` transactions are
+// accepted, since the request of a `CrossChainEvent` is recorded by a `makeProof` log of the
+// cross chain manager in the same transaction.
+func (api *PublicZionAPI) GetCrossChainProof(ctx context.Context, txHash common.Hash) (*CrossChainProof, error) {
+	b := api.e.APIBackend
+	tx, blockHash, number, index, err := b.GetTransaction(ctx, txHash)
+	if err != nil {
+		return nil, err
+	}
+	if tx == nil {
+		return nil, errCrossChainTxNotFound
+	}
+	receipts, err := b.GetReceipts(ctx, blockHash)
+	if err != nil {
+		return nil, err
+	}
+	if uint64(len(receipts)) <= index {
+		return nil, errCrossChainTxNotFound
+	}
+	extra, key, err := findMakeProof(receipts[index])
+	if err != nil {
+		return nil, err
+	}
+
+	header := api.e.blockchain.GetHeaderByHash(blockHash)
+	if header == nil {
+		return nil, fmt.Errorf("header %s not found", blockHash.Hex())
+	}
+	statedb, err := api.e.blockchain.StateAt(header.Root)
+	if err != nil {
+		return nil, err
+	}
+	epoch, err := node_manager.GetEpochByHeight(statedb, number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get epoch at %d: %v", number, err)
+	}
+
+	// the validators of the epoch are carried by the header before the epoch start
+	var epochHeader *types.Header
+	if epoch.StartHeight > 0 {
+		if epochHeader = api.e.blockchain.GetHeaderByNumber(epoch.StartHeight - 1); epochHeader == nil {
+			return nil, fmt.Errorf("epoch change header %d not found", epoch.StartHeight-1)
+		}
+	}
+
+	chainAPI := ethapi.NewPublicBlockChainAPI(b)
+	blockNrOrHash := rpc.BlockNumberOrHashWithHash(blockHash, false)
+	proof, err := getStorageProof(ctx, chainAPI, key, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	epochProof, err := getStorageProof(ctx, chainAPI, node_manager.EpochProofKey(epoch.ID), blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CrossChainProof{
+		Height:      hexutil.Uint64(number),
+		Header:      header,
+		EpochID:     hexutil.Uint64(epoch.ID),
+		EpochHeader: epochHeader,
+		EpochProof:  epochProof,
+		Key:         key,
+		Proof:       proof,
+		Extra:       extra,
+	}, nil
+}
+
+// findMakeProof decodes the raw request and its storage key from the `makeProof` log.
+func findMakeProof(receipt *types.Receipt) ([]byte, []byte, error) {
+	event := scom.ABI.Events[scom.NOTIFY_MAKE_PROOF_EVENT]
+	for _, log := range receipt.Logs {
+		if log.Address != utils.CrossChainManagerContractAddress || len(log.Topics) == 0 || log.Topics[0] != event.ID {
+			continue
+		}
+		values, err := scom.ABI.Unpack(scom.NOTIFY_MAKE_PROOF_EVENT, log.Data)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to unpack makeProof log: %v", err)
+		}
+		extra, err := hex.DecodeString(values[0].(string))
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid merkle value: %v", err)
+		}
+		key, err := hex.DecodeString(values[2].(string))
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid request key: %v", err)
+		}
+		if len(key) <= common.AddressLength {
+			return nil, nil, fmt.Errorf("invalid request key length %d", len(key))
+		}
+		return extra, key, nil
+	}
+	return nil, nil, errNoMakeProofLog
+}
+
+// getStorageProof proves the native storage key, which is stored in the slot derived from
+// the key without the contract address.
+func getStorageProof(ctx context.Context, chainAPI *ethapi.PublicBlockChainAPI, key []byte, blockNrOrHash rpc.BlockNumberOrHash) (*ethapi.AccountResult, error) {
+	contract := common.BytesToAddress(key[:common.AddressLength])
+	slot := state.Key2Slot(key[common.AddressLength:])
+	return chainAPI.GetProof(ctx, contract, []string{slot.Hex()}, blockNrOrHash)
+}
@@ -0,0 +1,222 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package eth
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	scom "github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/common"
+	xutils "github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/zion/utils"
+	"github.com/ethereum/go-ethereum/contracts/native/governance/node_manager"
+	"github.com/ethereum/go-ethereum/contracts/native/governance/side_chain_manager"
+	"github.com/ethereum/go-ethereum/contracts/native/header_sync/zion"
+	"github.com/ethereum/go-ethereum/contracts/native/helper"
+	"github.com/ethereum/go-ethereum/contracts/native/utils"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/downloader"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/ethclient/zionclient"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// newTestZionNode starts a zion main chain node, whose single validator seals the blocks.
+func newTestZionNode(t *testing.T, chainID uint64) (*node.Node, *Ethereum, *ecdsa.PrivateKey) {
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+
+	config := *params.AllEthashProtocolChanges
+	config.Ethash = nil
+	config.HotStuff = &params.HotStuffConfig{Protocol: "basic"}
+	config.Zion = &params.ZionConfig{Role: params.ZionRoleMain, CrossChainID: chainID}
+
+	extra, err := rlp.EncodeToBytes(&types.HotstuffExtra{Validators: []common.Address{addr}, Seal: []byte{}, CommittedSeal: [][]byte{}})
+	if err != nil {
+		t.Fatalf("failed to encode genesis extra: %v", err)
+	}
+	genesis := &core.Genesis{
+		Config:     &config,
+		ExtraData:  append(make([]byte, types.HotstuffExtraVanity), extra...),
+		GasLimit:   30000000,
+		Difficulty: big.NewInt(1),
+		Alloc: core.GenesisAlloc{addr: {
+			Balance:   new(big.Int).Mul(big.NewInt(1000), big.NewInt(params.Ether)),
+			PublicKey: crypto.CompressPubkey(&key.PublicKey),
+		}},
+	}
+
+	n, err := node.New(&node.Config{P2P: p2p.Config{PrivateKey: key, NoDiscovery: true}})
+	if err != nil {
+		t.Fatalf("failed to create node: %v", err)
+	}
+	ethcfg := ethconfig.Defaults
+	ethcfg.Genesis = genesis
+	ethcfg.SyncMode = downloader.FullSync
+	ethcfg.NoPruning = true
+	ethcfg.Miner.Etherbase = addr
+	ethservice, err := New(n, &ethcfg)
+	if err != nil {
+		t.Fatalf("failed to create ethereum service: %v", err)
+	}
+	if err := n.Start(); err != nil {
+		t.Fatalf("failed to start node: %v", err)
+	}
+	ethservice.Miner().DisablePreseal()
+	if err := ethservice.StartMining(1); err != nil {
+		t.Fatalf("failed to start mining: %v", err)
+	}
+	return n, ethservice, key
+}
+
+// TestGetCrossChainProof feeds the proof bundle of a lock proxy transfer to the verification
+// of the `header_sync/zion` and `cross_chain_manager/zion` contracts, as the relayer does.
+func TestGetCrossChainProof(t *testing.T) {
+	const (
+		mainID = uint64(1)
+		sideID = uint64(77)
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	n, ethservice, key := newTestZionNode(t, mainID)
+	defer n.Close()
+	rpcClient, err := n.Attach()
+	if err != nil {
+		t.Fatalf("failed to attach node: %v", err)
+	}
+	client := ethclient.NewClient(rpcClient)
+	zc := zionclient.NewClient(client)
+	send := func(name string, tx *types.Transaction, err error) {
+		if err != nil {
+			t.Fatalf("failed to send %s: %v", name, err)
+		}
+		receipt, err := bind.WaitMined(ctx, client, tx)
+		if err != nil || receipt.Status != types.ReceiptStatusSuccessful {
+			t.Fatalf("failed to mine %s: %v", name, err)
+		}
+	}
+	opts, err := bind.NewKeyedTransactorWithChainID(key, params.AllEthashProtocolChanges.ChainID)
+	if err != nil {
+		t.Fatalf("failed to create transactor: %v", err)
+	}
+	opts.Context = ctx
+
+	// lock the native token to the side chain
+	tx, err := zc.RegisterSideChain(opts, &side_chain_manager.RegisterSideChainParam{
+		Address:      opts.From,
+		ChainId:      sideID,
+		Router:       utils.ZION_ROUTER,
+		Name:         "side",
+		BlocksToWait: 1,
+		CCMCAddress:  common.HexToAddress("0xeccd").Bytes(),
+	})
+	send("register", tx, err)
+	tx, err = zc.ApproveRegisterSideChain(opts, sideID)
+	send("approve", tx, err)
+	amount := big.NewInt(params.Ether)
+	opts.Value = amount
+	lock, err := zc.Lock(opts, sideID, common.HexToAddress("0xbeef"), amount)
+	send("lock", lock, err)
+
+	// the bundle is retrieved in json as the relayer does
+	proof := new(CrossChainProof)
+	if err := rpcClient.CallContext(ctx, proof, "zion_getCrossChainProof", lock.Hash()); err != nil {
+		t.Fatalf("failed to get cross chain proof: %v", err)
+	}
+	if err := rpcClient.CallContext(ctx, new(CrossChainProof), "zion_getCrossChainProof", common.Hash{0x01}); err == nil {
+		t.Fatal("proof of unknown transaction should fail")
+	}
+	if uint64(proof.Height) != proof.Header.Number.Uint64() || proof.EpochID != hexutil.Uint64(node_manager.StartEpochID) || proof.EpochHeader != nil {
+		t.Fatalf("unexpected proof of epoch %d at %d", proof.EpochID, proof.Height)
+	}
+
+	// the header is committed by the genesis validators synced by `header_sync/zion`
+	genesis := ethservice.BlockChain().Genesis().Header()
+	genesisExtra, err := types.ExtractHotstuffExtra(genesis)
+	if err != nil {
+		t.Fatalf("failed to extract genesis extra: %v", err)
+	}
+	if _, _, err := zion.VerifyHeader(proof.Header, genesisExtra.Validators, false); err != nil {
+		t.Fatalf("failed to verify header: %v", err)
+	}
+	if _, _, err := zion.VerifyHeader(proof.Header, []common.Address{common.HexToAddress("0xbeef")}, false); err == nil {
+		t.Fatal("header should not be verified by other validators")
+	}
+
+	// the request is proved against the header by `cross_chain_manager/zion`
+	value := new(scom.ToMerkleValue)
+	if err := rlp.DecodeBytes(proof.Extra, value); err != nil {
+		t.Fatalf("failed to decode request: %v", err)
+	}
+	if value.MakeTxParam.ToChainID != sideID {
+		t.Fatalf("unexpected request %+v", value.MakeTxParam)
+	}
+	requestKey := utils.ConcatKey(utils.CrossChainManagerContractAddress, []byte(scom.REQUEST), utils.GetUint64Bytes(sideID), value.TxHash)
+	if !bytes.Equal(proof.Key, requestKey) {
+		t.Fatalf("unexpected request key %x, expect %x", proof.Key, requestKey)
+	}
+	raw, err := json.Marshal(proof.Proof)
+	if err != nil {
+		t.Fatalf("failed to encode proof: %v", err)
+	}
+	if _, err := xutils.VerifyTx(raw, proof.Header, utils.CrossChainManagerContractAddress, proof.Extra, true); err != nil {
+		t.Fatalf("failed to verify request: %v", err)
+	}
+	if _, err := xutils.VerifyTx(raw, proof.Header, utils.CrossChainManagerContractAddress, append(proof.Extra, 0x00), true); err == nil {
+		t.Fatal("tampered request should fail")
+	}
+	if _, err := xutils.VerifyTx(raw, genesis, utils.CrossChainManagerContractAddress, proof.Extra, true); err == nil {
+		t.Fatal("proof against another header should fail")
+	}
+
+	// the epoch proof stores the hash of the epoch of the header
+	result, err := helper.VerifyAccountResult(proof.EpochProof, proof.Header, utils.NodeManagerContractAddress)
+	if err != nil {
+		t.Fatalf("failed to verify epoch proof: %v", err)
+	}
+	var epochHash []byte
+	if err := rlp.DecodeBytes(result, &epochHash); err != nil {
+		t.Fatalf("failed to decode epoch proof: %v", err)
+	}
+	statedb, err := ethservice.BlockChain().StateAt(proof.Header.Root)
+	if err != nil {
+		t.Fatalf("failed to get state: %v", err)
+	}
+	epoch, err := node_manager.GetEpochByHeight(statedb, uint64(proof.Height))
+	if err != nil {
+		t.Fatalf("failed to get epoch: %v", err)
+	}
+	if common.BytesToHash(epochHash) != epoch.Hash() {
+		t.Fatalf("epoch proof %x, expect %s", epochHash, epoch.Hash().Hex())
+	}
+}
@@ -43,6 +43,7 @@ import (
 	"github.com/ethereum/go-ethereum/eth/filters"
 	"github.com/ethereum/go-ethereum/eth/gasprice"
 	"github.com/ethereum/go-ethereum/eth/protocols/eth"
+	"github.com/ethereum/go-ethereum/eth/protocols/hotstuff"
 	"github.com/ethereum/go-ethereum/eth/protocols/snap"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/event"
@@ -137,11 +138,12 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		return nil, genesisErr
 	}
 
-	// init different native contracts
-	if params.IsMainChain(config.NetworkId) {
-		boot.InitMainChainNativeContracts()
-	} else {
-		boot.InitSideChainNativeContracts()
+	// init native contracts enabled in chain config
+	if err := boot.InitNativeContracts(chainConfig); err != nil {
+		return nil, err
+	}
+	if err := boot.CheckNativeUpgrades(chainConfig); err != nil {
+		return nil, err
 	}
 	log.Info("Initialised chain configuration", "config", chainConfig)
 
@@ -208,6 +210,13 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		eth.blockchain.SetHead(compat.RewindTo)
 		rawdb.WriteChainConfig(chainDb, genesisHash, chainConfig)
 	}
+	// Refuse to start if governance activated an native contract version unsupported by
+	// the node, the head state may be missing while syncing and is checked by block import.
+	if statedb, err := eth.blockchain.State(); err == nil {
+		if err := boot.CheckApprovedUpgrades(chainConfig, statedb, eth.blockchain.CurrentBlock().NumberU64()); err != nil {
+			return nil, err
+		}
+	}
 	eth.bloomIndexer.Start(eth.blockchain)
 
 	if config.TxPool.Journal != "" {
@@ -231,6 +240,8 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		EventMux:   eth.eventMux,
 		Checkpoint: checkpoint,
 		Whitelist:  config.Whitelist,
+		NodeID:     enode.PubkeyToIDV4(&eth.p2pServer.PrivateKey.PublicKey),
+		Dialer:     eth.p2pServer,
 	}, eth.engine); err != nil {
 		return nil, err
 	}
@@ -352,6 +363,11 @@ func (s *Ethereum) APIs() []rpc.API {
 			Version:   "1.0",
 			Service:   s.netRPCService,
 			Public:    true,
+		}, {
+			Namespace: "zion",
+			Version:   "1.0",
+			Service:   NewPublicZionAPI(s),
+			Public:    true,
 		},
 	}...)
 }
@@ -529,6 +545,9 @@ func (s *Ethereum) Protocols() []p2p.Protocol {
 	if s.config.SnapshotCache > 0 {
 		protos = append(protos, snap.MakeProtocols((*snapHandler)(s.handler), s.snapDialCandidates)...)
 	}
+	if _, ok := s.engine.(consensus.Validator); ok {
+		protos = append(protos, hotstuff.MakeProtocols((*hotstuffHandler)(s.handler))...)
+	}
 	return protos
 }
 
@@ -27,6 +27,7 @@ import (
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state/snapshot"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -93,6 +94,8 @@ var (
 	errNoSyncActive            = errors.New("no sync active")
 	errTooOld                  = errors.New("peer's protocol version too old")
 	errNoAncestorFound         = errors.New("no common ancestor found")
+	errNoEpochPeers            = errors.New("no peers to sync epochs from")
+	errEpochSyncUnsupported    = errors.New("epoch sync not supported by consensus engine")
 )
 
 type Downloader struct {
@@ -145,8 +148,11 @@ type Downloader struct {
 	pivotHeader *types.Header // Pivot block header to dynamically push the syncing state root
 	pivotLock   sync.RWMutex  // Lock protecting pivot header reads from updates
 
-	snapSync       bool         // Whether to run state sync over the snap protocol
-	SnapSyncer     *snap.Syncer // TODO(karalabe): make private! hack for now
+	snapSync       bool                    // Whether to run state sync over the snap protocol
+	epochSync      bool                    // Whether to verify the epochs before the snap sync (per sync cycle)
+	epochVerifier  consensus.EpochVerifier // Consensus engine verifying the epoch change headers
+	epochPeers     func() []EpochPeer      // Retrieves the peers serving the epoch change headers
+	SnapSyncer     *snap.Syncer            // TODO(karalabe): make private! hack for now
 	stateSyncStart chan *stateSync
 	trackStateReq  chan *stateReq
 	stateCh        chan dataPack // Channel receiving inbound node state data
@@ -392,6 +398,16 @@ func (d *Downloader) synchronise(id string, hash common.Hash, td *big.Int, mode
 	if mode == FullSync && d.stateBloom != nil {
 		d.stateBloom.Close()
 	}
+	// If epoch sync was requested, the epochs are verified before the snap sync,
+	// so that the state can be synced at a verified chain head.
+	d.epochSync = false
+	if mode == EpochSync {
+		if d.epochVerifier == nil || d.epochPeers == nil {
+			return errEpochSyncUnsupported
+		}
+		d.epochSync = true
+		mode = SnapSync
+	}
 	// If snap sync was requested, create the snap scheduler and switch to fast
 	// sync mode. Long term we could drop fast sync or merge the two together,
 	// but until snap becomes prevalent, we should support both. TODO(karalabe).
@@ -492,6 +508,11 @@ func (d *Downloader) syncWithPeer(p *peerConnection, hash common.Hash, td *big.I
 		// nil panics on an access.
 		pivot = d.blockchain.CurrentBlock().Header()
 	}
+	if d.epochSync {
+		if err := d.syncEpochs(latest, pivot); err != nil {
+			return err
+		}
+	}
 	height := latest.Number.Uint64()
 
 	origin, err := d.findAncestor(p, latest)
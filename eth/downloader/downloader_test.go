@@ -28,7 +28,9 @@ import (
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/state/snapshot"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/eth/protocols/eth"
@@ -37,6 +39,15 @@ import (
 	"github.com/ethereum/go-ethereum/trie"
 )
 
+// The genesis hooks are registered by the governance and consensus packages, which
+// can't be imported by the downloader tests. Install no-op ones before the test chains
+// are generated.
+var _ = func() bool {
+	core.RegGenesis = func(db *state.StateDB, data core.GenesisAlloc) error { return nil }
+	core.StoreGenesis = func(db ethdb.Database, header *types.Header) error { return nil }
+	return true
+}()
+
 // Reduce some of the parameters to make the tester faster.
 func init() {
 	fullMaxForkAncestry = 10000
@@ -474,11 +485,16 @@ func (dlp *downloadTesterPeer) RequestNodeData(hashes []common.Hash) error {
 
 	results := make([][]byte, 0, len(hashes))
 	for _, hash := range hashes {
-		if data, err := dlp.dl.peerDb.Get(hash.Bytes()); err == nil {
-			if !dlp.missingStates[hash] {
-				results = append(results, data)
+		// contract codes are stored with a prefix, serve them like the eth handler does
+		data, err := dlp.dl.peerDb.Get(hash.Bytes())
+		if err != nil {
+			if data = rawdb.ReadCodeWithPrefix(dlp.dl.peerDb, hash); len(data) == 0 {
+				continue
 			}
 		}
+		if !dlp.missingStates[hash] {
+			results = append(results, data)
+		}
 	}
 	go dlp.dl.downloader.DeliverNodeData(dlp.id, results)
 	return nil
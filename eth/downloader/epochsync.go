@@ -0,0 +1,121 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package downloader
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// EpochPeer is a remote peer serving the epoch change headers of the chain.
+type EpochPeer interface {
+	// ID retrieves the peer's unique identifier, which is shared with the eth peer.
+	ID() string
+
+	// RequestEpochHeaders fetches the epoch change headers starting at the given
+	// block number.
+	RequestEpochHeaders(from uint64) ([]*types.Header, error)
+}
+
+// EnableEpochSync sets the consensus engine verifying the epoch change headers and
+// the source of epoch peers, both are required by the EpochSync mode.
+func (d *Downloader) EnableEpochSync(verifier consensus.EpochVerifier, peers func() []EpochPeer) {
+	d.epochVerifier = verifier
+	d.epochPeers = peers
+}
+
+// syncEpochs retrieves the epoch change headers from all the epoch peers, and verifies
+// them in ascending order, each against the quorum of the validators before it. A
+// peer can't forge an epoch without the committed seals of the previous validators,
+// and withholding an epoch is defeated by any other peer serving it. At last the sync
+// head and pivot are verified with the validators of their epochs, so that the state
+// is only synced at a header committed by the validators.
+func (d *Downloader) syncEpochs(head, pivot *types.Header) error {
+	var number uint64 // Number of the last verified epoch change header
+	for {
+		peers := d.epochPeers()
+		if len(peers) == 0 {
+			return errNoEpochPeers
+		}
+		// Gather the epoch change headers after the verified ones from all peers
+		var (
+			headers []*types.Header
+			sources = make(map[common.Hash][]string)
+		)
+		for _, p := range peers {
+			select {
+			case <-d.cancelCh:
+				return errCanceled
+			default:
+			}
+			res, err := p.RequestEpochHeaders(number + 1)
+			if err != nil {
+				log.Debug("Epoch headers retrieval failed", "peer", p.ID(), "err", err)
+				continue
+			}
+			for _, header := range res {
+				hash := header.Hash()
+				if _, ok := sources[hash]; !ok {
+					headers = append(headers, header)
+				}
+				sources[hash] = append(sources[hash], p.ID())
+			}
+		}
+		sort.SliceStable(headers, func(i, j int) bool {
+			return headers[i].Number.Cmp(headers[j].Number) < 0
+		})
+		// Verify the headers one by one, conflicting headers of the same number are
+		// skipped once one of them is verified
+		last := number
+		for _, header := range headers {
+			if header.Number.Uint64() <= number || header.Number.Cmp(head.Number) > 0 {
+				continue
+			}
+			if err := d.epochVerifier.VerifyEpochChange(header); err != nil {
+				log.Warn("Invalid epoch change header", "number", header.Number, "hash", header.Hash(), "err", err)
+				if d.dropPeer != nil {
+					for _, id := range sources[header.Hash()] {
+						d.dropPeer(id)
+					}
+				}
+				continue
+			}
+			number = header.Number.Uint64()
+		}
+		if number == last {
+			break
+		}
+		log.Info("Verified epoch change headers", "number", number)
+	}
+	if err := d.epochVerifier.VerifyCommitted(head); err != nil {
+		return fmt.Errorf("%w: head %d not committed by epoch validators: %v", errBadPeer, head.Number, err)
+	}
+	if pivot.Number.Sign() > 0 && pivot.Hash() != head.Hash() {
+		if err := d.epochVerifier.VerifyCommitted(pivot); err != nil {
+			return fmt.Errorf("%w: pivot %d not committed by epoch validators: %v", errBadPeer, pivot.Number, err)
+		}
+	}
+	log.Info("Verified sync head with epoch validators", "epoch", number, "head", head.Number, "pivot", pivot.Number)
+	return nil
+}
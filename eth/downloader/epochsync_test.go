@@ -0,0 +1,195 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package downloader
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// epochTestVerifier accepts an epoch change header only if it is signed by the
+// validators of the last verified epoch, modelled by the extra data naming the
+// previous epoch change and a `forged` marker.
+type epochTestVerifier struct {
+	last      uint64   // Number of the last verified epoch change header
+	verified  []uint64 // Numbers of all verified epoch change headers in order
+	committed uint64   // Headers are committed if they are after this epoch change
+}
+
+func (v *epochTestVerifier) EpochChanges(chain consensus.ChainHeaderReader, from uint64, limit int) []*types.Header {
+	return nil
+}
+
+func (v *epochTestVerifier) VerifyEpochChange(header *types.Header) error {
+	if string(header.Extra) != fmt.Sprintf("epoch after %d", v.last) {
+		return errors.New("not signed by the last epoch validators")
+	}
+	v.last = header.Number.Uint64()
+	v.verified = append(v.verified, v.last)
+	return nil
+}
+
+func (v *epochTestVerifier) VerifyCommitted(header *types.Header) error {
+	if v.last < v.committed {
+		return errors.New("not committed by the last epoch validators")
+	}
+	return nil
+}
+
+// epochTestPeer serves a fixed list of epoch change headers, at most two per request.
+type epochTestPeer struct {
+	id      string
+	headers []*types.Header
+}
+
+func (p *epochTestPeer) ID() string { return p.id }
+
+func (p *epochTestPeer) RequestEpochHeaders(from uint64) ([]*types.Header, error) {
+	var res []*types.Header
+	for _, header := range p.headers {
+		if header.Number.Uint64() >= from && len(res) < 2 {
+			res = append(res, header)
+		}
+	}
+	return res, nil
+}
+
+// makeEpochHeaders creates the epoch change headers at the given numbers, each one
+// signed by the validators of the previous epoch.
+func makeEpochHeaders(numbers ...uint64) []*types.Header {
+	var (
+		headers []*types.Header
+		last    uint64
+	)
+	for _, number := range numbers {
+		headers = append(headers, &types.Header{
+			Number: new(big.Int).SetUint64(number),
+			Extra:  []byte(fmt.Sprintf("epoch after %d", last)),
+		})
+		last = number
+	}
+	return headers
+}
+
+func TestSyncEpochs(t *testing.T) {
+	var (
+		honest   = makeEpochHeaders(10, 20, 30, 40, 50)
+		head     = &types.Header{Number: big.NewInt(45)}
+		pivot    = &types.Header{Number: big.NewInt(35)}
+		forged   = &types.Header{Number: big.NewInt(20), Extra: []byte("forged")}
+		withheld = []*types.Header{honest[0], honest[2], honest[3]}
+	)
+	tests := []struct {
+		name      string
+		peers     []*epochTestPeer
+		committed uint64
+		verified  []uint64
+		dropped   []string
+		err       error
+	}{
+		{
+			name:      "single honest peer",
+			peers:     []*epochTestPeer{{id: "honest", headers: honest}},
+			committed: 40,
+			verified:  []uint64{10, 20, 30, 40},
+		},
+		{
+			name: "withheld epoch served by another peer",
+			peers: []*epochTestPeer{
+				{id: "withholder", headers: withheld},
+				{id: "honest", headers: honest},
+			},
+			committed: 40,
+			verified:  []uint64{10, 20, 30, 40},
+		},
+		{
+			name: "forged epoch dropped",
+			peers: []*epochTestPeer{
+				{id: "forger", headers: []*types.Header{honest[0], forged}},
+				{id: "honest", headers: honest},
+			},
+			committed: 40,
+			verified:  []uint64{10, 20, 30, 40},
+			dropped:   []string{"forger"},
+		},
+		{
+			name:      "head not committed by epoch validators",
+			peers:     []*epochTestPeer{{id: "withholder", headers: withheld}},
+			committed: 40,
+			verified:  []uint64{10},
+			dropped:   []string{"withholder"},
+			err:       errBadPeer,
+		},
+		{
+			name: "no epoch peers",
+			err:  errNoEpochPeers,
+		},
+	}
+	for _, tt := range tests {
+		var (
+			verifier = &epochTestVerifier{committed: tt.committed}
+			dropped  []string
+		)
+		d := &Downloader{
+			cancelCh: make(chan struct{}),
+			dropPeer: func(id string) {
+				for _, v := range dropped {
+					if v == id {
+						return
+					}
+				}
+				dropped = append(dropped, id)
+			},
+		}
+		d.EnableEpochSync(verifier, func() []EpochPeer {
+			peers := make([]EpochPeer, 0, len(tt.peers))
+			for _, p := range tt.peers {
+				peers = append(peers, p)
+			}
+			return peers
+		})
+		err := d.syncEpochs(head, pivot)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("%s: error mismatch: have %v, want %v", tt.name, err, tt.err)
+		}
+		if !reflect.DeepEqual(verifier.verified, tt.verified) {
+			t.Errorf("%s: verified epochs mismatch: have %v, want %v", tt.name, verifier.verified, tt.verified)
+		}
+		if !reflect.DeepEqual(dropped, tt.dropped) {
+			t.Errorf("%s: dropped peers mismatch: have %v, want %v", tt.name, dropped, tt.dropped)
+		}
+	}
+}
+
+func TestSyncEpochsCanceled(t *testing.T) {
+	d := &Downloader{cancelCh: make(chan struct{})}
+	d.EnableEpochSync(new(epochTestVerifier), func() []EpochPeer {
+		return []EpochPeer{&epochTestPeer{id: "honest", headers: makeEpochHeaders(10)}}
+	})
+	close(d.cancelCh)
+	if err := d.syncEpochs(&types.Header{Number: big.NewInt(20)}, &types.Header{Number: big.NewInt(0)}); err != errCanceled {
+		t.Fatalf("error mismatch: have %v, want %v", err, errCanceled)
+	}
+}
@@ -27,10 +27,11 @@ const (
 	FastSync                  // Quickly download the headers, full sync only at the chain
 	SnapSync                  // Download the chain and the state via compact snapshots
 	LightSync                 // Download only the headers and terminate afterwards
+	EpochSync                 // Verify the epoch change headers, then snap sync at the chain head
 )
 
 func (mode SyncMode) IsValid() bool {
-	return mode >= FullSync && mode <= LightSync
+	return mode >= FullSync && mode <= EpochSync
 }
 
 // String implements the stringer interface.
@@ -44,6 +45,8 @@ func (mode SyncMode) String() string {
 		return "snap"
 	case LightSync:
 		return "light"
+	case EpochSync:
+		return "epoch"
 	default:
 		return "unknown"
 	}
@@ -59,6 +62,8 @@ func (mode SyncMode) MarshalText() ([]byte, error) {
 		return []byte("snap"), nil
 	case LightSync:
 		return []byte("light"), nil
+	case EpochSync:
+		return []byte("epoch"), nil
 	default:
 		return nil, fmt.Errorf("unknown sync mode %d", mode)
 	}
@@ -74,8 +79,10 @@ func (mode *SyncMode) UnmarshalText(text []byte) error {
 		*mode = SnapSync
 	case "light":
 		*mode = LightSync
+	case "epoch":
+		*mode = EpochSync
 	default:
-		return fmt.Errorf(`unknown sync mode %q, want "full", "fast" or "light"`, text)
+		return fmt.Errorf(`unknown sync mode %q, want "full", "fast", "snap", "light" or "epoch"`, text)
 	}
 	return nil
 }
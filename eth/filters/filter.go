@@ -39,6 +39,7 @@ type Backend interface {
 
 	SubscribeNewTxsEvent(chan<- core.NewTxsEvent) event.Subscription
 	SubscribeChainEvent(ch chan<- core.ChainEvent) event.Subscription
+	SubscribeFinalizedHeadEvent(ch chan<- core.FinalizedHeadEvent) event.Subscription
 	SubscribeRemovedLogsEvent(ch chan<- core.RemovedLogsEvent) event.Subscription
 	SubscribeLogsEvent(ch chan<- []*types.Log) event.Subscription
 	SubscribePendingLogsEvent(ch chan<- []*types.Log) event.Subscription
@@ -135,13 +136,31 @@ func (f *Filter) Logs(ctx context.Context) ([]*types.Log, error) {
 	}
 	head := header.Number.Uint64()
 
+	// Resolve the finalized head only if the range refers to it
+	var final uint64
+	if f.begin == rpc.FinalizedBlockNumber.Int64() || f.end == rpc.FinalizedBlockNumber.Int64() {
+		header, err := f.backend.HeaderByNumber(ctx, rpc.FinalizedBlockNumber)
+		if err != nil {
+			return nil, err
+		}
+		if header == nil {
+			return nil, errors.New("finalized header not found")
+		}
+		final = header.Number.Uint64()
+	}
 	if f.begin == -1 {
 		f.begin = int64(head)
 	}
+	if f.begin == rpc.FinalizedBlockNumber.Int64() {
+		f.begin = int64(final)
+	}
 	end := uint64(f.end)
 	if f.end == -1 {
 		end = head
 	}
+	if f.end == rpc.FinalizedBlockNumber.Int64() {
+		end = final
+	}
 	// Gather all indexed logs, and finish with non indexed ones
 	var (
 		logs []*types.Log
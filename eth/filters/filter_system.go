@@ -52,6 +52,10 @@ const (
 	PendingTransactionsSubscription
 	// BlocksSubscription queries hashes for blocks that are imported
 	BlocksSubscription
+	// FinalizedBlocksSubscription queries headers for blocks that are finalized
+	FinalizedBlocksSubscription
+	// FinalizedLogsSubscription queries for logs in blocks that are finalized
+	FinalizedLogsSubscription
 	// LastSubscription keeps track of the last index
 	LastIndexSubscription
 )
@@ -66,6 +70,8 @@ const (
 	logsChanSize = 10
 	// chainEvChanSize is the size of channel listening to ChainEvent.
 	chainEvChanSize = 10
+	// finalizedEvChanSize is the size of channel listening to FinalizedHeadEvent.
+	finalizedEvChanSize = 10
 )
 
 type subscription struct {
@@ -87,21 +93,25 @@ type EventSystem struct {
 	lightMode bool
 	lastHead  *types.Header
 
+	lastFinalized *types.Header // Last finalized head the finalized logs were filtered up to
+
 	// Subscriptions
 	txsSub         event.Subscription // Subscription for new transaction event
 	logsSub        event.Subscription // Subscription for new log event
 	rmLogsSub      event.Subscription // Subscription for removed log event
 	pendingLogsSub event.Subscription // Subscription for pending log event
 	chainSub       event.Subscription // Subscription for new chain event
+	finalizedSub   event.Subscription // Subscription for new finalized head event
 
 	// Channels
-	install       chan *subscription         // install filter for event notification
-	uninstall     chan *subscription         // remove filter for event notification
-	txsCh         chan core.NewTxsEvent      // Channel to receive new transactions event
-	logsCh        chan []*types.Log          // Channel to receive new log event
-	pendingLogsCh chan []*types.Log          // Channel to receive new log event
-	rmLogsCh      chan core.RemovedLogsEvent // Channel to receive removed log event
-	chainCh       chan core.ChainEvent       // Channel to receive new chain event
+	install       chan *subscription           // install filter for event notification
+	uninstall     chan *subscription           // remove filter for event notification
+	txsCh         chan core.NewTxsEvent        // Channel to receive new transactions event
+	logsCh        chan []*types.Log            // Channel to receive new log event
+	pendingLogsCh chan []*types.Log            // Channel to receive new log event
+	rmLogsCh      chan core.RemovedLogsEvent   // Channel to receive removed log event
+	chainCh       chan core.ChainEvent         // Channel to receive new chain event
+	finalizedCh   chan core.FinalizedHeadEvent // Channel to receive new finalized head event
 }
 
 // NewEventSystem creates a new manager that listens for event on the given mux,
@@ -121,6 +131,7 @@ func NewEventSystem(backend Backend, lightMode bool) *EventSystem {
 		rmLogsCh:      make(chan core.RemovedLogsEvent, rmLogsChanSize),
 		pendingLogsCh: make(chan []*types.Log, logsChanSize),
 		chainCh:       make(chan core.ChainEvent, chainEvChanSize),
+		finalizedCh:   make(chan core.FinalizedHeadEvent, finalizedEvChanSize),
 	}
 
 	// Subscribe events
@@ -129,9 +140,10 @@ func NewEventSystem(backend Backend, lightMode bool) *EventSystem {
 	m.rmLogsSub = m.backend.SubscribeRemovedLogsEvent(m.rmLogsCh)
 	m.chainSub = m.backend.SubscribeChainEvent(m.chainCh)
 	m.pendingLogsSub = m.backend.SubscribePendingLogsEvent(m.pendingLogsCh)
+	m.finalizedSub = m.backend.SubscribeFinalizedHeadEvent(m.finalizedCh)
 
 	// Make sure none of the subscriptions are empty
-	if m.txsSub == nil || m.logsSub == nil || m.rmLogsSub == nil || m.chainSub == nil || m.pendingLogsSub == nil {
+	if m.txsSub == nil || m.logsSub == nil || m.rmLogsSub == nil || m.chainSub == nil || m.pendingLogsSub == nil || m.finalizedSub == nil {
 		log.Crit("Subscribe for event system failed")
 	}
 
@@ -186,7 +198,8 @@ func (es *EventSystem) subscribe(sub *subscription) *Subscription {
 
 // SubscribeLogs creates a subscription that will write all logs matching the
 // given criteria to the given logs channel. Default value for the from and to
-// block is "latest". If the fromBlock > toBlock an error is returned.
+// block is "latest". If the fromBlock > toBlock an error is returned. Logs up to
+// the "finalized" block are written once the consensus engine finalizes them.
 func (es *EventSystem) SubscribeLogs(crit ethereum.FilterQuery, logs chan []*types.Log) (*Subscription, error) {
 	var from, to rpc.BlockNumber
 	if crit.FromBlock == nil {
@@ -200,6 +213,10 @@ func (es *EventSystem) SubscribeLogs(crit ethereum.FilterQuery, logs chan []*typ
 		to = rpc.BlockNumber(crit.ToBlock.Int64())
 	}
 
+	// only interested in logs once their blocks are finalized
+	if (from == rpc.FinalizedBlockNumber || from >= 0) && to == rpc.FinalizedBlockNumber {
+		return es.subscribeFinalizedLogs(crit, logs), nil
+	}
 	// only interested in pending logs
 	if from == rpc.PendingBlockNumber && to == rpc.PendingBlockNumber {
 		return es.subscribePendingLogs(crit, logs), nil
@@ -257,6 +274,23 @@ func (es *EventSystem) subscribeLogs(crit ethereum.FilterQuery, logs chan []*typ
 	return es.subscribe(sub)
 }
 
+// subscribeFinalizedLogs creates a subscription that will write the logs of the
+// finalized blocks matching the given criteria to the given logs channel.
+func (es *EventSystem) subscribeFinalizedLogs(crit ethereum.FilterQuery, logs chan []*types.Log) *Subscription {
+	sub := &subscription{
+		id:        rpc.NewID(),
+		typ:       FinalizedLogsSubscription,
+		logsCrit:  crit,
+		created:   time.Now(),
+		logs:      logs,
+		hashes:    make(chan []common.Hash),
+		headers:   make(chan *types.Header),
+		installed: make(chan struct{}),
+		err:       make(chan error),
+	}
+	return es.subscribe(sub)
+}
+
 // subscribePendingLogs creates a subscription that writes transaction hashes for
 // transactions that enter the transaction pool.
 func (es *EventSystem) subscribePendingLogs(crit ethereum.FilterQuery, logs chan []*types.Log) *Subscription {
@@ -290,6 +324,22 @@ func (es *EventSystem) SubscribeNewHeads(headers chan *types.Header) *Subscripti
 	return es.subscribe(sub)
 }
 
+// SubscribeNewFinalizedHeads creates a subscription that writes the header of a
+// block once it is finalized by the consensus engine.
+func (es *EventSystem) SubscribeNewFinalizedHeads(headers chan *types.Header) *Subscription {
+	sub := &subscription{
+		id:        rpc.NewID(),
+		typ:       FinalizedBlocksSubscription,
+		created:   time.Now(),
+		logs:      make(chan []*types.Log),
+		hashes:    make(chan []common.Hash),
+		headers:   headers,
+		installed: make(chan struct{}),
+		err:       make(chan error),
+	}
+	return es.subscribe(sub)
+}
+
 // SubscribePendingTxs creates a subscription that writes transaction hashes for
 // transactions that enter the transaction pool.
 func (es *EventSystem) SubscribePendingTxs(hashes chan []common.Hash) *Subscription {
@@ -366,6 +416,49 @@ func (es *EventSystem) handleChainEvent(filters filterIndex, ev core.ChainEvent)
 	}
 }
 
+func (es *EventSystem) handleFinalizedEvent(filters filterIndex, ev core.FinalizedHeadEvent) {
+	for _, f := range filters[FinalizedBlocksSubscription] {
+		f.headers <- ev.Block.Header()
+	}
+	if len(filters[FinalizedLogsSubscription]) == 0 {
+		es.lastFinalized = ev.Block.Header()
+		return
+	}
+	es.filterNewFinalized(ev.Block.Header(), func(header *types.Header) {
+		for _, f := range filters[FinalizedLogsSubscription] {
+			if from := f.logsCrit.FromBlock; from != nil && from.Int64() >= 0 && from.Uint64() > header.Number.Uint64() {
+				continue
+			}
+			if matchedLogs := es.lightFilterLogs(header, f.logsCrit.Addresses, f.logsCrit.Topics, false); len(matchedLogs) > 0 {
+				f.logs <- matchedLogs
+			}
+		}
+	})
+}
+
+// filterNewFinalized calls back with the headers finalized since the last
+// finalized head in ascending order. Only the last header of an insertion is
+// announced, the ones before it are read from the database.
+func (es *EventSystem) filterNewFinalized(newHeader *types.Header, callBack func(*types.Header)) {
+	oldh := es.lastFinalized
+	es.lastFinalized = newHeader
+
+	headers := []*types.Header{newHeader}
+	if oldh != nil {
+		for h := newHeader; h.Number.Uint64() > oldh.Number.Uint64()+1; {
+			if h = rawdb.ReadHeader(es.backend.ChainDb(), h.ParentHash, h.Number.Uint64()-1); h == nil {
+				// happens when CHT syncing, nothing to do
+				break
+			}
+			headers = append(headers, h)
+		}
+	}
+	// check new blocks (array is in reverse order)
+	for i := len(headers) - 1; i >= 0; i-- {
+		callBack(headers[i])
+	}
+}
+
 func (es *EventSystem) lightFilterNewHead(newHeader *types.Header, callBack func(*types.Header, bool)) {
 	oldh := es.lastHead
 	es.lastHead = newHeader
@@ -448,6 +541,7 @@ func (es *EventSystem) eventLoop() {
 		es.rmLogsSub.Unsubscribe()
 		es.pendingLogsSub.Unsubscribe()
 		es.chainSub.Unsubscribe()
+		es.finalizedSub.Unsubscribe()
 	}()
 
 	index := make(filterIndex)
@@ -467,6 +561,8 @@ func (es *EventSystem) eventLoop() {
 			es.handlePendingLogs(index, ev)
 		case ev := <-es.chainCh:
 			es.handleChainEvent(index, ev)
+		case ev := <-es.finalizedCh:
+			es.handleFinalizedEvent(index, ev)
 
 		case f := <-es.install:
 			if f.typ == MinedAndPendingLogsSubscription {
@@ -497,6 +593,8 @@ func (es *EventSystem) eventLoop() {
 			return
 		case <-es.chainSub.Err():
 			return
+		case <-es.finalizedSub.Err():
+			return
 		}
 	}
 }
@@ -32,6 +32,7 @@ import (
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/bloombits"
 	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/event"
@@ -43,6 +44,15 @@ var (
 	deadline = 5 * time.Minute
 )
 
+// The genesis hooks are registered by the governance and consensus packages, which
+// can't be imported by the filter tests. Install no-op ones before the test chains
+// are generated.
+var _ = func() bool {
+	core.RegGenesis = func(db *state.StateDB, data core.GenesisAlloc) error { return nil }
+	core.StoreGenesis = func(db ethdb.Database, header *types.Header) error { return nil }
+	return true
+}()
+
 type testBackend struct {
 	mux             *event.TypeMux
 	db              ethdb.Database
@@ -52,6 +62,7 @@ type testBackend struct {
 	rmLogsFeed      event.Feed
 	pendingLogsFeed event.Feed
 	chainFeed       event.Feed
+	finalizedFeed   event.Feed
 }
 
 func (b *testBackend) ChainDb() ethdb.Database {
@@ -70,6 +81,13 @@ func (b *testBackend) HeaderByNumber(ctx context.Context, blockNr rpc.BlockNumbe
 			return nil, nil
 		}
 		num = *number
+	} else if blockNr == rpc.FinalizedBlockNumber {
+		hash = rawdb.ReadHeadFinalizedBlockHash(b.db)
+		number := rawdb.ReadHeaderNumber(b.db, hash)
+		if number == nil {
+			return nil, nil
+		}
+		num = *number
 	} else {
 		num = uint64(blockNr)
 		hash = rawdb.ReadCanonicalHash(b.db, num)
@@ -126,6 +144,10 @@ func (b *testBackend) SubscribeChainEvent(ch chan<- core.ChainEvent) event.Subsc
 	return b.chainFeed.Subscribe(ch)
 }
 
+func (b *testBackend) SubscribeFinalizedHeadEvent(ch chan<- core.FinalizedHeadEvent) event.Subscription {
+	return b.finalizedFeed.Subscribe(ch)
+}
+
 func (b *testBackend) BloomStatus() (uint64, uint64) {
 	return params.BloomBitsBlocks, b.sections
 }
@@ -213,6 +235,50 @@ func TestBlockSubscription(t *testing.T) {
 	<-sub1.Err()
 }
 
+// TestFinalizedBlockSubscription tests if a finalized head subscription returns
+// the headers of the finalized head events.
+func TestFinalizedBlockSubscription(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db      = rawdb.NewMemoryDatabase()
+		backend = &testBackend{db: db}
+		api     = NewPublicFilterAPI(backend, false, deadline)
+		chain   []*types.Block
+	)
+	for i := int64(1); i <= 10; i++ {
+		chain = append(chain, types.NewBlockWithHeader(&types.Header{Number: big.NewInt(i)}))
+	}
+
+	headers := make(chan *types.Header)
+	sub := api.events.SubscribeNewFinalizedHeads(headers)
+	blocks := make(chan *types.Header)
+	blockSub := api.events.SubscribeNewHeads(blocks)
+
+	go func() { // simulate client
+		for i := 0; i != len(chain); {
+			select {
+			case header := <-headers:
+				if chain[i].Hash() != header.Hash() {
+					t.Errorf("received invalid hash on index %d, want %x, got %x", i, chain[i].Hash(), header.Hash())
+				}
+				i++
+			case header := <-blocks:
+				t.Errorf("received unexpected chain head %x", header.Hash())
+			}
+		}
+		sub.Unsubscribe()
+		blockSub.Unsubscribe()
+	}()
+
+	time.Sleep(1 * time.Second)
+	for _, blk := range chain {
+		backend.finalizedFeed.Send(core.FinalizedHeadEvent{Block: blk})
+	}
+	<-sub.Err()
+	<-blockSub.Err()
+}
+
 // TestPendingTxFilter tests whether pending tx filters retrieve all pending transactions that are posted to the event mux.
 func TestPendingTxFilter(t *testing.T) {
 	t.Parallel()
@@ -328,6 +394,8 @@ func TestInvalidLogFilterCreation(t *testing.T) {
 		0: {FromBlock: big.NewInt(rpc.PendingBlockNumber.Int64()), ToBlock: big.NewInt(rpc.LatestBlockNumber.Int64())},
 		1: {FromBlock: big.NewInt(rpc.PendingBlockNumber.Int64()), ToBlock: big.NewInt(100)},
 		2: {FromBlock: big.NewInt(rpc.LatestBlockNumber.Int64()), ToBlock: big.NewInt(100)},
+		3: {FromBlock: big.NewInt(rpc.FinalizedBlockNumber.Int64()), ToBlock: big.NewInt(rpc.LatestBlockNumber.Int64())},
+		4: {FromBlock: big.NewInt(rpc.FinalizedBlockNumber.Int64()), ToBlock: big.NewInt(100)},
 	}
 
 	for i, test := range testCases {
@@ -473,6 +541,96 @@ func TestLogFilter(t *testing.T) {
 	}
 }
 
+// TestFinalizedLogsSubscription tests if a subscription up to the finalized block
+// receives the logs of all blocks finalized since the last finalized head event.
+func TestFinalizedLogsSubscription(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db      = rawdb.NewMemoryDatabase()
+		backend = &testBackend{db: db}
+		api     = NewPublicFilterAPI(backend, false, deadline)
+
+		firstAddr  = common.HexToAddress("0x1111111111111111111111111111111111111111")
+		secondAddr = common.HexToAddress("0x2222222222222222222222222222222222222222")
+	)
+	genesis := core.GenesisBlockForTesting(db, firstAddr, big.NewInt(1000000))
+	chain, receipts := core.GenerateChain(params.TestChainConfig, genesis, ethash.NewFaker(), db, 5, func(i int, gen *core.BlockGen) {
+		addr := firstAddr
+		if i%2 == 1 {
+			addr = secondAddr
+		}
+		receipt := types.NewReceipt(nil, false, 0)
+		receipt.Logs = []*types.Log{{Address: addr}}
+		gen.AddUncheckedReceipt(receipt)
+		gen.AddUncheckedTx(types.NewTransaction(uint64(i), addr, big.NewInt(1), 1, big.NewInt(1), nil))
+	})
+	for i, block := range chain {
+		rawdb.WriteBlock(db, block)
+		rawdb.WriteCanonicalHash(db, block.Hash(), block.NumberU64())
+		rawdb.WriteHeadBlockHash(db, block.Hash())
+		rawdb.WriteReceipts(db, block.Hash(), block.NumberU64(), receipts[i])
+	}
+
+	finalized := big.NewInt(rpc.FinalizedBlockNumber.Int64())
+	testCases := []struct {
+		crit     ethereum.FilterQuery
+		expected []uint64
+		c        chan []*types.Log
+		sub      *Subscription
+	}{
+		// all finalized logs
+		{ethereum.FilterQuery{FromBlock: finalized, ToBlock: finalized}, []uint64{1, 2, 3, 4, 5}, nil, nil},
+		// finalized logs with block num >= 3
+		{ethereum.FilterQuery{FromBlock: big.NewInt(3), ToBlock: finalized}, []uint64{3, 4, 5}, nil, nil},
+		// finalized logs based on addresses
+		{ethereum.FilterQuery{Addresses: []common.Address{secondAddr}, FromBlock: finalized, ToBlock: finalized}, []uint64{2, 4}, nil, nil},
+	}
+	for i := range testCases {
+		testCases[i].c = make(chan []*types.Log)
+		sub, err := api.events.SubscribeLogs(testCases[i].crit, testCases[i].c)
+		if err != nil {
+			t.Fatalf("failed to subscribe case %d: %v", i, err)
+		}
+		testCases[i].sub = sub
+	}
+
+	// the subscriptions are served by a single loop, read them concurrently
+	fetched := make([]chan []uint64, len(testCases))
+	for i, tt := range testCases {
+		fetched[i] = make(chan []uint64, 1)
+		go func(c chan []*types.Log, want int, result chan []uint64) {
+			var numbers []uint64
+			timeout := time.After(5 * time.Second)
+			for len(numbers) < want {
+				select {
+				case logs := <-c:
+					for _, log := range logs {
+						numbers = append(numbers, log.BlockNumber)
+					}
+				case <-timeout:
+					result <- numbers
+					return
+				}
+			}
+			result <- numbers
+		}(tt.c, len(tt.expected), fetched[i])
+	}
+	// the genesis only sets the finalized head, the blocks in between the
+	// announced ones are finalized as well
+	time.Sleep(1 * time.Second)
+	for _, block := range []*types.Block{genesis, chain[1], chain[4]} {
+		backend.finalizedFeed.Send(core.FinalizedHeadEvent{Block: block})
+	}
+
+	for i, tt := range testCases {
+		if numbers := <-fetched[i]; !reflect.DeepEqual(numbers, tt.expected) {
+			t.Errorf("invalid logs for case %d, want blocks %v, got %v", i, tt.expected, numbers)
+		}
+		tt.sub.Unsubscribe()
+	}
+}
+
 // TestPendingLogsSubscription tests if a subscription receives the correct pending logs that are posted to the event feed.
 func TestPendingLogsSubscription(t *testing.T) {
 	t.Parallel()
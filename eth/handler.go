@@ -19,7 +19,6 @@ package eth
 import (
 	"errors"
 	"github.com/ethereum/go-ethereum/consensus"
-	"github.com/ethereum/go-ethereum/crypto"
 	"math"
 	"math/big"
 	"sync"
@@ -33,11 +32,13 @@ import (
 	"github.com/ethereum/go-ethereum/eth/downloader"
 	"github.com/ethereum/go-ethereum/eth/fetcher"
 	"github.com/ethereum/go-ethereum/eth/protocols/eth"
+	"github.com/ethereum/go-ethereum/eth/protocols/hotstuff"
 	"github.com/ethereum/go-ethereum/eth/protocols/snap"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/p2p/enode"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/trie"
 )
@@ -46,6 +47,10 @@ const (
 	// txChanSize is the size of channel listening to NewTxsEvent.
 	// The number is referenced from the size of tx pool.
 	txChanSize = 4096
+
+	// chainHeadChanSize is the size of channel listening to ChainHeadEvent for the
+	// hotstuff validator mesh.
+	chainHeadChanSize = 10
 )
 
 var (
@@ -87,6 +92,8 @@ type handlerConfig struct {
 	EventMux   *event.TypeMux            // Legacy event mux, deprecate for `feed`
 	Checkpoint *params.TrustedCheckpoint // Hard coded checkpoint for sync challenges
 	Whitelist  map[uint64]common.Hash    // Hard coded whitelist for sync challenged
+	NodeID     enode.ID                  // Local node id committed in hotstuff challenge signatures
+	Dialer     hotstuff.Dialer           // Dialer to keep the hotstuff validator mesh, nil to disable
 }
 
 type handler struct {
@@ -95,6 +102,7 @@ type handler struct {
 
 	fastSync  uint32 // Flag whether fast sync is enabled (gets disabled if we already have blocks)
 	snapSync  uint32 // Flag whether fast sync should operate on top of the snap protocol
+	epochSync uint32 // Flag whether snap sync should start with verifying the epochs
 	acceptTxs uint32 // Flag whether we're considered synchronised (enables transaction processing)
 
 	checkpointNumber uint64      // Block number for the sync progress validator to cross reference
@@ -127,7 +135,12 @@ type handler struct {
 	peerWG    sync.WaitGroup
 
 	// hotstuff
-	engine consensus.Engine
+	engine        consensus.Engine
+	nodeID        enode.ID
+	hotstuffPeers *hotstuffPeerSet
+	mesh          *hotstuff.Mesh
+	chainHeadCh   chan core.ChainHeadEvent
+	chainHeadSub  event.Subscription
 }
 
 // newHandler returns a handler for all Ethereum chain management protocol.
@@ -148,12 +161,18 @@ func newHandler(config *handlerConfig, engine consensus.Engine) (*handler, error
 		txsyncCh:   make(chan *txsync),
 		quitSync:   make(chan struct{}),
 		engine:     engine,
+
+		nodeID:        config.NodeID,
+		hotstuffPeers: newHotstuffPeerSet(),
 	}
 
 	// only for hotstuff
 	if handler, ok := h.engine.(consensus.Handler); ok {
 		handler.SetBroadcaster(h)
 	}
+	if validator, ok := h.engine.(consensus.Validator); ok && config.Dialer != nil {
+		h.mesh = hotstuff.NewMesh(config.Dialer, validator.Address())
+	}
 
 	if config.Sync == downloader.FullSync {
 		// The database seems empty as the current block is the genesis. Yet the fast
@@ -176,9 +195,16 @@ func newHandler(config *handlerConfig, engine consensus.Engine) (*handler, error
 		} else {
 			// If fast sync was requested and our database is empty, grant it
 			h.fastSync = uint32(1)
-			if config.Sync == downloader.SnapSync {
+			if config.Sync == downloader.SnapSync || config.Sync == downloader.EpochSync {
 				h.snapSync = uint32(1)
 			}
+			if config.Sync == downloader.EpochSync {
+				if _, ok := h.engine.(consensus.EpochVerifier); ok {
+					h.epochSync = uint32(1)
+				} else {
+					log.Warn("Epoch sync not supported by consensus engine, switch to snap sync")
+				}
+			}
 		}
 	}
 	// If we have trusted checkpoints, enforce them on the chain
@@ -197,6 +223,9 @@ func newHandler(config *handlerConfig, engine consensus.Engine) (*handler, error
 		h.stateBloom = trie.NewSyncBloom(config.BloomCache, config.Database)
 	}
 	h.downloader = downloader.New(h.checkpointNumber, config.Database, h.stateBloom, h.eventMux, h.chain, nil, h.removePeer)
+	if verifier, ok := h.engine.(consensus.EpochVerifier); ok && atomic.LoadUint32(&h.epochSync) == 1 {
+		h.downloader.EnableEpochSync(verifier, h.hotstuffPeers.epochPeers)
+	}
 
 	// Construct the fetcher (short sync)
 	validator := func(header *types.Header) error {
@@ -418,11 +447,22 @@ func (h *handler) Start(maxPeers int) {
 	h.wg.Add(2)
 	go h.chainSync.loop()
 	go h.txsyncLoop64() // TODO(karalabe): Legacy initial tx echange, drop with eth/64.
+
+	// keep connections among hotstuff validators
+	if h.mesh != nil {
+		h.wg.Add(1)
+		h.chainHeadCh = make(chan core.ChainHeadEvent, chainHeadChanSize)
+		h.chainHeadSub = h.chain.SubscribeChainHeadEvent(h.chainHeadCh)
+		go h.meshLoop()
+	}
 }
 
 func (h *handler) Stop() {
 	h.txsSub.Unsubscribe()        // quits txBroadcastLoop
 	h.minedBlockSub.Unsubscribe() // quits blockBroadcastLoop
+	if h.chainHeadSub != nil {
+		h.chainHeadSub.Unsubscribe() // quits meshLoop
+	}
 
 	// Quit chainSync and txsync64.
 	// After this is done, no new peers will be accepted.
@@ -544,25 +584,22 @@ func (h *handler) Enqueue(id string, block *types.Block) {
 	h.blockFetcher.Enqueue(id, block)
 }
 
+// FindPeers retrieves peers by validator addresses, only the `hotstuff` peers with
+// proved validator addresses carry the consensus messages.
 func (h *handler) FindPeers(targets map[common.Address]bool) map[common.Address]consensus.Peer {
 	m := make(map[common.Address]consensus.Peer)
-	for _, p := range h.peers.allPeers() {
-		pubKey := p.Node().Pubkey()
-		addr := crypto.PubkeyToAddress(*pubKey)
-		if targets[addr] {
+	for addr := range targets {
+		if p := h.hotstuffPeers.validator(addr); p != nil {
 			m[addr] = p
 		}
 	}
 	return m
 }
 
+// FindPeer retrieves the `hotstuff` peer with the proved validator address.
 func (h *handler) FindPeer(target common.Address) consensus.Peer {
-	for _, p := range h.peers.allPeers() {
-		pubKey := p.Node().Pubkey()
-		addr := crypto.PubkeyToAddress(*pubKey)
-		if addr == target {
-			return p
-		}
+	if p := h.hotstuffPeers.validator(target); p != nil {
+		return p
 	}
 	return nil
 }
@@ -0,0 +1,245 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package eth
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	hsb "github.com/ethereum/go-ethereum/consensus/hotstuff/backend"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/downloader"
+	"github.com/ethereum/go-ethereum/eth/protocols/hotstuff"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+)
+
+// hotstuffHandler implements the hotstuff.Backend interface to run the peers and
+// deliver the consensus messages to the engine.
+type hotstuffHandler handler
+
+// RunPeer is invoked when a peer joins on the `hotstuff` protocol.
+func (h *hotstuffHandler) RunPeer(peer *hotstuff.Peer, hand hotstuff.Handler) error {
+	return (*handler)(h).runHotstuffPeer(peer, hand)
+}
+
+// PeerInfo retrieves all known `hotstuff` information about a peer.
+func (h *hotstuffHandler) PeerInfo(id enode.ID) interface{} {
+	if p := h.hotstuffPeers.peer(id.String()); p != nil {
+		return p.Info()
+	}
+	return nil
+}
+
+// Handle is invoked from a peer's message handler when it receives a consensus
+// message, the message is handled by the engine with the proved validator address.
+// Only the undecodable messages drop the peer, the other failures are local ones,
+// e.g. the engine is not started yet, so the message is dropped instead.
+func (h *hotstuffHandler) Handle(peer *hotstuff.Peer, msg p2p.Msg) error {
+	handler, ok := h.engine.(consensus.Handler)
+	if !ok {
+		return errors.New("consensus engine can not handle messages")
+	}
+	if _, err := handler.HandleMsg(peer.Validator(), msg); err != nil {
+		if errors.Is(err, hsb.ErrDecodeFailed) {
+			return err
+		}
+		peer.Log().Debug("Hotstuff consensus message dropped", "err", err)
+	}
+	return nil
+}
+
+// EpochHeaders retrieves the local epoch change headers to serve the peers syncing
+// the epochs.
+func (h *hotstuffHandler) EpochHeaders(from uint64, limit int) []*types.Header {
+	verifier, ok := h.engine.(consensus.EpochVerifier)
+	if !ok {
+		return nil
+	}
+	return verifier.EpochChanges(h.chain, from, limit)
+}
+
+// runHotstuffPeer proves the validator identities with the peer, registers it into
+// the hotstuff peerset and starts handling consensus messages. Only the validators
+// of the current epoch are accepted.
+func (h *handler) runHotstuffPeer(peer *hotstuff.Peer, handler hotstuff.Handler) error {
+	validator, ok := h.engine.(consensus.Validator)
+	if !ok {
+		return p2p.DiscUselessPeer
+	}
+	h.peerWG.Add(1)
+	defer h.peerWG.Done()
+
+	authorize := func(addr common.Address) bool {
+		for _, v := range validator.CurrentValidators() {
+			if v == addr {
+				return true
+			}
+		}
+		return false
+	}
+	if err := peer.Handshake(h.networkID, h.chain.Genesis().Hash(), h.nodeID, validator.SignChallenge, authorize); err != nil {
+		peer.Log().Debug("Hotstuff handshake failed", "err", err)
+		return err
+	}
+	if h.mesh != nil {
+		h.mesh.Learn(peer.Validator(), peer.DialNode())
+	}
+	peer.Log().Debug("Hotstuff peer connected", "validator", peer.Validator())
+
+	if err := h.hotstuffPeers.register(peer); err != nil {
+		peer.Log().Error("Hotstuff peer registration failed", "err", err)
+		return err
+	}
+	defer h.hotstuffPeers.unregister(peer.ID())
+
+	return handler(peer)
+}
+
+// meshLoop updates the validator mesh with the validators of current epoch on each
+// chain head, and disconnects the hotstuff peers which are not validators anymore.
+func (h *handler) meshLoop() {
+	defer h.wg.Done()
+
+	validator := h.engine.(consensus.Validator)
+	update := func() {
+		validators := validator.CurrentValidators()
+		h.mesh.Update(validators)
+		for _, peer := range h.hotstuffPeers.stale(validators) {
+			peer.Log().Debug("Hotstuff peer is not a validator anymore", "validator", peer.Validator())
+			peer.Disconnect(p2p.DiscUselessPeer)
+		}
+	}
+	update()
+	for {
+		select {
+		case <-h.chainHeadCh:
+			update()
+		case <-h.chainHeadSub.Err():
+			return
+		}
+	}
+}
+
+var errHotstuffPeerAlreadyRegistered = errors.New("hotstuff peer already registered")
+
+// hotstuffPeerSet represents the collection of active peers participating in the
+// `hotstuff` protocol, indexed by both peer id and validator address.
+type hotstuffPeerSet struct {
+	peers      map[string]*hotstuff.Peer
+	validators map[common.Address]*hotstuff.Peer
+	lock       sync.RWMutex
+}
+
+func newHotstuffPeerSet() *hotstuffPeerSet {
+	return &hotstuffPeerSet{
+		peers:      make(map[string]*hotstuff.Peer),
+		validators: make(map[common.Address]*hotstuff.Peer),
+	}
+}
+
+// register injects a new peer into the working set, the peer replaces the one
+// previously connected with the same validator address.
+func (ps *hotstuffPeerSet) register(peer *hotstuff.Peer) error {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+
+	if _, ok := ps.peers[peer.ID()]; ok {
+		return errHotstuffPeerAlreadyRegistered
+	}
+	ps.peers[peer.ID()] = peer
+	if old, ok := ps.validators[peer.Validator()]; ok {
+		log.Debug("Hotstuff validator reconnected", "validator", peer.Validator(), "old", old.ID(), "new", peer.ID())
+	}
+	ps.validators[peer.Validator()] = peer
+	return nil
+}
+
+// unregister removes a remote peer from the working set.
+func (ps *hotstuffPeerSet) unregister(id string) {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+
+	peer, ok := ps.peers[id]
+	if !ok {
+		return
+	}
+	delete(ps.peers, id)
+	if ps.validators[peer.Validator()] == peer {
+		delete(ps.validators, peer.Validator())
+	}
+}
+
+// peer retrieves the registered peer with the given id.
+func (ps *hotstuffPeerSet) peer(id string) *hotstuff.Peer {
+	ps.lock.RLock()
+	defer ps.lock.RUnlock()
+
+	return ps.peers[id]
+}
+
+// validator retrieves the registered peer proved the validator address.
+func (ps *hotstuffPeerSet) validator(addr common.Address) *hotstuff.Peer {
+	ps.lock.RLock()
+	defer ps.lock.RUnlock()
+
+	return ps.validators[addr]
+}
+
+// stale retrieves the registered peers proved a validator address outside of the
+// validators, they were admitted by the validators of an earlier epoch.
+func (ps *hotstuffPeerSet) stale(validators []common.Address) []*hotstuff.Peer {
+	ps.lock.RLock()
+	defer ps.lock.RUnlock()
+
+	current := make(map[common.Address]struct{}, len(validators))
+	for _, v := range validators {
+		current[v] = struct{}{}
+	}
+	var list []*hotstuff.Peer
+	for _, p := range ps.peers {
+		if _, ok := current[p.Validator()]; !ok {
+			list = append(list, p)
+		}
+	}
+	return list
+}
+
+// epochPeers retrieves all the registered peers to sync the epochs from.
+func (ps *hotstuffPeerSet) epochPeers() []downloader.EpochPeer {
+	ps.lock.RLock()
+	defer ps.lock.RUnlock()
+
+	list := make([]downloader.EpochPeer, 0, len(ps.peers))
+	for _, p := range ps.peers {
+		list = append(list, p)
+	}
+	return list
+}
+
+// len returns if the current number of `hotstuff` peers in the set.
+func (ps *hotstuffPeerSet) len() int {
+	ps.lock.RLock()
+	defer ps.lock.RUnlock()
+
+	return len(ps.peers)
+}
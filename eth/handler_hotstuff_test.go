@@ -0,0 +1,81 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package eth
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	hsb "github.com/ethereum/go-ethereum/consensus/hotstuff/backend"
+	"github.com/ethereum/go-ethereum/eth/protocols/hotstuff"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+)
+
+// testConsensusHandler is the engine failing the consensus messages with err.
+type testConsensusHandler struct {
+	consensus.Engine
+	consensus.Handler
+	err error
+}
+
+func (e *testConsensusHandler) HandleMsg(common.Address, p2p.Msg) (bool, error) {
+	return true, e.err
+}
+
+// Tests that only the undecodable consensus messages drop the `hotstuff` peer.
+func TestHotstuffHandleErrors(t *testing.T) {
+	app, net := p2p.MsgPipe()
+	defer app.Close()
+	defer net.Close()
+	peer := hotstuff.NewPeer(hotstuff.HOTSTUFF1, p2p.NewPeer(enode.ID{1}, "peer", nil), net)
+
+	tests := []struct {
+		err  error
+		drop bool
+	}{
+		{nil, false},
+		{hsb.ErrStoppedEngine, false},
+		{errors.New("local failure"), false},
+		{hsb.ErrDecodeFailed, true},
+	}
+	for i, test := range tests {
+		h := &hotstuffHandler{engine: &testConsensusHandler{err: test.err}}
+		if err := h.Handle(peer, p2p.Msg{}); (err != nil) != test.drop {
+			t.Errorf("test %d: engine error %v, handle error %v, want drop %v", i, test.err, err, test.drop)
+		}
+	}
+}
+
+// Tests that the peers proved an address outside of the current validators are stale.
+func TestHotstuffStalePeers(t *testing.T) {
+	ps := newHotstuffPeerSet()
+	peer := hotstuff.NewPeer(hotstuff.HOTSTUFF1, p2p.NewPeer(enode.ID{1}, "peer", nil), nil)
+	if err := ps.register(peer); err != nil {
+		t.Fatalf("failed to register peer: %v", err)
+	}
+	if stale := ps.stale([]common.Address{peer.Validator()}); len(stale) != 0 {
+		t.Errorf("validator peer is stale: %v", stale)
+	}
+	if stale := ps.stale([]common.Address{common.HexToAddress("0x1")}); len(stale) != 1 || stale[0] != peer {
+		t.Errorf("stale peers mismatch: have %v, want %v", stale, peer)
+	}
+}
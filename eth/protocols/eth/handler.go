@@ -224,13 +224,18 @@ func handleMessage(backend Backend, peer *Peer) error {
 	}
 	defer msg.Discard()
 
-	// mark: hotstuff only
-	if handler, ok := backend.Engine().(consensus.Handler); ok {
-		pubKey := peer.Node().Pubkey()
-		addr := crypto.PubkeyToAddress(*pubKey)
-		handled, err := handler.HandleMsg(addr, msg)
-		if handled {
-			return err
+	if msg.Code == ConsensusMsg {
+		return fmt.Errorf("%w: consensus message over %s", errInvalidMsgCode, ProtocolName)
+	}
+	// mark: hotstuff only, the proposer ignores the block it is proposing propagated by other nodes
+	if msg.Code == NewBlockMsg {
+		if handler, ok := backend.Engine().(consensus.Handler); ok {
+			pubKey := peer.Node().Pubkey()
+			addr := crypto.PubkeyToAddress(*pubKey)
+			handled, err := handler.HandleMsg(addr, msg)
+			if handled {
+				return err
+			}
 		}
 	}
 
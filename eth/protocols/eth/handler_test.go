@@ -17,13 +17,14 @@
 package eth
 
 import (
-	"github.com/ethereum/go-ethereum/consensus"
+	"errors"
 	"math"
 	"math/big"
 	"math/rand"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
@@ -46,6 +47,14 @@ var (
 	testAddr = crypto.PubkeyToAddress(testKey.PublicKey)
 )
 
+// The genesis hooks are installed by the governance contracts, which are not imported
+// by the protocol tests. Install no-op ones before the test chains are generated.
+var _ = func() bool {
+	core.RegGenesis = func(db *state.StateDB, data core.GenesisAlloc) error { return nil }
+	core.StoreGenesis = func(db ethdb.Database, header *types.Header) error { return nil }
+	return true
+}()
+
 // testBackend is a mock implementation of the live Ethereum message handler. Its
 // purpose is to allow testing the request/reply workflows and wire serialization
 // in the `eth` protocol without actually doing any data processing.
@@ -588,3 +597,18 @@ func testGetBlockReceipts(t *testing.T, protocol uint) {
 		}
 	}
 }
+
+// Tests that consensus messages over `eth` drop the peer, they are only accepted over
+// the `hotstuff` protocol.
+func TestConsensusMsgRejected(t *testing.T) {
+	backend := newTestBackend(0)
+	defer backend.close()
+
+	peer, errc := newTestPeer("peer", HOTSTUFF, backend)
+	defer peer.close()
+
+	go p2p.Send(peer.app, ConsensusMsg, []byte{0x01})
+	if err := <-errc; !errors.Is(err, errInvalidMsgCode) {
+		t.Fatalf("wrong error: got %q, want %q", err, errInvalidMsgCode)
+	}
+}
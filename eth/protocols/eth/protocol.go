@@ -70,6 +70,10 @@ const (
 	NewPooledTransactionHashesMsg = 0x08
 	GetPooledTransactionsMsg      = 0x09
 	PooledTransactionsMsg         = 0x0a
+
+	// ConsensusMsg is reserved for the consensus messages, which are only accepted
+	// over the `hotstuff` protocol with the proved validator address.
+	ConsensusMsg = 0x11
 )
 
 var (
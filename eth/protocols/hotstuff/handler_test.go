@@ -0,0 +1,89 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package hotstuff
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+)
+
+// testBackend serves the epoch change headers at the given numbers.
+type testBackend struct {
+	epochs []*types.Header
+}
+
+func (b *testBackend) RunPeer(peer *Peer, handler Handler) error { return handler(peer) }
+func (b *testBackend) PeerInfo(id enode.ID) interface{}          { return nil }
+func (b *testBackend) Handle(peer *Peer, msg p2p.Msg) error {
+	return errors.New("unexpected consensus message")
+}
+
+func (b *testBackend) EpochHeaders(from uint64, limit int) []*types.Header {
+	var headers []*types.Header
+	for _, header := range b.epochs {
+		if header.Number.Uint64() >= from && len(headers) < limit {
+			headers = append(headers, header)
+		}
+	}
+	return headers
+}
+
+func TestEpochHeaders(t *testing.T) {
+	backend := new(testBackend)
+	for _, number := range []int64{9, 19, 29} {
+		backend.epochs = append(backend.epochs, &types.Header{Number: big.NewInt(number)})
+	}
+	app, net := p2p.MsgPipe()
+	defer app.Close()
+	defer net.Close()
+
+	client := NewPeer(HOTSTUFF1, p2p.NewPeer(enode.ID{2}, "server", nil), app)
+	server := NewPeer(HOTSTUFF1, p2p.NewPeer(enode.ID{1}, "client", nil), net)
+	go Handle(backend, client)
+	go Handle(backend, server)
+
+	tests := []struct {
+		from uint64
+		want []uint64
+	}{
+		{0, []uint64{9, 19, 29}},
+		{10, []uint64{19, 29}},
+		{29, []uint64{29}},
+		{30, nil},
+	}
+	for i, tt := range tests {
+		headers, err := client.RequestEpochHeaders(tt.from)
+		if err != nil {
+			t.Fatalf("test %d: request failed: %v", i, err)
+		}
+		if len(headers) != len(tt.want) {
+			t.Fatalf("test %d: headers mismatch: have %d, want %d", i, len(headers), len(tt.want))
+		}
+		for j, header := range headers {
+			if header.Number.Uint64() != tt.want[j] {
+				t.Errorf("test %d: header %d mismatch: have %d, want %d", i, j, header.Number, tt.want[j])
+			}
+		}
+	}
+}
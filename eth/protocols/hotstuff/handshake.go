@@ -0,0 +1,173 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package hotstuff
+
+import (
+	"crypto/rand"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+)
+
+const (
+	// handshakeTimeout is the maximum allowed time for the `hotstuff` handshake to
+	// complete before dropping the connection as malicious.
+	handshakeTimeout = 5 * time.Second
+)
+
+// SignFn signs the keccak256 hash of data with the validator key.
+type SignFn func(data []byte) ([]byte, error)
+
+// AuthorizeFn reports whether the proved validator address is allowed to join.
+type AuthorizeFn func(validator common.Address) bool
+
+// challengeData returns the data signed for the challenge. The node id of signer is
+// committed as well, so a signature can not be relayed by another node which happens
+// to connect with the challenger.
+func challengeData(challenge common.Hash, signer enode.ID) []byte {
+	data := make([]byte, 0, len(ProtocolName)+common.HashLength+len(signer))
+	data = append(data, ProtocolName...)
+	data = append(data, challenge.Bytes()...)
+	return append(data, signer.Bytes()...)
+}
+
+// Handshake executes the hotstuff protocol handshake, negotiating version number,
+// network IDs and genesis blocks, then both sides prove their validator address
+// by signing the challenge of the other side, the peer is rejected unless the
+// proved address is authorized.
+func (p *Peer) Handshake(network uint64, genesis common.Hash, self enode.ID, sign SignFn, authorize AuthorizeFn) error {
+	var challenge common.Hash
+	if _, err := rand.Read(challenge[:]); err != nil {
+		return err
+	}
+	var status StatusPacket // safe to read after two values have been received from errc
+	if err := p.exchange(func() error {
+		return p2p.Send(p.rw, StatusMsg, &StatusPacket{
+			ProtocolVersion: uint32(p.version),
+			NetworkID:       network,
+			Genesis:         genesis,
+			Challenge:       challenge,
+		})
+	}, func() error {
+		return p.readStatus(network, &status, genesis)
+	}); err != nil {
+		return err
+	}
+
+	sig, err := sign(challengeData(status.Challenge, self))
+	if err != nil {
+		return err
+	}
+	var auth AuthPacket
+	if err := p.exchange(func() error {
+		return p2p.Send(p.rw, AuthMsg, &AuthPacket{Signature: sig})
+	}, func() error {
+		return p.readAuth(&auth)
+	}); err != nil {
+		return err
+	}
+	hash := crypto.Keccak256(challengeData(challenge, p.Peer.ID()))
+	pubkey, err := crypto.SigToPub(hash, auth.Signature)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errInvalidSignature, err)
+	}
+	validator := crypto.PubkeyToAddress(*pubkey)
+	if !authorize(validator) {
+		return fmt.Errorf("%w: %x", errUnauthorizedValidator, validator)
+	}
+	p.validator = validator
+	return nil
+}
+
+// exchange runs the send and read in parallel and waits for both of them.
+func (p *Peer) exchange(send, read func() error) error {
+	errc := make(chan error, 2)
+	go func() {
+		errc <- send()
+	}()
+	go func() {
+		errc <- read()
+	}()
+	timeout := time.NewTimer(handshakeTimeout)
+	defer timeout.Stop()
+	for i := 0; i < 2; i++ {
+		select {
+		case err := <-errc:
+			if err != nil {
+				return err
+			}
+		case <-timeout.C:
+			return p2p.DiscReadTimeout
+		}
+	}
+	return nil
+}
+
+// readStatus reads the remote handshake message.
+func (p *Peer) readStatus(network uint64, status *StatusPacket, genesis common.Hash) error {
+	msg, err := p.rw.ReadMsg()
+	if err != nil {
+		return err
+	}
+	defer msg.Discard()
+
+	if msg.Code != StatusMsg {
+		return fmt.Errorf("%w: first msg has code %x (!= %x)", errNoStatusMsg, msg.Code, StatusMsg)
+	}
+	if msg.Size > maxHandshakeSize {
+		return fmt.Errorf("%w: %v > %v", errMsgTooLarge, msg.Size, maxHandshakeSize)
+	}
+	if err := msg.Decode(&status); err != nil {
+		return fmt.Errorf("%w: message %v: %v", errDecode, msg, err)
+	}
+	if status.NetworkID != network {
+		return fmt.Errorf("%w: %d (!= %d)", errNetworkIDMismatch, status.NetworkID, network)
+	}
+	if uint(status.ProtocolVersion) != p.version {
+		return fmt.Errorf("%w: %d (!= %d)", errProtocolVersionMismatch, status.ProtocolVersion, p.version)
+	}
+	if status.Genesis != genesis {
+		return fmt.Errorf("%w: %x (!= %x)", errGenesisMismatch, status.Genesis, genesis)
+	}
+	return nil
+}
+
+// readAuth reads the remote signature of the challenge.
+func (p *Peer) readAuth(auth *AuthPacket) error {
+	msg, err := p.rw.ReadMsg()
+	if err != nil {
+		return err
+	}
+	defer msg.Discard()
+
+	if msg.Code != AuthMsg {
+		return fmt.Errorf("%w: second msg has code %x (!= %x)", errNoAuthMsg, msg.Code, AuthMsg)
+	}
+	if msg.Size > maxHandshakeSize {
+		return fmt.Errorf("%w: %v > %v", errMsgTooLarge, msg.Size, maxHandshakeSize)
+	}
+	if err := msg.Decode(auth); err != nil {
+		return fmt.Errorf("%w: message %v: %v", errDecode, msg, err)
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package hotstuff
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+)
+
+func signFn(key *ecdsa.PrivateKey) SignFn {
+	return func(data []byte) ([]byte, error) {
+		return crypto.Sign(crypto.Keccak256(data), key)
+	}
+}
+
+func authorizeAll(common.Address) bool { return true }
+
+// shake runs the handshake between two peers and returns the peers seen by each side.
+func shake(t *testing.T, genesis [2]common.Hash, keys [2]*ecdsa.PrivateKey, ids, selves [2]enode.ID, authorize [2]AuthorizeFn) ([2]*Peer, [2]error) {
+	app, net := p2p.MsgPipe()
+	t.Cleanup(func() {
+		app.Close()
+		net.Close()
+	})
+	peers := [2]*Peer{
+		NewPeer(HOTSTUFF1, p2p.NewPeer(ids[1], "b", nil), app),
+		NewPeer(HOTSTUFF1, p2p.NewPeer(ids[0], "a", nil), net),
+	}
+	var errs [2]error
+	errc := make(chan struct{}, 2)
+	for i := 0; i < 2; i++ {
+		go func(i int) {
+			errs[i] = peers[i].Handshake(1, genesis[i], selves[i], signFn(keys[i]), authorize[i])
+			if errs[i] != nil {
+				// unblock the other side waiting for messages
+				peers[i].rw.(*p2p.MsgPipeRW).Close()
+			}
+			errc <- struct{}{}
+		}(i)
+	}
+	<-errc
+	<-errc
+	return peers, errs
+}
+
+func TestHandshake(t *testing.T) {
+	keyA, _ := crypto.GenerateKey()
+	keyB, _ := crypto.GenerateKey()
+	ids := [2]enode.ID{{1}, {2}}
+	genesis := [2]common.Hash{{1}, {1}}
+
+	peers, errs := shake(t, genesis, [2]*ecdsa.PrivateKey{keyA, keyB}, ids, ids, [2]AuthorizeFn{authorizeAll, authorizeAll})
+	if errs[0] != nil || errs[1] != nil {
+		t.Fatalf("handshake failed: %v, %v", errs[0], errs[1])
+	}
+	if have, want := peers[0].Validator(), crypto.PubkeyToAddress(keyB.PublicKey); have != want {
+		t.Errorf("validator mismatch: have %x, want %x", have, want)
+	}
+	if have, want := peers[1].Validator(), crypto.PubkeyToAddress(keyA.PublicKey); have != want {
+		t.Errorf("validator mismatch: have %x, want %x", have, want)
+	}
+}
+
+// Tests that the peer proving an address out of the validator set is rejected.
+func TestHandshakeUnauthorized(t *testing.T) {
+	keyA, _ := crypto.GenerateKey()
+	keyB, _ := crypto.GenerateKey()
+	ids := [2]enode.ID{{1}, {2}}
+	genesis := [2]common.Hash{{1}, {1}}
+
+	// a only accepts itself, b accepts both
+	validatorA := crypto.PubkeyToAddress(keyA.PublicKey)
+	onlyA := func(addr common.Address) bool { return addr == validatorA }
+	_, errs := shake(t, genesis, [2]*ecdsa.PrivateKey{keyA, keyB}, ids, ids, [2]AuthorizeFn{onlyA, authorizeAll})
+	if !errors.Is(errs[0], errUnauthorizedValidator) {
+		t.Errorf("wrong error: got %q, want %q", errs[0], errUnauthorizedValidator)
+	}
+}
+
+// Tests that the signature bound to another node does not prove the validator address.
+func TestHandshakeRelayed(t *testing.T) {
+	keyA, _ := crypto.GenerateKey()
+	keyB, _ := crypto.GenerateKey()
+	ids := [2]enode.ID{{1}, {2}}
+	genesis := [2]common.Hash{{1}, {1}}
+
+	// b signs the challenge as node 3, which is how a relayed signature looks like
+	peers, errs := shake(t, genesis, [2]*ecdsa.PrivateKey{keyA, keyB}, ids, [2]enode.ID{{1}, {3}}, [2]AuthorizeFn{authorizeAll, authorizeAll})
+	if errs[0] != nil || errs[1] != nil {
+		t.Fatalf("handshake failed: %v, %v", errs[0], errs[1])
+	}
+	if peers[0].Validator() == crypto.PubkeyToAddress(keyB.PublicKey) {
+		t.Errorf("relayed signature proved validator %x", peers[0].Validator())
+	}
+}
+
+// Tests that handshake failures are detected and reported correctly.
+func TestHandshakeFailures(t *testing.T) {
+	genesis := common.Hash{1}
+	tests := []struct {
+		code uint64
+		data interface{}
+		want error
+	}{
+		{
+			code: ConsensusMsg, data: []byte{},
+			want: errNoStatusMsg,
+		},
+		{
+			code: StatusMsg, data: StatusPacket{10, 1, genesis, common.Hash{}},
+			want: errProtocolVersionMismatch,
+		},
+		{
+			code: StatusMsg, data: StatusPacket{HOTSTUFF1, 999, genesis, common.Hash{}},
+			want: errNetworkIDMismatch,
+		},
+		{
+			code: StatusMsg, data: StatusPacket{HOTSTUFF1, 1, common.Hash{3}, common.Hash{}},
+			want: errGenesisMismatch,
+		},
+		{
+			code: StatusMsg, data: make([]byte, maxHandshakeSize+1),
+			want: errMsgTooLarge,
+		},
+	}
+	key, _ := crypto.GenerateKey()
+	for i, test := range tests {
+		app, net := p2p.MsgPipe()
+		defer app.Close()
+		defer net.Close()
+
+		peer := NewPeer(HOTSTUFF1, p2p.NewPeer(enode.ID{}, "peer", nil), net)
+		go p2p.Send(app, test.code, test.data)
+		go func() {
+			// drain the outbound status of local peer
+			for {
+				msg, err := app.ReadMsg()
+				if err != nil {
+					return
+				}
+				msg.Discard()
+			}
+		}()
+		if err := peer.Handshake(1, genesis, enode.ID{}, signFn(key), authorizeAll); !errors.Is(err, test.want) {
+			t.Errorf("test %d: wrong error: got %q, want %q", i, err, test.want)
+		}
+	}
+}
+
+// Tests that the auth message is required after the status.
+func TestHandshakeNoAuth(t *testing.T) {
+	genesis := common.Hash{1}
+	key, _ := crypto.GenerateKey()
+
+	app, net := p2p.MsgPipe()
+	defer app.Close()
+	defer net.Close()
+
+	peer := NewPeer(HOTSTUFF1, p2p.NewPeer(enode.ID{}, "peer", nil), net)
+	go func() {
+		p2p.Send(app, StatusMsg, &StatusPacket{HOTSTUFF1, 1, genesis, common.Hash{}})
+		p2p.Send(app, ConsensusMsg, []byte{})
+	}()
+	go func() {
+		for {
+			msg, err := app.ReadMsg()
+			if err != nil {
+				return
+			}
+			msg.Discard()
+		}
+	}()
+	if err := peer.Handshake(1, genesis, enode.ID{}, signFn(key), authorizeAll); !errors.Is(err, errNoAuthMsg) {
+		t.Errorf("wrong error: got %q, want %q", err, errNoAuthMsg)
+	}
+}
@@ -0,0 +1,143 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package hotstuff
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+)
+
+// Dialer is the subset of p2p.Server methods used to maintain the validator mesh.
+type Dialer interface {
+	AddPeer(node *enode.Node)
+	RemovePeer(node *enode.Node)
+	AddTrustedPeer(node *enode.Node)
+	RemoveTrustedPeer(node *enode.Node)
+}
+
+// Mesh keeps the connections among the validators of current epoch. The nodes of
+// validators are learnt from the peers which proved their validator address in the
+// handshake, and those of current validators are added as static and trusted peers,
+// so they are dialed prior to others and take connection slots reserved beyond the
+// max peers limit.
+type Mesh struct {
+	dialer Dialer
+	self   common.Address
+
+	nodes      map[common.Address]*enode.Node // Nodes of validators learnt from peers
+	validators map[common.Address]struct{}    // Validators of current epoch
+	reserved   map[common.Address]*enode.Node // Nodes added into the dialer
+
+	lock sync.Mutex
+}
+
+// NewMesh creates a validator mesh over the dialer, the local validator is excluded.
+func NewMesh(dialer Dialer, self common.Address) *Mesh {
+	return &Mesh{
+		dialer:     dialer,
+		self:       self,
+		nodes:      make(map[common.Address]*enode.Node),
+		validators: make(map[common.Address]struct{}),
+		reserved:   make(map[common.Address]*enode.Node),
+	}
+}
+
+// Learn records the node of validator, the node replaces the known one if the
+// validator moves to another node.
+func (m *Mesh) Learn(validator common.Address, node *enode.Node) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if old, ok := m.nodes[validator]; ok && old.ID() == node.ID() && (old.IP() != nil || node.IP() == nil) {
+		return
+	}
+	m.nodes[validator] = node
+	m.refresh()
+}
+
+// Update sets the validators of current epoch.
+func (m *Mesh) Update(validators []common.Address) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	changed := len(validators) != len(m.validators)
+	set := make(map[common.Address]struct{}, len(validators))
+	for _, val := range validators {
+		if _, ok := m.validators[val]; !ok {
+			changed = true
+		}
+		set[val] = struct{}{}
+	}
+	if !changed {
+		return
+	}
+	m.validators = set
+	m.refresh()
+}
+
+// IsValidator returns whether the address is a validator of current epoch.
+func (m *Mesh) IsValidator(addr common.Address) bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	_, ok := m.validators[addr]
+	return ok
+}
+
+// Reserved returns the number of validator nodes added into the dialer.
+func (m *Mesh) Reserved() int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return len(m.reserved)
+}
+
+// refresh reconciles the nodes in dialer with the current validators, the caller
+// should hold the lock.
+func (m *Mesh) refresh() {
+	for val, node := range m.reserved {
+		_, ok := m.validators[val]
+		if ok && m.nodes[val] == node {
+			continue
+		}
+		m.dialer.RemoveTrustedPeer(node)
+		m.dialer.RemovePeer(node)
+		delete(m.reserved, val)
+		log.Debug("Released validator node", "validator", val, "node", node.ID())
+	}
+	for val := range m.validators {
+		if val == m.self {
+			continue
+		}
+		node, ok := m.nodes[val]
+		if !ok {
+			continue
+		}
+		if _, ok := m.reserved[val]; ok {
+			continue
+		}
+		m.dialer.AddTrustedPeer(node)
+		m.dialer.AddPeer(node)
+		m.reserved[val] = node
+		log.Debug("Reserved validator node", "validator", val, "node", node.ID())
+	}
+}
@@ -0,0 +1,92 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package hotstuff
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+)
+
+type testDialer struct {
+	static  map[enode.ID]bool
+	trusted map[enode.ID]bool
+}
+
+func newTestDialer() *testDialer {
+	return &testDialer{static: make(map[enode.ID]bool), trusted: make(map[enode.ID]bool)}
+}
+
+func (d *testDialer) AddPeer(node *enode.Node)           { d.static[node.ID()] = true }
+func (d *testDialer) RemovePeer(node *enode.Node)        { delete(d.static, node.ID()) }
+func (d *testDialer) AddTrustedPeer(node *enode.Node)    { d.trusted[node.ID()] = true }
+func (d *testDialer) RemoveTrustedPeer(node *enode.Node) { delete(d.trusted, node.ID()) }
+
+func (d *testDialer) reserved(node *enode.Node) bool {
+	return d.static[node.ID()] && d.trusted[node.ID()]
+}
+
+func newTestNode() *enode.Node {
+	key, _ := crypto.GenerateKey()
+	return enode.NewV4(&key.PublicKey, nil, 0, 0)
+}
+
+func TestMesh(t *testing.T) {
+	var (
+		self   = common.Address{1}
+		valA   = common.Address{2}
+		valB   = common.Address{3}
+		valC   = common.Address{4}
+		nodeA  = newTestNode()
+		nodeB  = newTestNode()
+		nodeC  = newTestNode()
+		dialer = newTestDialer()
+		mesh   = NewMesh(dialer, self)
+	)
+	mesh.Update([]common.Address{self, valA, valB})
+	if mesh.Reserved() != 0 {
+		t.Fatalf("unknown validator nodes reserved")
+	}
+
+	// nodes are reserved once learnt, and only for validators of current epoch
+	mesh.Learn(self, newTestNode())
+	mesh.Learn(valA, nodeA)
+	mesh.Learn(valC, nodeC)
+	if !dialer.reserved(nodeA) || dialer.reserved(nodeC) || mesh.Reserved() != 1 {
+		t.Fatalf("unexpected reserved nodes %d", mesh.Reserved())
+	}
+
+	// validator moves to another node
+	mesh.Learn(valB, nodeC)
+	mesh.Learn(valB, nodeB)
+	if !dialer.reserved(nodeB) || dialer.reserved(nodeC) || mesh.Reserved() != 2 {
+		t.Fatalf("unexpected reserved nodes %d", mesh.Reserved())
+	}
+
+	// epoch changed, the node learnt before is reserved for the new validator
+	mesh.Update([]common.Address{self, valB, valC})
+	if dialer.reserved(nodeA) || !dialer.reserved(nodeB) || !dialer.reserved(nodeC) || mesh.Reserved() != 2 {
+		t.Fatalf("unexpected reserved nodes %d", mesh.Reserved())
+	}
+	if mesh.IsValidator(valA) || !mesh.IsValidator(valC) {
+		t.Fatalf("unexpected validators")
+	}
+}
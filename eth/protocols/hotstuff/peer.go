@@ -0,0 +1,166 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package hotstuff
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+)
+
+const (
+	// requestTimeout is the maximum allowed time for the remote peer to reply a
+	// request.
+	requestTimeout = 10 * time.Second
+)
+
+// Peer is a collection of relevant information we have about a `hotstuff` peer.
+type Peer struct {
+	id string // Unique ID for the peer, cached
+
+	*p2p.Peer                   // The embedded P2P package peer
+	rw        p2p.MsgReadWriter // Input/output streams for hotstuff
+	version   uint              // Protocol version negotiated
+	validator common.Address    // Validator address proved in the handshake
+
+	pending map[uint64]chan []*types.Header // Pending requests waiting for the reply
+	lock    sync.Mutex                      // Mutex protecting the pending requests
+
+	logger log.Logger // Contextual logger with the peer id injected
+}
+
+// NewPeer create a wrapper for a network connection and negotiated protocol version.
+func NewPeer(version uint, p *p2p.Peer, rw p2p.MsgReadWriter) *Peer {
+	id := p.ID().String()
+	return &Peer{
+		id:      id,
+		Peer:    p,
+		rw:      rw,
+		version: version,
+		pending: make(map[uint64]chan []*types.Header),
+		logger:  log.New("peer", id[:8]),
+	}
+}
+
+// ID retrieves the peer's unique identifier.
+func (p *Peer) ID() string {
+	return p.id
+}
+
+// Version retrieves the peer's negoatiated `hotstuff` protocol version.
+func (p *Peer) Version() uint {
+	return p.version
+}
+
+// Validator retrieves the validator address proved by the peer in the handshake.
+func (p *Peer) Validator() common.Address {
+	return p.validator
+}
+
+// Log overrides the P2P logget with the higher level one containing only the id.
+func (p *Peer) Log() log.Logger {
+	return p.logger
+}
+
+// DialNode returns the node to redial the peer. The tcp endpoint of inbound peer is
+// not the listening one, so it should be resolved through discovery.
+func (p *Peer) DialNode() *enode.Node {
+	if p.Inbound() {
+		return enode.NewV4(p.Node().Pubkey(), nil, 0, 0)
+	}
+	return p.Node()
+}
+
+// Send implements consensus.Peer, the engine message code is replaced by ConsensusMsg
+// as the engine only sends consensus messages.
+func (p *Peer) Send(msgcode uint64, data interface{}) error {
+	return p2p.Send(p.rw, ConsensusMsg, data)
+}
+
+// RequestEpochHeaders fetches the epoch change headers starting at the given block
+// number, and waits for the reply of remote peer.
+func (p *Peer) RequestEpochHeaders(from uint64) ([]*types.Header, error) {
+	id := rand.Uint64()
+	resCh := make(chan []*types.Header, 1)
+
+	p.lock.Lock()
+	p.pending[id] = resCh
+	p.lock.Unlock()
+
+	defer func() {
+		p.lock.Lock()
+		delete(p.pending, id)
+		p.lock.Unlock()
+	}()
+
+	p.Log().Debug("Fetching epoch headers", "from", from)
+	if err := p2p.Send(p.rw, GetEpochHeadersMsg, &GetEpochHeadersPacket{RequestId: id, From: from}); err != nil {
+		return nil, err
+	}
+	timeout := time.NewTimer(requestTimeout)
+	defer timeout.Stop()
+
+	select {
+	case headers := <-resCh:
+		return headers, nil
+	case <-timeout.C:
+		return nil, errRequestTimeout
+	}
+}
+
+// ReplyEpochHeaders is the reply to GetEpochHeaders.
+func (p *Peer) ReplyEpochHeaders(id uint64, headers []*types.Header) error {
+	return p2p.Send(p.rw, EpochHeadersMsg, &EpochHeadersPacket{RequestId: id, Headers: headers})
+}
+
+// deliverEpochHeaders hands the reply over to the pending request, replies arrived
+// after the request timed out are dropped.
+func (p *Peer) deliverEpochHeaders(packet *EpochHeadersPacket) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	resCh, ok := p.pending[packet.RequestId]
+	if !ok {
+		p.Log().Debug("Dropping unrequested epoch headers", "id", packet.RequestId)
+		return
+	}
+	delete(p.pending, packet.RequestId)
+	resCh <- packet.Headers
+}
+
+// PeerInfo represents a short summary of the `hotstuff` sub-protocol metadata known
+// about a connected peer.
+type PeerInfo struct {
+	Version   uint           `json:"version"`   // Hotstuff protocol version negotiated
+	Validator common.Address `json:"validator"` // Validator address proved by the peer
+}
+
+// Info gathers and returns some `hotstuff` protocol metadata known about a peer.
+func (p *Peer) Info() *PeerInfo {
+	return &PeerInfo{
+		Version:   p.version,
+		Validator: p.validator,
+	}
+}
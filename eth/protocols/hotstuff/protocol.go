@@ -0,0 +1,110 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package hotstuff
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Constants to match up protocol versions and messages
+const (
+	HOTSTUFF1 = 1
+)
+
+// ProtocolName is the official short name of the `hotstuff` protocol used during
+// devp2p capability negotiation.
+const ProtocolName = "hotstuff"
+
+// ProtocolVersions are the supported versions of the `hotstuff` protocol (first
+// is primary).
+var ProtocolVersions = []uint{HOTSTUFF1}
+
+// protocolLengths are the number of implemented message corresponding to
+// different protocol versions.
+var protocolLengths = map[uint]uint64{HOTSTUFF1: 5}
+
+const (
+	// maxMessageSize is the maximum cap on the size of a consensus message, which
+	// may carry a whole proposed block.
+	maxMessageSize = 10 * 1024 * 1024
+
+	// maxHandshakeSize is the maximum cap on the size of status and auth messages.
+	maxHandshakeSize = 1024
+
+	// maxEpochHeadersServe is the maximum number of epoch change headers to serve
+	// in a single reply.
+	maxEpochHeadersServe = 128
+)
+
+const (
+	StatusMsg          = 0x00
+	AuthMsg            = 0x01
+	ConsensusMsg       = 0x02
+	GetEpochHeadersMsg = 0x03
+	EpochHeadersMsg    = 0x04
+)
+
+// engineMsg is the message code expected by the hotstuff engine in consensus.Handler,
+// consensus messages are translated from and into it at the protocol boundary.
+const engineMsg = 0x11
+
+var (
+	errNoStatusMsg             = errors.New("no status message")
+	errNoAuthMsg               = errors.New("no auth message")
+	errMsgTooLarge             = errors.New("message too long")
+	errDecode                  = errors.New("invalid message")
+	errInvalidMsgCode          = errors.New("invalid message code")
+	errProtocolVersionMismatch = errors.New("protocol version mismatch")
+	errNetworkIDMismatch       = errors.New("network ID mismatch")
+	errGenesisMismatch         = errors.New("genesis mismatch")
+	errInvalidSignature        = errors.New("invalid challenge signature")
+	errUnauthorizedValidator   = errors.New("unauthorized validator")
+	errRequestTimeout          = errors.New("request timed out")
+)
+
+// StatusPacket is the network packet for the status message, the challenge should
+// be signed by the remote peer with its validator key.
+type StatusPacket struct {
+	ProtocolVersion uint32
+	NetworkID       uint64
+	Genesis         common.Hash
+	Challenge       common.Hash
+}
+
+// AuthPacket is the network packet proving the validator address of the sender.
+type AuthPacket struct {
+	Signature []byte
+}
+
+// GetEpochHeadersPacket requests the epoch change headers starting at the given
+// block number.
+type GetEpochHeadersPacket struct {
+	RequestId uint64
+	From      uint64
+}
+
+// EpochHeadersPacket is the reply of GetEpochHeadersPacket, headers are sorted in
+// ascending order.
+type EpochHeadersPacket struct {
+	RequestId uint64
+	Headers   []*types.Header
+}
@@ -259,6 +259,10 @@ func (cs *chainSyncer) nextSyncOp() *chainSyncOp {
 	if mode == downloader.FastSync && atomic.LoadUint32(&cs.handler.snapSync) == 1 {
 		// Fast sync via the snap protocol
 		mode = downloader.SnapSync
+		if atomic.LoadUint32(&cs.handler.epochSync) == 1 {
+			// Snap sync at the head proved by the epochs
+			mode = downloader.EpochSync
+		}
 	}
 	op := peerToSyncOp(mode, peer)
 	if op.td.Cmp(ourTD) <= 0 {
@@ -302,7 +306,7 @@ func (cs *chainSyncer) startSync(op *chainSyncOp) {
 
 // doSync synchronizes the local blockchain with a remote peer.
 func (h *handler) doSync(op *chainSyncOp) error {
-	if op.mode == downloader.FastSync || op.mode == downloader.SnapSync {
+	if op.mode == downloader.FastSync || op.mode == downloader.SnapSync || op.mode == downloader.EpochSync {
 		// Before launch the fast sync, we have to ensure user uses the same
 		// txlookup limit.
 		// The main concern here is: during the fast sync Geth won't index the
@@ -333,6 +337,10 @@ func (h *handler) doSync(op *chainSyncOp) error {
 		log.Info("Snap sync complete, auto disabling")
 		atomic.StoreUint32(&h.snapSync, 0)
 	}
+	if atomic.LoadUint32(&h.epochSync) == 1 {
+		log.Info("Epoch sync complete, auto disabling")
+		atomic.StoreUint32(&h.epochSync, 0)
+	}
 	// If we've successfully finished a sync cycle and passed any required checkpoint,
 	// enable accepting transactions from the network.
 	head := h.chain.CurrentBlock()
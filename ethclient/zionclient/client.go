@@ -0,0 +1,199 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+// Package zionclient provides typed access to the Zion native contracts on top of a
+// contract backend, e.g. an ethclient.Client or a backends.SimulatedBackend.
+//
+// Native contracts pack all event arguments into the log data and only put the event
+// id into topics, so the abigen generated filterers in go_abi can not decode them. The
+// event iterators provided here decode the whole log data into typed events.
+package zionclient
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/contracts/native/go_abi/cross_chain_manager_abi"
+	"github.com/ethereum/go-ethereum/contracts/native/go_abi/header_sync_abi"
+	"github.com/ethereum/go-ethereum/contracts/native/go_abi/main_chain_lock_proxy_abi"
+	"github.com/ethereum/go-ethereum/contracts/native/go_abi/relayer_manager_abi"
+	"github.com/ethereum/go-ethereum/contracts/native/go_abi/side_chain_lock_proxy_abi"
+	"github.com/ethereum/go-ethereum/contracts/native/go_abi/side_chain_manager_abi"
+	"github.com/ethereum/go-ethereum/contracts/native/governance/node_manager"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+var (
+	sideChainManagerABI   = mustParseABI(side_chain_manager_abi.SideChainManagerABI)
+	relayerManagerABI     = mustParseABI(relayer_manager_abi.RelayerManagerABI)
+	crossChainManagerABI  = mustParseABI(cross_chain_manager_abi.CrossChainManagerABI)
+	headerSyncABI         = mustParseABI(header_sync_abi.HeaderSyncABI)
+	mainChainLockProxyABI = mustParseABI(main_chain_lock_proxy_abi.IMainChainLockProxyABI)
+	sideChainLockProxyABI = mustParseABI(side_chain_lock_proxy_abi.ISideChainLockProxyABI)
+)
+
+func init() {
+	// node manager codecs in package node_manager depend on the package level abi
+	node_manager.InitABI()
+}
+
+func mustParseABI(str string) *abi.ABI {
+	ab, err := abi.JSON(strings.NewReader(str))
+	if err != nil {
+		panic(fmt.Sprintf("failed to load abi json string: [%v]", err))
+	}
+	return &ab
+}
+
+// Client wraps a contract backend with typed methods of the native contracts.
+type Client struct {
+	backend bind.ContractBackend
+}
+
+// NewClient creates a client of native contracts on top of the contract backend.
+func NewClient(backend bind.ContractBackend) *Client {
+	return &Client{backend: backend}
+}
+
+// Dial connects a client of native contracts to the given URL.
+func Dial(rawurl string) (*Client, error) {
+	client, err := ethclient.Dial(rawurl)
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(client), nil
+}
+
+// call executes the read only native contract method packed in payload.
+func (c *Client) call(opts *bind.CallOpts, contract common.Address, payload []byte) ([]byte, error) {
+	if opts == nil {
+		opts = new(bind.CallOpts)
+	}
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	msg := ethereum.CallMsg{From: opts.From, To: &contract, Data: payload}
+	if opts.Pending {
+		pb, ok := c.backend.(bind.PendingContractCaller)
+		if !ok {
+			return nil, bind.ErrNoPendingState
+		}
+		return pb.PendingCallContract(ctx, msg)
+	}
+	return c.backend.CallContract(ctx, msg, opts.BlockNumber)
+}
+
+// transact signs and sends the native contract method packed in payload.
+func (c *Client) transact(opts *bind.TransactOpts, contract common.Address, payload []byte) (*types.Transaction, error) {
+	bound := bind.NewBoundContract(contract, abi.ABI{}, c.backend, c.backend, c.backend)
+	return bound.RawTransact(opts, payload)
+}
+
+// filterLogs retrieves the logs of native contract event in the range of filter options.
+func (c *Client) filterLogs(opts *bind.FilterOpts, contract common.Address, ab *abi.ABI, event string) ([]types.Log, error) {
+	if opts == nil {
+		opts = new(bind.FilterOpts)
+	}
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	query := ethereum.FilterQuery{
+		Addresses: []common.Address{contract},
+		Topics:    [][]common.Hash{{ab.Events[event].ID}},
+		FromBlock: new(big.Int).SetUint64(opts.Start),
+	}
+	if opts.End != nil {
+		query.ToBlock = new(big.Int).SetUint64(*opts.End)
+	}
+	return c.backend.FilterLogs(ctx, query)
+}
+
+// unpackEvent unpacks all arguments of native contract event from log data, the
+// indexed arguments are packed into data as well.
+func unpackEvent(ab *abi.ABI, event string, log types.Log) ([]interface{}, error) {
+	ev, ok := ab.Events[event]
+	if !ok {
+		return nil, fmt.Errorf("event %s not exist", event)
+	}
+	args := make(abi.Arguments, len(ev.Inputs))
+	for i, arg := range ev.Inputs {
+		arg.Indexed = false
+		args[i] = arg
+	}
+	return args.Unpack(log.Data)
+}
+
+// eventIterator iterates over the logs of a native contract event and decodes them.
+type eventIterator struct {
+	logs   []types.Log
+	decode func(types.Log) (interface{}, error)
+
+	event interface{} // Event decoded from the current log
+	fail  error       // Occurred error to stop iteration
+}
+
+// next advances the iterator to the next event, returning whether there are any more.
+func (it *eventIterator) next() bool {
+	if it.fail != nil || len(it.logs) == 0 {
+		return false
+	}
+	log := it.logs[0]
+	it.logs = it.logs[1:]
+	it.event, it.fail = it.decode(log)
+	return it.fail == nil
+}
+
+// Error returns any retrieval or decoding error occurred during iteration.
+func (it *eventIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending logs.
+func (it *eventIterator) Close() error {
+	it.logs = nil
+	return nil
+}
+
+// newEventIterator retrieves the event logs and wraps them with the decoder.
+func (c *Client) newEventIterator(opts *bind.FilterOpts, contract common.Address, ab *abi.ABI, event string,
+	decode func([]interface{}, types.Log) (interface{}, error)) (*eventIterator, error) {
+
+	logs, err := c.filterLogs(opts, contract, ab, event)
+	if err != nil {
+		return nil, err
+	}
+	return &eventIterator{
+		logs: logs,
+		decode: func(log types.Log) (interface{}, error) {
+			values, err := unpackEvent(ab, event, log)
+			if err != nil {
+				return nil, err
+			}
+			return decode(values, log)
+		},
+	}, nil
+}
@@ -0,0 +1,227 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package zionclient
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"sort"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/contracts/native/boot"
+	"github.com/ethereum/go-ethereum/contracts/native/governance/node_manager"
+	"github.com/ethereum/go-ethereum/contracts/native/governance/side_chain_manager"
+	"github.com/ethereum/go-ethereum/contracts/native/utils"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+type testEnv struct {
+	backend *backends.SimulatedBackend
+	client  *Client
+	keys    []*ecdsa.PrivateKey
+}
+
+func newTestEnv(t *testing.T, n int) *testEnv {
+	config := *params.AllEthashProtocolChanges
+	config.Zion = &params.ZionConfig{Role: params.ZionRoleMain, CrossChainID: 1}
+	if err := boot.InitNativeContracts(&config); err != nil {
+		t.Fatalf("failed to init native contracts: %v", err)
+	}
+
+	env := &testEnv{keys: make([]*ecdsa.PrivateKey, n)}
+	alloc := make(core.GenesisAlloc)
+	for i := 0; i < n; i++ {
+		key, _ := crypto.GenerateKey()
+		env.keys[i] = key
+		alloc[crypto.PubkeyToAddress(key.PublicKey)] = core.GenesisAccount{
+			Balance:   new(big.Int).Mul(big.NewInt(1000), big.NewInt(params.Ether)),
+			PublicKey: crypto.CompressPubkey(&key.PublicKey),
+		}
+	}
+	env.backend = backends.NewSimulatedBackendWithConfig(rawdb.NewMemoryDatabase(), alloc, 30000000, &config)
+	env.client = NewClient(env.backend)
+	return env
+}
+
+func (env *testEnv) opts(t *testing.T, i int) *bind.TransactOpts {
+	opts, err := bind.NewKeyedTransactorWithChainID(env.keys[i], big.NewInt(1337))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return opts
+}
+
+func (env *testEnv) mine(t *testing.T, tx *types.Transaction, err error) {
+	if err != nil {
+		t.Fatalf("failed to send transaction: %v", err)
+	}
+	env.backend.Commit()
+	receipt, err := env.backend.TransactionReceipt(context.Background(), tx.Hash())
+	if err != nil {
+		t.Fatalf("failed to get receipt: %v", err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		t.Fatalf("transaction %s failed", tx.Hash().Hex())
+	}
+}
+
+func TestEpochChange(t *testing.T) {
+	env := newTestEnv(t, 4)
+	defer env.backend.Close()
+
+	epoch, err := env.client.Epoch(nil)
+	if err != nil {
+		t.Fatalf("failed to get current epoch: %v", err)
+	}
+	if epoch.ID != 1 || len(epoch.Peers.List) != 4 {
+		t.Fatalf("unexpected genesis epoch, id %d, peers %d", epoch.ID, len(epoch.Peers.List))
+	}
+
+	// replace one of the genesis validators, 2/3 of old participants should be kept
+	peers := &node_manager.Peers{List: []*node_manager.PeerInfo{node_manager.GenerateTestPeer()}}
+	for _, key := range env.keys[:3] {
+		peers.List = append(peers.List, &node_manager.PeerInfo{
+			PubKey:  hexutil.Encode(crypto.CompressPubkey(&key.PublicKey)),
+			Address: crypto.PubkeyToAddress(key.PublicKey),
+		})
+	}
+	sort.Sort(peers)
+	tx, err := env.client.Propose(env.opts(t, 0), 0, peers)
+	env.mine(t, tx, err)
+
+	proposed, err := env.client.FilterProposed(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proposed.Next() {
+		t.Fatalf("proposal not found, err: %v", proposed.Error())
+	}
+	proposal := proposed.Event.Epoch
+	if proposal.ID != 2 || len(proposal.Peers.List) != 4 {
+		t.Fatalf("unexpected proposal, id %d, peers %d", proposal.ID, len(proposal.Peers.List))
+	}
+	if proposed.Next() {
+		t.Fatalf("unexpected proposal %v", proposed.Event.Epoch)
+	}
+
+	// the proposer votes for its own proposal, 3 of 4 votes reach the quorum
+	for i := 1; i < 3; i++ {
+		tx, err := env.client.Vote(env.opts(t, i), proposal.ID, proposal.Hash())
+		env.mine(t, tx, err)
+	}
+
+	voted, err := env.client.FilterVoted(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer voted.Close()
+	votes := uint64(1)
+	for voted.Next() {
+		votes++
+		if voted.Event.EpochID != proposal.ID || voted.Event.EpochHash != proposal.Hash() {
+			t.Fatalf("unexpected vote for epoch %d, hash %s", voted.Event.EpochID, voted.Event.EpochHash.Hex())
+		}
+		if voted.Event.VotedNumber != votes || voted.Event.GroupSize != 4 {
+			t.Fatalf("unexpected vote progress %d/%d", voted.Event.VotedNumber, voted.Event.GroupSize)
+		}
+	}
+	if err := voted.Error(); err != nil || votes != 3 {
+		t.Fatalf("expected 3 votes, got %d, err: %v", votes, err)
+	}
+
+	changed, err := env.client.FilterEpochChanged(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !changed.Next() {
+		t.Fatalf("epoch change not found, err: %v", changed.Error())
+	}
+	if changed.Event.Epoch.ID != 1 || changed.Event.NextEpoch.Hash() != proposal.Hash() {
+		t.Fatalf("unexpected epoch change from %d to %d", changed.Event.Epoch.ID, changed.Event.NextEpoch.ID)
+	}
+
+	changing, err := env.client.ChangingEpoch(nil)
+	if err != nil {
+		t.Fatalf("failed to get changing epoch: %v", err)
+	}
+	if changing.Hash() != proposal.Hash() {
+		t.Fatalf("changing epoch mismatch, expect %s, got %s", proposal.Hash().Hex(), changing.Hash().Hex())
+	}
+}
+
+func TestSideChainRegister(t *testing.T) {
+	env := newTestEnv(t, 4)
+	defer env.backend.Close()
+
+	const chainID = 77
+	sideChain, err := env.client.SideChain(nil, chainID)
+	if err != nil {
+		t.Fatalf("failed to get side chain: %v", err)
+	}
+	if sideChain != nil {
+		t.Fatalf("unexpected side chain %v", sideChain)
+	}
+
+	owner := env.opts(t, 0)
+	tx, err := env.client.RegisterSideChain(owner, &side_chain_manager.RegisterSideChainParam{
+		Address:      owner.From,
+		ChainId:      chainID,
+		Router:       utils.ZION_ROUTER,
+		Name:         "side",
+		BlocksToWait: 1,
+		CCMCAddress:  owner.From.Bytes(),
+	})
+	env.mine(t, tx, err)
+
+	for i := 0; i < 3; i++ {
+		tx, err := env.client.ApproveRegisterSideChain(env.opts(t, i), chainID)
+		env.mine(t, tx, err)
+	}
+
+	signed, err := env.client.FilterConsensusSigned(nil, utils.SideChainManagerContractAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var signs uint64
+	for signed.Next() {
+		signs++
+		if signed.Event.Method != side_chain_manager.MethodApproveRegisterSideChain || signed.Event.Size != signs {
+			t.Fatalf("unexpected consensus sign %s, size %d", signed.Event.Method, signed.Event.Size)
+		}
+	}
+	if signs != 3 {
+		t.Fatalf("expected 3 signs, got %d", signs)
+	}
+
+	sideChain, err = env.client.SideChain(nil, chainID)
+	if err != nil {
+		t.Fatalf("failed to get side chain: %v", err)
+	}
+	if sideChain == nil || sideChain.ChainId != chainID || sideChain.Name != "side" || sideChain.Address != owner.From {
+		t.Fatalf("unexpected side chain %v", sideChain)
+	}
+}
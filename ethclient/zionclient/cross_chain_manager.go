@@ -0,0 +1,245 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package zionclient
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager"
+	scom "github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/common"
+	"github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/consensus_vote"
+	. "github.com/ethereum/go-ethereum/contracts/native/go_abi/cross_chain_manager_abi"
+	"github.com/ethereum/go-ethereum/contracts/native/utils"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// ImportOuterTransfer imports the cross chain transfer proved on the source chain.
+func (c *Client) ImportOuterTransfer(opts *bind.TransactOpts, param *scom.EntranceParam) (*types.Transaction, error) {
+	payload, err := utils.PackMethodWithStruct(crossChainManagerABI, MethodImportOuterTransfer, param)
+	if err != nil {
+		return nil, err
+	}
+	return c.transact(opts, utils.CrossChainManagerContractAddress, payload)
+}
+
+// BlackChain signs for freezing the side chain.
+func (c *Client) BlackChain(opts *bind.TransactOpts, chainID uint64) (*types.Transaction, error) {
+	payload, err := utils.PackMethod(crossChainManagerABI, MethodBlackChain, chainID)
+	if err != nil {
+		return nil, err
+	}
+	return c.transact(opts, utils.CrossChainManagerContractAddress, payload)
+}
+
+// WhiteChain signs for unfreezing the side chain.
+func (c *Client) WhiteChain(opts *bind.TransactOpts, chainID uint64) (*types.Transaction, error) {
+	payload, err := utils.PackMethod(crossChainManagerABI, MethodWhiteChain, chainID)
+	if err != nil {
+		return nil, err
+	}
+	return c.transact(opts, utils.CrossChainManagerContractAddress, payload)
+}
+
+// SetRateLimit signs for limiting the volume of the route within a rolling window, transfers
+// exceeding the limit are queued for delay seconds. a zero limit removes the limit.
+func (c *Client) SetRateLimit(opts *bind.TransactOpts, srcChainID, dstChainID uint64, asset []byte, window uint64, limit *big.Int, delay uint64) (*types.Transaction, error) {
+	payload, err := utils.PackMethod(crossChainManagerABI, MethodSetRateLimit, srcChainID, dstChainID, asset, window, limit, delay)
+	if err != nil {
+		return nil, err
+	}
+	return c.transact(opts, utils.CrossChainManagerContractAddress, payload)
+}
+
+// RateLimit retrieves the volume limit of the route.
+func (c *Client) RateLimit(opts *bind.CallOpts, srcChainID, dstChainID uint64, asset []byte) (*cross_chain_manager.RateLimit, error) {
+	limit := new(cross_chain_manager.RateLimit)
+	if err := c.callRLP(opts, MethodGetRateLimit, limit, srcChainID, dstChainID, asset); err != nil {
+		return nil, err
+	}
+	return limit, nil
+}
+
+// SetGuardian signs for setting the guardian who is able to pause routes instantly.
+func (c *Client) SetGuardian(opts *bind.TransactOpts, guardian common.Address) (*types.Transaction, error) {
+	payload, err := utils.PackMethod(crossChainManagerABI, MethodSetGuardian, guardian)
+	if err != nil {
+		return nil, err
+	}
+	return c.transact(opts, utils.CrossChainManagerContractAddress, payload)
+}
+
+// Guardian retrieves the guardian of the cross chain manager.
+func (c *Client) Guardian(opts *bind.CallOpts) (common.Address, error) {
+	payload, err := utils.PackMethod(crossChainManagerABI, MethodGetGuardian)
+	if err != nil {
+		return common.EmptyAddress, err
+	}
+	enc, err := c.call(opts, utils.CrossChainManagerContractAddress, payload)
+	if err != nil {
+		return common.EmptyAddress, err
+	}
+	output, err := crossChainManagerABI.Unpack(MethodGetGuardian, enc)
+	if err != nil {
+		return common.EmptyAddress, err
+	}
+	guardian, ok := output[0].(common.Address)
+	if !ok {
+		return common.EmptyAddress, fmt.Errorf("unexpected output type %T", output[0])
+	}
+	return guardian, nil
+}
+
+// PauseRoute pauses the route instantly, the sender should be the guardian. an empty asset
+// pauses all the routes between the chains.
+func (c *Client) PauseRoute(opts *bind.TransactOpts, srcChainID, dstChainID uint64, asset []byte) (*types.Transaction, error) {
+	payload, err := utils.PackMethod(crossChainManagerABI, MethodPauseRoute, srcChainID, dstChainID, asset)
+	if err != nil {
+		return nil, err
+	}
+	return c.transact(opts, utils.CrossChainManagerContractAddress, payload)
+}
+
+// UnpauseRoute signs for resuming the paused route.
+func (c *Client) UnpauseRoute(opts *bind.TransactOpts, srcChainID, dstChainID uint64, asset []byte) (*types.Transaction, error) {
+	payload, err := utils.PackMethod(crossChainManagerABI, MethodUnpauseRoute, srcChainID, dstChainID, asset)
+	if err != nil {
+		return nil, err
+	}
+	return c.transact(opts, utils.CrossChainManagerContractAddress, payload)
+}
+
+// ReleaseTransfer executes the transfer queued by the circuit breaker once its delay has passed.
+func (c *Client) ReleaseTransfer(opts *bind.TransactOpts, sourceChainID uint64, crossChainID []byte) (*types.Transaction, error) {
+	payload, err := utils.PackMethod(crossChainManagerABI, MethodReleaseTransfer, sourceChainID, crossChainID)
+	if err != nil {
+		return nil, err
+	}
+	return c.transact(opts, utils.CrossChainManagerContractAddress, payload)
+}
+
+// QueuedTransfer retrieves the transfer queued by the circuit breaker.
+func (c *Client) QueuedTransfer(opts *bind.CallOpts, sourceChainID uint64, crossChainID []byte) (*cross_chain_manager.QueuedTransfer, error) {
+	queued := new(cross_chain_manager.QueuedTransfer)
+	if err := c.callRLP(opts, MethodGetQueuedTransfer, queued, sourceChainID, crossChainID); err != nil {
+		return nil, err
+	}
+	return queued, nil
+}
+
+// callRLP calls the view method of the cross chain manager and decodes its rlp encoded output into result.
+func (c *Client) callRLP(opts *bind.CallOpts, method string, result interface{}, args ...interface{}) error {
+	payload, err := utils.PackMethod(crossChainManagerABI, method, args...)
+	if err != nil {
+		return err
+	}
+	enc, err := c.call(opts, utils.CrossChainManagerContractAddress, payload)
+	if err != nil {
+		return err
+	}
+	output, err := crossChainManagerABI.Unpack(method, enc)
+	if err != nil {
+		return err
+	}
+	raw, ok := output[0].([]byte)
+	if !ok {
+		return fmt.Errorf("unexpected output type %T", output[0])
+	}
+	return rlp.DecodeBytes(raw, result)
+}
+
+// VoteProgress retrieves the votes collected for a transfer imported through the vote router.
+func (c *Client) VoteProgress(opts *bind.CallOpts, sourceChainID uint64, height uint32, extra []byte) (*consensus_vote.VoteProgress, error) {
+	payload, err := utils.PackMethod(crossChainManagerABI, MethodGetVoteProgress, sourceChainID, height, extra)
+	if err != nil {
+		return nil, err
+	}
+	enc, err := c.call(opts, utils.CrossChainManagerContractAddress, payload)
+	if err != nil {
+		return nil, err
+	}
+	output, err := crossChainManagerABI.Unpack(MethodGetVoteProgress, enc)
+	if err != nil {
+		return nil, err
+	}
+	raw, ok := output[0].([]byte)
+	if !ok {
+		return nil, fmt.Errorf("unexpected output type %T", output[0])
+	}
+	progress := new(consensus_vote.VoteProgress)
+	if err := rlp.DecodeBytes(raw, progress); err != nil {
+		return nil, err
+	}
+	return progress, nil
+}
+
+// MakeProof represents a makeProof event raised when a cross chain transaction is
+// recorded on the main chain, relayers prove the storage of Key to the target chain.
+type MakeProof struct {
+	MerkleValue *scom.ToMerkleValue
+	BlockHeight uint64
+	Key         string
+	Raw         types.Log
+}
+
+// MakeProofIterator is returned from FilterMakeProof to iterate over makeProof events.
+type MakeProofIterator struct {
+	Event *MakeProof
+	*eventIterator
+}
+
+// Next advances the iterator to the next event, returning whether there are any more.
+func (it *MakeProofIterator) Next() bool {
+	if !it.next() {
+		return false
+	}
+	it.Event = it.event.(*MakeProof)
+	return true
+}
+
+// FilterMakeProof retrieves the makeProof events raised by the contract, which is the
+// cross chain manager for transfers relayed from side chains, or the lock proxy for
+// transfers locked on the main chain.
+func (c *Client) FilterMakeProof(opts *bind.FilterOpts, contract common.Address) (*MakeProofIterator, error) {
+	it, err := c.newEventIterator(opts, contract, crossChainManagerABI, scom.NOTIFY_MAKE_PROOF_EVENT,
+		func(values []interface{}, log types.Log) (interface{}, error) {
+			raw, err := hex.DecodeString(values[0].(string))
+			if err != nil {
+				return nil, err
+			}
+			merkleValue := new(scom.ToMerkleValue)
+			if err := rlp.DecodeBytes(raw, merkleValue); err != nil {
+				return nil, err
+			}
+			return &MakeProof{
+				MerkleValue: merkleValue,
+				BlockHeight: values[1].(uint64),
+				Key:         values[2].(string),
+				Raw:         log,
+			}, nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return &MakeProofIterator{eventIterator: it}, nil
+}
@@ -0,0 +1,54 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package zionclient
+
+import (
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	. "github.com/ethereum/go-ethereum/contracts/native/go_abi/header_sync_abi"
+	"github.com/ethereum/go-ethereum/contracts/native/utils"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// SyncGenesisHeader syncs the genesis header of the side chain, it should be signed by
+// the epoch members.
+func (c *Client) SyncGenesisHeader(opts *bind.TransactOpts, chainID uint64, header []byte) (*types.Transaction, error) {
+	payload, err := utils.PackMethod(headerSyncABI, MethodSyncGenesisHeader, chainID, header)
+	if err != nil {
+		return nil, err
+	}
+	return c.transact(opts, utils.HeaderSyncContractAddress, payload)
+}
+
+// SyncBlockHeader syncs the block headers of the side chain.
+func (c *Client) SyncBlockHeader(opts *bind.TransactOpts, chainID uint64, headers [][]byte) (*types.Transaction, error) {
+	payload, err := utils.PackMethod(headerSyncABI, MethodSyncBlockHeader, chainID, opts.From, headers)
+	if err != nil {
+		return nil, err
+	}
+	return c.transact(opts, utils.HeaderSyncContractAddress, payload)
+}
+
+// SyncCrossChainMsg syncs the cross chain messages of the side chain.
+func (c *Client) SyncCrossChainMsg(opts *bind.TransactOpts, chainID uint64, msgs [][]byte) (*types.Transaction, error) {
+	payload, err := utils.PackMethod(headerSyncABI, MethodSyncCrossChainMsg, chainID, opts.From, msgs)
+	if err != nil {
+		return nil, err
+	}
+	return c.transact(opts, utils.HeaderSyncContractAddress, payload)
+}
@@ -0,0 +1,349 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package zionclient
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/zion/mainchain/lock_proxy"
+	"github.com/ethereum/go-ethereum/contracts/native/go_abi/main_chain_lock_proxy_abi"
+	"github.com/ethereum/go-ethereum/contracts/native/go_abi/side_chain_lock_proxy_abi"
+	"github.com/ethereum/go-ethereum/contracts/native/utils"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// Lock locks the native token on the main chain for transferring to the side chain.
+func (c *Client) Lock(opts *bind.TransactOpts, toChainID uint64, toAddress common.Address, amount *big.Int) (*types.Transaction, error) {
+	payload, err := utils.PackMethod(mainChainLockProxyABI, main_chain_lock_proxy_abi.MethodLock, toChainID, toAddress, amount)
+	if err != nil {
+		return nil, err
+	}
+	return c.transact(opts, utils.LockProxyContractAddress, payload)
+}
+
+// LockWithFee locks the native token on the main chain together with a relayer fee, which is paid to
+// the relayer once the side chain settles the transfer delivered in time, or refunded to the payer with
+// RefundRelayerFee otherwise.
+func (c *Client) LockWithFee(opts *bind.TransactOpts, toChainID uint64, toAddress common.Address, amount, fee *big.Int) (*types.Transaction, error) {
+	payload, err := utils.PackMethod(mainChainLockProxyABI, main_chain_lock_proxy_abi.MethodLockWithFee, toChainID, toAddress, amount, fee)
+	if err != nil {
+		return nil, err
+	}
+	return c.transact(opts, utils.LockProxyContractAddress, payload)
+}
+
+// RelayerFee retrieves the relayer fee escrowed by the main chain transfer of crossChainID.
+func (c *Client) RelayerFee(opts *bind.CallOpts, crossChainID []byte) (*lock_proxy.RelayerFee, error) {
+	method := main_chain_lock_proxy_abi.MethodGetRelayerFee
+	payload, err := utils.PackMethod(mainChainLockProxyABI, method, crossChainID)
+	if err != nil {
+		return nil, err
+	}
+	enc, err := c.call(opts, utils.LockProxyContractAddress, payload)
+	if err != nil {
+		return nil, err
+	}
+	output, err := mainChainLockProxyABI.Unpack(method, enc)
+	if err != nil {
+		return nil, err
+	}
+	raw, ok := output[0].([]byte)
+	if !ok {
+		return nil, fmt.Errorf("unexpected output type %T", output[0])
+	}
+	fee := new(lock_proxy.RelayerFee)
+	if err := rlp.DecodeBytes(raw, fee); err != nil {
+		return nil, err
+	}
+	return fee, nil
+}
+
+// RefundRelayerFee refunds the relayer fee of the main chain transfer of crossChainID to the payer, if
+// the transfer was not settled before the fee deadline plus the refund delay.
+func (c *Client) RefundRelayerFee(opts *bind.TransactOpts, crossChainID []byte) (*types.Transaction, error) {
+	payload, err := utils.PackMethod(mainChainLockProxyABI, main_chain_lock_proxy_abi.MethodRefundRelayerFee, crossChainID)
+	if err != nil {
+		return nil, err
+	}
+	return c.transact(opts, utils.LockProxyContractAddress, payload)
+}
+
+// FailedReceipt retrieves the receipt of a side chain transfer which failed on the main chain and
+// was sent back to the side chain for refund.
+func (c *Client) FailedReceipt(opts *bind.CallOpts, fromChainID uint64, crossChainID []byte) (*lock_proxy.FailedReceipt, error) {
+	method := main_chain_lock_proxy_abi.MethodGetFailedReceipt
+	payload, err := utils.PackMethod(mainChainLockProxyABI, method, fromChainID, crossChainID)
+	if err != nil {
+		return nil, err
+	}
+	enc, err := c.call(opts, utils.LockProxyContractAddress, payload)
+	if err != nil {
+		return nil, err
+	}
+	output, err := mainChainLockProxyABI.Unpack(method, enc)
+	if err != nil {
+		return nil, err
+	}
+	raw, ok := output[0].([]byte)
+	if !ok {
+		return nil, fmt.Errorf("unexpected output type %T", output[0])
+	}
+	receipt := new(lock_proxy.FailedReceipt)
+	if err := rlp.DecodeBytes(raw, receipt); err != nil {
+		return nil, err
+	}
+	return receipt, nil
+}
+
+// SideChainLockAmount retrieves the amount locked on the main chain for the side chain.
+func (c *Client) SideChainLockAmount(opts *bind.CallOpts, chainID uint64) (*big.Int, error) {
+	method := main_chain_lock_proxy_abi.MethodGetSideChainLockAmount
+	payload, err := utils.PackMethod(mainChainLockProxyABI, method, chainID)
+	if err != nil {
+		return nil, err
+	}
+	enc, err := c.call(opts, utils.LockProxyContractAddress, payload)
+	if err != nil {
+		return nil, err
+	}
+	output, err := mainChainLockProxyABI.Unpack(method, enc)
+	if err != nil {
+		return nil, err
+	}
+	amount, ok := output[0].(*big.Int)
+	if !ok {
+		return nil, fmt.Errorf("unexpected output type %T", output[0])
+	}
+	return amount, nil
+}
+
+// Burn burns the native token on the side chain for transferring back to the main chain, or to another
+// side chain through the main chain.
+func (c *Client) Burn(opts *bind.TransactOpts, toChainID uint64, amount *big.Int) (*types.Transaction, error) {
+	payload, err := utils.PackMethod(sideChainLockProxyABI, side_chain_lock_proxy_abi.MethodBurn, toChainID, amount)
+	if err != nil {
+		return nil, err
+	}
+	return c.transact(opts, utils.LockProxyContractAddress, payload)
+}
+
+// BurnWithFee burns the native token on the side chain together with a relayer fee, which is paid to
+// the relayer once the main chain settles the transfer delivered in time, or refunded to the payer with
+// RefundSideChainRelayerFee otherwise.
+func (c *Client) BurnWithFee(opts *bind.TransactOpts, toChainID uint64, amount, fee *big.Int) (*types.Transaction, error) {
+	payload, err := utils.PackMethod(sideChainLockProxyABI, side_chain_lock_proxy_abi.MethodBurnWithFee, toChainID, amount, fee)
+	if err != nil {
+		return nil, err
+	}
+	return c.transact(opts, utils.LockProxyContractAddress, payload)
+}
+
+// SideChainRelayerFee retrieves the relayer fee escrowed by the side chain transfer of transferID, the
+// side chain record shares the layout of the main chain one.
+func (c *Client) SideChainRelayerFee(opts *bind.CallOpts, transferID []byte) (*lock_proxy.RelayerFee, error) {
+	method := side_chain_lock_proxy_abi.MethodGetRelayerFee
+	payload, err := utils.PackMethod(sideChainLockProxyABI, method, transferID)
+	if err != nil {
+		return nil, err
+	}
+	enc, err := c.call(opts, utils.LockProxyContractAddress, payload)
+	if err != nil {
+		return nil, err
+	}
+	output, err := sideChainLockProxyABI.Unpack(method, enc)
+	if err != nil {
+		return nil, err
+	}
+	raw, ok := output[0].([]byte)
+	if !ok {
+		return nil, fmt.Errorf("unexpected output type %T", output[0])
+	}
+	fee := new(lock_proxy.RelayerFee)
+	if err := rlp.DecodeBytes(raw, fee); err != nil {
+		return nil, err
+	}
+	return fee, nil
+}
+
+// RefundSideChainRelayerFee refunds the relayer fee of the side chain transfer of transferID to the
+// payer, if the transfer was not settled before the fee deadline plus the refund delay.
+func (c *Client) RefundSideChainRelayerFee(opts *bind.TransactOpts, transferID []byte) (*types.Transaction, error) {
+	payload, err := utils.PackMethod(sideChainLockProxyABI, side_chain_lock_proxy_abi.MethodRefundRelayerFee, transferID)
+	if err != nil {
+		return nil, err
+	}
+	return c.transact(opts, utils.LockProxyContractAddress, payload)
+}
+
+// CrossChainEvent represents a CrossChainEvent raised by the main chain lock proxy.
+type CrossChainEvent struct {
+	Sender               common.Address
+	TxId                 []byte
+	ProxyOrAssetContract common.Address
+	ToChainId            uint64
+	ToContract           []byte
+	Rawdata              []byte
+	Raw                  types.Log
+}
+
+// CrossChainEventIterator is returned from FilterCrossChainEvent to iterate over CrossChainEvent events.
+type CrossChainEventIterator struct {
+	Event *CrossChainEvent
+	*eventIterator
+}
+
+// Next advances the iterator to the next event, returning whether there are any more.
+func (it *CrossChainEventIterator) Next() bool {
+	if !it.next() {
+		return false
+	}
+	it.Event = it.event.(*CrossChainEvent)
+	return true
+}
+
+// FilterCrossChainEvent retrieves the cross chain transactions made by the main chain lock proxy.
+func (c *Client) FilterCrossChainEvent(opts *bind.FilterOpts) (*CrossChainEventIterator, error) {
+	it, err := c.newEventIterator(opts, utils.LockProxyContractAddress, mainChainLockProxyABI, main_chain_lock_proxy_abi.EventCrossChainEvent,
+		func(values []interface{}, log types.Log) (interface{}, error) {
+			return &CrossChainEvent{
+				Sender:               values[0].(common.Address),
+				TxId:                 values[1].([]byte),
+				ProxyOrAssetContract: values[2].(common.Address),
+				ToChainId:            values[3].(uint64),
+				ToContract:           values[4].([]byte),
+				Rawdata:              values[5].([]byte),
+				Raw:                  log,
+			}, nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return &CrossChainEventIterator{eventIterator: it}, nil
+}
+
+// LockEvent represents a LockEvent raised by the main chain lock proxy, BurnEvent raised
+// by the side chain lock proxy shares the same layout.
+type LockEvent struct {
+	FromAssetHash common.Address
+	FromAddress   common.Address
+	ToChainId     uint64
+	ToAssetHash   []byte
+	ToAddress     []byte
+	Amount        *big.Int
+	Raw           types.Log
+}
+
+// LockEventIterator is returned from FilterLockEvent and FilterBurnEvent to iterate over the events.
+type LockEventIterator struct {
+	Event *LockEvent
+	*eventIterator
+}
+
+// Next advances the iterator to the next event, returning whether there are any more.
+func (it *LockEventIterator) Next() bool {
+	if !it.next() {
+		return false
+	}
+	it.Event = it.event.(*LockEvent)
+	return true
+}
+
+// FilterLockEvent retrieves the tokens locked on the main chain.
+func (c *Client) FilterLockEvent(opts *bind.FilterOpts) (*LockEventIterator, error) {
+	return c.filterLockEvent(opts, mainChainLockProxyABI, main_chain_lock_proxy_abi.EventLockEvent)
+}
+
+// FilterBurnEvent retrieves the tokens burnt on the side chain.
+func (c *Client) FilterBurnEvent(opts *bind.FilterOpts) (*LockEventIterator, error) {
+	return c.filterLockEvent(opts, sideChainLockProxyABI, side_chain_lock_proxy_abi.EventBurnEvent)
+}
+
+func (c *Client) filterLockEvent(opts *bind.FilterOpts, ab *abi.ABI, event string) (*LockEventIterator, error) {
+	it, err := c.newEventIterator(opts, utils.LockProxyContractAddress, ab, event,
+		func(values []interface{}, log types.Log) (interface{}, error) {
+			return &LockEvent{
+				FromAssetHash: values[0].(common.Address),
+				FromAddress:   values[1].(common.Address),
+				ToChainId:     values[2].(uint64),
+				ToAssetHash:   values[3].([]byte),
+				ToAddress:     values[4].([]byte),
+				Amount:        values[5].(*big.Int),
+				Raw:           log,
+			}, nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return &LockEventIterator{eventIterator: it}, nil
+}
+
+// UnlockEvent represents an UnlockEvent raised by the main chain lock proxy, MintEvent
+// raised by the side chain lock proxy shares the same layout.
+type UnlockEvent struct {
+	ToAssetHash common.Address
+	ToAddress   common.Address
+	Amount      *big.Int
+	Raw         types.Log
+}
+
+// UnlockEventIterator is returned from FilterUnlockEvent and FilterMintEvent to iterate over the events.
+type UnlockEventIterator struct {
+	Event *UnlockEvent
+	*eventIterator
+}
+
+// Next advances the iterator to the next event, returning whether there are any more.
+func (it *UnlockEventIterator) Next() bool {
+	if !it.next() {
+		return false
+	}
+	it.Event = it.event.(*UnlockEvent)
+	return true
+}
+
+// FilterUnlockEvent retrieves the tokens unlocked on the main chain.
+func (c *Client) FilterUnlockEvent(opts *bind.FilterOpts) (*UnlockEventIterator, error) {
+	return c.filterUnlockEvent(opts, mainChainLockProxyABI, main_chain_lock_proxy_abi.EventUnlockEvent)
+}
+
+// FilterMintEvent retrieves the tokens minted on the side chain.
+func (c *Client) FilterMintEvent(opts *bind.FilterOpts) (*UnlockEventIterator, error) {
+	return c.filterUnlockEvent(opts, sideChainLockProxyABI, side_chain_lock_proxy_abi.EventMintEvent)
+}
+
+func (c *Client) filterUnlockEvent(opts *bind.FilterOpts, ab *abi.ABI, event string) (*UnlockEventIterator, error) {
+	it, err := c.newEventIterator(opts, utils.LockProxyContractAddress, ab, event,
+		func(values []interface{}, log types.Log) (interface{}, error) {
+			return &UnlockEvent{
+				ToAssetHash: values[0].(common.Address),
+				ToAddress:   values[1].(common.Address),
+				Amount:      values[2].(*big.Int),
+				Raw:         log,
+			}, nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return &UnlockEventIterator{eventIterator: it}, nil
+}
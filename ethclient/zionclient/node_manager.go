@@ -0,0 +1,350 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package zionclient
+
+import (
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/contracts/native"
+	. "github.com/ethereum/go-ethereum/contracts/native/go_abi/node_manager_abi"
+	"github.com/ethereum/go-ethereum/contracts/native/governance/node_manager"
+	"github.com/ethereum/go-ethereum/contracts/native/utils"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// Epoch retrieves the current epoch.
+func (c *Client) Epoch(opts *bind.CallOpts) (*node_manager.EpochInfo, error) {
+	payload, err := new(node_manager.MethodEpochInput).Encode()
+	if err != nil {
+		return nil, err
+	}
+	return c.callEpoch(opts, payload)
+}
+
+// ChangingEpoch retrieves the epoch which has been voted but not started yet.
+func (c *Client) ChangingEpoch(opts *bind.CallOpts) (*node_manager.EpochInfo, error) {
+	payload, err := new(node_manager.MethodGetChangingEpochInput).Encode()
+	if err != nil {
+		return nil, err
+	}
+	return c.callEpoch(opts, payload)
+}
+
+// EpochByID retrieves the history effective epoch with epoch id.
+func (c *Client) EpochByID(opts *bind.CallOpts, epochID uint64) (*node_manager.EpochInfo, error) {
+	payload, err := (&node_manager.MethodGetEpochByIDInput{EpochID: epochID}).Encode()
+	if err != nil {
+		return nil, err
+	}
+	return c.callEpoch(opts, payload)
+}
+
+func (c *Client) callEpoch(opts *bind.CallOpts, payload []byte) (*node_manager.EpochInfo, error) {
+	enc, err := c.call(opts, utils.NodeManagerContractAddress, payload)
+	if err != nil {
+		return nil, err
+	}
+	output := new(node_manager.MethodEpochOutput)
+	if err := output.Decode(enc); err != nil {
+		return nil, err
+	}
+	return output.Epoch, nil
+}
+
+// EpochProof retrieves the proof hash of the epoch with epoch id.
+func (c *Client) EpochProof(opts *bind.CallOpts, epochID uint64) (common.Hash, error) {
+	payload, err := (&node_manager.MethodProofInput{EpochID: epochID}).Encode()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	enc, err := c.call(opts, utils.NodeManagerContractAddress, payload)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	output := new(node_manager.MethodProofOutput)
+	if err := output.Decode(enc); err != nil {
+		return common.Hash{}, err
+	}
+	return output.Hash, nil
+}
+
+// NativeUpgrades retrieves the versions approved for the native contract.
+func (c *Client) NativeUpgrades(opts *bind.CallOpts, contract common.Address) ([]*native.Upgrade, error) {
+	payload, err := (&node_manager.MethodGetNativeUpgradesInput{Contract: contract}).Encode()
+	if err != nil {
+		return nil, err
+	}
+	enc, err := c.call(opts, utils.NodeManagerContractAddress, payload)
+	if err != nil {
+		return nil, err
+	}
+	output := new(node_manager.MethodGetNativeUpgradesOutput)
+	if err := output.Decode(enc); err != nil {
+		return nil, err
+	}
+	return output.Upgrades, nil
+}
+
+// Propose proposes a new epoch with the peers, the start height is decided by node
+// manager if zero specified.
+func (c *Client) Propose(opts *bind.TransactOpts, startHeight uint64, peers *node_manager.Peers) (*types.Transaction, error) {
+	payload, err := (&node_manager.MethodProposeInput{StartHeight: startHeight, Peers: peers}).Encode()
+	if err != nil {
+		return nil, err
+	}
+	return c.transact(opts, utils.NodeManagerContractAddress, payload)
+}
+
+// Vote votes for the epoch proposal.
+func (c *Client) Vote(opts *bind.TransactOpts, epochID uint64, epochHash common.Hash) (*types.Transaction, error) {
+	payload, err := (&node_manager.MethodVoteInput{EpochID: epochID, EpochHash: epochHash}).Encode()
+	if err != nil {
+		return nil, err
+	}
+	return c.transact(opts, utils.NodeManagerContractAddress, payload)
+}
+
+// ApproveNativeUpgrade approves the native contract version activated from height.
+func (c *Client) ApproveNativeUpgrade(opts *bind.TransactOpts, contract common.Address, version, height uint64) (*types.Transaction, error) {
+	payload, err := (&node_manager.MethodApproveNativeUpgradeInput{Contract: contract, Version: version, Height: height}).Encode()
+	if err != nil {
+		return nil, err
+	}
+	return c.transact(opts, utils.NodeManagerContractAddress, payload)
+}
+
+func decodeEpoch(enc []byte) (*node_manager.EpochInfo, error) {
+	var epoch *node_manager.EpochInfo
+	if err := rlp.DecodeBytes(enc, &epoch); err != nil {
+		return nil, err
+	}
+	return epoch, nil
+}
+
+// Proposed represents a Proposed event raised by node manager.
+type Proposed struct {
+	Epoch *node_manager.EpochInfo
+	Raw   types.Log
+}
+
+// ProposedIterator is returned from FilterProposed to iterate over Proposed events.
+type ProposedIterator struct {
+	Event *Proposed
+	*eventIterator
+}
+
+// Next advances the iterator to the next event, returning whether there are any more.
+func (it *ProposedIterator) Next() bool {
+	if !it.next() {
+		return false
+	}
+	it.Event = it.event.(*Proposed)
+	return true
+}
+
+// FilterProposed retrieves the epoch proposals.
+func (c *Client) FilterProposed(opts *bind.FilterOpts) (*ProposedIterator, error) {
+	it, err := c.newEventIterator(opts, utils.NodeManagerContractAddress, node_manager.ABI, EventProposed,
+		func(values []interface{}, log types.Log) (interface{}, error) {
+			epoch, err := decodeEpoch(values[0].([]byte))
+			if err != nil {
+				return nil, err
+			}
+			return &Proposed{Epoch: epoch, Raw: log}, nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return &ProposedIterator{eventIterator: it}, nil
+}
+
+// Voted represents a Voted event raised by node manager.
+type Voted struct {
+	EpochID     uint64
+	EpochHash   common.Hash
+	VotedNumber uint64
+	GroupSize   uint64
+	Raw         types.Log
+}
+
+// VotedIterator is returned from FilterVoted to iterate over Voted events.
+type VotedIterator struct {
+	Event *Voted
+	*eventIterator
+}
+
+// Next advances the iterator to the next event, returning whether there are any more.
+func (it *VotedIterator) Next() bool {
+	if !it.next() {
+		return false
+	}
+	it.Event = it.event.(*Voted)
+	return true
+}
+
+// FilterVoted retrieves the votes for epoch proposals.
+func (c *Client) FilterVoted(opts *bind.FilterOpts) (*VotedIterator, error) {
+	it, err := c.newEventIterator(opts, utils.NodeManagerContractAddress, node_manager.ABI, EventVoted,
+		func(values []interface{}, log types.Log) (interface{}, error) {
+			return &Voted{
+				EpochID:     values[0].(uint64),
+				EpochHash:   common.BytesToHash(values[1].([]byte)),
+				VotedNumber: values[2].(uint64),
+				GroupSize:   values[3].(uint64),
+				Raw:         log,
+			}, nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return &VotedIterator{eventIterator: it}, nil
+}
+
+// EpochChanged represents an EpochChanged event raised by node manager.
+type EpochChanged struct {
+	Epoch     *node_manager.EpochInfo
+	NextEpoch *node_manager.EpochInfo
+	Raw       types.Log
+}
+
+// EpochChangedIterator is returned from FilterEpochChanged to iterate over EpochChanged events.
+type EpochChangedIterator struct {
+	Event *EpochChanged
+	*eventIterator
+}
+
+// Next advances the iterator to the next event, returning whether there are any more.
+func (it *EpochChangedIterator) Next() bool {
+	if !it.next() {
+		return false
+	}
+	it.Event = it.event.(*EpochChanged)
+	return true
+}
+
+// FilterEpochChanged retrieves the passed epoch proposals.
+func (c *Client) FilterEpochChanged(opts *bind.FilterOpts) (*EpochChangedIterator, error) {
+	it, err := c.newEventIterator(opts, utils.NodeManagerContractAddress, node_manager.ABI, EventEpochChanged,
+		func(values []interface{}, log types.Log) (interface{}, error) {
+			epoch, err := decodeEpoch(values[0].([]byte))
+			if err != nil {
+				return nil, err
+			}
+			next, err := decodeEpoch(values[1].([]byte))
+			if err != nil {
+				return nil, err
+			}
+			return &EpochChanged{Epoch: epoch, NextEpoch: next, Raw: log}, nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return &EpochChangedIterator{eventIterator: it}, nil
+}
+
+// ConsensusSigned represents a ConsensusSigned event raised by node manager.
+type ConsensusSigned struct {
+	Method string
+	Input  []byte
+	Signer common.Address
+	Size   uint64
+	Raw    types.Log
+}
+
+// Sign returns the consensus sign which the event signed for.
+func (e *ConsensusSigned) Sign() *node_manager.ConsensusSign {
+	return &node_manager.ConsensusSign{Method: e.Method, Input: e.Input}
+}
+
+// ConsensusSignedIterator is returned from FilterConsensusSigned to iterate over ConsensusSigned events.
+type ConsensusSignedIterator struct {
+	Event *ConsensusSigned
+	*eventIterator
+}
+
+// Next advances the iterator to the next event, returning whether there are any more.
+func (it *ConsensusSignedIterator) Next() bool {
+	if !it.next() {
+		return false
+	}
+	it.Event = it.event.(*ConsensusSigned)
+	return true
+}
+
+// FilterConsensusSigned retrieves the signatures of governance flows which should be
+// signed by 2/3 of the epoch members, the events are raised by the native contract
+// which the flow belongs to, e.g. side chain manager for side chain approvals.
+func (c *Client) FilterConsensusSigned(opts *bind.FilterOpts, contract common.Address) (*ConsensusSignedIterator, error) {
+	it, err := c.newEventIterator(opts, contract, node_manager.ABI, EventConsensusSigned,
+		func(values []interface{}, log types.Log) (interface{}, error) {
+			return &ConsensusSigned{
+				Method: values[0].(string),
+				Input:  values[1].([]byte),
+				Signer: values[2].(common.Address),
+				Size:   values[3].(uint64),
+				Raw:    log,
+			}, nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return &ConsensusSignedIterator{eventIterator: it}, nil
+}
+
+// NativeUpgradeApproved represents a NativeUpgradeApproved event raised by node manager.
+type NativeUpgradeApproved struct {
+	Contract common.Address
+	Version  uint64
+	Height   uint64
+	Raw      types.Log
+}
+
+// NativeUpgradeApprovedIterator is returned from FilterNativeUpgradeApproved to iterate
+// over NativeUpgradeApproved events.
+type NativeUpgradeApprovedIterator struct {
+	Event *NativeUpgradeApproved
+	*eventIterator
+}
+
+// Next advances the iterator to the next event, returning whether there are any more.
+func (it *NativeUpgradeApprovedIterator) Next() bool {
+	if !it.next() {
+		return false
+	}
+	it.Event = it.event.(*NativeUpgradeApproved)
+	return true
+}
+
+// FilterNativeUpgradeApproved retrieves the approved native contract upgrades.
+func (c *Client) FilterNativeUpgradeApproved(opts *bind.FilterOpts) (*NativeUpgradeApprovedIterator, error) {
+	it, err := c.newEventIterator(opts, utils.NodeManagerContractAddress, node_manager.ABI, EventNativeUpgradeApproved,
+		func(values []interface{}, log types.Log) (interface{}, error) {
+			return &NativeUpgradeApproved{
+				Contract: values[0].(common.Address),
+				Version:  values[1].(uint64),
+				Height:   values[2].(uint64),
+				Raw:      log,
+			}, nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return &NativeUpgradeApprovedIterator{eventIterator: it}, nil
+}
@@ -0,0 +1,58 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package zionclient
+
+import (
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	. "github.com/ethereum/go-ethereum/contracts/native/go_abi/relayer_manager_abi"
+	"github.com/ethereum/go-ethereum/contracts/native/governance/relayer_manager"
+	"github.com/ethereum/go-ethereum/contracts/native/utils"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// RegisterRelayer applies for registering the relayers, the apply takes effect after
+// approved by the epoch members.
+func (c *Client) RegisterRelayer(opts *bind.TransactOpts, relayers []common.Address) (*types.Transaction, error) {
+	return c.transactRelayer(opts, MethodRegisterRelayer, &relayer_manager.RelayerListParam{AddressList: relayers, Address: opts.From})
+}
+
+// RemoveRelayer applies for removing the relayers, the apply takes effect after
+// approved by the epoch members.
+func (c *Client) RemoveRelayer(opts *bind.TransactOpts, relayers []common.Address) (*types.Transaction, error) {
+	return c.transactRelayer(opts, MethodRemoveRelayer, &relayer_manager.RelayerListParam{AddressList: relayers, Address: opts.From})
+}
+
+// ApproveRegisterRelayer signs for the relayer register apply with the apply id.
+func (c *Client) ApproveRegisterRelayer(opts *bind.TransactOpts, id uint64) (*types.Transaction, error) {
+	return c.transactRelayer(opts, MethodApproveRegisterRelayer, &relayer_manager.ApproveRelayerParam{ID: id, Address: opts.From})
+}
+
+// ApproveRemoveRelayer signs for the relayer remove apply with the apply id.
+func (c *Client) ApproveRemoveRelayer(opts *bind.TransactOpts, id uint64) (*types.Transaction, error) {
+	return c.transactRelayer(opts, MethodApproveRemoveRelayer, &relayer_manager.ApproveRelayerParam{ID: id, Address: opts.From})
+}
+
+func (c *Client) transactRelayer(opts *bind.TransactOpts, method string, param interface{}) (*types.Transaction, error) {
+	payload, err := utils.PackMethodWithStruct(relayerManagerABI, method, param)
+	if err != nil {
+		return nil, err
+	}
+	return c.transact(opts, utils.RelayerManagerContractAddress, payload)
+}
@@ -0,0 +1,138 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package zionclient
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	. "github.com/ethereum/go-ethereum/contracts/native/go_abi/side_chain_manager_abi"
+	"github.com/ethereum/go-ethereum/contracts/native/governance/side_chain_manager"
+	"github.com/ethereum/go-ethereum/contracts/native/utils"
+	"github.com/ethereum/go-ethereum/core/types"
+	polycomm "github.com/polynetwork/poly/common"
+)
+
+// SideChain retrieves the registered side chain, nil returned if the chain is not registered.
+func (c *Client) SideChain(opts *bind.CallOpts, chainID uint64) (*side_chain_manager.SideChain, error) {
+	payload, err := utils.PackMethod(sideChainManagerABI, MethodGetSideChain, chainID)
+	if err != nil {
+		return nil, err
+	}
+	enc, err := c.call(opts, utils.SideChainManagerContractAddress, payload)
+	if err != nil {
+		return nil, err
+	}
+	output, err := sideChainManagerABI.Unpack(MethodGetSideChain, enc)
+	if err != nil {
+		return nil, err
+	}
+	raw, ok := output[0].([]byte)
+	if !ok {
+		return nil, fmt.Errorf("unexpected output type %T", output[0])
+	}
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	sideChain := new(side_chain_manager.SideChain)
+	if err := sideChain.Deserialization(polycomm.NewZeroCopySource(raw)); err != nil {
+		return nil, err
+	}
+	return sideChain, nil
+}
+
+// RegisterSideChain applies for registering the side chain, the apply takes effect after
+// approved by the epoch members.
+func (c *Client) RegisterSideChain(opts *bind.TransactOpts, param *side_chain_manager.RegisterSideChainParam) (*types.Transaction, error) {
+	return c.transactSideChain(opts, MethodRegisterSideChain, param)
+}
+
+// UpdateSideChain applies for updating the side chain, the apply takes effect after
+// approved by the epoch members.
+func (c *Client) UpdateSideChain(opts *bind.TransactOpts, param *side_chain_manager.RegisterSideChainParam) (*types.Transaction, error) {
+	return c.transactSideChain(opts, MethodUpdateSideChain, param)
+}
+
+// ApproveRegisterSideChain signs for the side chain register apply.
+func (c *Client) ApproveRegisterSideChain(opts *bind.TransactOpts, chainID uint64) (*types.Transaction, error) {
+	return c.transactSideChain(opts, MethodApproveRegisterSideChain, &side_chain_manager.ChainidParam{Chainid: chainID, Address: opts.From})
+}
+
+// ApproveUpdateSideChain signs for the side chain update apply.
+func (c *Client) ApproveUpdateSideChain(opts *bind.TransactOpts, chainID uint64) (*types.Transaction, error) {
+	return c.transactSideChain(opts, MethodApproveUpdateSideChain, &side_chain_manager.ChainidParam{Chainid: chainID, Address: opts.From})
+}
+
+// QuitSideChain applies for quiting the side chain, it should be sent by the side chain owner.
+func (c *Client) QuitSideChain(opts *bind.TransactOpts, chainID uint64) (*types.Transaction, error) {
+	return c.transactSideChain(opts, MethodQuitSideChain, &side_chain_manager.ChainidParam{Chainid: chainID, Address: opts.From})
+}
+
+// ApproveQuitSideChain signs for the side chain quit apply.
+func (c *Client) ApproveQuitSideChain(opts *bind.TransactOpts, chainID uint64) (*types.Transaction, error) {
+	return c.transactSideChain(opts, MethodApproveQuitSideChain, &side_chain_manager.ChainidParam{Chainid: chainID, Address: opts.From})
+}
+
+// VoterCommittee retrieves the voter committee of a side chain using the vote router, nil returned
+// if the transfers of the chain are voted by all zion validators.
+func (c *Client) VoterCommittee(opts *bind.CallOpts, chainID uint64) (*side_chain_manager.VoterCommittee, error) {
+	payload, err := utils.PackMethod(sideChainManagerABI, MethodGetVoterCommittee, chainID)
+	if err != nil {
+		return nil, err
+	}
+	enc, err := c.call(opts, utils.SideChainManagerContractAddress, payload)
+	if err != nil {
+		return nil, err
+	}
+	output, err := sideChainManagerABI.Unpack(MethodGetVoterCommittee, enc)
+	if err != nil {
+		return nil, err
+	}
+	raw, ok := output[0].([]byte)
+	if !ok {
+		return nil, fmt.Errorf("unexpected output type %T", output[0])
+	}
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	committee := new(side_chain_manager.VoterCommittee)
+	if err := committee.Deserialization(polycomm.NewZeroCopySource(raw)); err != nil {
+		return nil, err
+	}
+	return committee, nil
+}
+
+// UpdateVoterCommittee applies for rotating the voter committee of the side chain, it should be
+// sent by the side chain owner and takes effect after approved by the epoch members.
+func (c *Client) UpdateVoterCommittee(opts *bind.TransactOpts, param *side_chain_manager.UpdateVoterCommitteeParam) (*types.Transaction, error) {
+	return c.transactSideChain(opts, MethodUpdateVoterCommittee, param)
+}
+
+// ApproveUpdateVoterCommittee signs for the voter committee update apply.
+func (c *Client) ApproveUpdateVoterCommittee(opts *bind.TransactOpts, chainID uint64) (*types.Transaction, error) {
+	return c.transactSideChain(opts, MethodApproveUpdateVoterCommittee, &side_chain_manager.ChainidParam{Chainid: chainID, Address: opts.From})
+}
+
+func (c *Client) transactSideChain(opts *bind.TransactOpts, method string, param interface{}) (*types.Transaction, error) {
+	payload, err := utils.PackMethodWithStruct(sideChainManagerABI, method, param)
+	if err != nil {
+		return nil, err
+	}
+	return c.transact(opts, utils.SideChainManagerContractAddress, payload)
+}
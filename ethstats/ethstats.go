@@ -34,6 +34,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/mclock"
 	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/hotstuff"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/eth/downloader"
@@ -81,6 +82,12 @@ type fullNodeBackend interface {
 	SuggestPrice(ctx context.Context) (*big.Int, error)
 }
 
+// hotstuffEngine is implemented by the HotStuff consensus engine to expose the
+// consensus progress and validators participation
+type hotstuffEngine interface {
+	Stats() *hotstuff.Stats
+}
+
 // Service implements an Ethereum netstats reporting daemon that pushes local
 // chain statistics up to a monitoring server.
 type Service struct {
@@ -731,13 +738,23 @@ func (s *Service) reportPending(conn *connWrapper) error {
 
 // nodeStats is the information to report about the local node.
 type nodeStats struct {
-	Active   bool `json:"active"`
-	Syncing  bool `json:"syncing"`
-	Mining   bool `json:"mining"`
-	Hashrate int  `json:"hashrate"`
-	Peers    int  `json:"peers"`
-	GasPrice int  `json:"gasPrice"`
-	Uptime   int  `json:"uptime"`
+	Active    bool            `json:"active"`
+	Syncing   bool            `json:"syncing"`
+	Mining    bool            `json:"mining"`
+	Hashrate  int             `json:"hashrate"`
+	Peers     int             `json:"peers"`
+	GasPrice  int             `json:"gasPrice"`
+	Uptime    int             `json:"uptime"`
+	Consensus *hotstuff.Stats `json:"consensus,omitempty"`
+}
+
+// consensusStats gathers the consensus progress and validators participation if
+// the engine reports it, or nil otherwise.
+func (s *Service) consensusStats() *hotstuff.Stats {
+	if engine, ok := s.engine.(hotstuffEngine); ok {
+		return engine.Stats()
+	}
+	return nil
 }
 
 // reportStats retrieves various stats about the node at the networking and
@@ -771,13 +788,14 @@ func (s *Service) reportStats(conn *connWrapper) error {
 	stats := map[string]interface{}{
 		"id": s.node,
 		"stats": &nodeStats{
-			Active:   true,
-			Mining:   mining,
-			Hashrate: hashrate,
-			Peers:    s.server.PeerCount(),
-			GasPrice: gasprice,
-			Syncing:  syncing,
-			Uptime:   100,
+			Active:    true,
+			Mining:    mining,
+			Hashrate:  hashrate,
+			Peers:     s.server.PeerCount(),
+			GasPrice:  gasprice,
+			Syncing:   syncing,
+			Uptime:    100,
+			Consensus: s.consensusStats(),
 		},
 	}
 	report := map[string][]interface{}{
@@ -0,0 +1,94 @@
+// Copyright 2021 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethstats
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/hotstuff"
+)
+
+// testEngine is a consensus engine reporting a fixed hotstuff snapshot.
+type testEngine struct {
+	consensus.Engine
+	stats *hotstuff.Stats
+}
+
+func (e *testEngine) Stats() *hotstuff.Stats { return e.stats }
+
+func TestConsensusStats(t *testing.T) {
+	stats := &hotstuff.Stats{
+		Height:      100,
+		Round:       2,
+		Proposer:    common.HexToAddress("0x01"),
+		ViewChanges: 3,
+		Timeouts:    1,
+		Validators: []*hotstuff.ValidatorStats{
+			{Address: common.HexToAddress("0x01"), Signed: 99, Missed: 1, LastSigned: 100},
+			{Address: common.HexToAddress("0x02"), Signed: 0, Missed: 100},
+		},
+	}
+	s := &Service{engine: &testEngine{stats: stats}}
+	if have := s.consensusStats(); have != stats {
+		t.Fatalf("consensus stats mismatch: have %v, want %v", have, stats)
+	}
+
+	blob, err := json.Marshal(&nodeStats{Active: true, Consensus: s.consensusStats()})
+	if err != nil {
+		t.Fatalf("failed to encode node stats: %v", err)
+	}
+	var report map[string]interface{}
+	if err := json.Unmarshal(blob, &report); err != nil {
+		t.Fatalf("failed to decode node stats: %v", err)
+	}
+	want := map[string]interface{}{
+		"height":      float64(100),
+		"round":       float64(2),
+		"proposer":    "0x0000000000000000000000000000000000000001",
+		"viewChanges": float64(3),
+		"timeouts":    float64(1),
+		"validators": []interface{}{
+			map[string]interface{}{"address": "0x0000000000000000000000000000000000000001", "signed": float64(99), "missed": float64(1), "lastSigned": float64(100)},
+			map[string]interface{}{"address": "0x0000000000000000000000000000000000000002", "signed": float64(0), "missed": float64(100), "lastSigned": float64(0)},
+		},
+	}
+	if !reflect.DeepEqual(report["consensus"], want) {
+		t.Errorf("consensus report mismatch: have %v, want %v", report["consensus"], want)
+	}
+}
+
+func TestConsensusStatsOtherEngine(t *testing.T) {
+	s := &Service{engine: nil}
+	if stats := s.consensusStats(); stats != nil {
+		t.Fatalf("expected no consensus stats, have %v", stats)
+	}
+	blob, err := json.Marshal(&nodeStats{Active: true, Consensus: s.consensusStats()})
+	if err != nil {
+		t.Fatalf("failed to encode node stats: %v", err)
+	}
+	var report map[string]interface{}
+	if err := json.Unmarshal(blob, &report); err != nil {
+		t.Fatalf("failed to decode node stats: %v", err)
+	}
+	if _, ok := report["consensus"]; ok {
+		t.Errorf("consensus field should be omitted, have %v", report["consensus"])
+	}
+}
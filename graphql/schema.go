@@ -218,6 +218,18 @@ const schema string = `
         # EstimateGas estimates the amount of gas that will be required for
         # successful execution of a transaction at the current block's state.
         estimateGas(data: CallData!): Long!
+        # Proposer is the validator that proposed this block, recovered from the
+        # HotStuff seal. This is null for blocks without the proposer seal.
+        proposer(block: Long): Account
+        # CommittedSigners is the list of validators whose committed seals are
+        # included in this block. This is null if this is not a HotStuff block.
+        committedSigners(block: Long): [Account!]
+        # NextValidators is the validator set taking effect after this block. This
+        # is null if this block doesn't change the validator set.
+        nextValidators(block: Long): [Account!]
+        # EpochID is the id of the epoch this block belongs to. This is null if
+        # this is not a HotStuff block.
+        epochID: Long
     }
 
     # CallData represents the data associated with a local contract call.
@@ -272,6 +284,88 @@ const schema string = `
         topics: [[Bytes32!]!]
     }
 
+    # CrossChainEventFilter encapsulates criteria for searching cross chain events.
+    input CrossChainEventFilter {
+        # FromBlock is the block at which to start searching, inclusive. Defaults
+        # to the latest block if not supplied.
+        fromBlock: Long
+        # ToBlock is the block at which to stop searching, inclusive. Defaults
+        # to the latest block if not supplied.
+        toBlock: Long
+        # SourceChain restricts matches to the transfers from the chain.
+        sourceChain: Long
+        # DestinationChain restricts matches to the transfers to the chain.
+        destinationChain: Long
+    }
+
+    # CrossChainEvent is a cross chain transfer recorded by the cross chain manager.
+    type CrossChainEvent {
+        # Log is the makeProof log recording the transfer.
+        log: Log!
+        # SourceChain is the chain the transfer comes from.
+        sourceChain: Long!
+        # DestinationChain is the chain the transfer goes to.
+        destinationChain: Long!
+        # TxHash is the hash of the transaction recording the transfer on this chain.
+        txHash: Bytes!
+        # SourceTxHash is the hash of the transfer on the source chain.
+        sourceTxHash: Bytes!
+        # CrossChainID is the id of the transfer assigned by the source chain.
+        crossChainID: Bytes!
+        # FromContract is the contract sending the transfer on the source chain.
+        fromContract: Bytes!
+        # ToContract is the contract receiving the transfer on the destination chain.
+        toContract: Bytes!
+        # Method is the method called on the destination contract.
+        method: String!
+        # Args is the argument of the destination method.
+        args: Bytes!
+        # Key is the storage key proving the transfer to the destination chain.
+        key: Bytes!
+    }
+
+    # EpochEventFilter encapsulates criteria for searching epoch proposals and votes.
+    input EpochEventFilter {
+        # FromBlock is the block at which to start searching, inclusive. Defaults
+        # to the latest block if not supplied.
+        fromBlock: Long
+        # ToBlock is the block at which to stop searching, inclusive. Defaults
+        # to the latest block if not supplied.
+        toBlock: Long
+        # EpochID restricts matches to the epoch.
+        epochID: Long
+    }
+
+    # EpochProposal is an epoch proposed to the node manager.
+    type EpochProposal {
+        # Log is the Proposed log of the proposal.
+        log: Log!
+        # EpochID is the id of the proposed epoch.
+        epochID: Long!
+        # Hash is the hash of the proposed epoch, which is voted by validators.
+        hash: Bytes32!
+        # StartHeight is the block at which the epoch takes effect.
+        startHeight: Long!
+        # Proposer is the validator that proposed the epoch.
+        proposer: Address!
+        # Validators is the validator set of the epoch.
+        validators: [Address!]!
+    }
+
+    # EpochVote is a vote for a proposed epoch.
+    type EpochVote {
+        # Log is the Voted log of the vote, the voter is the sender of its transaction.
+        log: Log!
+        # EpochID is the id of the voted epoch.
+        epochID: Long!
+        # EpochHash is the hash of the voted epoch.
+        epochHash: Bytes32!
+        # VotedNumber is the number of votes for the epoch including this one.
+        votedNumber: Long!
+        # GroupSize is the number of validators voting for the epoch.
+        groupSize: Long!
+    }
+
     # SyncState contains the current synchronisation state of the client.
     type SyncState{
         # StartingBlock is the block number at which synchronisation started.
@@ -323,6 +417,12 @@ const schema string = `
         syncing: SyncState
         # ChainID returns the current chain ID for transaction replay protection.
         chainID: BigInt!
+        # CrossChainEvents returns the cross chain transfers matching the provided filter.
+        crossChainEvents(filter: CrossChainEventFilter!): [CrossChainEvent!]!
+        # EpochProposals returns the epoch proposals matching the provided filter.
+        epochProposals(filter: EpochEventFilter!): [EpochProposal!]!
+        # EpochVotes returns the epoch votes matching the provided filter.
+        epochVotes(filter: EpochEventFilter!): [EpochVote!]!
     }
 
     type Mutation {
@@ -0,0 +1,399 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package graphql
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	scom "github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/common"
+	"github.com/ethereum/go-ethereum/contracts/native/go_abi/node_manager_abi"
+	"github.com/ethereum/go-ethereum/contracts/native/governance/node_manager"
+	"github.com/ethereum/go-ethereum/contracts/native/utils"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/filters"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// nodeManagerABI decodes the epoch events, it's parsed here as the ABI of node manager
+// package is only loaded with the native contracts.
+var nodeManagerABI, _ = abi.JSON(strings.NewReader(node_manager_abi.INodeManagerABI))
+
+// resolveHotstuffExtra returns the hotstuff extra of the block, or nil if the block
+// isn't sealed by the hotstuff engine.
+func (b *Block) resolveHotstuffExtra(ctx context.Context) (*types.Header, *types.HotstuffExtra, error) {
+	header, err := b.resolveHeader(ctx)
+	if err != nil || header == nil {
+		return nil, nil, err
+	}
+	if header.MixDigest != types.HotstuffDigest {
+		return header, nil, nil
+	}
+	extra, err := types.ExtractHotstuffExtra(header)
+	if err != nil {
+		return nil, nil, err
+	}
+	return header, extra, nil
+}
+
+// recoverSealer recovers the address of the validator signing the block hash, both of the
+// proposer seal and committed seals are signed on the hash without committed seals.
+func recoverSealer(hash common.Hash, seal []byte) (common.Address, error) {
+	pubkey, err := crypto.SigToPub(crypto.Keccak256(hash.Bytes()), seal)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return crypto.PubkeyToAddress(*pubkey), nil
+}
+
+func (b *Block) accounts(addrs []common.Address, args BlockNumberArgs) *[]*Account {
+	ret := make([]*Account, 0, len(addrs))
+	for _, addr := range addrs {
+		ret = append(ret, &Account{
+			backend:       b.backend,
+			address:       addr,
+			blockNrOrHash: args.NumberOrLatest(),
+		})
+	}
+	return &ret
+}
+
+func (b *Block) Proposer(ctx context.Context, args BlockNumberArgs) (*Account, error) {
+	header, extra, err := b.resolveHotstuffExtra(ctx)
+	if err != nil || extra == nil || len(extra.Seal) == 0 {
+		return nil, err
+	}
+	proposer, err := recoverSealer(header.Hash(), extra.Seal)
+	if err != nil {
+		return nil, err
+	}
+	return &Account{
+		backend:       b.backend,
+		address:       proposer,
+		blockNrOrHash: args.NumberOrLatest(),
+	}, nil
+}
+
+func (b *Block) CommittedSigners(ctx context.Context, args BlockNumberArgs) (*[]*Account, error) {
+	header, extra, err := b.resolveHotstuffExtra(ctx)
+	if err != nil || extra == nil {
+		return nil, err
+	}
+	hash := header.Hash()
+	signers := make([]common.Address, 0, len(extra.CommittedSeal))
+	for _, seal := range extra.CommittedSeal {
+		signer, err := recoverSealer(hash, seal)
+		if err != nil {
+			return nil, err
+		}
+		signers = append(signers, signer)
+	}
+	return b.accounts(signers, args), nil
+}
+
+func (b *Block) NextValidators(ctx context.Context, args BlockNumberArgs) (*[]*Account, error) {
+	_, extra, err := b.resolveHotstuffExtra(ctx)
+	if err != nil || extra == nil || len(extra.Validators) == 0 {
+		return nil, err
+	}
+	return b.accounts(extra.Validators, args), nil
+}
+
+func (b *Block) EpochID(ctx context.Context) (*hexutil.Uint64, error) {
+	header, extra, err := b.resolveHotstuffExtra(ctx)
+	if err != nil || extra == nil {
+		return nil, err
+	}
+	state, _, err := b.backend.StateAndHeaderByNumberOrHash(ctx, rpc.BlockNumberOrHashWithHash(header.Hash(), false))
+	if err != nil {
+		return nil, err
+	}
+	epoch, err := node_manager.GetEpochByHeight(state, header.Number.Uint64())
+	if err != nil {
+		return nil, err
+	}
+	ret := hexutil.Uint64(epoch.ID)
+	return &ret, nil
+}
+
+// nativeLogs returns the logs of the native contract event in the range.
+func (r *Resolver) nativeLogs(ctx context.Context, from, to *hexutil.Uint64, contract common.Address, event common.Hash) ([]*Log, error) {
+	begin := rpc.LatestBlockNumber.Int64()
+	if from != nil {
+		begin = int64(*from)
+	}
+	end := rpc.LatestBlockNumber.Int64()
+	if to != nil {
+		end = int64(*to)
+	}
+	filter := filters.NewRangeFilter(filters.Backend(r.backend), begin, end, []common.Address{contract}, [][]common.Hash{{event}})
+	return runFilter(ctx, r.backend, filter)
+}
+
+// CrossChainEventFilter encapsulates the arguments to `crossChainEvents`.
+type CrossChainEventFilter struct {
+	FromBlock        *hexutil.Uint64 // beginning of the queried range, nil means latest block
+	ToBlock          *hexutil.Uint64 // end of the range, nil means latest block
+	SourceChain      *hexutil.Uint64 // restricts matches to transfers from the chain
+	DestinationChain *hexutil.Uint64 // restricts matches to transfers to the chain
+}
+
+// CrossChainEvent is the cross chain transaction recorded by the `makeProof` event of
+// the cross chain manager.
+type CrossChainEvent struct {
+	log   *Log
+	value *scom.ToMerkleValue
+	key   []byte
+}
+
+// decodeCrossChainEvent decodes the merkle value and the request key of `makeProof`.
+func decodeCrossChainEvent(l *Log) (*CrossChainEvent, error) {
+	values, err := scom.ABI.Unpack(scom.NOTIFY_MAKE_PROOF_EVENT, l.log.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack makeProof log: %v", err)
+	}
+	raw, err := hex.DecodeString(values[0].(string))
+	if err != nil {
+		return nil, fmt.Errorf("invalid merkle value: %v", err)
+	}
+	value := new(scom.ToMerkleValue)
+	if err := rlp.DecodeBytes(raw, value); err != nil {
+		return nil, fmt.Errorf("invalid merkle value: %v", err)
+	}
+	if value.MakeTxParam == nil {
+		return nil, fmt.Errorf("merkle value without cross chain param")
+	}
+	key, err := hex.DecodeString(values[2].(string))
+	if err != nil {
+		return nil, fmt.Errorf("invalid request key: %v", err)
+	}
+	return &CrossChainEvent{log: l, value: value, key: key}, nil
+}
+
+func (e *CrossChainEvent) Log(ctx context.Context) *Log {
+	return e.log
+}
+
+func (e *CrossChainEvent) SourceChain(ctx context.Context) hexutil.Uint64 {
+	return hexutil.Uint64(e.value.FromChainID)
+}
+
+func (e *CrossChainEvent) DestinationChain(ctx context.Context) hexutil.Uint64 {
+	return hexutil.Uint64(e.value.MakeTxParam.ToChainID)
+}
+
+func (e *CrossChainEvent) TxHash(ctx context.Context) hexutil.Bytes {
+	return e.value.TxHash
+}
+
+func (e *CrossChainEvent) SourceTxHash(ctx context.Context) hexutil.Bytes {
+	return e.value.MakeTxParam.TxHash
+}
+
+func (e *CrossChainEvent) CrossChainID(ctx context.Context) hexutil.Bytes {
+	return e.value.MakeTxParam.CrossChainID
+}
+
+func (e *CrossChainEvent) FromContract(ctx context.Context) hexutil.Bytes {
+	return e.value.MakeTxParam.FromContractAddress
+}
+
+func (e *CrossChainEvent) ToContract(ctx context.Context) hexutil.Bytes {
+	return e.value.MakeTxParam.ToContractAddress
+}
+
+func (e *CrossChainEvent) Method(ctx context.Context) string {
+	return e.value.MakeTxParam.Method
+}
+
+func (e *CrossChainEvent) Args(ctx context.Context) hexutil.Bytes {
+	return e.value.MakeTxParam.Args
+}
+
+func (e *CrossChainEvent) Key(ctx context.Context) hexutil.Bytes {
+	return e.key
+}
+
+func (r *Resolver) CrossChainEvents(ctx context.Context, args struct{ Filter CrossChainEventFilter }) ([]*CrossChainEvent, error) {
+	event := scom.ABI.Events[scom.NOTIFY_MAKE_PROOF_EVENT].ID
+	logs, err := r.nativeLogs(ctx, args.Filter.FromBlock, args.Filter.ToBlock, utils.CrossChainManagerContractAddress, event)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*CrossChainEvent, 0, len(logs))
+	for _, l := range logs {
+		ev, err := decodeCrossChainEvent(l)
+		if err != nil {
+			return nil, err
+		}
+		if src := args.Filter.SourceChain; src != nil && uint64(*src) != ev.value.FromChainID {
+			continue
+		}
+		if dst := args.Filter.DestinationChain; dst != nil && uint64(*dst) != ev.value.MakeTxParam.ToChainID {
+			continue
+		}
+		ret = append(ret, ev)
+	}
+	return ret, nil
+}
+
+// EpochEventFilter encapsulates the arguments to `epochProposals` and `epochVotes`.
+type EpochEventFilter struct {
+	FromBlock *hexutil.Uint64 // beginning of the queried range, nil means latest block
+	ToBlock   *hexutil.Uint64 // end of the range, nil means latest block
+	EpochID   *hexutil.Uint64 // restricts matches to the epoch
+}
+
+// EpochProposal is the epoch proposed to the node manager.
+type EpochProposal struct {
+	log   *Log
+	epoch *node_manager.EpochInfo
+}
+
+func decodeEpochProposal(l *Log) (*EpochProposal, error) {
+	values, err := nodeManagerABI.Unpack(node_manager_abi.EventProposed, l.log.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack %s log: %v", node_manager_abi.EventProposed, err)
+	}
+	epoch := new(node_manager.EpochInfo)
+	if err := rlp.DecodeBytes(values[0].([]byte), epoch); err != nil {
+		return nil, fmt.Errorf("invalid proposed epoch: %v", err)
+	}
+	return &EpochProposal{log: l, epoch: epoch}, nil
+}
+
+func (p *EpochProposal) Log(ctx context.Context) *Log {
+	return p.log
+}
+
+func (p *EpochProposal) EpochID(ctx context.Context) hexutil.Uint64 {
+	return hexutil.Uint64(p.epoch.ID)
+}
+
+func (p *EpochProposal) Hash(ctx context.Context) common.Hash {
+	return p.epoch.Hash()
+}
+
+func (p *EpochProposal) StartHeight(ctx context.Context) hexutil.Uint64 {
+	return hexutil.Uint64(p.epoch.StartHeight)
+}
+
+func (p *EpochProposal) Proposer(ctx context.Context) common.Address {
+	return p.epoch.Proposer
+}
+
+func (p *EpochProposal) Validators(ctx context.Context) []common.Address {
+	var ret []common.Address
+	if p.epoch.Peers != nil {
+		for _, peer := range p.epoch.Peers.List {
+			ret = append(ret, peer.Address)
+		}
+	}
+	return ret
+}
+
+func (r *Resolver) EpochProposals(ctx context.Context, args struct{ Filter EpochEventFilter }) ([]*EpochProposal, error) {
+	event := nodeManagerABI.Events[node_manager_abi.EventProposed].ID
+	logs, err := r.nativeLogs(ctx, args.Filter.FromBlock, args.Filter.ToBlock, utils.NodeManagerContractAddress, event)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*EpochProposal, 0, len(logs))
+	for _, l := range logs {
+		proposal, err := decodeEpochProposal(l)
+		if err != nil {
+			return nil, err
+		}
+		if id := args.Filter.EpochID; id != nil && uint64(*id) != proposal.epoch.ID {
+			continue
+		}
+		ret = append(ret, proposal)
+	}
+	return ret, nil
+}
+
+// EpochVote is the vote of a validator for the proposed epoch.
+type EpochVote struct {
+	log         *Log
+	epochID     uint64
+	epochHash   common.Hash
+	votedNumber uint64
+	groupSize   uint64
+}
+
+func decodeEpochVote(l *Log) (*EpochVote, error) {
+	values, err := nodeManagerABI.Unpack(node_manager_abi.EventVoted, l.log.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack %s log: %v", node_manager_abi.EventVoted, err)
+	}
+	return &EpochVote{
+		log:         l,
+		epochID:     values[0].(uint64),
+		epochHash:   common.BytesToHash(values[1].([]byte)),
+		votedNumber: values[2].(uint64),
+		groupSize:   values[3].(uint64),
+	}, nil
+}
+
+func (v *EpochVote) Log(ctx context.Context) *Log {
+	return v.log
+}
+
+func (v *EpochVote) EpochID(ctx context.Context) hexutil.Uint64 {
+	return hexutil.Uint64(v.epochID)
+}
+
+func (v *EpochVote) EpochHash(ctx context.Context) common.Hash {
+	return v.epochHash
+}
+
+func (v *EpochVote) VotedNumber(ctx context.Context) hexutil.Uint64 {
+	return hexutil.Uint64(v.votedNumber)
+}
+
+func (v *EpochVote) GroupSize(ctx context.Context) hexutil.Uint64 {
+	return hexutil.Uint64(v.groupSize)
+}
+
+func (r *Resolver) EpochVotes(ctx context.Context, args struct{ Filter EpochEventFilter }) ([]*EpochVote, error) {
+	event := nodeManagerABI.Events[node_manager_abi.EventVoted].ID
+	logs, err := r.nativeLogs(ctx, args.Filter.FromBlock, args.Filter.ToBlock, utils.NodeManagerContractAddress, event)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*EpochVote, 0, len(logs))
+	for _, l := range logs {
+		vote, err := decodeEpochVote(l)
+		if err != nil {
+			return nil, err
+		}
+		if id := args.Filter.EpochID; id != nil && uint64(*id) != vote.epochID {
+			continue
+		}
+		ret = append(ret, vote)
+	}
+	return ret, nil
+}
@@ -0,0 +1,182 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	scom "github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/common"
+	"github.com/ethereum/go-ethereum/contracts/native/go_abi/node_manager_abi"
+	"github.com/ethereum/go-ethereum/contracts/native/governance/node_manager"
+	"github.com/ethereum/go-ethereum/contracts/native/utils"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+func sealHotstuffHeader(t *testing.T, header *types.Header, proposer *ecdsa.PrivateKey, committers []*ecdsa.PrivateKey) {
+	sign := func(key *ecdsa.PrivateKey) []byte {
+		sig, err := crypto.Sign(crypto.Keccak256(header.Hash().Bytes()), key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sig
+	}
+	extra, err := types.ExtractHotstuffExtra(header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	extra.Seal = sign(proposer)
+	for _, key := range committers {
+		extra.CommittedSeal = append(extra.CommittedSeal, sign(key))
+	}
+	payload, err := rlp.EncodeToBytes(&extra)
+	if err != nil {
+		t.Fatal(err)
+	}
+	header.Extra = append(header.Extra[:types.HotstuffExtraVanity], payload...)
+}
+
+func TestHotstuffBlockFields(t *testing.T) {
+	var (
+		keys  []*ecdsa.PrivateKey
+		addrs []common.Address
+	)
+	for i := 0; i < 4; i++ {
+		key, _ := crypto.GenerateKey()
+		keys = append(keys, key)
+		addrs = append(addrs, crypto.PubkeyToAddress(key.PublicKey))
+	}
+	header := &types.Header{Number: big.NewInt(10), MixDigest: types.HotstuffDigest, Difficulty: big.NewInt(1)}
+	if err := types.HotstuffHeaderFillWithValidators(header, addrs[1:]); err != nil {
+		t.Fatal(err)
+	}
+	sealHotstuffHeader(t, header, keys[0], keys[:3])
+
+	ctx := context.Background()
+	block := &Block{hash: header.Hash(), header: header}
+	proposer, err := block.Proposer(ctx, BlockNumberArgs{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if proposer == nil || proposer.address != addrs[0] {
+		t.Fatalf("wrong proposer: %v", proposer)
+	}
+	signers, err := block.CommittedSigners(ctx, BlockNumberArgs{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signers == nil || len(*signers) != 3 {
+		t.Fatalf("wrong committed signers: %v", signers)
+	}
+	for i, signer := range *signers {
+		if signer.address != addrs[i] {
+			t.Errorf("committed signer %d mismatch: have %x, want %x", i, signer.address, addrs[i])
+		}
+	}
+	validators, err := block.NextValidators(ctx, BlockNumberArgs{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if validators == nil || len(*validators) != 3 || (*validators)[0].address != addrs[1] {
+		t.Fatalf("wrong next validators: %v", validators)
+	}
+
+	// Blocks not sealed by hotstuff have none of the fields
+	plain := &Block{hash: common.Hash{1}, header: &types.Header{Number: big.NewInt(1)}}
+	if proposer, err := plain.Proposer(ctx, BlockNumberArgs{}); err != nil || proposer != nil {
+		t.Fatalf("unexpected proposer of plain block: %v %v", proposer, err)
+	}
+	if id, err := plain.EpochID(ctx); err != nil || id != nil {
+		t.Fatalf("unexpected epoch of plain block: %v %v", id, err)
+	}
+}
+
+func TestDecodeNativeEvents(t *testing.T) {
+	param := &scom.MakeTxParam{
+		TxHash:              []byte{1},
+		CrossChainID:        []byte{2},
+		FromContractAddress: []byte{3},
+		ToChainID:           77,
+		ToContractAddress:   []byte{4},
+		Method:              "unlock",
+		Args:                []byte{5},
+	}
+	value, err := rlp.EncodeToBytes(&scom.ToMerkleValue{TxHash: []byte{6}, FromChainID: 2, MakeTxParam: param})
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := utils.ConcatKey(utils.CrossChainManagerContractAddress, []byte("request"), utils.GetUint64Bytes(77), []byte{6})
+	data, err := utils.PackEvents(scom.ABI, scom.NOTIFY_MAKE_PROOF_EVENT, hex.EncodeToString(value), uint64(10), hex.EncodeToString(key))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ev, err := decodeCrossChainEvent(&Log{log: &types.Log{Data: data}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	if ev.SourceChain(ctx) != 2 || ev.DestinationChain(ctx) != 77 || ev.Method(ctx) != "unlock" || !bytes.Equal(ev.Key(ctx), key) {
+		t.Fatalf("wrong cross chain event: %v %v", ev.value, ev.key)
+	}
+
+	epoch := &node_manager.EpochInfo{
+		ID:          3,
+		Peers:       &node_manager.Peers{List: []*node_manager.PeerInfo{{PubKey: "0x02", Address: common.Address{7}}}},
+		StartHeight: 400,
+		Proposer:    common.Address{8},
+	}
+	enc, err := rlp.EncodeToBytes(epoch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err = utils.PackEvents(&nodeManagerABI, node_manager_abi.EventProposed, enc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proposal, err := decodeEpochProposal(&Log{log: &types.Log{Data: data}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if proposal.EpochID(ctx) != 3 || proposal.StartHeight(ctx) != 400 || proposal.Proposer(ctx) != (common.Address{8}) {
+		t.Fatalf("wrong epoch proposal: %v", proposal.epoch)
+	}
+	if validators := proposal.Validators(ctx); len(validators) != 1 || validators[0] != (common.Address{7}) {
+		t.Fatalf("wrong proposed validators: %v", validators)
+	}
+
+	hash := epoch.Hash()
+	data, err = utils.PackEvents(&nodeManagerABI, node_manager_abi.EventVoted, uint64(3), hash.Bytes(), uint64(2), uint64(4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	vote, err := decodeEpochVote(&Log{log: &types.Log{Data: data}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vote.EpochID(ctx) != 3 || vote.EpochHash(ctx) != hash || vote.VotedNumber(ctx) != 2 || vote.GroupSize(ctx) != 4 {
+		t.Fatalf("wrong epoch vote: %+v", vote)
+	}
+}
@@ -34,6 +34,7 @@ var Modules = map[string]string{
 	"txpool":     TxpoolJs,
 	"les":        LESJs,
 	"vflux":      VfluxJs,
+	"zion":       ZionJs,
 }
 
 const ChequebookJs = `
@@ -925,3 +926,16 @@ web3._extend({
 	]
 });
 `
+
+const ZionJs = `
+web3._extend({
+	property: 'zion',
+	methods: [
+		new web3._extend.Method({
+			name: 'getCrossChainProof',
+			call: 'zion_getCrossChainProof',
+			params: 1
+		}),
+	]
+});
+`
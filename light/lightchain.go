@@ -57,9 +57,12 @@ type LightChain struct {
 	chainFeed     event.Feed
 	chainSideFeed event.Feed
 	chainHeadFeed event.Feed
+	finalizedFeed event.Feed
 	scope         event.SubscriptionScope
 	genesisBlock  *types.Block
 
+	currentFinalized atomic.Value // Current head of the committed header chain, only tracked by HotStuff
+
 	bodyCache    *lru.Cache // Cache for the most recent block bodies
 	bodyRLPCache *lru.Cache // Cache for the most recent block bodies in RLP encoded format
 	blockCache   *lru.Cache // Cache for the most recent entire blocks
@@ -92,6 +95,9 @@ func NewLightChain(odr OdrBackend, config *params.ChainConfig, engine consensus.
 		blockCache:    blockCache,
 		engine:        engine,
 	}
+	var nilHeader *types.Header
+	bc.currentFinalized.Store(nilHeader)
+
 	var err error
 	bc.hc, err = core.NewHeaderChain(odr.Database(), config, bc.engine, bc.getProcInterrupt)
 	if err != nil {
@@ -163,6 +169,20 @@ func (lc *LightChain) loadLastState() error {
 			lc.hc.SetCurrentHeader(header)
 		}
 	}
+	// Restore the last known finalized header, which never runs ahead of the
+	// head header even if the chain was rewound beneath it
+	if _, ok := lc.engine.(consensus.HotStuff); ok {
+		finalized := lc.genesisBlock.Header()
+		if head := rawdb.ReadHeadFinalizedBlockHash(lc.chainDb); head != (common.Hash{}) {
+			if header := lc.GetHeaderByHash(head); header != nil {
+				finalized = header
+			}
+		}
+		if current := lc.hc.CurrentHeader(); finalized.Number.Uint64() > current.Number.Uint64() {
+			finalized = current
+		}
+		lc.currentFinalized.Store(finalized)
+	}
 	// Issue a status log and return
 	header := lc.hc.CurrentHeader()
 	headerTd := lc.GetTd(header.Hash(), header.Number.Uint64())
@@ -210,6 +230,10 @@ func (lc *LightChain) ResetWithGenesisBlock(genesis *types.Block) {
 	lc.genesisBlock = genesis
 	lc.hc.SetGenesis(lc.genesisBlock.Header())
 	lc.hc.SetCurrentHeader(lc.genesisBlock.Header())
+	if _, ok := lc.engine.(consensus.HotStuff); ok {
+		rawdb.WriteHeadFinalizedBlockHash(lc.chainDb, lc.genesisBlock.Hash())
+		lc.currentFinalized.Store(lc.genesisBlock.Header())
+	}
 }
 
 // Accessors
@@ -347,6 +371,10 @@ func (lc *LightChain) Rollback(chain []common.Hash) {
 			rawdb.WriteHeadHeaderHash(batch, head.ParentHash)
 			lc.hc.SetCurrentHeader(lc.GetHeader(head.ParentHash, head.Number.Uint64()-1))
 		}
+		if final := lc.CurrentFinalizedHeader(); final != nil && final.Hash() == hash {
+			rawdb.WriteHeadFinalizedBlockHash(batch, final.ParentHash)
+			lc.currentFinalized.Store(lc.GetHeader(final.ParentHash, final.Number.Uint64()-1))
+		}
 	}
 	if err := batch.Write(); err != nil {
 		log.Crit("Failed to rollback light chain", "error", err)
@@ -415,15 +443,41 @@ func (lc *LightChain) InsertHeaderChain(chain []*types.Header, checkFreq int) (i
 	}
 	lc.postChainEvents(events)
 
+	// Headers with verified seals are final as well.
+	if status == core.CanonStatTy && checkFreq != 0 {
+		lc.writeFinalizedHeader(lastHeader)
+	}
 	return 0, err
 }
 
+// writeFinalizedHeader advances the finalized head to the given header if the
+// consensus engine provides finality. It expects the chain mutex to be held.
+func (lc *LightChain) writeFinalizedHeader(header *types.Header) {
+	if _, ok := lc.engine.(consensus.HotStuff); !ok {
+		return
+	}
+	if current := lc.CurrentFinalizedHeader(); current != nil && current.Number.Uint64() >= header.Number.Uint64() {
+		return
+	}
+	rawdb.WriteHeadFinalizedBlockHash(lc.chainDb, header.Hash())
+	lc.currentFinalized.Store(header)
+
+	lc.finalizedFeed.Send(core.FinalizedHeadEvent{Block: types.NewBlockWithHeader(header)})
+}
+
 // CurrentHeader retrieves the current head header of the canonical chain. The
 // header is retrieved from the HeaderChain's internal cache.
 func (lc *LightChain) CurrentHeader() *types.Header {
 	return lc.hc.CurrentHeader()
 }
 
+// CurrentFinalizedHeader retrieves the latest header whose committed seals have
+// been verified. It returns nil if the consensus engine has no notion of
+// finality.
+func (lc *LightChain) CurrentFinalizedHeader() *types.Header {
+	return lc.currentFinalized.Load().(*types.Header)
+}
+
 // GetTd retrieves a block's total difficulty in the canonical chain from the
 // database by hash and number, caching it if found.
 func (lc *LightChain) GetTd(hash common.Hash, number uint64) *big.Int {
@@ -556,6 +610,11 @@ func (lc *LightChain) SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) eve
 	return lc.scope.Track(lc.chainHeadFeed.Subscribe(ch))
 }
 
+// SubscribeFinalizedHeadEvent registers a subscription of FinalizedHeadEvent.
+func (lc *LightChain) SubscribeFinalizedHeadEvent(ch chan<- core.FinalizedHeadEvent) event.Subscription {
+	return lc.scope.Track(lc.finalizedFeed.Subscribe(ch))
+}
+
 // SubscribeChainSideEvent registers a subscription of ChainSideEvent.
 func (lc *LightChain) SubscribeChainSideEvent(ch chan<- core.ChainSideEvent) event.Subscription {
 	return lc.scope.Track(lc.chainSideFeed.Subscribe(ch))
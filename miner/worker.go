@@ -1103,6 +1103,8 @@ func (w *worker) initChangingEpoch() {
 
 	log.Debug("init changing epoch...")
 	ref := native.NewContractRef(statedb, caller, caller, parent.Number(), common.EmptyHash, 0, nil)
+	ref.SetNativeUpgrades(w.chainConfig.NativeUpgrades)
+	ref.SetZionConfig(w.chainConfig.ZionConfig())
 	payload, err := new(nm.MethodGetChangingEpochInput).Encode()
 	if err != nil {
 		log.Error("[miner worker]", "pack `getChangingEpoch` input failed", err)
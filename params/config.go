@@ -58,12 +58,17 @@ var (
 	TestnetMainChainID uint64 = 3
 	DevnetMainChainID  uint64 = 10897
 
+	// LegacyRelayChainID cross chain id of the relay chain which genesis has no zion config
+	LegacyRelayChainID uint64 = 1
+
 	OneEth, _ = new(big.Int).SetString("1000000000000000000", 10)
 	// mint some native token for side chain genesis validators, because genesis validators will spent
 	// gas while deploy cross chain contracts, and the default value is 100 eth
 	SideChainInitAlloc = new(big.Int).Mul(OneEth, big.NewInt(100))
 )
 
+// IsMainChain is the legacy rule to tell the chain role by chain id, it is only used
+// for chains which genesis has no zion config.
 func IsMainChain(chainID uint64) bool {
 	switch chainID {
 	case MainnetMainChainID, TestnetMainChainID, DevnetMainChainID:
@@ -264,16 +269,16 @@ var (
 	//
 	// This configuration is intentionally not using keyed fields to force anyone
 	// adding flags to the config to also have to set these fields.
-	AllEthashProtocolChanges = &ChainConfig{big.NewInt(1337), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, nil, nil, new(EthashConfig), nil, nil}
+	AllEthashProtocolChanges = &ChainConfig{big.NewInt(1337), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, nil, nil, new(EthashConfig), nil, nil, nil, nil}
 
 	// AllCliqueProtocolChanges contains every protocol change (EIPs) introduced
 	// and accepted by the Ethereum core developers into the Clique consensus.
 	//
 	// This configuration is intentionally not using keyed fields to force anyone
 	// adding flags to the config to also have to set these fields.
-	AllCliqueProtocolChanges = &ChainConfig{big.NewInt(1337), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, nil, nil, nil, &CliqueConfig{Period: 0, Epoch: 30000}, nil}
+	AllCliqueProtocolChanges = &ChainConfig{big.NewInt(1337), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, nil, nil, nil, &CliqueConfig{Period: 0, Epoch: 30000}, nil, nil, nil}
 
-	TestChainConfig = &ChainConfig{big.NewInt(1), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, nil, nil, new(EthashConfig), nil, nil}
+	TestChainConfig = &ChainConfig{big.NewInt(1), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, nil, nil, new(EthashConfig), nil, nil, nil, nil}
 	TestRules       = TestChainConfig.Rules(new(big.Int))
 )
 
@@ -360,6 +365,75 @@ type ChainConfig struct {
 	Ethash   *EthashConfig   `json:"ethash,omitempty"`
 	Clique   *CliqueConfig   `json:"clique,omitempty"`
 	HotStuff *HotStuffConfig `json:"hotstuff"`
+
+	// Role of the chain in zion cross chain network
+	Zion *ZionConfig `json:"zion,omitempty"`
+
+	// Native contract implementations activated at coordinated heights
+	NativeUpgrades []*NativeUpgrade `json:"nativeUpgrades,omitempty"`
+}
+
+const (
+	ZionRoleMain = "main" // relay chain of zion cross chain network
+	ZionRoleSide = "side" // side chain connected to the relay chain
+)
+
+// ZionConfig declares the role of the chain, the chain ids used in cross chain
+// protocol and the native contracts deployed in genesis.
+type ZionConfig struct {
+	Role            string   `json:"role"`                      // `main` or `side`
+	CrossChainID    uint64   `json:"crossChainID"`              // Chain id of this chain in cross chain protocol
+	RelayChainID    uint64   `json:"relayChainID,omitempty"`    // Cross chain id of the relay chain, same as CrossChainID on main chain
+	NativeContracts []string `json:"nativeContracts,omitempty"` // Enabled native contracts, default by role if empty
+}
+
+// IsMainChain returns whether the chain works as the relay chain.
+func (c *ZionConfig) IsMainChain() bool {
+	return c.Role == ZionRoleMain
+}
+
+// Validate checks the role and cross chain ids.
+func (c *ZionConfig) Validate() error {
+	switch c.Role {
+	case ZionRoleMain:
+		if c.RelayChainID != c.CrossChainID {
+			return fmt.Errorf("zion main chain relay chain id %d should be equal to cross chain id %d", c.RelayChainID, c.CrossChainID)
+		}
+	case ZionRoleSide:
+		if c.RelayChainID == 0 || c.RelayChainID == c.CrossChainID {
+			return fmt.Errorf("zion side chain relay chain id %d invalid", c.RelayChainID)
+		}
+	default:
+		return fmt.Errorf("unknown zion chain role %q", c.Role)
+	}
+	if c.CrossChainID == 0 {
+		return fmt.Errorf("zion cross chain id should not be 0")
+	}
+	return nil
+}
+
+// ZionConfig returns the zion chain role configuration. chains initialized before
+// the role declared in genesis are resolved by the legacy chain id rules.
+func (c *ChainConfig) ZionConfig() *ZionConfig {
+	if c != nil && c.Zion != nil {
+		cfg := *c.Zion
+		if cfg.Role == ZionRoleMain && cfg.RelayChainID == 0 {
+			cfg.RelayChainID = cfg.CrossChainID
+		}
+		return &cfg
+	}
+	if c == nil || c.ChainID == nil || IsMainChain(c.ChainID.Uint64()) {
+		return &ZionConfig{Role: ZionRoleMain, CrossChainID: LegacyRelayChainID, RelayChainID: LegacyRelayChainID}
+	}
+	return &ZionConfig{Role: ZionRoleSide, CrossChainID: c.ChainID.Uint64(), RelayChainID: LegacyRelayChainID}
+}
+
+// NativeUpgrade activates implementation `Version` of the native contract deployed
+// at `Contract` from block `Block`.
+type NativeUpgrade struct {
+	Contract common.Address `json:"contract"`
+	Version  uint64         `json:"version"`
+	Block    *big.Int       `json:"block"`
 }
 
 // EthashConfig is the consensus engine configs for proof-of-work based sealing.
@@ -606,6 +680,44 @@ func (c *ChainConfig) checkCompatible(newcfg *ChainConfig, head *big.Int) *Confi
 	if isForkIncompatible(c.EWASMBlock, newcfg.EWASMBlock, head) {
 		return newCompatError("ewasm fork block", c.EWASMBlock, newcfg.EWASMBlock)
 	}
+	if err := checkNativeUpgradesCompatible(c.NativeUpgrades, newcfg.NativeUpgrades, head); err != nil {
+		return err
+	}
+	return nil
+}
+
+// CheckZionCompatible checks whether the zion chain role and cross chain ids are kept.
+// they are fixed by the genesis state and can not be changed by rewinding the chain.
+func (c *ChainConfig) CheckZionCompatible(newcfg *ChainConfig) error {
+	zc, newzc := c.ZionConfig(), newcfg.ZionConfig()
+	if zc.Role != newzc.Role || zc.CrossChainID != newzc.CrossChainID || zc.RelayChainID != newzc.RelayChainID {
+		return fmt.Errorf("mismatching zion chain role in database (have %s %d/%d, want %s %d/%d)",
+			zc.Role, zc.CrossChainID, zc.RelayChainID, newzc.Role, newzc.CrossChainID, newzc.RelayChainID)
+	}
+	return nil
+}
+
+// checkNativeUpgradesCompatible ensures that no native contract upgrade which already
+// activated at head is removed or rescheduled.
+func checkNativeUpgradesCompatible(stored, upgrades []*NativeUpgrade, head *big.Int) *ConfigCompatError {
+	find := func(list []*NativeUpgrade, target *NativeUpgrade) *big.Int {
+		for _, v := range list {
+			if v.Contract == target.Contract && v.Version == target.Version {
+				return v.Block
+			}
+		}
+		return nil
+	}
+	for _, v := range stored {
+		if block := find(upgrades, v); isForkIncompatible(v.Block, block, head) {
+			return newCompatError(fmt.Sprintf("native upgrade %s v%d", v.Contract.Hex(), v.Version), v.Block, block)
+		}
+	}
+	for _, v := range upgrades {
+		if block := find(stored, v); isForkIncompatible(block, v.Block, head) {
+			return newCompatError(fmt.Sprintf("native upgrade %s v%d", v.Contract.Hex(), v.Version), block, v.Block)
+		}
+	}
 	return nil
 }
 
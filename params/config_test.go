@@ -96,3 +96,57 @@ func TestCheckCompatible(t *testing.T) {
 		}
 	}
 }
+
+func TestZionConfig(t *testing.T) {
+	tests := []struct {
+		config *ChainConfig
+		want   *ZionConfig
+	}{
+		{
+			config: &ChainConfig{ChainID: big.NewInt(int64(DevnetMainChainID))},
+			want:   &ZionConfig{Role: ZionRoleMain, CrossChainID: LegacyRelayChainID, RelayChainID: LegacyRelayChainID},
+		},
+		{
+			config: &ChainConfig{ChainID: big.NewInt(2)},
+			want:   &ZionConfig{Role: ZionRoleSide, CrossChainID: 2, RelayChainID: LegacyRelayChainID},
+		},
+		{
+			config: &ChainConfig{ChainID: big.NewInt(1), Zion: &ZionConfig{Role: ZionRoleMain, CrossChainID: 100}},
+			want:   &ZionConfig{Role: ZionRoleMain, CrossChainID: 100, RelayChainID: 100},
+		},
+		{
+			config: &ChainConfig{ChainID: big.NewInt(1), Zion: &ZionConfig{Role: ZionRoleSide, CrossChainID: 1, RelayChainID: 100}},
+			want:   &ZionConfig{Role: ZionRoleSide, CrossChainID: 1, RelayChainID: 100},
+		},
+	}
+	for i, test := range tests {
+		got := test.config.ZionConfig()
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("test %d: zion config mismatch, have %+v, want %+v", i, got, test.want)
+		}
+		if err := got.Validate(); err != nil {
+			t.Errorf("test %d: validate failed: %v", i, err)
+		}
+	}
+
+	invalid := []*ZionConfig{
+		{Role: "relay", CrossChainID: 1, RelayChainID: 1},
+		{Role: ZionRoleMain, CrossChainID: 0},
+		{Role: ZionRoleMain, CrossChainID: 1, RelayChainID: 2},
+		{Role: ZionRoleSide, CrossChainID: 2},
+		{Role: ZionRoleSide, CrossChainID: 2, RelayChainID: 2},
+	}
+	for i, config := range invalid {
+		if err := config.Validate(); err == nil {
+			t.Errorf("test %d: expect invalid config %+v", i, config)
+		}
+	}
+
+	stored := &ChainConfig{ChainID: big.NewInt(1)}
+	if err := stored.CheckZionCompatible(&ChainConfig{ChainID: big.NewInt(1), Zion: &ZionConfig{Role: ZionRoleMain, CrossChainID: 1}}); err != nil {
+		t.Errorf("legacy role declared in genesis should be compatible: %v", err)
+	}
+	if err := stored.CheckZionCompatible(&ChainConfig{ChainID: big.NewInt(1), Zion: &ZionConfig{Role: ZionRoleMain, CrossChainID: 2}}); err == nil {
+		t.Errorf("changed cross chain id should be incompatible")
+	}
+}
@@ -136,6 +136,14 @@ func (ui *CommandlineUI) ApproveTx(request *SignTxRequest) (SignTxResponse, erro
 			fmt.Printf("data:     %v\n", hexutil.Encode(d))
 		}
 	}
+	if call := request.NativeCall; call != nil {
+		fmt.Printf("\nNative contract call:\n")
+		fmt.Printf("  contract: %s (%v)\n", call.Contract, call.Address.Hex())
+		fmt.Printf("  method:   %s\n", call.Method)
+		if args, err := json.MarshalIndent(call.Args, "  ", "  "); err == nil {
+			fmt.Printf("  args:     %s\n", args)
+		}
+	}
 	if request.Callinfo != nil {
 		fmt.Printf("\nTransaction validation:\n")
 		for _, m := range request.Callinfo {
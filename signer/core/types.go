@@ -32,7 +32,18 @@ type ValidationInfo struct {
 	Message string `json:"message"`
 }
 type ValidationMessages struct {
-	Messages []ValidationInfo
+	Messages   []ValidationInfo
+	NativeCall *NativeCall
+}
+
+// NativeCall is the decoded call to a Zion native contract, the rlp or json encoded
+// payloads nested in the arguments are decoded as well, so that they can be reviewed
+// and matched by rules.
+type NativeCall struct {
+	Contract string                 `json:"contract"`
+	Address  common.Address         `json:"address"`
+	Method   string                 `json:"method"`
+	Args     map[string]interface{} `json:"args"`
 }
 
 const (
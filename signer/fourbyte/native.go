@@ -0,0 +1,273 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package fourbyte
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	scom "github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/common"
+	"github.com/ethereum/go-ethereum/contracts/native/go_abi/cross_chain_manager_abi"
+	"github.com/ethereum/go-ethereum/contracts/native/go_abi/header_sync_abi"
+	"github.com/ethereum/go-ethereum/contracts/native/go_abi/main_chain_lock_proxy_abi"
+	"github.com/ethereum/go-ethereum/contracts/native/go_abi/neo3_state_manager_abi"
+	"github.com/ethereum/go-ethereum/contracts/native/go_abi/node_manager_abi"
+	"github.com/ethereum/go-ethereum/contracts/native/go_abi/relayer_manager_abi"
+	"github.com/ethereum/go-ethereum/contracts/native/go_abi/side_chain_lock_proxy_abi"
+	"github.com/ethereum/go-ethereum/contracts/native/go_abi/side_chain_manager_abi"
+	"github.com/ethereum/go-ethereum/contracts/native/governance/node_manager"
+	"github.com/ethereum/go-ethereum/contracts/native/utils"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/signer/core"
+)
+
+// nestedDecoder decodes the rlp or json payload carried by a bytes argument.
+type nestedDecoder func(value interface{}) (interface{}, error)
+
+// nativeContract is a Zion native contract whose calls are decoded for review.
+type nativeContract struct {
+	name   string
+	abis   []*abi.ABI                          // ABIs of the contract, lock proxy has different ones on main and side chains
+	nested map[string]map[string]nestedDecoder // Decoders of the nested payloads by method and argument
+}
+
+var nativeContracts = map[common.Address]*nativeContract{
+	utils.NodeManagerContractAddress: {
+		name: "node manager",
+		abis: []*abi.ABI{mustParseABI(node_manager_abi.INodeManagerABI)},
+		nested: map[string]map[string]nestedDecoder{
+			node_manager_abi.MethodPropose: {"peers": decodePeers},
+		},
+	},
+	utils.SideChainManagerContractAddress: {
+		name: "side chain manager",
+		abis: []*abi.ABI{mustParseABI(side_chain_manager_abi.SideChainManagerABI)},
+	},
+	utils.RelayerManagerContractAddress: {
+		name: "relayer manager",
+		abis: []*abi.ABI{mustParseABI(relayer_manager_abi.RelayerManagerABI)},
+	},
+	utils.Neo3StateManagerContractAddress: {
+		name: "neo3 state manager",
+		abis: []*abi.ABI{mustParseABI(neo3_state_manager_abi.Neo3StateManagerABI)},
+	},
+	utils.HeaderSyncContractAddress: {
+		name: "header sync",
+		abis: []*abi.ABI{mustParseABI(header_sync_abi.HeaderSyncABI)},
+		nested: map[string]map[string]nestedDecoder{
+			header_sync_abi.MethodSyncGenesisHeader: {"GenesisHeader": decodeJSON},
+			header_sync_abi.MethodSyncBlockHeader:   {"Headers": decodeJSON},
+		},
+	},
+	utils.CrossChainManagerContractAddress: {
+		name: "cross chain manager",
+		abis: []*abi.ABI{mustParseABI(cross_chain_manager_abi.CrossChainManagerABI)},
+		nested: map[string]map[string]nestedDecoder{
+			cross_chain_manager_abi.MethodImportOuterTransfer: {
+				"Proof":                 decodeJSON,
+				"Extra":                 decodeTxParam,
+				"HeaderOrCrossChainMsg": decodeJSON,
+			},
+		},
+	},
+	utils.LockProxyContractAddress: {
+		name: "lock proxy",
+		abis: []*abi.ABI{
+			mustParseABI(main_chain_lock_proxy_abi.IMainChainLockProxyABI),
+			mustParseABI(side_chain_lock_proxy_abi.ISideChainLockProxyABI),
+		},
+		nested: map[string]map[string]nestedDecoder{
+			side_chain_lock_proxy_abi.MethodMint:   {"argsBs": decodeTxArgs},
+			side_chain_lock_proxy_abi.MethodRefund: {"argsBs": decodeTxArgs},
+			side_chain_lock_proxy_abi.MethodSettle: {"argsBs": decodeTxArgs},
+		},
+	},
+}
+
+func mustParseABI(str string) *abi.ABI {
+	ab, err := abi.JSON(strings.NewReader(str))
+	if err != nil {
+		panic(fmt.Sprintf("failed to load abi json string: [%v]", err))
+	}
+	return &ab
+}
+
+// validateNativeCall decodes the call to the native contract, the call is rejected with
+// a warning if it can't be decoded with the native contract ABI.
+func validateNativeCall(contract *nativeContract, to common.Address, data []byte, messages *core.ValidationMessages) {
+	if len(data) == 0 {
+		messages.Warn(fmt.Sprintf("Transaction sends value to the %s native contract without calling it", contract.name))
+		return
+	}
+	call, sig, errs := decodeNativeCall(contract, to, data)
+	if call == nil {
+		messages.Warn(fmt.Sprintf("Transaction data could not be decoded as a call of the %s native contract: %v", contract.name, errs[0]))
+		return
+	}
+	for _, err := range errs {
+		messages.Warn(fmt.Sprintf("Transaction invokes the %s native contract with undecodable payload: %v", contract.name, err))
+	}
+	messages.Info(fmt.Sprintf("Transaction invokes the %s native contract method: %q", contract.name, sig))
+	messages.NativeCall = call
+}
+
+// decodeNativeCall unpacks the ABI arguments and the nested payloads of the call, nil
+// call is returned if the method can't be unpacked, and the errors of nested payloads
+// are returned with the raw payloads left in the arguments.
+func decodeNativeCall(contract *nativeContract, to common.Address, data []byte) (*core.NativeCall, string, []error) {
+	if len(data) < 4 {
+		return nil, "", []error{fmt.Errorf("missing the 4 byte call prefix")}
+	}
+	var method *abi.Method
+	for _, ab := range contract.abis {
+		if m, err := ab.MethodById(data[:4]); err == nil {
+			method = m
+			break
+		}
+	}
+	if method == nil {
+		return nil, "", []error{fmt.Errorf("method %x not found", data[:4])}
+	}
+	args := make(map[string]interface{})
+	if err := method.Inputs.UnpackIntoMap(args, data[4:]); err != nil {
+		return nil, "", []error{fmt.Errorf("failed to unpack %s: %v", method.Name, err)}
+	}
+
+	var errs []error
+	for name, value := range args {
+		if decode, ok := contract.nested[method.Name][name]; ok {
+			nested, err := decode(value)
+			if err == nil {
+				args[name] = nested
+				continue
+			}
+			errs = append(errs, fmt.Errorf("argument %s of %s: %v", name, method.Name, err))
+		}
+		args[name] = readable(value)
+	}
+	call := &core.NativeCall{
+		Contract: contract.name,
+		Address:  to,
+		Method:   method.Name,
+		Args:     args,
+	}
+	return call, method.Sig, errs
+}
+
+// readable converts the unpacked value into the form shown in json as other fields of
+// the transaction, e.g. bytes in hex instead of base64.
+func readable(value interface{}) interface{} {
+	switch v := value.(type) {
+	case []byte:
+		return hexutil.Bytes(v)
+	case [][]byte:
+		list := make([]hexutil.Bytes, len(v))
+		for i, b := range v {
+			list[i] = b
+		}
+		return list
+	case *big.Int:
+		return (*hexutil.Big)(v)
+	default:
+		return value
+	}
+}
+
+// nativePeer is the readable form of node_manager.PeerInfo.
+type nativePeer struct {
+	PubKey  string         `json:"pubKey"`
+	Address common.Address `json:"address"`
+}
+
+// decodePeers decodes the rlp encoded epoch members of the `propose` method.
+func decodePeers(value interface{}) (interface{}, error) {
+	peers := new(node_manager.Peers)
+	if err := rlp.DecodeBytes(value.([]byte), peers); err != nil {
+		return nil, err
+	}
+	list := make([]nativePeer, len(peers.List))
+	for i, peer := range peers.List {
+		list[i] = nativePeer{PubKey: peer.PubKey, Address: peer.Address}
+	}
+	return list, nil
+}
+
+// decodeTxArgs decodes the rlp encoded transfer arguments of the `mint`, `refund` and `settle`
+// methods, the relayer fee and transfer id are shown only if the transfer carries them.
+func decodeTxArgs(value interface{}) (interface{}, error) {
+	args := new(scom.TxArgs)
+	if err := rlp.DecodeBytes(value.([]byte), args); err != nil {
+		return nil, err
+	}
+	decoded := map[string]interface{}{
+		"toAssetHash": hexutil.Bytes(args.ToAssetHash),
+		"toAddress":   hexutil.Bytes(args.ToAddress),
+		"amount":      (*hexutil.Big)(args.Amount),
+	}
+	if args.HasFee() {
+		decoded["fee"] = (*hexutil.Big)(args.Fee)
+		decoded["feeDeadline"] = args.FeeDeadline
+	}
+	if len(args.TransferID) > 0 {
+		decoded["transferID"] = hexutil.Bytes(args.TransferID)
+	}
+	return decoded, nil
+}
+
+// decodeTxParam decodes the cross chain transaction carried by `importOuterTransfer`.
+func decodeTxParam(value interface{}) (interface{}, error) {
+	param, err := scom.DecodeTxParam(value.([]byte))
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"txHash":              hexutil.Bytes(param.TxHash),
+		"crossChainID":        hexutil.Bytes(param.CrossChainID),
+		"fromContractAddress": hexutil.Bytes(param.FromContractAddress),
+		"toChainID":           param.ToChainID,
+		"toContractAddress":   hexutil.Bytes(param.ToContractAddress),
+		"method":              param.Method,
+		"args":                hexutil.Bytes(param.Args),
+	}, nil
+}
+
+// decodeJSON decodes the json payload, e.g. headers and proofs, or the list of them.
+func decodeJSON(value interface{}) (interface{}, error) {
+	if list, ok := value.([][]byte); ok {
+		decoded := make([]interface{}, len(list))
+		for i, blob := range list {
+			item, err := decodeJSON(blob)
+			if err != nil {
+				return nil, err
+			}
+			decoded[i] = item
+		}
+		return decoded, nil
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(value.([]byte), &decoded); err != nil {
+		return nil, err
+	}
+	return decoded, nil
+}
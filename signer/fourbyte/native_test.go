@@ -0,0 +1,157 @@
+/*
+ * Copyright (C) 2021 The Zion Authors
+ * This file is part of The Zion library.
+ *
+ * The Zion is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * The Zion is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with The Zion.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package fourbyte
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	scom "github.com/ethereum/go-ethereum/contracts/native/cross_chain_manager/common"
+	"github.com/ethereum/go-ethereum/contracts/native/go_abi/node_manager_abi"
+	"github.com/ethereum/go-ethereum/contracts/native/governance/node_manager"
+	"github.com/ethereum/go-ethereum/contracts/native/utils"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/signer/core"
+)
+
+func nativeTxArgs(to common.Address, data []byte) *core.SendTxArgs {
+	from, _ := mixAddr("0x000000000000000000000000000000000000dead")
+	mixed := common.NewMixedcaseAddress(to)
+	input := hexutil.Bytes(data)
+	return &core.SendTxArgs{
+		From:     *from,
+		To:       &mixed,
+		Gas:      hexutil.Uint64(100000),
+		GasPrice: hexutil.Big(*big.NewInt(1)),
+		Value:    hexutil.Big(*big.NewInt(0)),
+		Nonce:    hexutil.Uint64(1),
+		Data:     &input,
+	}
+}
+
+func warnings(msgs *core.ValidationMessages) int {
+	var n int
+	for _, msg := range msgs.Messages {
+		if msg.Typ == core.WARN {
+			n++
+		}
+	}
+	return n
+}
+
+func TestNativeCallValidation(t *testing.T) {
+	db := newEmpty()
+	nm := mustParseABI(node_manager_abi.INodeManagerABI)
+
+	member := common.HexToAddress("0x258af48e28e4a6846e931ddff8e1cdf8579821e5")
+	peers, err := rlp.EncodeToBytes(&node_manager.Peers{List: []*node_manager.PeerInfo{
+		{PubKey: "0x02c07fb7d48eac559a2483e249d27841c18c7ce5dbbbf2796a6963cc9cef27cabd", Address: member},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	propose, err := nm.Pack(node_manager_abi.MethodPropose, uint64(400), peers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msgs, err := db.ValidateTransaction(nil, nativeTxArgs(utils.NodeManagerContractAddress, propose))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if warnings(msgs) != 0 {
+		t.Fatalf("unexpected warnings: %v", msgs.Messages)
+	}
+	call := msgs.NativeCall
+	if call == nil {
+		t.Fatal("expected native call to be decoded")
+	}
+	if call.Method != node_manager_abi.MethodPropose || call.Address != utils.NodeManagerContractAddress {
+		t.Fatalf("wrong call: %s at %x", call.Method, call.Address)
+	}
+	if height := call.Args["startHeight"]; height != uint64(400) {
+		t.Fatalf("wrong start height: %v", height)
+	}
+	decoded, ok := call.Args["peers"].([]nativePeer)
+	if !ok || len(decoded) != 1 || decoded[0].Address != member {
+		t.Fatalf("wrong peers: %v", call.Args["peers"])
+	}
+
+	// Undecodable nested payload keeps the call but warns
+	propose, _ = nm.Pack(node_manager_abi.MethodPropose, uint64(400), []byte{0x01, 0x02})
+	msgs, _ = db.ValidateTransaction(nil, nativeTxArgs(utils.NodeManagerContractAddress, propose))
+	if warnings(msgs) == 0 || msgs.NativeCall == nil {
+		t.Fatal("expected warning and decoded call for bad peers payload")
+	}
+	if _, ok := msgs.NativeCall.Args["peers"].(hexutil.Bytes); !ok {
+		t.Fatalf("expected raw peers, got %T", msgs.NativeCall.Args["peers"])
+	}
+
+	// Unknown selector of a native contract is rejected with a warning
+	msgs, _ = db.ValidateTransaction(nil, nativeTxArgs(utils.NodeManagerContractAddress, []byte{0xde, 0xad, 0xbe, 0xef}))
+	if warnings(msgs) == 0 || msgs.NativeCall != nil {
+		t.Fatal("expected warning for unknown native method")
+	}
+
+	// Calls to other contracts are left to the 4byte database
+	msgs, _ = db.ValidateTransaction(nil, nativeTxArgs(common.HexToAddress("0x1234"), propose))
+	if msgs.NativeCall != nil {
+		t.Fatal("unexpected native call for regular contract")
+	}
+}
+
+func TestDecodeTxArgs(t *testing.T) {
+	to := common.HexToAddress("0x258af48e28e4a6846e931ddff8e1cdf8579821e5")
+	blob, err := rlp.EncodeToBytes(&scom.TxArgs{
+		ToAssetHash: common.EmptyAddress.Bytes(),
+		ToAddress:   to.Bytes(),
+		Amount:      big.NewInt(100),
+		Fee:         big.NewInt(3),
+		FeeDeadline: 1650000000,
+		TransferID:  []byte{0x01, 0x02},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	value, err := decodeTxArgs(blob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := value.(map[string]interface{})
+	if fee := decoded["fee"].(*hexutil.Big); fee.ToInt().Cmp(big.NewInt(3)) != 0 {
+		t.Fatalf("wrong fee: %v", fee)
+	}
+	if deadline := decoded["feeDeadline"]; deadline != uint64(1650000000) {
+		t.Fatalf("wrong fee deadline: %v", deadline)
+	}
+	if id := decoded["transferID"].(hexutil.Bytes); id.String() != "0x0102" {
+		t.Fatalf("wrong transfer id: %v", id)
+	}
+
+	// Transfers without fee and id only show the original fields
+	blob, _ = rlp.EncodeToBytes(&scom.TxArgs{ToAssetHash: common.EmptyAddress.Bytes(), ToAddress: to.Bytes(), Amount: big.NewInt(100)})
+	value, err = decodeTxArgs(blob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded := value.(map[string]interface{}); len(decoded) != 3 {
+		t.Fatalf("unexpected fields: %v", decoded)
+	}
+}
@@ -73,7 +73,12 @@ func (db *Database) ValidateTransaction(selector *string, tx *core.SendTxArgs) (
 	if bytes.Equal(tx.To.Address().Bytes(), common.Address{}.Bytes()) {
 		messages.Crit("Transaction recipient is the zero address")
 	}
-	// Semantic fields validated, try to make heads or tails of the call data
+	// Semantic fields validated, try to make heads or tails of the call data. The
+	// native contracts are decoded with their ABIs instead of the 4byte database
+	if contract, ok := nativeContracts[tx.To.Address()]; ok {
+		validateNativeCall(contract, tx.To.Address(), data, messages)
+		return messages, nil
+	}
 	db.ValidateCallData(selector, data, messages)
 	return messages, nil
 }
@@ -546,6 +546,52 @@ func (d *dontCallMe) OnApprovedTx(tx ethapi.SignTransactionResult) {
 //TestContextIsCleared tests that the rule-engine does not retain variables over several requests.
 // if it does, that would be bad since developers may rely on that to store data,
 // instead of using the disk-based data storage
+func TestNativeCall(t *testing.T) {
+	js := `
+	function ApproveTx(r) {
+		var call = r.native_call
+		if (!call) {
+			return "Reject"
+		}
+		if (call.method == "propose" && call.args.peers.length == 1 &&
+			call.args.peers[0].address.toLowerCase() == "0x258af48e28e4a6846e931ddff8e1cdf8579821e5") {
+			return "Approve"
+		}
+		return "Reject"
+	}
+	`
+	r, err := initRuleEngine(js)
+	if err != nil {
+		t.Fatalf("Couldn't create evaluator %v", err)
+	}
+	tx := dummyTxWithV(0)
+	resp, err := r.ApproveTx(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Approved {
+		t.Errorf("Expected plain transaction to be rejected")
+	}
+	tx.NativeCall = &core.NativeCall{
+		Contract: "node manager",
+		Address:  common.HexToAddress("0x0000000000000000000000000000000000001000"),
+		Method:   "propose",
+		Args: map[string]interface{}{
+			"startHeight": uint64(400),
+			"peers": []map[string]interface{}{
+				{"pubKey": "0x02", "address": common.HexToAddress("0x258af48e28e4a6846e931ddff8e1cdf8579821e5")},
+			},
+		},
+	}
+	resp, err = r.ApproveTx(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.Approved {
+		t.Errorf("Expected native call to be approved")
+	}
+}
+
 func TestContextIsCleared(t *testing.T) {
 
 	js := `